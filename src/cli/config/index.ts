@@ -7,6 +7,8 @@
 export { ConfigurationManager, ConfigurationError, configurationManager } from './configuration-manager';
 export { validateConfiguration, validateDefaults } from './validation';
 export { DEFAULT_CONFIG } from './default-config';
+export { loadConfig, REPO_CONFIG_FILES, REPO_CONFIG_SCHEMA } from './repo-config';
+export type { RepoConfig, RepoConfigRuntime, LoadedRepoConfig } from './repo-config';
 export type {
   CLIConfig,
   DefaultSettings,
@@ -0,0 +1,712 @@
+/**
+ * Repository Configuration
+ *
+ * Loads the `.readme-to-cicd.yml` file at a repository root. Its values override what
+ * detection found, which in turn overrides the generator's built-in defaults.
+ */
+
+import * as fs from 'fs/promises';
+import * as path from 'path';
+import * as yaml from 'js-yaml';
+import { ConfigurationError } from './configuration-manager';
+import { CI_JOB_IDS, CI_JOB_CATEGORIES, isKnownJobId } from '../../generator/utils/job-ids';
+import { HOSTED_OS_NAMES, HostedOS, InjectedStep, MatrixRule, STEP_ANCHORS, StepAnchor } from '../../generator/interfaces';
+import { JobEnvironmentConfig, StepTemplate } from '../../generator/types';
+
+/**
+ * Config file names looked up at the repository root, in priority order
+ */
+export const REPO_CONFIG_FILES = ['.readme-to-cicd.yml', '.readme-to-cicd.yaml'];
+
+/**
+ * Providers the config file can force
+ */
+const REPO_CONFIG_PROVIDERS = ['github', 'gitlab', 'circleci', 'azure', 'bitbucket', 'jenkins'] as const;
+
+/**
+ * Runtimes whose version matrix the config file can override
+ */
+const REPO_CONFIG_RUNTIMES = ['node', 'python', 'go'] as const;
+
+/**
+ * CLI settings sections that share the file; they are read by the configuration manager
+ */
+const CLI_CONFIG_SECTIONS = ['defaults', 'templates', 'organization', 'output', 'git', 'ui'];
+
+/**
+ * GitHub Actions step keys an injected step can set, with the step template fields they fill
+ */
+const STEP_KEYS: Record<string, keyof StepTemplate> = {
+  name: 'name',
+  id: 'id',
+  uses: 'uses',
+  run: 'run',
+  with: 'with',
+  env: 'env',
+  if: 'if',
+  shell: 'shell',
+  'working-directory': 'workingDirectory',
+  'continue-on-error': 'continueOnError',
+  'timeout-minutes': 'timeout'
+};
+
+export type RepoConfigRuntime = typeof REPO_CONFIG_RUNTIMES[number];
+
+/**
+ * Schema of a matrixExclude or matrixInclude entry: values of the matrix dimensions and the job it applies to
+ */
+const MATRIX_RULE_SCHEMA = {
+  type: 'object',
+  minProperties: 1,
+  properties: { job: { type: 'string' } },
+  additionalProperties: { type: ['string', 'number', 'boolean'] }
+};
+
+/**
+ * Overrides read from `.readme-to-cicd.yml`
+ */
+export interface RepoConfig {
+  /** CI provider to generate configuration for */
+  provider?: typeof REPO_CONFIG_PROVIDERS[number];
+  /** Runner label jobs run on instead of ubuntu-latest */
+  runnerOS?: string;
+  /** Self-hosted runner labels for every job, or per job name */
+  runnerLabels?: string[] | Record<string, string[]>;
+  /** Exact hosted image label each operating system runs on instead of its -latest alias */
+  runnerImages?: Partial<Record<HostedOS, string>>;
+  /** Language versions tested, replacing the detected ones */
+  versions?: Partial<Record<RepoConfigRuntime, string[]>>;
+  /** Matrix combinations left out, such as Windows runs of the oldest Node version */
+  matrixExclude?: MatrixRule[];
+  /** Matrix combinations added, or values added to the combinations they match */
+  matrixInclude?: MatrixRule[];
+  /** Ids of generated jobs to leave out (lint, build, unit-tests, ...) or categories of them (test) */
+  disabledJobs?: string[];
+  /** Environment variables set on every generated job */
+  env?: Record<string, string>;
+  /** Variables of .env.example or .env.sample to leave out of the job environment */
+  envExampleExclude?: string[];
+  /** GOPRIVATE patterns of private Go modules, such as acme/* for github.com/acme */
+  privateModules?: string[];
+  /** Repository secret holding the token private Go modules are fetched with */
+  privateModulesSecret?: string;
+  /** Submodules checkout steps fetch: true, recursive or false, instead of what .gitmodules asks for */
+  submodules?: boolean | 'recursive';
+  /** Commits checkout steps fetch, 0 for the whole history */
+  fetchDepth?: number;
+  /** Steps spliced into the CI jobs before the build, after the tests or before the deploy */
+  injectSteps?: InjectedStep[];
+  /** gitignore-style patterns of paths detection leaves out, besides those .gitignore lists */
+  detectIgnore?: string[];
+  /** SPDX ids of the dependency licenses the license check allows, replacing the default permissive ones */
+  licenseAllowlist?: string[];
+  /** Label exempting a pull request from the changelog check, instead of no-changelog */
+  changelogSkipLabel?: string;
+  /** Branch buf breaking compares pull requests against, instead of the default branch */
+  bufBreakingBranch?: string;
+  /** GitHub environment the deploy job runs in, for its protection rules and secrets */
+  deployEnvironment?: JobEnvironmentConfig;
+  /** Refs or mirrors and refs (my-org/checkout@v4) the generated workflows use actions at, by action path or repository */
+  actionVersions?: Record<string, string>;
+}
+
+/**
+ * JSON Schema of `.readme-to-cicd.yml`, for editors to validate and complete the file with
+ */
+export const REPO_CONFIG_SCHEMA = {
+  $schema: 'http://json-schema.org/draft-07/schema#',
+  title: 'readme-to-cicd repository configuration',
+  type: 'object',
+  properties: {
+    provider: {
+      description: 'CI provider to generate configuration for',
+      enum: [...REPO_CONFIG_PROVIDERS]
+    },
+    runnerOS: {
+      description: 'Runner label jobs run on instead of ubuntu-latest',
+      type: 'string'
+    },
+    runnerLabels: {
+      description: 'Self-hosted runner labels for every job, or per job id',
+      oneOf: [
+        { type: 'array', items: { type: 'string' } },
+        { type: 'object', additionalProperties: { type: 'array', items: { type: 'string' } } }
+      ]
+    },
+    runnerImages: {
+      description: 'Hosted runner image label (ubuntu-22.04, macos-14, windows-2022) each operating system runs on instead of its -latest alias; runnerLabels take precedence',
+      type: 'object',
+      properties: Object.fromEntries(HOSTED_OS_NAMES.map(os => [os, { type: 'string' }]))
+    },
+    versions: {
+      description: 'Language versions tested, replacing the detected ones',
+      type: 'object',
+      properties: Object.fromEntries(REPO_CONFIG_RUNTIMES.map(runtime => [runtime, {
+        oneOf: [{ type: ['string', 'number'] }, { type: 'array', items: { type: ['string', 'number'] } }]
+      }]))
+    },
+    matrixExclude: {
+      description: 'Matrix combinations left out, by the values of the matrix dimensions (os: windows, node-version: 18); job restricts a rule to one job',
+      type: 'array',
+      items: MATRIX_RULE_SCHEMA
+    },
+    matrixInclude: {
+      description: 'Matrix combinations added, or values added to the combinations they match, as GitHub Actions matrix.include entries; job restricts a rule to one job',
+      type: 'array',
+      items: MATRIX_RULE_SCHEMA
+    },
+    disabledJobs: {
+      description: 'Generated jobs to leave out, by id, or every job of a category (test: all test jobs). ' +
+        'Per-crate and per-package test jobs are unit-tests-<name>, Go build tag test jobs integration-tests-<tag>; ' +
+        'coverage also drops the coverage upload.',
+      type: 'array',
+      items: {
+        anyOf: [
+          { enum: [...CI_JOB_IDS, ...Object.keys(CI_JOB_CATEGORIES)] },
+          { type: 'string', pattern: '^(unit|integration)-tests-.+$' }
+        ]
+      }
+    },
+    env: {
+      description: 'Environment variables set on every generated job',
+      type: 'object',
+      additionalProperties: { type: ['string', 'number', 'boolean'] }
+    },
+    envExampleExclude: {
+      description: 'Variables of .env.example or .env.sample to leave out of the job environment',
+      type: 'array',
+      items: { type: 'string' }
+    },
+    privateModules: {
+      description: 'GOPRIVATE patterns of private Go modules, such as acme/* for github.com/acme',
+      oneOf: [{ type: 'string' }, { type: 'array', items: { type: 'string' } }]
+    },
+    privateModulesSecret: {
+      description: 'Repository secret holding the token private Go modules are fetched with',
+      type: 'string',
+      pattern: '^[A-Za-z_][A-Za-z0-9_]*$'
+    },
+    submodules: {
+      description: 'Submodules checkout steps fetch, instead of all of them recursively when .gitmodules exists',
+      oneOf: [{ type: 'boolean' }, { const: 'recursive' }]
+    },
+    fetchDepth: {
+      description: 'Commits checkout steps fetch, 0 for the whole history; by default the history is fetched when git tags version the project',
+      type: 'integer',
+      minimum: 0
+    },
+    injectSteps: {
+      description: 'Steps spliced into the CI jobs: before the build steps (before-build), after the test steps (after-test) ' +
+        'or before the deploy step (pre-deploy). Steps at the same position keep their order.',
+      type: 'array',
+      items: {
+        type: 'object',
+        required: ['position', 'step'],
+        additionalProperties: false,
+        properties: {
+          position: { enum: [...STEP_ANCHORS] },
+          step: {
+            description: 'GitHub Actions step, running a command or an action',
+            type: 'object',
+            additionalProperties: false,
+            oneOf: [{ required: ['run'] }, { required: ['uses'] }],
+            properties: {
+              ...Object.fromEntries(['name', 'id', 'uses', 'run', 'if', 'shell', 'working-directory'].map(key => [key, { type: 'string' }])),
+              with: { type: 'object', additionalProperties: { type: ['string', 'number', 'boolean'] } },
+              env: { type: 'object', additionalProperties: { type: ['string', 'number', 'boolean'] } },
+              'continue-on-error': { type: 'boolean' },
+              'timeout-minutes': { type: 'number', exclusiveMinimum: 0 }
+            }
+          }
+        }
+      }
+    },
+    detectIgnore: {
+      description: 'gitignore-style patterns of paths detection leaves out, besides those .gitignore lists',
+      type: 'array',
+      items: { type: 'string' }
+    },
+    licenseAllowlist: {
+      description: 'SPDX ids of the dependency licenses the license-check job allows, replacing the default permissive ones',
+      type: 'array',
+      items: { type: 'string' }
+    },
+    changelogSkipLabel: {
+      description: 'Label exempting a pull request from the changelog check, instead of no-changelog',
+      type: 'string'
+    },
+    bufBreakingBranch: {
+      description: 'Branch buf breaking compares pull requests against, instead of the default branch',
+      type: 'string'
+    },
+    deployEnvironment: {
+      description: 'GitHub environment the deploy job runs in: a name, or a name and the url shown for the deployment, where {url} is the URL deployed to',
+      oneOf: [
+        { type: 'string', minLength: 1 },
+        {
+          type: 'object',
+          properties: { name: { type: 'string', minLength: 1 }, url: { type: 'string' } },
+          required: ['name'],
+          additionalProperties: false
+        }
+      ]
+    },
+    actionVersions: {
+      description: 'Ref (v4.2.2), or mirror repository and ref (my-org/checkout@v4), each action (actions/checkout) is used at instead of the built-in one; mirrored actions are not pinned by --pin-actions',
+      type: 'object',
+      additionalProperties: { type: 'string' }
+    }
+  }
+};
+
+/**
+ * Config file contents with the path it was read from and warnings about keys that were ignored
+ */
+export interface LoadedRepoConfig {
+  config: RepoConfig;
+  /** Path of the file read; undefined when the repository has none */
+  path?: string;
+  warnings: string[];
+}
+
+/**
+ * Load `.readme-to-cicd.yml` (or `.yaml`) from root. A missing file yields an empty config;
+ * unknown keys are reported as warnings so new fields can be rolled out gradually.
+ */
+export async function loadConfig(root: string): Promise<LoadedRepoConfig> {
+  const warnings: string[] = [];
+  const found: string[] = [];
+
+  for (const fileName of REPO_CONFIG_FILES) {
+    try {
+      await fs.access(path.join(root, fileName));
+      found.push(fileName);
+    } catch {
+      // Not present
+    }
+  }
+
+  const [fileName, ...ignored] = found;
+  if (!fileName) {
+    return { config: {}, warnings };
+  }
+  for (const other of ignored) {
+    warnings.push(`${other} is ignored because ${fileName} exists`);
+  }
+
+  const configPath = path.join(root, fileName);
+  let raw: unknown;
+  try {
+    raw = yaml.load(await fs.readFile(configPath, 'utf-8'));
+  } catch (error) {
+    const details = error instanceof Error ? error.message : 'Unknown error';
+    throw new ConfigurationError(`Failed to load ${fileName}: ${details}`, details, configPath);
+  }
+
+  if (raw === undefined || raw === null) {
+    return { config: {}, path: configPath, warnings };
+  }
+
+  const invalid = (details: string) => new ConfigurationError(`Invalid ${fileName}: ${details}`, details, configPath);
+  if (typeof raw !== 'object' || Array.isArray(raw)) {
+    throw invalid('the file must contain a mapping of settings');
+  }
+
+  const config: RepoConfig = {};
+
+  for (const [key, value] of Object.entries(raw as Record<string, unknown>)) {
+    switch (key) {
+      case 'provider':
+        if (!REPO_CONFIG_PROVIDERS.includes(value as any)) {
+          throw invalid(`provider must be one of ${REPO_CONFIG_PROVIDERS.join(', ')}`);
+        }
+        config.provider = value as RepoConfig['provider'];
+        break;
+      case 'runnerOS':
+        if (typeof value !== 'string' || value.trim() === '') {
+          throw invalid('runnerOS must be a runner label such as ubuntu-22.04');
+        }
+        config.runnerOS = value.trim();
+        break;
+      case 'runnerLabels':
+        config.runnerLabels = readRunnerLabels(value, invalid);
+        break;
+      case 'runnerImages':
+        config.runnerImages = readRunnerImages(value, warnings, invalid);
+        break;
+      case 'versions':
+        config.versions = readVersions(value, warnings, invalid);
+        break;
+      case 'disabledJobs':
+        if (!Array.isArray(value) || !value.every(job => typeof job === 'string')) {
+          throw invalid('disabledJobs must be a list of job names');
+        }
+        for (const job of value.filter(job => !isKnownJobId(job))) {
+          warnings.push(`Unknown job '${job}' under disabledJobs is ignored; job ids are ${CI_JOB_IDS.join(', ')}, unit-tests-<name> and integration-tests-<tag>; categories are ${Object.keys(CI_JOB_CATEGORIES).join(', ')}`);
+        }
+        config.disabledJobs = value.filter(isKnownJobId);
+        break;
+      case 'env':
+        config.env = readEnv(value, invalid);
+        break;
+      case 'envExampleExclude':
+        if (!Array.isArray(value) || !value.every(name => typeof name === 'string')) {
+          throw invalid('envExampleExclude must be a list of variable names');
+        }
+        config.envExampleExclude = value.map(name => name.trim()).filter(name => name !== '');
+        break;
+      case 'privateModules': {
+        const patterns = typeof value === 'string' ? value.split(',') : value;
+        if (!Array.isArray(patterns) || !patterns.every(pattern => typeof pattern === 'string')) {
+          throw invalid('privateModules must be a list of module path patterns such as [acme/*]');
+        }
+        config.privateModules = patterns.map(pattern => pattern.trim()).filter(pattern => pattern !== '');
+        break;
+      }
+      case 'privateModulesSecret':
+        if (typeof value !== 'string' || !/^[A-Za-z_][A-Za-z0-9_]*$/.test(value)) {
+          throw invalid('privateModulesSecret must be a secret name such as GO_MODULES_TOKEN');
+        }
+        config.privateModulesSecret = value;
+        break;
+      case 'submodules':
+        if (typeof value !== 'boolean' && value !== 'recursive') {
+          throw invalid('submodules must be true, false or recursive');
+        }
+        config.submodules = value;
+        break;
+      case 'fetchDepth':
+        if (typeof value !== 'number' || !Number.isInteger(value) || value < 0) {
+          throw invalid('fetchDepth must be a number of commits, or 0 for the whole history');
+        }
+        config.fetchDepth = value;
+        break;
+      case 'injectSteps':
+        config.injectSteps = readInjectSteps(value, invalid);
+        break;
+      case 'matrixExclude':
+      case 'matrixInclude':
+        config[key] = readMatrixRules(key, value, invalid);
+        break;
+      case 'detectIgnore':
+        if (!Array.isArray(value) || !value.every(pattern => typeof pattern === 'string')) {
+          throw invalid('detectIgnore must be a list of gitignore-style patterns such as [vendor/, third_party/]');
+        }
+        config.detectIgnore = value.map(pattern => pattern.trim()).filter(pattern => pattern !== '');
+        break;
+      case 'licenseAllowlist': {
+        const licenses = typeof value === 'string' ? value.split(',') : value;
+        if (!Array.isArray(licenses) || !licenses.every(license => typeof license === 'string' && /^\s*[\w.+-]*\s*$/.test(license))) {
+          throw invalid('licenseAllowlist must be a list of SPDX license ids such as [MIT, Apache-2.0]');
+        }
+        config.licenseAllowlist = [...new Set(licenses.map(license => license.trim()).filter(license => license !== ''))];
+        break;
+      }
+      case 'changelogSkipLabel':
+        // The label is quoted in the job's if expression
+        if (typeof value !== 'string' || value.trim() === '' || /['"\r\n]/.test(value)) {
+          throw invalid('changelogSkipLabel must be a label name such as skip-changelog');
+        }
+        config.changelogSkipLabel = value.trim();
+        break;
+      case 'bufBreakingBranch':
+        // The branch goes into a quoted buf input, whose options are comma separated
+        if (typeof value !== 'string' || !/^[\w./-]+$/.test(value.trim())) {
+          throw invalid('bufBreakingBranch must be a branch name such as main');
+        }
+        config.bufBreakingBranch = value.trim();
+        break;
+      case 'deployEnvironment':
+        config.deployEnvironment = readDeployEnvironment(value, invalid);
+        break;
+      case 'actionVersions':
+        config.actionVersions = readActionVersions(value, invalid);
+        break;
+      default:
+        if (!CLI_CONFIG_SECTIONS.includes(key)) {
+          warnings.push(`Unknown key '${key}' in ${fileName} is ignored`);
+        }
+    }
+  }
+
+  // Other providers only run commands; the actions of GitHub Actions steps are lost on them
+  const action = config.provider && config.provider !== 'github' ? config.injectSteps?.find(injected => injected.step.uses) : undefined;
+  if (action) {
+    throw invalid(`injectSteps: ${config.provider} pipelines cannot run the action ${action.step.uses}; inject a run step instead`);
+  }
+
+  return { config, path: configPath, warnings };
+}
+
+/**
+ * Read `versions`, accepting a single version or a list per runtime
+ */
+function readVersions(
+  value: unknown,
+  warnings: string[],
+  invalid: (details: string) => ConfigurationError
+): RepoConfig['versions'] {
+  if (typeof value !== 'object' || value === null || Array.isArray(value)) {
+    throw invalid('versions must map a runtime (node, python, go) to a version or list of versions');
+  }
+
+  const versions: NonNullable<RepoConfig['versions']> = {};
+  for (const [runtime, listed] of Object.entries(value)) {
+    if (!REPO_CONFIG_RUNTIMES.includes(runtime as any)) {
+      warnings.push(`Unknown runtime '${runtime}' under versions is ignored; supported runtimes are ${REPO_CONFIG_RUNTIMES.join(', ')}`);
+      continue;
+    }
+
+    const entries = (Array.isArray(listed) ? listed : [listed]).map(version =>
+      typeof version === 'number' || typeof version === 'string' ? String(version).trim() : '');
+    if (entries.length === 0 || entries.some(version => version === '')) {
+      throw invalid(`versions.${runtime} must be a version or a non-empty list of versions`);
+    }
+
+    // YAML reads unquoted versions such as 3.10 as the number 3.1
+    for (const version of Array.isArray(listed) ? listed : [listed]) {
+      if (typeof version === 'number' && !Number.isInteger(version)) {
+        warnings.push(`versions.${runtime}: ${version} was read as a number; quote versions such as '3.10' to keep trailing zeros`);
+      }
+    }
+    versions[runtime as RepoConfigRuntime] = entries;
+  }
+
+  return versions;
+}
+
+/**
+ * Read `runnerImages`: a map of operating systems to a runner image label; unknown operating systems are ignored
+ */
+function readRunnerImages(
+  value: unknown,
+  warnings: string[],
+  invalid: (details: string) => ConfigurationError
+): RepoConfig['runnerImages'] {
+  if (typeof value !== 'object' || value === null || Array.isArray(value)) {
+    throw invalid('runnerImages must map operating systems to runner image labels, such as { linux: ubuntu-22.04 }');
+  }
+
+  const images: Partial<Record<HostedOS, string>> = {};
+  for (const [os, label] of Object.entries(value)) {
+    if (!(HOSTED_OS_NAMES as readonly string[]).includes(os)) {
+      warnings.push(`Unknown operating system '${os}' under runnerImages is ignored; supported ones are ${HOSTED_OS_NAMES.join(', ')}`);
+      continue;
+    }
+    if (typeof label !== 'string' || label.trim() === '') {
+      throw invalid(`runnerImages.${os} must be a runner image label such as ubuntu-22.04`);
+    }
+    images[os as HostedOS] = label.trim();
+  }
+  return images;
+}
+
+/**
+ * Read `actionVersions`: a map of action paths or repositories to a ref, or to a repository and ref
+ */
+function readActionVersions(value: unknown, invalid: (details: string) => ConfigurationError): RepoConfig['actionVersions'] {
+  if (typeof value !== 'object' || value === null || Array.isArray(value)) {
+    throw invalid('actionVersions must map actions to refs, such as { actions/checkout: v4.2.2 }');
+  }
+
+  const versions: Record<string, string> = {};
+  for (const [action, version] of Object.entries(value)) {
+    if (!/^[\w.-]+\/[\w./-]+$/.test(action)) {
+      throw invalid(`actionVersions key '${action}' must be an action such as actions/checkout`);
+    }
+    if (typeof version !== 'string' || !/^([\w.-]+\/[\w./-]+@)?[\w./-]+$/.test(version.trim())) {
+      throw invalid(`actionVersions.${action} must be a ref such as v4, or a repository and ref such as my-org/checkout@v4`);
+    }
+    versions[action] = version.trim();
+  }
+  return versions;
+}
+
+/**
+ * Read `deployEnvironment`: an environment name, or a name and url
+ */
+function readDeployEnvironment(value: unknown, invalid: (details: string) => ConfigurationError): JobEnvironmentConfig {
+  const environment = typeof value === 'string' ? { name: value } : value;
+  if (typeof environment !== 'object' || environment === null || Array.isArray(environment)) {
+    throw invalid('deployEnvironment must be an environment name, or a name and url such as { name: production, url: https://example.com }');
+  }
+
+  const { name, url, ...unknown } = environment as Record<string, unknown>;
+  if (typeof name !== 'string' || name.trim() === '') {
+    throw invalid('deployEnvironment.name must be a non-empty environment name such as production');
+  }
+  if (url !== undefined && (typeof url !== 'string' || url.trim() === '')) {
+    throw invalid('deployEnvironment.url must be a URL such as https://example.com, or {url} for the URL deployed to');
+  }
+  const keys = Object.keys(unknown);
+  if (keys.length > 0) {
+    throw invalid(`deployEnvironment has unknown key ${keys[0]} - expected name and url`);
+  }
+  return { name: name.trim(), ...(typeof url === 'string' && { url: url.trim() }) };
+}
+
+/**
+ * Read `matrixExclude` or `matrixInclude`: a list of matrix dimension values, each optionally
+ * naming the job it applies to
+ */
+function readMatrixRules(
+  key: 'matrixExclude' | 'matrixInclude',
+  value: unknown,
+  invalid: (details: string) => ConfigurationError
+): MatrixRule[] {
+  if (!Array.isArray(value)) {
+    throw invalid(`${key} must be a list of matrix combinations, such as [{ os: windows, node-version: 18 }]`);
+  }
+
+  return value.map((entry, index) => {
+    if (typeof entry !== 'object' || entry === null || Array.isArray(entry)) {
+      throw invalid(`${key}[${index}] must map matrix dimensions to values`);
+    }
+    const { job, ...values } = entry as Record<string, unknown>;
+    if (job !== undefined && (typeof job !== 'string' || job.trim() === '')) {
+      throw invalid(`${key}[${index}].job must be a job id such as unit-tests`);
+    }
+    if (Object.keys(values).length === 0) {
+      throw invalid(`${key}[${index}] names no matrix dimension`);
+    }
+    const dimension = Object.entries(values).find(([, item]) => !['string', 'number', 'boolean'].includes(typeof item));
+    if (dimension) {
+      throw invalid(`${key}[${index}].${dimension[0]} must be a string, number or boolean`);
+    }
+    return { ...(job !== undefined && { job: (job as string).trim() }), values: values as MatrixRule['values'] };
+  });
+}
+
+/**
+ * Read `runnerLabels`: a list (or comma-separated string) of labels, or a map of job names to one
+ */
+function readRunnerLabels(
+  value: unknown,
+  invalid: (details: string) => ConfigurationError
+): RepoConfig['runnerLabels'] {
+  const readList = (entry: unknown, key: string): string[] => {
+    const labels = typeof entry === 'string' ? entry.split(',') : Array.isArray(entry) ? entry : undefined;
+    if (!labels || !labels.every(label => typeof label === 'string')) {
+      throw invalid(`${key} must be a list of runner labels such as [self-hosted, linux]`);
+    }
+    const trimmed = labels.map(label => label.trim()).filter(label => label !== '');
+    if (trimmed.length === 0) {
+      throw invalid(`${key} must contain at least one non-empty runner label`);
+    }
+    return trimmed;
+  };
+
+  if (typeof value !== 'object' || value === null || Array.isArray(value)) {
+    return readList(value, 'runnerLabels');
+  }
+
+  const perJob: Record<string, string[]> = {};
+  for (const [job, entry] of Object.entries(value)) {
+    perJob[job] = readList(entry, `runnerLabels.${job}`);
+  }
+  if (Object.keys(perJob).length === 0) {
+    throw invalid('runnerLabels must list runner labels or map job names to them');
+  }
+  return perJob;
+}
+
+/**
+ * Read `env`, keeping values as strings
+ */
+function readEnv(value: unknown, invalid: (details: string) => ConfigurationError): Record<string, string> {
+  if (typeof value !== 'object' || value === null || Array.isArray(value)) {
+    throw invalid('env must map variable names to values');
+  }
+
+  const env: Record<string, string> = {};
+  for (const [name, entry] of Object.entries(value)) {
+    if (!/^[A-Za-z_][A-Za-z0-9_]*$/.test(name)) {
+      throw invalid(`env variable name '${name}' is not valid`);
+    }
+    if (typeof entry === 'object' && entry !== null) {
+      throw invalid(`env.${name} must be a string, number or boolean`);
+    }
+    env[name] = String(entry ?? '');
+  }
+
+  return env;
+}
+
+/**
+ * Read `injectSteps`, checking each step against the GitHub Actions step schema
+ */
+function readInjectSteps(value: unknown, invalid: (details: string) => ConfigurationError): InjectedStep[] {
+  if (!Array.isArray(value)) {
+    throw invalid(`injectSteps must be a list of steps, each with a position (${STEP_ANCHORS.join(', ')}) and a step`);
+  }
+
+  return value.map((entry, index) => {
+    const key = `injectSteps[${index}]`;
+    if (typeof entry !== 'object' || entry === null || Array.isArray(entry)) {
+      throw invalid(`${key} must map position and step`);
+    }
+    const { position, step, ...rest } = entry as Record<string, unknown>;
+    if (Object.keys(rest).length > 0) {
+      throw invalid(`${key} has unknown key '${Object.keys(rest)[0]}'; only position and step are read`);
+    }
+    if (!STEP_ANCHORS.includes(position as any)) {
+      throw invalid(`${key}.position must be one of ${STEP_ANCHORS.join(', ')}`);
+    }
+    return { position: position as StepAnchor, step: readStep(step, `${key}.step`, invalid) };
+  });
+}
+
+/**
+ * Read one GitHub Actions step into a step template. Steps without a name get the one GitHub
+ * shows for them.
+ */
+function readStep(value: unknown, key: string, invalid: (details: string) => ConfigurationError): StepTemplate {
+  if (typeof value !== 'object' || value === null || Array.isArray(value)) {
+    throw invalid(`${key} must be a GitHub Actions step mapping, such as { run: make check }`);
+  }
+
+  const step: Record<string, unknown> = {};
+  for (const [name, entry] of Object.entries(value)) {
+    const field = STEP_KEYS[name];
+    if (!field) {
+      throw invalid(`${key} has unknown key '${name}'; steps can set ${Object.keys(STEP_KEYS).join(', ')}`);
+    }
+    if (name === 'with' || name === 'env') {
+      if (typeof entry !== 'object' || entry === null || Array.isArray(entry) ||
+        Object.values(entry).some(item => typeof item === 'object' && item !== null)) {
+        throw invalid(`${key}.${name} must map names to strings, numbers or booleans`);
+      }
+      step[field] = name === 'env'
+        ? Object.fromEntries(Object.entries(entry).map(([variable, item]) => [variable, String(item ?? '')]))
+        : entry;
+    } else if (name === 'continue-on-error') {
+      if (typeof entry !== 'boolean') {
+        throw invalid(`${key}.continue-on-error must be true or false`);
+      }
+      step[field] = entry;
+    } else if (name === 'timeout-minutes') {
+      if (typeof entry !== 'number' || entry <= 0) {
+        throw invalid(`${key}.timeout-minutes must be a positive number of minutes`);
+      }
+      step[field] = entry;
+    } else {
+      if (typeof entry !== 'string' || entry.trim() === '') {
+        throw invalid(`${key}.${name} must be a non-empty string`);
+      }
+      step[field] = entry;
+    }
+  }
+
+  const { uses, run, id } = step as Partial<StepTemplate>;
+  if ((uses === undefined) === (run === undefined)) {
+    throw invalid(`${key} must have exactly one of run or uses`);
+  }
+  if (uses !== undefined && !/^(\.\/\S*|docker:\/\/\S+|[\w.-]+\/[\w./-]+@\S+)$/.test(uses)) {
+    throw invalid(`${key}.uses must name an action as owner/repo@ref, ./path or docker://image`);
+  }
+  if (id !== undefined && !/^[A-Za-z_][\w-]*$/.test(id)) {
+    throw invalid(`${key}.id must start with a letter or '_' and contain only letters, digits, '-' and '_'`);
+  }
+
+  return { name: uses ? `Run ${uses}` : `Run ${run!.trim().split('\n')[0]}`, ...step } as StepTemplate;
+}
@@ -0,0 +1,147 @@
+import { execFile } from 'child_process';
+import { promisify } from 'util';
+import { readFile } from 'fs/promises';
+import * as yaml from 'js-yaml';
+import { CLIError } from './types';
+import { Logger } from './logger';
+
+const execFileAsync = promisify(execFile);
+
+/**
+ * Output act may print for a job, which can include the logs of a whole build
+ */
+const MAX_ACT_OUTPUT = 64 * 1024 * 1024;
+
+export interface ActVerifyOptions {
+  /** Job to run; the first job of the workflow when not given */
+  job?: string;
+  /** Aborts the act run, such as when the user interrupts the CLI */
+  signal?: AbortSignal;
+}
+
+export interface ActVerifyResult {
+  job: string;
+  success: boolean;
+  /** What act printed while running the job */
+  output: string;
+}
+
+/**
+ * Runs a job of a workflow already written locally with act (nektos/act), to check the
+ * generated workflow works before pushing it. act runs jobs in Docker, which it needs too.
+ */
+export class ActRunner {
+  constructor(
+    private readonly logger: Logger,
+    private readonly workingDirectory: string = process.cwd()
+  ) {}
+
+  /**
+   * Whether act can be run from the PATH
+   */
+  async isInstalled(): Promise<boolean> {
+    try {
+      await this.executeAct(['--version']);
+      return true;
+    } catch (error) {
+      this.logger.debug('act not found', { error: (error as Error).message });
+      return false;
+    }
+  }
+
+  /**
+   * Run one job of a workflow with act, the one named or else the first the workflow declares,
+   * reporting whether it succeeded. Jobs it needs are not run first. Throws a CLIError when act
+   * is not installed or the workflow has no such job; a job failing is reported, not thrown.
+   */
+  async verify(workflowPath: string, options: ActVerifyOptions = {}): Promise<ActVerifyResult> {
+    if (!await this.isInstalled()) {
+      throw this.createActError('ACT_NOT_FOUND', 'act not found - install it to run workflows locally');
+    }
+
+    const jobs = await this.readJobs(workflowPath);
+    if (jobs.length === 0) {
+      throw this.createActError('NO_JOBS', `Workflow ${workflowPath} declares no jobs`);
+    }
+    const job = options.job ?? jobs[0]!;
+    // act runs nothing, successfully, for a job the workflow does not have
+    if (!jobs.includes(job)) {
+      throw this.createActError('JOB_NOT_FOUND', `Workflow ${workflowPath} has no job '${job}' - its jobs are ${jobs.join(', ')}`);
+    }
+    this.logger.info('Running workflow job with act', { workflowPath, job });
+
+    try {
+      const { stdout, stderr } = await this.executeAct(['-W', workflowPath, '-j', job], options.signal);
+      return { job, success: true, output: stdout + stderr };
+    } catch (error: any) {
+      if (error.name === 'AbortError') {
+        throw this.createActError('ACT_ABORTED', `act run of job '${job}' was aborted`, error);
+      }
+      // act exits non-zero when a step fails, leaving what the job printed in the error
+      if (typeof error.code !== 'number') {
+        throw this.createActError('ACT_FAILED', `act could not run job '${job}': ${error.message}`, error);
+      }
+      return { job, success: false, output: `${error.stdout || ''}${error.stderr || ''}` };
+    }
+  }
+
+  /**
+   * Ids of the jobs a workflow declares, in order
+   */
+  private async readJobs(workflowPath: string): Promise<string[]> {
+    let workflow: any;
+    try {
+      workflow = yaml.load(await readFile(workflowPath, 'utf8'));
+    } catch (error) {
+      throw this.createActError('WORKFLOW_NOT_READABLE', `Failed to read workflow ${workflowPath}`, error as Error);
+    }
+    return Object.keys(workflow?.jobs || {});
+  }
+
+  private async executeAct(args: string[], signal?: AbortSignal): Promise<{ stdout: string; stderr: string }> {
+    try {
+      return await execFileAsync('act', args, {
+        cwd: this.workingDirectory,
+        maxBuffer: MAX_ACT_OUTPUT,
+        ...(signal && { signal })
+      });
+    } catch (error: any) {
+      this.logger.debug('act command failed', { args, error: error.message });
+      throw error;
+    }
+  }
+
+  private createActError(code: string, message: string, originalError?: Error): CLIError {
+    const suggestions: string[] = [];
+
+    switch (code) {
+      case 'ACT_NOT_FOUND':
+        suggestions.push('Install act: https://nektosact.com/installation/');
+        suggestions.push('Make sure Docker is running, act runs jobs in containers');
+        break;
+      case 'JOB_NOT_FOUND':
+        suggestions.push('Pass one of the workflow\'s jobs with --job');
+        break;
+      case 'NO_JOBS':
+      case 'WORKFLOW_NOT_READABLE':
+        suggestions.push('Generate the workflow first with: readme-to-cicd generate');
+        suggestions.push('Pass the path of a workflow file, such as .github/workflows/ci.yml');
+        break;
+      default:
+        suggestions.push('Check that Docker is running');
+        suggestions.push('Try running with --debug flag for more information');
+    }
+
+    return {
+      code,
+      message,
+      category: 'processing',
+      severity: 'error',
+      suggestions,
+      context: originalError ? {
+        originalMessage: originalError.message,
+        stack: originalError.stack
+      } : undefined
+    };
+  }
+}
@@ -1,4 +1,4 @@
-import { CLIOptions, CLIResult, BatchProcessingOptions, CLIConfig } from './types';
+import { CLIOptions, CLIResult, CLIError, BatchProcessingOptions, CLIConfig } from './types';
 import { Logger } from './logger';
 import { ErrorHandler } from './error-handler';
 import { CommandParser } from './command-parser';
@@ -8,6 +8,8 @@ import { ConfigExporter, ImportOptions } from './config-exporter';
 import { CIEnvironmentDetector, MachineOutputFormatter, CIExitCodeManager } from './ci-environment';
 import { InitCommand, InitCommandOptions } from './init-command';
 import { ReadmeCommandHandler, ReadmeCommandOptions } from './readme-command-handler';
+import { ActRunner } from './act-runner';
+import { formatDiagnostics } from './diagnostic-formatter';
 
 /**
  * Main CLI Application class
@@ -137,6 +139,8 @@ export class CLIApplication {
         return this.executeGenerateCommand(options);
       case 'validate':
         return this.executeValidateCommand(options);
+      case 'verify':
+        return this.executeVerifyCommand(options);
       case 'init':
         return this.executeInitCommand(options);
       case 'export':
@@ -305,6 +309,19 @@ export class CLIApplication {
     
     // Use ComponentOrchestrator to execute the complete workflow
     const result = await this.componentOrchestrator.executeWorkflow(options);
+
+    // --check reports the diff and fails the process when the committed workflows are stale
+    if (options.check && !result.success) {
+      for (const error of result.errors) {
+        console.log(error.message);
+      }
+      process.exitCode = 1;
+    }
+
+    // Diagnostics go to stderr, apart from the logs; --format json already reports them on stdout
+    if (result.diagnostics && !options.quiet && options.format !== 'json') {
+      console.error(formatDiagnostics(result.diagnostics));
+    }
     
     this.logger.info('Single project generation completed', {
       success: result.success,
@@ -421,6 +438,65 @@ export class CLIApplication {
     };
   }
 
+  /**
+   * Execute verify command: run a job of a generated workflow locally with act
+   */
+  private async executeVerifyCommand(options: CLIOptions): Promise<CLIResult> {
+    this.logger.info('Executing verify command', { options });
+    const startTime = Date.now();
+    const workflowPath = options.workflowPath || '.github/workflows/ci.yml';
+    const summary = (): CLIResult['summary'] => ({
+      totalTime: Date.now() - startTime,
+      filesGenerated: 0,
+      workflowsCreated: 0,
+      frameworksDetected: [],
+      optimizationsApplied: 0,
+      executionTime: Date.now() - startTime,
+      filesProcessed: 1,
+      workflowsGenerated: 0
+    });
+
+    try {
+      const result = await new ActRunner(this.logger).verify(workflowPath, { ...(options.job && { job: options.job }) });
+      if (!options.quiet || !result.success) {
+        console.log(result.output);
+      }
+
+      return {
+        success: result.success,
+        generatedFiles: [],
+        errors: result.success ? [] : [{
+          code: 'VERIFY_FAILED',
+          message: `Job '${result.job}' of ${workflowPath} failed when run with act`,
+          category: 'processing',
+          severity: 'error',
+          suggestions: ['Read the act output above for the step that failed']
+        }],
+        warnings: [],
+        summary: summary()
+      };
+    } catch (error) {
+      this.logger.error('Verify command failed', { error });
+      // ActRunner throws CLIErrors, such as ACT_NOT_FOUND, with their suggestions
+      const cliError: CLIError = error && typeof error === 'object' && 'suggestions' in error
+        ? error as CLIError
+        : {
+          code: 'VERIFY_FAILED',
+          message: `Verify command failed: ${error instanceof Error ? error.message : String(error)}`,
+          category: 'processing',
+          severity: 'error',
+          suggestions: ['Try running with --debug flag for more information']
+        };
+      return {
+        success: false,
+        generatedFiles: [],
+        errors: [cliError],
+        warnings: [],
+        summary: summary()
+      };
+    }
+  }
+
   /**
    * Execute init command using InitCommand
    */
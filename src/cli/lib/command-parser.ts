@@ -8,6 +8,9 @@
 import { Command, Option, CommanderError } from 'commander';
 import { CLIOptions, WorkflowType, CLIError } from './types';
 import { HelpSystem, HelpRequest } from './help-system';
+import { validateCron } from '../../generator/utils/cron';
+import { REPAIR_FIXES, RepairFixId } from '../../generator/utils/workflow-repair';
+import { HOSTED_OS_NAMES, HostedOS } from '../../generator/interfaces';
 
 export class CommandParser {
   private program: Command;
@@ -124,7 +127,8 @@ export class CommandParser {
    */
   private setupGlobalOptions(): void {
     this.program
-      .addOption(new Option('-v, --verbose', 'Enable verbose output with detailed processing information'))
+      .addOption(new Option('-v, --verbose', 'Enable verbose output with detailed processing information; repeat (-vv) for the debug output of -d')
+        .argParser((_value: string, previous: number | undefined) => (previous ?? 0) + 1))
       .addOption(new Option('-d, --debug', 'Enable debug output with internal processing steps'))
       .addOption(new Option('-q, --quiet', 'Suppress all non-essential output'))
       .addOption(new Option('-c, --config <path>', 'Load configuration from specified file'))
@@ -138,6 +142,7 @@ export class CommandParser {
   private setupCommands(): void {
     this.setupGenerateCommand();
     this.setupValidateCommand();
+    this.setupVerifyCommand();
     this.setupInitCommand();
     this.setupExportCommand();
     this.setupImportCommand();
@@ -158,12 +163,119 @@ export class CommandParser {
       .addOption(new Option('-w, --workflow-type <types...>', 'Workflow types to generate')
         .choices(['ci', 'cd', 'release'])
         .default(['ci', 'cd']))
+      .addOption(new Option('--provider <provider>', 'CI provider to generate configuration for (default: the provider the config file names, else the one the repository is configured for; required when it has no CI configuration or several)')
+        .choices(['github', 'gitlab', 'circleci', 'azure', 'bitbucket', 'jenkins']))
+      .addOption(new Option('--circleci-orbs', 'Use CircleCI orbs for dependency installation')
+        .default(false))
+      .addOption(new Option('--preset <preset>', 'Categories of CI jobs to generate: lint/static analysis only, build and test only, or everything')
+        .choices(['lint', 'test', 'full']))
+      .addOption(new Option('--coverage <service>', 'Collect unit test coverage and upload it to Codecov or Coveralls')
+        .choices(['codecov', 'coveralls', 'none']))
+      .addOption(new Option('--default-branch <branch>', 'Branch that triggers CI and deployments (default: read from git, else main)'))
+      .addOption(new Option('--no-cancel-in-progress', 'Let superseded CI runs finish instead of cancelling them when a newer commit is pushed'))
+      .addOption(new Option('--no-permissions', 'Leave token permissions to the organization defaults instead of declaring least-privilege ones'))
+      .addOption(new Option('--schedule <cron>', 'Also write nightly.yml, running the build and test jobs on this cron schedule (GitHub Actions)'))
+      .addOption(new Option('--force', 'Write generated configuration even when it fails structural validation')
+        .default(false))
+      .addOption(new Option('--test-retries <n>', 'Run a failed test command again up to n times (GitLab retries the test job, at most twice)')
+        .argParser(Number))
+      .addOption(new Option('--job-timeout <minutes>', 'Cancel test jobs running longer than this (default: 30)')
+        .argParser(Number))
+      .addOption(new Option('--reusable', 'Generate ci.yml as a reusable workflow (on: workflow_call) taking the runner and language version as inputs')
+        .default(false))
+      .addOption(new Option('--split <policy>', 'Write all CI jobs to ci.yml, or split them into build.yml, test.yml and lint.yml (GitHub Actions)')
+        .choices(['none', 'by-kind']))
+      .addOption(new Option('--split-setup <mode>', 'How split workflows get the build their jobs need: call it from a reusable setup.yml, or run their own copy')
+        .choices(['reusable', 'duplicate']))
+      .addOption(new Option('--release', 'Also write release.yml, building pushed version tags and publishing them as GitHub Releases (GoReleaser when configured)')
+        .default(false))
+      .addOption(new Option('--release-tag-prefix <prefix>', 'What release tags start with before the version; pass "" for tags like 1.2.3 (default: v)'))
+      .addOption(new Option('--artifacts', 'Upload the build output (dist/, bin/, target/release/...) as an artifact')
+        .default(false))
+      .addOption(new Option('--artifact-path <path>', 'Paths to upload instead of the detected build output'))
+      .addOption(new Option('--artifact-name <name>', 'Artifact name, suffixed with the build matrix values (default: build-artifacts)'))
+      .addOption(new Option('--artifact-retention-days <days>', 'Days to keep uploaded build artifacts (default: 7)')
+        .argParser(Number))
+      .addOption(new Option('--check', 'Compare generated workflows with the committed ones and fail with a diff instead of writing them')
+        .default(false))
+      .addOption(new Option('--emit-secrets-manifest', 'Also write required-secrets.json, listing the secrets the generated workflows reference')
+        .default(false))
+      .addOption(new Option('--emit <artifact>', 'Also write composite-action: .github/actions/ci/action.yml with the setup, build and test steps, for other workflows to run as one step')
+        .choices(['composite-action']))
+      .addOption(new Option('--pin-actions', 'Pin the actions of the generated workflows to commit SHAs (actions/checkout@<sha> # v4), resolved with git ls-remote')
+        .default(false))
+      .addOption(new Option('--repair', 'Fix the existing GitHub workflows in place instead of generating new ones: pin actions to commit SHAs, add read-only permissions and a concurrency group')
+        .default(false))
+      .addOption(new Option('--repair-skip <fixes...>', `Fixes --repair leaves out (space or comma separated: ${REPAIR_FIXES.join(', ')})`))
+      .addOption(new Option('--dependabot', 'Also write .github/dependabot.yml with weekly updates for the detected ecosystems and GitHub Actions')
+        .default(false))
+      .addOption(new Option('--renovate', 'Also write renovate.json with weekly updates for the detected ecosystems and GitHub Actions')
+        .default(false))
+      .addOption(new Option('--working-directory <dir>', 'Directory the project lives in, relative to the repository root ("." for the root), instead of the one holding its manifests'))
+      .addOption(new Option('--monorepo <layout>', 'Detect packages in a monorepo and generate per-package jobs')
+        .choices(['single', 'per-package']))
+      .addOption(new Option('--changed-files <mode>', 'Run monorepo package jobs only when the package or a dependency changed, via trigger paths or dorny/paths-filter')
+        .choices(['path-triggers', 'paths-filter']))
+      .addOption(new Option('--detect-workers <n>', 'Detect up to n monorepo packages at once (default: number of CPUs)')
+        .argParser(Number))
+      .addOption(new Option('--since <ref>', 'Only re-detect and regenerate the monorepo packages with files changed since a git ref, leaving the other workflows untouched'))
+      .addOption(new Option('--registry <host>', 'Container registry to push images built from Dockerfiles to (default: ghcr.io)'))
+      .addOption(new Option('--test-runners <layout>', 'Run multiple detected test runners as separate jobs or as one matrix job')
+        .choices(['jobs', 'matrix']))
+      .addOption(new Option('--existing-ci <action>', 'Generate or skip the CI workflow when README badges show the project already has CI')
+        .choices(['generate', 'skip']))
+      .addOption(new Option('--pre-commit <mode>', 'Add a job running the .pre-commit-config.yaml hooks, alongside the lint job or replacing it')
+        .choices(['job', 'replace-lint']))
+      .addOption(new Option('--terraform', 'Add a job validating the Terraform configuration and planning it on the default branch (GitHub Actions)')
+        .default(false))
+      .addOption(new Option('--license-check', 'Add a job failing when a dependency has a license outside the allowlist (licenseAllowlist in the config file)')
+        .default(false))
+      .addOption(new Option('--changelog-check', 'Add a job failing pull requests that leave the changelog unchanged, unless labelled no-changelog (changelogSkipLabel in the config file)')
+        .default(false))
+      .addOption(new Option('--codeowners-comment', 'Add a job commenting on pull requests which CODEOWNERS rules the changed files fall under')
+        .default(false))
+      .addOption(new Option('--api-lint', 'Add a job linting the OpenAPI documents with spectral and the GraphQL schemas with graphql-schema-linter')
+        .default(false))
+      .addOption(new Option('--protobuf', 'Add a job linting the .proto files with buf, checking pull requests for breaking changes and, with buf.gen.yaml, that generated code is committed')
+        .default(false))
+      .addOption(new Option('--phoenix-db', 'Create and migrate the test database of a Phoenix app against a postgres service before its tests')
+        .default(false))
+      .addOption(new Option('--cxx-matrix', 'Build and test CMake projects with both gcc and clang')
+        .default(false))
+      .addOption(new Option('--swift-linux', 'Build and test Swift packages in the swift container on Linux as well as on macOS')
+        .default(false))
+      .addOption(new Option('--flutter-build', 'Run flutter build for each platform a Flutter app has a directory for')
+        .default(false))
+      .addOption(new Option('--provenance', 'Comment each generated step with where it comes from: the README, a manifest or a default')
+        .default(false))
+      .addOption(new Option('--minimal', 'Generate the leanest CI workflow that builds and tests: no comments, workflow permissions or concurrency (not with --provenance)')
+        .default(false))
+      .addOption(new Option('--make-ci', 'Run `make ci` as the whole pipeline when the Makefile has a ci target')
+        .default(false))
+      .addOption(new Option('--rust-workspace <layout>', 'Test a Cargo workspace in one job or with one test job per member crate')
+        .choices(['workspace', 'per-crate']))
+      .addOption(new Option('--node-workspace <layout>', 'Test an npm or pnpm workspace with workspace-wide commands or with one test job per package')
+        .choices(['workspace', 'per-package']))
+      .addOption(new Option('--cargo-features-matrix', 'Build and test each Cargo feature of the root package in its own matrix entry')
+        .default(false))
+      .addOption(new Option('--deploy-pages', 'Deploy the detected static site (Hugo, Jekyll, Next.js export, VitePress, MkDocs) to GitHub Pages')
+        .default(false))
+      .addOption(new Option('--pages-dir <dir>', 'Site build output directory to deploy instead of the generator default'))
+      .addOption(new Option('--min-confidence <score>', 'Drop detected frameworks and test runners scoring below this confidence (0-1)')
+        .argParser(parseFloat))
+      .addOption(new Option('--runner-labels <labels...>', 'Run every job on self-hosted runners with these labels (space or comma separated)'))
+      .addOption(new Option('--runner-images <images...>', `Hosted runner image each operating system runs on instead of its -latest alias, as os=label (space or comma separated: ${HOSTED_OS_NAMES.join(', ')})`))
+      .addOption(new Option('--os <systems...>', `Hosted runner operating systems the unit tests run on, replacing the detected ones (space or comma separated: ${HOSTED_OS_NAMES.join(', ')})`))
+      .addOption(new Option('--private-modules <patterns...>', 'Fetch Go modules matching these GOPRIVATE patterns with a token, e.g. acme/* (GitHub Actions)'))
+      .addOption(new Option('--private-modules-secret <name>', 'Repository secret holding the private Go modules token (default: GO_MODULES_TOKEN)'))
       .addOption(new Option('-f, --framework <frameworks...>', 'Override automatic framework detection'))
       .addOption(new Option('--dry-run', 'Show what would be generated without creating files')
         .default(false))
+      .addOption(new Option('--format <format>', 'Print the detection result in this format instead of generating workflows')
+        .choices(['json']))
       
       // Phase 3: Enhanced CLI options for better control
-      .addOption(new Option('--timeout <seconds>', 'Set custom timeout for detection and generation')
+      .addOption(new Option('--timeout <seconds>', 'Set custom timeout for detection, generation and resolving action refs')
         .argParser(parseInt)
         .default(15))
       .addOption(new Option('--use-fallback', 'Skip complex detection, use simple generator')
@@ -195,6 +307,9 @@ export class CommandParser {
       this.parsedOptions = this.buildCLIOptions('generate', {
         ...globalOptions,
         ...commandOptions,
+        // Without --provider the github default stands until the repository configuration replaces it
+        provider: commandOptions.provider ?? 'github',
+        providerDefaulted: commandOptions.provider === undefined,
         readmePath
       });
     });
@@ -223,6 +338,28 @@ export class CommandParser {
     });
   }
 
+  /**
+   * Setup the verify command
+   */
+  private setupVerifyCommand(): void {
+    const verifyCommand = this.program
+      .command('verify [workflow-path]')
+      .description('Run a job of a generated workflow locally with act to check it works')
+      .argument('[workflow-path]', 'Path to the workflow file (defaults to .github/workflows/ci.yml)')
+      .addOption(new Option('--job <id>', 'Job to run (default: the first job of the workflow)'))
+      .addHelpText('after', this.getVerifyExamples());
+
+    verifyCommand.action((workflowPath, options, command) => {
+      const globalOptions = this.program.opts();
+      const commandOptions = verifyCommand.opts();
+      this.parsedOptions = this.buildCLIOptions('verify', {
+        ...globalOptions,
+        ...commandOptions,
+        workflowPath
+      });
+    });
+  }
+
   /**
    * Setup the init command
    */
@@ -293,7 +430,7 @@ export class CommandParser {
    */
   private buildCLIOptions(command: string, options: any): CLIOptions {
     // Validate command
-    const validCommands = ['generate', 'validate', 'init', 'export', 'import', 'parse', 'analyze', 'readme-validate', 'status'];
+    const validCommands = ['generate', 'validate', 'verify', 'init', 'export', 'import', 'parse', 'analyze', 'readme-validate', 'status'];
     if (!validCommands.includes(command)) {
       throw new Error(`Invalid command: ${command}. Valid commands are: ${validCommands.join(', ')}`);
     }
@@ -309,16 +446,183 @@ export class CommandParser {
     // Validate batch processing options
     this.validateBatchProcessingOptions(options);
 
+    if (options.minConfidence !== undefined && (isNaN(options.minConfidence) || options.minConfidence < 0 || options.minConfidence > 1)) {
+      throw new Error('Min confidence must be a number between 0 and 1');
+    }
+
+    for (const [name, value] of [
+      ['--test-retries', options.testRetries],
+      ['--job-timeout', options.jobTimeout],
+      ['--artifact-retention-days', options.artifactRetentionDays],
+      ['--detect-workers', options.detectWorkers]
+    ] as const) {
+      if (value !== undefined && (!Number.isInteger(value) || value < 1)) {
+        throw new Error(`Option ${name} must be a positive integer`);
+      }
+    }
+
+    const runnerLabels = options.runnerLabels
+      ?.flatMap((label: string) => label.split(','))
+      .map((label: string) => label.trim())
+      .filter((label: string) => label !== '');
+    if (runnerLabels && runnerLabels.length === 0) {
+      throw new Error('Runner labels must include at least one non-empty label');
+    }
+
+    const runnerImages: Partial<Record<HostedOS, string>> | undefined = options.runnerImages ? {} : undefined;
+    for (const image of options.runnerImages?.flatMap((image: string) => image.split(',')) || []) {
+      const [os = '', label = ''] = image.split('=').map((part: string) => part.trim());
+      if (os === '' && label === '') {
+        continue;
+      }
+      if (!(HOSTED_OS_NAMES as readonly string[]).includes(os.toLowerCase()) || label === '') {
+        throw new Error(`Invalid --runner-images '${image.trim()}': expected os=label with os one of ${HOSTED_OS_NAMES.join(', ')}`);
+      }
+      runnerImages![os.toLowerCase() as HostedOS] = label;
+    }
+    if (runnerImages && Object.keys(runnerImages).length === 0) {
+      throw new Error('Option --runner-images must name at least one runner image');
+    }
+
+    const testOS = options.os
+      ?.flatMap((os: string) => os.split(','))
+      .map((os: string) => os.trim().toLowerCase())
+      .filter((os: string) => os !== '');
+    const unknownOS = testOS?.find((os: string) => !(HOSTED_OS_NAMES as readonly string[]).includes(os));
+    if (unknownOS !== undefined) {
+      throw new Error(`Invalid --os '${unknownOS}': expected one of ${HOSTED_OS_NAMES.join(', ')}`);
+    }
+    if (testOS && testOS.length === 0) {
+      throw new Error('Option --os must name at least one operating system');
+    }
+
+    const privateModules = options.privateModules
+      ?.flatMap((pattern: string) => pattern.split(','))
+      .map((pattern: string) => pattern.trim())
+      .filter((pattern: string) => pattern !== '');
+    if (options.privateModulesSecret !== undefined && !/^[A-Za-z_][A-Za-z0-9_]*$/.test(options.privateModulesSecret)) {
+      throw new Error(`Invalid --private-modules-secret '${options.privateModulesSecret}': must be a secret name such as GO_MODULES_TOKEN`);
+    }
+
+    const repairSkip = options.repairSkip
+      ?.flatMap((fix: string) => fix.split(','))
+      .map((fix: string) => fix.trim())
+      .filter((fix: string) => fix !== '');
+    const unknownFix = repairSkip?.find((fix: string) => !(REPAIR_FIXES as readonly string[]).includes(fix));
+    if (unknownFix !== undefined) {
+      throw new Error(`Invalid --repair-skip '${unknownFix}': expected one of ${REPAIR_FIXES.join(', ')}`);
+    }
+
+    const scheduleProblem = options.schedule !== undefined ? validateCron(options.schedule) : undefined;
+    if (scheduleProblem) {
+      throw new Error(`Invalid --schedule '${options.schedule}': ${scheduleProblem}`);
+    }
+
+    if (options.releaseTagPrefix !== undefined && !/^[\w.\/-]*$/.test(options.releaseTagPrefix)) {
+      throw new Error(`Invalid --release-tag-prefix '${options.releaseTagPrefix}': may only contain letters, digits, '.', '_', '-' and '/'`);
+    }
+    if (options.releaseTagPrefix !== undefined && !options.release) {
+      throw new Error('Option --release-tag-prefix requires --release');
+    }
+
+    if ((options.artifactPath || options.artifactName || options.artifactRetentionDays) && !options.artifacts) {
+      throw new Error('Options --artifact-path, --artifact-name and --artifact-retention-days require --artifacts');
+    }
+
+    if (options.workingDirectory !== undefined &&
+      (!/^[\w.-]+(\/[\w.-]+)*$/.test(options.workingDirectory) || options.workingDirectory.split('/').includes('..'))) {
+      throw new Error(`Invalid --working-directory '${options.workingDirectory}': must be a directory inside the repository, such as app`);
+    }
+
+    if (options.changedFiles && !options.monorepo) {
+      throw new Error('Option --changed-files requires --monorepo');
+    }
+    if (options.detectWorkers !== undefined && !options.monorepo) {
+      throw new Error('Option --detect-workers requires --monorepo');
+    }
+    if (options.since !== undefined && options.monorepo !== 'per-package') {
+      throw new Error('Option --since requires --monorepo per-package, as a single workflow covers every package');
+    }
+    if (options.since !== undefined && (options.dependabot || options.renovate)) {
+      throw new Error('Option --since cannot be combined with --dependabot or --renovate, whose config covers every package');
+    }
+    if (options.changedFiles === 'paths-filter' && options.monorepo === 'per-package') {
+      throw new Error('Options --changed-files paths-filter and --monorepo per-package are mutually exclusive');
+    }
+
     return {
       command: command as CLIOptions['command'],
       readmePath: options.readmePath,
       outputDir: options.outputDir,
+      workingDirectory: options.workingDirectory,
       workflowType: options.workflowType as WorkflowType[],
+      provider: options.provider,
+      ...(options.providerDefaulted && { providerDefaulted: true }),
+      circleciOrbs: Boolean(options.circleciOrbs),
+      preset: options.preset,
+      coverage: options.coverage,
+      defaultBranch: options.defaultBranch,
+      cancelInProgress: options.cancelInProgress !== false,
+      permissions: options.permissions !== false,
+      schedule: options.schedule,
+      force: Boolean(options.force),
+      check: Boolean(options.check),
+      emitSecretsManifest: Boolean(options.emitSecretsManifest),
+      emit: options.emit,
+      pinActions: Boolean(options.pinActions),
+      repair: Boolean(options.repair),
+      ...(repairSkip && { repairSkip: repairSkip as RepairFixId[] }),
+      dependabot: Boolean(options.dependabot),
+      renovate: Boolean(options.renovate),
+      reusable: Boolean(options.reusable),
+      split: options.split,
+      splitSetup: options.splitSetup,
+      release: Boolean(options.release),
+      releaseTagPrefix: options.releaseTagPrefix,
+      artifacts: Boolean(options.artifacts),
+      artifactPath: options.artifactPath,
+      artifactName: options.artifactName,
+      artifactRetentionDays: options.artifactRetentionDays,
+      testRetries: options.testRetries,
+      jobTimeout: options.jobTimeout,
+      monorepo: options.monorepo,
+      changedFiles: options.changedFiles,
+      detectWorkers: options.detectWorkers,
+      since: options.since,
+      registry: options.registry,
+      testRunners: options.testRunners,
+      existingCi: options.existingCi,
+      preCommit: options.preCommit,
+      terraform: Boolean(options.terraform),
+      licenseCheck: Boolean(options.licenseCheck),
+      changelogCheck: Boolean(options.changelogCheck),
+      codeownersComment: Boolean(options.codeownersComment),
+      apiLint: Boolean(options.apiLint),
+      protobuf: Boolean(options.protobuf),
+      phoenixDb: Boolean(options.phoenixDb),
+      cxxMatrix: Boolean(options.cxxMatrix),
+      swiftLinux: Boolean(options.swiftLinux),
+      flutterBuild: Boolean(options.flutterBuild),
+      provenance: Boolean(options.provenance),
+      minimal: Boolean(options.minimal),
+      makeCi: Boolean(options.makeCi),
+      rustWorkspace: options.rustWorkspace,
+      nodeWorkspace: options.nodeWorkspace,
+      cargoFeatureMatrix: Boolean(options.cargoFeaturesMatrix),
+      deployPages: Boolean(options.deployPages),
+      pagesDir: options.pagesDir,
+      minConfidence: options.minConfidence,
+      runnerLabels,
+      ...(runnerImages && { runnerImages }),
+      ...(testOS && { os: [...new Set<string>(testOS)] as HostedOS[] }),
+      privateModules,
+      privateModulesSecret: options.privateModulesSecret,
       framework: options.framework,
       dryRun: Boolean(options.dryRun),
       interactive: Boolean(options.interactive),
       verbose: Boolean(options.verbose),
-      debug: Boolean(options.debug),
+      // -vv asks for the same output as --debug
+      debug: Boolean(options.debug) || options.verbose >= 2,
       quiet: Boolean(options.quiet),
       config: options.config,
       ci: Boolean(options.ci),
@@ -330,6 +634,10 @@ export class CommandParser {
       
       // Init specific options
       template: options.template,
+
+      // Verify specific options
+      workflowPath: options.workflowPath,
+      job: options.job,
       
       // Batch processing options
       directories: options.directories,
@@ -380,6 +688,21 @@ export class CommandParser {
     if (options.debug && options.quiet) {
       throw new Error('Options --debug and --quiet are mutually exclusive');
     }
+
+    // A split CI workflow is not one workflow other workflows could call
+    if (options.split === 'by-kind' && options.reusable) {
+      throw new Error('Options --split by-kind and --reusable are mutually exclusive');
+    }
+
+    // A repository is kept up to date by one bot
+    if (options.dependabot && options.renovate) {
+      throw new Error('Options --dependabot and --renovate are mutually exclusive');
+    }
+
+    // Minimal output has no comments to put provenance in
+    if (options.minimal && options.provenance) {
+      throw new Error('Options --minimal and --provenance are mutually exclusive');
+    }
   }
 
   /**
@@ -493,7 +816,7 @@ export class CommandParser {
   private getUsageExamples(): string {
     return `
 Examples:
-  $ readme-to-cicd generate                    # Generate workflows from ./README.md
+  $ readme-to-cicd generate --provider github  # Generate workflows from ./README.md
   $ readme-to-cicd generate --interactive      # Use interactive mode with prompts
   $ readme-to-cicd generate --dry-run          # Preview what would be generated
   $ readme-to-cicd validate                    # Validate existing workflows
@@ -511,11 +834,63 @@ For more help on specific commands, use:
     return `
 Examples:
   Single Project:
-    $ readme-to-cicd generate                                    # Basic generation
+    $ readme-to-cicd generate --provider github                 # Basic generation
     $ readme-to-cicd generate ./docs/README.md                  # Specific README file
     $ readme-to-cicd generate -o ./workflows                    # Custom output directory
     $ readme-to-cicd generate -w ci cd                          # Specific workflow types
     $ readme-to-cicd generate -f nodejs react                   # Override framework detection
+    $ readme-to-cicd generate --provider gitlab                 # Write .gitlab-ci.yml instead
+    $ readme-to-cicd generate --provider circleci               # Write .circleci/config.yml instead
+    $ readme-to-cicd generate --provider azure                  # Write azure-pipelines.yml instead
+    $ readme-to-cicd generate --provider bitbucket              # Write bitbucket-pipelines.yml instead
+    $ readme-to-cicd generate --provider jenkins                # Write a Jenkinsfile instead
+    $ readme-to-cicd generate --preset lint                     # Only generate the lint job
+    $ readme-to-cicd generate --coverage codecov                # Upload unit test coverage to Codecov
+    $ readme-to-cicd generate --default-branch trunk            # Trigger on trunk instead of the git default
+    $ readme-to-cicd generate --no-cancel-in-progress           # Let every CI run complete
+    $ readme-to-cicd generate --no-permissions                  # Rely on the organization's token permissions
+    $ readme-to-cicd generate --schedule "0 6 * * *"            # Add a nightly build and test workflow
+    $ readme-to-cicd generate --force                           # Write workflows that fail validation
+    $ readme-to-cicd generate --check                           # Fail in CI when workflows are out of date
+    $ readme-to-cicd generate --emit-secrets-manifest           # List the secrets to set up before the first run
+    $ readme-to-cicd generate --emit composite-action           # Share the build and test steps as an action
+    $ readme-to-cicd generate --pin-actions                     # Reference actions by commit SHA
+    $ readme-to-cicd generate --repair --repair-skip concurrency  # Pin actions and limit token permissions
+    $ readme-to-cicd generate --dependabot                      # Keep dependencies and actions up to date
+    $ readme-to-cicd generate --reusable                        # Write ci.yml for other workflows to call
+    $ readme-to-cicd generate --split by-kind                   # Write build.yml, test.yml and lint.yml
+    $ readme-to-cicd generate --release --release-tag-prefix ""  # Publish GitHub Releases for tags like 1.2.3
+    $ readme-to-cicd generate --artifacts --artifact-retention-days 30  # Keep build output for 30 days
+    $ readme-to-cicd generate --test-retries 2 --job-timeout 20 # Retry flaky tests, cap test jobs at 20 min
+    $ readme-to-cicd generate --working-directory app           # Run jobs in app/, where the project lives
+    $ readme-to-cicd generate --monorepo per-package            # One workflow per monorepo package
+    $ readme-to-cicd generate --monorepo single --changed-files paths-filter  # Only run jobs of changed packages
+    $ readme-to-cicd generate --monorepo single --detect-workers 2  # Detect two packages at a time
+    $ readme-to-cicd generate --monorepo per-package --since origin/main  # Regenerate changed packages only
+    $ readme-to-cicd generate --test-runners matrix             # One matrix job over all test runners
+    $ readme-to-cicd generate --pre-commit replace-lint         # Lint with the pre-commit hooks
+    $ readme-to-cicd generate --terraform                       # Validate and plan the Terraform configuration
+    $ readme-to-cicd generate --license-check                   # Fail on dependencies with disallowed licenses
+    $ readme-to-cicd generate --changelog-check                 # Require pull requests to update the changelog
+    $ readme-to-cicd generate --codeowners-comment              # Summarize the code owners of pull request changes
+    $ readme-to-cicd generate --api-lint                        # Lint the OpenAPI and GraphQL schemas
+    $ readme-to-cicd generate --protobuf                        # Lint protobuf files and catch breaking changes
+    $ readme-to-cicd generate --phoenix-db                      # Migrate a Phoenix test database before the tests
+    $ readme-to-cicd generate --cxx-matrix                      # Build C/C++ with gcc and clang
+    $ readme-to-cicd generate --swift-linux                     # Test Swift packages on Linux too
+    $ readme-to-cicd generate --flutter-build                   # Build a Flutter app for each of its platforms
+    $ readme-to-cicd generate --provenance                      # Comment each step with where it comes from
+    $ readme-to-cicd generate --minimal                         # Only the build and test jobs, without comments
+    $ readme-to-cicd generate --make-ci                         # Run make ci as the whole pipeline
+    $ readme-to-cicd generate --rust-workspace per-crate        # One test job per workspace crate
+    $ readme-to-cicd generate --node-workspace per-package      # One test job per npm/pnpm workspace package
+    $ readme-to-cicd generate --deploy-pages --pages-dir build  # Publish the static site to GitHub Pages
+    $ readme-to-cicd generate --min-confidence 0.6              # Ignore weakly detected frameworks
+    $ readme-to-cicd generate --format json                     # Print the detection result as JSON
+    $ readme-to-cicd generate --runner-labels self-hosted,gpu   # Run every job on self-hosted runners
+    $ readme-to-cicd generate --runner-images macos=macos-14    # Run macOS jobs on macos-14, not macos-latest
+    $ readme-to-cicd generate --os linux,macos,windows          # Run the unit tests on all three hosted OSes
+    $ readme-to-cicd generate --private-modules acme/*          # Fetch private Go modules of github.com/acme
     $ readme-to-cicd generate --dry-run --verbose               # Preview with details
     $ readme-to-cicd generate --interactive                     # Interactive mode
     $ readme-to-cicd generate --config ./custom-config.json    # Custom configuration
@@ -543,6 +918,18 @@ Examples:
 `;
   }
 
+  /**
+   * Get verify command examples
+   */
+  private getVerifyExamples(): string {
+    return `
+Examples:
+  $ readme-to-cicd verify                             # Run the first job of ci.yml with act
+  $ readme-to-cicd verify --job test                  # Run the test job
+  $ readme-to-cicd verify .github/workflows/build.yml # Run a job of another workflow
+`;
+  }
+
   /**
    * Get init command examples
    */
@@ -7,11 +7,13 @@
  * and memory management.
  */
 
-import { ReadmeParserImpl, ParseResult } from '../../parser';
-import { FrameworkDetectorImpl, DetectionResult } from '../../detection';
-import { YAMLGeneratorImpl, WorkflowOutput, GenerationOptions } from '../../generator';
+import { ReadmeParserImpl, ParseResult, findReadme } from '../../parser';
+import { FrameworkDetectorImpl, DetectionResult, ProjectUnit, MonorepoDetectionError, Diagnostic, sortDiagnostics, createDetectionReport, loadIgnoreMatcher } from '../../detection';
+import { YAMLGeneratorImpl, WorkflowOutput, GenerationOptions, GenerationPreset, ActionShaResolver, Provider, GITHUB_WORKFLOWS_DIRECTORY, CIRCLECI_CONFIG_DIRECTORY, SINGLE_PIPELINE_PROVIDERS, getExistingCIProviders, detectExistingCI, resolveExistingProvider, PROVIDER_NAMES, validateWorkflowStructure, diffWorkflowFile, workflowsEquivalent, collectSecrets, SECRETS_MANIFEST_FILENAME, collectUpdateTargets, renderDependabotConfig, repairWorkflow, findUnpinnedActions, renderRenovateConfig, readManagedBlock, DEPENDABOT_CONFIG_PATH, RENOVATE_CONFIG_PATH, COMPOSITE_ACTION_PATH, planSummary } from '../../generator';
 import { CLIOptions, CLIResult, CLIError, ExecutionSummary, WorkflowType } from './types';
+import { loadConfig, RepoConfig, REPO_CONFIG_FILES } from '../config/repo-config';
 import { Logger } from './logger';
+import { GitIntegration } from './git-integration';
 import { ErrorHandler } from './error-handler';
 import { OutputHandler, WorkflowFile } from './output-handler';
 import { CacheManager } from './cache-manager';
@@ -31,11 +33,27 @@ export interface ExecutionContext {
   readonly startTime: Date;
   readonly options: CLIOptions;
   readonly workingDirectory: string;
+
+  // Overrides from .readme-to-cicd.yml at the repository root
+  repoConfig?: RepoConfig;
+  // Branch that triggers CI and CD, from --default-branch or git metadata
+  defaultBranch?: string;
+  // The one CI provider the repository already has configuration for, targeted without --provider
+  existingProvider?: Provider;
+  // Workflows in the output directory without a managed block, which split workflows must not replace
+  userWorkflows?: string[];
   
   // Component results
   parseResult?: ParseResult;
   detectionResult?: DetectionResult;
+  projectUnits?: ProjectUnit[];
+  /** Files changed since --since, relative to the working directory; only their packages are regenerated */
+  changedSince?: string[];
+  /** Workflows left out because a generation preset found no jobs for them */
+  skippedWorkflows?: string[];
   generationResults?: WorkflowOutput[];
+  /** action.yml bundling the CI setup, build and test steps (--emit composite-action) */
+  compositeAction?: WorkflowOutput;
   generatedFiles?: string[];
   
   // Execution state
@@ -170,14 +188,15 @@ export class ComponentOrchestrator {
 
     if (!this.frameworkDetector) {
       const FrameworkDetectorClass = await this.lazyLoader.getFrameworkDetector();
-      this.frameworkDetector = new FrameworkDetectorClass();
+      this.frameworkDetector = new FrameworkDetectorClass({ logger: this.logger });
     }
 
     if (!this.yamlGenerator) {
       const YAMLGeneratorClass = await this.lazyLoader.getYamlGenerator();
       this.yamlGenerator = new YAMLGeneratorClass({
         cacheEnabled: true,
-        advancedPatternsEnabled: true
+        advancedPatternsEnabled: true,
+        logger: this.logger
       });
     }
   }
@@ -223,6 +242,28 @@ export class ComponentOrchestrator {
       // Monitor memory before execution
       await this.memoryOptimizer.monitorMemory();
 
+      // Repository overrides apply to dry runs as well
+      await this.loadRepoConfig(context);
+      await this.resolveChangedSince(context);
+
+      // --repair fixes the workflows already committed instead of generating new ones
+      if (cliOptions.repair) {
+        const result = await this.executeRepair(context);
+        this.performanceMonitor.endTimer(workflowTimerId, { success: result.success, repair: true });
+        return result;
+      }
+
+      // --format json reports the detection result without generating anything
+      if (cliOptions.format === 'json') {
+        const result = await this.executeDetectionReport(context);
+        this.performanceMonitor.endTimer(workflowTimerId, { success: result.success, detectionReport: true });
+        return result;
+      }
+
+      await this.resolveDefaultBranch(context);
+      await this.resolveExistingCI(context);
+      await this.resolveUserWorkflows(context);
+
       // Handle dry-run mode
       if (cliOptions.dryRun) {
         const result = await this.executeDryRun(context);
@@ -234,7 +275,11 @@ export class ComponentOrchestrator {
       await this.executeParsingStep(context);
       await this.executeDetectionStep(context);
       await this.executeGenerationStep(context);
-      await this.executeOutputStep(context);
+      if (cliOptions.check) {
+        await this.executeCheckStep(context);
+      } else {
+        await this.executeOutputStep(context);
+      }
 
       // Trigger GC if needed after processing
       await this.memoryOptimizer.triggerGCIfNeeded();
@@ -245,7 +290,9 @@ export class ComponentOrchestrator {
 
       // Phase 2: Complete all progress and show success
       const generatedCount = context.generatedFiles?.length || 0;
-      context.progressIndicator?.complete(`Successfully generated ${generatedCount} workflow files`);
+      context.progressIndicator?.complete(cliOptions.check
+        ? `All ${context.generationResults?.length || 0} workflow files are up to date`
+        : `Successfully generated ${generatedCount} workflow files`);
 
       const result = this.createSuccessResult(context);
       
@@ -294,6 +341,121 @@ export class ComponentOrchestrator {
     }
   }
 
+  /**
+   * Read .readme-to-cicd.yml from the repository root, surfacing ignored keys as warnings
+   */
+  private async loadRepoConfig(context: ExecutionContext): Promise<void> {
+    const loaded = await loadConfig(context.workingDirectory);
+    if (loaded.path) {
+      context.repoConfig = loaded.config;
+      this.logger.debug('Loaded repository configuration', {
+        executionId: context.executionId,
+        path: loaded.path,
+        keys: Object.keys(loaded.config)
+      });
+    }
+    context.warnings.push(...loaded.warnings);
+  }
+
+  /**
+   * With --since, list the files changed since its ref, so monorepo detection only re-detects
+   * the packages they belong to. Runs before any detection, failing outside a Git repository or
+   * for a ref that names no commit. A changed config file affects every package, so then all of
+   * them are regenerated.
+   */
+  private async resolveChangedSince(context: ExecutionContext): Promise<void> {
+    const ref = context.options.since;
+    if (!ref) {
+      return;
+    }
+
+    let files: string[];
+    try {
+      files = await new GitIntegration(this.logger, context.workingDirectory).getChangedFilesSince(ref);
+    } catch (error) {
+      throw new Error(`Cannot use --since ${ref}: ${error instanceof Error ? error.message : (error as CLIError).message}`);
+    }
+
+    if (files.some(file => REPO_CONFIG_FILES.includes(file))) {
+      this.logger.info('Configuration changed, regenerating every package', { executionId: context.executionId, since: ref });
+      return;
+    }
+    context.changedSince = files;
+    this.logger.debug('Files changed since ref', { executionId: context.executionId, since: ref, files });
+  }
+
+  /**
+   * Use --default-branch, else the default branch recorded in git. Without git metadata
+   * main is assumed, with a warning since the triggers may then name the wrong branch.
+   */
+  private async resolveDefaultBranch(context: ExecutionContext): Promise<void> {
+    if (context.options.defaultBranch) {
+      context.defaultBranch = context.options.defaultBranch;
+      return;
+    }
+
+    const detected = await new GitIntegration(this.logger, context.workingDirectory).getDefaultBranch();
+    if (detected) {
+      context.defaultBranch = detected;
+      this.logger.debug('Detected default branch', { executionId: context.executionId, branch: detected });
+    } else {
+      context.defaultBranch = 'main';
+      context.warnings.push('Could not read the default branch from git metadata - assuming main; pass --default-branch to set it');
+    }
+  }
+
+  /**
+   * Without --provider or a provider in the config file, target the CI system the repository
+   * is already configured for rather than adding a second one. A repository configured for
+   * none or several systems leaves no safe choice, so --provider is required then.
+   */
+  private async resolveExistingCI(context: ExecutionContext): Promise<void> {
+    if (!context.options.providerDefaulted || context.repoConfig?.provider) {
+      return;
+    }
+
+    const existing = resolveExistingProvider(await detectExistingCI(context.workingDirectory));
+    context.existingProvider = existing;
+    this.logger.debug('Targeting the existing CI provider', { executionId: context.executionId, provider: existing });
+    if (existing !== Provider.GitHubActions) {
+      context.warnings.push(`Generating ${PROVIDER_NAMES[existing]} configuration, which the repository already uses; pass --provider to choose another provider`);
+    }
+  }
+
+  /**
+   * With --split by-kind, find the workflows in the output directory the generator did not
+   * write, so build.yml, test.yml or lint.yml of the user's own are not overwritten
+   */
+  private async resolveUserWorkflows(context: ExecutionContext): Promise<void> {
+    if (context.options.split !== 'by-kind') {
+      return;
+    }
+
+    const outputDir = this.resolveOutputDirectory(context);
+    let entries: string[];
+    try {
+      entries = await fs.readdir(outputDir);
+    } catch {
+      return;
+    }
+
+    const userWorkflows: string[] = [];
+    for (const entry of entries.filter(name => /\.ya?ml$/.test(name))) {
+      const content = await fs.readFile(path.join(outputDir, entry), 'utf8').catch(() => '');
+      let managed: boolean;
+      try {
+        managed = readManagedBlock(content) !== undefined;
+      } catch {
+        // A damaged block still marks a generated file
+        managed = true;
+      }
+      if (!managed) {
+        userWorkflows.push(entry);
+      }
+    }
+    context.userWorkflows = userWorkflows;
+  }
+
   /**
    * Execute dry-run mode to show what would be generated
    */
@@ -330,6 +492,7 @@ export class ComponentOrchestrator {
           ...dryRunResult.warnings,
           `DRY RUN: Would generate ${dryRunResult.wouldGenerate.files.length} files`
         ],
+        ...this.diagnosticsOf(context),
         summary: {
           totalTime: Date.now() - context.startTime.getTime(),
           filesGenerated: 0, // No files actually generated
@@ -352,6 +515,141 @@ export class ComponentOrchestrator {
     }
   }
 
+  /**
+   * Print the detection result, with the steps it calls for, as JSON on stdout
+   */
+  private async executeDetectionReport(context: ExecutionContext): Promise<CLIResult> {
+    this.logger.info('Executing detection report', { executionId: context.executionId });
+
+    try {
+      await this.executeParsingStep(context);
+      await this.executeDetectionStep(context);
+
+      const detectionResult = context.detectionResult!;
+      const pipeline = await this.frameworkDetector!.suggestCISteps(detectionResult);
+      const report = createDetectionReport(detectionResult, pipeline, context.workingDirectory);
+
+      console.log(JSON.stringify(report, null, 2));
+
+      return {
+        success: true,
+        generatedFiles: [],
+        errors: context.errors,
+        warnings: context.warnings,
+        ...this.diagnosticsOf(context),
+        summary: {
+          totalTime: Date.now() - context.startTime.getTime(),
+          filesGenerated: 0,
+          workflowsCreated: 0,
+          frameworksDetected: detectionResult.frameworks.map(framework => framework.name),
+          optimizationsApplied: 0,
+          executionTime: Date.now() - context.startTime.getTime(),
+          filesProcessed: 1,
+          workflowsGenerated: 0
+        }
+      };
+
+    } catch (error) {
+      this.logger.error('Detection report failed', {
+        executionId: context.executionId,
+        error: error instanceof Error ? error.message : String(error)
+      });
+
+      return this.createErrorResult(context, error);
+    }
+  }
+
+  /**
+   * Repair the GitHub workflows in the output directory in place (--repair). Action refs are
+   * pinned to the commits git ls-remote resolves them to; refs it cannot resolve are left as
+   * they are, with a warning. Each fix applied is printed.
+   */
+  private async executeRepair(context: ExecutionContext): Promise<CLIResult> {
+    const outputDir = context.options.outputDir || path.join(context.workingDirectory, ...GITHUB_WORKFLOWS_DIRECTORY.split('/'));
+    this.logger.info('Executing workflow repair', { executionId: context.executionId, outputDirectory: outputDir });
+
+    try {
+      let files: string[];
+      try {
+        files = (await fs.readdir(outputDir)).filter(file => /\.ya?ml$/.test(file)).sort();
+      } catch {
+        files = [];
+      }
+      if (files.length === 0) {
+        throw new Error(`No workflows to repair in ${outputDir}`);
+      }
+
+      const workflows = new Map<string, string>();
+      for (const file of files) {
+        workflows.set(file, await fs.readFile(path.join(outputDir, file), 'utf8'));
+      }
+
+      const skip = context.options.repairSkip || [];
+      const actionShas: Record<string, string> = {};
+      if (!skip.includes('pin-actions')) {
+        const git = new GitIntegration(this.logger, context.workingDirectory, { deadline: Date.now() + (context.options.timeout || 15) * 1000 });
+        const references = new Set([...workflows.values()].flatMap(content => findUnpinnedActions(content)));
+        for (const reference of references) {
+          const [action, ref] = reference.split('@') as [string, string];
+          const sha = await git.resolveRemoteRef(action.split('/').slice(0, 2).join('/'), ref);
+          if (sha) {
+            actionShas[reference] = sha;
+          }
+        }
+      }
+
+      const repaired: string[] = [];
+      let fixesApplied = 0;
+      for (const [file, content] of workflows) {
+        const relativePath = path.relative(context.workingDirectory, path.join(outputDir, file)).split(path.sep).join('/');
+        const result = repairWorkflow(content, { skip, actionShas });
+        context.warnings.push(...result.warnings.map(warning => `${relativePath}: ${warning}`));
+        if (result.content === content) {
+          continue;
+        }
+
+        await fs.writeFile(path.join(outputDir, file), result.content, 'utf8');
+        repaired.push(path.join(outputDir, file));
+        fixesApplied += result.fixes.length;
+        for (const fix of result.fixes) {
+          console.log(`${relativePath}: ${fix.description}`);
+        }
+      }
+
+      this.logger.info('Workflow repair completed', {
+        executionId: context.executionId,
+        workflowsChecked: files.length,
+        workflowsRepaired: repaired.length,
+        fixesApplied
+      });
+
+      return {
+        success: true,
+        generatedFiles: repaired,
+        errors: context.errors,
+        warnings: context.warnings,
+        summary: {
+          totalTime: Date.now() - context.startTime.getTime(),
+          filesGenerated: repaired.length,
+          workflowsCreated: 0,
+          frameworksDetected: [],
+          optimizationsApplied: fixesApplied,
+          executionTime: Date.now() - context.startTime.getTime(),
+          filesProcessed: files.length,
+          workflowsGenerated: 0
+        }
+      };
+
+    } catch (error) {
+      this.logger.error('Workflow repair failed', {
+        executionId: context.executionId,
+        error: error instanceof Error ? error.message : String(error)
+      });
+
+      return this.createErrorResult(context, error);
+    }
+  }
+
   /**
    * Execute the README parsing step
    */
@@ -366,7 +664,7 @@ export class ComponentOrchestrator {
 
     try {
       // Determine README file path
-      const readmePath = this.resolveReadmePath(context.options);
+      const readmePath = await this.resolveReadmePath(context);
       
       // Validate input if enabled
       if (this.options.validateInputs) {
@@ -437,8 +735,13 @@ export class ComponentOrchestrator {
         throw new Error('Framework detector not initialized');
       }
       
+      // What .gitignore and detectIgnore leave out is neither walked nor detected
+      const ignore = await loadIgnoreMatcher(context.workingDirectory, context.repoConfig?.detectIgnore);
+
       // PHASE 1&2 FIX: Add timeout wrapper with user-configurable timeout
       const timeoutMs = (context.options.timeout || 15) * 1000; // Convert to milliseconds
+      // Reads still failing once detection has run out of time are not retried behind the fallback
+      const deadline = Date.now() + timeoutMs;
       
       // Phase 2: Check if user wants to skip complex detection
       if (context.options.useFallback) {
@@ -447,7 +750,7 @@ export class ComponentOrchestrator {
       } else {
         try {
           context.detectionResult = await this.executeWithTimeout(
-            () => this.frameworkDetector!.detectFrameworks(projectInfo, context.workingDirectory),
+            () => this.frameworkDetector!.detectFrameworks(projectInfo, context.workingDirectory, context.options.workingDirectory, ignore, deadline),
             timeoutMs,
             () => {
               // Phase 2: Show fallback progress message
@@ -477,6 +780,32 @@ export class ComponentOrchestrator {
         }
       }
 
+      if (context.options.monorepo) {
+        context.progressIndicator?.updateStep('Detecting monorepo packages');
+        try {
+          context.projectUnits = await this.frameworkDetector.detectMonorepo(context.workingDirectory, context.options.detectWorkers, ignore, context.changedSince, deadline);
+        } catch (error) {
+          if (!(error instanceof MonorepoDetectionError)) {
+            throw error;
+          }
+          // Generate for the packages that were detected rather than failing the whole repository
+          context.projectUnits = error.units;
+          context.warnings.push(...error.failures.map(failure => `Skipped monorepo package ${failure.path}: ${failure.message}`));
+        }
+        this.logger.info('Monorepo packages detected', {
+          executionId: context.executionId,
+          packages: context.projectUnits.map(unit => unit.path)
+        });
+      }
+
+      if (context.options.minConfidence !== undefined) {
+        context.detectionResult = this.applyMinConfidence(context, context.detectionResult);
+        context.projectUnits = context.projectUnits?.map(unit => ({
+          ...unit,
+          detection: this.applyMinConfidence(context, unit.detection, unit.path)
+        }));
+      }
+
       context.stepTimes.detection = Date.now() - stepStartTime;
 
       // Phase 2: Complete detection step
@@ -497,6 +826,33 @@ export class ComponentOrchestrator {
     }
   }
 
+  /**
+   * Drop frameworks and test runners scoring below --min-confidence, warning about each with
+   * the signals it had so the score can be understood
+   */
+  private applyMinConfidence(context: ExecutionContext, detectionResult: DetectionResult, packagePath?: string): DetectionResult {
+    const threshold = context.options.minConfidence!;
+    const prefix = packagePath ? `${packagePath}: ` : '';
+    const describe = (signals?: Array<{ kind: string; source: string }>) => signals?.length
+      ? signals.map(signal => `${signal.kind} ${signal.source}`).join(', ')
+      : 'none';
+    const keep = (kind: string, name: string, confidence: number | undefined, signals?: Array<{ kind: string; source: string }>) => {
+      if (confidence === undefined || confidence >= threshold) {
+        return true;
+      }
+      context.warnings.push(`${prefix}Ignoring ${kind} ${name} - confidence ${confidence.toFixed(2)} is below ${threshold} (signals: ${describe(signals)})`);
+      return false;
+    };
+
+    return {
+      ...detectionResult,
+      frameworks: (detectionResult.frameworks || []).filter(f => keep('framework', f.name, f.confidence, f.signals)),
+      ...(detectionResult.testRunners && {
+        testRunners: detectionResult.testRunners.filter(r => keep('test runner', r.name, r.confidence, r.signals))
+      })
+    };
+  }
+
   /**
    * Execute the YAML generation step
    */
@@ -515,30 +871,63 @@ export class ComponentOrchestrator {
 
     try {
       // Prepare generation options
-      const generationOptions = this.createGenerationOptions(context.options);
+      const generationOptions = this.createGenerationOptions(context.options, context.repoConfig, context.defaultBranch, context.existingProvider, context.userWorkflows);
 
       // Convert detection result to generator-expected format
-      const generatorDetectionResult = this.convertDetectionResultForGenerator(context.detectionResult);
+      const generatorDetectionResult = this.applyConfigVersions(
+        this.convertDetectionResultForGenerator(context.detectionResult, context.parseResult?.data),
+        context.repoConfig
+      );
+
+      // GitLab output is a single pipeline file, so only the CI workflow applies
+      const workflowTypes = this.resolveWorkflowTypes(context, generationOptions, generatorDetectionResult);
 
       // Execute generation based on workflow types
-      if (context.options.workflowType && context.options.workflowType.length > 0) {
+      if (context.changedSince && context.projectUnits?.length === 0) {
+        // No package changed, so every package workflow is left as it is
+        context.generationResults = [];
+        context.warnings.push(`No monorepo package changed since ${context.options.since} - no workflows regenerated`);
+      } else if (context.projectUnits && context.projectUnits.length > 0) {
+        if (!this.yamlGenerator) {
+          throw new Error('YAML generator not initialized');
+        }
+
+        const packages = context.projectUnits.map(unit => ({
+          path: unit.path,
+          name: unit.name,
+          detectionResult: this.applyConfigVersions(this.convertDetectionResultForGenerator(unit.detection), context.repoConfig),
+          excludePaths: unit.nestedUnits,
+          dependsOn: unit.dependsOn
+        }));
+
+        context.generationResults = await this.executeWithRetry(
+          () => this.yamlGenerator!.generateMonorepoWorkflows(packages, generationOptions),
+          'YAML generation (monorepo workflows)',
+          context
+        );
+      } else if (workflowTypes.length > 0) {
         // Generate specific workflow types
         if (!this.yamlGenerator) {
           throw new Error('YAML generator not initialized');
         }
         
         try {
-          context.generationResults = await this.executeWithRetry(
-            () => this.yamlGenerator!.generateMultipleWorkflows(generatorDetectionResult, context.options.workflowType!),
+          context.generationResults = this.dropEmptyWorkflows(context, await this.executeWithRetry(
+            () => this.yamlGenerator!.generateMultipleWorkflows(generatorDetectionResult, workflowTypes, generationOptions),
             'YAML generation (multiple workflows)',
             context
-          );
+          ));
           
-          // Check if generated workflows have meaningful content
-          if (!this.hasValidWorkflowContent(context.generationResults)) {
+          // Check if generated workflows have meaningful content; a preset may leave nothing to write
+          if (!context.skippedWorkflows?.length && !this.hasValidWorkflowContent(context.generationResults)) {
             throw new Error('Generated workflows have insufficient content');
           }
         } catch (error) {
+          // The fallback workflows are GitHub Actions only
+          if (generationOptions.provider !== Provider.GitHubActions) {
+            throw error;
+          }
+
           this.logger.warn('YAML generator failed or produced insufficient content, using fallback workflows', {
             executionId: context.executionId,
             error: error instanceof Error ? error.message : String(error)
@@ -575,11 +964,22 @@ export class ComponentOrchestrator {
         }
       }
 
+      if (context.options.emit === 'composite-action') {
+        const action = await this.yamlGenerator!.generateCompositeAction(generatorDetectionResult, generationOptions);
+        if (action.content !== '') {
+          context.compositeAction = action;
+        } else {
+          context.warnings.push(...action.metadata.warnings);
+        }
+      }
+
       context.stepTimes.generation = Date.now() - stepStartTime;
 
       // Phase 2: Complete generation step
       context.progressIndicator?.completeStep();
 
+      this.printPlanSummary(context, generatorDetectionResult, context.generationResults, generationOptions);
+
       this.logger.info('Generation step completed successfully', {
         executionId: context.executionId,
         workflowsGenerated: context.generationResults.length,
@@ -606,13 +1006,24 @@ export class ComponentOrchestrator {
 
     this.logger.debug('Starting output step', { executionId: context.executionId });
 
+    if (context.generationResults?.length === 0 && (context.skippedWorkflows?.length || context.changedSince)) {
+      this.logger.info('No workflows to write', {
+        executionId: context.executionId,
+        skippedWorkflows: context.skippedWorkflows
+      });
+      context.progressIndicator?.completeStep();
+      return;
+    }
+
     if (!context.generationResults || context.generationResults.length === 0) {
       throw new Error('Cannot execute output step: generation step failed or no workflows generated');
     }
 
+    this.validateGeneratedWorkflows(context, context.generationResults);
+
     try {
       // Determine output directory
-      const outputDir = context.options.outputDir || path.join(context.workingDirectory, '.github', 'workflows');
+      const outputDir = this.resolveOutputDirectory(context);
       
       // Configure output handler for this execution
       this.outputHandler.updateOptions({
@@ -671,6 +1082,21 @@ export class ComponentOrchestrator {
         throw new Error(`File output failed: ${outputResult.errors.map(e => e.message).join(', ')}`);
       }
 
+      if (context.options.emitSecretsManifest) {
+        context.generatedFiles.push(await this.writeSecretsManifest(context, outputDir));
+      }
+
+      if (context.compositeAction) {
+        context.generatedFiles.push(await this.writeCompositeAction(context, context.compositeAction));
+      }
+
+      if (context.options.dependabot || context.options.renovate) {
+        const configPath = await this.writeDependencyUpdateConfig(context);
+        if (configPath) {
+          context.generatedFiles.push(configPath);
+        }
+      }
+
     } catch (error) {
       context.stepTimes.output = Date.now() - stepStartTime;
       this.addError(context, 'OUTPUT_FAILED', `File output failed: ${error instanceof Error ? error.message : String(error)}`, 'file-system');
@@ -678,6 +1104,144 @@ export class ComponentOrchestrator {
     }
   }
 
+  /**
+   * Write the secrets the generated workflows reference to required-secrets.json in the output
+   * directory (--emit-secrets-manifest), returning its path
+   */
+  private async writeSecretsManifest(context: ExecutionContext, outputDir: string): Promise<string> {
+    const workflows = Object.fromEntries((context.generationResults || []).map(workflow => [workflow.filename, workflow.content]));
+    const secrets = collectSecrets(workflows);
+    const filePath = path.join(outputDir, SECRETS_MANIFEST_FILENAME);
+    await fs.writeFile(filePath, `${JSON.stringify({ secrets }, null, 2)}\n`, 'utf8');
+
+    this.logger.info('Secrets manifest written', {
+      executionId: context.executionId,
+      filePath,
+      secrets
+    });
+    return filePath;
+  }
+
+  /**
+   * Write the composite action to .github/actions/ci/action.yml at the repository root
+   * (--emit composite-action), replacing the one an earlier run wrote, and return its path
+   */
+  private async writeCompositeAction(context: ExecutionContext, action: WorkflowOutput): Promise<string> {
+    const filePath = path.join(context.workingDirectory, ...COMPOSITE_ACTION_PATH.split('/'));
+    await fs.mkdir(path.dirname(filePath), { recursive: true });
+    await fs.writeFile(filePath, action.content, 'utf8');
+
+    this.logger.info('Composite action written', {
+      executionId: context.executionId,
+      filePath
+    });
+    return filePath;
+  }
+
+  /**
+   * Write .github/dependabot.yml (--dependabot) or renovate.json (--renovate) at the repository
+   * root for the ecosystems detected in the project and its monorepo packages, returning its
+   * path. A config the repository already has is left alone, with a warning.
+   */
+  private async writeDependencyUpdateConfig(context: ExecutionContext): Promise<string | undefined> {
+    const relativePath = context.options.renovate ? RENOVATE_CONFIG_PATH : DEPENDABOT_CONFIG_PATH;
+    const filePath = path.join(context.workingDirectory, ...relativePath.split('/'));
+    try {
+      await fs.access(filePath);
+      context.warnings.push(`${relativePath} already exists - left unchanged`);
+      return undefined;
+    } catch {
+      // Nothing to preserve
+    }
+
+    const projects = [
+      ...(context.detectionResult ? [{ path: '.', detectionResult: this.convertDetectionResultForGenerator(context.detectionResult, context.parseResult?.data) }] : []),
+      ...(context.projectUnits || []).map(unit => ({ path: unit.path, detectionResult: this.convertDetectionResultForGenerator(unit.detection) }))
+    ];
+    const targets = collectUpdateTargets(projects);
+    await fs.mkdir(path.dirname(filePath), { recursive: true });
+    await fs.writeFile(filePath, context.options.renovate ? renderRenovateConfig(targets) : renderDependabotConfig(targets), 'utf8');
+
+    this.logger.info('Dependency update config written', {
+      executionId: context.executionId,
+      filePath,
+      ecosystems: [...new Set(targets.map(target => target.ecosystem))]
+    });
+    return filePath;
+  }
+
+  /**
+   * Compare the generated workflows with the files in the output directory instead of writing
+   * them (--check). Out-of-date or missing files fail the run with a diff of each of them.
+   */
+  private async executeCheckStep(context: ExecutionContext): Promise<void> {
+    context.progressIndicator?.nextStep();
+
+    context.currentStep = 'output';
+    const stepStartTime = Date.now();
+    const outputDir = this.resolveOutputDirectory(context);
+    const diffs: string[] = [];
+
+    for (const workflow of context.generationResults || []) {
+      const filePath = path.join(outputDir, workflow.filename);
+      let existing: string | undefined;
+      try {
+        existing = await fs.readFile(filePath, 'utf8');
+      } catch {
+        existing = undefined;
+      }
+
+      if (existing === undefined || !workflowsEquivalent(existing, workflow.content)) {
+        const relativePath = path.relative(context.workingDirectory, filePath).split(path.sep).join('/');
+        diffs.push(diffWorkflowFile(relativePath, existing, workflow.content));
+      }
+    }
+
+    context.stepTimes.output = Date.now() - stepStartTime;
+    this.logger.info('Check step completed', {
+      executionId: context.executionId,
+      workflowsChecked: context.generationResults?.length || 0,
+      outOfDate: diffs.length,
+      outputDirectory: outputDir
+    });
+
+    if (diffs.length > 0) {
+      this.addError(
+        context,
+        'WORKFLOWS_OUT_OF_DATE',
+        `Committed workflows are out of date (run generate without --check to update them):\n${diffs.join('\n')}`,
+        'processing'
+      );
+      throw new Error(`${diffs.length} workflow file(s) out of date`);
+    }
+
+    context.progressIndicator?.completeStep();
+  }
+
+  /**
+   * Refuse to write configuration that breaks the provider's basic structural rules, since that
+   * points at a generator bug rather than something the user can fix. --force writes it anyway.
+   */
+  private validateGeneratedWorkflows(context: ExecutionContext, workflows: WorkflowOutput[]): void {
+    const provider = this.resolveProvider(context.options, context.repoConfig, context.existingProvider);
+    const problems = workflows.flatMap(workflow =>
+      validateWorkflowStructure(workflow.content, provider).map(error => `${workflow.filename}: ${error.message}`));
+
+    if (problems.length === 0) {
+      return;
+    }
+
+    if (context.options.force) {
+      context.warnings.push(...problems.map(problem => `Writing invalid configuration because of --force - ${problem}`));
+      return;
+    }
+
+    for (const problem of problems) {
+      this.addError(context, 'INVALID_GENERATED_WORKFLOW', problem, 'processing');
+    }
+    throw new Error(`Generated configuration failed validation and was not written (use --force to write it anyway):\n  ${problems.join('\n  ')}`);
+  }
+
   /**
    * Simulate generation for dry-run mode
    */
@@ -691,10 +1255,13 @@ export class ComponentOrchestrator {
     const buildTools = context.detectionResult.buildTools || [];
 
     // Determine what workflows would be generated
-    const generationOptions = this.createGenerationOptions(context.options);
+    const generationOptions = this.createGenerationOptions(context.options, context.repoConfig, context.defaultBranch, context.existingProvider, context.userWorkflows);
     
     // Convert detection result to generator-expected format
-    const generatorDetectionResult = this.convertDetectionResultForGenerator(context.detectionResult);
+    const generatorDetectionResult = this.applyConfigVersions(
+      this.convertDetectionResultForGenerator(context.detectionResult, context.parseResult?.data),
+      context.repoConfig
+    );
     
     // Phase 3: Add timeout protection for workflow generation
     if (!this.yamlGenerator) {
@@ -722,7 +1289,8 @@ export class ComponentOrchestrator {
       simulatedWorkflows = await this.createFallbackWorkflows(context.detectionResult);
     }
 
-    const outputDir = context.options.outputDir || path.join(context.workingDirectory, '.github', 'workflows');
+    const outputDir = this.resolveOutputDirectory(context);
+    this.printPlanSummary(context, generatorDetectionResult, simulatedWorkflows, generationOptions);
     
     return {
       wouldGenerate: {
@@ -813,15 +1381,24 @@ export class ComponentOrchestrator {
   }
 
   /**
-   * Resolve README file path
+   * Resolve README file path. A directory, or no path at all for the working directory, is
+   * searched for its README (README.md at the root first, then README.rst, README.adoc...
+   * and the docs directories); without one README.md is assumed so the error names it.
    */
-  private resolveReadmePath(options: CLIOptions): string {
-    if (options.readmePath) {
-      return path.resolve(options.readmePath);
+  private async resolveReadmePath(context: ExecutionContext): Promise<string> {
+    const options = context.options;
+    const directory = options.readmePath ? path.resolve(options.readmePath) : context.workingDirectory;
+    if (options.readmePath && !(await fs.stat(directory).then(stats => stats.isDirectory(), () => false))) {
+      return directory;
     }
 
-    // Default to README.md in current directory
-    return path.resolve('README.md');
+    const readme = await findReadme(directory);
+    if (!readme) {
+      return path.join(directory, 'README.md');
+    }
+
+    this.logger.info('Found README', { executionId: context.executionId, path: readme.path, format: readme.format });
+    return readme.path;
   }
 
   /**
@@ -869,7 +1446,7 @@ export class ComponentOrchestrator {
   /**
    * Convert detection result to generator-expected format
    */
-  private convertDetectionResultForGenerator(detectionResult: DetectionResult): any {
+  private convertDetectionResultForGenerator(detectionResult: DetectionResult, parseData: any = {}): any {
     // Safely access arrays with fallbacks
     const frameworks = detectionResult.frameworks || [];
     const buildTools = detectionResult.buildTools || [];
@@ -883,24 +1460,18 @@ export class ComponentOrchestrator {
         evidence: f.evidence?.map(e => e.value || e.source || e.type || 'Evidence found') || [],
         category: this.mapFrameworkTypeToCategory(f.type)
       })),
-      languages: frameworks
-        .filter(f => f.ecosystem)
-        .map(f => ({
-          name: this.mapEcosystemToLanguage(f.ecosystem),
-          version: f.version,
-          confidence: f.confidence,
-          primary: f.confidence > 0.7
-        })),
+      languages: this.extractLanguages(detectionResult),
       buildTools: buildTools.map(bt => ({
         name: bt.name,
         configFile: bt.configFile,
         confidence: bt.confidence
       })),
       packageManagers: detectionResult.buildTools
-        .filter(bt => ['npm', 'yarn', 'pip', 'cargo', 'maven', 'gradle'].includes(bt.name.toLowerCase()))
+        .filter(bt => ['npm', 'yarn', 'pnpm', 'bun', 'pip', 'cargo', 'maven', 'gradle'].includes(bt.name.toLowerCase()))
         .map(bt => ({
           name: bt.name,
           lockFile: bt.configFile,
+          ...(bt.version && { version: bt.version }),
           confidence: bt.confidence
         })),
       testingFrameworks: detectionResult.frameworks
@@ -919,30 +1490,684 @@ export class ComponentOrchestrator {
         name: 'Generated Project',
         description: 'Auto-generated from README analysis',
         version: '1.0.0'
-      }
+      },
+      buildConstraints: this.extractBuildConstraints(buildTools),
+      versionConstraints: this.extractVersionConstraints(detectionResult, parseData),
+      lockFiles: buildTools.filter(bt => bt.lockFile).map(bt => bt.lockFile),
+      dockerImages: this.extractDockerImages(detectionResult),
+      testRunners: this.extractTestRunners(detectionResult),
+      makefile: this.extractMakefile(detectionResult),
+      cargoWorkspace: this.extractCargoWorkspace(detectionResult),
+      nodeWorkspace: this.extractNodeWorkspace(detectionResult),
+      taskGraph: this.extractTaskGraph(detectionResult),
+      staticSite: this.extractStaticSite(detectionResult),
+      javaBuild: this.extractJavaBuild(detectionResult),
+      coverageTools: this.extractCoverageTools(detectionResult),
+      linters: this.extractLinters(detectionResult),
+      pythonLayout: this.extractPythonLayout(detectionResult),
+      goModule: this.extractGoModule(detectionResult),
+      goReleaser: this.extractGoReleaser(detectionResult),
+      terraform: this.extractTerraform(detectionResult),
+      elixirProject: this.extractElixirProject(detectionResult),
+      rubyProject: this.extractRubyProject(detectionResult),
+      swiftPackage: this.extractSwiftPackage(detectionResult),
+      dotnetProject: this.extractDotnetProject(detectionResult),
+      dartProject: this.extractDartProject(detectionResult),
+      haskellProject: this.extractHaskellProject(detectionResult),
+      cmakeProject: this.extractCMakeProject(detectionResult),
+      shellProject: this.extractShellProject(detectionResult),
+      apiSchemas: this.extractApiSchemas(detectionResult),
+      packageScripts: this.extractPackageScripts(detectionResult),
+      preCommit: this.extractPreCommit(detectionResult),
+      workingDirectory: detectionResult.workingDirectory,
+      ciBadges: this.extractCIBadges(parseData),
+      requiredSecrets: this.extractRequiredSecrets(parseData),
+      envExample: this.extractEnvExample(detectionResult),
+      gitCheckout: this.extractGitCheckout(detectionResult),
+      license: this.extractLicense(detectionResult),
+      changelog: this.extractChangelog(detectionResult),
+      codeowners: this.extractCodeowners(detectionResult),
+      protobuf: this.extractProtobuf(detectionResult),
+      generatedCode: this.extractGeneratedCode(detectionResult),
+      systemPackages: this.extractSystemPackages(parseData),
+      services: this.extractServices(detectionResult, parseData)
     };
   }
 
   /**
-   * Map framework type to generator category
+   * Replace detected version constraints with the versions listed in the config file
    */
-  private mapFrameworkTypeToCategory(type: string): 'frontend' | 'backend' | 'fullstack' | 'mobile' | 'desktop' {
-    switch (type) {
-      case 'frontend_framework':
-      case 'static_site_generator':
-        return 'frontend';
-      case 'backend_framework':
-      case 'api_framework':
-      case 'microservice_framework':
-        return 'backend';
-      case 'fullstack_framework':
-        return 'fullstack';
-      case 'mobile_framework':
-        return 'mobile';
-      case 'desktop_framework':
-        return 'desktop';
-      default:
-        return 'backend';
+  private applyConfigVersions(generatorDetectionResult: any, repoConfig?: RepoConfig): any {
+    const versions = Object.entries(repoConfig?.versions || {});
+    if (versions.length === 0) {
+      return generatorDetectionResult;
+    }
+
+    const overridden = new Set(versions.map(([runtime]) => runtime));
+    return {
+      ...generatorDetectionResult,
+      versionConstraints: [
+        ...(generatorDetectionResult.versionConstraints || []).filter((c: any) => !overridden.has(c.runtime)),
+        ...versions.map(([runtime, listed]) => ({
+          runtime,
+          source: '.readme-to-cicd.yml',
+          raw: listed!.join(', '),
+          ...(listed!.length === 1 && { exact: listed![0] }),
+          versions: listed,
+          listed: true
+        }))
+      ]
+    };
+  }
+
+  /**
+   * Extract Dockerfiles the CI workflow builds images from
+   */
+  private extractDockerImages(detectionResult: DetectionResult): any {
+    const images = detectionResult.dockerImages;
+    if (!images || images.length === 0) {
+      return undefined;
+    }
+
+    return images.map(image => ({
+      dockerfile: image.dockerfile,
+      context: image.context,
+      ...(image.baseImage && { baseImage: image.baseImage }),
+      ...(image.targetStage && { target: image.targetStage }),
+      hasDockerignore: image.hasDockerignore
+    }));
+  }
+
+  /**
+   * Extract README badges that report on an existing CI provider
+   */
+  private extractCIBadges(parseData: any): any {
+    const badges = (parseData?.badges || []).filter((badge: any) => badge.provider);
+    if (badges.length === 0) {
+      return undefined;
+    }
+
+    return badges.map((badge: any) => ({
+      provider: badge.provider,
+      imageUrl: badge.imageUrl,
+      ...(badge.targetUrl && { targetUrl: badge.targetUrl })
+    }));
+  }
+
+  /**
+   * Extract the test runners each test job invokes
+   */
+  private extractTestRunners(detectionResult: DetectionResult): any {
+    const runners = detectionResult.testRunners;
+    if (!runners || runners.length === 0) {
+      return undefined;
+    }
+
+    return runners.map(runner => ({
+      name: runner.name,
+      language: runner.language,
+      command: runner.command,
+      ...(runner.setup.length > 0 && { setup: runner.setup }),
+      ...(runner.suppressedBy && { suppressedBy: runner.suppressedBy }),
+      source: runner.source
+    }));
+  }
+
+  /**
+   * Extract the Makefile targets CI steps can invoke
+   */
+  private extractMakefile(detectionResult: DetectionResult): any {
+    const makefile = detectionResult.makefile;
+    if (!makefile || makefile.targets.length === 0) {
+      return undefined;
+    }
+
+    return {
+      file: makefile.file,
+      targets: makefile.targets
+    };
+  }
+
+  /**
+   * Extract the Cargo workspace crates CI builds and tests
+   */
+  private extractCargoWorkspace(detectionResult: DetectionResult): any {
+    const workspace = detectionResult.cargoWorkspace;
+    if (!workspace || workspace.members.length === 0) {
+      return undefined;
+    }
+
+    return {
+      members: workspace.members.map(member => ({ name: member.name, path: member.path })),
+      features: workspace.features
+    };
+  }
+
+  /**
+   * Extract the npm or pnpm workspace packages CI builds and tests
+   */
+  private extractNodeWorkspace(detectionResult: DetectionResult): any {
+    const workspace = detectionResult.nodeWorkspace;
+    if (!workspace || workspace.members.length === 0) {
+      return undefined;
+    }
+
+    return {
+      manager: workspace.manager,
+      members: workspace.members.map(member => ({ name: member.name, path: member.path, scripts: member.scripts }))
+    };
+  }
+
+  /**
+   * Extract the Nx or Turborepo tasks the affected job runs
+   */
+  private extractTaskGraph(detectionResult: DetectionResult): any {
+    const taskGraph = detectionResult.taskGraph;
+    return taskGraph ? { tool: taskGraph.tool, configFile: taskGraph.configFile, tasks: [...taskGraph.tasks] } : undefined;
+  }
+
+  /**
+   * Extract the secrets the README asks users to set
+   */
+  private extractRequiredSecrets(parseData: any): any {
+    const secrets = parseData?.requiredSecrets || [];
+    if (secrets.length === 0) {
+      return undefined;
+    }
+
+    return secrets.map((secret: any) => ({ name: secret.name }));
+  }
+
+  /**
+   * Extract the example environment file the job environment is pre-populated from
+   */
+  private extractEnvExample(detectionResult: DetectionResult): any {
+    const envExample = detectionResult.envExample;
+    return envExample
+      ? {
+        file: envExample.file,
+        variables: envExample.variables.map(variable => ({
+          name: variable.name,
+          ...(variable.value !== undefined && { value: variable.value }),
+          secret: variable.secret
+        }))
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the submodules and git-versioning tools the checkout steps fetch for
+   */
+  private extractGitCheckout(detectionResult: DetectionResult): any {
+    const gitCheckout = detectionResult.gitCheckout;
+    return gitCheckout
+      ? {
+        submodules: gitCheckout.submodules.map(submodule => ({ name: submodule.name, path: submodule.path, url: submodule.url })),
+        versionFromGit: [...gitCheckout.versionFromGit]
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the project's own license the license check allows
+   */
+  private extractLicense(detectionResult: DetectionResult): any {
+    const license = detectionResult.license;
+    return license
+      ? {
+        ...(license.spdxId && { spdxId: license.spdxId }),
+        ...(license.file && { file: license.file }),
+        source: license.source
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the changelog the changelog check requires pull requests to change
+   */
+  private extractChangelog(detectionResult: DetectionResult): any {
+    return detectionResult.changelog ? { file: detectionResult.changelog.file } : undefined;
+  }
+
+  /**
+   * Extract the CODEOWNERS rules the codeowners job matches pull request changes against
+   */
+  private extractCodeowners(detectionResult: DetectionResult): any {
+    const codeowners = detectionResult.codeowners;
+    return codeowners
+      ? {
+        file: codeowners.file,
+        rules: codeowners.rules.map(rule => ({ pattern: rule.pattern, globs: [...rule.globs], owners: [...rule.owners] }))
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the generated code the lint steps leave out
+   */
+  private extractGeneratedCode(detectionResult: DetectionResult): any {
+    const generatedCode = detectionResult.generatedCode;
+    return generatedCode
+      ? {
+        goFiles: [...generatedCode.goFiles],
+        goDirectories: [...generatedCode.goDirectories],
+        bundleDirectories: [...generatedCode.bundleDirectories]
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the .proto files and buf configuration the buf job lints and regenerates
+   */
+  private extractProtobuf(detectionResult: DetectionResult): any {
+    const protobuf = detectionResult.protobuf;
+    return protobuf
+      ? {
+        protoFiles: [...protobuf.protoFiles],
+        bufDirectory: protobuf.bufDirectory,
+        ...(protobuf.bufConfig && { bufConfig: protobuf.bufConfig }),
+        ...(protobuf.bufGenConfig && { bufGenConfig: protobuf.bufGenConfig })
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the system packages listed in the README's prerequisites section
+   */
+  private extractSystemPackages(parseData?: any): any {
+    const packages = (parseData?.prerequisites || []).filter((prerequisite: any) => prerequisite.kind === 'system-package');
+    if (packages.length === 0) {
+      return undefined;
+    }
+
+    return packages.map((prerequisite: any) => prerequisite.name);
+  }
+
+  /**
+   * Extract the services of the project's compose file, then those listed in the README's prerequisites section
+   */
+  private extractServices(detectionResult: DetectionResult, parseData?: any): any {
+    const services = (detectionResult.services || []).map(service => ({
+      name: service.name,
+      ...(service.version && { version: service.version }),
+      ...(service.image && { image: service.image }),
+      ...(Object.keys(service.env).length > 0 && { env: service.env })
+    }));
+    for (const prerequisite of parseData?.prerequisites || []) {
+      if (prerequisite.kind === 'service' && !services.some(service => service.name === prerequisite.name)) {
+        services.push({ name: prerequisite.name, ...(prerequisite.version && { version: prerequisite.version }) });
+      }
+    }
+
+    return services.length > 0 ? services : undefined;
+  }
+
+  /**
+   * Extract the static site generator a GitHub Pages job can deploy
+   */
+  private extractStaticSite(detectionResult: DetectionResult): any {
+    const staticSite = detectionResult.staticSite;
+    if (!staticSite) {
+      return undefined;
+    }
+
+    return {
+      generator: staticSite.generator,
+      configFile: staticSite.configFile,
+      outputDir: staticSite.outputDir
+    };
+  }
+
+  /**
+   * Extract the Maven or Gradle build the Java steps run
+   */
+  private extractJavaBuild(detectionResult: DetectionResult): any {
+    const javaBuild = detectionResult.javaBuild;
+    if (!javaBuild) {
+      return undefined;
+    }
+
+    return {
+      buildSystem: javaBuild.buildSystem,
+      ...(javaBuild.javaVersion && { javaVersion: javaBuild.javaVersion }),
+      wrapper: javaBuild.wrapper,
+      modules: javaBuild.modules
+    };
+  }
+
+  private extractCoverageTools(detectionResult: DetectionResult): any {
+    const coverageTools = detectionResult.coverageTools;
+    if (!coverageTools || coverageTools.length === 0) {
+      return undefined;
+    }
+
+    return coverageTools.map(coverage => ({
+      tool: coverage.tool,
+      language: coverage.language,
+      ...(coverage.reportFile && { reportFile: coverage.reportFile })
+    }));
+  }
+
+  /**
+   * Configured linters, in the order the lint job runs them
+   */
+  private extractLinters(detectionResult: DetectionResult): any {
+    const linters = detectionResult.linters;
+    if (!linters || linters.length === 0) {
+      return undefined;
+    }
+
+    return linters.map(linter => ({
+      name: linter.name,
+      language: linter.language,
+      command: linter.command,
+      installed: linter.installed,
+      source: linter.source
+    }));
+  }
+
+  /**
+   * Languages found on disk, primary first; without a file system scan, the ecosystems of the
+   * detected frameworks
+   */
+  private extractLanguages(detectionResult: DetectionResult): any[] {
+    if (detectionResult.languages && detectionResult.languages.length > 0) {
+      return detectionResult.languages.map(language => ({
+        name: language.name,
+        confidence: language.share,
+        primary: language.primary,
+        ...(language.directory !== '.' && { directory: language.directory })
+      }));
+    }
+
+    return (detectionResult.frameworks || [])
+      .filter(f => f.ecosystem)
+      .map(f => ({
+        name: this.mapEcosystemToLanguage(f.ecosystem),
+        version: f.version,
+        confidence: f.confidence,
+        primary: f.confidence > 0.7
+      }));
+  }
+
+  private extractPythonLayout(detectionResult: DetectionResult): any {
+    const pythonLayout = detectionResult.pythonLayout;
+    if (!pythonLayout) {
+      return undefined;
+    }
+
+    return {
+      layout: pythonLayout.layout,
+      packages: pythonLayout.packages,
+      namespacePackages: pythonLayout.namespacePackages,
+      installable: pythonLayout.installable,
+      requirementsFile: pythonLayout.requirementsFile
+    };
+  }
+
+  /**
+   * Extract the module path and requirements of the root go.mod
+   */
+  private extractGoModule(detectionResult: DetectionResult): any {
+    const goModule = detectionResult.goModule;
+    if (!goModule) {
+      return undefined;
+    }
+
+    return {
+      path: goModule.path,
+      requires: goModule.requires
+    };
+  }
+
+  /**
+   * Extract the GoReleaser config tag releases run
+   */
+  private extractGoReleaser(detectionResult: DetectionResult): any {
+    const goReleaser = detectionResult.goReleaser;
+    return goReleaser ? { file: goReleaser.file } : undefined;
+  }
+
+  /**
+   * Extract the package.json scripts Node jobs run
+   */
+  private extractPackageScripts(detectionResult: DetectionResult): any {
+    const packageScripts = detectionResult.packageScripts;
+    return packageScripts ? { scripts: { ...packageScripts.scripts } } : undefined;
+  }
+
+  /**
+   * Extract the Terraform root modules the terraform job checks
+   */
+  private extractTerraform(detectionResult: DetectionResult): any {
+    const terraform = detectionResult.terraform;
+    return terraform
+      ? { directories: terraform.directories, ...(terraform.requiredVersion && { requiredVersion: terraform.requiredVersion }) }
+      : undefined;
+  }
+
+  /**
+   * Extract the Mix project the BEAM setup and the Phoenix database steps read
+   */
+  private extractElixirProject(detectionResult: DetectionResult): any {
+    const elixirProject = detectionResult.elixirProject;
+    return elixirProject
+      ? {
+        ...(elixirProject.elixirVersion && { elixirVersion: elixirProject.elixirVersion }),
+        ...(elixirProject.otpVersion && { otpVersion: elixirProject.otpVersion }),
+        phoenix: elixirProject.phoenix,
+        postgres: elixirProject.postgres
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the Bundler project the Ruby setup, test, lint and Rails database steps read
+   */
+  private extractRubyProject(detectionResult: DetectionResult): any {
+    const rubyProject = detectionResult.rubyProject;
+    return rubyProject
+      ? {
+        ...(rubyProject.rubyVersion && { rubyVersion: rubyProject.rubyVersion }),
+        ...(rubyProject.gemspec && { gemspec: rubyProject.gemspec }),
+        rspec: rubyProject.rspec,
+        rake: rubyProject.rake,
+        rubocop: rubyProject.rubocop,
+        rails: rubyProject.rails,
+        ...(rubyProject.database && { database: rubyProject.database })
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the Swift package the Swift setup, build and test steps read
+   */
+  private extractSwiftPackage(detectionResult: DetectionResult): any {
+    const swiftPackage = detectionResult.swiftPackage;
+    return swiftPackage
+      ? {
+        ...(swiftPackage.toolsVersion && { toolsVersion: swiftPackage.toolsVersion }),
+        executables: [...swiftPackage.executables],
+        libraries: [...swiftPackage.libraries],
+        testTargets: [...swiftPackage.testTargets]
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the .NET project the dotnet setup, restore, build and test steps read
+   */
+  private extractDotnetProject(detectionResult: DetectionResult): any {
+    const dotnetProject = detectionResult.dotnetProject;
+    return dotnetProject
+      ? {
+        ...(dotnetProject.solution && { solution: dotnetProject.solution }),
+        projects: [...dotnetProject.projects],
+        testProjects: [...dotnetProject.testProjects],
+        targetFrameworks: [...dotnetProject.targetFrameworks],
+        ...(dotnetProject.sdkVersion && { sdkVersion: dotnetProject.sdkVersion }),
+        lockFile: dotnetProject.lockFile
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the Dart project the Dart or Flutter setup, analyze, test and build steps read
+   */
+  private extractDartProject(detectionResult: DetectionResult): any {
+    const dartProject = detectionResult.dartProject;
+    return dartProject
+      ? {
+        ...(dartProject.name && { name: dartProject.name }),
+        flutter: dartProject.flutter,
+        ...(dartProject.sdkConstraint && { sdkConstraint: dartProject.sdkConstraint }),
+        ...(dartProject.flutterConstraint && { flutterConstraint: dartProject.flutterConstraint }),
+        platforms: [...dartProject.platforms],
+        tests: dartProject.tests
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the Haskell project the Stack or Cabal setup, cache, build, test and HLint steps read
+   */
+  private extractHaskellProject(detectionResult: DetectionResult): any {
+    const haskellProject = detectionResult.haskellProject;
+    return haskellProject
+      ? {
+        tool: haskellProject.tool,
+        ...(haskellProject.resolver && { resolver: haskellProject.resolver }),
+        ...(haskellProject.ghcVersion && { ghcVersion: haskellProject.ghcVersion }),
+        cabalFiles: [...haskellProject.cabalFiles],
+        tests: haskellProject.tests,
+        hlint: haskellProject.hlint
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the CMake project the C/C++ configure, build and ctest steps read
+   */
+  private extractCMakeProject(detectionResult: DetectionResult): any {
+    const cmakeProject = detectionResult.cmakeProject;
+    return cmakeProject
+      ? {
+        ...(cmakeProject.minimumVersion && { minimumVersion: cmakeProject.minimumVersion }),
+        ...(cmakeProject.cxxStandard && { cxxStandard: cmakeProject.cxxStandard }),
+        tests: cmakeProject.tests,
+        ...(cmakeProject.presets && { presets: { ...cmakeProject.presets } }),
+        binaryDir: cmakeProject.binaryDir,
+        clangFormat: cmakeProject.clangFormat
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the shell scripts the shellcheck and shfmt steps check
+   */
+  private extractShellProject(detectionResult: DetectionResult): any {
+    const shellProject = detectionResult.shellProject;
+    return shellProject
+      ? { scripts: [...shellProject.scripts], batsTests: [...shellProject.batsTests], shfmt: shellProject.shfmt }
+      : undefined;
+  }
+
+  /**
+   * Extract the OpenAPI documents and GraphQL schemas the api-lint job lints
+   */
+  private extractApiSchemas(detectionResult: DetectionResult): any {
+    const apiSchemas = detectionResult.apiSchemas;
+    return apiSchemas
+      ? {
+        openapi: [...apiSchemas.openapi],
+        graphql: [...apiSchemas.graphql],
+        ...(apiSchemas.spectralRuleset && { spectralRuleset: apiSchemas.spectralRuleset })
+      }
+      : undefined;
+  }
+
+  /**
+   * Extract the pre-commit config the pre-commit job runs
+   */
+  private extractPreCommit(detectionResult: DetectionResult): any {
+    const preCommit = detectionResult.preCommit;
+    if (!preCommit) {
+      return undefined;
+    }
+
+    return {
+      file: preCommit.file,
+      hooks: preCommit.hooks
+    };
+  }
+
+  /**
+   * Extract Go build constraints attached to the go build tool
+   */
+  private extractBuildConstraints(buildTools: any[]): any {
+    const constraints = buildTools.find(bt => bt.name === 'go')?.config?.buildConstraints;
+    if (!constraints) {
+      return undefined;
+    }
+
+    return {
+      platforms: constraints.platforms || [],
+      tags: constraints.tags || [],
+      testTags: constraints.testTags || []
+    };
+  }
+
+  /**
+   * Extract usable language version constraints read from project manifests. Versions the README
+   * lists as tested are more specific than a manifest range and replace it; a manifest pinning
+   * a single release still wins.
+   */
+  private extractVersionConstraints(detectionResult: DetectionResult, parseData?: any): any {
+    const constraints: any[] = (detectionResult.versionConstraints || []).map(c => ({
+      runtime: c.runtime,
+      source: c.source,
+      raw: c.raw,
+      ...(c.exact && { exact: c.exact }),
+      versions: c.versions
+    }));
+
+    const readme = parseData?.readme?.path ? path.basename(parseData.readme.path) : 'README.md';
+    for (const tested of parseData?.testedVersions || []) {
+      const index = constraints.findIndex(c => c.runtime === tested.runtime);
+      if (index >= 0 && constraints[index].exact) {
+        continue;
+      }
+      const documented = { runtime: tested.runtime, source: readme, raw: tested.versions.join(', '), versions: tested.versions, listed: true };
+      if (index >= 0) {
+        constraints[index] = documented;
+      } else {
+        constraints.push(documented);
+      }
+    }
+
+    return constraints.length > 0 ? constraints : undefined;
+  }
+
+  /**
+   * Map framework type to generator category
+   */
+  private mapFrameworkTypeToCategory(type: string): 'frontend' | 'backend' | 'fullstack' | 'mobile' | 'desktop' {
+    switch (type) {
+      case 'frontend_framework':
+      case 'static_site_generator':
+        return 'frontend';
+      case 'backend_framework':
+      case 'api_framework':
+      case 'microservice_framework':
+        return 'backend';
+      case 'fullstack_framework':
+        return 'fullstack';
+      case 'mobile_framework':
+        return 'mobile';
+      case 'desktop_framework':
+        return 'desktop';
+      default:
+        return 'backend';
     }
   }
 
@@ -971,13 +2196,79 @@ export class ComponentOrchestrator {
   /**
    * Create generation options from CLI options
    */
-  private createGenerationOptions(cliOptions: CLIOptions): GenerationOptions {
+  private createGenerationOptions(cliOptions: CLIOptions, repoConfig?: RepoConfig, defaultBranch?: string, existingProvider?: Provider, userWorkflows?: string[]): GenerationOptions {
     return {
       workflowType: cliOptions.workflowType?.[0] || 'ci',
       optimizationLevel: 'standard',
       includeComments: true,
       securityLevel: 'standard',
       agentHooksEnabled: false,
+      provider: this.resolveProvider(cliOptions, repoConfig, existingProvider),
+      ...(cliOptions.circleciOrbs && { useOrbs: true }),
+      ...(cliOptions.monorepo && { monorepoLayout: cliOptions.monorepo }),
+      ...(cliOptions.changedFiles && { monorepoChangeDetection: cliOptions.changedFiles }),
+      ...(cliOptions.registry && { containerRegistry: cliOptions.registry }),
+      ...(cliOptions.testRunners && { testRunnerLayout: cliOptions.testRunners }),
+      ...(cliOptions.existingCi && { existingCI: cliOptions.existingCi }),
+      ...(cliOptions.preCommit && { preCommit: cliOptions.preCommit }),
+      ...(cliOptions.terraform && { terraform: true }),
+      ...(cliOptions.licenseCheck && { licenseCheck: true }),
+      ...(cliOptions.changelogCheck && { changelogCheck: true }),
+      ...(cliOptions.codeownersComment && { codeownersComment: true }),
+      ...(cliOptions.apiLint && { apiLint: true }),
+      ...(cliOptions.protobuf && { protobuf: true }),
+      ...(cliOptions.pinActions && { pinActions: true, resolveActionSha: this.createActionShaResolver((cliOptions.timeout || 20) * 1000) }),
+      ...(cliOptions.phoenixDb && { phoenixDatabase: true }),
+      ...(cliOptions.cxxMatrix && { compilerMatrix: true }),
+      ...(cliOptions.swiftLinux && { swiftLinux: true }),
+      ...(cliOptions.flutterBuild && { flutterBuild: true }),
+      ...(cliOptions.provenance && { provenance: true }),
+      ...(cliOptions.minimal && { minimal: true }),
+      ...(cliOptions.makeCi && { makeCI: true }),
+      ...(cliOptions.rustWorkspace && { cargoWorkspaceLayout: cliOptions.rustWorkspace }),
+      ...(cliOptions.nodeWorkspace && { nodeWorkspaceLayout: cliOptions.nodeWorkspace }),
+      ...(cliOptions.cargoFeatureMatrix && { cargoFeatureMatrix: true }),
+      ...(cliOptions.deployPages && { deployPages: true }),
+      ...(cliOptions.pagesDir && { pagesOutputDir: cliOptions.pagesDir }),
+      ...(cliOptions.preset && { preset: cliOptions.preset as GenerationPreset }),
+      ...(cliOptions.coverage && { coverage: cliOptions.coverage }),
+      ...(defaultBranch && { defaultBranch }),
+      ...(cliOptions.cancelInProgress === false && { cancelInProgress: false }),
+      ...(cliOptions.permissions === false && { permissions: false }),
+      ...(cliOptions.schedule && { schedule: cliOptions.schedule }),
+      ...(cliOptions.reusable && { reusable: true }),
+      ...(cliOptions.split && { split: cliOptions.split }),
+      ...(cliOptions.splitSetup && { splitSetup: cliOptions.splitSetup }),
+      ...(userWorkflows && userWorkflows.length > 0 && { userWorkflows }),
+      ...(cliOptions.release && { tagRelease: true }),
+      ...(cliOptions.releaseTagPrefix !== undefined && { releaseTagPrefix: cliOptions.releaseTagPrefix }),
+      ...(cliOptions.artifacts && { artifacts: true }),
+      ...(cliOptions.artifactPath && { artifactPath: cliOptions.artifactPath }),
+      ...(cliOptions.artifactName && { artifactName: cliOptions.artifactName }),
+      ...(cliOptions.artifactRetentionDays && { artifactRetentionDays: cliOptions.artifactRetentionDays }),
+      ...(cliOptions.testRetries && { testRetries: cliOptions.testRetries }),
+      ...(cliOptions.jobTimeout && { jobTimeout: cliOptions.jobTimeout }),
+      ...(repoConfig?.runnerOS && { runnerOS: repoConfig.runnerOS }),
+      ...((cliOptions.runnerLabels || repoConfig?.runnerLabels) && { runnerLabels: cliOptions.runnerLabels || repoConfig?.runnerLabels }),
+      ...((cliOptions.runnerImages || repoConfig?.runnerImages) && { runnerImages: { ...repoConfig?.runnerImages, ...cliOptions.runnerImages } }),
+      ...(cliOptions.os && { testOS: cliOptions.os }),
+      ...(repoConfig?.matrixExclude && { matrixExclude: repoConfig.matrixExclude }),
+      ...(repoConfig?.matrixInclude && { matrixInclude: repoConfig.matrixInclude }),
+      ...(repoConfig?.disabledJobs && { disabledJobs: repoConfig.disabledJobs }),
+      ...(repoConfig?.env && { jobEnv: repoConfig.env }),
+      ...(repoConfig?.envExampleExclude && { envExampleExclude: repoConfig.envExampleExclude }),
+      ...(repoConfig?.licenseAllowlist && { licenseAllowlist: repoConfig.licenseAllowlist }),
+      ...(repoConfig?.changelogSkipLabel && { changelogSkipLabel: repoConfig.changelogSkipLabel }),
+      ...(repoConfig?.bufBreakingBranch && { bufBreakingBranch: repoConfig.bufBreakingBranch }),
+      ...(repoConfig?.deployEnvironment && { deployEnvironment: repoConfig.deployEnvironment }),
+      ...(repoConfig?.actionVersions && { actionVersions: repoConfig.actionVersions }),
+      ...(repoConfig?.submodules !== undefined && { checkoutSubmodules: repoConfig.submodules }),
+      ...(repoConfig?.fetchDepth !== undefined && { checkoutFetchDepth: repoConfig.fetchDepth }),
+      ...(repoConfig?.injectSteps && { injectSteps: repoConfig.injectSteps }),
+      ...((cliOptions.privateModules || repoConfig?.privateModules) && { privateModules: cliOptions.privateModules || repoConfig?.privateModules }),
+      ...((cliOptions.privateModulesSecret || repoConfig?.privateModulesSecret) && {
+        privateModulesSecret: cliOptions.privateModulesSecret || repoConfig?.privateModulesSecret
+      }),
       environmentManagement: {
         includeSecretValidation: true,
         includeOIDC: true,
@@ -988,6 +2279,110 @@ export class ComponentOrchestrator {
     };
   }
 
+  /**
+   * Resolve action refs to commit SHAs with git ls-remote, each once per run. Remotes still
+   * unreachable timeoutMs from now are not retried.
+   */
+  private createActionShaResolver(timeoutMs: number): ActionShaResolver {
+    const git = new GitIntegration(this.logger, process.cwd(), { deadline: Date.now() + timeoutMs });
+    const shas = new Map<string, Promise<string | undefined>>();
+    return (repository, ref) => {
+      const reference = `${repository}@${ref}`;
+      if (!shas.has(reference)) {
+        shas.set(reference, git.resolveRemoteRef(repository, ref));
+      }
+      return shas.get(reference)!;
+    };
+  }
+
+  /**
+   * Map the CLI provider name onto the generator provider. The config file's provider, else
+   * the provider the repository already has configuration for, replaces the github default;
+   * a different --provider flag still wins over them.
+   */
+  private resolveProvider(cliOptions: CLIOptions, repoConfig?: RepoConfig, existingProvider?: Provider): Provider {
+    const provider = cliOptions.provider && cliOptions.provider !== 'github'
+      ? cliOptions.provider
+      : repoConfig?.provider || existingProvider || cliOptions.provider;
+
+    switch (provider) {
+      case 'gitlab':
+        return Provider.GitLab;
+      case 'circleci':
+        return Provider.CircleCI;
+      case 'azure':
+        return Provider.AzurePipelines;
+      case 'bitbucket':
+        return Provider.BitbucketPipelines;
+      case 'jenkins':
+        return Provider.Jenkins;
+      default:
+        return Provider.GitHubActions;
+    }
+  }
+
+  /**
+   * Determine which workflow types to generate for the selected provider,
+   * leaving out the CI workflow when the README shows existing CI and skipping was requested
+   */
+  private resolveWorkflowTypes(
+    context: ExecutionContext,
+    generationOptions: GenerationOptions,
+    generatorDetectionResult: any
+  ): WorkflowType[] {
+    let types = context.options.workflowType || [];
+
+    if (generationOptions.provider && SINGLE_PIPELINE_PROVIDERS.includes(generationOptions.provider)) {
+      const unsupported = types.filter(type => type !== 'ci');
+      if (unsupported.length > 0) {
+        context.warnings.push(`The ${generationOptions.provider} provider only generates a CI pipeline; skipping ${unsupported.join(', ')} workflows`);
+      }
+      types = ['ci'];
+    }
+
+    // Lint and test presets only select CI jobs
+    if (generationOptions.preset && generationOptions.preset !== GenerationPreset.Full) {
+      const unsupported = types.filter(type => type !== 'ci');
+      if (unsupported.length > 0) {
+        context.warnings.push(`The ${generationOptions.preset} preset only generates the ci workflow; skipping ${unsupported.join(', ')} workflows`);
+      }
+      types = ['ci'];
+    }
+
+    const existingCI = getExistingCIProviders(generatorDetectionResult);
+    if (generationOptions.existingCI === 'skip' && existingCI.length > 0 && types.includes('ci')) {
+      context.warnings.push(`An existing CI badge for ${existingCI.join(', ')} was found in the README; skipping the ci workflow`);
+      types = types.filter(type => type !== 'ci');
+      if (types.length === 0) {
+        throw new Error('Nothing to generate: the ci workflow was skipped because the README shows existing CI');
+      }
+    }
+
+    return types;
+  }
+
+  /**
+   * Determine the output directory for generated files.
+   * GitLab, Azure Pipelines, Bitbucket Pipelines and Jenkins read their pipeline from the repository
+   * root and CircleCI reads .circleci/config.yml, so the GitHub default is ignored for all of them.
+   */
+  private resolveOutputDirectory(context: ExecutionContext): string {
+    const outputDir = context.options.outputDir;
+    const customOutputDir = outputDir && outputDir !== GITHUB_WORKFLOWS_DIRECTORY ? outputDir : undefined;
+
+    const provider = this.resolveProvider(context.options, context.repoConfig, context.existingProvider);
+
+    if ([Provider.GitLab, Provider.AzurePipelines, Provider.BitbucketPipelines, Provider.Jenkins].includes(provider)) {
+      return customOutputDir || context.workingDirectory;
+    }
+
+    if (provider === Provider.CircleCI) {
+      return customOutputDir || path.join(context.workingDirectory, CIRCLECI_CONFIG_DIRECTORY);
+    }
+
+    return outputDir || path.join(context.workingDirectory, ...GITHUB_WORKFLOWS_DIRECTORY.split('/'));
+  }
+
   /**
    * Add error to execution context
    */
@@ -1022,7 +2417,8 @@ export class ComponentOrchestrator {
       optimizationsApplied: context.generationResults?.reduce((sum, w) => sum + w.metadata.optimizations.length, 0) || 0,
       executionTime: context.totalExecutionTime || 0,
       filesProcessed: 1,
-      workflowsGenerated: context.generationResults?.length || 0
+      workflowsGenerated: context.generationResults?.length || 0,
+      ...(context.parseResult?.data?.readme && { readmePath: context.parseResult.data.readme.path })
     };
 
     return {
@@ -1030,10 +2426,29 @@ export class ComponentOrchestrator {
       generatedFiles,
       errors: context.errors,
       warnings: context.warnings,
+      ...this.diagnosticsOf(context),
       summary
     };
   }
 
+  /**
+   * The diagnostics of the repository and of each monorepo package, whose paths are made
+   * repository-relative; none when detection found nothing to report
+   */
+  private diagnosticsOf(context: ExecutionContext): { diagnostics?: Diagnostic[] } {
+    const diagnostics = [...(context.detectionResult?.diagnostics || [])];
+    for (const unit of context.projectUnits || []) {
+      for (const diagnostic of unit.detection.diagnostics || []) {
+        const packaged = diagnostic.path ? { ...diagnostic, path: path.posix.join(unit.path, diagnostic.path) } : diagnostic;
+        // The root package was detected along with the repository
+        if (!diagnostics.some(found => found.code === packaged.code && found.path === packaged.path && found.message === packaged.message)) {
+          diagnostics.push(packaged);
+        }
+      }
+    }
+    return diagnostics.length > 0 ? { diagnostics: sortDiagnostics(diagnostics) } : {};
+  }
+
   /**
    * Create error result
    */
@@ -1064,6 +2479,7 @@ export class ComponentOrchestrator {
       generatedFiles: [],
       errors: context.errors,
       warnings: context.warnings,
+      ...this.diagnosticsOf(context),
       summary
     };
   }
@@ -1165,6 +2581,38 @@ export class ComponentOrchestrator {
     return fallbackResult;
   }
 
+  /**
+   * Leave out workflows a generation preset left without jobs, keeping their warnings
+   */
+  private dropEmptyWorkflows(context: ExecutionContext, workflows: WorkflowOutput[]): WorkflowOutput[] {
+    const empty = workflows.filter(workflow => workflow.content === '');
+    if (empty.length === 0) {
+      return workflows;
+    }
+
+    for (const workflow of empty) {
+      context.warnings.push(...workflow.metadata.warnings);
+    }
+    context.skippedWorkflows = empty.map(workflow => workflow.filename);
+
+    return workflows.filter(workflow => workflow.content !== '');
+  }
+
+  /**
+   * Tell the operator what was detected and which workflows and jobs are about to be written,
+   * unless --quiet
+   */
+  private printPlanSummary(context: ExecutionContext, detectionResult: any, workflows: WorkflowOutput[], options: GenerationOptions): void {
+    if (context.options.quiet) {
+      return;
+    }
+    const outputDirectory = path.relative(context.workingDirectory, this.resolveOutputDirectory(context)).split(path.sep).join('/');
+    console.log(planSummary(detectionResult, workflows, options, {
+      outputDirectory: outputDirectory || '.',
+      ...(context.skippedWorkflows && { skippedWorkflows: context.skippedWorkflows })
+    }));
+  }
+
   /**
    * Check if generated workflows have valid, meaningful content
    */
@@ -0,0 +1,33 @@
+/**
+ * Diagnostic Formatter
+ *
+ * Renders the diagnostics of a detection run for the terminal, apart from the logs.
+ */
+
+import chalk from 'chalk';
+import { Diagnostic, DiagnosticSeverity, DIAGNOSTIC_SEVERITY_ORDER } from '../../detection/interfaces/diagnostic';
+
+const SEVERITY_COLORS: Record<DiagnosticSeverity, (text: string) => string> = {
+  error: chalk.red,
+  warning: chalk.yellow,
+  info: chalk.cyan
+};
+
+/**
+ * Diagnostics one per line, with their severity, code and path, followed by a count per severity
+ */
+export function formatDiagnostics(diagnostics: Diagnostic[]): string {
+  const width = Math.max(...diagnostics.map(diagnostic => diagnostic.severity.length));
+  const lines = diagnostics.map(diagnostic => [
+    `  ${SEVERITY_COLORS[diagnostic.severity](diagnostic.severity.padEnd(width))}`,
+    chalk.bold(diagnostic.code),
+    `${diagnostic.path ? chalk.gray(`${diagnostic.path}: `) : ''}${diagnostic.message}`
+  ].join(' '));
+
+  const counts = DIAGNOSTIC_SEVERITY_ORDER
+    .map(severity => ({ severity, count: diagnostics.filter(diagnostic => diagnostic.severity === severity).length }))
+    .filter(({ count }) => count > 0)
+    .map(({ severity, count }) => `${count} ${severity}${count === 1 || severity === 'info' ? '' : 's'}`);
+
+  return [chalk.bold('Detection diagnostics:'), ...lines, `  ${counts.join(', ')}`].join('\n');
+}
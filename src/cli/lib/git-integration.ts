@@ -1,13 +1,21 @@
 import { exec } from 'child_process';
 import { promisify } from 'util';
 import { existsSync } from 'fs';
+import { readFile } from 'fs/promises';
 import { join } from 'path';
 import chalk from 'chalk';
 import { CLIError, GitConfig } from './types';
 import { Logger } from './logger';
+import { ErrorRecovery, RetryConfig } from '../../detection/errors/error-recovery';
 
 const execAsync = promisify(exec);
 
+/**
+ * Failures of git talking to a remote that may not happen again: the command timing out, the
+ * host not resolving or the connection dropping, and server errors
+ */
+const TRANSIENT_REMOTE_ERROR = /timed out|could not resolve host|connection (reset|refused)|early EOF|unexpected disconnect|returned error: 5\d\d|RPC failed/i;
+
 /**
  * Git Integration System for CLI Tool
  * 
@@ -16,9 +24,14 @@ const execAsync = promisify(exec);
  * detection as specified in requirements 7.1-7.5.
  */
 export class GitIntegration {
+  /**
+   * @param retry How commands reaching a remote are retried when they fail transiently:
+   * attempts, backoff and a deadline; by default three attempts
+   */
   constructor(
     private readonly logger: Logger,
-    private readonly workingDirectory: string = process.cwd()
+    private readonly workingDirectory: string = process.cwd(),
+    private readonly retry: Partial<RetryConfig> = {}
   ) {}
 
   /**
@@ -68,6 +81,88 @@ export class GitIntegration {
     }
   }
 
+  /**
+   * Get the repository's default branch: the branch origin/HEAD points at, otherwise the
+   * branch checked out locally. Returns undefined without git metadata (e.g. an exported tarball).
+   */
+  async getDefaultBranch(): Promise<string | undefined> {
+    for (const ref of ['refs/remotes/origin/HEAD', 'HEAD']) {
+      try {
+        const branch = (await this.executeGitCommand(`symbolic-ref --short ${ref}`)).stdout.trim().replace(/^origin\//, '');
+        if (branch) {
+          return branch;
+        }
+      } catch {
+        // Not set, detached, or not a repository; try the next source
+      }
+    }
+
+    // git itself may be missing while the metadata is there
+    try {
+      const head = await readFile(join(this.workingDirectory, '.git', 'HEAD'), 'utf-8');
+      return head.match(/^ref: refs\/heads\/(\S+)/)?.[1];
+    } catch {
+      return undefined;
+    }
+  }
+
+  /**
+   * Commit SHA a tag or branch of a GitHub repository (owner/name) points at, from git ls-remote.
+   * Annotated tags resolve to the commit they tag. Returns undefined when the ref does not exist
+   * or the remote cannot be reached; transient failures are retried first, and a warning is
+   * logged when they persist.
+   */
+  async resolveRemoteRef(repository: string, ref: string): Promise<string | undefined> {
+    const command = `ls-remote https://github.com/${repository}.git refs/tags/${ref} refs/tags/${ref}^{} refs/heads/${ref}`;
+    const transient = (error: unknown) => (error as any)?.killed || TRANSIENT_REMOTE_ERROR.test(`${(error as any)?.stderr || ''} ${(error as Error)?.message || ''}`);
+    let output: string;
+    try {
+      output = (await ErrorRecovery.withBackoff(() => this.executeGitCommand(command), transient, this.retry)).stdout;
+    } catch (error) {
+      if (transient(error)) {
+        this.logger.warn(`Could not reach ${repository} to resolve ${ref}; leaving it unresolved`, { error: (error as Error).message });
+      }
+      return undefined;
+    }
+
+    const refs = new Map(output.trim().split('\n').filter(Boolean).map(line => {
+      const [sha, name] = line.split(/\s+/);
+      return [name!, sha!] as const;
+    }));
+    return refs.get(`refs/tags/${ref}^{}`) || refs.get(`refs/tags/${ref}`) || refs.get(`refs/heads/${ref}`);
+  }
+
+  /**
+   * Files changed since a ref, relative to the working directory and limited to it: those the
+   * working tree has added, modified or deleted compared to the commit the ref names, and the
+   * untracked ones git does not ignore. Renames count as a deletion and an addition, so both
+   * paths are listed. Throws NOT_GIT_REPOSITORY outside a repository, and INVALID_REF before
+   * diffing when the ref does not name a commit.
+   */
+  async getChangedFilesSince(ref: string): Promise<string[]> {
+    if (!await this.isGitRepository()) {
+      throw this.createGitError('NOT_GIT_REPOSITORY', 'Cannot diff: not in a Git repository');
+    }
+
+    // Refs are passed through the shell, so only the characters they are made of are let in
+    const valid = /^(?!-)[\w./@{}~^-]+$/.test(ref) && await this.executeGitCommand(`rev-parse --verify --quiet '${ref}^{commit}'`)
+      .then(() => true, () => false);
+    if (!valid) {
+      throw this.createGitError('INVALID_REF', `'${ref}' does not name a commit`);
+    }
+
+    try {
+      const [changed, untracked] = await Promise.all([
+        this.executeGitCommand(`diff --name-only --no-renames --relative -z '${ref}'`),
+        this.executeGitCommand('ls-files --others --exclude-standard -z')
+      ]);
+      const files = [...changed.stdout.split('\0'), ...untracked.stdout.split('\0')].filter(Boolean);
+      return [...new Set(files)].sort();
+    } catch (error) {
+      throw this.createGitError('DIFF_FAILED', `Failed to list the files changed since ${ref}`, error as Error);
+    }
+  }
+
   /**
    * Create automatic commit with descriptive message for generated workflows
    * Requirement 7.2: WHEN Git is detected THEN the system SHALL offer to commit generated workflows automatically
@@ -483,6 +578,10 @@ export class GitIntegration {
         suggestions.push('Verify Git is installed and accessible');
         suggestions.push('Check repository permissions');
         break;
+      case 'INVALID_REF':
+        suggestions.push('Check the branch, tag or commit exists: git rev-parse --verify <ref>');
+        suggestions.push('Fetch remote branches first when comparing against one: git fetch origin');
+        break;
       case 'DIFF_FAILED':
         suggestions.push('Ensure files exist and are accessible');
         suggestions.push('Check Git repository integrity');
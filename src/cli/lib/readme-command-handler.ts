@@ -8,7 +8,7 @@
 import { CLIOptions, CLIResult, CLIError } from './types';
 import { Logger } from './logger';
 import { ErrorHandler } from './error-handler';
-import { ReadmeParserImpl, createReadmeParserWithPipeline } from '../../parser';
+import { ReadmeParserImpl, createReadmeParserWithPipeline, findReadme } from '../../parser';
 import { ParseResult } from '../../parser/types';
 import path from 'path';
 import fs from 'fs/promises';
@@ -205,19 +205,11 @@ export class ReadmeCommandHandler {
       return path.resolve(providedPath);
     }
     
-    // Try common README file names in current directory
-    const commonNames = ['README.md', 'readme.md', 'README.txt', 'readme.txt', 'README'];
-    
-    for (const name of commonNames) {
-      const fullPath = path.resolve(name);
-      try {
-        await fs.access(fullPath);
-        this.logger.debug('Found README file', { path: fullPath });
-        return fullPath;
-      } catch {
-        // File doesn't exist, try next
-        continue;
-      }
+    // Look for README.md, README.rst, README.adoc... here and in the docs directories
+    const readme = await findReadme(process.cwd());
+    if (readme) {
+      this.logger.debug('Found README file', { path: readme.path, format: readme.format });
+      return readme.path;
     }
     
     // Default to README.md if nothing found
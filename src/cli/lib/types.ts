@@ -7,6 +7,7 @@
 
 // Import from shared types - commented out temporarily to fix test issues
 // import { DetectedFramework, WorkflowType, DeploymentTarget } from '../../shared/types';
+import { Diagnostic } from '../../detection/interfaces/diagnostic';
 
 export interface CLITool {
   run(args: string[]): Promise<CLIResult>;
@@ -19,14 +20,78 @@ export interface CLIResult {
   generatedFiles: string[];
   errors: CLIError[];
   warnings: string[];
+  /** Diagnostics of the detection run, errors first; those of monorepo packages carry their path */
+  diagnostics?: Diagnostic[];
   summary: ExecutionSummary;
 }
 
 export interface CLIOptions {
-  command: 'generate' | 'validate' | 'init' | 'export' | 'import' | 'parse' | 'analyze' | 'readme-validate' | 'status' | 'help';
+  command: 'generate' | 'validate' | 'verify' | 'init' | 'export' | 'import' | 'parse' | 'analyze' | 'readme-validate' | 'status' | 'help';
   readmePath?: string;
   outputDir?: string;
+  workingDirectory?: string;
   workflowType?: WorkflowType[];
+  provider?: 'github' | 'gitlab' | 'circleci' | 'azure' | 'bitbucket' | 'jenkins';
+  providerDefaulted?: boolean;
+  circleciOrbs?: boolean;
+  preset?: 'lint' | 'test' | 'full';
+  coverage?: 'codecov' | 'coveralls' | 'none';
+  defaultBranch?: string;
+  cancelInProgress?: boolean;
+  permissions?: boolean;
+  schedule?: string;
+  force?: boolean;
+  check?: boolean;
+  emitSecretsManifest?: boolean;
+  emit?: 'composite-action';
+  pinActions?: boolean;
+  repair?: boolean;
+  repairSkip?: Array<'pin-actions' | 'permissions' | 'concurrency'>;
+  dependabot?: boolean;
+  renovate?: boolean;
+  reusable?: boolean;
+  split?: 'none' | 'by-kind';
+  splitSetup?: 'reusable' | 'duplicate';
+  release?: boolean;
+  releaseTagPrefix?: string;
+  artifacts?: boolean;
+  artifactPath?: string;
+  artifactName?: string;
+  artifactRetentionDays?: number;
+  testRetries?: number;
+  jobTimeout?: number;
+  monorepo?: 'single' | 'per-package';
+  changedFiles?: 'path-triggers' | 'paths-filter';
+  detectWorkers?: number;
+  since?: string;
+  registry?: string;
+  testRunners?: 'jobs' | 'matrix';
+  existingCi?: 'generate' | 'skip';
+  preCommit?: 'job' | 'replace-lint';
+  terraform?: boolean;
+  licenseCheck?: boolean;
+  changelogCheck?: boolean;
+  codeownersComment?: boolean;
+  apiLint?: boolean;
+  protobuf?: boolean;
+  phoenixDb?: boolean;
+  cxxMatrix?: boolean;
+  swiftLinux?: boolean;
+  flutterBuild?: boolean;
+  provenance?: boolean;
+  minimal?: boolean;
+  makeCi?: boolean;
+  rustWorkspace?: 'workspace' | 'per-crate';
+  nodeWorkspace?: 'workspace' | 'per-package';
+  cargoFeatureMatrix?: boolean;
+  deployPages?: boolean;
+  pagesDir?: string;
+  minConfidence?: number;
+  runnerLabels?: string[];
+  runnerImages?: Partial<Record<'linux' | 'macos' | 'windows', string>>;
+  os?: Array<'linux' | 'macos' | 'windows'>;
+  privateModules?: string[];
+  privateModulesSecret?: string;
   framework?: string[];
   dryRun: boolean;
   interactive: boolean;
@@ -43,6 +108,12 @@ export interface CLIOptions {
   
   // Init specific options
   template?: 'basic' | 'enterprise' | 'team';
+
+  // Verify specific options
+  /** Workflow file verify runs a job of */
+  workflowPath?: string;
+  /** Job verify runs, the first of the workflow when not given */
+  job?: string;
   
   // Batch processing options
   directories?: string[];
@@ -132,6 +203,8 @@ export interface ExecutionSummary {
   executionTime: number;
   filesProcessed: number;
   workflowsGenerated: number;
+  /** README the project information was read from */
+  readmePath?: string;
 }
 
 export interface CLIError {
@@ -1,6 +1,7 @@
 import { LanguageAnalyzer, LanguageDetectionResult, ProjectInfo } from '../interfaces/language-analyzer';
 import { FrameworkInfo } from '../interfaces/framework-info';
 import { CIStep } from '../interfaces/ci-pipeline';
+import { ProjectFileSystem } from '../utils/project-fs';
 
 /**
  * Base implementation for language analyzers
@@ -17,7 +18,7 @@ export abstract class BaseLanguageAnalyzer implements LanguageAnalyzer {
   /**
    * Analyze project and detect frameworks
    */
-  abstract analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult>;
+  abstract analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult>;
 
   /**
    * Generate CI/CD steps for detected frameworks
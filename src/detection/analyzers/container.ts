@@ -4,7 +4,7 @@ import { FrameworkInfo, ContainerInfo, BuildToolInfo } from '../interfaces/frame
 import { CIStep } from '../interfaces/ci-pipeline';
 import { Evidence } from '../interfaces/evidence';
 import { FileSystemScanner } from '../utils/file-scanner';
-import { join } from 'path';
+import { ProjectFileSystem } from '../utils/project-fs';
 
 /**
  * Container and deployment analyzer
@@ -29,7 +29,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
            this.hasCommand(projectInfo, 'docker-compose');
   }
 
-  async analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult> {
+  async analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult> {
     const startTime = Date.now();
     const frameworks: FrameworkInfo[] = [];
     const buildTools: BuildToolInfo[] = [];
@@ -40,7 +40,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     // Detect Docker
     try {
-      const dockerInfo = await this.detectDocker(projectInfo, projectPath);
+      const dockerInfo = await this.detectDocker(projectInfo, project);
       if (dockerInfo) {
         frameworks.push(dockerInfo.framework);
         if (dockerInfo.buildTool) {
@@ -58,7 +58,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     // Detect Docker Compose
     try {
-      const composeInfo = await this.detectDockerCompose(projectInfo, projectPath);
+      const composeInfo = await this.detectDockerCompose(projectInfo, project);
       if (composeInfo) {
         frameworks.push(composeInfo.framework);
         if (composeInfo.buildTool) {
@@ -76,7 +76,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     // Detect Kubernetes
     try {
-      const k8sInfo = await this.detectKubernetes(projectInfo, projectPath);
+      const k8sInfo = await this.detectKubernetes(projectInfo, project);
       if (k8sInfo) {
         frameworks.push(k8sInfo.framework);
         if (k8sInfo.container) {
@@ -91,7 +91,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     // Detect Helm
     try {
-      const helmInfo = await this.detectHelm(projectInfo, projectPath);
+      const helmInfo = await this.detectHelm(projectInfo, project);
       if (helmInfo) {
         frameworks.push(helmInfo.framework);
         if (helmInfo.buildTool) {
@@ -125,7 +125,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Docker configuration
    */
-  private async detectDocker(projectInfo: ProjectInfo, projectPath?: string): Promise<ContainerDetectionResult | null> {
+  private async detectDocker(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<ContainerDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -164,9 +164,9 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     // Parse Dockerfile if available
     let dockerfileInfo: DockerfileInfo | null = null;
-    if (projectPath && this.hasConfigFile(projectInfo, 'Dockerfile')) {
+    if (project && this.hasConfigFile(projectInfo, 'Dockerfile')) {
       try {
-        dockerfileInfo = await this.parseDockerfile(join(projectPath, 'Dockerfile'));
+        dockerfileInfo = await this.parseDockerfile(project, 'Dockerfile');
         filesAnalyzed.push('Dockerfile');
         
         if (dockerfileInfo.baseImage) {
@@ -273,7 +273,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Docker Compose configuration
    */
-  private async detectDockerCompose(projectInfo: ProjectInfo, projectPath?: string): Promise<ContainerDetectionResult | null> {
+  private async detectDockerCompose(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<ContainerDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -309,9 +309,9 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     // Parse docker-compose file if available
     let composeInfo: DockerComposeInfo | null = null;
-    if (projectPath && composeFile) {
+    if (project && composeFile) {
       try {
-        composeInfo = await this.parseDockerCompose(join(projectPath, composeFile));
+        composeInfo = await this.parseDockerCompose(project, composeFile);
         filesAnalyzed.push(composeFile);
         
         if (composeInfo.services.length > 0) {
@@ -414,7 +414,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Kubernetes configuration
    */
-  private async detectKubernetes(projectInfo: ProjectInfo, projectPath?: string): Promise<ContainerDetectionResult | null> {
+  private async detectKubernetes(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<ContainerDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -467,9 +467,9 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     // Parse manifest files if available
     let k8sInfo: KubernetesInfo | null = null;
-    if (projectPath && manifestFiles.length > 0) {
+    if (project && manifestFiles.length > 0) {
       try {
-        k8sInfo = await this.parseKubernetesManifests(projectPath, manifestFiles);
+        k8sInfo = await this.parseKubernetesManifests(project, manifestFiles);
         filesAnalyzed.push(...manifestFiles);
       } catch (error) {
         evidence.push({
@@ -521,7 +521,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Helm configuration
    */
-  private async detectHelm(projectInfo: ProjectInfo, projectPath?: string): Promise<ContainerDetectionResult | null> {
+  private async detectHelm(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<ContainerDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -560,9 +560,9 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     // Parse Helm chart if available
     let helmInfo: HelmInfo | null = null;
-    if (projectPath && this.hasConfigFile(projectInfo, 'Chart.yaml')) {
+    if (project && this.hasConfigFile(projectInfo, 'Chart.yaml')) {
       try {
-        helmInfo = await this.parseHelmChart(projectPath);
+        helmInfo = await this.parseHelmChart(project);
         filesAnalyzed.push('Chart.yaml');
         if (helmInfo.hasValues) {
           filesAnalyzed.push('values.yaml');
@@ -663,8 +663,8 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Parse Dockerfile content
    */
-  private async parseDockerfile(dockerfilePath: string): Promise<DockerfileInfo> {
-    const content = await this.fileScanner.readConfigFile(dockerfilePath);
+  private async parseDockerfile(project: string | ProjectFileSystem, dockerfilePath: string): Promise<DockerfileInfo> {
+    const content = await this.fileScanner.readConfigFile(dockerfilePath, project);
     const lines = content.split('\n').map((line: string) => line.trim()).filter((line: string) => line && !line.startsWith('#'));
     
     const info: DockerfileInfo = {
@@ -705,8 +705,8 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Parse docker-compose.yml content
    */
-  private async parseDockerCompose(composePath: string): Promise<DockerComposeInfo> {
-    const content = await this.fileScanner.readConfigFile(composePath);
+  private async parseDockerCompose(project: string | ProjectFileSystem, composePath: string): Promise<DockerComposeInfo> {
+    const content = await this.fileScanner.readConfigFile(composePath, project);
     
     const info: DockerComposeInfo = {
       version: content.version || null,
@@ -733,7 +733,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Parse Kubernetes manifest files
    */
-  private async parseKubernetesManifests(projectPath: string, manifestFiles: string[]): Promise<KubernetesInfo> {
+  private async parseKubernetesManifests(project: string | ProjectFileSystem, manifestFiles: string[]): Promise<KubernetesInfo> {
     const info: KubernetesInfo = {
       resources: [],
       namespaces: []
@@ -741,7 +741,7 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
 
     for (const file of manifestFiles) {
       try {
-        const content = await this.fileScanner.readConfigFile(join(projectPath, file));
+        const content = await this.fileScanner.readConfigFile(file, project);
         
         if (content.kind) {
           info.resources.push(content.kind);
@@ -765,13 +765,13 @@ export class ContainerAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Parse Helm chart
    */
-  private async parseHelmChart(projectPath: string): Promise<HelmInfo> {
-    const chartContent = await this.fileScanner.readConfigFile(join(projectPath, 'Chart.yaml'));
+  private async parseHelmChart(project: string | ProjectFileSystem): Promise<HelmInfo> {
+    const chartContent = await this.fileScanner.readConfigFile('Chart.yaml', project);
     
     const info: HelmInfo = {
       name: chartContent.name || null,
       version: chartContent.version || null,
-      hasValues: await this.fileScanner.fileExists(projectPath, 'values.yaml')
+      hasValues: await this.fileScanner.fileExists(project, 'values.yaml')
     };
 
     return info;
@@ -4,7 +4,7 @@ import { FrameworkInfo, BuildToolInfo } from '../interfaces/framework-info';
 import { CIStep } from '../interfaces/ci-pipeline';
 import { Evidence } from '../interfaces/evidence';
 import { FileSystemScanner } from '../utils/file-scanner';
-import { join } from 'path';
+import { ProjectFileSystem } from '../utils/project-fs';
 
 /**
  * Frontend tooling and build system analyzer
@@ -26,7 +26,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
            this.hasFrontendDependencies(projectInfo);
   }
 
-  async analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult> {
+  async analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult> {
     const startTime = Date.now();
     const frameworks: FrameworkInfo[] = [];
     const buildTools: BuildToolInfo[] = [];
@@ -36,7 +36,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 
     // Detect build tools
     try {
-      const webpackInfo = await this.detectWebpack(projectInfo, projectPath);
+      const webpackInfo = await this.detectWebpack(projectInfo, project);
       if (webpackInfo) {
         buildTools.push(webpackInfo.buildTool);
         filesAnalyzed.push(...webpackInfo.filesAnalyzed);
@@ -47,7 +47,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
     }
 
     try {
-      const viteInfo = await this.detectVite(projectInfo, projectPath);
+      const viteInfo = await this.detectVite(projectInfo, project);
       if (viteInfo) {
         buildTools.push(viteInfo.buildTool);
         filesAnalyzed.push(...viteInfo.filesAnalyzed);
@@ -58,7 +58,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
     }
 
     try {
-      const parcelInfo = await this.detectParcel(projectInfo, projectPath);
+      const parcelInfo = await this.detectParcel(projectInfo, project);
       if (parcelInfo) {
         buildTools.push(parcelInfo.buildTool);
         filesAnalyzed.push(...parcelInfo.filesAnalyzed);
@@ -69,7 +69,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
     }
 
     try {
-      const rollupInfo = await this.detectRollup(projectInfo, projectPath);
+      const rollupInfo = await this.detectRollup(projectInfo, project);
       if (rollupInfo) {
         buildTools.push(rollupInfo.buildTool);
         filesAnalyzed.push(...rollupInfo.filesAnalyzed);
@@ -81,7 +81,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 
     // Detect static site generators
     try {
-      const ssgInfo = await this.detectStaticSiteGenerators(projectInfo, projectPath);
+      const ssgInfo = await this.detectStaticSiteGenerators(projectInfo, project);
       if (ssgInfo.length > 0) {
         frameworks.push(...ssgInfo.map(info => info.framework));
         filesAnalyzed.push(...ssgInfo.flatMap(info => info.filesAnalyzed));
@@ -93,7 +93,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 
     // Detect deployment platforms
     try {
-      const deploymentInfo = await this.detectDeploymentPlatforms(projectInfo, projectPath);
+      const deploymentInfo = await this.detectDeploymentPlatforms(projectInfo, project);
       if (deploymentInfo.length > 0) {
         frameworks.push(...deploymentInfo.map(info => info.framework));
         filesAnalyzed.push(...deploymentInfo.flatMap(info => info.filesAnalyzed));
@@ -119,7 +119,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 /**
    * Detect Webpack configuration
    */
-  private async detectWebpack(projectInfo: ProjectInfo, projectPath?: string): Promise<FrontendDetectionResult | null> {
+  private async detectWebpack(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<FrontendDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -189,9 +189,9 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 
     // Parse webpack config if available
     let webpackInfo: WebpackInfo | null = null;
-    if (projectPath && configFile) {
+    if (project && configFile) {
       try {
-        webpackInfo = await this.parseWebpackConfig(join(projectPath, configFile));
+        webpackInfo = await this.parseWebpackConfig(configFile);
         filesAnalyzed.push(configFile);
       } catch (error) {
         evidence.push({
@@ -246,7 +246,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Vite configuration
    */
-  private async detectVite(projectInfo: ProjectInfo, projectPath?: string): Promise<FrontendDetectionResult | null> {
+  private async detectVite(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<FrontendDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -306,9 +306,9 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 
     // Parse vite config if available
     let viteInfo: ViteInfo | null = null;
-    if (projectPath && configFile) {
+    if (project && configFile) {
       try {
-        viteInfo = await this.parseViteConfig(join(projectPath, configFile));
+        viteInfo = await this.parseViteConfig(configFile);
         filesAnalyzed.push(configFile);
       } catch (error) {
         evidence.push({
@@ -362,7 +362,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 *
    * Detect Parcel configuration
    */
-  private async detectParcel(projectInfo: ProjectInfo, _projectPath?: string): Promise<FrontendDetectionResult | null> {
+  private async detectParcel(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<FrontendDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -456,7 +456,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Rollup configuration
    */
-  private async detectRollup(projectInfo: ProjectInfo, _projectPath?: string): Promise<FrontendDetectionResult | null> {
+  private async detectRollup(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<FrontendDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -549,23 +549,23 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 /**
    * Detect static site generators
    */
-  private async detectStaticSiteGenerators(projectInfo: ProjectInfo, projectPath?: string): Promise<StaticSiteDetectionResult[]> {
+  private async detectStaticSiteGenerators(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<StaticSiteDetectionResult[]> {
     const results: StaticSiteDetectionResult[] = [];
 
     // Detect Gatsby
-    const gatsbyResult = await this.detectGatsby(projectInfo, projectPath);
+    const gatsbyResult = await this.detectGatsby(projectInfo, project);
     if (gatsbyResult) results.push(gatsbyResult);
 
     // Detect Next.js
-    const nextResult = await this.detectNextJS(projectInfo, projectPath);
+    const nextResult = await this.detectNextJS(projectInfo, project);
     if (nextResult) results.push(nextResult);
 
     // Detect Nuxt.js
-    const nuxtResult = await this.detectNuxtJS(projectInfo, projectPath);
+    const nuxtResult = await this.detectNuxtJS(projectInfo, project);
     if (nuxtResult) results.push(nuxtResult);
 
     // Detect Jekyll
-    const jekyllResult = await this.detectJekyll(projectInfo, projectPath);
+    const jekyllResult = await this.detectJekyll(projectInfo, project);
     if (jekyllResult) results.push(jekyllResult);
 
     return results;
@@ -574,7 +574,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Gatsby
    */
-  private async detectGatsby(projectInfo: ProjectInfo, _projectPath?: string): Promise<StaticSiteDetectionResult | null> {
+  private async detectGatsby(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<StaticSiteDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -639,7 +639,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Next.js
    */
-  private async detectNextJS(projectInfo: ProjectInfo, _projectPath?: string): Promise<StaticSiteDetectionResult | null> {
+  private async detectNextJS(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<StaticSiteDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -719,7 +719,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Nuxt.js
    */
-  private async detectNuxtJS(projectInfo: ProjectInfo, _projectPath?: string): Promise<StaticSiteDetectionResult | null> {
+  private async detectNuxtJS(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<StaticSiteDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -788,7 +788,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Jekyll
    */
-  private async detectJekyll(projectInfo: ProjectInfo, _projectPath?: string): Promise<StaticSiteDetectionResult | null> {
+  private async detectJekyll(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<StaticSiteDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -863,19 +863,19 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
 
    * Detect deployment platforms
    */
-  private async detectDeploymentPlatforms(projectInfo: ProjectInfo, projectPath?: string): Promise<DeploymentDetectionResult[]> {
+  private async detectDeploymentPlatforms(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<DeploymentDetectionResult[]> {
     const results: DeploymentDetectionResult[] = [];
 
     // Detect Netlify
-    const netlifyResult = await this.detectNetlify(projectInfo, projectPath);
+    const netlifyResult = await this.detectNetlify(projectInfo, project);
     if (netlifyResult) results.push(netlifyResult);
 
     // Detect Vercel
-    const vercelResult = await this.detectVercel(projectInfo, projectPath);
+    const vercelResult = await this.detectVercel(projectInfo, project);
     if (vercelResult) results.push(vercelResult);
 
     // Detect GitHub Pages
-    const githubPagesResult = await this.detectGitHubPages(projectInfo, projectPath);
+    const githubPagesResult = await this.detectGitHubPages(projectInfo, project);
     if (githubPagesResult) results.push(githubPagesResult);
 
     return results;
@@ -884,7 +884,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Netlify deployment
    */
-  private async detectNetlify(projectInfo: ProjectInfo, _projectPath?: string): Promise<DeploymentDetectionResult | null> {
+  private async detectNetlify(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<DeploymentDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -964,7 +964,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Vercel deployment
    */
-  private async detectVercel(projectInfo: ProjectInfo, _projectPath?: string): Promise<DeploymentDetectionResult | null> {
+  private async detectVercel(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<DeploymentDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -1043,7 +1043,7 @@ export class FrontendAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect GitHub Pages deployment
    */
-  private async detectGitHubPages(projectInfo: ProjectInfo, _projectPath?: string): Promise<DeploymentDetectionResult | null> {
+  private async detectGitHubPages(projectInfo: ProjectInfo, _project?: string | ProjectFileSystem): Promise<DeploymentDetectionResult | null> {
     const evidence: Evidence[] = [];
     const filesAnalyzed: string[] = [];
     const patternsMatched: string[] = [];
@@ -3,6 +3,10 @@ import { LanguageDetectionResult, ProjectInfo } from '../interfaces/language-ana
 import { FrameworkInfo, BuildToolInfo } from '../interfaces/framework-info';
 import { CIStep } from '../interfaces/ci-pipeline';
 import { FileSystemScanner } from '../utils/file-scanner';
+import { ProjectFileSystem } from '../utils/project-fs';
+import { GoBuildConstraintScanner, GoBuildConstraints } from '../utils/go-build-constraints';
+import { parseVersionConstraint } from '../utils/version-constraint';
+import { VersionConstraint } from '../interfaces/version-constraint';
 import { Evidence } from '../interfaces/evidence';
 
 /**
@@ -12,6 +16,7 @@ export class GoAnalyzer extends BaseLanguageAnalyzer {
   readonly name = 'Go Analyzer';
   readonly ecosystem = 'go';
   private fileScanner = new FileSystemScanner();
+  private constraintScanner = new GoBuildConstraintScanner();
 
   canAnalyze(projectInfo: ProjectInfo): boolean {
     // Check for Go indicators
@@ -23,7 +28,7 @@ export class GoAnalyzer extends BaseLanguageAnalyzer {
            this.hasCommand(projectInfo, 'go test');
   }
 
-  async analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult> {
+  async analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult> {
     const startTime = Date.now();
     const frameworks: FrameworkInfo[] = [];
     const buildTools: BuildToolInfo[] = [];
@@ -33,23 +38,27 @@ export class GoAnalyzer extends BaseLanguageAnalyzer {
     const recommendations: string[] = [];
     let hasGoMod = false;
     let hasGoSum = false;
+    let versionConstraint: VersionConstraint | undefined;
 
     try {
-      if (!projectPath) {
+      if (!project) {
         warnings.push('No project path provided, skipping file system analysis');
         return this.createMinimalResult(startTime, warnings, recommendations);
       }
 
       // Check for go.mod
-      hasGoMod = await this.fileScanner.fileExists(projectPath, 'go.mod');
+      hasGoMod = await this.fileScanner.fileExists(project, 'go.mod');
       if (hasGoMod) {
         filesAnalyzed.push('go.mod');
         try {
-          const goModContent = await this.fileScanner.readConfigFile(`${projectPath}/go.mod`);
+          const goModContent = await this.fileScanner.readConfigFile('go.mod', project);
           const goModData = this.parseGoMod(goModContent);
           
           // Extract Go version
           const goVersion = goModData.goVersion;
+          if (goVersion) {
+            versionConstraint = parseVersionConstraint('go', goVersion, 'go.mod');
+          }
           
           // Detect workspace configuration
           const isWorkspace = goModData.isWorkspace;
@@ -85,7 +94,7 @@ export class GoAnalyzer extends BaseLanguageAnalyzer {
       }
 
       // Check for go.sum for reproducible builds
-      hasGoSum = await this.fileScanner.fileExists(projectPath, 'go.sum');
+      hasGoSum = await this.fileScanner.fileExists(project, 'go.sum');
       if (hasGoSum) {
         filesAnalyzed.push('go.sum');
         patternsMatched.push('go_sum_present');
@@ -95,11 +104,19 @@ export class GoAnalyzer extends BaseLanguageAnalyzer {
         const goBuildTool = buildTools.find(tool => tool.name === 'go');
         if (goBuildTool) {
           goBuildTool.confidence = 0.95;
+          goBuildTool.lockFile = 'go.sum';
         }
       }
 
       // Detect build constraints and tags
-      await this.detectBuildConstraints(projectPath, patternsMatched, recommendations);
+      const buildConstraints = await this.detectBuildConstraints(project, patternsMatched, recommendations);
+      const goTool = buildTools.find(tool => tool.name === 'go');
+      if (buildConstraints && goTool) {
+        goTool.config = {
+          ...goTool.config,
+          buildConstraints
+        };
+      }
 
       // Generate recommendations
       this.generateRecommendations(frameworks, buildTools, hasGoMod, hasGoSum, recommendations);
@@ -118,6 +135,7 @@ export class GoAnalyzer extends BaseLanguageAnalyzer {
       buildTools,
       confidence,
       recommendations,
+      ...(versionConstraint && { versionConstraint }),
       metadata: {
         executionTime: Date.now() - startTime,
         filesAnalyzed,
@@ -316,45 +334,53 @@ export class GoAnalyzer extends BaseLanguageAnalyzer {
    * Detect build constraints and tags
    */
   private async detectBuildConstraints(
-    projectPath: string,
+    project: string | ProjectFileSystem,
     patternsMatched: string[],
     recommendations: string[]
-  ): Promise<void> {
+  ): Promise<GoBuildConstraints | null> {
     try {
-      const goFiles = await this.findGoFiles(projectPath);
-      let hasBuildConstraints = false;
+      const goFiles = await this.findGoFiles(project);
+      const sources: Array<{ path: string; content: string }> = [];
 
-      for (const file of goFiles.slice(0, 10)) { // Limit to first 10 files for performance
+      for (const file of goFiles.slice(0, MAX_CONSTRAINT_SCAN_FILES)) {
         try {
-          const content = await this.fileScanner.readConfigFile(file);
+          const content = await this.fileScanner.readConfigFile(file, project);
           if (typeof content === 'string') {
-            // Check for build constraints
-            if (content.includes('//go:build') || content.includes('// +build')) {
-              hasBuildConstraints = true;
-              patternsMatched.push('build_constraints');
-              break;
-            }
+            sources.push({ path: file, content });
           }
         } catch (error) {
           // Skip files that can't be read
         }
       }
 
-      if (hasBuildConstraints) {
-        recommendations.push('Build constraints detected. Consider using appropriate build tags in CI for different environments.');
+      const constraints = this.constraintScanner.scan(sources);
+      if (constraints.files.length === 0) {
+        return null;
+      }
+
+      patternsMatched.push('build_constraints');
+      recommendations.push('Build constraints detected. Consider using appropriate build tags in CI for different environments.');
+
+      if (constraints.platforms.length > 0) {
+        const targets = constraints.platforms.map(p => `${p.goos}/${p.goarch}`).join(', ');
+        recommendations.push(`Build constraints target ${targets}. Generated workflows will build a GOOS/GOARCH matrix.`);
       }
+
+      return constraints;
     } catch (error) {
       // Skip build constraint detection if it fails
+      return null;
     }
   }
 
   /**
-   * Find Go source files in project
+   * Find the Go source files the project builds: not those in vendor or testdata directories,
+   * nor those a project file system with ignore patterns leaves out
    */
-  private async findGoFiles(projectPath: string): Promise<string[]> {
+  private async findGoFiles(project: string | ProjectFileSystem): Promise<string[]> {
     try {
-      const allFiles = await this.fileScanner.scanProjectFiles(projectPath);
-      return allFiles.filter(file => file.endsWith('.go') && !file.includes('vendor/'));
+      const allFiles = await this.fileScanner.findFilesByExtension(project, ['.go']);
+      return allFiles.filter(file => !file.includes('vendor/') && !file.includes('testdata/'));
     } catch (error) {
       return [];
     }
@@ -529,6 +555,11 @@ export class GoAnalyzer extends BaseLanguageAnalyzer {
   }
 }
 
+/**
+ * Maximum number of Go files read when collecting build constraints
+ */
+const MAX_CONSTRAINT_SCAN_FILES = 200;
+
 /**
  * Go module data structure
  */
@@ -4,6 +4,7 @@ import { FrameworkInfo, BuildToolInfo, FrameworkType } from '../interfaces/frame
 import { CIStep } from '../interfaces/ci-pipeline';
 import { Evidence } from '../interfaces/evidence';
 import { FileSystemScanner } from '../utils/file-scanner';
+import { ProjectFileSystem } from '../utils/project-fs';
 import { EvidenceCollectorImpl } from '../utils/evidence-collector';
 
 /**
@@ -34,7 +35,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
            this.hasCommand(projectInfo, 'gradle');
   }
 
-  async analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult> {
+  async analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult> {
     const startTime = Date.now();
     const frameworks: FrameworkInfo[] = [];
     const buildTools: BuildToolInfo[] = [];
@@ -44,7 +45,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
 
     try {
       // Detect build tools first
-      const detectedBuildTools = await this.detectBuildTools(projectInfo, projectPath);
+      const detectedBuildTools = await this.detectBuildTools(projectInfo, project);
       buildTools.push(...detectedBuildTools);
 
       // Parse configuration files
@@ -52,11 +53,11 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
       let gradleBuild: any = null;
       let gradleKotlinBuild: string = '';
 
-      if (projectPath) {
+      if (project) {
         // Try to parse Maven pom.xml
-        if (await this.fileScanner.fileExists(projectPath, 'pom.xml')) {
+        if (await this.fileScanner.fileExists(project, 'pom.xml')) {
           try {
-            mavenPom = await this.fileScanner.readConfigFile(`${projectPath}/pom.xml`);
+            mavenPom = await this.fileScanner.readConfigFile('pom.xml', project);
             filesAnalyzed.push('pom.xml');
           } catch (error) {
             warnings.push(`Failed to parse pom.xml: ${error instanceof Error ? error.message : 'Unknown error'}`);
@@ -64,9 +65,9 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
         }
 
         // Try to parse Gradle build.gradle
-        if (await this.fileScanner.fileExists(projectPath, 'build.gradle')) {
+        if (await this.fileScanner.fileExists(project, 'build.gradle')) {
           try {
-            gradleBuild = await this.fileScanner.readConfigFile(`${projectPath}/build.gradle`);
+            gradleBuild = await this.fileScanner.readConfigFile('build.gradle', project);
             filesAnalyzed.push('build.gradle');
           } catch (error) {
             warnings.push(`Failed to parse build.gradle: ${error instanceof Error ? error.message : 'Unknown error'}`);
@@ -74,9 +75,9 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
         }
 
         // Try to parse Gradle build.gradle.kts
-        if (await this.fileScanner.fileExists(projectPath, 'build.gradle.kts')) {
+        if (await this.fileScanner.fileExists(project, 'build.gradle.kts')) {
           try {
-            gradleKotlinBuild = await this.fileScanner.readConfigFile(`${projectPath}/build.gradle.kts`);
+            gradleKotlinBuild = await this.fileScanner.readConfigFile('build.gradle.kts', project);
             filesAnalyzed.push('build.gradle.kts');
           } catch (error) {
             warnings.push(`Failed to parse build.gradle.kts: ${error instanceof Error ? error.message : 'Unknown error'}`);
@@ -90,7 +91,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
         mavenPom, 
         gradleBuild, 
         gradleKotlinBuild, 
-        projectPath
+        project
       );
       frameworks.push(...detectedFrameworks);
 
@@ -135,15 +136,15 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect build tools used in the project
    */
-  private async detectBuildTools(projectInfo: ProjectInfo, projectPath?: string): Promise<BuildToolInfo[]> {
+  private async detectBuildTools(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<BuildToolInfo[]> {
     const buildTools: BuildToolInfo[] = [];
 
     // Detect Maven
-    const mavenTool = await this.detectMaven(projectInfo, projectPath);
+    const mavenTool = await this.detectMaven(projectInfo, project);
     if (mavenTool) buildTools.push(mavenTool);
 
     // Detect Gradle
-    const gradleTool = await this.detectGradle(projectInfo, projectPath);
+    const gradleTool = await this.detectGradle(projectInfo, project);
     if (gradleTool) buildTools.push(gradleTool);
 
     return buildTools;
@@ -152,7 +153,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Maven build tool
    */
-  private async detectMaven(projectInfo: ProjectInfo, projectPath?: string): Promise<BuildToolInfo | null> {
+  private async detectMaven(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<BuildToolInfo | null> {
     const evidence: Evidence[] = [];
 
     // Check for pom.xml
@@ -176,7 +177,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check file system if path provided
-    if (projectPath && await this.fileScanner.fileExists(projectPath, 'pom.xml')) {
+    if (project && await this.fileScanner.fileExists(project, 'pom.xml')) {
       evidence.push({
         type: 'config_file',
         source: 'filesystem',
@@ -186,8 +187,8 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check for Maven wrapper
-    if (projectPath) {
-      const wrapperFiles = await this.fileScanner.findConfigFiles(projectPath, [
+    if (project) {
+      const wrapperFiles = await this.fileScanner.findConfigFiles(project, [
         'mvnw', 'mvnw.cmd', '.mvn/wrapper/maven-wrapper.properties'
       ]);
       wrapperFiles.forEach(file => {
@@ -222,7 +223,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
   /**
    * Detect Gradle build tool
    */
-  private async detectGradle(projectInfo: ProjectInfo, projectPath?: string): Promise<BuildToolInfo | null> {
+  private async detectGradle(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<BuildToolInfo | null> {
     const evidence: Evidence[] = [];
 
     // Check for build.gradle files
@@ -255,8 +256,8 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check file system if path provided
-    if (projectPath) {
-      const gradleFiles = await this.fileScanner.findConfigFiles(projectPath, [
+    if (project) {
+      const gradleFiles = await this.fileScanner.findConfigFiles(project, [
         'build.gradle', 'build.gradle.kts', 'settings.gradle', 'settings.gradle.kts'
       ]);
       gradleFiles.forEach(file => {
@@ -269,7 +270,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
       });
 
       // Check for Gradle wrapper
-      const wrapperFiles = await this.fileScanner.findConfigFiles(projectPath, [
+      const wrapperFiles = await this.fileScanner.findConfigFiles(project, [
         'gradlew', 'gradlew.bat', 'gradle/wrapper/gradle-wrapper.properties'
       ]);
       wrapperFiles.forEach(file => {
@@ -309,25 +310,25 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
     mavenPom: any,
     gradleBuild: any,
     gradleKotlinBuild: string,
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo[]> {
     const frameworks: FrameworkInfo[] = [];
 
     // Spring Boot detection
     const springBootFramework = await this.detectSpringBoot(
-      projectInfo, mavenPom, gradleBuild, gradleKotlinBuild, projectPath
+      projectInfo, mavenPom, gradleBuild, gradleKotlinBuild, project
     );
     if (springBootFramework) frameworks.push(springBootFramework);
 
     // Quarkus detection
     const quarkusFramework = await this.detectQuarkus(
-      projectInfo, mavenPom, gradleBuild, gradleKotlinBuild, projectPath
+      projectInfo, mavenPom, gradleBuild, gradleKotlinBuild, project
     );
     if (quarkusFramework) frameworks.push(quarkusFramework);
 
     // Micronaut detection
     const micronautFramework = await this.detectMicronaut(
-      projectInfo, mavenPom, gradleBuild, gradleKotlinBuild, projectPath
+      projectInfo, mavenPom, gradleBuild, gradleKotlinBuild, project
     );
     if (micronautFramework) frameworks.push(micronautFramework);
 
@@ -342,7 +343,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
     mavenPom: any,
     gradleBuild: any,
     gradleKotlinBuild: string,
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -462,7 +463,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
     mavenPom: any,
     gradleBuild: any,
     gradleKotlinBuild: string,
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -547,7 +548,7 @@ export class JavaAnalyzer extends BaseLanguageAnalyzer {
     mavenPom: any,
     gradleBuild: any,
     gradleKotlinBuild: string,
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
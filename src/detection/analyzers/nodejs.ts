@@ -4,7 +4,32 @@ import { FrameworkInfo, BuildToolInfo, FrameworkType } from '../interfaces/frame
 import { CIStep } from '../interfaces/ci-pipeline';
 import { Evidence } from '../interfaces/evidence';
 import { FileSystemScanner } from '../utils/file-scanner';
+import { ProjectFileSystem } from '../utils/project-fs';
 import { EvidenceCollectorImpl } from '../utils/evidence-collector';
+import { parseVersionConstraint } from '../utils/version-constraint';
+
+/**
+ * Node.js package managers the analyzer can resolve
+ */
+export type NodePackageManager = 'npm' | 'yarn' | 'pnpm' | 'bun';
+
+/**
+ * Lockfiles and the package manager that writes them
+ */
+const NODE_LOCK_FILES: Record<string, NodePackageManager> = {
+  'pnpm-lock.yaml': 'pnpm',
+  'yarn.lock': 'yarn',
+  'bun.lockb': 'bun',
+  'bun.lock': 'bun',
+  'package-lock.json': 'npm'
+};
+
+const DEFAULT_LOCK_FILES: Record<NodePackageManager, string> = {
+  npm: 'package-lock.json',
+  yarn: 'yarn.lock',
+  pnpm: 'pnpm-lock.yaml',
+  bun: 'bun.lockb'
+};
 
 /**
  * Node.js framework analyzer
@@ -32,7 +57,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
            this.hasCommand(projectInfo, 'pnpm');
   }
 
-  async analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult> {
+  async analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult> {
     const startTime = Date.now();
     const frameworks: FrameworkInfo[] = [];
     const buildTools: BuildToolInfo[] = [];
@@ -44,9 +69,9 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
       // Parse package.json if available
       let packageJson: any = null;
       let packageJsonParsingFailed = false;
-      if (projectPath && await this.fileScanner.fileExists(projectPath, 'package.json')) {
+      if (project && await this.fileScanner.fileExists(project, 'package.json')) {
         try {
-          packageJson = await this.fileScanner.readConfigFile(`${projectPath}/package.json`);
+          packageJson = await this.fileScanner.readConfigFile('package.json', project);
           filesAnalyzed.push('package.json');
         } catch (error) {
           packageJsonParsingFailed = true;
@@ -55,14 +80,14 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
       }
 
       // Detect package manager
-      const packageManager = await this.detectPackageManager(projectInfo, projectPath);
+      const packageManager = await this.detectPackageManager(projectInfo, packageJson, warnings, project);
       if (packageManager) {
         buildTools.push(packageManager);
       }
 
       // Detect frameworks (skip if package.json parsing failed, but allow if no package.json exists)
       if (!packageJsonParsingFailed) {
-        const detectedFrameworks = await this.detectFrameworks(projectInfo, packageJson, projectPath);
+        const detectedFrameworks = await this.detectFrameworks(projectInfo, packageJson, project);
         frameworks.push(...detectedFrameworks);
         
         // Track patterns matched
@@ -74,7 +99,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
       // Generate recommendations
       const recommendations = this.generateRecommendations(frameworks, packageJson);
 
-      return {
+      const result: LanguageDetectionResult = {
         frameworks,
         buildTools,
         confidence: this.calculateConfidence(frameworks, buildTools),
@@ -86,6 +111,13 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
           warnings
         }
       };
+
+      // Honour the Node.js range from engines.node
+      if (typeof packageJson?.engines?.node === 'string') {
+        result.versionConstraint = parseVersionConstraint('node', packageJson.engines.node, 'package.json');
+      }
+
+      return result;
     } catch (error) {
       warnings.push(`Analysis failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
       
@@ -105,89 +137,98 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
   }
 
   /**
-   * Detect package manager used in the project
+   * Detect package manager used in the project.
+   * A single lockfile decides; several lockfiles defer to the packageManager field of package.json,
+   * falling back to npm when nothing is conclusive.
    */
-  private async detectPackageManager(projectInfo: ProjectInfo, projectPath?: string): Promise<BuildToolInfo | null> {
-    const evidence: Evidence[] = [];
-    
-    // Check for lock files and commands
-    if (this.hasConfigFile(projectInfo, 'yarn.lock') || this.hasCommand(projectInfo, 'yarn')) {
-      evidence.push({
-        type: 'config_file',
-        source: 'yarn.lock',
-        value: 'yarn',
-        weight: 0.8
-      });
-    }
-    
-    if (this.hasConfigFile(projectInfo, 'pnpm-lock.yaml') || this.hasCommand(projectInfo, 'pnpm')) {
-      evidence.push({
-        type: 'config_file',
-        source: 'pnpm-lock.yaml',
-        value: 'pnpm',
-        weight: 0.8
-      });
-    }
-    
-    if (this.hasConfigFile(projectInfo, 'package-lock.json') || this.hasCommand(projectInfo, 'npm')) {
-      evidence.push({
-        type: 'config_file',
-        source: 'package-lock.json',
-        value: 'npm',
-        weight: 0.7
-      });
-    }
-
-    // Check file system if path provided
-    if (projectPath) {
-      const lockFiles = await this.fileScanner.findConfigFiles(projectPath, [
-        'yarn.lock', 'pnpm-lock.yaml', 'package-lock.json'
-      ]);
-      
-      lockFiles.forEach(file => {
-        const manager = file.includes('yarn') ? 'yarn' : 
-                      file.includes('pnpm') ? 'pnpm' : 'npm';
-        evidence.push({
-          type: 'config_file',
-          source: file,
-          value: manager,
-          weight: 0.9
-        });
-      });
+  private async detectPackageManager(
+    projectInfo: ProjectInfo,
+    packageJson: any,
+    warnings: string[],
+    project?: string | ProjectFileSystem
+  ): Promise<BuildToolInfo | null> {
+    // Lockfiles on disk are authoritative; README mentions only stand in when there is no path
+    const lockFiles = project
+      ? await this.fileScanner.findConfigFiles(project, Object.keys(NODE_LOCK_FILES))
+      : Object.keys(NODE_LOCK_FILES).filter(file => projectInfo.configFiles.includes(file));
+
+    const locked = [...new Set(lockFiles.map(file => NODE_LOCK_FILES[file]).filter((pm): pm is NodePackageManager => !!pm))];
+    const declared = this.parsePackageManagerField(packageJson?.packageManager);
+
+    let manager: NodePackageManager;
+    let confidence: number;
+    if (locked.length === 1) {
+      manager = locked[0]!;
+      confidence = declared?.name === manager ? 0.95 : 0.9;
+    } else if (locked.length > 1) {
+      if (declared && locked.includes(declared.name)) {
+        manager = declared.name;
+        confidence = 0.9;
+      } else {
+        manager = 'npm';
+        confidence = 0.5;
+        warnings.push(`Multiple lockfiles found (${lockFiles.join(', ')}); defaulting to npm. Set "packageManager" in package.json to choose one.`);
+      }
+    } else if (declared) {
+      manager = declared.name;
+      confidence = 0.8;
+    } else {
+      const used = this.getCommandPackageManagers(projectInfo);
+      if (used.length === 0) {
+        return null;
+      }
+      manager = used.length === 1 ? used[0]! : 'npm';
+      confidence = used.length === 1 ? 0.7 : 0.5;
     }
 
-    if (evidence.length === 0) {
-      return null;
+    const foundLockFile = lockFiles.find(file => NODE_LOCK_FILES[file] === manager);
+    const packageManager: BuildToolInfo = {
+      name: manager,
+      configFile: foundLockFile || DEFAULT_LOCK_FILES[manager],
+      ...(foundLockFile && { lockFile: foundLockFile }),
+      commands: this.getPackageManagerCommands(manager),
+      confidence
+    };
+    if (declared?.name === manager && declared.version) {
+      packageManager.version = declared.version;
     }
 
-    // Determine primary package manager
-    const managerCounts = evidence.reduce((acc, e) => {
-      acc[e.value] = (acc[e.value] || 0) + e.weight;
-      return acc;
-    }, {} as Record<string, number>);
+    return packageManager;
+  }
 
-    const entries = Object.entries(managerCounts);
-    if (entries.length === 0) {
+  /**
+   * Parse the corepack packageManager field, e.g. "pnpm@9.1.0+sha512.abc"
+   */
+  private parsePackageManagerField(field: unknown): { name: NodePackageManager; version?: string } | null {
+    if (typeof field !== 'string') {
       return null;
     }
-    const sortedEntries = entries.sort(([,a], [,b]) => b - a);
-    const primaryManagerEntry = sortedEntries[0];
-    if (!primaryManagerEntry) {
+
+    const match = field.trim().match(/^(npm|yarn|pnpm|bun)(?:@([^+\s]+))?/);
+    if (!match) {
       return null;
     }
-    const primaryManager = primaryManagerEntry[0];
-    const primaryManagerScore = managerCounts[primaryManager];
-    if (primaryManagerScore === undefined) {
-      return null;
+
+    return match[2]
+      ? { name: match[1] as NodePackageManager, version: match[2] }
+      : { name: match[1] as NodePackageManager };
+  }
+
+  /**
+   * Package managers invoked by README build and test commands
+   */
+  private getCommandPackageManagers(projectInfo: ProjectInfo): NodePackageManager[] {
+    const managers = new Set<NodePackageManager>();
+
+    for (const command of [...projectInfo.buildCommands, ...projectInfo.testCommands]) {
+      const executable = command.trim().split(/\s+/)[0]?.toLowerCase();
+      const manager = executable === 'npx' ? 'npm' : executable === 'bunx' ? 'bun' : executable;
+      if (manager === 'npm' || manager === 'yarn' || manager === 'pnpm' || manager === 'bun') {
+        managers.add(manager);
+      }
     }
 
-    return {
-      name: primaryManager,
-      configFile: primaryManager === 'yarn' ? 'yarn.lock' : 
-                 primaryManager === 'pnpm' ? 'pnpm-lock.yaml' : 'package-lock.json',
-      commands: this.getPackageManagerCommands(primaryManager),
-      confidence: Math.min(primaryManagerScore / evidence.length, 1.0)
-    };
+    return [...managers];
   }
 
   /**
@@ -212,6 +253,12 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
         { name: 'build', command: 'pnpm build', description: 'Build project', isPrimary: true },
         { name: 'test', command: 'pnpm test', description: 'Run tests', isPrimary: true },
         { name: 'start', command: 'pnpm start', description: 'Start application', isPrimary: false }
+      ],
+      bun: [
+        { name: 'install', command: 'bun install', description: 'Install dependencies', isPrimary: true },
+        { name: 'build', command: 'bun run build', description: 'Build project', isPrimary: true },
+        { name: 'test', command: 'bun run test', description: 'Run tests', isPrimary: true },
+        { name: 'start', command: 'bun run start', description: 'Start application', isPrimary: false }
       ]
     };
 
@@ -224,32 +271,32 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
   private async detectFrameworks(
     projectInfo: ProjectInfo, 
     packageJson: any, 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo[]> {
     const frameworks: FrameworkInfo[] = [];
 
     // React detection
-    const reactFramework = await this.detectReact(projectInfo, packageJson, projectPath);
+    const reactFramework = await this.detectReact(projectInfo, packageJson, project);
     if (reactFramework) frameworks.push(reactFramework);
 
     // Vue.js detection
-    const vueFramework = await this.detectVue(projectInfo, packageJson, projectPath);
+    const vueFramework = await this.detectVue(projectInfo, packageJson, project);
     if (vueFramework) frameworks.push(vueFramework);
 
     // Angular detection
-    const angularFramework = await this.detectAngular(projectInfo, packageJson, projectPath);
+    const angularFramework = await this.detectAngular(projectInfo, packageJson, project);
     if (angularFramework) frameworks.push(angularFramework);
 
     // Next.js detection
-    const nextFramework = await this.detectNextJS(projectInfo, packageJson, projectPath);
+    const nextFramework = await this.detectNextJS(projectInfo, packageJson, project);
     if (nextFramework) frameworks.push(nextFramework);
 
     // Express detection
-    const expressFramework = await this.detectExpress(projectInfo, packageJson, projectPath);
+    const expressFramework = await this.detectExpress(projectInfo, packageJson, project);
     if (expressFramework) frameworks.push(expressFramework);
 
     // NestJS detection
-    const nestFramework = await this.detectNestJS(projectInfo, packageJson, projectPath);
+    const nestFramework = await this.detectNestJS(projectInfo, packageJson, project);
     if (nestFramework) frameworks.push(nestFramework);
 
     return frameworks;
@@ -261,7 +308,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
   private async detectReact(
     projectInfo: ProjectInfo, 
     packageJson: any, 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -348,7 +395,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
   private async detectVue(
     projectInfo: ProjectInfo, 
     packageJson: any, 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -374,7 +421,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check config files
-    if (projectPath && await this.fileScanner.fileExists(projectPath, 'vue.config.js')) {
+    if (project && await this.fileScanner.fileExists(project, 'vue.config.js')) {
       evidence.push({
         type: 'config_file',
         source: 'vue.config.js',
@@ -425,7 +472,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
   private async detectAngular(
     projectInfo: ProjectInfo, 
     packageJson: any, 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -465,7 +512,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check config files
-    if (projectPath && await this.fileScanner.fileExists(projectPath, 'angular.json')) {
+    if (project && await this.fileScanner.fileExists(project, 'angular.json')) {
       evidence.push({
         type: 'config_file',
         source: 'angular.json',
@@ -526,7 +573,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
   private async detectNextJS(
     projectInfo: ProjectInfo, 
     packageJson: any, 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -572,8 +619,8 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check config files
-    if (projectPath) {
-      const configFiles = await this.fileScanner.findConfigFiles(projectPath, [
+    if (project) {
+      const configFiles = await this.fileScanner.findConfigFiles(project, [
         'next.config.js', 'next.config.ts', 'next.config.mjs'
       ]);
       configFiles.forEach(file => {
@@ -629,7 +676,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
   private async detectExpress(
     projectInfo: ProjectInfo, 
     packageJson: any, 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -688,7 +735,7 @@ export class NodeJSAnalyzer extends BaseLanguageAnalyzer {
   private async detectNestJS(
     projectInfo: ProjectInfo, 
     packageJson: any, 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
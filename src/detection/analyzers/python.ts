@@ -4,7 +4,9 @@ import { FrameworkInfo, BuildToolInfo, FrameworkType } from '../interfaces/frame
 import { CIStep } from '../interfaces/ci-pipeline';
 import { Evidence } from '../interfaces/evidence';
 import { FileSystemScanner } from '../utils/file-scanner';
+import { ProjectFileSystem } from '../utils/project-fs';
 import { EvidenceCollectorImpl } from '../utils/evidence-collector';
+import { parseVersionConstraint } from '../utils/version-constraint';
 
 /**
  * Python framework analyzer
@@ -29,7 +31,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
            this.hasCommand(projectInfo, 'poetry');
   }
 
-  async analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult> {
+  async analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult> {
     const startTime = Date.now();
     const frameworks: FrameworkInfo[] = [];
     const buildTools: BuildToolInfo[] = [];
@@ -39,10 +41,10 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
 
     try {
       // Parse Python dependency files
-      const dependencyData = await this.parseDependencyFiles(projectInfo, projectPath, filesAnalyzed, warnings);
+      const dependencyData = await this.parseDependencyFiles(projectInfo, project, filesAnalyzed, warnings);
 
       // Detect package manager and virtual environment
-      const packageManager = await this.detectPackageManager(projectInfo, projectPath, dependencyData);
+      const packageManager = await this.detectPackageManager(projectInfo, project, dependencyData);
       if (packageManager) {
         buildTools.push(packageManager);
       }
@@ -51,7 +53,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
       const pythonVersion = this.extractPythonVersion(dependencyData);
 
       // Detect frameworks
-      const detectedFrameworks = await this.detectFrameworks(projectInfo, dependencyData, projectPath, pythonVersion);
+      const detectedFrameworks = await this.detectFrameworks(projectInfo, dependencyData, project, pythonVersion);
       frameworks.push(...detectedFrameworks);
       
       // Track patterns matched
@@ -62,11 +64,14 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
       // Generate recommendations
       const recommendations = this.generateRecommendations(frameworks, dependencyData, packageManager);
 
+      const requiresPython = dependencyData.pyprojectToml?.pythonVersion;
+
       return {
         frameworks,
         buildTools,
         confidence: this.calculateConfidence(frameworks, buildTools),
         recommendations,
+        ...(requiresPython && { versionConstraint: parseVersionConstraint('python', requiresPython, 'pyproject.toml') }),
         metadata: {
           executionTime: Date.now() - startTime,
           filesAnalyzed,
@@ -97,7 +102,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
    */
   private async parseDependencyFiles(
     projectInfo: ProjectInfo, 
-    projectPath?: string, 
+    project?: string | ProjectFileSystem, 
     filesAnalyzed: string[] = [], 
     warnings: string[] = []
   ): Promise<PythonDependencyData> {
@@ -109,14 +114,14 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
       pythonVersion: null
     };
 
-    if (!projectPath) {
+    if (!project) {
       return dependencyData;
     }
 
     // Parse requirements.txt
-    if (await this.fileScanner.fileExists(projectPath, 'requirements.txt')) {
+    if (await this.fileScanner.fileExists(project, 'requirements.txt')) {
       try {
-        const content = await this.fileScanner.readConfigFile(`${projectPath}/requirements.txt`);
+        const content = await this.fileScanner.readConfigFile('requirements.txt', project);
         dependencyData.requirements = this.parseRequirementsTxt(content);
         filesAnalyzed.push('requirements.txt');
       } catch (error) {
@@ -125,9 +130,9 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Parse setup.py
-    if (await this.fileScanner.fileExists(projectPath, 'setup.py')) {
+    if (await this.fileScanner.fileExists(project, 'setup.py')) {
       try {
-        const content = await this.fileScanner.readConfigFile(`${projectPath}/setup.py`);
+        const content = await this.fileScanner.readConfigFile('setup.py', project);
         dependencyData.setupPy = this.parseSetupPy(content);
         filesAnalyzed.push('setup.py');
       } catch (error) {
@@ -136,9 +141,9 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Parse Pipfile
-    if (await this.fileScanner.fileExists(projectPath, 'Pipfile')) {
+    if (await this.fileScanner.fileExists(project, 'Pipfile')) {
       try {
-        const content = await this.fileScanner.readConfigFile(`${projectPath}/Pipfile`);
+        const content = await this.fileScanner.readConfigFile('Pipfile', project);
         dependencyData.pipfile = this.parsePipfile(content);
         filesAnalyzed.push('Pipfile');
       } catch (error) {
@@ -147,9 +152,9 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Parse pyproject.toml
-    if (await this.fileScanner.fileExists(projectPath, 'pyproject.toml')) {
+    if (await this.fileScanner.fileExists(project, 'pyproject.toml')) {
       try {
-        const content = await this.fileScanner.readConfigFile(`${projectPath}/pyproject.toml`);
+        const content = await this.fileScanner.readConfigFile('pyproject.toml', project);
         dependencyData.pyprojectToml = this.parsePyprojectToml(content);
         filesAnalyzed.push('pyproject.toml');
       } catch (error) {
@@ -291,6 +296,11 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
       buildSystem: null
     };
 
+    // Parsed TOML exposes requires-python under [project]
+    if (typeof content === 'object' && typeof content?.project?.['requires-python'] === 'string') {
+      data.pythonVersion = content.project['requires-python'];
+    }
+
     // If content is already parsed as TOML object
     if (typeof content === 'object' && content._format === 'toml') {
       // For now, return empty data since we don't have TOML parser
@@ -364,7 +374,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
    */
   private async detectPackageManager(
     projectInfo: ProjectInfo, 
-    projectPath?: string, 
+    project?: string | ProjectFileSystem, 
     dependencyData?: PythonDependencyData
   ): Promise<BuildToolInfo | null> {
     const evidence: Evidence[] = [];
@@ -408,8 +418,9 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check file system if path provided
-    if (projectPath) {
-      const configFiles = await this.fileScanner.findConfigFiles(projectPath, [
+    let configFiles: string[] = [];
+    if (project) {
+      configFiles = await this.fileScanner.findConfigFiles(project, [
         'poetry.lock', 'Pipfile.lock', 'requirements.txt'
       ]);
       
@@ -464,14 +475,29 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
       return null;
     }
 
+    const lockFile = this.getPackageManagerLockFile(primaryManager);
+
     return {
       name: primaryManager,
       configFile: this.getPackageManagerConfigFile(primaryManager),
+      ...(configFiles.includes(lockFile) && { lockFile }),
       commands: this.getPackageManagerCommands(primaryManager),
       confidence: Math.min(primaryManagerScore / evidence.length, 1.0)
     };
   }
 
+  /**
+   * Get the file that pins dependency versions for a package manager
+   */
+  private getPackageManagerLockFile(manager: string): string {
+    const lockFiles = {
+      poetry: 'poetry.lock',
+      pipenv: 'Pipfile.lock',
+      pip: 'requirements.txt'
+    };
+    return lockFiles[manager as keyof typeof lockFiles] || 'requirements.txt';
+  }
+
   /**
    * Get config file for package manager
    */
@@ -516,7 +542,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
   private async detectFrameworks(
     projectInfo: ProjectInfo, 
     dependencyData: PythonDependencyData, 
-    projectPath?: string,
+    project?: string | ProjectFileSystem,
     pythonVersion?: string | null
   ): Promise<FrameworkInfo[]> {
     const frameworks: FrameworkInfo[] = [];
@@ -525,15 +551,15 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
     const allDependencies = this.getAllDependencies(dependencyData);
 
     // Django detection
-    const djangoFramework = await this.detectDjango(projectInfo, allDependencies, projectPath);
+    const djangoFramework = await this.detectDjango(projectInfo, allDependencies, project);
     if (djangoFramework) frameworks.push(djangoFramework);
 
     // Flask detection
-    const flaskFramework = await this.detectFlask(projectInfo, allDependencies, projectPath);
+    const flaskFramework = await this.detectFlask(projectInfo, allDependencies, project);
     if (flaskFramework) frameworks.push(flaskFramework);
 
     // FastAPI detection
-    const fastapiFramework = await this.detectFastAPI(projectInfo, allDependencies, projectPath);
+    const fastapiFramework = await this.detectFastAPI(projectInfo, allDependencies, project);
     if (fastapiFramework) frameworks.push(fastapiFramework);
 
     // Add Python version to all frameworks
@@ -574,7 +600,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
   private async detectDjango(
     projectInfo: ProjectInfo, 
     dependencies: PythonDependency[], 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -590,9 +616,9 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check for Django-specific files
-    if (projectPath) {
+    if (project) {
       try {
-        if (await this.fileScanner.fileExists(projectPath, 'manage.py')) {
+        if (await this.fileScanner.fileExists(project, 'manage.py')) {
           evidence.push({
             type: 'file_pattern',
             source: 'manage.py',
@@ -604,7 +630,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
         // Check for settings.py in common locations
         const settingsLocations = ['settings.py', 'settings/settings.py', '*/settings.py'];
         for (const location of settingsLocations) {
-          if (await this.fileScanner.fileExists(projectPath, location)) {
+          if (await this.fileScanner.fileExists(project, location)) {
             evidence.push({
               type: 'file_pattern',
               source: location,
@@ -659,7 +685,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
   private async detectFlask(
     projectInfo: ProjectInfo, 
     dependencies: PythonDependency[], 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -675,12 +701,12 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check for Flask-specific patterns in common files
-    if (projectPath) {
+    if (project) {
       const commonFiles = ['app.py', 'main.py', 'run.py', 'wsgi.py'];
       for (const file of commonFiles) {
-        if (await this.fileScanner.fileExists(projectPath, file)) {
+        if (await this.fileScanner.fileExists(project, file)) {
           try {
-            const content = await this.fileScanner.readConfigFile(`${projectPath}/${file}`);
+            const content = await this.fileScanner.readConfigFile(file, project);
             if (typeof content === 'string' && content.includes('from flask import')) {
               evidence.push({
                 type: 'import_statement',
@@ -737,7 +763,7 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
   private async detectFastAPI(
     projectInfo: ProjectInfo, 
     dependencies: PythonDependency[], 
-    projectPath?: string
+    project?: string | ProjectFileSystem
   ): Promise<FrameworkInfo | null> {
     const evidence: Evidence[] = [];
 
@@ -753,12 +779,12 @@ export class PythonAnalyzer extends BaseLanguageAnalyzer {
     }
 
     // Check for FastAPI-specific patterns in common files
-    if (projectPath) {
+    if (project) {
       const commonFiles = ['main.py', 'app.py', 'api.py', 'server.py'];
       for (const file of commonFiles) {
-        if (await this.fileScanner.fileExists(projectPath, file)) {
+        if (await this.fileScanner.fileExists(project, file)) {
           try {
-            const content = await this.fileScanner.readConfigFile(`${projectPath}/${file}`);
+            const content = await this.fileScanner.readConfigFile(file, project);
             if (typeof content === 'string' && content.includes('from fastapi import')) {
               evidence.push({
                 type: 'import_statement',
@@ -3,6 +3,7 @@ import { LanguageDetectionResult, ProjectInfo } from '../interfaces/language-ana
 import { FrameworkInfo, BuildToolInfo } from '../interfaces/framework-info';
 import { CIStep } from '../interfaces/ci-pipeline';
 import { FileSystemScanner } from '../utils/file-scanner';
+import { ProjectFileSystem } from '../utils/project-fs';
 import { Evidence } from '../interfaces/evidence';
 
 /**
@@ -22,7 +23,7 @@ export class RustAnalyzer extends BaseLanguageAnalyzer {
            this.hasCommand(projectInfo, 'cargo');
   }
 
-  async analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult> {
+  async analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult> {
     const startTime = Date.now();
     const frameworks: FrameworkInfo[] = [];
     const buildTools: BuildToolInfo[] = [];
@@ -34,17 +35,17 @@ export class RustAnalyzer extends BaseLanguageAnalyzer {
     let hasCargoLock = false;
 
     try {
-      if (!projectPath) {
+      if (!project) {
         warnings.push('No project path provided, skipping file system analysis');
         return this.createMinimalResult(startTime, warnings, recommendations);
       }
 
       // Check for Cargo.toml
-      hasCargoToml = await this.fileScanner.fileExists(projectPath, 'Cargo.toml');
+      hasCargoToml = await this.fileScanner.fileExists(project, 'Cargo.toml');
       if (hasCargoToml) {
         filesAnalyzed.push('Cargo.toml');
         try {
-          const cargoToml = await this.fileScanner.readConfigFile(`${projectPath}/Cargo.toml`);
+          const cargoToml = await this.fileScanner.readConfigFile('Cargo.toml', project);
           
           // Extract Rust edition and version
           const rustEdition = cargoToml.package?.edition;
@@ -90,7 +91,7 @@ export class RustAnalyzer extends BaseLanguageAnalyzer {
       }
 
       // Check for Cargo.lock for reproducible builds
-      hasCargoLock = await this.fileScanner.fileExists(projectPath, 'Cargo.lock');
+      hasCargoLock = await this.fileScanner.fileExists(project, 'Cargo.lock');
       if (hasCargoLock) {
         filesAnalyzed.push('Cargo.lock');
         patternsMatched.push('cargo_lock_present');
@@ -100,6 +101,7 @@ export class RustAnalyzer extends BaseLanguageAnalyzer {
         const cargoBuildTool = buildTools.find(tool => tool.name === 'cargo');
         if (cargoBuildTool) {
           cargoBuildTool.confidence = 0.95;
+          cargoBuildTool.lockFile = 'Cargo.lock';
         }
       }
 
@@ -0,0 +1,91 @@
+import { ApiSchemaInfo } from './interfaces/framework-info';
+import { SKIPPED_DIRECTORIES } from './monorepo-detector';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * How deep below the project root schema files are looked for
+ */
+const MAX_SCHEMA_DEPTH = 4;
+
+/**
+ * Names of OpenAPI documents: openapi.yaml, swagger.json, api.openapi.yml and the like
+ */
+const OPENAPI_FILE = /(^|\.)(openapi|swagger)(\.[\w-]+)?\.(ya?ml|json)$/i;
+
+/**
+ * Top-level version key an OpenAPI 3 or Swagger 2 document opens with, in YAML or JSON
+ */
+const OPENAPI_VERSION = /^[\s{]*["']?(openapi|swagger)["']?\s*:\s*["']?[23]\./m;
+
+/**
+ * Extensions of GraphQL documents
+ */
+const GRAPHQL_FILE = /\.(graphqls?|gql)$/i;
+
+/**
+ * Type system definitions, which tell a schema from a document of queries
+ */
+const GRAPHQL_DEFINITION = /^\s*(extend\s+)?(schema|type|interface|input|enum|scalar|union|directive)\b/m;
+
+/**
+ * Spectral ruleset files, which spectral lint reads from the project root
+ */
+const SPECTRAL_RULESETS = ['.spectral.yaml', '.spectral.yml', '.spectral.json', '.spectral.js'];
+
+/**
+ * Finds the API contracts of a project: OpenAPI documents and GraphQL schemas
+ */
+export class ApiSchemaDetector {
+  /**
+   * Detect the OpenAPI documents (named openapi or swagger, declaring an OpenAPI 3 or Swagger 2
+   * version) and GraphQL schemas (.graphql files with type definitions, not just operations) of a
+   * project, given as a directory or a file system, and the Spectral ruleset at its root.
+   * Undefined when it has neither.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<ApiSchemaInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const openapi: string[] = [];
+    const graphql: string[] = [];
+
+    const visit = async (directory: string, depth: number): Promise<void> => {
+      const entries = await files.readdir(directory).catch(() => []);
+      for (const entry of entries) {
+        const path = directory === '.' ? entry.name : `${directory}/${entry.name}`;
+        if (entry.isDirectory()) {
+          if (depth < MAX_SCHEMA_DEPTH && !entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name)) {
+            await visit(path, depth + 1);
+          }
+        } else if (entry.isFile() && OPENAPI_FILE.test(entry.name)) {
+          if (OPENAPI_VERSION.test(await files.readFile(path).catch(() => ''))) {
+            openapi.push(path);
+          }
+        } else if (entry.isFile() && GRAPHQL_FILE.test(entry.name)) {
+          if (GRAPHQL_DEFINITION.test((await files.readFile(path).catch(() => '')).replace(/#.*$/gm, ''))) {
+            graphql.push(path);
+          }
+        }
+      }
+    };
+    await visit('.', 0);
+
+    if (openapi.length === 0 && graphql.length === 0) {
+      return undefined;
+    }
+    let spectralRuleset: string | undefined;
+    for (const ruleset of SPECTRAL_RULESETS) {
+      if (await files.exists(ruleset)) {
+        spectralRuleset = ruleset;
+        break;
+      }
+    }
+    return { openapi: openapi.sort(), graphql: graphql.sort(), ...(spectralRuleset && { spectralRuleset }) };
+  }
+}
+
+registerDetector('api-schemas', {
+  async detect(files: ProjectFileSystem) {
+    const apiSchemas = await new ApiSchemaDetector().detect(files);
+    return apiSchemas ? [{ fields: { apiSchemas }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
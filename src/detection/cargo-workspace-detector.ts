@@ -0,0 +1,211 @@
+import * as toml from '@iarna/toml';
+import { CargoWorkspaceInfo, CargoWorkspaceMember } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+import { toPosixPath } from '../shared/input-normalization';
+
+/**
+ * Dependency tables whose path entries Cargo pulls into the workspace
+ */
+const DEPENDENCY_TABLES = ['dependencies', 'dev-dependencies', 'build-dependencies'];
+
+/**
+ * Reads the member crates of the Cargo workspace at a project root
+ */
+export class CargoWorkspaceDetector {
+  /**
+   * Resolve the workspace declared in the Cargo.toml at the root of a project, given as a directory or
+   * a file system; undefined when there is no [workspace] table.
+   * Members come from `members` globs and from path dependencies inside the workspace directory,
+   * minus `exclude`; path dependencies outside it are reported but not treated as members.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<CargoWorkspaceInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const root = await this.readManifest(files, '.');
+    if (!root?.workspace) {
+      return undefined;
+    }
+
+    const workspace = root.workspace as Record<string, any>;
+    const excluded = this.readStringList(workspace.exclude).map(normalizePath);
+    const isExcluded = (memberPath: string) =>
+      excluded.some(entry => memberPath === entry || memberPath.startsWith(`${entry}/`));
+
+    const members = new Map<string, CargoWorkspaceMember>();
+    const externalPathDependencies = new Set<string>();
+    const queue: Array<{ path: string; manifest: Record<string, any> }> = [];
+
+    const addMember = (memberPath: string, manifest: Record<string, any>) => {
+      if (members.has(memberPath) || isExcluded(memberPath) || !manifest.package) {
+        return;
+      }
+      members.set(memberPath, {
+        name: typeof manifest.package.name === 'string' ? manifest.package.name : memberPath.split('/').pop()!,
+        path: memberPath
+      });
+      queue.push({ path: memberPath, manifest });
+    };
+
+    addMember('.', root);
+
+    for (const pattern of this.readStringList(workspace.members)) {
+      for (const memberPath of await this.expandMemberPattern(files, normalizePath(pattern))) {
+        if (isOutsideWorkspace(memberPath)) {
+          externalPathDependencies.add(memberPath);
+          continue;
+        }
+        const manifest = await this.readManifest(files, memberPath);
+        if (manifest) {
+          addMember(memberPath, manifest);
+        }
+      }
+    }
+
+    // Path dependencies residing in the workspace directory become members as well
+    while (queue.length > 0) {
+      const { path: memberPath, manifest } = queue.shift()!;
+      for (const dependencyPath of this.readPathDependencies(manifest)) {
+        const resolved = normalizePath(memberPath === '.' ? dependencyPath : `${memberPath}/${dependencyPath}`);
+        if (isOutsideWorkspace(resolved)) {
+          externalPathDependencies.add(resolved);
+          continue;
+        }
+        const dependency = await this.readManifest(files, resolved);
+        if (dependency) {
+          addMember(resolved, dependency);
+        }
+      }
+    }
+
+    const features = Object.keys(root.features || {}).filter(feature => feature !== 'default');
+
+    return {
+      members: [...members.values()].sort((a, b) => a.path.localeCompare(b.path)),
+      excluded,
+      externalPathDependencies: [...externalPathDependencies].sort(),
+      features,
+      virtual: !root.package
+    };
+  }
+
+  /**
+   * Expand a `members` entry into workspace-relative directories. Each path segment may use
+   * the `*` and `?` wildcards Cargo accepts; literal entries are returned as they are.
+   */
+  private async expandMemberPattern(files: ProjectFileSystem, pattern: string): Promise<string[]> {
+    if (!/[*?]/.test(pattern)) {
+      return [pattern];
+    }
+
+    let candidates = ['.'];
+    for (const segment of pattern.split('/')) {
+      const next: string[] = [];
+      for (const candidate of candidates) {
+        if (!/[*?]/.test(segment)) {
+          next.push(candidate === '.' ? segment : `${candidate}/${segment}`);
+          continue;
+        }
+
+        const matcher = new RegExp(`^${segment.replace(/[.+^${}()|[\]\\]/g, '\\$&').replace(/\*/g, '.*').replace(/\?/g, '.')}$`);
+        let entries;
+        try {
+          entries = await files.readdir(candidate);
+        } catch {
+          continue;
+        }
+        for (const entry of entries) {
+          if (entry.isDirectory() && !entry.name.startsWith('.') && matcher.test(entry.name)) {
+            next.push(candidate === '.' ? entry.name : `${candidate}/${entry.name}`);
+          }
+        }
+      }
+      candidates = next;
+    }
+
+    return candidates.sort();
+  }
+
+  private readPathDependencies(manifest: Record<string, any>): string[] {
+    const paths: string[] = [];
+    const tables = [
+      manifest,
+      // [target.'cfg(unix)'.dependencies] and similar
+      ...Object.values(manifest.target || {}) as Record<string, any>[]
+    ];
+
+    for (const table of tables) {
+      for (const name of DEPENDENCY_TABLES) {
+        for (const dependency of Object.values(table?.[name] || {}) as any[]) {
+          if (dependency && typeof dependency === 'object' && typeof dependency.path === 'string') {
+            paths.push(dependency.path);
+          }
+        }
+      }
+    }
+
+    return paths;
+  }
+
+  private async readManifest(files: ProjectFileSystem, memberPath: string): Promise<Record<string, any> | undefined> {
+    const manifestPath = memberPath === '.' ? 'Cargo.toml' : `${memberPath}/Cargo.toml`;
+    let content: string;
+    try {
+      content = await files.readFile(manifestPath);
+    } catch {
+      return undefined;
+    }
+
+    try {
+      return toml.parse(content) as Record<string, any>;
+    } catch (error) {
+      throw new Error(`Failed to parse ${manifestPath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  private readStringList(value: unknown): string[] {
+    return Array.isArray(value) ? value.filter((entry): entry is string => typeof entry === 'string') : [];
+  }
+}
+
+/**
+ * Normalize a workspace-relative path to POSIX form without `.` segments or trailing slashes,
+ * resolving `..` so paths outside the workspace start with `../`
+ */
+function normalizePath(path: string): string {
+  const segments: string[] = [];
+  for (const segment of toPosixPath(path).split('/')) {
+    if (segment === '' || segment === '.') {
+      continue;
+    }
+    if (segment === '..' && segments.length > 0 && segments[segments.length - 1] !== '..') {
+      segments.pop();
+    } else {
+      segments.push(segment);
+    }
+  }
+  return segments.length > 0 ? segments.join('/') : '.';
+}
+
+function isOutsideWorkspace(path: string): boolean {
+  return path === '..' || path.startsWith('../');
+}
+
+registerDetector('cargo-workspace', {
+  async detect(files: ProjectFileSystem) {
+    const cargoWorkspace = await new CargoWorkspaceDetector().detect(files);
+    if (!cargoWorkspace) {
+      return [];
+    }
+    return [{
+      fields: { cargoWorkspace },
+      confidence: BUILTIN_DETECTOR_CONFIDENCE,
+      warnings: cargoWorkspace.externalPathDependencies.map(dependency => ({
+        type: 'incomplete' as const,
+        message: `Path dependency ${dependency} lies outside the Cargo workspace - it is built through its dependents but not tested on its own`,
+        affected: ['cargo'],
+        code: 'CARGO_EXTERNAL_PATH_DEPENDENCY' as const,
+        path: dependency
+      }))
+    }];
+  }
+});
@@ -0,0 +1,39 @@
+import { ChangelogInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Changelog files looked up at a project root, in priority order, matched whatever their case
+ */
+const CHANGELOG_FILES = [
+  'changelog.md', 'changelog', 'changelog.rst', 'changelog.txt',
+  'changes.md', 'changes', 'changes.rst',
+  'history.md', 'history.rst',
+  'news.md', 'news', 'news.rst'
+];
+
+/**
+ * Finds the changelog kept at a project root
+ */
+export class ChangelogDetector {
+  /**
+   * Detect the changelog of a project, given as a directory or a file system: the first file
+   * at its root named as changelogs usually are (CHANGELOG.md, CHANGES.rst, HISTORY.md, NEWS),
+   * by its name on disk. Undefined when it has none.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<ChangelogInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const entries = await files.readdir('.').catch(() => []);
+    const names = new Map(entries.filter(entry => entry.isFile()).map(entry => [entry.name.toLowerCase(), entry.name]));
+
+    const file = CHANGELOG_FILES.map(candidate => names.get(candidate)).find(name => name !== undefined);
+    return file ? { file } : undefined;
+  }
+}
+
+registerDetector('changelog', {
+  async detect(files: ProjectFileSystem) {
+    const changelog = await new ChangelogDetector().detect(files);
+    return changelog ? [{ fields: { changelog }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
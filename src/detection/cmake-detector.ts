@@ -0,0 +1,142 @@
+import { BuildToolInfo, CMakeProjectInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Directory a project is configured into when no preset names one
+ */
+export const DEFAULT_CMAKE_BINARY_DIR = 'build';
+
+/**
+ * CMake release that introduced each CMakePresets.json schema version
+ */
+const PRESET_SCHEMA_VERSIONS: Record<number, string> = {
+  1: '3.19', 2: '3.20', 3: '3.21', 4: '3.23', 5: '3.24', 6: '3.25', 7: '3.27', 8: '3.28', 9: '3.30', 10: '3.31'
+};
+
+/**
+ * Configure preset names used for CI, preferred over the first visible preset
+ */
+const CI_PRESET_NAMES = ['ci', 'release', 'default'];
+
+/**
+ * Reads the CMake project at a project root
+ */
+export class CMakeDetector {
+  /**
+   * Detect the CMake project of a project, given as a directory or a file system: the version
+   * cmake_minimum_required asks for, the C++ standard it sets, whether the root CMakeLists.txt
+   * enables tests and, with a CMakePresets.json, the presets CI runs. Undefined when there is no
+   * CMakeLists.txt.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<CMakeProjectInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    let lists: string;
+    try {
+      lists = await files.readFile('CMakeLists.txt');
+    } catch {
+      return undefined;
+    }
+
+    const withoutComments = lists.replace(/#.*$/gm, '');
+    const minimumVersion = withoutComments.match(/cmake_minimum_required\s*\(\s*VERSION\s+(\d+\.\d+(?:\.\d+)?)/i)?.[1];
+    const cxxStandard = withoutComments.match(/set\s*\(\s*CMAKE_CXX_STANDARD\s+(\d+)\s*\)/i)?.[1] ||
+      withoutComments.match(/\bcxx_std_(\d+)\b/)?.[1];
+    const presets = await this.readPresets(files);
+
+    return {
+      ...(minimumVersion && { minimumVersion }),
+      ...(cxxStandard && { cxxStandard }),
+      tests: /\b(enable_testing\s*\(|include\s*\(\s*CTest\s*\)|add_test\s*\()/i.test(withoutComments) || Boolean(presets?.test),
+      ...(presets && { presets }),
+      binaryDir: presets?.binaryDir || DEFAULT_CMAKE_BINARY_DIR,
+      clangFormat: await files.exists('.clang-format') || await files.exists('_clang-format')
+    };
+  }
+
+  /**
+   * The configure preset CI runs (ci, release or default when the file has one, else the first
+   * visible one) with its build and test presets and the directory it configures into, when
+   * ${sourceDir} and ${presetName} are all that binaryDir uses
+   */
+  private async readPresets(files: ProjectFileSystem): Promise<CMakeProjectInfo['presets']> {
+    let document: any;
+    try {
+      document = JSON.parse(await files.readFile('CMakePresets.json'));
+    } catch {
+      return undefined;
+    }
+
+    const configurePresets: any[] = Array.isArray(document?.configurePresets) ? document.configurePresets : [];
+    const visible = configurePresets.filter(preset => typeof preset?.name === 'string' && !preset.hidden);
+    const configure = CI_PRESET_NAMES.map(name => visible.find(preset => preset.name === name)).find(Boolean) || visible[0];
+    if (!configure) {
+      return undefined;
+    }
+
+    const pick = (presets: unknown) => (Array.isArray(presets) ? presets : [])
+      .find((preset: any) => typeof preset?.name === 'string' && !preset.hidden && preset.configurePreset === configure.name)?.name as string | undefined;
+    const build = pick(document.buildPresets);
+    const test = pick(document.testPresets);
+    const binaryDir = this.resolveBinaryDir(configure, configure.name, configurePresets);
+    const requiredVersion = PRESET_SCHEMA_VERSIONS[Number(document.version)];
+
+    return {
+      configure: configure.name,
+      ...(build && { build }),
+      ...(test && { test }),
+      ...(binaryDir && { binaryDir }),
+      ...(requiredVersion && { requiredVersion })
+    };
+  }
+
+  /**
+   * binaryDir of a preset or the presets it inherits from, relative to the source directory
+   */
+  private resolveBinaryDir(preset: any, presetName: string, presets: any[], seen = new Set<string>()): string | undefined {
+    if (seen.has(preset.name)) {
+      return undefined;
+    }
+    seen.add(preset.name);
+
+    // Macros expand with the name of the preset being configured, not the one defining binaryDir
+    if (typeof preset.binaryDir === 'string') {
+      const binaryDir = preset.binaryDir
+        .replace(/^\$\{sourceDir\}\/?/, '')
+        .replace(/\$\{presetName\}/g, presetName)
+        .replace(/\/+$/, '');
+      return binaryDir && !binaryDir.includes('$') && !binaryDir.startsWith('/') ? binaryDir : undefined;
+    }
+
+    for (const parent of [preset.inherits].flat().filter(name => typeof name === 'string')) {
+      const base = presets.find(candidate => candidate?.name === parent);
+      const binaryDir = base ? this.resolveBinaryDir(base, presetName, presets, seen) : undefined;
+      if (binaryDir) {
+        return binaryDir;
+      }
+    }
+    return undefined;
+  }
+}
+
+registerDetector('cmake', {
+  async detect(files: ProjectFileSystem) {
+    const cmakeProject = await new CMakeDetector().detect(files);
+    if (!cmakeProject) {
+      return [];
+    }
+
+    const binaryDir = cmakeProject.binaryDir;
+    const cmake: BuildToolInfo = {
+      name: 'cmake',
+      configFile: 'CMakeLists.txt',
+      commands: [
+        { name: 'configure', command: `cmake -B ${binaryDir}`, isPrimary: false },
+        { name: 'build', command: `cmake --build ${binaryDir}`, isPrimary: true },
+        ...(cmakeProject.tests ? [{ name: 'test', command: `ctest --test-dir ${binaryDir}`, isPrimary: false }] : [])
+      ],
+      confidence: BUILTIN_DETECTOR_CONFIDENCE
+    };
+    return [{ fields: { cmakeProject, buildTools: [cmake] }, confidence: BUILTIN_DETECTOR_CONFIDENCE }];
+  }
+});
@@ -0,0 +1,147 @@
+import { CodeownersInfo, CodeownersRule } from './interfaces/framework-info';
+import { DetectionWarning } from './interfaces/detection-result';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Where GitHub looks for the CODEOWNERS file, in order; the first one found is used
+ */
+const CODEOWNERS_FILES = ['.github/CODEOWNERS', 'CODEOWNERS', 'docs/CODEOWNERS'];
+
+/**
+ * Owners GitHub accepts: a user (@octocat), a team (@org/team) or an email address
+ */
+const OWNER = /^(@[\w-]+(\/[\w.-]+)?|[^@\s]+@[^@\s]+\.[^@\s]+)$/;
+
+/**
+ * A CODEOWNERS file with the lines GitHub ignores, with a warning for each
+ */
+export interface CodeownersDetectionResult {
+  codeowners: CodeownersInfo;
+  warnings: DetectionWarning[];
+}
+
+/**
+ * Reads the CODEOWNERS file of a repository
+ */
+export class CodeownersDetector {
+  /**
+   * Detect the CODEOWNERS file of a repository, given as a directory or a file system, where
+   * GitHub looks for it: .github/, the root, then docs/. Lines GitHub ignores are left out with
+   * a warning: patterns using negation (!) or character ranges ([ ]), which CODEOWNERS does
+   * not support unlike gitignore, and owners that are neither users, teams nor emails.
+   * Undefined when there is no CODEOWNERS file.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<CodeownersDetectionResult | undefined> {
+    const files = toProjectFileSystem(project);
+    for (const file of CODEOWNERS_FILES) {
+      const content = await files.readFile(file).catch(() => undefined);
+      if (content !== undefined) {
+        return parseCodeowners(file, content);
+      }
+    }
+    return undefined;
+  }
+}
+
+/**
+ * Parse a CODEOWNERS file: a pattern and its owners per line, a word starting with # beginning
+ * a comment. A backslash escapes the character after it, so \# starts a pattern with
+ * # and `\ ` puts a space in one. A pattern without owners leaves its paths unowned.
+ */
+export function parseCodeowners(file: string, content: string): CodeownersDetectionResult {
+  const rules: CodeownersRule[] = [];
+  const warnings: DetectionWarning[] = [];
+  const ignore = (line: number, reason: string) => warnings.push({
+    type: 'incomplete',
+    message: `${file} line ${line} is ignored: ${reason}`,
+    affected: ['codeowners'],
+    code: 'CODEOWNERS_LINE_IGNORED',
+    path: file
+  });
+
+  content.split(/\r?\n/).forEach((text, index) => {
+    const line = index + 1;
+    const [pattern, ...owners] = tokenize(text);
+    if (pattern === undefined) {
+      return;
+    }
+
+    const invalidOwner = owners.find(owner => !OWNER.test(owner));
+    if (pattern.startsWith('!')) {
+      ignore(line, `negated pattern ${pattern} - CODEOWNERS does not support negation`);
+    } else if (/(^|[^\\])\[/.test(pattern)) {
+      ignore(line, `pattern ${pattern} uses a character range - CODEOWNERS does not support them`);
+    } else if (pattern.replace(/\//g, '') === '') {
+      ignore(line, `pattern ${pattern} matches no path`);
+    } else if (invalidOwner) {
+      ignore(line, `${invalidOwner} is not a user, team or email address`);
+    } else {
+      rules.push({ pattern, globs: toCodeownersGlobs(pattern), owners, line });
+    }
+  });
+
+  return { codeowners: { file, rules }, warnings };
+}
+
+/**
+ * paths-filter (picomatch) globs matching the paths a CODEOWNERS pattern does. As in
+ * gitignore, a slash at the start or in the middle anchors the pattern at the root, a
+ * pattern without one matches at any depth, a trailing slash matches a directory's contents
+ * and a name matches a file or everything below a directory of that name. Unlike gitignore,
+ * a wildcard in the last part matches files only: docs/* matches docs/setup.md but not
+ * docs/guides/setup.md.
+ */
+export function toCodeownersGlobs(pattern: string): string[] {
+  const directory = pattern.endsWith('/');
+  const trimmed = pattern.replace(/\/+$/, '');
+  const anchored = trimmed.includes('/');
+  const path = trimmed.replace(/^\/+/, '');
+  const glob = anchored || path.startsWith('**') ? path : `**/${path}`;
+
+  if (directory) {
+    return [`${glob}/**`];
+  }
+  const last = path.split('/').pop()!;
+  return /(^|[^\\])[*?]/.test(last) ? [glob] : [glob, `${glob}/**`];
+}
+
+/**
+ * Whitespace-separated tokens of a line, up to a comment. Escaped spaces and #s become plain
+ * characters; other escapes are kept, as they escape glob characters in picomatch too.
+ */
+function tokenize(text: string): string[] {
+  const tokens: string[] = [];
+  let token = '';
+
+  for (let i = 0; i < text.length; i++) {
+    const char = text[i]!;
+    if (char === '\\' && i + 1 < text.length) {
+      const next = text[++i]!;
+      token += next === ' ' || next === '#' ? next : `\\${next}`;
+    } else if (char === ' ' || char === '\t') {
+      if (token) {
+        tokens.push(token);
+        token = '';
+      }
+    } else if (char === '#' && !token) {
+      break;
+    } else {
+      token += char;
+    }
+  }
+
+  if (token) {
+    tokens.push(token);
+  }
+  return tokens;
+}
+
+registerDetector('codeowners', {
+  async detect(files: ProjectFileSystem) {
+    const detected = await new CodeownersDetector().detect(files);
+    return detected
+      ? [{ fields: { codeowners: detected.codeowners }, confidence: BUILTIN_DETECTOR_CONFIDENCE, warnings: detected.warnings }]
+      : [];
+  }
+});
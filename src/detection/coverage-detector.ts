@@ -0,0 +1,146 @@
+import { CoverageInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Files where build and test commands are usually spelled out
+ */
+const COMMAND_FILES = ['Makefile', 'makefile', 'GNUmakefile', 'Taskfile.yml', 'justfile'];
+
+const PYTHON_REQUIREMENTS = ['requirements.txt', 'requirements-dev.txt', 'requirements-test.txt', 'dev-requirements.txt', 'test-requirements.txt'];
+
+const JEST_CONFIGS = ['jest.config.js', 'jest.config.ts', 'jest.config.mjs', 'jest.config.cjs'];
+
+const VITEST_CONFIGS = ['vitest.config.ts', 'vitest.config.js', 'vitest.config.mts', 'vite.config.ts', 'vite.config.js'];
+
+/**
+ * Finds the coverage tooling a project already uses for Go, JavaScript and Python tests
+ */
+export class CoverageDetector {
+  /**
+   * Detect coverage tools configured in a project, given as a directory or a file system, at most one per language.
+   * JavaScript prefers explicit instrumenters (nyc, c8) over the test runner's built-in coverage.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<CoverageInfo[]> {
+    const files = toProjectFileSystem(project);
+    const found: CoverageInfo[] = [];
+
+    const go = await this.detectGo(files);
+    if (go) {
+      found.push(go);
+    }
+    const node = await this.detectNode(files);
+    if (node) {
+      found.push(node);
+    }
+    const python = await this.detectPython(files);
+    if (python) {
+      found.push(python);
+    }
+
+    return found;
+  }
+
+  /**
+   * Go coverage is built in; it counts as configured once a command writes a profile
+   */
+  private async detectGo(files: ProjectFileSystem): Promise<CoverageInfo | undefined> {
+    if (await this.readFile(files, 'go.mod') === undefined) {
+      return undefined;
+    }
+
+    for (const file of COMMAND_FILES) {
+      const content = await this.readFile(files, file);
+      const profile = content?.match(/\bgo test\b[^\n]*-coverprofile[= ](\S+)/)?.[1];
+      if (profile) {
+        return { tool: 'go-cover', language: 'Go', source: file, reportFile: profile };
+      }
+    }
+
+    return undefined;
+  }
+
+  private async detectNode(files: ProjectFileSystem): Promise<CoverageInfo | undefined> {
+    const content = await this.readFile(files, 'package.json');
+    if (content === undefined) {
+      return undefined;
+    }
+
+    let packageJson: any;
+    try {
+      packageJson = JSON.parse(content);
+    } catch (error) {
+      return undefined;
+    }
+
+    const dependencies = { ...packageJson.dependencies, ...packageJson.devDependencies };
+    const scripts = Object.values(packageJson.scripts || {}).filter((script): script is string => typeof script === 'string');
+
+    for (const tool of ['nyc', 'c8'] as const) {
+      const configured = dependencies[tool] || (tool === 'nyc' && (packageJson.nyc || await this.exists(files, ['.nycrc', '.nycrc.json', '.nycrc.yml'])));
+      if (configured || scripts.some(script => new RegExp(`(^|[\\s&;])${tool}\\s`).test(script))) {
+        return { tool, language: 'JavaScript', source: 'package.json' };
+      }
+    }
+
+    const jestConfig = await this.findFirst(files, JEST_CONFIGS);
+    const jestConfigContent = jestConfig ? await this.readFile(files, jestConfig) : undefined;
+    if (packageJson.jest?.collectCoverage || /collectCoverage\s*:\s*true/.test(jestConfigContent || '')) {
+      return { tool: 'jest', language: 'JavaScript', source: packageJson.jest?.collectCoverage ? 'package.json' : jestConfig! };
+    }
+    if (scripts.some(script => /\bjest\b[^&;]*--coverage\b/.test(script))) {
+      return { tool: 'jest', language: 'JavaScript', source: 'package.json' };
+    }
+
+    if (Object.keys(dependencies).some(name => /^@vitest\/coverage-/.test(name)) ||
+      scripts.some(script => /\bvitest\b[^&;]*--coverage\b/.test(script))) {
+      return { tool: 'vitest', language: 'JavaScript', source: 'package.json' };
+    }
+    const vitestConfig = await this.findFirst(files, VITEST_CONFIGS);
+    const vitestConfigContent = vitestConfig ? await this.readFile(files, vitestConfig) : undefined;
+    if (/coverage\s*:\s*\{[^}]*enabled\s*:\s*true/.test(vitestConfigContent || '')) {
+      return { tool: 'vitest', language: 'JavaScript', source: vitestConfig! };
+    }
+
+    return undefined;
+  }
+
+  private async detectPython(files: ProjectFileSystem): Promise<CoverageInfo | undefined> {
+    for (const file of ['pyproject.toml', 'setup.cfg', 'tox.ini', ...PYTHON_REQUIREMENTS]) {
+      const content = await this.readFile(files, file);
+      if (content && (/\bpytest-cov\b/.test(content) || /addopts\s*=.*--cov\b/.test(content))) {
+        return { tool: 'pytest-cov', language: 'Python', source: file };
+      }
+    }
+
+    return undefined;
+  }
+
+  private async readFile(files: ProjectFileSystem, file: string): Promise<string | undefined> {
+    try {
+      return await files.readFile(file);
+    } catch {
+      return undefined;
+    }
+  }
+
+  private async exists(files: ProjectFileSystem, candidates: string[]): Promise<boolean> {
+    return await this.findFirst(files, candidates) !== undefined;
+  }
+
+  private async findFirst(files: ProjectFileSystem, candidates: string[]): Promise<string | undefined> {
+    for (const file of candidates) {
+      if (await files.exists(file)) {
+        return file;
+      }
+    }
+    return undefined;
+  }
+}
+
+registerDetector('coverage', {
+  async detect(files: ProjectFileSystem) {
+    const coverageTools = await new CoverageDetector().detect(files);
+    return coverageTools.length > 0 ? [{ fields: { coverageTools }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
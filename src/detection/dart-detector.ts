@@ -0,0 +1,82 @@
+import * as yaml from 'js-yaml';
+import { BuildToolInfo, DartProjectInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Directories flutter create makes for the platforms an app runs on, each a flutter build target
+ */
+export const FLUTTER_PLATFORMS = ['android', 'ios', 'web', 'linux', 'macos', 'windows'];
+
+/**
+ * Reads the pubspec.yaml of a Dart package or Flutter app at a project root
+ */
+export class DartDetector {
+  /**
+   * Detect the Dart project of a project, given as a directory or a file system: its name, the
+   * SDK constraints of its environment section, whether it is a Flutter project (a flutter
+   * section or the flutter SDK dependency) and the platforms it has directories for, and
+   * whether it has tests. Undefined when there is no pubspec.yaml or it is not a mapping.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<DartProjectInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const content = await files.readFile('pubspec.yaml').catch(() => undefined);
+    if (content === undefined) {
+      return undefined;
+    }
+
+    let pubspec: any;
+    try {
+      pubspec = yaml.load(content);
+    } catch {
+      return undefined;
+    }
+    if (!pubspec || typeof pubspec !== 'object' || Array.isArray(pubspec)) {
+      return undefined;
+    }
+
+    const environment = pubspec.environment && typeof pubspec.environment === 'object' ? pubspec.environment : {};
+    const flutter = pubspec.flutter !== undefined || pubspec.dependencies?.flutter?.sdk === 'flutter';
+    const platforms: string[] = [];
+    if (flutter) {
+      for (const platform of FLUTTER_PLATFORMS) {
+        if (await files.readdir(platform).then(() => true, () => false)) {
+          platforms.push(platform);
+        }
+      }
+    }
+
+    return {
+      ...(typeof pubspec.name === 'string' && { name: pubspec.name }),
+      flutter,
+      ...(typeof environment.sdk === 'string' && { sdkConstraint: environment.sdk }),
+      ...(typeof environment.flutter === 'string' && { flutterConstraint: environment.flutter }),
+      platforms,
+      tests: await files.readdir('test').then(() => true, () => false)
+    };
+  }
+}
+
+registerDetector('dart', {
+  async detect(files: ProjectFileSystem) {
+    const dartProject = await new DartDetector().detect(files);
+    if (!dartProject) {
+      return [];
+    }
+
+    // pub gets the packages pubspec.lock pins; flutter wraps the same commands
+    const tool = dartProject.flutter ? 'flutter' : 'dart';
+    const pub: BuildToolInfo = {
+      name: 'pub',
+      configFile: 'pubspec.yaml',
+      ...(await files.exists('pubspec.lock') && { lockFile: 'pubspec.lock' }),
+      commands: [
+        { name: 'install', command: `${tool} pub get`, isPrimary: false },
+        { name: 'analyze', command: `${tool} analyze`, isPrimary: true },
+        ...(dartProject.tests ? [{ name: 'test', command: `${tool} test`, isPrimary: false }] : [])
+      ],
+      confidence: BUILTIN_DETECTOR_CONFIDENCE
+    };
+    return [{ fields: { dartProject, buildTools: [pub] }, confidence: BUILTIN_DETECTOR_CONFIDENCE }];
+  }
+});
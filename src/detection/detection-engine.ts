@@ -17,6 +17,7 @@ import { DetectionWarning } from './interfaces/detection-result';
 import { DetectionLogger, getLogger, timeOperation } from './utils/logger';
 import { LazyLoader, getLazyLoader } from './performance/lazy-loader';
 import { getConfigManager } from './configuration/config-manager';
+import { ProjectFileSystem } from './utils/project-fs';
 
 /**
  * Core detection engine that orchestrates framework analysis with lazy loading
@@ -121,9 +122,13 @@ export class DetectionEngine {
       return null;
     }
 
-    // Load analyzer lazily
+    // Load analyzer lazily; a concurrent detection may have loaded it in the meantime
     const result = await this.lazyLoader.loadAnalyzer(analyzerName);
     if (result.success && result.module) {
+      const loaded = this.analyzers.find(a => a.ecosystem === ecosystem);
+      if (loaded) {
+        return loaded;
+      }
       this.analyzers.push(result.module);
       return result.module;
     }
@@ -223,9 +228,9 @@ export class DetectionEngine {
   }
 
   /**
-   * Analyze project and detect frameworks
+   * Analyze project and detect frameworks, reading the project directory or file system given
    */
-  async analyze(projectInfo: ProjectInfo | null, projectPath?: string): Promise<Omit<DetectionResult, 'detectedAt' | 'executionTime'>> {
+  async analyze(projectInfo: ProjectInfo | null, project?: string | ProjectFileSystem): Promise<Omit<DetectionResult, 'detectedAt' | 'executionTime'>> {
     if (!projectInfo) {
       throw new DetectionFailureError('ProjectInfo is required for analysis', 'DetectionEngine');
     }
@@ -240,12 +245,12 @@ export class DetectionEngine {
         this.logger.info('DetectionEngine', 'Starting framework detection analysis', {
           projectLanguages: projectInfo.languages,
           configFiles: projectInfo.configFiles?.length || 0,
-          projectPath: projectPath ? '[PROVIDED]' : '[NOT_PROVIDED]'
+          projectPath: project ? '[PROVIDED]' : '[NOT_PROVIDED]'
         });
 
         // Collect evidence from project information with error handling
         const evidenceResult = await ErrorRecovery.withRetry(
-          () => this.evidenceCollector.collectEvidence({ ...projectInfo, languages: projectInfo.languages || [] }, projectPath),
+          () => this.evidenceCollector.collectEvidence({ ...projectInfo, languages: projectInfo.languages || [] }, project),
           { maxAttempts: 2 }
         );
 
@@ -275,7 +280,7 @@ export class DetectionEngine {
 
         const analyzerPromises = applicableAnalyzers.map(async (analyzer): Promise<LanguageDetectionResult | null> => {
           const analyzerResult = await ErrorRecovery.withRetry(
-            () => analyzer.analyze(projectInfo, projectPath),
+            () => analyzer.analyze(projectInfo, project),
             { 
               maxAttempts: 2,
               retryableErrors: ['FILESYSTEM_ERROR', 'PARSE_ERROR']
@@ -384,6 +389,9 @@ export class DetectionEngine {
           configFiles: projectInfo.configFiles || []
         });
 
+        const versionConstraints = aggregatedResults.versionConstraints || [];
+        const usableConstraints = versionConstraints.filter(c => !c.error);
+
         // Combine all warnings - convert everything to DetectionWarning format
         const allWarnings: DetectionWarning[] = [
           // Convert aggregated warnings to DetectionWarning format
@@ -404,6 +412,16 @@ export class DetectionEngine {
             message: w.message,
             affected: w.affectedItems,
             resolution: w.recommendations.join('; ')
+          })),
+          // Unusable version constraints fall back to default versions instead of failing detection
+          ...versionConstraints.filter(c => c.error).map(c => ({
+            type: 'version_mismatch' as const,
+            message: c.error!,
+            affected: [c.source],
+            resolution: 'Default language versions will be used',
+            // Constraints that parsed have ranges; no supported release satisfying them is another matter
+            code: c.ranges.length > 0 ? 'VERSION_UNSATISFIABLE' as const : 'VERSION_UNPARSEABLE' as const,
+            path: c.source
           }))
         ];
 
@@ -421,10 +439,11 @@ export class DetectionEngine {
           containers: [], // Will be populated when container analyzer is implemented
           confidence,
           alternatives,
-          warnings: allWarnings
+          warnings: allWarnings,
+          ...(usableConstraints.length > 0 && { versionConstraints: usableConstraints })
         };
       },
-      { projectPath: projectPath ? '[PROVIDED]' : '[NOT_PROVIDED]' }
+      { projectPath: project ? '[PROVIDED]' : '[NOT_PROVIDED]' }
     );
   }
 
@@ -0,0 +1,129 @@
+import { isAbsolute, relative } from 'path';
+import { DetectionResult } from './interfaces/detection-result';
+import { CIPipeline, CIStep } from './interfaces/ci-pipeline';
+import { DetectionReport, DetectionReportStep } from './interfaces/detection-report';
+import { ecosystemToLanguage } from './utils/detection-signals';
+
+/**
+ * Version of the DetectionReport layout; bumped whenever a field is renamed or removed
+ */
+export const DETECTION_REPORT_SCHEMA_VERSION = 1;
+
+/**
+ * Pipeline stages in the order their steps run
+ */
+const PIPELINE_STAGES: Array<DetectionReportStep['stage']> = ['setup', 'build', 'test', 'security', 'deploy'];
+
+/**
+ * Build the serializable report for a detection result. Paths are made relative to projectPath
+ * and use forward slashes; pipeline supplies the planned steps when given.
+ */
+export function createDetectionReport(
+  result: DetectionResult,
+  pipeline?: CIPipeline,
+  projectPath: string = process.cwd()
+): DetectionReport {
+  const toPath = (path: string) => toReportPath(path, projectPath);
+
+  const languages = new Map<string, number>();
+  for (const framework of result.frameworks) {
+    const language = ecosystemToLanguage(framework.ecosystem);
+    languages.set(language, Math.max(languages.get(language) ?? 0, framework.confidence));
+  }
+
+  return {
+    schemaVersion: DETECTION_REPORT_SCHEMA_VERSION,
+    languages: [...languages.entries()]
+      .map(([name, confidence]) => ({ name, confidence }))
+      .sort((a, b) => b.confidence - a.confidence || a.name.localeCompare(b.name)),
+    frameworks: result.frameworks.map(framework => ({
+      name: framework.name,
+      type: framework.type,
+      ecosystem: framework.ecosystem,
+      ...(framework.version && { version: framework.version }),
+      confidence: framework.confidence,
+      signals: (framework.signals || []).map(signal => ({ kind: signal.kind, source: toPath(signal.source) }))
+    })),
+    packageManagers: result.buildTools.map(tool => ({
+      name: tool.name,
+      configFile: toPath(tool.configFile),
+      ...(tool.lockFile && { lockFile: toPath(tool.lockFile) }),
+      ...(tool.version && { version: tool.version })
+    })),
+    versions: (result.versionConstraints || []).map(constraint => ({
+      runtime: constraint.runtime,
+      source: toPath(constraint.source),
+      raw: constraint.raw,
+      ...(constraint.exact && { exact: constraint.exact }),
+      versions: constraint.versions,
+      ...(constraint.error && { error: constraint.error })
+    })),
+    testRunners: (result.testRunners || []).map(runner => ({
+      name: runner.name,
+      language: runner.language,
+      command: runner.command,
+      source: toPath(runner.source),
+      ...(runner.confidence !== undefined && { confidence: runner.confidence }),
+      ...(runner.suppressedBy && { suppressedBy: runner.suppressedBy })
+    })),
+    dockerImages: (result.dockerImages || []).map(image => ({
+      dockerfile: toPath(image.dockerfile),
+      context: toPath(image.context),
+      ...(image.baseImage && { baseImage: image.baseImage }),
+      ...(image.targetStage && { targetStage: image.targetStage })
+    })),
+    ...(result.makefile && { makefile: { ...result.makefile, file: toPath(result.makefile.file) } }),
+    ...(result.cargoWorkspace && {
+      cargoWorkspace: {
+        ...result.cargoWorkspace,
+        members: result.cargoWorkspace.members.map(member => ({ name: member.name, path: toPath(member.path) }))
+      }
+    }),
+    ...(result.staticSite && {
+      staticSite: {
+        generator: result.staticSite.generator,
+        configFile: toPath(result.staticSite.configFile),
+        outputDir: toPath(result.staticSite.outputDir)
+      }
+    }),
+    ...(result.javaBuild && { javaBuild: result.javaBuild }),
+    ...(result.packageScripts && { packageScripts: result.packageScripts }),
+    confidence: { score: result.confidence.score, level: result.confidence.level },
+    warnings: result.warnings.map(warning => ({
+      type: warning.type,
+      message: warning.message,
+      affected: warning.affected
+    })),
+    diagnostics: (result.diagnostics || []).map(diagnostic => ({
+      ...diagnostic,
+      ...(diagnostic.path && { path: toPath(diagnostic.path) })
+    })),
+    plannedSteps: pipeline
+      ? PIPELINE_STAGES.flatMap(stage => pipeline[stage].map(step => toReportStep(step, stage, toPath)))
+      : []
+  };
+}
+
+function toReportStep(
+  step: CIStep,
+  stage: DetectionReportStep['stage'],
+  toPath: (path: string) => string
+): DetectionReportStep {
+  return {
+    id: step.id,
+    name: step.name,
+    stage,
+    ...(step.command && { command: step.command }),
+    ...(step.uses && { uses: step.uses }),
+    ...(step.workingDirectory && { workingDirectory: toPath(step.workingDirectory) })
+  };
+}
+
+/**
+ * Repository-relative POSIX form of a path; absolute paths are resolved against projectPath
+ */
+function toReportPath(path: string, projectPath: string): string {
+  const resolved = isAbsolute(path) ? relative(projectPath, path) : path;
+  const normalized = resolved.replace(/\\/g, '/').replace(/^(\.\/)+/, '').replace(/\/+$/, '');
+  return normalized || '.';
+}
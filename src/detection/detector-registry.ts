@@ -0,0 +1,155 @@
+import { DetectionResult } from './interfaces/detection-result';
+import { Detection, DetectedFields, Detector } from './interfaces/detector';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+import { PipelineLogger, NOOP_LOGGER } from '../shared/logging/pipeline-logger';
+
+/**
+ * Confidence of the built-in detectors' findings, read from manifests and configuration files.
+ * A registered detector reporting a higher confidence overrides them.
+ */
+export const BUILTIN_DETECTOR_CONFIDENCE = 0.9;
+
+/**
+ * Registered detectors by name, in registration order; the built-in ones register as their modules load
+ */
+const detectors = new Map<string, Detector>();
+
+/**
+ * Fields holding one entry per detected item, with the key identifying an item across detectors
+ */
+const LIST_FIELDS: { [K in keyof DetectedFields]?: (item: any) => string } = {
+  frameworks: item => item.name.toLowerCase(),
+  buildTools: item => item.name.toLowerCase(),
+  dockerImages: item => item.path,
+  testRunners: item => `${item.language}:${item.name}`,
+  coverageTools: item => `${item.language}:${item.tool}`,
+  linters: item => `${item.language}:${item.name}`,
+  languages: item => item.name.toLowerCase(),
+  services: item => item.name
+};
+
+/**
+ * Register a detector run on every scanned project directory.
+ * Registering again under a name replaces the detector.
+ */
+export function registerDetector(name: string, detector: Detector): void {
+  detectors.set(name, detector);
+}
+
+/**
+ * Remove a registered detector; returns whether one was registered under the name
+ */
+export function unregisterDetector(name: string): boolean {
+  return detectors.delete(name);
+}
+
+/**
+ * Names of the registered detectors, in the order they run
+ */
+export function getRegisteredDetectors(): string[] {
+  return [...detectors.keys()];
+}
+
+/**
+ * Run every registered detector on a project, given as a directory or a file system, and merge
+ * what they find into result. A failing detector is reported as a warning rather than failing detection.
+ * Each finding and merge decision is logged at debug level.
+ */
+export async function runDetectors(
+  result: DetectionResult,
+  project: string | ProjectFileSystem,
+  logger: PipelineLogger = NOOP_LOGGER
+): Promise<void> {
+  const files = toProjectFileSystem(project);
+  const detections: Detection[] = [];
+
+  // Detections of several packages run at once; one registering a detector mid-run must not change what the others run
+  for (const [name, detector] of [...detectors]) {
+    try {
+      const found = await detector.detect(files);
+      for (const detection of found) {
+        logger.debug('Detector found fields', { detector: name, confidence: detection.confidence, fields: describeFields(detection.fields) });
+      }
+      detections.push(...found);
+    } catch (error) {
+      logger.warn('Detector failed', { detector: name, error: error instanceof Error ? error.message : String(error) });
+      result.warnings.push({
+        type: 'incomplete',
+        message: `Failed to run ${name} detector: ${error instanceof Error ? error.message : 'Unknown error'}`,
+        affected: [name],
+        code: 'DETECTOR_FAILED'
+      });
+    }
+  }
+
+  mergeDetections(result, detections, logger);
+}
+
+/**
+ * What a detection found, for logs: the keys of list entries, and whether single-valued fields were set
+ */
+function describeFields(fields: DetectedFields): Record<string, string[] | boolean> {
+  const described: Record<string, string[] | boolean> = {};
+  for (const [field, value] of Object.entries(fields)) {
+    const keyOf = LIST_FIELDS[field as keyof DetectedFields];
+    if (value !== undefined) {
+      described[field] = keyOf ? (value as any[]).map(keyOf) : true;
+    }
+  }
+  return described;
+}
+
+/**
+ * Merge detections into result. An entry of a list (a framework, a test runner...) found by
+ * several detectors is kept from the most confident one, judged by the entry's own confidence
+ * when it has one; a single-valued field likewise. Ties keep what was found first.
+ */
+export function mergeDetections(result: DetectionResult, detections: Detection[], logger: PipelineLogger = NOOP_LOGGER): void {
+  // Confidence of what was kept, for fields and for entries without a confidence of their own
+  const confidence = new Map<string, number>();
+  const target = result as Record<string, any>;
+
+  for (const detection of detections) {
+    result.warnings.push(...(detection.warnings || []));
+
+    for (const [field, value] of Object.entries(detection.fields)) {
+      if (value === undefined) {
+        continue;
+      }
+
+      const keyOf = LIST_FIELDS[field as keyof DetectedFields];
+      if (!keyOf) {
+        if (target[field] === undefined || detection.confidence > (confidence.get(field) ?? 0)) {
+          if (target[field] !== undefined) {
+            logger.debug('Replaced field with a more confident detection', { field, confidence: detection.confidence, replaced: confidence.get(field) ?? 0 });
+          }
+          target[field] = value;
+          confidence.set(field, detection.confidence);
+        } else {
+          logger.debug('Kept field over a less confident detection', { field, confidence: confidence.get(field), ignored: detection.confidence });
+        }
+        continue;
+      }
+
+      const merged: any[] = [...(target[field] || [])];
+      for (const item of value as any[]) {
+        const key = `${field}:${keyOf(item)}`;
+        const itemConfidence = item.confidence ?? detection.confidence;
+        const index = merged.findIndex(existing => keyOf(existing) === keyOf(item));
+        if (index < 0) {
+          merged.push(item);
+        } else if (itemConfidence > (merged[index].confidence ?? confidence.get(key) ?? 0)) {
+          logger.debug('Replaced entry with a more confident detection', { field, entry: keyOf(item), confidence: itemConfidence });
+          merged[index] = item;
+        } else {
+          logger.debug('Kept entry over a less confident detection', { field, entry: keyOf(item), ignored: itemConfidence });
+          continue;
+        }
+        confidence.set(key, itemConfidence);
+      }
+      if (merged.length > 0) {
+        target[field] = merged;
+      }
+    }
+  }
+}
@@ -0,0 +1,67 @@
+import { posix } from 'path';
+import { DetectionResult, DetectionWarning } from './interfaces/detection-result';
+import { Diagnostic, DiagnosticCode, DIAGNOSTIC_SEVERITIES, DIAGNOSTIC_SEVERITY_ORDER } from './interfaces/diagnostic';
+
+/**
+ * Codes of warnings that carry none of their own, by warning type
+ */
+const WARNING_TYPE_CODES: Record<DetectionWarning['type'], DiagnosticCode> = {
+  conflict: 'DETECTION_CONFLICT',
+  incomplete: 'DETECTION_INCOMPLETE',
+  version_mismatch: 'VERSION_MISMATCH',
+  deprecated: 'DEPRECATED'
+};
+
+/**
+ * Lockfiles of the package managers that pin dependencies through one, for those whose
+ * lockFile is only set when it was found on disk
+ */
+const PACKAGE_MANAGER_LOCK_FILES: Record<string, string> = {
+  npm: 'package-lock.json',
+  yarn: 'yarn.lock',
+  pnpm: 'pnpm-lock.yaml',
+  bun: 'bun.lockb',
+  bundler: 'Gemfile.lock',
+  mix: 'mix.lock'
+};
+
+/**
+ * The diagnostics of a detection result, errors first: one per warning, coded by the warning's
+ * own code or else its type, and one per package manager without the lockfile it pins
+ * dependencies with. Lockfiles are only looked for when scanned, as a README alone does not
+ * tell whether one is committed.
+ */
+export function collectDiagnostics(result: DetectionResult, scanned: boolean): Diagnostic[] {
+  const diagnostics: Diagnostic[] = result.warnings.map(warning => {
+    const code = warning.code ?? WARNING_TYPE_CODES[warning.type];
+    return {
+      severity: DIAGNOSTIC_SEVERITIES[code],
+      code,
+      message: warning.message,
+      ...(warning.path && { path: warning.path })
+    };
+  });
+
+  if (scanned) {
+    for (const tool of result.buildTools) {
+      const lockFile = PACKAGE_MANAGER_LOCK_FILES[tool.name];
+      if (lockFile && !tool.lockFile) {
+        diagnostics.push({
+          severity: DIAGNOSTIC_SEVERITIES.LOCKFILE_MISSING,
+          code: 'LOCKFILE_MISSING',
+          message: `No ${lockFile} committed for ${tool.name} - dependency versions are not pinned and installs are not reproducible`,
+          path: posix.join(posix.dirname(tool.configFile), lockFile)
+        });
+      }
+    }
+  }
+
+  return sortDiagnostics(diagnostics);
+}
+
+/**
+ * Sort diagnostics errors first; those of a severity keep their order, as the sort is stable
+ */
+export function sortDiagnostics(diagnostics: Diagnostic[]): Diagnostic[] {
+  return diagnostics.sort((a, b) => DIAGNOSTIC_SEVERITY_ORDER.indexOf(a.severity) - DIAGNOSTIC_SEVERITY_ORDER.indexOf(b.severity));
+}
@@ -0,0 +1,179 @@
+import { posix } from 'path';
+import { DockerImageInfo } from './interfaces/framework-info';
+import { DetectionWarning } from './interfaces/detection-result';
+import { SKIPPED_DIRECTORIES } from './monorepo-detector';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * A single FROM instruction
+ */
+export interface DockerStage {
+  /** Image the stage builds on; may name an earlier stage */
+  image: string;
+  /** Stage name from `AS <name>` */
+  name?: string;
+}
+
+/**
+ * Dockerfiles found in a repository, with warnings about their build contexts
+ */
+export interface DockerDetectionResult {
+  images: DockerImageInfo[];
+  warnings: DetectionWarning[];
+}
+
+/**
+ * Check whether a file name is a Dockerfile: `Dockerfile` or a `Dockerfile.<variant>`.
+ * Documentation about a Dockerfile (Dockerfile.md) is not one.
+ */
+export function isDockerfile(fileName: string): boolean {
+  return fileName === 'Dockerfile' ||
+    (/^Dockerfile\.[\w.-]+$/.test(fileName) && !/\.(md|txt)$/i.test(fileName));
+}
+
+/**
+ * Parse the FROM stages of a Dockerfile, skipping comments and joining continued lines
+ */
+export function parseDockerStages(content: string): DockerStage[] {
+  const stages: DockerStage[] = [];
+  const instructions = content
+    .split(/\r?\n/)
+    .filter(line => !line.trim().startsWith('#'))
+    .join('\n')
+    .replace(/\\\r?\n/g, ' ')
+    .split('\n');
+
+  for (const instruction of instructions) {
+    const match = instruction.trim().match(/^FROM\s+(?:--\S+\s+)*(\S+)(?:\s+AS\s+(\S+))?/i);
+    if (match) {
+      stages.push({ image: match[1]!, ...(match[2] && { name: match[2] }) });
+    }
+  }
+
+  return stages;
+}
+
+/**
+ * Walks a repository for Dockerfiles and reads their build stages
+ */
+export class DockerDetector {
+  private maxDepth: number;
+
+  constructor(maxDepth: number = 6) {
+    this.maxDepth = maxDepth;
+  }
+
+  /**
+   * Find every Dockerfile in a project, given as a directory or a file system. Images are sorted by path.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<DockerDetectionResult> {
+    const files = toProjectFileSystem(project);
+    let dockerfiles: string[];
+    try {
+      dockerfiles = await this.findDockerfiles(files, '.', this.maxDepth);
+    } catch (error) {
+      throw new Error(`Failed to scan for Dockerfiles at ${files.root}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+
+    dockerfiles.sort((a, b) => a.localeCompare(b));
+
+    const images: DockerImageInfo[] = [];
+    const warnings: DetectionWarning[] = [];
+    for (const dockerfile of dockerfiles) {
+      const separator = dockerfile.lastIndexOf('/');
+      const context = separator === -1 ? '.' : dockerfile.slice(0, separator);
+
+      let stages: DockerStage[];
+      try {
+        stages = parseDockerStages(await files.readFile(dockerfile));
+      } catch (error) {
+        throw new Error(`Failed to read ${dockerfile}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      }
+
+      const finalStage = stages[stages.length - 1];
+      const baseImage = this.resolveBaseImage(stages);
+      const hasDockerignore = await files.exists(posix.join(context, '.dockerignore'));
+
+      images.push({
+        dockerfile,
+        context,
+        ...(baseImage && { baseImage }),
+        ...(stages.length > 1 && finalStage?.name && { targetStage: finalStage.name }),
+        stageCount: stages.length,
+        hasDockerignore
+      });
+
+      if (!hasDockerignore) {
+        warnings.push({
+          type: 'incomplete',
+          message: `No .dockerignore next to ${dockerfile}; the whole ${context === '.' ? 'repository' : context} directory is sent as build context`,
+          affected: [dockerfile],
+          resolution: 'Add a .dockerignore excluding .git, dependency directories and build output',
+          code: 'DOCKERIGNORE_MISSING',
+          path: dockerfile
+        });
+      }
+    }
+
+    return { images, warnings };
+  }
+
+  /**
+   * Follow the final stage through stage references back to an external image
+   */
+  private resolveBaseImage(stages: DockerStage[]): string | undefined {
+    let image = stages[stages.length - 1]?.image;
+
+    for (let hops = 0; image && hops < stages.length; hops++) {
+      const referenced = stages.find(stage => stage.name?.toLowerCase() === image!.toLowerCase());
+      if (!referenced) {
+        break;
+      }
+      image = referenced.image;
+    }
+
+    return image;
+  }
+
+  private async findDockerfiles(files: ProjectFileSystem, relativePath: string, depth: number): Promise<string[]> {
+    const entries = await files.readdir(relativePath);
+    const found: string[] = [];
+
+    for (const entry of entries) {
+      if (entry.isFile() && isDockerfile(entry.name)) {
+        found.push(relativePath === '.' ? entry.name : `${relativePath}/${entry.name}`);
+      }
+    }
+
+    if (depth <= 0) {
+      return found;
+    }
+
+    for (const entry of entries) {
+      if (!entry.isDirectory() || entry.name.startsWith('.') || SKIPPED_DIRECTORIES.has(entry.name)) {
+        continue;
+      }
+
+      const childPath = relativePath === '.' ? entry.name : `${relativePath}/${entry.name}`;
+      try {
+        found.push(...await this.findDockerfiles(files, childPath, depth - 1));
+      } catch (error) {
+        // Skip subdirectories that can't be read (permission issues)
+      }
+    }
+
+    return found;
+  }
+}
+
+registerDetector('docker', {
+  async detect(files: ProjectFileSystem) {
+    const docker = await new DockerDetector().detect(files);
+    return [{
+      fields: { ...(docker.images.length > 0 && { dockerImages: docker.images }) },
+      confidence: BUILTIN_DETECTOR_CONFIDENCE,
+      warnings: docker.warnings
+    }];
+  }
+});
@@ -0,0 +1,102 @@
+import { posix } from 'path';
+import { BuildToolInfo, DotnetProjectInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+import { toPosixPath } from '../shared/input-normalization';
+
+/**
+ * Extensions of the MSBuild project files the dotnet CLI builds
+ */
+const PROJECT_EXTENSIONS = ['.csproj', '.fsproj', '.vbproj'];
+
+/**
+ * Package references that make a project a test project dotnet test runs
+ */
+const TEST_PACKAGES = /<PackageReference\s+Include="(?:Microsoft\.NET\.Test\.Sdk|xunit|NUnit|MSTest\.TestFramework|MSTest)"/i;
+
+/**
+ * Reads the .NET solution or projects at a project root
+ */
+export class DotnetDetector {
+  /**
+   * Detect the .NET project of a project, given as a directory or a file system: the solution
+   * at the root and the projects it lists (else the project files at the root), the test
+   * projects among them, the target frameworks they declare and the SDK global.json pins.
+   * Undefined when there is no solution, project file or global.json.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<DotnetProjectInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const read = (file: string) => files.readFile(file).catch(() => undefined);
+
+    const rootFiles = (await files.readdir('.').catch(() => []))
+      .filter(entry => entry.isFile())
+      .map(entry => entry.name)
+      .sort();
+    const solution = rootFiles.find(name => name.endsWith('.sln')) || rootFiles.find(name => name.endsWith('.slnx'));
+    const globalJson = await read('global.json');
+    const projects = solution
+      ? this.readSolutionProjects(await read(solution) || '')
+      : rootFiles.filter(name => PROJECT_EXTENSIONS.some(extension => name.endsWith(extension)));
+    if (!solution && projects.length === 0 && globalJson === undefined) {
+      return undefined;
+    }
+
+    const targetFrameworks: string[] = [];
+    const testProjects: string[] = [];
+    let lockFile = false;
+    for (const projectFile of projects) {
+      const content = (await read(projectFile) || '').replace(/<!--[\s\S]*?-->/g, '');
+      const declared = content.match(/<TargetFrameworks?>([^<]*)<\/TargetFrameworks?>/)?.[1] || '';
+      targetFrameworks.push(...declared.split(';').map(framework => framework.trim()).filter(framework => framework !== ''));
+      if (TEST_PACKAGES.test(content) || /<IsTestProject>\s*true\s*<\/IsTestProject>/i.test(content)) {
+        testProjects.push(projectFile);
+      }
+      lockFile = lockFile || await files.exists(posix.join(posix.dirname(projectFile), 'packages.lock.json'));
+    }
+    const sdkVersion = globalJson?.match(/"sdk"\s*:\s*\{[^}]*"version"\s*:\s*"([^"]+)"/)?.[1];
+
+    return {
+      ...(solution && { solution }),
+      projects,
+      testProjects,
+      targetFrameworks: [...new Set(targetFrameworks)],
+      ...(sdkVersion && { sdkVersion }),
+      lockFile
+    };
+  }
+
+  /**
+   * Project files a solution lists, as POSIX paths; solution folders are left out. Reads both
+   * the classic .sln format and the XML of .slnx.
+   */
+  private readSolutionProjects(solution: string): string[] {
+    const projectExtensions = PROJECT_EXTENSIONS.map(extension => extension.slice(1)).join('|');
+    const classic = new RegExp(`^\\s*Project\\("[^"]*"\\)\\s*=\\s*"[^"]*"\\s*,\\s*"([^"]+\\.(?:${projectExtensions}))"`, 'gm');
+    const xml = new RegExp(`<Project\\s[^>]*Path="([^"]+\\.(?:${projectExtensions}))"`, 'g');
+    return [...new Set([...solution.matchAll(classic), ...solution.matchAll(xml)].map(match => toPosixPath(match[1]!)))];
+  }
+}
+
+registerDetector('dotnet', {
+  async detect(files: ProjectFileSystem) {
+    const dotnetProject = await new DotnetDetector().detect(files);
+    if (!dotnetProject) {
+      return [];
+    }
+
+    // The dotnet CLI restores the NuGet packages; packages.lock.json pins them per project
+    const target = dotnetProject.solution ? ` ${dotnetProject.solution}` : '';
+    const dotnet: BuildToolInfo = {
+      name: 'dotnet',
+      configFile: dotnetProject.solution || dotnetProject.projects[0] || 'global.json',
+      ...(dotnetProject.lockFile && { lockFile: 'packages.lock.json' }),
+      commands: [
+        { name: 'restore', command: `dotnet restore${target}`, isPrimary: false },
+        { name: 'build', command: `dotnet build${target} --no-restore`, isPrimary: true },
+        ...(dotnetProject.testProjects.length > 0 ? [{ name: 'test', command: `dotnet test${target} --no-build`, isPrimary: false }] : [])
+      ],
+      confidence: BUILTIN_DETECTOR_CONFIDENCE
+    };
+    return [{ fields: { dotnetProject, buildTools: [dotnet] }, confidence: BUILTIN_DETECTOR_CONFIDENCE }];
+  }
+});
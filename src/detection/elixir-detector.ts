@@ -0,0 +1,84 @@
+import { BuildToolInfo, ElixirProjectInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Reads the Mix project at a project root
+ */
+export class ElixirDetector {
+  /**
+   * Detect the Mix project of a project, given as a directory or a file system: the Elixir and
+   * OTP versions pinned in .tool-versions, else the release line the `elixir` requirement of
+   * mix.exs starts from, and whether it is a Phoenix app using Ecto with Postgres. Undefined
+   * when there is no mix.exs.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<ElixirProjectInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    let mixfile: string;
+    try {
+      mixfile = await files.readFile('mix.exs');
+    } catch {
+      return undefined;
+    }
+
+    const withoutComments = mixfile.replace(/#.*$/gm, '');
+    const deps = new Set([...withoutComments.matchAll(/\{\s*:(\w+)\s*,/g)].map(match => match[1]!));
+    const toolVersions = await this.readToolVersions(files);
+    const elixirVersion = toolVersions.elixir || this.readElixirRequirement(withoutComments);
+
+    return {
+      ...(elixirVersion && { elixirVersion }),
+      ...(toolVersions.otp && { otpVersion: toolVersions.otp }),
+      ...(elixirVersion && { versionSource: toolVersions.elixir ? '.tool-versions' : 'mix.exs' }),
+      phoenix: deps.has('phoenix'),
+      postgres: deps.has('ecto_sql') && deps.has('postgrex')
+    };
+  }
+
+  /**
+   * Versions of an asdf .tool-versions file: `elixir 1.16.2-otp-26` gives Elixir 1.16.2 and,
+   * without an `erlang` line, OTP 26
+   */
+  private async readToolVersions(files: ProjectFileSystem): Promise<{ elixir?: string; otp?: string }> {
+    let content: string;
+    try {
+      content = await files.readFile('.tool-versions');
+    } catch {
+      return {};
+    }
+
+    const versionOf = (tool: string) => content.match(new RegExp(`^\\s*${tool}\\s+(\\S+)`, 'm'))?.[1];
+    const elixir = versionOf('elixir')?.match(/^v?(\d+\.\d+(?:\.\d+)?)(?:-otp-(\d+))?$/);
+    const otp = versionOf('erlang') || elixir?.[2];
+    return { ...(elixir && { elixir: elixir[1] }), ...(otp && { otp }) };
+  }
+
+  /**
+   * Release line the `elixir: "~> 1.15"` requirement of the project definition starts from
+   */
+  private readElixirRequirement(mixfile: string): string | undefined {
+    return mixfile.match(/\belixir:\s*"[~>=\s]*(\d+\.\d+)/)?.[1];
+  }
+}
+
+registerDetector('elixir', {
+  async detect(files: ProjectFileSystem) {
+    const elixirProject = await new ElixirDetector().detect(files);
+    if (!elixirProject) {
+      return [];
+    }
+
+    // Mix builds the project; its lockfile keys the dependency cache
+    const mix: BuildToolInfo = {
+      name: 'mix',
+      configFile: 'mix.exs',
+      ...(await files.exists('mix.lock') && { lockFile: 'mix.lock' }),
+      commands: [
+        { name: 'compile', command: 'mix compile', isPrimary: true },
+        { name: 'test', command: 'mix test', isPrimary: false }
+      ],
+      confidence: BUILTIN_DETECTOR_CONFIDENCE
+    };
+    return [{ fields: { elixirProject, buildTools: [mix] }, confidence: BUILTIN_DETECTOR_CONFIDENCE }];
+  }
+});
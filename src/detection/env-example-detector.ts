@@ -0,0 +1,122 @@
+import { EnvExampleInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Example environment files documenting the variables a project reads, in priority order
+ */
+export const ENV_EXAMPLE_FILES = ['.env.example', '.env.sample'];
+
+/**
+ * Parts of a variable name marking it as a credential
+ */
+const SECRET_NAME = /(^|_)(TOKEN|SECRET|PASSWORD|PASSWD|PASS|PWD|KEY|APIKEY|PRIVATE|CREDENTIALS?|AUTH|DSN|SALT|CERT)(_|$)|(TOKEN|SECRET|PASSWORD|APIKEY)$/;
+
+/**
+ * Shapes of issued credentials: provider token prefixes, AWS access key ids, JWTs and PEM keys
+ */
+const CREDENTIAL_VALUES = [
+  /^(sk|pk|rk)_(live|test)_[A-Za-z0-9]{8,}/,
+  /^(ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{20,}|^github_pat_\w{20,}/,
+  /^xox[abprs]-[A-Za-z0-9-]{10,}/,
+  /^AKIA[0-9A-Z]{16}$/,
+  /^AIza[0-9A-Za-z_-]{30,}/,
+  /^eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\./,
+  /-----BEGIN [A-Z ]*PRIVATE KEY-----/
+];
+
+/**
+ * Passwords that only stand in for the real one in connection URLs of example files
+ */
+const PLACEHOLDER_PASSWORDS = new Set(['password', 'pass', 'postgres', 'mysql', 'root', 'secret', 'changeme', 'example', 'test', 'dev']);
+
+/**
+ * Reads the variables an example environment file documents
+ */
+export class EnvExampleDetector {
+  /**
+   * Detect the variables of the first example environment file of a project, given as a
+   * directory or a file system, in the order they are listed. Variables with a credential's
+   * name, or with a value shaped like a real credential, are marked secret and lose their value.
+   * Undefined when there is no such file.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<EnvExampleInfo | undefined> {
+    const files = toProjectFileSystem(project);
+
+    for (const file of ENV_EXAMPLE_FILES) {
+      let content: string;
+      try {
+        content = await files.readFile(file);
+      } catch {
+        continue;
+      }
+      return { file, variables: this.parse(content) };
+    }
+    return undefined;
+  }
+
+  /**
+   * `KEY=VALUE` lines, optionally prefixed with `export`, with quotes and trailing comments of
+   * unquoted values removed; the first definition of a name wins
+   */
+  private parse(content: string): EnvExampleInfo['variables'] {
+    const variables = new Map<string, EnvExampleInfo['variables'][number]>();
+
+    for (const line of content.split(/\r?\n/)) {
+      const match = line.match(/^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$/);
+      if (!match || variables.has(match[1]!)) {
+        continue;
+      }
+
+      const name = match[1]!;
+      const raw = match[2]!.trim();
+      const quoted = raw.match(/^(["'])(.*)\1/);
+      const value = quoted ? quoted[2]! : raw.replace(/\s+#.*$/, '').trim();
+
+      const secret = SECRET_NAME.test(name.toUpperCase()) || this.looksLikeCredential(value);
+      variables.set(name, secret || value === '' ? { name, secret } : { name, value, secret });
+    }
+
+    return [...variables.values()];
+  }
+
+  /**
+   * Whether a value looks like an issued credential rather than a placeholder: a known token
+   * shape, a connection URL with a real-looking password, or a long random string
+   */
+  private looksLikeCredential(value: string): boolean {
+    if (CREDENTIAL_VALUES.some(pattern => pattern.test(value))) {
+      return true;
+    }
+
+    const password = value.match(/^[a-z][a-z0-9+.-]*:\/\/[^:/@\s]*:([^@\s]+)@/i)?.[1];
+    if (password !== undefined) {
+      return !PLACEHOLDER_PASSWORDS.has(password.toLowerCase()) && !/^[<{$]|^x+$|^\*+$/i.test(password);
+    }
+
+    return value.length >= 24 && !/[\s/:.]/.test(value) && /[a-z]/.test(value) && /[A-Z0-9]/.test(value) && entropy(value) > 3.5;
+  }
+}
+
+/**
+ * Shannon entropy of a string, in bits per character
+ */
+function entropy(value: string): number {
+  const counts = new Map<string, number>();
+  for (const character of value) {
+    counts.set(character, (counts.get(character) || 0) + 1);
+  }
+  return [...counts.values()].reduce((sum, count) => {
+    const p = count / value.length;
+    return sum - p * Math.log2(p);
+  }, 0);
+}
+
+registerDetector('env-example', {
+  async detect(files: ProjectFileSystem) {
+    const envExample = await new EnvExampleDetector().detect(files);
+    return envExample && envExample.variables.length > 0
+      ? [{ fields: { envExample }, confidence: BUILTIN_DETECTOR_CONFIDENCE }]
+      : [];
+  }
+});
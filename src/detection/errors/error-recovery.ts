@@ -16,6 +16,10 @@ export interface RetryConfig {
   maxDelay: number;
   backoffMultiplier: number;
   retryableErrors: string[];
+  /** Time (epoch milliseconds) past which no further attempt starts, however many are left */
+  deadline?: number;
+  /** Waits between attempts; tests replace it so they need not wait */
+  sleep?: (ms: number) => Promise<void>;
 }
 
 /**
@@ -41,47 +45,56 @@ export class ErrorRecovery {
     config: Partial<RetryConfig> = {}
   ): Promise<Result<T, DetectionError>> {
     const finalConfig = { ...DEFAULT_RETRY_CONFIG, ...config };
-    let lastError: DetectionError | null = null;
-
-    for (let attempt = 1; attempt <= finalConfig.maxAttempts; attempt++) {
-      try {
-        const result = await operation();
-        return { success: true, data: result };
-      } catch (error) {
-        const detectionError = error instanceof DetectionError 
-          ? error 
-          : new DetectionFailureError(
-              error instanceof Error ? error.message : 'Unknown error',
-              'unknown'
-            );
-
-        lastError = detectionError;
+    const toDetectionError = (error: unknown) => error instanceof DetectionError
+      ? error
+      : new DetectionFailureError(
+          error instanceof Error ? error.message : 'Unknown error',
+          'unknown'
+        );
 
+    try {
+      const result = await ErrorRecovery.withBackoff(operation, error => {
         // Don't retry if error is not recoverable or not in retryable list
-        if (!detectionError.recoverable || 
-            !finalConfig.retryableErrors.includes(detectionError.code)) {
-          break;
-        }
+        const detectionError = toDetectionError(error);
+        return detectionError.recoverable && finalConfig.retryableErrors.includes(detectionError.code);
+      }, finalConfig);
+      return { success: true, data: result };
+    } catch (error) {
+      return { success: false, error: toDetectionError(error) };
+    }
+  }
 
-        // Don't retry on last attempt
-        if (attempt === finalConfig.maxAttempts) {
-          break;
-        }
+  /**
+   * Execute operation, retrying with exponential backoff while it fails with errors the predicate
+   * deems transient, until the attempts run out or the next one would start past the deadline.
+   * Rejects with the error of the last attempt.
+   */
+  static async withBackoff<T>(
+    operation: () => Promise<T>,
+    retryable: (error: unknown) => boolean,
+    config: Partial<RetryConfig> = {}
+  ): Promise<T> {
+    const finalConfig = { ...DEFAULT_RETRY_CONFIG, ...config };
+    const sleep = finalConfig.sleep || (ms => new Promise<void>(resolve => setTimeout(resolve, ms)));
 
+    for (let attempt = 1; ; attempt++) {
+      try {
+        return await operation();
+      } catch (error) {
         // Calculate delay with exponential backoff
         const delay = Math.min(
           finalConfig.baseDelay * Math.pow(finalConfig.backoffMultiplier, attempt - 1),
           finalConfig.maxDelay
         );
+        const pastDeadline = finalConfig.deadline !== undefined && Date.now() + delay > finalConfig.deadline;
+
+        if (attempt >= finalConfig.maxAttempts || pastDeadline || !retryable(error)) {
+          throw error;
+        }
 
-        await new Promise(resolve => setTimeout(resolve, delay));
+        await sleep(delay);
       }
     }
-
-    return { 
-      success: false, 
-      error: lastError || new DetectionFailureError('Operation failed', 'unknown')
-    };
   }
 
   /**
@@ -2,13 +2,56 @@ import { FrameworkDetector, ProjectInfo } from './interfaces/framework-detector'
 import { DetectionResult } from './interfaces/detection-result';
 import { CIPipeline } from './interfaces/ci-pipeline';
 import { DetectionEngine } from './detection-engine';
+import { MonorepoDetector, ProjectUnit } from './monorepo-detector';
+import { runDetectors } from './detector-registry';
+import { collectDiagnostics } from './diagnostics';
+import { WorkingDirectoryDetector } from './working-directory-detector';
+// The built-in project directory detectors register themselves on load
+import './docker-detector';
+import './test-runner-detector';
+import './makefile-detector';
+import './cargo-workspace-detector';
+import './node-workspace-detector';
+import './task-graph-detector';
+import './static-site-detector';
+import './java-build-detector';
+import './coverage-detector';
+import './lint-detector';
+import './python-layout-detector';
+import './language-detector';
+import './service-detector';
+import './go-module-detector';
+import './goreleaser-detector';
+import './terraform-detector';
+import './elixir-detector';
+import './ruby-detector';
+import './swift-detector';
+import './dotnet-detector';
+import './dart-detector';
+import './haskell-detector';
+import './cmake-detector';
+import './shell-detector';
+import './api-schema-detector';
+import './env-example-detector';
+import './git-checkout-detector';
+import './license-detector';
+import './changelog-detector';
+import './protobuf-detector';
+import './generated-code-detector';
+import './codeowners-detector';
+import './package-scripts-detector';
+import './pre-commit-detector';
+import { DetectionSignalCollector, scoreSignals } from './utils/detection-signals';
+import { IgnoredFileSystem, ProjectFileSystem, RetryingFileSystem, SubdirectoryFileSystem, toProjectFileSystem } from './utils/project-fs';
+import { IgnoreMatcher } from './utils/ignore-patterns';
 import { DetectionError, DetectionFailureError } from './errors/detection-errors';
-import { ErrorRecovery } from './errors/error-recovery';
+import { ErrorRecovery, RetryConfig } from './errors/error-recovery';
 import { DetectionLogger, getLogger, timeOperation } from './utils/logger';
 import { CacheManager, getCacheManager } from './performance/cache-manager';
 import { PerformanceMonitor, getPerformanceMonitor, timed } from './performance/performance-monitor';
 import { getPluginSystem } from './extensibility/plugin-system';
 import { getConfigManager } from './configuration/config-manager';
+import { PipelineLogger, NOOP_LOGGER } from '../shared/logging/pipeline-logger';
 
 /**
  * Main framework detection implementation with performance optimizations
@@ -18,12 +61,22 @@ export class FrameworkDetectorImpl implements FrameworkDetector {
   private cacheManager: CacheManager;
   private performanceMonitor: PerformanceMonitor;
   private logger: DetectionLogger;
+  private pipelineLogger: PipelineLogger;
+  private retry: Partial<RetryConfig>;
 
-  constructor() {
+  /**
+   * @param options.logger Receives each detection decision (file found, signal matched, confidence
+   * computed) at debug level; nothing is logged without one
+   * @param options.retry How reads of the project that fail transiently are retried: attempts,
+   * backoff and a deadline; by default three attempts
+   */
+  constructor(options: { logger?: PipelineLogger; retry?: Partial<RetryConfig> } = {}) {
     this.detectionEngine = new DetectionEngine();
     this.cacheManager = getCacheManager();
     this.performanceMonitor = getPerformanceMonitor();
     this.logger = getLogger();
+    this.pipelineLogger = options.logger || NOOP_LOGGER;
+    this.retry = options.retry || {};
     
     // Initialize configuration and plugins
     this.initializeExtensions();
@@ -60,10 +113,17 @@ export class FrameworkDetectorImpl implements FrameworkDetector {
   }
 
   /**
-   * Detect frameworks and build tools from project information with caching
+   * Detect frameworks and build tools from project information with caching. The project,
+   * a directory or a file system, is read by the analyzers and the project directory
+   * detectors; the latter scan workingDirectory, or the single subdirectory holding the
+   * manifests when there are none at the root. Paths ignore leaves out are neither searched
+   * for that subdirectory nor read. A deadline overrides the one the retry option sets.
+   * Projects given as a file system are not cached, having no path to tell them apart.
    */
-  async detectFrameworks(projectInfo: ProjectInfo, projectPath?: string): Promise<DetectionResult> {
-    const operationId = `detectFrameworks-${projectInfo.name}-${Date.now()}`;
+  async detectFrameworks(projectInfo: ProjectInfo, project?: string | ProjectFileSystem, workingDirectory?: string, ignore?: IgnoreMatcher, deadline?: number): Promise<DetectionResult> {
+    const projectPath = typeof project === 'string' ? project : project?.root;
+    // Packages of a monorepo share names and are detected at once; their paths tell the operations apart
+    const operationId = `detectFrameworks-${projectPath || projectInfo.name}-${Date.now()}`;
     this.performanceMonitor.startOperation(operationId, 'FrameworkDetector', {
       languages: projectInfo.languages || [],
       configFiles: projectInfo.configFiles?.length || 0
@@ -71,8 +131,16 @@ export class FrameworkDetectorImpl implements FrameworkDetector {
 
     try {
       // Check cache first
-      const cachedResult = this.cacheManager.getCachedDetectionResult(projectInfo, projectPath);
+      // An explicit working directory or ignore patterns make a different result for the same checkout
+      const cacheable = typeof project !== 'object';
+      const cacheKey = typeof project === 'string' ? [
+        project,
+        ...(workingDirectory !== undefined ? [`#${workingDirectory}`] : []),
+        ...(ignore?.patterns.length ? [`!${ignore.base}:${ignore.patterns.join(',')}`] : [])
+      ].join('') : undefined;
+      const cachedResult = cacheable ? this.cacheManager.getCachedDetectionResult(projectInfo, cacheKey) : null;
       if (cachedResult) {
+        this.pipelineLogger.debug('Using cached detection result', { project: projectInfo.name, projectPath });
         this.logger.info('FrameworkDetector', 'Returning cached detection result', {
           project: projectInfo.name,
           frameworks: cachedResult.frameworks.length,
@@ -89,11 +157,15 @@ export class FrameworkDetectorImpl implements FrameworkDetector {
       this.logger.info('FrameworkDetector', 'Starting framework detection', {
         languages: projectInfo.languages || [],
         configFilesCount: projectInfo.configFiles?.length || 0,
-        hasProjectPath: !!projectPath
+        hasProjectPath: !!project
       });
 
+      // Reads of the project are retried and warned about, and what ignore leaves out is unseen, by all of detection
+      const retrying = project ? new RetryingFileSystem(toProjectFileSystem(project), deadline === undefined ? this.retry : { ...this.retry, deadline }) : undefined;
+      const files = retrying && ignore?.patterns.length ? new IgnoredFileSystem(retrying, ignore) : retrying;
+
       const detectionResult = await ErrorRecovery.withRetry(
-        () => this.detectionEngine.analyze(projectInfo, projectPath),
+        () => this.detectionEngine.analyze(projectInfo, files),
         {
           maxAttempts: 2,
           retryableErrors: ['DETECTION_FAILURE', 'INTEGRATION_ERROR']
@@ -155,12 +227,45 @@ export class FrameworkDetectorImpl implements FrameworkDetector {
         },
         alternatives: detectionResult.data?.alternatives || [],
         warnings: detectionResult.data?.warnings || [],
+        ...(detectionResult.data?.versionConstraints && { versionConstraints: detectionResult.data.versionConstraints }),
         detectedAt: new Date(),
         executionTime: 0 // Will be set by performance monitor
       };
 
+      for (const framework of result.frameworks) {
+        this.pipelineLogger.debug('Framework detected from README', {
+          framework: framework.name,
+          confidence: framework.confidence,
+          evidence: (framework.evidence || []).map(evidence => `${evidence.type}:${evidence.source}`)
+        });
+      }
+
+      if (retrying && files) {
+        const directory = workingDirectory ?? await new WorkingDirectoryDetector().detect(files);
+        if (directory && directory !== '.') {
+          this.pipelineLogger.debug('Project found in a subdirectory', { directory, explicit: workingDirectory !== undefined });
+          result.workingDirectory = directory;
+          await runDetectors(result, new SubdirectoryFileSystem(files, directory), this.pipelineLogger);
+        } else {
+          await runDetectors(result, files, this.pipelineLogger);
+        }
+        await this.attachSignals(result, projectInfo, files);
+        result.warnings.push(...retrying.warnings);
+      }
+
+      this.pipelineLogger.debug('Detection confidence computed', {
+        score: result.confidence.score,
+        level: result.confidence.level,
+        frameworks: result.frameworks.map(framework => `${framework.name}:${framework.confidence}`),
+        testRunners: (result.testRunners || []).map(runner => `${runner.name}:${runner.confidence}`)
+      });
+
+      result.diagnostics = collectDiagnostics(result, project !== undefined);
+
       // Cache the result
-      this.cacheManager.cacheDetectionResult(projectInfo, result, projectPath);
+      if (cacheable) {
+        this.cacheManager.cacheDetectionResult(projectInfo, result, cacheKey);
+      }
 
       this.logger.info('FrameworkDetector', 'Framework detection completed successfully', {
         frameworksDetected: result.frameworks.length,
@@ -269,6 +374,46 @@ export class FrameworkDetectorImpl implements FrameworkDetector {
     }
   }
 
+  /**
+   * Record the signals behind each framework and test runner; test runners are scored from them
+   */
+  private async attachSignals(result: DetectionResult, projectInfo: ProjectInfo, files: ProjectFileSystem): Promise<void> {
+    const collector = new DetectionSignalCollector(files, projectInfo.rawContent);
+
+    for (const framework of result.frameworks) {
+      framework.signals = await collector.collectFromEvidence(framework.ecosystem, framework.name, framework.evidence || []);
+      this.pipelineLogger.debug('Signals matched', { framework: framework.name, signals: framework.signals.map(signal => `${signal.kind}:${signal.source}`) });
+    }
+
+    for (const runner of result.testRunners || []) {
+      runner.signals = await collector.collect(runner.language, [runner.name, runner.command], runner.source);
+      runner.confidence = scoreSignals(runner.signals);
+      this.pipelineLogger.debug('Signals matched', {
+        testRunner: `${runner.language}:${runner.name}`,
+        signals: runner.signals.map(signal => `${signal.kind}:${signal.source}`),
+        confidence: runner.confidence
+      });
+    }
+  }
+
+  /**
+   * Detect each package in a monorepo, one unit per directory with a manifest, with up to
+   * concurrency packages detected at once (the number of CPUs by default). Paths ignore leaves
+   * out hold no packages. Given changedFiles, only the packages they belong to are detected.
+   * Past deadline, reads that fail transiently are no longer retried. The root is a directory
+   * or a file system.
+   */
+  async detectMonorepo(root: string | ProjectFileSystem, concurrency?: number, ignore?: IgnoreMatcher, changedFiles?: string[], deadline?: number): Promise<ProjectUnit[]> {
+    const units = await new MonorepoDetector(this, undefined, concurrency).detect(root, ignore, changedFiles, deadline);
+
+    this.logger.info('FrameworkDetector', 'Monorepo detection completed', {
+      root: typeof root === 'string' ? root : root.root,
+      packages: units.map(unit => unit.path)
+    });
+
+    return units;
+  }
+
   /**
    * Get performance statistics
    */
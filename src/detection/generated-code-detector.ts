@@ -0,0 +1,113 @@
+import { GeneratedCodeInfo } from './interfaces/framework-info';
+import { SKIPPED_DIRECTORIES } from './monorepo-detector';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * How deep below the project root generated code is looked for
+ */
+const MAX_GENERATED_DEPTH = 6;
+
+/**
+ * The header Go tools write atop the files they generate (go.dev/s/generatedcode), which must
+ * come before the package clause
+ */
+const GO_GENERATED_HEADER = /^\/\/ Code generated .* DO NOT EDIT\.$/m;
+
+/**
+ * Names of generated Go files, for generators that leave out the header: protobuf and
+ * go:generate output
+ */
+const GO_GENERATED_NAME = /(\.pb(\.gw)?\.go|_gen\.go)$/;
+
+/**
+ * Directories JavaScript packages bundle their build into
+ */
+const BUNDLE_DIRECTORIES = new Set(['dist']);
+
+/**
+ * Finds the generated code of a project, which linters should not check
+ */
+export class GeneratedCodeDetector {
+  /**
+   * Detect the generated code of a project, given as a directory or a file system: Go files
+   * carrying the standard `// Code generated ... DO NOT EDIT.` header, or else named like
+   * protobuf or go:generate output, and the dist/ directories JavaScript packages bundle into.
+   * Go directories holding nothing but generated files are reported as a whole. Vendored
+   * dependencies and test data are not looked at, as linters skip them already. Undefined
+   * when nothing generated is found.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<GeneratedCodeInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const goFiles: string[] = [];
+    const goDirectories: string[] = [];
+    const bundleDirectories: string[] = [];
+
+    const visit = async (directory: string, depth: number): Promise<void> => {
+      const entries = await files.readdir(directory).catch(() => []);
+      const prefix = directory === '.' ? '' : `${directory}/`;
+      const generated: string[] = [];
+      let handWritten = 0;
+
+      for (const entry of entries) {
+        const path = `${prefix}${entry.name}`;
+        if (entry.isDirectory()) {
+          if (BUNDLE_DIRECTORIES.has(entry.name)) {
+            if (await this.isBundle(files, directory, path)) {
+              bundleDirectories.push(path);
+            }
+          } else if (depth < MAX_GENERATED_DEPTH && !entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name)) {
+            await visit(path, depth + 1);
+          }
+        } else if (entry.isFile() && entry.name.endsWith('.go')) {
+          if (await this.isGeneratedGo(files, path)) {
+            generated.push(path);
+          } else {
+            handWritten++;
+          }
+        }
+      }
+
+      if (generated.length > 0 && handWritten === 0 && directory !== '.') {
+        goDirectories.push(directory);
+      } else {
+        goFiles.push(...generated);
+      }
+    };
+    await visit('.', 0);
+
+    if (goFiles.length === 0 && goDirectories.length === 0 && bundleDirectories.length === 0) {
+      return undefined;
+    }
+    return { goFiles: goFiles.sort(), goDirectories: goDirectories.sort(), bundleDirectories: bundleDirectories.sort() };
+  }
+
+  /**
+   * Whether a Go file is generated: by its header, which tools add whatever they name the file,
+   * else by its name
+   */
+  private async isGeneratedGo(files: ProjectFileSystem, path: string): Promise<boolean> {
+    const content = await files.readFile(path).catch(() => '');
+    const packageClause = content.search(/^package\s/m);
+    const header = packageClause < 0 ? content : content.slice(0, packageClause);
+    return GO_GENERATED_HEADER.test(header) || GO_GENERATED_NAME.test(path);
+  }
+
+  /**
+   * Whether a dist/ directory is a JavaScript package's bundle: next to a package.json, holding scripts
+   */
+  private async isBundle(files: ProjectFileSystem, parent: string, path: string): Promise<boolean> {
+    if (!await files.exists(parent === '.' ? 'package.json' : `${parent}/package.json`)) {
+      return false;
+    }
+    const entries = await files.readdir(path).catch(() => []);
+    return entries.some(entry => entry.isFile() && /\.[cm]?js$/.test(entry.name));
+  }
+}
+
+registerDetector('generated-code', {
+  async detect(files: ProjectFileSystem) {
+    const generatedCode = await new GeneratedCodeDetector().detect(files);
+    return generatedCode ? [{ fields: { generatedCode }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
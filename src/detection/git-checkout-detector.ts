@@ -0,0 +1,87 @@
+import { GitCheckoutInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Tools that derive the project version from git tags, by the file that configures them and
+ * what the file says when it does
+ */
+const VERSION_FROM_GIT_TOOLS: Array<{ name: string; file: string; pattern?: RegExp }> = [
+  { name: 'setuptools-scm', file: 'pyproject.toml', pattern: /setuptools[_-]scm/i },
+  { name: 'hatch-vcs', file: 'pyproject.toml', pattern: /hatch-vcs/i },
+  { name: 'poetry-dynamic-versioning', file: 'pyproject.toml', pattern: /poetry-dynamic-versioning/i },
+  { name: 'versioningit', file: 'pyproject.toml', pattern: /versioningit/i },
+  { name: 'setuptools-scm', file: 'setup.py', pattern: /use_scm_version|setuptools_scm/ },
+  { name: 'setuptools-scm', file: 'setup.cfg', pattern: /setuptools_scm/ },
+  { name: 'versioneer', file: 'versioneer.py' },
+  { name: 'GitVersion', file: 'GitVersion.yml' },
+  { name: 'GitVersion', file: 'GitVersion.yaml' },
+  { name: 'vergen', file: 'Cargo.toml', pattern: /^\s*vergen(-\w+)?\s*=/m },
+  { name: 'git describe', file: 'Makefile', pattern: /\bgit\s+describe\b/ }
+];
+
+/**
+ * Reads what a CI checkout of a project has to fetch besides its latest commit
+ */
+export class GitCheckoutDetector {
+  /**
+   * Detect the submodules .gitmodules declares and the tools that read the version from git
+   * tags of a project, given as a directory or a file system. Undefined when there are neither.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<GitCheckoutInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const contents = new Map<string, string | undefined>();
+    const read = async (file: string) => {
+      if (!contents.has(file)) {
+        contents.set(file, await files.readFile(file).catch(() => undefined));
+      }
+      return contents.get(file);
+    };
+
+    const gitmodules = await read('.gitmodules');
+    const submodules = gitmodules ? this.parseSubmodules(gitmodules) : [];
+
+    const versionFromGit: string[] = [];
+    for (const tool of VERSION_FROM_GIT_TOOLS) {
+      const content = await read(tool.file);
+      if (content !== undefined && (!tool.pattern || tool.pattern.test(content)) && !versionFromGit.includes(tool.name)) {
+        versionFromGit.push(tool.name);
+      }
+    }
+
+    return submodules.length > 0 || versionFromGit.length > 0 ? { submodules, versionFromGit } : undefined;
+  }
+
+  /**
+   * `[submodule "name"]` sections of .gitmodules with both a path and a url
+   */
+  private parseSubmodules(content: string): GitCheckoutInfo['submodules'] {
+    const submodules: GitCheckoutInfo['submodules'] = [];
+    let current: { name: string; path?: string; url?: string } | undefined;
+
+    for (const line of [...content.split(/\r?\n/), '[end]']) {
+      const section = line.match(/^\s*\[\s*([^\s\]]+)(?:\s+"([^"]*)")?\s*\]/);
+      if (section) {
+        if (current?.path && current.url) {
+          submodules.push({ name: current.name, path: current.path, url: current.url });
+        }
+        current = section[1] === 'submodule' && section[2] ? { name: section[2] } : undefined;
+        continue;
+      }
+
+      const setting = current && line.match(/^\s*(path|url)\s*=\s*(.+?)\s*$/);
+      if (current && setting) {
+        current[setting[1] as 'path' | 'url'] = setting[2]!;
+      }
+    }
+
+    return submodules;
+  }
+}
+
+registerDetector('git-checkout', {
+  async detect(files: ProjectFileSystem) {
+    const gitCheckout = await new GitCheckoutDetector().detect(files);
+    return gitCheckout ? [{ fields: { gitCheckout }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,72 @@
+import { GoModuleInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Reads the module path and requirements of a Go module from its go.mod
+ */
+export class GoModuleDetector {
+  /**
+   * Detect the root go.mod of a project, given as a directory or a file system; undefined when
+   * there is none. Indirect requirements are included, since downloading them needs the same access.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<GoModuleInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    let content: string;
+    try {
+      content = await files.readFile('go.mod');
+    } catch {
+      return undefined;
+    }
+
+    return this.parse(content);
+  }
+
+  private parse(content: string): GoModuleInfo {
+    const module: GoModuleInfo = { path: '', requires: [] };
+    let inRequireBlock = false;
+
+    for (const rawLine of content.split('\n')) {
+      const line = rawLine.replace(/\/\/.*$/, '').trim();
+      if (line === '') {
+        continue;
+      }
+
+      if (inRequireBlock) {
+        if (line === ')') {
+          inRequireBlock = false;
+        } else {
+          this.addRequirement(module, line);
+        }
+        continue;
+      }
+
+      const declaration = line.match(/^(module|require)\s+(.*)$/);
+      if (declaration?.[1] === 'module') {
+        module.path = declaration[2]!.replace(/^"|"$/g, '');
+      } else if (declaration?.[1] === 'require') {
+        if (declaration[2] === '(') {
+          inRequireBlock = true;
+        } else {
+          this.addRequirement(module, declaration[2]!);
+        }
+      }
+    }
+
+    return module;
+  }
+
+  private addRequirement(module: GoModuleInfo, line: string): void {
+    const path = line.split(/\s+/)[0]!.replace(/^"|"$/g, '');
+    if (path && !module.requires.includes(path)) {
+      module.requires.push(path);
+    }
+  }
+}
+
+registerDetector('go-modules', {
+  async detect(files: ProjectFileSystem) {
+    const goModule = await new GoModuleDetector().detect(files);
+    return goModule ? [{ fields: { goModule }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
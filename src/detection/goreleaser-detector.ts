@@ -0,0 +1,36 @@
+import { GoReleaserInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Config file names GoReleaser looks up, in its own order
+ */
+const GORELEASER_CONFIG_FILES = ['.goreleaser.yml', '.goreleaser.yaml', 'goreleaser.yml', 'goreleaser.yaml'];
+
+/**
+ * Finds the GoReleaser config of a Go project
+ */
+export class GoReleaserDetector {
+  /**
+   * Detect the GoReleaser config of a project, given as a directory or a file system;
+   * undefined when there is none
+   */
+  async detect(project: string | ProjectFileSystem): Promise<GoReleaserInfo | undefined> {
+    const files = toProjectFileSystem(project);
+
+    for (const file of GORELEASER_CONFIG_FILES) {
+      if (await files.exists(file)) {
+        return { file };
+      }
+    }
+
+    return undefined;
+  }
+}
+
+registerDetector('goreleaser', {
+  async detect(files: ProjectFileSystem) {
+    const goReleaser = await new GoReleaserDetector().detect(files);
+    return goReleaser ? [{ fields: { goReleaser }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,106 @@
+import * as yaml from 'js-yaml';
+import { BuildToolInfo, HaskellProjectInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * The tested-with field of a .cabal file, with the indented lines continuing it
+ */
+const TESTED_WITH = /^tested-with:[ \t]*(.*(?:\r?\n[ \t]+.*)*)/im;
+
+/**
+ * Reads the Stack or Cabal project at a project root
+ */
+export class HaskellDetector {
+  /**
+   * Detect the Haskell project of a project, given as a directory or a file system: Stack when
+   * there is a stack.yaml, with the snapshot it resolves, else Cabal for a .cabal file at the
+   * root, with the newest GHC its tested-with field names. Also reads whether a test suite is
+   * declared and an HLint configuration is present. Undefined with neither file.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<HaskellProjectInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const stackYaml = await files.readFile('stack.yaml').catch(() => undefined);
+    const entries = await files.readdir('.').catch(() => []);
+    const cabalFiles = entries.filter(entry => entry.isFile() && entry.name.endsWith('.cabal')).map(entry => entry.name).sort();
+    if (stackYaml === undefined && cabalFiles.length === 0) {
+      return undefined;
+    }
+
+    const cabal = (await Promise.all(cabalFiles.map(file => files.readFile(file).catch(() => '')))).join('\n');
+    // Stack projects usually declare their packages in hpack's package.yaml instead
+    const packageYaml = await files.readFile('package.yaml').catch(() => '');
+    const resolver = stackYaml !== undefined ? this.readResolver(stackYaml) : undefined;
+    const ghcVersion = this.readTestedWith(cabal);
+
+    return {
+      tool: stackYaml !== undefined ? 'stack' : 'cabal',
+      ...(resolver && { resolver }),
+      ...(ghcVersion && { ghcVersion }),
+      cabalFiles,
+      tests: /^test-suite\s+\S/im.test(cabal) || /^tests:/m.test(packageYaml),
+      hlint: await files.exists('.hlint.yaml')
+    };
+  }
+
+  /**
+   * Snapshot of stack.yaml: the snapshot key, or resolver before Stack 2.11
+   */
+  private readResolver(content: string): string | undefined {
+    let stack: any;
+    try {
+      stack = yaml.load(content);
+    } catch {
+      return undefined;
+    }
+    const resolver = stack?.snapshot ?? stack?.resolver;
+    return typeof resolver === 'string' ? resolver : undefined;
+  }
+
+  /**
+   * Newest GHC release `tested-with: GHC == 9.4.8 || == 9.6.4` lists
+   */
+  private readTestedWith(cabal: string): string | undefined {
+    const testedWith = cabal.match(TESTED_WITH)?.[1];
+    const versions = [...(testedWith || '').matchAll(/\d+(?:\.\d+)+/g)].map(match => match[0]);
+    return versions.sort((a, b) => b.localeCompare(a, undefined, { numeric: true }))[0];
+  }
+}
+
+registerDetector('haskell', {
+  async detect(files: ProjectFileSystem) {
+    const haskellProject = await new HaskellDetector().detect(files);
+    if (!haskellProject) {
+      return [];
+    }
+
+    const stack = haskellProject.tool === 'stack';
+    const lockFile = stack ? 'stack.yaml.lock' : 'cabal.project.freeze';
+    const buildTool: BuildToolInfo = {
+      name: haskellProject.tool,
+      configFile: stack ? 'stack.yaml' : haskellProject.cabalFiles[0]!,
+      ...(await files.exists(lockFile) && { lockFile }),
+      commands: [
+        { name: 'build', command: stack ? 'stack build' : 'cabal build all', isPrimary: true },
+        ...(haskellProject.tests ? [{ name: 'test', command: stack ? 'stack test' : 'cabal test all', isPrimary: false }] : [])
+      ],
+      confidence: BUILTIN_DETECTOR_CONFIDENCE
+    };
+
+    return [{
+      fields: { haskellProject, buildTools: [buildTool] },
+      confidence: BUILTIN_DETECTOR_CONFIDENCE,
+      // Stack builds the packages of the .cabal files itself, so the project is built with it alone
+      warnings: stack && haskellProject.cabalFiles.length > 0
+        ? [{
+          type: 'conflict' as const,
+          message: `Both stack.yaml and ${haskellProject.cabalFiles.join(', ')} found - building with Stack`,
+          affected: ['stack', 'cabal'],
+          resolution: 'Remove stack.yaml to build with Cabal',
+          code: 'BUILD_TOOL_CONFLICT' as const,
+          path: 'stack.yaml'
+        }]
+        : []
+    }];
+  }
+});
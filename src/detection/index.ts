@@ -1,8 +1,49 @@
 // Framework Detection Component Entry Point
 export * from './interfaces';
 export * from './framework-detector';
+export * from './detector-registry';
+export * from './monorepo-detector';
+export * from './docker-detector';
+export * from './test-runner-detector';
+export * from './makefile-detector';
+export * from './cargo-workspace-detector';
+export * from './node-workspace-detector';
+export * from './task-graph-detector';
+export * from './static-site-detector';
+export * from './java-build-detector';
+export * from './coverage-detector';
+export * from './lint-detector';
+export * from './python-layout-detector';
+export * from './language-detector';
+export * from './service-detector';
+export * from './go-module-detector';
+export * from './goreleaser-detector';
+export * from './terraform-detector';
+export * from './elixir-detector';
+export * from './ruby-detector';
+export * from './swift-detector';
+export * from './dotnet-detector';
+export * from './dart-detector';
+export * from './haskell-detector';
+export * from './cmake-detector';
+export * from './shell-detector';
+export * from './api-schema-detector';
+export * from './env-example-detector';
+export * from './git-checkout-detector';
+export * from './license-detector';
+export * from './changelog-detector';
+export * from './protobuf-detector';
+export * from './generated-code-detector';
+export * from './codeowners-detector';
+export * from './package-scripts-detector';
+export * from './pre-commit-detector';
+export * from './working-directory-detector';
+export * from './detection-report';
+export * from './diagnostics';
 export * from './detection-engine';
 export * from './analyzers';
 export * from './templates';
 export * from './integration';
-export { FileSystemScanner, EvidenceCollectorImpl, ResultAggregator } from './utils';
\ No newline at end of file
+export { FileSystemScanner, EvidenceCollectorImpl, ResultAggregator } from './utils';
+export { ProjectFileSystem, ProjectEntry, DirectoryFileSystem, MemoryFileSystem, SubdirectoryFileSystem, NormalizedFileSystem, IgnoredFileSystem, toProjectFileSystem } from './utils/project-fs';
+export { IgnoreMatcher, IGNORE_FILE, loadIgnoreMatcher, parseIgnoreFile } from './utils/ignore-patterns';
\ No newline at end of file
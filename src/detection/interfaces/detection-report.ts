@@ -0,0 +1,59 @@
+import { CargoWorkspaceInfo, DetectionSignal, JavaBuildInfo, MakefileInfo, PackageScriptsInfo, StaticSiteInfo } from './framework-info';
+import { ConfidenceLevel } from './confidence';
+import { DetectionWarning } from './detection-result';
+import { Diagnostic } from './diagnostic';
+import { VersionConstraint } from './version-constraint';
+
+/**
+ * Serializable view of a detection result for tools consuming it outside the generator.
+ * Fields are only added within a schema version; renaming or removing one bumps schemaVersion.
+ * Every path is repository-relative and uses forward slashes.
+ */
+export interface DetectionReport {
+  schemaVersion: number;
+  /** Languages of the detected frameworks, most confident first */
+  languages: Array<{ name: string; confidence: number }>;
+  frameworks: Array<{
+    name: string;
+    type: string;
+    ecosystem: string;
+    version?: string;
+    confidence: number;
+    signals: DetectionSignal[];
+  }>;
+  packageManagers: Array<{ name: string; configFile: string; lockFile?: string; version?: string }>;
+  versions: Array<Pick<VersionConstraint, 'runtime' | 'source' | 'raw' | 'versions'> & { exact?: string; error?: string }>;
+  testRunners: Array<{
+    name: string;
+    language: string;
+    command: string;
+    source: string;
+    confidence?: number;
+    suppressedBy?: string;
+  }>;
+  dockerImages: Array<{ dockerfile: string; context: string; baseImage?: string; targetStage?: string }>;
+  makefile?: MakefileInfo;
+  cargoWorkspace?: CargoWorkspaceInfo;
+  staticSite?: StaticSiteInfo;
+  javaBuild?: JavaBuildInfo;
+  /** Scripts of the root package.json, whichever of them CI runs */
+  packageScripts?: PackageScriptsInfo;
+  confidence: { score: number; level: ConfidenceLevel };
+  warnings: Array<Pick<DetectionWarning, 'type' | 'message' | 'affected'>>;
+  /** Warnings, errors and notes with stable codes, errors first */
+  diagnostics: Diagnostic[];
+  /** Steps the detected stack calls for, in pipeline order */
+  plannedSteps: DetectionReportStep[];
+}
+
+/**
+ * Step the pipeline for the detected stack would run
+ */
+export interface DetectionReportStep {
+  id: string;
+  name: string;
+  stage: 'setup' | 'build' | 'test' | 'security' | 'deploy';
+  command?: string;
+  uses?: string;
+  workingDirectory?: string;
+}
@@ -1,7 +1,43 @@
 import { FrameworkInfo } from './framework-info';
 import { BuildToolInfo } from './framework-info';
 import { ContainerInfo } from './framework-info';
+import { DockerImageInfo } from './framework-info';
+import { TestRunner } from './framework-info';
+import { MakefileInfo } from './framework-info';
+import { CargoWorkspaceInfo } from './framework-info';
+import { NodeWorkspaceInfo } from './framework-info';
+import { TaskGraphInfo } from './framework-info';
+import { StaticSiteInfo } from './framework-info';
+import { JavaBuildInfo } from './framework-info';
+import { CoverageInfo } from './framework-info';
+import { LinterInfo } from './framework-info';
+import { PythonLayoutInfo } from './framework-info';
+import { LanguageInfo } from './framework-info';
+import { ServiceDependency } from './framework-info';
+import { GoModuleInfo } from './framework-info';
+import { GoReleaserInfo } from './framework-info';
+import { PreCommitInfo } from './framework-info';
+import { TerraformInfo } from './framework-info';
+import { ElixirProjectInfo } from './framework-info';
+import { RubyProjectInfo } from './framework-info';
+import { SwiftPackageInfo } from './framework-info';
+import { DotnetProjectInfo } from './framework-info';
+import { DartProjectInfo } from './framework-info';
+import { HaskellProjectInfo } from './framework-info';
+import { CMakeProjectInfo } from './framework-info';
+import { ShellProjectInfo } from './framework-info';
+import { ApiSchemaInfo } from './framework-info';
+import { EnvExampleInfo } from './framework-info';
+import { GitCheckoutInfo } from './framework-info';
+import { LicenseInfo } from './framework-info';
+import { ChangelogInfo } from './framework-info';
+import { ProtobufInfo } from './framework-info';
+import { GeneratedCodeInfo } from './framework-info';
+import { CodeownersInfo } from './framework-info';
+import { PackageScriptsInfo } from './framework-info';
 import { OverallConfidence } from './confidence';
+import { VersionConstraint } from './version-constraint';
+import { Diagnostic, DiagnosticCode } from './diagnostic';
 
 /**
  * Complete result of framework detection analysis
@@ -19,6 +55,80 @@ export interface DetectionResult {
   alternatives: AlternativeFramework[];
   /** Warnings about conflicts or issues */
   warnings: DetectionWarning[];
+  /** Warnings, errors and notes with stable codes, for tools consuming the result, errors first */
+  diagnostics?: Diagnostic[];
+  /** Language version constraints read from manifests */
+  versionConstraints?: VersionConstraint[];
+  /** Dockerfiles found when a project path was scanned */
+  dockerImages?: DockerImageInfo[];
+  /** Test runners found when a project path was scanned */
+  testRunners?: TestRunner[];
+  /** Makefile found when a project path was scanned */
+  makefile?: MakefileInfo;
+  /** Cargo workspace found when a project path was scanned */
+  cargoWorkspace?: CargoWorkspaceInfo;
+  /** npm or pnpm workspace found when a project path was scanned */
+  nodeWorkspace?: NodeWorkspaceInfo;
+  /** Nx or Turborepo config found when a project path was scanned */
+  taskGraph?: TaskGraphInfo;
+  /** Static site generator found when a project path was scanned */
+  staticSite?: StaticSiteInfo;
+  /** Maven or Gradle build found when a project path was scanned */
+  javaBuild?: JavaBuildInfo;
+  /** Coverage tools found when a project path was scanned */
+  coverageTools?: CoverageInfo[];
+  /** Configured linters found when a project path was scanned */
+  linters?: LinterInfo[];
+  /** Python package layout found when a project path was scanned */
+  pythonLayout?: PythonLayoutInfo;
+  /** Languages with a build manifest found when a project path was scanned, primary first */
+  languages?: LanguageInfo[];
+  /** Services from docker-compose files and the README found when a project path was scanned */
+  services?: ServiceDependency[];
+  /** Root go.mod found when a project path was scanned */
+  goModule?: GoModuleInfo;
+  /** GoReleaser config found when a project path was scanned */
+  goReleaser?: GoReleaserInfo;
+  /** pre-commit configuration found when a project path was scanned */
+  preCommit?: PreCommitInfo;
+  /** Terraform root modules found when a project path was scanned */
+  terraform?: TerraformInfo;
+  /** Mix project found when a project path was scanned */
+  elixirProject?: ElixirProjectInfo;
+  /** Bundler project found when a project path was scanned */
+  rubyProject?: RubyProjectInfo;
+  /** Swift package found when a project path was scanned */
+  swiftPackage?: SwiftPackageInfo;
+  /** .NET solution or projects found when a project path was scanned */
+  dotnetProject?: DotnetProjectInfo;
+  /** Dart package or Flutter app found when a project path was scanned */
+  dartProject?: DartProjectInfo;
+  /** Stack or Cabal project found when a project path was scanned */
+  haskellProject?: HaskellProjectInfo;
+  /** CMake project found when a project path was scanned */
+  cmakeProject?: CMakeProjectInfo;
+  /** Shell scripts found when a project path was scanned with no other language */
+  shellProject?: ShellProjectInfo;
+  /** OpenAPI documents and GraphQL schemas found when a project path was scanned */
+  apiSchemas?: ApiSchemaInfo;
+  /** Example environment file found when a project path was scanned */
+  envExample?: EnvExampleInfo;
+  /** Submodules and git-versioning tools found when a project path was scanned */
+  gitCheckout?: GitCheckoutInfo;
+  /** The project's own license found when a project path was scanned */
+  license?: LicenseInfo;
+  /** Changelog at the root found when a project path was scanned */
+  changelog?: ChangelogInfo;
+  /** .proto files and buf configuration found when a project path was scanned */
+  protobuf?: ProtobufInfo;
+  /** Generated Go files and bundled JavaScript found when a project path was scanned */
+  generatedCode?: GeneratedCodeInfo;
+  /** CODEOWNERS file found when a project path was scanned */
+  codeowners?: CodeownersInfo;
+  /** Scripts of the root package.json found when a project path was scanned */
+  packageScripts?: PackageScriptsInfo;
+  /** Subdirectory of the project path the project lives in, when it is not at the root */
+  workingDirectory?: string;
   /** Timestamp of detection */
   detectedAt: Date;
   /** Detection execution time in milliseconds */
@@ -51,4 +161,8 @@ export interface DetectionWarning {
   affected: string[];
   /** Suggested resolution */
   resolution?: string;
+  /** Diagnostic code, when a more specific one than the type's applies */
+  code?: DiagnosticCode;
+  /** File the warning is about */
+  path?: string;
 }
\ No newline at end of file
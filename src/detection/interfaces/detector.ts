@@ -0,0 +1,37 @@
+import { DetectionResult, DetectionWarning } from './detection-result';
+import { ProjectFileSystem } from '../utils/project-fs';
+
+/**
+ * Parts of a detection result a detector can fill in from the project directory
+ */
+export type DetectedFields = Partial<Pick<DetectionResult,
+  'frameworks' | 'buildTools' | 'dockerImages' | 'testRunners' | 'makefile' | 'cargoWorkspace' | 'nodeWorkspace' | 'taskGraph' |
+  'staticSite' | 'javaBuild' | 'coverageTools' | 'linters' | 'pythonLayout' | 'languages' | 'services' | 'goModule' |
+  'goReleaser' | 'preCommit' | 'terraform' | 'packageScripts' | 'elixirProject' | 'rubyProject' | 'swiftPackage' |
+  'dotnetProject' | 'dartProject' | 'haskellProject' | 'cmakeProject' | 'shellProject' | 'apiSchemas' | 'envExample' | 'gitCheckout' | 'license' |
+  'changelog' | 'protobuf' | 'generatedCode' | 'codeowners'>>;
+
+/**
+ * What a detector found in one pass over the project directory
+ */
+export interface Detection {
+  /** Fields found */
+  fields: DetectedFields;
+  /** Confidence from 0 to 1; when detectors disagree on a field or entry, the more confident one wins */
+  confidence: number;
+  /** Problems met while detecting */
+  warnings?: DetectionWarning[];
+}
+
+/**
+ * Pluggable detection logic run against the project directory after the README analysis.
+ * Register implementations with registerDetector to recognize tools the built-in detectors do not.
+ */
+export interface Detector {
+  /**
+   * Detect what the project tree contains
+   * @param files - Project tree to read, rooted at the project directory
+   * @returns Promise resolving to the detections made; none when nothing was recognized
+   */
+  detect(files: ProjectFileSystem): Promise<Detection[]>;
+}
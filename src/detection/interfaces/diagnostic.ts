@@ -0,0 +1,75 @@
+/**
+ * How much a diagnostic matters: errors mean part of the project could not be detected,
+ * warnings that the generated CI may not behave as intended
+ */
+export type DiagnosticSeverity = 'error' | 'warning' | 'info';
+
+/**
+ * Severities, most severe first
+ */
+export const DIAGNOSTIC_SEVERITY_ORDER: DiagnosticSeverity[] = ['error', 'warning', 'info'];
+
+/**
+ * Stable codes of diagnostics, for tools filtering and counting them: new codes may be added,
+ * but none is renamed or removed
+ */
+export type DiagnosticCode =
+  | 'DETECTOR_FAILED'
+  | 'FILE_READ_FAILED'
+  | 'LOCKFILE_MISSING'
+  | 'VERSION_UNPARSEABLE'
+  | 'VERSION_UNSATISFIABLE'
+  | 'BUILD_TOOL_CONFLICT'
+  | 'CARGO_EXTERNAL_PATH_DEPENDENCY'
+  | 'DOCKERIGNORE_MISSING'
+  | 'TEST_RUNNER_SUPPRESSED'
+  | 'CODEOWNERS_LINE_IGNORED'
+  | 'DETECTION_CONFLICT'
+  | 'DETECTION_INCOMPLETE'
+  | 'VERSION_MISMATCH'
+  | 'DEPRECATED';
+
+/**
+ * Severity each code is reported at
+ */
+export const DIAGNOSTIC_SEVERITIES: Record<DiagnosticCode, DiagnosticSeverity> = {
+  /** A detector threw; what it finds is missing from the result */
+  DETECTOR_FAILED: 'error',
+  /** A file or directory could not be read after retrying; detection went on as if it were missing */
+  FILE_READ_FAILED: 'warning',
+  /** A package manager that pins dependencies through a lockfile has none committed */
+  LOCKFILE_MISSING: 'warning',
+  /** A manifest's language version constraint could not be parsed; default versions are used */
+  VERSION_UNPARSEABLE: 'warning',
+  /** No supported release satisfies a manifest's language version constraint; default versions are used */
+  VERSION_UNSATISFIABLE: 'warning',
+  /** Build files of two tools were found for one project and one of them was picked */
+  BUILD_TOOL_CONFLICT: 'warning',
+  /** A Cargo path dependency lies outside the workspace and is not tested on its own */
+  CARGO_EXTERNAL_PATH_DEPENDENCY: 'warning',
+  /** A Dockerfile's build context has no .dockerignore */
+  DOCKERIGNORE_MISSING: 'info',
+  /** A test runner is run through another one, so it gets no test job of its own */
+  TEST_RUNNER_SUPPRESSED: 'info',
+  /** A CODEOWNERS line GitHub ignores: a negated pattern, a character range or an invalid owner */
+  CODEOWNERS_LINE_IGNORED: 'warning',
+  /** Conflicting detections without a more specific code */
+  DETECTION_CONFLICT: 'warning',
+  /** Incomplete detection without a more specific code */
+  DETECTION_INCOMPLETE: 'warning',
+  /** Version mismatches without a more specific code */
+  VERSION_MISMATCH: 'warning',
+  /** Deprecated configuration without a more specific code */
+  DEPRECATED: 'info'
+};
+
+/**
+ * Machine-readable finding of a detection run, reported apart from logs
+ */
+export interface Diagnostic {
+  severity: DiagnosticSeverity;
+  code: DiagnosticCode;
+  message: string;
+  /** File the diagnostic is about, relative to the project */
+  path?: string;
+}
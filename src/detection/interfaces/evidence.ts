@@ -1,3 +1,5 @@
+import type { ProjectFileSystem } from '../utils/project-fs';
+
 /**
  * Evidence supporting framework detection
  */
@@ -54,10 +56,10 @@ export interface EvidenceCollector {
   /**
    * Collect evidence from project information
    * @param projectInfo - Project information to analyze
-   * @param projectPath - Optional project directory path
+   * @param project - Optional project directory path or file system
    * @returns Promise resolving to collected evidence
    */
-  collectEvidence(projectInfo: ProjectInfo, projectPath?: string): Promise<Evidence[]>;
+  collectEvidence(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<Evidence[]>;
   
   /**
    * Weight evidence based on type and context
@@ -1,5 +1,7 @@
 import { DetectionResult } from './detection-result';
 import { CIPipeline } from './ci-pipeline';
+import type { IgnoreMatcher } from '../utils/ignore-patterns';
+import type { ProjectFileSystem } from '../utils/project-fs';
 
 /**
  * Main interface for framework detection functionality
@@ -8,10 +10,15 @@ export interface FrameworkDetector {
   /**
    * Detect frameworks and build tools from project information
    * @param projectInfo - Parsed project information from README
-   * @param projectPath - Optional path to project directory for file system analysis
+   * @param project - Optional path to project directory, or project file system, for file system analysis
+   * @param workingDirectory - Optional directory under project the project lives in ('.' for the root),
+   * instead of the one found from where its manifests are
+   * @param ignore - Optional patterns of the paths under project detection leaves out
+   * @param deadline - Optional time (epoch milliseconds) past which reads of project that fail
+   * transiently are no longer retried
    * @returns Promise resolving to detection results with confidence scores
    */
-  detectFrameworks(projectInfo: ProjectInfo, projectPath?: string): Promise<DetectionResult>;
+  detectFrameworks(projectInfo: ProjectInfo, project?: string | ProjectFileSystem, workingDirectory?: string, ignore?: IgnoreMatcher, deadline?: number): Promise<DetectionResult>;
 
   /**
    * Generate CI/CD pipeline steps based on detection results
@@ -14,6 +14,8 @@ export interface FrameworkInfo {
   confidence: number;
   /** Evidence supporting this detection */
   evidence: Evidence[];
+  /** Signals that agreed on this detection, set when a project path was scanned */
+  signals?: DetectionSignal[];
   /** Programming language ecosystem */
   ecosystem: string; // 'nodejs', 'python', 'rust', 'go', 'java', 'frontend'
   /** Associated build tool */
@@ -26,6 +28,20 @@ export interface FrameworkInfo {
   metadata?: Record<string, any>;
 }
 
+/**
+ * Kind of observation backing a detection
+ */
+export type DetectionSignalKind = 'manifest' | 'lockfile' | 'file_extension' | 'readme';
+
+/**
+ * Observation that contributed to a detection's confidence
+ */
+export interface DetectionSignal {
+  kind: DetectionSignalKind;
+  /** File (or README) the signal was observed in */
+  source: string;
+}
+
 /**
  * Framework type categories
  */
@@ -54,6 +70,8 @@ export interface BuildToolInfo {
   name: string;
   /** Configuration file path */
   configFile: string;
+  /** Lockfile committed alongside the configuration (if found) */
+  lockFile?: string;
   /** Available build commands */
   commands: BuildCommand[];
   /** Tool version (if detected) */
@@ -96,4 +114,537 @@ export interface ContainerInfo {
   volumes?: string[];
   /** Deployment strategy */
   deploymentStrategy?: string;
-}
\ No newline at end of file
+}
+
+/**
+ * Dockerfile found in the repository
+ */
+export interface DockerImageInfo {
+  /** Repository-relative POSIX path of the Dockerfile */
+  dockerfile: string;
+  /** Build context: the directory containing the Dockerfile */
+  context: string;
+  /** Base image of the final stage, resolved through earlier stages */
+  baseImage?: string;
+  /** Name of the final stage of a multi-stage build, used as the build target */
+  targetStage?: string;
+  /** Number of FROM stages */
+  stageCount: number;
+  /** Whether a .dockerignore sits in the build context */
+  hasDockerignore: boolean;
+}
+
+/**
+ * Test runner configured in the project
+ */
+export interface TestRunner {
+  /** Runner name (tox, pytest, jest, nextest, ...) */
+  name: string;
+  /** Language the runner tests */
+  language: string;
+  /** Command that runs the tests */
+  command: string;
+  /** Commands that install the runner before it can be invoked */
+  setup: string[];
+  /** File the runner was detected from */
+  source: string;
+  /** Higher-level runner that already invokes this one */
+  suppressedBy?: string;
+  /** Confidence score (0-1) from the signals that agreed */
+  confidence?: number;
+  /** Signals that agreed on this runner */
+  signals?: DetectionSignal[];
+}
+
+/**
+ * Makefile at the project root and the targets it defines
+ */
+export interface MakefileInfo {
+  /** Makefile name (Makefile, makefile or GNUmakefile) */
+  file: string;
+  /** Target names in order of definition, excluding pattern rules and special targets */
+  targets: string[];
+  /** Targets declared in .PHONY */
+  phonyTargets: string[];
+}
+
+/**
+ * Crate belonging to a Cargo workspace
+ */
+export interface CargoWorkspaceMember {
+  /** Package name from the member's Cargo.toml */
+  name: string;
+  /** Workspace-relative POSIX path of the crate directory ('.' for the root package) */
+  path: string;
+}
+
+/**
+ * Cargo workspace declared in the root Cargo.toml
+ */
+export interface CargoWorkspaceInfo {
+  /** Member crates sorted by path, including path dependencies inside the workspace */
+  members: CargoWorkspaceMember[];
+  /** Directories listed under exclude */
+  excluded: string[];
+  /** Path dependencies outside the workspace directory, relative to it */
+  externalPathDependencies: string[];
+  /** Features declared by the root package, excluding default */
+  features: string[];
+  /** Whether the root manifest only declares the workspace and has no [package] */
+  virtual: boolean;
+}
+
+/**
+ * Package of an npm or pnpm workspace
+ */
+export interface NodeWorkspaceMember {
+  /** Name from the package's package.json, else its directory name */
+  name: string;
+  /** Directory relative to the workspace root */
+  path: string;
+  /** Names of the package's scripts */
+  scripts: string[];
+}
+
+/**
+ * npm or pnpm workspace declared at the project root. Its packages share the root lockfile.
+ */
+export interface NodeWorkspaceInfo {
+  /** pnpm for a pnpm-workspace.yaml, npm for the package.json `workspaces` field */
+  manager: 'npm' | 'pnpm';
+  /** Packages sorted by path */
+  members: NodeWorkspaceMember[];
+}
+
+/**
+ * Build tool a Java project is driven by
+ */
+export type JavaBuildSystem = 'maven' | 'gradle';
+
+/**
+ * Task runner of a JavaScript monorepo, which works out the packages a change affects
+ */
+export interface TaskGraphInfo {
+  tool: 'nx' | 'turbo';
+  /** nx.json or turbo.json */
+  configFile: string;
+  /** Tasks CI runs, as the config names them */
+  tasks: string[];
+}
+
+/**
+ * Java build found at the project root
+ */
+export interface JavaBuildInfo {
+  buildSystem: JavaBuildSystem;
+  /** pom.xml, or the Gradle build script (the settings script when there is none) */
+  manifest: string;
+  /** JDK feature release the sources target (e.g. '17'), when a manifest sets one */
+  javaVersion?: string;
+  /** Manifest the release was read from */
+  versionSource?: string;
+  /** Whether the build tool's wrapper script (mvnw or gradlew) is committed */
+  wrapper: boolean;
+  /** Module directories of a multi-module build, from the aggregator pom or settings script */
+  modules: string[];
+}
+
+/**
+ * Coverage tools the generator knows how to run and report
+ */
+export type CoverageTool = 'go-cover' | 'jest' | 'vitest' | 'nyc' | 'c8' | 'pytest-cov';
+
+/**
+ * Coverage tooling a project already has configured
+ */
+export interface CoverageInfo {
+  tool: CoverageTool;
+  /** Language whose tests the tool instruments */
+  language: string;
+  /** File the tool was found in */
+  source: string;
+  /** Report file the project's own commands write, when they name one */
+  reportFile?: string;
+}
+
+/**
+ * Linters the generator knows how to run
+ */
+export type Linter = 'golangci-lint' | 'eslint' | 'ruff' | 'flake8' | 'black' | 'clippy';
+
+/**
+ * Linter a project has configured
+ */
+export interface LinterInfo {
+  name: Linter;
+  /** Language whose sources it checks */
+  language: string;
+  /** File the linter was found in */
+  source: string;
+  /** Command running it over the project */
+  command: string;
+  /** Whether installing the project's dependencies installs it; otherwise CI installs it first */
+  installed: boolean;
+}
+
+/**
+ * Language found from its manifest and source files when a project path was scanned
+ */
+export interface LanguageInfo {
+  /** JavaScript, TypeScript, Python, Go, Rust or Java */
+  name: string;
+  /** Source files of the language in the repository */
+  files: number;
+  /** Fraction of the repository's source files, rounded to two decimals */
+  share: number;
+  /** Directory the language is built in: '.' or the top-level directory holding its manifest */
+  directory: string;
+  /** Whether this is the language with the most source files */
+  primary: boolean;
+}
+
+/**
+ * Where a Python project keeps its importable packages
+ */
+export type ProjectLayout = 'src' | 'flat';
+
+/**
+ * Python package layout found at the project root
+ */
+export interface PythonLayoutInfo {
+  layout: ProjectLayout;
+  /** Top-level packages, under src/ for the src layout and at the root otherwise */
+  packages: string[];
+  /** Packages among them without an __init__.py */
+  namespacePackages: string[];
+  /** Whether pyproject.toml or setup.py lets pip install the project itself */
+  installable: boolean;
+  /** Whether a requirements.txt is committed */
+  requirementsFile: boolean;
+}
+
+/**
+ * Go module declared by a go.mod
+ */
+export interface GoModuleInfo {
+  /** Module path from the module directive */
+  path: string;
+  /** Paths of required modules, direct and indirect */
+  requires: string[];
+}
+
+/**
+ * GoReleaser configuration of a Go project
+ */
+export interface GoReleaserInfo {
+  /** Config file name (.goreleaser.yml) */
+  file: string;
+}
+
+/**
+ * Scripts of the package.json at a project root
+ */
+export interface PackageScriptsInfo {
+  /** Script names (test, test:ci, build...) and the commands they run */
+  scripts: Record<string, string>;
+}
+
+/**
+ * Terraform configuration of a project
+ */
+export interface TerraformInfo {
+  /** Directories holding root module configuration, relative to the project root ('.' for the root) */
+  directories: string[];
+  /** Version constraint of the first `required_version` setting found (~> 1.6) */
+  requiredVersion?: string;
+}
+
+/**
+ * Mix project at a project root
+ */
+export interface ElixirProjectInfo {
+  /** Elixir version pinned in .tool-versions (1.16.2) or the release line mix.exs requires (1.15) */
+  elixirVersion?: string;
+  /** Erlang/OTP version pinned in .tool-versions */
+  otpVersion?: string;
+  /** File the Elixir version was read from */
+  versionSource?: '.tool-versions' | 'mix.exs';
+  /** Depends on phoenix */
+  phoenix: boolean;
+  /** Depends on ecto_sql with the postgrex adapter */
+  postgres: boolean;
+}
+
+/**
+ * Bundler project at a project root
+ */
+export interface RubyProjectInfo {
+  /** Ruby version pinned in .ruby-version (3.2.2) or named by the Gemfile's ruby directive */
+  rubyVersion?: string;
+  /** File the Ruby version was read from */
+  versionSource?: '.ruby-version' | 'Gemfile';
+  /** Gemspec at the root, when the project is a gem */
+  gemspec?: string;
+  /** Tests with rspec: it is a dependency or there is a .rspec file */
+  rspec: boolean;
+  /** Has a Rakefile */
+  rake: boolean;
+  /** Depends on rubocop or one of its plugins */
+  rubocop: boolean;
+  /** Depends on rails */
+  rails: boolean;
+  /** Database the pg or sqlite3 adapter gem of a Rails app connects to */
+  database?: 'postgres' | 'sqlite';
+}
+
+/**
+ * Swift Package Manager package at a project root
+ */
+export interface SwiftPackageInfo {
+  /** Tools version the `// swift-tools-version` comment opening Package.swift names (5.9) */
+  toolsVersion?: string;
+  /** Executable targets and products */
+  executables: string[];
+  /** Library products */
+  libraries: string[];
+  /** Test targets; without them there is nothing for swift test to run */
+  testTargets: string[];
+}
+
+/**
+ * .NET solution or projects at a project root
+ */
+export interface DotnetProjectInfo {
+  /** Solution at the root (App.sln or App.slnx); restore, build and test run on it */
+  solution?: string;
+  /** Project files the solution lists, or those at the root without one */
+  projects: string[];
+  /** Projects referencing a test framework; without them there is nothing for dotnet test to run */
+  testProjects: string[];
+  /** Target frameworks the projects declare (net8.0, netstandard2.0), in the order they appear */
+  targetFrameworks: string[];
+  /** SDK version global.json pins */
+  sdkVersion?: string;
+  /** Whether the projects commit NuGet lockfiles (packages.lock.json) */
+  lockFile: boolean;
+}
+
+/**
+ * Dart package or Flutter app at a project root
+ */
+export interface DartProjectInfo {
+  /** Package name pubspec.yaml declares */
+  name?: string;
+  /** Flutter project: pubspec.yaml has a flutter section or depends on the flutter SDK */
+  flutter: boolean;
+  /** Dart SDK constraint of the environment section (^3.3.0, >=3.0.0 <4.0.0) */
+  sdkConstraint?: string;
+  /** Flutter constraint of the environment section */
+  flutterConstraint?: string;
+  /** Platforms a Flutter app has directories for (android, ios, web, linux, macos, windows) */
+  platforms: string[];
+  /** Has a test directory; without it there is nothing for dart test or flutter test to run */
+  tests: boolean;
+}
+
+/**
+ * Stack or Cabal project at a project root
+ */
+export interface HaskellProjectInfo {
+  /** Tool the project builds with: stack when there is a stack.yaml, even alongside .cabal files */
+  tool: 'stack' | 'cabal';
+  /** Snapshot stack.yaml builds against (lts-22.7, nightly-2024-01-15, ghc-9.6.4), which names its GHC */
+  resolver?: string;
+  /** Newest GHC release the tested-with field of the .cabal files lists */
+  ghcVersion?: string;
+  /** .cabal files at the root, sorted */
+  cabalFiles: string[];
+  /** Declares a test suite: a test-suite stanza of a .cabal file or the tests of package.yaml */
+  tests: boolean;
+  /** Has an HLint configuration, .hlint.yaml */
+  hlint: boolean;
+}
+
+/**
+ * Shell scripts of a repository with no other language to build
+ */
+export interface ShellProjectInfo {
+  /** Scripts by path, sorted: .sh and .bash files, and files without an extension with a sh, bash, dash or ksh shebang */
+  scripts: string[];
+  /** Directories holding .bats tests, sorted */
+  batsTests: string[];
+  /** .editorconfig sets shfmt's options, so the scripts are formatted with shfmt */
+  shfmt: boolean;
+}
+
+/**
+ * API contracts of a project
+ */
+export interface ApiSchemaInfo {
+  /** OpenAPI and Swagger documents by path, sorted */
+  openapi: string[];
+  /** GraphQL schema files by path, sorted */
+  graphql: string[];
+  /** Spectral ruleset at the root (.spectral.yaml), which spectral lint reads instead of the OpenAPI one */
+  spectralRuleset?: string;
+}
+
+/**
+ * The project's own license
+ */
+export interface LicenseInfo {
+  /** SPDX id of the license (MIT, Apache-2.0), when the manifest declares it or the text is recognized */
+  spdxId?: string;
+  /** License file at the root (LICENSE, COPYING) */
+  file?: string;
+  /** Manifest or license file the SPDX id was read from */
+  source: string;
+}
+
+/**
+ * Changelog kept at the project root
+ */
+export interface ChangelogInfo {
+  /** File name as it is on disk (CHANGELOG.md, HISTORY.rst) */
+  file: string;
+}
+
+/**
+ * Protocol Buffers definitions of a project and the buf configuration for them
+ */
+export interface ProtobufInfo {
+  /** .proto files by path, sorted */
+  protoFiles: string[];
+  /** Directory buf runs in: the one holding bufConfig, else the root ('.') */
+  bufDirectory: string;
+  /** buf.work.yaml or buf.yaml closest to the root, configuring the workspace or module */
+  bufConfig?: string;
+  /** buf.gen.yaml closest to the root, configuring buf generate */
+  bufGenConfig?: string;
+}
+
+/**
+ * CODEOWNERS file of a repository
+ */
+export interface CodeownersInfo {
+  /** Where the file is: .github/CODEOWNERS, CODEOWNERS or docs/CODEOWNERS */
+  file: string;
+  /** Rules in file order; the last one matching a path decides its owners */
+  rules: CodeownersRule[];
+}
+
+/**
+ * A CODEOWNERS line: a pattern and who owns the paths it matches
+ */
+export interface CodeownersRule {
+  /** Pattern as written, with escaped spaces and #s unescaped */
+  pattern: string;
+  /** paths-filter globs matching what the pattern does */
+  globs: string[];
+  /** Users (@octocat), teams (@org/team) and emails; none leaves the paths unowned */
+  owners: string[];
+  /** Line number in the file */
+  line: number;
+}
+
+/**
+ * Generated code of a project, which linters leave out
+ */
+export interface GeneratedCodeInfo {
+  /** Generated Go files outside goDirectories by path, sorted */
+  goFiles: string[];
+  /** Directories holding only generated Go files (a protobuf package), sorted */
+  goDirectories: string[];
+  /** dist/ directories JavaScript packages bundle into, sorted */
+  bundleDirectories: string[];
+}
+
+/**
+ * Variables an example environment file (.env.example) documents
+ */
+export interface EnvExampleInfo {
+  /** File name (.env.example) */
+  file: string;
+  /** Variables in the order they are listed; secret ones never carry their example value */
+  variables: Array<{ name: string; value?: string; secret: boolean }>;
+}
+
+/**
+ * What a CI checkout has to fetch besides the latest commit
+ */
+export interface GitCheckoutInfo {
+  /** Submodules .gitmodules declares */
+  submodules: Array<{ name: string; path: string; url: string }>;
+  /** Tools deriving the version from git tags (setuptools-scm, GitVersion), which need the full history */
+  versionFromGit: string[];
+}
+
+/**
+ * CMake project of a C/C++ repository
+ */
+export interface CMakeProjectInfo {
+  /** Version cmake_minimum_required asks for (3.16) */
+  minimumVersion?: string;
+  /** C++ standard set through CMAKE_CXX_STANDARD or a cxx_std_N compile feature (17) */
+  cxxStandard?: string;
+  /** Root CMakeLists.txt enables CTest or a test preset exists */
+  tests: boolean;
+  /** Presets of CMakePresets.json CI configures, builds and tests with */
+  presets?: {
+    configure: string;
+    build?: string;
+    test?: string;
+    /** Directory the configure preset writes to, when it can be resolved statically */
+    binaryDir?: string;
+    /** CMake release the presets schema version needs */
+    requiredVersion?: string;
+  };
+  /** Directory the project is configured into, relative to the root */
+  binaryDir: string;
+  /** A .clang-format file styles the sources */
+  clangFormat: boolean;
+}
+
+/**
+ * pre-commit configuration of a repository
+ */
+export interface PreCommitInfo {
+  /** Config file name (.pre-commit-config.yaml) */
+  file: string;
+  /** Ids of the hooks it runs */
+  hooks: string[];
+}
+
+/**
+ * Static site generators whose output can be published to GitHub Pages
+ */
+export type StaticSiteGenerator = 'hugo' | 'jekyll' | 'nextjs' | 'vitepress' | 'mkdocs';
+
+/**
+ * Static site generator configured at the project root
+ */
+export interface StaticSiteInfo {
+  generator: StaticSiteGenerator;
+  /** Config file the generator was detected from */
+  configFile: string;
+  /** Project-relative directory the build writes the site to */
+  outputDir: string;
+}
+
+/**
+ * Backing service such as postgres or redis that the project's tests talk to
+ */
+export interface ServiceDependency {
+  /** Service id: postgres, mysql, redis, mongodb or rabbitmq */
+  name: string;
+  /** Image a docker-compose file runs it from, tag included when it pins one */
+  image?: string;
+  /** Version the README asks for, when no compose file names an image */
+  version?: string;
+  /** Container ports the compose file publishes */
+  ports: number[];
+  /** Environment the compose file sets for the container */
+  env: Record<string, string>;
+  /** Compose file or README the service was found in */
+  source: string;
+}
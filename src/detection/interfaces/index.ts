@@ -5,5 +5,9 @@ export * from './framework-info';
 export * from './ci-pipeline';
 export { LanguageAnalyzer, LanguageDetectionResult, AnalysisMetadata } from './language-analyzer';
 export * from './detection-rules';
+export * from './version-constraint';
+export * from './diagnostic';
+export * from './detection-report';
+export * from './detector';
 export { Evidence, EvidenceType, EvidenceLocation, EvidenceCollector, EvidenceFilter, EvidenceAggregation } from './evidence';
 export { OverallConfidence, ConfidenceLevel, ConfidenceBreakdown, ComponentConfidence, EvidenceQuality, ConfidenceFactor, FactorType } from './confidence';
\ No newline at end of file
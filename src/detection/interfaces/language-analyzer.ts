@@ -2,6 +2,8 @@ import { FrameworkInfo } from './framework-info';
 import { BuildToolInfo } from './framework-info';
 import { ContainerInfo } from './framework-info';
 import { CIStep } from './ci-pipeline';
+import { VersionConstraint } from './version-constraint';
+import type { ProjectFileSystem } from '../utils/project-fs';
 
 /**
  * Interface for language-specific framework analyzers
@@ -23,10 +25,10 @@ export interface LanguageAnalyzer {
   /**
    * Analyze project and detect frameworks
    * @param projectInfo - Project information from README parsing
-   * @param projectPath - Optional path to project directory
+   * @param project - Optional path to project directory, or the project file system to read
    * @returns Promise resolving to language-specific detection results
    */
-  analyze(projectInfo: ProjectInfo, projectPath?: string): Promise<LanguageDetectionResult>;
+  analyze(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<LanguageDetectionResult>;
   
   /**
    * Generate CI/CD steps for detected frameworks
@@ -50,6 +52,8 @@ export interface LanguageDetectionResult {
   confidence: number;
   /** Recommendations for improvement */
   recommendations: string[];
+  /** Language version constraint declared in the project manifest */
+  versionConstraint?: VersionConstraint;
   /** Analysis metadata */
   metadata: AnalysisMetadata;
 }
@@ -0,0 +1,33 @@
+/**
+ * Runtimes whose manifests declare a version constraint
+ */
+export type ConstraintRuntime = 'node' | 'go' | 'python';
+
+/**
+ * A single comparison against a version, e.g. `>= 18.17.0`.
+ * `!=` and prefix `=` compare only as many components as `version` has.
+ */
+export interface VersionComparator {
+  operator: '>=' | '>' | '<=' | '<' | '=' | '!=';
+  version: number[];
+}
+
+/**
+ * Version constraint read from a manifest
+ */
+export interface VersionConstraint {
+  /** Runtime the constraint applies to */
+  runtime: ConstraintRuntime;
+  /** Manifest the constraint was read from */
+  source: string;
+  /** Constraint exactly as written */
+  raw: string;
+  /** Set when the constraint pins a single release */
+  exact?: string;
+  /** Alternative comparator sets; a version satisfying every comparator of any set is allowed */
+  ranges: VersionComparator[][];
+  /** Supported release lines that satisfy the constraint, oldest first */
+  versions: string[];
+  /** Set when the constraint could not be parsed; ranges are then empty */
+  error?: string;
+}
@@ -0,0 +1,183 @@
+import { JavaBuildInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Gradle build and settings scripts, Groovy before Kotlin DSL
+ */
+const GRADLE_FILES = ['build.gradle', 'build.gradle.kts', 'settings.gradle', 'settings.gradle.kts'];
+
+/**
+ * pom.xml elements that set the JDK release, most authoritative first
+ */
+const MAVEN_VERSION_ELEMENTS = ['maven.compiler.release', 'release', 'maven.compiler.source', 'maven.compiler.target', 'java.version'];
+
+/**
+ * Gradle settings that set the JDK release: toolchain blocks take precedence over source compatibility
+ */
+const GRADLE_VERSION_PATTERNS = [
+  /languageVersion\s*(?:=|\.\s*set\s*\()\s*JavaLanguageVersion\.of\(\s*["']?(\d+)/,
+  /jvmToolchain\(\s*(\d+)/,
+  /sourceCompatibility\s*=?\s*(?:JavaVersion\.VERSION_|JavaVersion\.toVersion\(\s*)?["']?([\d_.]+)/
+];
+
+/**
+ * Determines whether a project builds with Maven or Gradle, and the JDK release it targets
+ */
+export class JavaBuildDetector {
+  /**
+   * Detect the Java build of a project, given as a directory or a file system; undefined when there
+   * is no pom.xml or Gradle script.
+   * A pom.xml wins over Gradle scripts. For multi-module builds the root pom or settings script
+   * is the aggregator the build runs from, and modules are searched when it sets no release.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<JavaBuildInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const pom = await this.readFile(files, 'pom.xml');
+    if (pom !== undefined) {
+      return this.detectMaven(files, pom);
+    }
+
+    for (const file of GRADLE_FILES) {
+      if (await this.readFile(files, file) !== undefined) {
+        return this.detectGradle(files);
+      }
+    }
+
+    return undefined;
+  }
+
+  private async detectMaven(files: ProjectFileSystem, pom: string): Promise<JavaBuildInfo> {
+    const modules = [...pom.replace(/<!--[\s\S]*?-->/g, '').matchAll(/<module>\s*([^<\s]+)\s*<\/module>/g)]
+      .map(match => match[1]!.replace(/\/pom\.xml$/, '').replace(/\/+$/, ''));
+
+    let version = readMavenVersion(pom);
+    let versionSource = version ? 'pom.xml' : undefined;
+
+    // Modules may each set the release; the build needs a JDK for the newest of them
+    if (!version) {
+      for (const module of modules) {
+        const modulePom = await this.readFile(files, `${module}/pom.xml`);
+        const moduleVersion = modulePom !== undefined ? readMavenVersion(modulePom) : undefined;
+        if (moduleVersion && (!version || Number(moduleVersion) > Number(version))) {
+          version = moduleVersion;
+          versionSource = `${module}/pom.xml`;
+        }
+      }
+    }
+
+    return {
+      buildSystem: 'maven',
+      manifest: 'pom.xml',
+      ...(version && { javaVersion: version, versionSource }),
+      wrapper: await this.readFile(files, 'mvnw') !== undefined,
+      modules
+    };
+  }
+
+  private async detectGradle(files: ProjectFileSystem): Promise<JavaBuildInfo> {
+    const scripts: Array<{ file: string; content: string }> = [];
+    for (const file of GRADLE_FILES) {
+      const content = await this.readFile(files, file);
+      if (content !== undefined) {
+        scripts.push({ file, content });
+      }
+    }
+
+    const settings = scripts.find(script => script.file.startsWith('settings.'));
+    const modules = settings ? readGradleIncludes(settings.content) : [];
+
+    let found = scripts.map(script => ({ file: script.file, version: readGradleVersion(script.content) }))
+      .find(entry => entry.version);
+
+    if (!found) {
+      for (const module of modules) {
+        for (const name of ['build.gradle', 'build.gradle.kts']) {
+          const content = await this.readFile(files, `${module}/${name}`);
+          const version = content !== undefined ? readGradleVersion(content) : undefined;
+          if (version && (!found || Number(version) > Number(found.version))) {
+            found = { file: `${module}/${name}`, version };
+          }
+        }
+      }
+    }
+
+    return {
+      buildSystem: 'gradle',
+      manifest: scripts.find(script => script.file.startsWith('build.'))?.file || settings!.file,
+      ...(found?.version && { javaVersion: found.version, versionSource: found.file }),
+      wrapper: await this.readFile(files, 'gradlew') !== undefined,
+      modules
+    };
+  }
+
+  private async readFile(files: ProjectFileSystem, file: string): Promise<string | undefined> {
+    try {
+      return await files.readFile(file);
+    } catch {
+      return undefined;
+    }
+  }
+}
+
+/**
+ * JDK release a pom.xml targets, resolving `${property}` references against its properties
+ */
+function readMavenVersion(pom: string): string | undefined {
+  const content = pom.replace(/<!--[\s\S]*?-->/g, '');
+  const read = (element: string) =>
+    content.match(new RegExp(`<${element.replace(/\./g, '\\.')}>\\s*([^<\\s]+)\\s*</${element.replace(/\./g, '\\.')}>`))?.[1];
+
+  for (const element of MAVEN_VERSION_ELEMENTS) {
+    let value = read(element);
+    const reference = value?.match(/^\$\{([^}]+)\}$/)?.[1];
+    if (reference) {
+      value = read(reference);
+    }
+    const version = value && normalizeJavaVersion(value);
+    if (version) {
+      return version;
+    }
+  }
+
+  return undefined;
+}
+
+function readGradleVersion(script: string): string | undefined {
+  for (const pattern of GRADLE_VERSION_PATTERNS) {
+    const value = script.match(pattern)?.[1];
+    const version = value && normalizeJavaVersion(value.replace(/_/g, '.'));
+    if (version) {
+      return version;
+    }
+  }
+  return undefined;
+}
+
+/**
+ * Project directories named by settings.gradle `include` statements; `:core:api` is core/api
+ */
+function readGradleIncludes(settings: string): string[] {
+  const modules: string[] = [];
+  for (const statement of settings.matchAll(/^\s*include\s*\(?([^\n)]*)/gm)) {
+    for (const name of statement[1]!.matchAll(/["']([^"']+)["']/g)) {
+      modules.push(name[1]!.replace(/^:/, '').replace(/:/g, '/'));
+    }
+  }
+  return [...new Set(modules)];
+}
+
+/**
+ * Feature release of a Java version: 1.8 is 8, 17.0.2 is 17
+ */
+function normalizeJavaVersion(value: string): string | undefined {
+  const match = value.match(/^(?:1\.)?(\d+)(?:\.\d+)*$/);
+  return match ? String(Number(match[1])) : undefined;
+}
+
+registerDetector('java-build', {
+  async detect(files: ProjectFileSystem) {
+    const javaBuild = await new JavaBuildDetector().detect(files);
+    return javaBuild ? [{ fields: { javaBuild }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,193 @@
+import { extname, posix } from 'path';
+import { LanguageInfo } from './interfaces/framework-info';
+import { SKIPPED_DIRECTORIES } from './monorepo-detector';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Languages the generator can build, with the manifests that mark a directory as one of their
+ * projects (by name, or by extension as in *.csproj) and the extensions of their source files
+ */
+const LANGUAGES: Array<{ name: string; manifests: string[]; extensions: string[] }> = [
+  { name: 'JavaScript', manifests: ['package.json'], extensions: ['.js', '.jsx', '.mjs', '.cjs', '.ts', '.tsx', '.mts', '.cts'] },
+  { name: 'Python', manifests: ['pyproject.toml', 'setup.py', 'setup.cfg', 'requirements.txt', 'Pipfile'], extensions: ['.py'] },
+  { name: 'Go', manifests: ['go.mod'], extensions: ['.go'] },
+  { name: 'Rust', manifests: ['Cargo.toml'], extensions: ['.rs'] },
+  { name: 'Java', manifests: ['pom.xml', 'build.gradle', 'build.gradle.kts'], extensions: ['.java', '.kt'] },
+  { name: 'Elixir', manifests: ['mix.exs'], extensions: ['.ex', '.exs'] },
+  { name: 'Ruby', manifests: ['Gemfile', '.ruby-version'], extensions: ['.rb', '.rake'] },
+  { name: 'Swift', manifests: ['Package.swift'], extensions: ['.swift'] },
+  { name: 'Dart', manifests: ['pubspec.yaml'], extensions: ['.dart'] },
+  { name: 'Haskell', manifests: ['stack.yaml', '*.cabal'], extensions: ['.hs', '.lhs'] },
+  { name: 'C#', manifests: ['*.sln', '*.slnx', '*.csproj', 'global.json'], extensions: ['.cs'] },
+  { name: 'C/C++', manifests: ['CMakeLists.txt'], extensions: ['.cpp', '.cc', '.cxx', '.c', '.h', '.hpp', '.hh', '.hxx'] }
+];
+
+/**
+ * Manifests of every language the generator can build
+ */
+export const LANGUAGE_MANIFESTS = LANGUAGES.flatMap(language => language.manifests);
+
+const TYPESCRIPT_EXTENSIONS = new Set(['.ts', '.tsx', '.mts', '.cts']);
+
+/**
+ * Share of the repository's source files below which a language counts as tooling
+ * (e.g. a package.json for commit hooks in a Go service) rather than part of the project
+ */
+const MIN_LANGUAGE_SHARE = 0.05;
+
+/**
+ * Directory depth and entry count the source file walk stops at
+ */
+const MAX_SCAN_DEPTH = 8;
+const MAX_SCAN_ENTRIES = 20000;
+
+/**
+ * Finds the languages of a repository that mixes several of them without being a monorepo,
+ * e.g. a Go backend at the root and a TypeScript frontend in web/
+ */
+export class LanguageDetector {
+  /**
+   * Detect languages with a manifest at the root or in a top-level directory, most source
+   * files first; the first one is primary. A language whose manifest is only found in a
+   * subdirectory is built there, in the one holding most of its files.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<LanguageInfo[]> {
+    const tree = toProjectFileSystem(project);
+    const { counts, typescript } = await this.countSourceFiles(tree);
+    const sum = (byDirectory: Map<string, number> | undefined, directory = '.') => directory === '.'
+      ? [...(byDirectory?.values() || [])].reduce((total, files) => total + files, 0)
+      : byDirectory?.get(directory) || 0;
+    const total = [...counts.values()].reduce((files, byDirectory) => files + sum(byDirectory), 0);
+    if (total === 0) {
+      return [];
+    }
+
+    const directories = ['.', ...await this.listDirectories(tree)];
+    const languages: Array<Omit<LanguageInfo, 'primary'>> = [];
+
+    for (const language of LANGUAGES) {
+      const byDirectory = counts.get(language.name);
+      const files = sum(byDirectory);
+      if (files === 0 || files / total < MIN_LANGUAGE_SHARE) {
+        continue;
+      }
+
+      const manifestDirectories: string[] = [];
+      for (const directory of directories) {
+        if (await hasManifest(tree, directory, language.manifests)) {
+          manifestDirectories.push(directory);
+        }
+      }
+      if (manifestDirectories.length === 0) {
+        continue;
+      }
+
+      const directory = manifestDirectories.includes('.')
+        ? '.'
+        : manifestDirectories.sort((a, b) => sum(byDirectory, b) - sum(byDirectory, a) || a.localeCompare(b))[0]!;
+      const isTypeScript = language.name === 'JavaScript' &&
+        (sum(typescript, directory) * 2 >= sum(byDirectory, directory) || await hasManifest(tree, directory, ['tsconfig.json']));
+
+      languages.push({
+        name: isTypeScript ? 'TypeScript' : language.name,
+        files,
+        share: Math.round((files / total) * 100) / 100,
+        directory
+      });
+    }
+
+    return languages
+      .sort((a, b) => b.files - a.files || a.name.localeCompare(b.name))
+      .map((language, index) => ({ ...language, primary: index === 0 }));
+  }
+
+  /**
+   * Count source files per language and top-level directory ('.' for files at the root).
+   * TypeScript files are also counted on their own to tell TypeScript projects from JavaScript ones.
+   */
+  private async countSourceFiles(files: ProjectFileSystem): Promise<{ counts: Map<string, Map<string, number>>; typescript: Map<string, number> }> {
+    const counts = new Map<string, Map<string, number>>();
+    const typescript = new Map<string, number>();
+    const byExtension = new Map(LANGUAGES.flatMap(language => language.extensions.map(extension => [extension, language.name] as const)));
+
+    let scanned = 0;
+    const walk = async (relativePath: string, depth: number): Promise<void> => {
+      let entries;
+      try {
+        entries = await files.readdir(relativePath);
+      } catch {
+        return;
+      }
+
+      for (const entry of entries) {
+        if (++scanned > MAX_SCAN_ENTRIES) {
+          return;
+        }
+        const childPath = relativePath === '.' ? entry.name : `${relativePath}/${entry.name}`;
+
+        if (entry.isDirectory()) {
+          if (depth < MAX_SCAN_DEPTH && !entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name)) {
+            await walk(childPath, depth + 1);
+          }
+          continue;
+        }
+
+        const extension = extname(entry.name);
+        const language = entry.isFile() && !entry.name.endsWith('.d.ts') ? byExtension.get(extension) : undefined;
+        if (!language) {
+          continue;
+        }
+
+        const topLevel = relativePath === '.' ? '.' : relativePath.split('/')[0]!;
+        const byDirectory = counts.get(language) || new Map<string, number>();
+        byDirectory.set(topLevel, (byDirectory.get(topLevel) || 0) + 1);
+        counts.set(language, byDirectory);
+        if (TYPESCRIPT_EXTENSIONS.has(extension)) {
+          typescript.set(topLevel, (typescript.get(topLevel) || 0) + 1);
+        }
+      }
+    };
+
+    await walk('.', 0);
+    return { counts, typescript };
+  }
+
+  private async listDirectories(files: ProjectFileSystem): Promise<string[]> {
+    try {
+      const entries = await files.readdir('.');
+      return entries
+        .filter(entry => entry.isDirectory() && !entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name))
+        .map(entry => entry.name)
+        .sort();
+    } catch {
+      return [];
+    }
+  }
+}
+
+/**
+ * Whether a directory holds one of the manifests, named exactly or, for *.csproj and the like,
+ * by extension
+ */
+export async function hasManifest(files: ProjectFileSystem, directory: string, manifests: string[] = LANGUAGE_MANIFESTS): Promise<boolean> {
+  for (const file of manifests.filter(manifest => !manifest.startsWith('*'))) {
+    if (await files.exists(posix.join(directory, file))) {
+      return true;
+    }
+  }
+
+  const extensions = manifests.filter(manifest => manifest.startsWith('*')).map(manifest => manifest.slice(1));
+  if (extensions.length === 0) {
+    return false;
+  }
+  const entries = await files.readdir(directory).catch(() => []);
+  return entries.some(entry => entry.isFile() && extensions.some(extension => entry.name.endsWith(extension)));
+}
+
+registerDetector('languages', {
+  async detect(files: ProjectFileSystem) {
+    const languages = await new LanguageDetector().detect(files);
+    return languages.length > 0 ? [{ fields: { languages }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
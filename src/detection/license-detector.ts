@@ -0,0 +1,90 @@
+import * as toml from '@iarna/toml';
+import { LicenseInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * License files looked up at a project root, in priority order
+ */
+const LICENSE_FILES = ['LICENSE', 'LICENSE.md', 'LICENSE.txt', 'LICENCE', 'LICENCE.md', 'COPYING', 'COPYING.md'];
+
+/**
+ * Phrases of the common license texts, most specific first, with the SPDX id of the license
+ */
+const LICENSE_TEXTS: Array<[RegExp, string]> = [
+  [/GNU AFFERO GENERAL PUBLIC LICENSE\s+Version 3/i, 'AGPL-3.0'],
+  [/GNU LESSER GENERAL PUBLIC LICENSE\s+Version 3/i, 'LGPL-3.0'],
+  [/GNU LESSER GENERAL PUBLIC LICENSE\s+Version 2\.1/i, 'LGPL-2.1'],
+  [/GNU GENERAL PUBLIC LICENSE\s+Version 3/i, 'GPL-3.0'],
+  [/GNU GENERAL PUBLIC LICENSE\s+Version 2/i, 'GPL-2.0'],
+  [/Mozilla Public License,?\s+(Version|v\.)\s*2\.0/i, 'MPL-2.0'],
+  [/Apache License,?\s+Version 2\.0/i, 'Apache-2.0'],
+  [/This is free and unencumbered software released into the public domain/i, 'Unlicense'],
+  [/Permission to use, copy, modify, and\/or distribute this software/i, 'ISC'],
+  [/Permission is hereby granted, free of charge/i, 'MIT'],
+  [/Redistribution and use in source and binary forms[\s\S]*Neither the name/i, 'BSD-3-Clause'],
+  [/Redistribution and use in source and binary forms/i, 'BSD-2-Clause']
+];
+
+/**
+ * Reads a project's own license from its manifest or the license file at its root
+ */
+export class LicenseDetector {
+  /**
+   * Detect the license of a project, given as a directory or a file system: the SPDX id the
+   * license field of package.json, Cargo.toml or pyproject.toml declares, else the one the
+   * license file's SPDX-License-Identifier line or text indicates. Undefined when it has
+   * neither a license field nor a license file.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<LicenseInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    let file: string | undefined;
+    let text: string | undefined;
+    for (const candidate of LICENSE_FILES) {
+      text = await files.readFile(candidate).catch(() => undefined);
+      if (text !== undefined) {
+        file = candidate;
+        break;
+      }
+    }
+
+    const declared = await this.readManifestLicense(files);
+    if (declared) {
+      return { spdxId: declared.spdxId, ...(file && { file }), source: declared.source };
+    }
+    if (file === undefined || text === undefined) {
+      return undefined;
+    }
+
+    const spdxId = text.match(/SPDX-License-Identifier:\s*([\w.+-]+)/)?.[1] ||
+      LICENSE_TEXTS.find(([pattern]) => pattern.test(text!))?.[1];
+    return { ...(spdxId && { spdxId }), file, source: file };
+  }
+
+  /**
+   * License field of the first manifest declaring one, with the manifest's name
+   */
+  private async readManifestLicense(files: ProjectFileSystem): Promise<{ spdxId: string; source: string } | undefined> {
+    const packageJson = await files.readFile('package.json').then(JSON.parse).catch(() => undefined);
+    if (typeof packageJson?.license === 'string' && packageJson.license.trim() !== '') {
+      return { spdxId: packageJson.license.trim(), source: 'package.json' };
+    }
+
+    for (const [manifest, table] of [['Cargo.toml', 'package'], ['pyproject.toml', 'project']] as const) {
+      const parsed: any = await files.readFile(manifest).then(content => toml.parse(content)).catch(() => undefined);
+      const license = parsed?.[table]?.license;
+      const spdxId = typeof license === 'string' ? license : typeof license?.text === 'string' ? license.text : undefined;
+      if (spdxId && spdxId.trim() !== '') {
+        return { spdxId: spdxId.trim(), source: manifest };
+      }
+    }
+    return undefined;
+  }
+}
+
+registerDetector('license', {
+  async detect(files: ProjectFileSystem) {
+    const license = await new LicenseDetector().detect(files);
+    return license ? [{ fields: { license }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,124 @@
+import { LinterInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+const GOLANGCI_CONFIGS = ['.golangci.yml', '.golangci.yaml', '.golangci.toml', '.golangci.json'];
+
+const ESLINT_CONFIGS = [
+  'eslint.config.js', 'eslint.config.mjs', 'eslint.config.cjs', 'eslint.config.ts',
+  '.eslintrc', '.eslintrc.js', '.eslintrc.cjs', '.eslintrc.json', '.eslintrc.yml', '.eslintrc.yaml'
+];
+
+const PYTHON_REQUIREMENTS = ['requirements.txt', 'requirements-dev.txt', 'requirements-test.txt', 'dev-requirements.txt', 'test-requirements.txt'];
+
+/**
+ * Python linters in the order they run, with the files and sections configuring them
+ */
+const PYTHON_LINTERS: Array<{ name: 'ruff' | 'flake8' | 'black'; command: string; files: string[]; sections: Array<[string, RegExp]> }> = [
+  { name: 'ruff', command: 'ruff check .', files: ['ruff.toml', '.ruff.toml'], sections: [['pyproject.toml', /^\[tool\.ruff[\].]/m]] },
+  { name: 'flake8', command: 'flake8 .', files: ['.flake8'], sections: [['setup.cfg', /^\[flake8\]/m], ['tox.ini', /^\[flake8\]/m]] },
+  { name: 'black', command: 'black --check .', files: [], sections: [['pyproject.toml', /^\[tool\.black\]/m]] }
+];
+
+/**
+ * Finds the linters a project has configured, so lint jobs run them the way the project does
+ */
+export class LintDetector {
+  /**
+   * Detect configured linters in a project, given as a directory or a file system, in the order
+   * they should run. A linter counts as configured once its config file is there, whether or not
+   * the project's dependencies install it; clippy comes with every Cargo project.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<LinterInfo[]> {
+    const files = toProjectFileSystem(project);
+    const found: LinterInfo[] = [];
+
+    const golangci = await this.findFirst(files, GOLANGCI_CONFIGS);
+    if (golangci) {
+      found.push({ name: 'golangci-lint', language: 'Go', source: golangci, command: 'golangci-lint run', installed: false });
+    }
+
+    const eslint = await this.detectESLint(files);
+    if (eslint) {
+      found.push(eslint);
+    }
+
+    found.push(...await this.detectPython(files));
+
+    if (await files.exists('Cargo.toml')) {
+      found.push({ name: 'clippy', language: 'Rust', source: 'Cargo.toml', command: 'cargo clippy -- -D warnings', installed: true });
+    }
+
+    return found;
+  }
+
+  /**
+   * ESLint from a config file, an `eslintConfig` key or an eslint dependency in package.json
+   */
+  private async detectESLint(files: ProjectFileSystem): Promise<LinterInfo | undefined> {
+    let packageJson: any = {};
+    const content = await this.readFile(files, 'package.json');
+    if (content !== undefined) {
+      try {
+        packageJson = JSON.parse(content);
+      } catch {
+        // Config files still count without a readable package.json
+      }
+    }
+
+    const installed = Boolean(packageJson.devDependencies?.eslint || packageJson.dependencies?.eslint);
+    const config = await this.findFirst(files, ESLINT_CONFIGS);
+    const source = config || (packageJson.eslintConfig || installed ? 'package.json' : undefined);
+    return source ? { name: 'eslint', language: 'JavaScript', source, command: 'npx eslint .', installed } : undefined;
+  }
+
+  private async detectPython(files: ProjectFileSystem): Promise<LinterInfo[]> {
+    const contents = new Map<string, string>();
+    for (const file of ['pyproject.toml', 'setup.cfg', 'tox.ini', ...PYTHON_REQUIREMENTS]) {
+      const content = await this.readFile(files, file);
+      if (content !== undefined) {
+        contents.set(file, content);
+      }
+    }
+
+    const found: LinterInfo[] = [];
+    for (const linter of PYTHON_LINTERS) {
+      const source = await this.findFirst(files, linter.files) ||
+        linter.sections.find(([file, section]) => section.test(contents.get(file) || ''))?.[0];
+      if (!source) {
+        continue;
+      }
+
+      // Listed as a requirement (`ruff>=0.4`, `"ruff>=0.4",`, `ruff = "^0.4"`), so installing the dependencies installs it
+      const requirement = new RegExp(`^\\s*["']?${linter.name}\\b`, 'im');
+      const installed = ['pyproject.toml', ...PYTHON_REQUIREMENTS].some(file => requirement.test(contents.get(file) || ''));
+      found.push({ name: linter.name, language: 'Python', source, command: linter.command, installed });
+    }
+
+    return found;
+  }
+
+  private async readFile(files: ProjectFileSystem, file: string): Promise<string | undefined> {
+    try {
+      return await files.readFile(file);
+    } catch {
+      return undefined;
+    }
+  }
+
+  private async findFirst(files: ProjectFileSystem, candidates: string[]): Promise<string | undefined> {
+    for (const file of candidates) {
+      if (await files.exists(file)) {
+        return file;
+      }
+    }
+    return undefined;
+  }
+}
+
+registerDetector('linters', {
+  async detect(files: ProjectFileSystem) {
+    const linters = await new LintDetector().detect(files);
+    return linters.length > 0 ? [{ fields: { linters }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,87 @@
+import { MakefileInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * File names GNU make reads by default, in lookup order
+ */
+const MAKEFILE_NAMES = ['GNUmakefile', 'makefile', 'Makefile'];
+
+/**
+ * Rule line: one or more target names, a colon (or two) and no assignment.
+ * Pattern rules (%.o: %.c) and special targets (.PHONY) start with other characters and never match.
+ */
+const RULE_PATTERN = /^([a-zA-Z0-9_-]+(?:[ \t]+[a-zA-Z0-9_-]+)*)[ \t]*:(?!:?=)/;
+
+/**
+ * Parse the targets a Makefile defines, in order of first appearance, and the ones declared .PHONY
+ */
+export function parseMakefileTargets(content: string): { targets: string[]; phonyTargets: string[] } {
+  const targets: string[] = [];
+  const phonyTargets: string[] = [];
+  const lines = content.replace(/\\\r?\n/g, ' ').split(/\r?\n/);
+
+  for (const line of lines) {
+    // Recipe lines start with a tab and may contain anything
+    if (line.startsWith('\t')) {
+      continue;
+    }
+
+    const phony = line.match(/^\.PHONY[ \t]*:(.*)$/);
+    if (phony) {
+      phonyTargets.push(...phony[1]!.replace(/#.*$/, '').split(/\s+/).filter(Boolean));
+      continue;
+    }
+
+    const rule = line.match(RULE_PATTERN);
+    if (rule) {
+      targets.push(...rule[1]!.split(/[ \t]+/));
+    }
+  }
+
+  return {
+    targets: [...new Set(targets)],
+    phonyTargets: [...new Set(phonyTargets)]
+  };
+}
+
+/**
+ * Reads the targets of the Makefile at a project root
+ */
+export class MakefileDetector {
+  /**
+   * Find the Makefile make would read in a project, given as a directory or a file system;
+   * undefined when there is none
+   */
+  async detect(project: string | ProjectFileSystem): Promise<MakefileInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    let entries: string[];
+    try {
+      entries = (await files.readdir('.')).map(entry => entry.name);
+    } catch (error) {
+      throw new Error(`Failed to read ${files.root}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+
+    // Compare exact names; on case-insensitive file systems makefile and Makefile are the same file
+    const file = MAKEFILE_NAMES.find(name => entries.includes(name));
+    if (!file) {
+      return undefined;
+    }
+
+    let content: string;
+    try {
+      content = await files.readFile(file);
+    } catch (error) {
+      throw new Error(`Failed to read ${file}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+
+    return { file, ...parseMakefileTargets(content) };
+  }
+}
+
+registerDetector('makefile', {
+  async detect(files: ProjectFileSystem) {
+    const makefile = await new MakefileDetector().detect(files);
+    return makefile ? [{ fields: { makefile }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
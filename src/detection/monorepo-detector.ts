@@ -0,0 +1,461 @@
+import { availableParallelism, cpus } from 'os';
+import { join, basename, posix } from 'path';
+import { FrameworkDetector, ProjectInfo } from './interfaces/framework-detector';
+import { DetectionResult } from './interfaces/detection-result';
+import { DetectionError } from './errors/detection-errors';
+import { IgnoreMatcher } from './utils/ignore-patterns';
+import { ProjectFileSystem, SubdirectoryFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Manifests that mark a directory as a package, with the language they imply
+ */
+export const MONOREPO_MANIFESTS: Record<string, string> = {
+  'go.mod': 'Go',
+  'package.json': 'JavaScript',
+  'Cargo.toml': 'Rust',
+  'pyproject.toml': 'Python'
+};
+
+/**
+ * Directories never scanned for packages or Dockerfiles
+ */
+export const SKIPPED_DIRECTORIES = new Set([
+  'node_modules', 'vendor', 'testdata', 'target', 'dist', 'build', 'out',
+  '__pycache__', 'venv', 'env'
+]);
+
+const README_FILES = ['README.md', 'readme.md', 'Readme.md', 'README'];
+
+/**
+ * A single package discovered in a monorepo
+ */
+export interface ProjectUnit {
+  /** Repository-relative POSIX path of the package directory ('.' for the root) */
+  path: string;
+  /** Package name from its manifest, falling back to the directory name */
+  name: string;
+  /** Manifests found in the package directory */
+  manifests: string[];
+  /** Languages implied by the manifests */
+  languages: string[];
+  /** Paths of packages nested inside this one; their files belong to them */
+  nestedUnits: string[];
+  /** Paths of the other packages this one depends on, by name or local path in its manifests */
+  dependsOn: string[];
+  /** Detection results for the package directory */
+  detection: DetectionResult;
+}
+
+/**
+ * Packages of a monorepo that could not be detected, thrown once every other package has been.
+ * `units` holds the packages that were, sorted by path with their dependency edges.
+ */
+export class MonorepoDetectionError extends DetectionError {
+  public readonly failures: Array<{ path: string; message: string }>;
+  public readonly units: ProjectUnit[];
+
+  constructor(failures: Array<{ path: string; message: string }>, units: ProjectUnit[]) {
+    super(
+      `Failed to detect ${failures.length} of ${failures.length + units.length} packages: ` +
+        failures.map(failure => `${failure.path}: ${failure.message}`).join('; '),
+      'MONOREPO_DETECTION_FAILURE',
+      'MonorepoDetector',
+      true,
+      { failures: failures.map(failure => failure.path) }
+    );
+    this.failures = failures;
+    this.units = units;
+  }
+}
+
+/**
+ * Walks a repository and detects each package with its own manifest
+ */
+export class MonorepoDetector {
+  private detector: FrameworkDetector;
+  private maxDepth: number;
+  private concurrency: number;
+
+  /**
+   * @param concurrency - Packages detected at once, the number of CPUs by default
+   */
+  constructor(detector: FrameworkDetector, maxDepth: number = 6, concurrency: number = defaultConcurrency()) {
+    this.detector = detector;
+    this.maxDepth = maxDepth;
+    this.concurrency = Math.max(1, Math.floor(concurrency));
+  }
+
+  /**
+   * Find every package under root and detect its stack, several packages at once.
+   * Units are sorted by path whatever order their detections finish in; nested packages are
+   * attributed to the innermost manifest. A package failing detection does not stop the others:
+   * the failures are thrown together as a MonorepoDetectionError once all have run.
+   * Directories and manifests ignore leaves out are not walked, and packages are detected without them.
+   * Given changedFiles, relative to root, only the packages those files belong to are detected and
+   * returned. The others still count as dependencies, and a returned package lists every package it
+   * depends on, directly or through others, as the ones in between may not be returned.
+   * The deadline is passed on to the detector for each package. The root is a directory or a
+   * file system; the packages of one are detected as a subtree of it.
+   */
+  async detect(root: string | ProjectFileSystem, ignore: IgnoreMatcher = new IgnoreMatcher([]), changedFiles?: string[], deadline?: number): Promise<ProjectUnit[]> {
+    const tree = toProjectFileSystem(root);
+    let directories: Array<{ path: string; manifests: string[] }>;
+    try {
+      directories = await this.findPackageDirectories(tree, '.', this.maxDepth, ignore);
+    } catch (error) {
+      throw new Error(`Failed to scan monorepo at ${tree.root}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+
+    directories.sort((a, b) => comparePaths(a.path, b.path));
+    const selected = changedFiles ? this.selectChanged(directories, changedFiles, ignore) : directories;
+
+    const failures: Array<{ path: string; message: string }> = [];
+    const detected = await mapConcurrently(selected, this.concurrency, async directory => {
+      const files = this.subtree(tree, directory.path);
+      const languages = await this.resolveLanguages(files, directory.manifests);
+      const name = await this.resolveName(files, directory.path, directory.manifests) || basename(files.root);
+
+      let detection: DetectionResult;
+      try {
+        detection = await this.detector.detectFrameworks(
+          await this.createProjectInfo(files, name, languages, directory.manifests),
+          // A checkout is detected by path, so its packages' results are cached
+          typeof root === 'string' ? (directory.path === '.' ? root : join(root, directory.path)) : files,
+          undefined,
+          ignore.within(directory.path),
+          deadline
+        );
+      } catch (error) {
+        failures.push({ path: directory.path, message: error instanceof Error ? error.message : 'Unknown error' });
+        return undefined;
+      }
+
+      const unit: ProjectUnit = {
+        path: directory.path,
+        name,
+        manifests: directory.manifests,
+        languages,
+        nestedUnits: directories
+          .map(other => other.path)
+          .filter(other => other !== directory.path && isWithin(other, directory.path)),
+        dependsOn: [],
+        detection
+      };
+      return unit;
+    });
+    const units = detected.filter((unit): unit is ProjectUnit => unit !== undefined);
+
+    // Packages changedFiles leaves out are not detected, but are depended on by the name they declare
+    const others = await mapConcurrently(directories.filter(directory => !selected.includes(directory)), this.concurrency, async directory => {
+      const files = this.subtree(tree, directory.path);
+      const name = await this.resolveName(files, directory.path, directory.manifests) || basename(files.root);
+      return { path: directory.path, name, manifests: directory.manifests };
+    });
+    const packages = [...units, ...others];
+
+    const paths = new Set(packages.map(pkg => pkg.path));
+    const byName = new Map(packages.map(pkg => [normalizePackageName(pkg.name), pkg.path]));
+    const edges = new Map<string, string[]>();
+    for (const pkg of packages) {
+      const references = await this.readDependencyReferences(this.subtree(tree, pkg.path), pkg.manifests);
+      const dependsOn = new Set<string>();
+
+      for (const name of references.names) {
+        const path = byName.get(normalizePackageName(name));
+        if (path) {
+          dependsOn.add(path);
+        }
+      }
+      for (const relative of references.paths) {
+        const path = posix.normalize(posix.join(pkg.path, relative)).replace(/\/+$/, '');
+        if (paths.has(path)) {
+          dependsOn.add(path);
+        }
+      }
+
+      dependsOn.delete(pkg.path);
+      edges.set(pkg.path, [...dependsOn].sort(comparePaths));
+    }
+    for (const unit of units) {
+      unit.dependsOn = changedFiles ? collectDependencies(unit.path, edges) : edges.get(unit.path)!;
+    }
+
+    if (failures.length > 0) {
+      throw new MonorepoDetectionError(failures.sort((a, b) => comparePaths(a.path, b.path)), units);
+    }
+    return units;
+  }
+
+  /**
+   * The package directory at path of the tree, as a tree of its own
+   */
+  private subtree(tree: ProjectFileSystem, path: string): ProjectFileSystem {
+    return path === '.' ? tree : new SubdirectoryFileSystem(tree, path);
+  }
+
+  /**
+   * The package directories changed files belong to: each file to the innermost package it is
+   * in. Files ignore leaves out belong to none, as detection does not look at them.
+   */
+  private selectChanged(
+    directories: Array<{ path: string; manifests: string[] }>,
+    changedFiles: string[],
+    ignore: IgnoreMatcher
+  ): Array<{ path: string; manifests: string[] }> {
+    const owners = new Set<string>();
+    for (const file of changedFiles.map(changed => changed.replace(/\\/g, '/').replace(/^\.\//, ''))) {
+      if (ignore.ignores(file, false)) {
+        continue;
+      }
+      // Sorted by path, the packages a file is in run from the outermost to the innermost
+      const owner = directories.filter(directory => isWithin(file, directory.path)).pop();
+      if (owner) {
+        owners.add(owner.path);
+      }
+    }
+    return directories.filter(directory => owners.has(directory.path));
+  }
+
+  /**
+   * Collect the package names and local directories a package's manifests depend on:
+   * package.json dependency names and file:/link: specs, go.mod requires and replace
+   * directories, Cargo.toml dependency names and paths, pyproject.toml dependencies
+   */
+  private async readDependencyReferences(files: ProjectFileSystem, manifests: string[]): Promise<{ names: string[]; paths: string[] }> {
+    const names: string[] = [];
+    const paths: string[] = [];
+
+    for (const manifest of manifests) {
+      let content: string;
+      try {
+        content = await files.readFile(manifest);
+      } catch (error) {
+        continue;
+      }
+
+      if (manifest === 'package.json') {
+        let pkg: any;
+        try {
+          pkg = JSON.parse(content);
+        } catch (error) {
+          continue;
+        }
+        for (const field of ['dependencies', 'devDependencies', 'peerDependencies', 'optionalDependencies']) {
+          for (const [name, spec] of Object.entries(pkg?.[field] || {})) {
+            names.push(name);
+            const local = typeof spec === 'string' ? spec.match(/^(?:file|link|portal):(.+)$/)?.[1] : undefined;
+            if (local) {
+              paths.push(local);
+            }
+          }
+        }
+      } else if (manifest === 'go.mod') {
+        for (const match of content.matchAll(/^\s*(?:require\s+)?([^\s()]+)\s+v\d\S*/gm)) {
+          names.push(match[1]!);
+        }
+        for (const match of content.matchAll(/=>\s*(\.{1,2}\/\S*)/g)) {
+          paths.push(match[1]!);
+        }
+      } else if (manifest === 'Cargo.toml') {
+        for (const section of content.matchAll(/^\[(?:[\w.-]+\.)?(?:dev-|build-)?dependencies\]\s*\n([\s\S]*?)(?=^\[|(?![\s\S]))/gm)) {
+          for (const entry of section[1]!.matchAll(/^([\w-]+)\s*=/gm)) {
+            names.push(entry[1]!);
+          }
+        }
+        for (const match of content.matchAll(/^\[(?:dev-|build-)?dependencies\.([\w-]+)\]/gm)) {
+          names.push(match[1]!);
+        }
+        for (const match of content.matchAll(/\bpath\s*=\s*["']([^"']+)["']/g)) {
+          paths.push(match[1]!);
+        }
+      } else if (manifest === 'pyproject.toml') {
+        const dependencies = content.match(/^dependencies\s*=\s*\[([\s\S]*?)\]/m)?.[1] || '';
+        for (const match of dependencies.matchAll(/["']\s*([A-Za-z0-9][A-Za-z0-9._-]*)/g)) {
+          names.push(match[1]!);
+        }
+      }
+    }
+
+    return { names, paths };
+  }
+
+  /**
+   * Recursively collect directories that contain a recognised manifest
+   */
+  private async findPackageDirectories(
+    tree: ProjectFileSystem,
+    relativePath: string,
+    depth: number,
+    ignore: IgnoreMatcher
+  ): Promise<Array<{ path: string; manifests: string[] }>> {
+    const entries = (await tree.readdir(relativePath))
+      .filter(entry => !ignore.ignores(relativePath === '.' ? entry.name : `${relativePath}/${entry.name}`, entry.isDirectory()));
+    const found: Array<{ path: string; manifests: string[] }> = [];
+
+    const manifests = entries
+      .filter(entry => entry.isFile() && MONOREPO_MANIFESTS[entry.name])
+      .map(entry => entry.name)
+      .sort();
+    if (manifests.length > 0) {
+      found.push({ path: relativePath, manifests });
+    }
+
+    if (depth <= 0) {
+      return found;
+    }
+
+    for (const entry of entries) {
+      if (!entry.isDirectory() || entry.name.startsWith('.') || SKIPPED_DIRECTORIES.has(entry.name)) {
+        continue;
+      }
+
+      const childPath = relativePath === '.' ? entry.name : `${relativePath}/${entry.name}`;
+      try {
+        found.push(...await this.findPackageDirectories(tree, childPath, depth - 1, ignore));
+      } catch (error) {
+        // Skip subdirectories that can't be read (permission issues)
+      }
+    }
+
+    return found;
+  }
+
+  /**
+   * Map manifests to languages, preferring TypeScript when a tsconfig is present
+   */
+  private async resolveLanguages(files: ProjectFileSystem, manifests: string[]): Promise<string[]> {
+    const languages: string[] = [];
+
+    for (const manifest of manifests) {
+      let language = MONOREPO_MANIFESTS[manifest];
+      if (manifest === 'package.json' && await files.exists('tsconfig.json')) {
+        language = 'TypeScript';
+      }
+      if (language && !languages.includes(language)) {
+        languages.push(language);
+      }
+    }
+
+    return languages;
+  }
+
+  /**
+   * Read the package name declared in the first manifest that has one
+   */
+  private async resolveName(files: ProjectFileSystem, relativePath: string, manifests: string[]): Promise<string | undefined> {
+    for (const manifest of manifests) {
+      let content: string;
+      try {
+        content = await files.readFile(manifest);
+      } catch (error) {
+        continue;
+      }
+
+      let name: string | undefined;
+      if (manifest === 'package.json') {
+        try {
+          name = JSON.parse(content).name;
+        } catch (error) {
+          name = undefined;
+        }
+      } else if (manifest === 'go.mod') {
+        name = content.match(/^module\s+(\S+)/m)?.[1];
+      } else {
+        name = content.match(/^name\s*=\s*["']([^"']+)["']/m)?.[1];
+      }
+
+      if (name) {
+        return name;
+      }
+    }
+
+    return relativePath === '.' ? undefined : basename(relativePath);
+  }
+
+  /**
+   * Build detector input for a package from its manifests and README
+   */
+  private async createProjectInfo(
+    files: ProjectFileSystem,
+    name: string,
+    languages: string[],
+    manifests: string[]
+  ): Promise<ProjectInfo> {
+    let rawContent = '';
+    for (const readme of README_FILES) {
+      try {
+        rawContent = await files.readFile(readme);
+        break;
+      } catch (error) {
+        // Try the next README spelling
+      }
+    }
+
+    return {
+      name,
+      languages,
+      dependencies: [],
+      buildCommands: [],
+      testCommands: [],
+      installationSteps: [],
+      usageExamples: [],
+      configFiles: manifests,
+      rawContent
+    };
+  }
+}
+
+/**
+ * Package names as compared across ecosystems: Cargo and Python treat - and _ alike, Python ignores case
+ */
+function normalizePackageName(name: string): string {
+  return name.toLowerCase().replace(/[_.]/g, '-');
+}
+
+function isWithin(path: string, parent: string): boolean {
+  return parent === '.' || path.startsWith(`${parent}/`);
+}
+
+/**
+ * Paths of the packages one depends on directly or transitively, sorted, given the direct edges
+ */
+function collectDependencies(path: string, edges: Map<string, string[]>): string[] {
+  const dependencies = new Set(edges.get(path));
+  for (const dependency of dependencies) {
+    for (const next of edges.get(dependency) || []) {
+      dependencies.add(next);
+    }
+  }
+  dependencies.delete(path);
+  return [...dependencies].sort(comparePaths);
+}
+
+/**
+ * Number of CPUs the process can use; availableParallelism is missing before Node 18.14
+ */
+function defaultConcurrency(): number {
+  return typeof availableParallelism === 'function' ? availableParallelism() : cpus().length || 1;
+}
+
+/**
+ * Map items with at most limit calls of fn running at once, keeping the results in item order
+ */
+async function mapConcurrently<T, R>(items: T[], limit: number, fn: (item: T) => Promise<R>): Promise<R[]> {
+  const results: R[] = new Array(items.length);
+  let next = 0;
+  const worker = async (): Promise<void> => {
+    while (next < items.length) {
+      const index = next++;
+      results[index] = await fn(items[index]!);
+    }
+  };
+
+  await Promise.all(Array.from({ length: Math.min(limit, items.length) }, worker));
+  return results;
+}
+
+function comparePaths(a: string, b: string): number {
+  if (a === '.') return b === '.' ? 0 : -1;
+  if (b === '.') return 1;
+  return a.localeCompare(b);
+}
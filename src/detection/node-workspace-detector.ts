@@ -0,0 +1,182 @@
+import * as yaml from 'js-yaml';
+import { NodeWorkspaceInfo, NodeWorkspaceMember } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+import { toPosixPath } from '../shared/input-normalization';
+
+/**
+ * Directories workspace globs never descend into
+ */
+const SKIPPED_DIRECTORIES = new Set(['node_modules', 'dist', 'build', 'coverage']);
+
+/**
+ * Reads the packages of the npm or pnpm workspace at a project root
+ */
+export class NodeWorkspaceDetector {
+  /**
+   * Resolve the workspace declared at the root of a project, given as a directory or a file
+   * system: the packages of pnpm-workspace.yaml, else the `workspaces` of package.json.
+   * Globs are expanded to the directories holding a package.json; `!` entries exclude
+   * what they match. Undefined when no workspace is declared.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<NodeWorkspaceInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const pnpm = await this.readPnpmWorkspace(files);
+    const patterns = pnpm ?? this.readPackageJsonWorkspaces(await this.readManifest(files, '.'));
+    if (!patterns) {
+      return undefined;
+    }
+
+    const included = patterns.filter(pattern => !pattern.startsWith('!')).map(normalizePattern);
+    const excluded = patterns.filter(pattern => pattern.startsWith('!')).map(pattern => toMatcher(normalizePattern(pattern.slice(1))));
+
+    const members = new Map<string, NodeWorkspaceMember>();
+    for (const pattern of included) {
+      for (const memberPath of await this.expandPattern(files, pattern)) {
+        if (members.has(memberPath) || excluded.some(matcher => matcher.test(memberPath))) {
+          continue;
+        }
+        const manifest = await this.readManifest(files, memberPath);
+        if (!manifest) {
+          continue;
+        }
+        members.set(memberPath, {
+          name: typeof manifest.name === 'string' ? manifest.name : memberPath.split('/').pop()!,
+          path: memberPath,
+          scripts: Object.keys(manifest.scripts || {}).sort()
+        });
+      }
+    }
+
+    return {
+      manager: pnpm ? 'pnpm' : 'npm',
+      members: [...members.values()].sort((a, b) => a.path.localeCompare(b.path))
+    };
+  }
+
+  private async readPnpmWorkspace(files: ProjectFileSystem): Promise<string[] | undefined> {
+    let content: string;
+    try {
+      content = await files.readFile('pnpm-workspace.yaml');
+    } catch {
+      return undefined;
+    }
+
+    let workspace: any;
+    try {
+      workspace = yaml.load(content);
+    } catch (error) {
+      throw new Error(`Failed to parse pnpm-workspace.yaml: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+    return readStringList(workspace?.packages);
+  }
+
+  /**
+   * `workspaces` as a list of globs or, in the older form, an object with a `packages` list
+   */
+  private readPackageJsonWorkspaces(manifest: Record<string, any> | undefined): string[] | undefined {
+    const workspaces = manifest?.workspaces;
+    if (Array.isArray(workspaces)) {
+      return readStringList(workspaces);
+    }
+    if (workspaces && Array.isArray(workspaces.packages)) {
+      return readStringList(workspaces.packages);
+    }
+    return undefined;
+  }
+
+  /**
+   * Expand a workspace glob into directories. `*` matches within a path segment and a `**`
+   * segment matches any number of directories; literal entries are returned as they are.
+   */
+  private async expandPattern(files: ProjectFileSystem, pattern: string): Promise<string[]> {
+    if (!/[*?]/.test(pattern)) {
+      return [pattern];
+    }
+
+    let candidates = ['.'];
+    for (const segment of pattern.split('/')) {
+      const next: string[] = [];
+      for (const candidate of candidates) {
+        if (!/[*?]/.test(segment)) {
+          next.push(join(candidate, segment));
+          continue;
+        }
+
+        const matcher = toMatcher(segment);
+        const directories = segment === '**' ? [candidate, ...await this.listDirectories(files, candidate, true)] : await this.listDirectories(files, candidate, false);
+        next.push(...directories.filter(directory => segment === '**' || matcher.test(directory.split('/').pop()!)));
+      }
+      candidates = [...new Set(next)];
+    }
+
+    return candidates.filter(candidate => candidate !== '.').sort();
+  }
+
+  private async listDirectories(files: ProjectFileSystem, directory: string, recursive: boolean): Promise<string[]> {
+    let entries;
+    try {
+      entries = await files.readdir(directory);
+    } catch {
+      return [];
+    }
+
+    const found: string[] = [];
+    for (const entry of entries) {
+      if (entry.isDirectory() && !entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name)) {
+        const path = join(directory, entry.name);
+        found.push(path, ...(recursive ? await this.listDirectories(files, path, true) : []));
+      }
+    }
+    return found;
+  }
+
+  private async readManifest(files: ProjectFileSystem, directory: string): Promise<Record<string, any> | undefined> {
+    const manifestPath = join(directory, 'package.json');
+    let content: string;
+    try {
+      content = await files.readFile(manifestPath);
+    } catch {
+      return undefined;
+    }
+
+    try {
+      return JSON.parse(content);
+    } catch (error) {
+      throw new Error(`Failed to parse ${manifestPath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+}
+
+function readStringList(value: unknown): string[] {
+  return Array.isArray(value) ? value.filter((entry): entry is string => typeof entry === 'string') : [];
+}
+
+function normalizePattern(pattern: string): string {
+  return toPosixPath(pattern).replace(/^\.\//, '').replace(/\/+$/, '');
+}
+
+/**
+ * Regular expression for a glob over workspace-relative paths
+ */
+function toMatcher(glob: string): RegExp {
+  const source = glob
+    .split('/')
+    .map(segment => segment === '**' ? '.*' : segment.replace(/[.+^${}()|[\]\\]/g, '\\$&').replace(/\*/g, '[^/]*').replace(/\?/g, '[^/]'))
+    .join('/')
+    .replace(/\.\*\//g, '(?:.*/)?');
+  return new RegExp(`^${source}$`);
+}
+
+function join(directory: string, name: string): string {
+  return directory === '.' ? name : `${directory}/${name}`;
+}
+
+registerDetector('node-workspace', {
+  async detect(files: ProjectFileSystem) {
+    const nodeWorkspace = await new NodeWorkspaceDetector().detect(files);
+    return nodeWorkspace && nodeWorkspace.members.length > 0
+      ? [{ fields: { nodeWorkspace }, confidence: BUILTIN_DETECTOR_CONFIDENCE }]
+      : [];
+  }
+});
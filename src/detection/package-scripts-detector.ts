@@ -0,0 +1,44 @@
+import { PackageScriptsInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Reads the scripts of the package.json at a project root, which CI runs instead of guessed commands
+ */
+export class PackageScriptsDetector {
+  /**
+   * Detect the scripts of a project, given as a directory or a file system; undefined when it
+   * has no package.json. Entries that are not strings are left out.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<PackageScriptsInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    let content: string;
+    try {
+      content = await files.readFile('package.json');
+    } catch {
+      return undefined;
+    }
+
+    let manifest: any;
+    try {
+      manifest = JSON.parse(content);
+    } catch (error) {
+      throw new Error(`Failed to parse package.json: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+
+    const scripts: Record<string, string> = {};
+    for (const [name, command] of Object.entries(manifest?.scripts || {})) {
+      if (typeof command === 'string') {
+        scripts[name] = command;
+      }
+    }
+    return { scripts };
+  }
+}
+
+registerDetector('package-scripts', {
+  async detect(files: ProjectFileSystem) {
+    const packageScripts = await new PackageScriptsDetector().detect(files);
+    return packageScripts ? [{ fields: { packageScripts }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
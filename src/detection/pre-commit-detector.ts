@@ -0,0 +1,53 @@
+import * as yaml from 'js-yaml';
+import { PreCommitInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Config file names pre-commit reads, the default first
+ */
+const PRE_COMMIT_CONFIG_FILES = ['.pre-commit-config.yaml', '.pre-commit-config.yml'];
+
+/**
+ * Finds a pre-commit configuration and the hooks it runs
+ */
+export class PreCommitDetector {
+  /**
+   * Detect the pre-commit config of a project, given as a directory or a file system;
+   * undefined when there is none
+   */
+  async detect(project: string | ProjectFileSystem): Promise<PreCommitInfo | undefined> {
+    const files = toProjectFileSystem(project);
+
+    for (const file of PRE_COMMIT_CONFIG_FILES) {
+      let content: string;
+      try {
+        content = await files.readFile(file);
+      } catch {
+        continue;
+      }
+
+      let config: any;
+      try {
+        config = yaml.load(content);
+      } catch (error) {
+        throw new Error(`Failed to parse ${file}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      }
+
+      const hooks = (Array.isArray(config?.repos) ? config.repos : [])
+        .flatMap((repo: any) => (Array.isArray(repo?.hooks) ? repo.hooks : []))
+        .map((hook: any) => hook?.id)
+        .filter((id: unknown): id is string => typeof id === 'string');
+      return { file, hooks: [...new Set<string>(hooks)] };
+    }
+
+    return undefined;
+  }
+}
+
+registerDetector('pre-commit', {
+  async detect(files: ProjectFileSystem) {
+    const preCommit = await new PreCommitDetector().detect(files);
+    return preCommit ? [{ fields: { preCommit }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
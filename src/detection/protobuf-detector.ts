@@ -0,0 +1,86 @@
+import { posix } from 'path';
+import { ProtobufInfo } from './interfaces/framework-info';
+import { SKIPPED_DIRECTORIES } from './monorepo-detector';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * How deep below the project root .proto files and buf configuration are looked for
+ */
+const MAX_PROTO_DEPTH = 6;
+
+/**
+ * buf configuration files marking the directory buf runs in: a workspace of modules (buf v1)
+ * or a module, which since buf v2 lists the workspace's modules itself
+ */
+const BUF_CONFIGS = ['buf.work.yaml', 'buf.yaml'];
+
+/**
+ * buf's code generation configuration
+ */
+const BUF_GEN_CONFIG = 'buf.gen.yaml';
+
+/**
+ * Finds the Protocol Buffers definitions of a project and the buf configuration for them
+ */
+export class ProtobufDetector {
+  /**
+   * Detect the .proto files of a project, given as a directory or a file system, with the buf
+   * configuration closest to the root: buf.work.yaml, else buf.yaml, which buf runs from, and
+   * buf.gen.yaml. Without buf.yaml buf runs from the root with its default rules. Vendored
+   * dependencies are not looked at. Undefined when the project has no .proto file.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<ProtobufInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const protoFiles: string[] = [];
+    const configs: string[] = [];
+    const genConfigs: string[] = [];
+
+    const visit = async (directory: string, depth: number): Promise<void> => {
+      const entries = await files.readdir(directory).catch(() => []);
+      for (const entry of entries) {
+        const path = directory === '.' ? entry.name : `${directory}/${entry.name}`;
+        if (entry.isDirectory()) {
+          if (depth < MAX_PROTO_DEPTH && !entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name)) {
+            await visit(path, depth + 1);
+          }
+        } else if (entry.isFile() && entry.name.endsWith('.proto')) {
+          protoFiles.push(path);
+        } else if (entry.isFile() && BUF_CONFIGS.includes(entry.name)) {
+          configs.push(path);
+        } else if (entry.isFile() && entry.name === BUF_GEN_CONFIG) {
+          genConfigs.push(path);
+        }
+      }
+    };
+    await visit('.', 0);
+
+    if (protoFiles.length === 0) {
+      return undefined;
+    }
+
+    const bufConfig = closestToRoot(configs, (a, b) => BUF_CONFIGS.indexOf(posix.basename(a)) - BUF_CONFIGS.indexOf(posix.basename(b)));
+    const bufGenConfig = closestToRoot(genConfigs);
+    return {
+      protoFiles: protoFiles.sort(),
+      bufDirectory: bufConfig ? posix.dirname(bufConfig) : '.',
+      ...(bufConfig && { bufConfig }),
+      ...(bufGenConfig && { bufGenConfig })
+    };
+  }
+}
+
+/**
+ * The shallowest of some paths, ties broken by prefer and then by name
+ */
+function closestToRoot(paths: string[], prefer: (a: string, b: string) => number = () => 0): string | undefined {
+  const depth = (path: string) => path.split('/').length;
+  return [...paths].sort((a, b) => depth(a) - depth(b) || prefer(a, b) || a.localeCompare(b))[0];
+}
+
+registerDetector('protobuf', {
+  async detect(files: ProjectFileSystem) {
+    const protobuf = await new ProtobufDetector().detect(files);
+    return protobuf ? [{ fields: { protobuf }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,141 @@
+import { posix } from 'path';
+import { PythonLayoutInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectEntry, ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Files that mark a directory as a Python project
+ */
+const PYTHON_MARKERS = ['pyproject.toml', 'setup.py', 'setup.cfg', 'requirements.txt', 'Pipfile'];
+
+/**
+ * Manifests pip can install the project itself from
+ */
+const INSTALLABLE_MANIFESTS = ['pyproject.toml', 'setup.py'];
+
+/**
+ * Root directories that hold tests, tooling or build output rather than the project's packages
+ */
+const NON_PACKAGE_DIRECTORIES = new Set([
+  'src', 'test', 'tests', 'testing', 'docs', 'doc', 'examples', 'example', 'scripts', 'tools', 'benchmarks',
+  'build', 'dist', 'site', 'venv', 'env', 'node_modules', '__pycache__'
+]);
+
+/**
+ * Packaging settings that point setuptools or Poetry at src/
+ */
+const SRC_LAYOUT_SETTINGS = [
+  /where\s*=\s*\[\s*["']src["']\s*\]/,
+  /package[-_]dir\s*=\s*\{\s*["']{2}\s*[:=]\s*["']src["']/,
+  /from\s*=\s*["']src["']/
+];
+
+/**
+ * Determines whether a Python project keeps its packages under src/ or at the repository root
+ */
+export class PythonLayoutDetector {
+  /**
+   * Detect the layout of a project, given as a directory or a file system; undefined when no Python
+   * manifest is present.
+   * A package is a directory with an __init__.py, or a namespace package: a directory of
+   * modules without one.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<PythonLayoutInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const manifests = await this.existing(files, PYTHON_MARKERS);
+    if (manifests.length === 0) {
+      return undefined;
+    }
+
+    const srcPackages = await this.findPackages(files, 'src', () => true);
+    const rootPackages = await this.findPackages(files, '.', name => !NON_PACKAGE_DIRECTORIES.has(name));
+    const configured = await this.configuresSrcLayout(files);
+
+    const layout = srcPackages.length > 0 || configured ? 'src' : 'flat';
+    const packages = layout === 'src' ? srcPackages : rootPackages;
+
+    return {
+      layout,
+      packages: packages.map(pkg => pkg.name),
+      namespacePackages: packages.filter(pkg => pkg.namespace).map(pkg => pkg.name),
+      installable: manifests.some(manifest => INSTALLABLE_MANIFESTS.includes(manifest)),
+      requirementsFile: manifests.includes('requirements.txt')
+    };
+  }
+
+  private async findPackages(files: ProjectFileSystem, directory: string, include: (name: string) => boolean): Promise<Array<{ name: string; namespace: boolean }>> {
+    const packages: Array<{ name: string; namespace: boolean }> = [];
+
+    for (const entry of await this.list(files, directory)) {
+      if (!entry.isDirectory() || entry.name.startsWith('.') || !include(entry.name) || !/^[A-Za-z_]\w*$/.test(entry.name)) {
+        continue;
+      }
+
+      const children = await this.list(files, posix.join(directory, entry.name));
+      if (children.some(child => child.isFile() && child.name === '__init__.py')) {
+        packages.push({ name: entry.name, namespace: false });
+      } else if (await this.containsModules(files, posix.join(directory, entry.name), children)) {
+        packages.push({ name: entry.name, namespace: true });
+      }
+    }
+
+    return packages.sort((a, b) => a.name.localeCompare(b.name));
+  }
+
+  /**
+   * Whether a directory without __init__.py holds modules, directly or one package level down
+   */
+  private async containsModules(files: ProjectFileSystem, directory: string, children: ProjectEntry[]): Promise<boolean> {
+    if (children.some(child => child.isFile() && child.name.endsWith('.py'))) {
+      return true;
+    }
+    for (const child of children) {
+      if (child.isDirectory() && !child.name.startsWith('.') && child.name !== '__pycache__') {
+        const nested = await this.list(files, posix.join(directory, child.name));
+        if (nested.some(entry => entry.isFile() && entry.name.endsWith('.py'))) {
+          return true;
+        }
+      }
+    }
+    return false;
+  }
+
+  private async configuresSrcLayout(files: ProjectFileSystem): Promise<boolean> {
+    for (const file of ['pyproject.toml', 'setup.cfg', 'setup.py']) {
+      try {
+        const content = await files.readFile(file);
+        if (SRC_LAYOUT_SETTINGS.some(pattern => pattern.test(content)) || /package_dir\s*=\s*\n\s*=\s*src\b/.test(content)) {
+          return true;
+        }
+      } catch {
+        // Not present
+      }
+    }
+    return false;
+  }
+
+  private async existing(files: ProjectFileSystem, candidates: string[]): Promise<string[]> {
+    const found: string[] = [];
+    for (const file of candidates) {
+      if (await files.exists(file)) {
+        found.push(file);
+      }
+    }
+    return found;
+  }
+
+  private async list(files: ProjectFileSystem, directory: string): Promise<ProjectEntry[]> {
+    try {
+      return await files.readdir(directory);
+    } catch {
+      return [];
+    }
+  }
+}
+
+registerDetector('python-layout', {
+  async detect(files: ProjectFileSystem) {
+    const pythonLayout = await new PythonLayoutDetector().detect(files);
+    return pythonLayout ? [{ fields: { pythonLayout }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,78 @@
+import { BuildToolInfo, RubyProjectInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Reads the Bundler project at a project root
+ */
+export class RubyDetector {
+  /**
+   * Detect the Ruby project of a project, given as a directory or a file system: the Ruby
+   * version pinned in .ruby-version, else the one the Gemfile's `ruby` directive names, the
+   * gems of the Gemfile and gemspec that pick the test, lint and database steps, and its
+   * gemspec. Undefined when there is no Gemfile, .ruby-version or gemspec.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<RubyProjectInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const read = (file: string) => files.readFile(file).catch(() => undefined);
+
+    const gemfile = await read('Gemfile');
+    const rubyVersionFile = await read('.ruby-version');
+    const gemspec = (await files.readdir('.').catch(() => []))
+      .filter(entry => entry.isFile() && entry.name.endsWith('.gemspec'))
+      .map(entry => entry.name)
+      .sort()[0];
+    if (gemfile === undefined && rubyVersionFile === undefined && !gemspec) {
+      return undefined;
+    }
+
+    const gems = new Set([
+      ...this.readGems(gemfile || '', /^\s*gem\s*\(?\s*["']([\w-]+)["']/gm),
+      ...this.readGems(gemspec ? await read(gemspec) || '' : '', /\.add_(?:development_|runtime_)?dependency\s*\(?\s*["']([\w-]+)["']/g)
+    ]);
+    const pinned = rubyVersionFile?.trim().match(/^(?:ruby-)?(\d+\.\d+(?:\.\d+)?)/)?.[1];
+    const required = gemfile?.match(/^\s*ruby\s*\(?\s*["'][~>=\s]*(\d+\.\d+(?:\.\d+)?)["']/m)?.[1];
+    const rubyVersion = pinned || required;
+    const rails = gems.has('rails') || gems.has('railties');
+    const database = gems.has('pg') ? 'postgres' : gems.has('sqlite3') ? 'sqlite' : undefined;
+
+    return {
+      ...(rubyVersion && { rubyVersion, versionSource: pinned ? '.ruby-version' : 'Gemfile' }),
+      ...(gemspec && { gemspec }),
+      rspec: gems.has('rspec') || gems.has('rspec-rails') || await files.exists('.rspec'),
+      rake: await files.exists('Rakefile'),
+      rubocop: [...gems].some(gem => gem === 'rubocop' || gem.startsWith('rubocop-')),
+      rails,
+      ...(rails && database && { database })
+    };
+  }
+
+  /**
+   * Gem names a pattern captures, outside comments
+   */
+  private readGems(content: string, pattern: RegExp): string[] {
+    return [...content.replace(/#.*$/gm, '').matchAll(pattern)].map(match => match[1]!);
+  }
+}
+
+registerDetector('ruby', {
+  async detect(files: ProjectFileSystem) {
+    const rubyProject = await new RubyDetector().detect(files);
+    if (!rubyProject) {
+      return [];
+    }
+
+    // Bundler installs the gems; its lockfile pins them
+    const bundler: BuildToolInfo = {
+      name: 'bundler',
+      configFile: await files.exists('Gemfile') ? 'Gemfile' : rubyProject.gemspec || '.ruby-version',
+      ...(await files.exists('Gemfile.lock') && { lockFile: 'Gemfile.lock' }),
+      commands: [
+        { name: 'install', command: 'bundle install', isPrimary: true },
+        { name: 'test', command: rubyProject.rspec ? 'bundle exec rspec' : 'bundle exec rake', isPrimary: false }
+      ],
+      confidence: BUILTIN_DETECTOR_CONFIDENCE
+    };
+    return [{ fields: { rubyProject, buildTools: [bundler] }, confidence: BUILTIN_DETECTOR_CONFIDENCE }];
+  }
+});
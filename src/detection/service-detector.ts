@@ -0,0 +1,182 @@
+import * as yaml from 'js-yaml';
+import { ServiceDependency } from './interfaces/framework-info';
+import { PrerequisiteExtractor } from '../parser/utils/prerequisite-extractor';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Compose file names in the order docker compose looks them up
+ */
+const COMPOSE_FILES = ['compose.yaml', 'compose.yml', 'docker-compose.yaml', 'docker-compose.yml'];
+
+const README_FILES = ['README.md', 'readme.md', 'Readme.md', 'README'];
+
+/**
+ * Image repositories of the services CI can run as containers
+ */
+const SERVICE_IMAGES: Array<{ name: string; repositories: RegExp }> = [
+  { name: 'postgres', repositories: /^(postgres|postgis\/postgis|bitnami\/postgresql|timescale\/timescaledb)$/ },
+  { name: 'mysql', repositories: /^(mysql|mariadb|bitnami\/mysql)$/ },
+  { name: 'redis', repositories: /^(redis|redis\/redis-stack(-server)?|bitnami\/redis)$/ },
+  { name: 'mongodb', repositories: /^(mongo|bitnami\/mongodb)$/ },
+  { name: 'rabbitmq', repositories: /^(rabbitmq|bitnami\/rabbitmq)$/ }
+];
+
+/**
+ * Finds the backing services a project's tests need, from its compose file and its README's requirements
+ */
+export class ServiceDetector {
+  /**
+   * Detect services in a project, given as a directory or a file system, once per service.
+   * Compose services come first in file order; README requirements add the services the compose
+   * file does not run. Images of other services (including the project's own) are ignored.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<ServiceDependency[]> {
+    const files = toProjectFileSystem(project);
+    const services = new Map<string, ServiceDependency>();
+
+    for (const file of COMPOSE_FILES) {
+      const content = await this.readFile(files, file);
+      if (content === undefined) {
+        continue;
+      }
+      for (const service of this.parseCompose(content, file)) {
+        if (!services.has(service.name)) {
+          services.set(service.name, service);
+        }
+      }
+      break;
+    }
+
+    for (const file of README_FILES) {
+      const content = await this.readFile(files, file);
+      if (content === undefined) {
+        continue;
+      }
+      for (const prerequisite of new PrerequisiteExtractor().extract(content)) {
+        const known = SERVICE_IMAGES.some(service => service.name === prerequisite.name);
+        if (prerequisite.kind === 'service' && known && !services.has(prerequisite.name)) {
+          services.set(prerequisite.name, {
+            name: prerequisite.name,
+            ...(prerequisite.version && { version: prerequisite.version }),
+            ports: [],
+            env: {},
+            source: file
+          });
+        }
+      }
+      break;
+    }
+
+    return [...services.values()];
+  }
+
+  private parseCompose(content: string, file: string): ServiceDependency[] {
+    let compose: any;
+    try {
+      compose = yaml.load(content);
+    } catch (error) {
+      throw new Error(`Failed to parse ${file}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+
+    const found: ServiceDependency[] = [];
+    for (const definition of Object.values<any>(compose?.services || {})) {
+      if (typeof definition?.image !== 'string') {
+        continue;
+      }
+      // A tag interpolated from the host environment is unknown in CI; the default tag is used instead
+      const image = resolveVariables(definition.image).replace(/:[^/]*\$\{?\w[^/]*$/, '');
+      const name = SERVICE_IMAGES.find(service => service.repositories.test(imageRepository(image)))?.name;
+      if (!name) {
+        continue;
+      }
+
+      found.push({
+        name,
+        image,
+        ports: readPorts(definition.ports),
+        env: readEnvironment(definition.environment),
+        source: file
+      });
+    }
+
+    return found;
+  }
+
+  private async readFile(files: ProjectFileSystem, file: string): Promise<string | undefined> {
+    try {
+      return await files.readFile(file);
+    } catch {
+      return undefined;
+    }
+  }
+}
+
+/**
+ * Repository of an image reference without registry host, `library/` prefix, tag or digest
+ */
+function imageRepository(image: string): string {
+  const segments = image.split('@')[0]!.split('/');
+  if (segments.length > 1 && /[.:]|^localhost$/.test(segments[0]!)) {
+    segments.shift();
+  }
+  if (segments[0] === 'library') {
+    segments.shift();
+  }
+  const last = segments.pop()!.split(':')[0]!;
+  return [...segments, last].join('/').toLowerCase();
+}
+
+/**
+ * Container side of compose port mappings: `5432`, `"5432:5432"`, `"127.0.0.1:5432:5432/tcp"` or `{ target: 5432 }`
+ */
+function readPorts(ports: unknown): number[] {
+  const found: number[] = [];
+  for (const port of Array.isArray(ports) ? ports : []) {
+    const target = typeof port === 'object' && port !== null ? (port as any).target : String(port).split('/')[0]!.split(':').pop();
+    const number = Number(target);
+    if (Number.isInteger(number) && number > 0 && !found.includes(number)) {
+      found.push(number);
+    }
+  }
+  return found;
+}
+
+/**
+ * Compose `environment`, as a mapping or a list of KEY=value entries. Variables that are only
+ * interpolated from the host environment without a default are left out.
+ */
+function readEnvironment(environment: unknown): Record<string, string> {
+  const entries: Array<[string, unknown]> = Array.isArray(environment)
+    ? environment.map(entry => {
+      const [key, ...value] = String(entry).split('=');
+      return [key!, value.length > 0 ? value.join('=') : undefined];
+    })
+    : Object.entries(environment || {});
+
+  const env: Record<string, string> = {};
+  for (const [key, value] of entries) {
+    if (value === undefined || value === null) {
+      continue;
+    }
+    const resolved = resolveVariables(String(value));
+    if (!/\$\{?\w/.test(resolved)) {
+      env[key] = resolved;
+    }
+  }
+  return env;
+}
+
+/**
+ * Replace `${VAR:-default}` and `${VAR-default}` with their defaults; other references stay as written
+ */
+function resolveVariables(value: string): string {
+  return value.replace(/\$\{\w+:?-([^}]*)\}/g, '$1');
+}
+
+registerDetector('services', {
+  async detect(files: ProjectFileSystem) {
+    const services = await new ServiceDetector().detect(files);
+    return services.length > 0 ? [{ fields: { services }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,97 @@
+import { extname } from 'path';
+import { ShellProjectInfo } from './interfaces/framework-info';
+import { SKIPPED_DIRECTORIES } from './monorepo-detector';
+import { LanguageDetector } from './language-detector';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Extensions of shell scripts; scripts without one are found by their shebang
+ */
+const SHELL_EXTENSIONS = new Set(['.sh', '.bash']);
+
+/**
+ * Shebangs of the shells shellcheck checks (#!/bin/sh, #!/usr/bin/env bash); zsh is not one of them
+ */
+const SHELL_SHEBANG = /^#!\s*(?:\/usr\/bin\/env\s+(?:-\S+\s+)*)?(?:\/\S*\/)?(?:bash|sh|dash|ksh)(?:\s|$)/;
+
+/**
+ * .editorconfig properties only shfmt reads, so a project setting one formats its scripts with shfmt
+ */
+const SHFMT_PROPERTIES = /^\s*(shell_variant|binary_next_line|switch_case_indent|space_redirects|keep_padding|function_next_line|simplify)\s*=/m;
+
+/**
+ * Directory depth the script walk stops at
+ */
+const MAX_SCAN_DEPTH = 8;
+
+/**
+ * Finds the shell scripts and bats tests of a repository with no other language to build
+ */
+export class ShellDetector {
+  /**
+   * Detect the shell project of a project, given as a directory or a file system: its scripts
+   * (.sh and .bash files, and files without an extension whose shebang names sh, bash, dash or
+   * ksh), the directories holding its .bats tests and whether .editorconfig sets shfmt's
+   * options. Undefined when it has no scripts, or a language with a manifest is found, as
+   * that language's jobs are built instead.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<ShellProjectInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const scripts: string[] = [];
+    const batsTests = new Set<string>();
+
+    const walk = async (relativePath: string, depth: number): Promise<void> => {
+      const entries = await files.readdir(relativePath).catch(() => []);
+      for (const entry of entries) {
+        const childPath = relativePath === '.' ? entry.name : `${relativePath}/${entry.name}`;
+        if (entry.isDirectory()) {
+          if (depth < MAX_SCAN_DEPTH && !entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name)) {
+            await walk(childPath, depth + 1);
+          }
+          continue;
+        }
+        if (!entry.isFile()) {
+          continue;
+        }
+
+        const extension = extname(entry.name);
+        if (extension === '.bats') {
+          batsTests.add(relativePath);
+        } else if (SHELL_EXTENSIONS.has(extension) || (extension === '' && SHELL_SHEBANG.test(await this.readFirstLine(files, childPath)))) {
+          scripts.push(childPath);
+        }
+      }
+    };
+
+    await walk('.', 0);
+    if (scripts.length === 0 || (await new LanguageDetector().detect(files)).length > 0) {
+      return undefined;
+    }
+
+    const editorconfig = await files.readFile('.editorconfig').catch(() => '');
+    return {
+      scripts: scripts.sort(),
+      batsTests: [...batsTests].sort(),
+      shfmt: SHFMT_PROPERTIES.test(editorconfig)
+    };
+  }
+
+  private async readFirstLine(files: ProjectFileSystem, path: string): Promise<string> {
+    const content = await files.readFile(path).catch(() => '');
+    return content.split('\n', 1)[0]!;
+  }
+}
+
+registerDetector('shell', {
+  async detect(files: ProjectFileSystem) {
+    const shellProject = await new ShellDetector().detect(files);
+    if (!shellProject) {
+      return [];
+    }
+
+    // The scripts are the project's only sources, so Shell is its language
+    const languages = [{ name: 'Shell', files: shellProject.scripts.length, share: 1, directory: '.', primary: true }];
+    return [{ fields: { shellProject, languages }, confidence: BUILTIN_DETECTOR_CONFIDENCE }];
+  }
+});
@@ -0,0 +1,102 @@
+import { StaticSiteInfo, StaticSiteGenerator } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Config files identifying each static site generator, in lookup order, with the directory
+ * the build writes to and the config key that moves it
+ */
+const STATIC_SITE_CONFIGS: Array<{
+  generator: StaticSiteGenerator;
+  files: string[];
+  outputDir: string;
+  outputKey?: RegExp;
+  /** Content the config file must have; config.toml is only Hugo's when it sets baseURL */
+  requires?: RegExp;
+}> = [
+  {
+    generator: 'hugo',
+    files: ['hugo.toml', 'hugo.yaml', 'hugo.yml', 'hugo.json'],
+    outputDir: 'public',
+    outputKey: /^\s*"?publishDir"?\s*[=:]\s*["']?([^"'\s,]+)/mi
+  },
+  {
+    generator: 'hugo',
+    files: ['config.toml'],
+    outputDir: 'public',
+    outputKey: /^\s*publishDir\s*=\s*["']([^"']+)["']/mi,
+    requires: /^\s*baseURL\s*=/mi
+  },
+  {
+    generator: 'jekyll',
+    files: ['_config.yml', '_config.yaml'],
+    outputDir: '_site',
+    outputKey: /^destination\s*:\s*["']?([^"'\s#]+)/m
+  },
+  {
+    generator: 'nextjs',
+    files: ['next.config.js', 'next.config.mjs', 'next.config.ts'],
+    outputDir: 'out',
+    // Only static exports produce a directory Pages can serve
+    requires: /\boutput\s*:\s*["']export["']/
+  },
+  {
+    generator: 'vitepress',
+    files: ['.vitepress/config.js', '.vitepress/config.mjs', '.vitepress/config.ts', '.vitepress/config.mts'],
+    outputDir: '.vitepress/dist'
+  },
+  {
+    generator: 'vitepress',
+    files: ['docs/.vitepress/config.js', 'docs/.vitepress/config.mjs', 'docs/.vitepress/config.ts', 'docs/.vitepress/config.mts'],
+    outputDir: 'docs/.vitepress/dist'
+  },
+  {
+    generator: 'mkdocs',
+    files: ['mkdocs.yml', 'mkdocs.yaml'],
+    outputDir: 'site',
+    outputKey: /^site_dir\s*:\s*["']?([^"'\s#]+)/m
+  }
+];
+
+/**
+ * Finds the static site generator configured at a project root
+ */
+export class StaticSiteDetector {
+  /**
+   * Detect the first static site generator whose config file is at the root of a project, given as a
+   * directory or a file system; undefined when there is none
+   */
+  async detect(project: string | ProjectFileSystem): Promise<StaticSiteInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    for (const config of STATIC_SITE_CONFIGS) {
+      for (const file of config.files) {
+        let content: string;
+        try {
+          content = await files.readFile(file);
+        } catch {
+          continue;
+        }
+
+        if (config.requires && !config.requires.test(content)) {
+          continue;
+        }
+
+        const configured = config.outputKey ? content.match(config.outputKey)?.[1] : undefined;
+        return {
+          generator: config.generator,
+          configFile: file,
+          outputDir: configured ? configured.replace(/^\.\//, '').replace(/\/+$/, '') : config.outputDir
+        };
+      }
+    }
+
+    return undefined;
+  }
+}
+
+registerDetector('static-site', {
+  async detect(files: ProjectFileSystem) {
+    const staticSite = await new StaticSiteDetector().detect(files);
+    return staticSite ? [{ fields: { staticSite }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
@@ -0,0 +1,54 @@
+import { BuildToolInfo, SwiftPackageInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Reads the Swift Package Manager manifest at a project root
+ */
+export class SwiftDetector {
+  /**
+   * Detect the Swift package of a project, given as a directory or a file system: the tools
+   * version the `// swift-tools-version` comment opening Package.swift names, and the executable,
+   * library and test targets it declares. Undefined when there is no Package.swift.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<SwiftPackageInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const manifest = await files.readFile('Package.swift').catch(() => undefined);
+    if (manifest === undefined) {
+      return undefined;
+    }
+
+    const toolsVersion = manifest.match(/^\s*\/\/\s*swift-tools-version\s*:\s*(\d+\.\d+(?:\.\d+)?)/)?.[1];
+    const declarations = [...manifest.replace(/(^|[^:])\/\/.*$/gm, '$1').matchAll(/\.(executableTarget|executable|library|testTarget)\s*\(\s*name\s*:\s*"([^"]+)"/g)];
+    const named = (...kinds: string[]) => [...new Set(declarations.filter(match => kinds.includes(match[1]!)).map(match => match[2]!))];
+
+    return {
+      ...(toolsVersion && { toolsVersion }),
+      executables: named('executableTarget', 'executable'),
+      libraries: named('library'),
+      testTargets: named('testTarget')
+    };
+  }
+}
+
+registerDetector('swift', {
+  async detect(files: ProjectFileSystem) {
+    const swiftPackage = await new SwiftDetector().detect(files);
+    if (!swiftPackage) {
+      return [];
+    }
+
+    // SwiftPM resolves the dependencies while building; Package.resolved pins them
+    const swiftpm: BuildToolInfo = {
+      name: 'swiftpm',
+      configFile: 'Package.swift',
+      ...(await files.exists('Package.resolved') && { lockFile: 'Package.resolved' }),
+      commands: [
+        { name: 'build', command: 'swift build', isPrimary: true },
+        ...(swiftPackage.testTargets.length > 0 ? [{ name: 'test', command: 'swift test', isPrimary: false }] : [])
+      ],
+      confidence: BUILTIN_DETECTOR_CONFIDENCE
+    };
+    return [{ fields: { swiftPackage, buildTools: [swiftpm] }, confidence: BUILTIN_DETECTOR_CONFIDENCE }];
+  }
+});
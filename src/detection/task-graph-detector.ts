@@ -0,0 +1,100 @@
+import { TaskGraphInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Tasks run when the config declares none CI can run
+ */
+const DEFAULT_TASKS = ['build', 'test', 'lint'];
+
+/**
+ * Tasks serving or shipping the project, which CI does not run
+ */
+const NON_CI_TASK = /^(dev|start|serve|preview|watch|clean|deploy|release)(:|$)/;
+
+/**
+ * Reads the Nx or Turborepo task runner at the root of a JavaScript monorepo
+ */
+export class TaskGraphDetector {
+  /**
+   * Detect the task runner of a project, given as a directory or a file system: Nx for an
+   * nx.json, else Turborepo for a turbo.json, with the tasks CI runs through it. Those are the
+   * targetDefaults of nx.json or the tasks (pipeline before Turborepo 2) of turbo.json, leaving
+   * out persistent tasks, dev servers and deployment; build, test and lint when it declares none.
+   * Undefined without either config.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<TaskGraphInfo | undefined> {
+    const files = toProjectFileSystem(project);
+
+    const nx = await this.readConfig(files, 'nx.json');
+    if (nx) {
+      // targetDefaults are keyed by target name or by executor, such as @nx/jest:jest
+      const targets = Object.keys(nx.targetDefaults || {}).filter(target => !target.includes('/'));
+      return { tool: 'nx', configFile: 'nx.json', tasks: this.selectTasks(targets, nx.targetDefaults) };
+    }
+
+    const turbo = await this.readConfig(files, 'turbo.json');
+    if (turbo) {
+      const definitions = turbo.tasks || turbo.pipeline || {};
+      // Tasks of one package (web#build) or of the root (//#format) run with the plain task
+      const tasks = [...new Set(Object.keys(definitions).filter(task => !task.includes('#')))];
+      return { tool: 'turbo', configFile: 'turbo.json', tasks: this.selectTasks(tasks, definitions) };
+    }
+    return undefined;
+  }
+
+  private selectTasks(tasks: string[], definitions: Record<string, any> = {}): string[] {
+    const selected = tasks.filter(task => !NON_CI_TASK.test(task) && definitions[task]?.persistent !== true);
+    return selected.length > 0 ? selected : DEFAULT_TASKS;
+  }
+
+  private async readConfig(files: ProjectFileSystem, path: string): Promise<Record<string, any> | undefined> {
+    let content: string;
+    try {
+      content = await files.readFile(path);
+    } catch {
+      return undefined;
+    }
+
+    try {
+      const config = JSON.parse(stripComments(content));
+      return config && typeof config === 'object' ? config : {};
+    } catch (error) {
+      throw new Error(`Failed to parse ${path}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+}
+
+/**
+ * JSON without the // and /* comments turbo.json and nx.json may have, strings left as they are
+ */
+function stripComments(content: string): string {
+  let result = '';
+  for (let i = 0; i < content.length; i++) {
+    const char = content[i]!;
+    if (char === '"') {
+      let end = i + 1;
+      while (end < content.length && content[end] !== '"') {
+        end += content[end] === '\\' ? 2 : 1;
+      }
+      result += content.slice(i, end + 1);
+      i = end;
+    } else if (char === '/' && content[i + 1] === '/') {
+      const end = content.indexOf('\n', i);
+      i = end === -1 ? content.length : end - 1;
+    } else if (char === '/' && content[i + 1] === '*') {
+      const end = content.indexOf('*/', i + 2);
+      i = end === -1 ? content.length : end + 1;
+    } else {
+      result += char;
+    }
+  }
+  return result;
+}
+
+registerDetector('task-graph', {
+  async detect(files: ProjectFileSystem) {
+    const taskGraph = await new TaskGraphDetector().detect(files);
+    return taskGraph ? [{ fields: { taskGraph }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
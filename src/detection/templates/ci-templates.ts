@@ -520,10 +520,9 @@ export class CITemplateRegistry {
         {
           id: 'setup-rust',
           name: 'Setup Rust',
-          uses: 'actions-rs/toolchain@v1',
+          uses: 'dtolnay/rust-toolchain@stable',
           with: {
             'toolchain': '{{ rustVersion }}',
-            'override': 'true',
             'components': 'rustfmt, clippy'
           },
           variables: ['rustVersion']
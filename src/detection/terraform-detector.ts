@@ -0,0 +1,80 @@
+import { TerraformInfo } from './interfaces/framework-info';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * How deep below the project root Terraform configurations are looked for
+ */
+const MAX_TERRAFORM_DEPTH = 3;
+
+/**
+ * Directories never holding configurations of the project's own
+ */
+const SKIPPED_DIRECTORIES = new Set(['node_modules', 'vendor', 'dist', 'build']);
+
+/**
+ * Finds the Terraform root modules of a project
+ */
+export class TerraformDetector {
+  /**
+   * Detect the directories holding Terraform configuration (`*.tf` files) in a project, given as
+   * a directory or a file system, and the Terraform version their `required_version` settings
+   * allow. Directories below a `modules` directory are child modules, validated through the
+   * root modules calling them, and are left out. Undefined when there is no configuration.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<TerraformInfo | undefined> {
+    const files = toProjectFileSystem(project);
+    const directories: string[] = [];
+    let requiredVersion: string | undefined;
+
+    const visit = async (directory: string, depth: number): Promise<void> => {
+      let entries;
+      try {
+        entries = await files.readdir(directory);
+      } catch {
+        return;
+      }
+
+      const configs = entries.filter(entry => entry.isFile() && entry.name.endsWith('.tf')).map(entry => entry.name).sort();
+      if (configs.length > 0 && !directory.split('/').includes('modules')) {
+        directories.push(directory);
+        for (const config of configs) {
+          requiredVersion = requiredVersion || this.readRequiredVersion(await files.readFile(join(directory, config)));
+        }
+      }
+
+      if (depth < MAX_TERRAFORM_DEPTH) {
+        for (const entry of entries.filter(entry => entry.isDirectory()).sort((a, b) => a.name.localeCompare(b.name))) {
+          if (!entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name)) {
+            await visit(join(directory, entry.name), depth + 1);
+          }
+        }
+      }
+    };
+    await visit('.', 0);
+
+    if (directories.length === 0) {
+      return undefined;
+    }
+    return { directories, ...(requiredVersion && { requiredVersion }) };
+  }
+
+  /**
+   * `required_version` of a configuration's terraform block, such as `~> 1.6`
+   */
+  private readRequiredVersion(content: string): string | undefined {
+    const withoutComments = content.replace(/\/\*[\s\S]*?\*\//g, '').replace(/(^|\s)(#|\/\/).*$/gm, '$1');
+    return withoutComments.match(/^\s*required_version\s*=\s*"([^"]+)"/m)?.[1]?.trim();
+  }
+}
+
+function join(directory: string, name: string): string {
+  return directory === '.' ? name : `${directory}/${name}`;
+}
+
+registerDetector('terraform', {
+  async detect(files: ProjectFileSystem) {
+    const terraform = await new TerraformDetector().detect(files);
+    return terraform ? [{ fields: { terraform }, confidence: BUILTIN_DETECTOR_CONFIDENCE }] : [];
+  }
+});
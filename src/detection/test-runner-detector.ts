@@ -0,0 +1,338 @@
+import { TestRunner } from './interfaces/framework-info';
+import { DetectionWarning } from './interfaces/detection-result';
+import { registerDetector, BUILTIN_DETECTOR_CONFIDENCE } from './detector-registry';
+import { RubyDetector } from './ruby-detector';
+import { SwiftDetector } from './swift-detector';
+import { DartDetector } from './dart-detector';
+import { HaskellDetector } from './haskell-detector';
+import { ShellDetector } from './shell-detector';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Test runners found in a project, with warnings about runners another one already invokes
+ */
+export interface TestRunnerDetectionResult {
+  runners: TestRunner[];
+  warnings: DetectionWarning[];
+}
+
+/**
+ * Reads a project directory's configuration for every test runner it uses
+ */
+export class TestRunnerDetector {
+  /**
+   * Detect the test runners configured in a project, given as a directory or a file system. Runners are
+   * ordered by language, then most general first; one invoked by another (pytest through tox) is kept
+   * but marked suppressed.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<TestRunnerDetectionResult> {
+    const tree = toProjectFileSystem(project);
+    const files = new ProjectFiles(tree);
+    const runners: TestRunner[] = [
+      ...await this.detectPythonRunners(files),
+      ...await this.detectNodeRunners(files),
+      ...await this.detectGoRunners(files),
+      ...await this.detectRustRunners(files),
+      ...await this.detectJavaRunners(files),
+      ...await this.detectElixirRunners(files),
+      ...await this.detectRubyRunners(tree),
+      ...await this.detectSwiftRunners(tree),
+      ...await this.detectDartRunners(tree),
+      ...await this.detectHaskellRunners(tree),
+      ...await this.detectShellRunners(tree)
+    ];
+
+    const warnings: DetectionWarning[] = runners
+      .filter(runner => runner.suppressedBy)
+      .map(runner => ({
+        type: 'conflict' as const,
+        message: `${runner.name} is run through ${runner.suppressedBy}; no separate ${runner.name} test job is generated`,
+        affected: [runner.name, runner.suppressedBy!],
+        resolution: `Remove ${runner.name} from the ${runner.suppressedBy} configuration to test with it separately`,
+        code: 'TEST_RUNNER_SUPPRESSED' as const,
+        path: runner.source
+      }));
+
+    return { runners, warnings };
+  }
+
+  private async detectPythonRunners(files: ProjectFiles): Promise<TestRunner[]> {
+    const runners: TestRunner[] = [];
+    const pyproject = await files.read('pyproject.toml');
+    const setupCfg = await files.read('setup.cfg');
+    const toxIni = await files.read('tox.ini');
+    const noxfile = await files.read('noxfile.py');
+
+    // tox and nox create their own environments and usually run pytest inside them
+    let toxConfig: { source: string; content: string } | undefined;
+    if (toxIni !== undefined) {
+      toxConfig = { source: 'tox.ini', content: getSections(toxIni, /^\[(tox|testenv)/) };
+    } else if (pyproject && /^\[tool\.tox\]/m.test(pyproject)) {
+      toxConfig = { source: 'pyproject.toml', content: getSections(pyproject, /^\[tool\.tox/) };
+    } else if (setupCfg && /^\[tox:tox\]/m.test(setupCfg)) {
+      toxConfig = { source: 'setup.cfg', content: getSections(setupCfg, /^\[(tox:|testenv)/) };
+    }
+    if (toxConfig) {
+      runners.push({ name: 'tox', language: 'Python', command: 'tox', setup: ['pip install tox'], source: toxConfig.source });
+    }
+    if (noxfile !== undefined) {
+      runners.push({ name: 'nox', language: 'Python', command: 'nox', setup: ['pip install nox'], source: 'noxfile.py' });
+    }
+
+    let pytestSource: string | undefined;
+    if (await files.exists('pytest.ini')) {
+      pytestSource = 'pytest.ini';
+    } else if (pyproject && /^\[tool\.pytest\.ini_options\]/m.test(pyproject)) {
+      pytestSource = 'pyproject.toml';
+    } else if (setupCfg && /^\[tool:pytest\]/m.test(setupCfg)) {
+      pytestSource = 'setup.cfg';
+    } else if (toxIni && /^\[pytest\]/m.test(toxIni)) {
+      pytestSource = 'tox.ini';
+    } else if (await files.exists('conftest.py')) {
+      pytestSource = 'conftest.py';
+    }
+
+    const requirements = await files.read('requirements.txt') || '';
+    const installed = /^pytest\b/m.test(requirements) ||
+      /["']pytest\b[^"']*["']/.test(pyproject || '') ||
+      /^pytest\s*=/m.test(pyproject || '');
+    if (!pytestSource && /^pytest\b/m.test(requirements)) {
+      pytestSource = 'requirements.txt';
+    }
+
+    if (pytestSource) {
+      let wrappedBy: string | undefined;
+      if (toxConfig && /\bpytest\b/.test(toxConfig.content)) {
+        wrappedBy = 'tox';
+      } else if (noxfile && /\bpytest\b/.test(noxfile)) {
+        wrappedBy = 'nox';
+      }
+
+      runners.push({
+        name: 'pytest',
+        language: 'Python',
+        command: 'pytest',
+        setup: installed ? [] : ['pip install pytest'],
+        source: pytestSource,
+        ...(wrappedBy && { suppressedBy: wrappedBy })
+      });
+    }
+
+    return runners;
+  }
+
+  private async detectNodeRunners(files: ProjectFiles): Promise<TestRunner[]> {
+    const content = await files.read('package.json');
+    if (content === undefined) {
+      return [];
+    }
+
+    let packageJson: any;
+    try {
+      packageJson = JSON.parse(content);
+    } catch (error) {
+      return [];
+    }
+
+    const dependencies = { ...packageJson.dependencies, ...packageJson.devDependencies };
+    const candidates: Array<{ name: string; command: string; configFiles: string[] }> = [
+      { name: 'jest', command: 'npx jest', configFiles: ['jest.config.js', 'jest.config.ts', 'jest.config.mjs', 'jest.config.cjs'] },
+      { name: 'vitest', command: 'npx vitest run', configFiles: ['vitest.config.ts', 'vitest.config.js', 'vitest.config.mts'] },
+      { name: 'mocha', command: 'npx mocha', configFiles: ['.mocharc.yml', '.mocharc.json', '.mocharc.js', '.mocharc.cjs'] }
+    ];
+
+    const runners: TestRunner[] = [];
+    for (const candidate of candidates) {
+      const configFile = await files.findFirst(candidate.configFiles);
+      if (configFile || dependencies[candidate.name]) {
+        runners.push({
+          name: candidate.name,
+          language: 'JavaScript',
+          command: candidate.command,
+          setup: [],
+          source: configFile || 'package.json'
+        });
+      }
+    }
+
+    return runners;
+  }
+
+  private async detectGoRunners(files: ProjectFiles): Promise<TestRunner[]> {
+    return await files.exists('go.mod')
+      ? [{ name: 'go test', language: 'Go', command: 'go test ./...', setup: [], source: 'go.mod' }]
+      : [];
+  }
+
+  private async detectRustRunners(files: ProjectFiles): Promise<TestRunner[]> {
+    if (!await files.exists('Cargo.toml')) {
+      return [];
+    }
+
+    // nextest runs the same test binaries as cargo test, so it replaces it
+    const usesNextest = await files.exists('.config/nextest.toml');
+    const runners: TestRunner[] = [];
+    if (usesNextest) {
+      runners.push({
+        name: 'nextest',
+        language: 'Rust',
+        command: 'cargo nextest run',
+        setup: ['cargo install cargo-nextest --locked'],
+        source: '.config/nextest.toml'
+      });
+    }
+    runners.push({
+      name: 'cargo test',
+      language: 'Rust',
+      command: 'cargo test',
+      setup: [],
+      source: 'Cargo.toml',
+      ...(usesNextest && { suppressedBy: 'nextest' })
+    });
+
+    return runners;
+  }
+
+  private async detectJavaRunners(files: ProjectFiles): Promise<TestRunner[]> {
+    if (await files.exists('pom.xml')) {
+      return [{ name: 'maven', language: 'Java', command: 'mvn -B test', setup: [], source: 'pom.xml' }];
+    }
+
+    const gradleFile = await files.findFirst(['build.gradle', 'build.gradle.kts']);
+    if (gradleFile) {
+      const command = await files.exists('gradlew') ? './gradlew test' : 'gradle test';
+      return [{ name: 'gradle', language: 'Java', command, setup: [], source: gradleFile }];
+    }
+
+    return [];
+  }
+
+  private async detectElixirRunners(files: ProjectFiles): Promise<TestRunner[]> {
+    return await files.exists('mix.exs')
+      ? [{ name: 'mix test', language: 'Elixir', command: 'mix test', setup: [], source: 'mix.exs' }]
+      : [];
+  }
+
+  /**
+   * rspec when the project uses it, else the Rakefile's default task
+   */
+  private async detectRubyRunners(files: ProjectFileSystem): Promise<TestRunner[]> {
+    const rubyProject = await new RubyDetector().detect(files);
+    if (rubyProject?.rspec) {
+      return [{ name: 'rspec', language: 'Ruby', command: 'bundle exec rspec', setup: [], source: 'Gemfile' }];
+    }
+    return rubyProject?.rake
+      ? [{ name: 'rake', language: 'Ruby', command: 'bundle exec rake', setup: [], source: 'Rakefile' }]
+      : [];
+  }
+
+  /**
+   * swift test, when Package.swift declares test targets for it to run
+   */
+  private async detectSwiftRunners(files: ProjectFileSystem): Promise<TestRunner[]> {
+    const swiftPackage = await new SwiftDetector().detect(files);
+    return swiftPackage && swiftPackage.testTargets.length > 0
+      ? [{ name: 'swift test', language: 'Swift', command: 'swift test', setup: [], source: 'Package.swift' }]
+      : [];
+  }
+
+  /**
+   * flutter test for a Flutter app, dart test for a Dart package, when there is a test directory
+   */
+  private async detectDartRunners(files: ProjectFileSystem): Promise<TestRunner[]> {
+    const dartProject = await new DartDetector().detect(files);
+    const command = dartProject?.flutter ? 'flutter test' : 'dart test';
+    return dartProject?.tests
+      ? [{ name: command, language: 'Dart', command, setup: [], source: 'pubspec.yaml' }]
+      : [];
+  }
+
+  /**
+   * stack test or cabal test, whichever the project builds with, when it declares a test suite
+   */
+  private async detectHaskellRunners(files: ProjectFileSystem): Promise<TestRunner[]> {
+    const haskellProject = await new HaskellDetector().detect(files);
+    if (!haskellProject?.tests) {
+      return [];
+    }
+    const stack = haskellProject.tool === 'stack';
+    return [{
+      name: `${haskellProject.tool} test`,
+      language: 'Haskell',
+      command: stack ? 'stack test' : 'cabal test all',
+      setup: [],
+      source: stack ? 'stack.yaml' : haskellProject.cabalFiles[0]!
+    }];
+  }
+
+  /**
+   * bats over the directories holding a shell project's .bats files, installed from the runner's packages
+   */
+  private async detectShellRunners(files: ProjectFileSystem): Promise<TestRunner[]> {
+    const batsTests = (await new ShellDetector().detect(files))?.batsTests || [];
+    return batsTests.length > 0
+      ? [{
+        name: 'bats',
+        language: 'Shell',
+        command: `bats ${batsTests.join(' ')}`,
+        setup: ['sudo apt-get update && sudo apt-get install -y bats'],
+        source: batsTests[0]!
+      }]
+      : [];
+  }
+}
+
+/**
+ * Join the INI/TOML sections whose header matches, so settings of other tools
+ * in a shared file (pytest's own [pytest] section in tox.ini) are not mistaken for tox's
+ */
+function getSections(content: string, header: RegExp): string {
+  return content
+    .split(/^(?=\[)/m)
+    .filter(section => header.test(section))
+    .join('');
+}
+
+/**
+ * Cached reads of files in a project tree
+ */
+class ProjectFiles {
+  private contents = new Map<string, string | undefined>();
+
+  constructor(private files: ProjectFileSystem) {}
+
+  async read(relativePath: string): Promise<string | undefined> {
+    if (!this.contents.has(relativePath)) {
+      try {
+        this.contents.set(relativePath, await this.files.readFile(relativePath));
+      } catch {
+        this.contents.set(relativePath, undefined);
+      }
+    }
+    return this.contents.get(relativePath);
+  }
+
+  async exists(relativePath: string): Promise<boolean> {
+    return this.files.exists(relativePath);
+  }
+
+  async findFirst(relativePaths: string[]): Promise<string | undefined> {
+    for (const relativePath of relativePaths) {
+      if (await this.exists(relativePath)) {
+        return relativePath;
+      }
+    }
+    return undefined;
+  }
+}
+
+registerDetector('test-runners', {
+  async detect(files: ProjectFileSystem) {
+    const detected = await new TestRunnerDetector().detect(files);
+    return [{
+      fields: { ...(detected.runners.length > 0 && { testRunners: detected.runners }) },
+      confidence: BUILTIN_DETECTOR_CONFIDENCE,
+      warnings: detected.warnings
+    }];
+  }
+});
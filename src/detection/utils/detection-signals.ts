@@ -0,0 +1,158 @@
+import { Evidence } from '../interfaces/evidence';
+import { DetectionSignal, DetectionSignalKind } from '../interfaces/framework-info';
+import { ProjectFileSystem, toProjectFileSystem } from './project-fs';
+
+/**
+ * Share of the confidence each kind of signal contributes; agreeing kinds add up to 1
+ */
+export const SIGNAL_WEIGHTS: Record<DetectionSignalKind, number> = {
+  manifest: 0.4,
+  lockfile: 0.2,
+  file_extension: 0.2,
+  readme: 0.2
+};
+
+/**
+ * Files that signal a language, by language name
+ */
+const LANGUAGE_FILES: Record<string, { manifests: string[]; lockfiles: string[]; extensions: string[] }> = {
+  JavaScript: {
+    manifests: ['package.json'],
+    lockfiles: ['package-lock.json', 'yarn.lock', 'pnpm-lock.yaml', 'bun.lockb'],
+    extensions: ['.js', '.jsx', '.mjs', '.cjs', '.ts', '.tsx']
+  },
+  Python: {
+    manifests: ['pyproject.toml', 'setup.py', 'setup.cfg', 'requirements.txt', 'Pipfile'],
+    lockfiles: ['poetry.lock', 'Pipfile.lock', 'uv.lock', 'pdm.lock'],
+    extensions: ['.py']
+  },
+  Go: { manifests: ['go.mod'], lockfiles: ['go.sum'], extensions: ['.go'] },
+  Rust: { manifests: ['Cargo.toml'], lockfiles: ['Cargo.lock'], extensions: ['.rs'] },
+  Java: {
+    manifests: ['pom.xml', 'build.gradle', 'build.gradle.kts'],
+    lockfiles: ['gradle.lockfile'],
+    extensions: ['.java', '.kt']
+  }
+};
+
+/**
+ * Directories searched for source files besides the project root
+ */
+const SOURCE_DIRECTORIES = ['src', 'lib', 'app', 'pkg', 'cmd', 'test', 'tests'];
+
+/**
+ * Ecosystem names used by the analyzers, mapped onto language names
+ */
+const ECOSYSTEM_LANGUAGES: Record<string, string> = {
+  nodejs: 'JavaScript',
+  frontend: 'JavaScript',
+  python: 'Python',
+  go: 'Go',
+  rust: 'Rust',
+  java: 'Java'
+};
+
+/**
+ * Confidence from the kinds of signals that agree, in [0, 1]
+ */
+export function scoreSignals(signals: DetectionSignal[]): number {
+  const kinds = new Set(signals.map(signal => signal.kind));
+  const score = [...kinds].reduce((sum, kind) => sum + SIGNAL_WEIGHTS[kind], 0);
+  return Math.round(Math.min(score, 1) * 100) / 100;
+}
+
+/**
+ * Language name for an analyzer ecosystem, or the ecosystem itself when unknown
+ */
+export function ecosystemToLanguage(ecosystem: string): string {
+  return ECOSYSTEM_LANGUAGES[ecosystem] || ecosystem;
+}
+
+/**
+ * Collects the signals backing a detection from the project directory and README
+ */
+export class DetectionSignalCollector {
+  private files: ProjectFileSystem;
+  private readme: string;
+  private listings = new Map<string, Promise<string[]>>();
+
+  constructor(project: string | ProjectFileSystem, readme: string = '') {
+    this.files = toProjectFileSystem(project);
+    this.readme = readme;
+  }
+
+  /**
+   * Signals for something detected from a config file: the file itself, its language's lockfile
+   * and source files, and a README mention of any of the names
+   */
+  async collect(language: string, names: string[], source?: string): Promise<DetectionSignal[]> {
+    const signals: DetectionSignal[] = [];
+    const files = LANGUAGE_FILES[language];
+
+    const manifest = source || (files && await this.findFirst('.', files.manifests));
+    if (manifest) {
+      signals.push({ kind: 'manifest', source: manifest });
+    }
+
+    if (files) {
+      const lockfile = await this.findFirst('.', files.lockfiles);
+      if (lockfile) {
+        signals.push({ kind: 'lockfile', source: lockfile });
+      }
+      const sourceFile = await this.findSourceFile(files.extensions);
+      if (sourceFile) {
+        signals.push({ kind: 'file_extension', source: sourceFile });
+      }
+    }
+
+    if (names.some(name => this.mentions(name))) {
+      signals.push({ kind: 'readme', source: 'README' });
+    }
+
+    return signals;
+  }
+
+  /**
+   * Signals for an analyzer's framework detection; manifest and README signals come from its evidence
+   */
+  async collectFromEvidence(ecosystem: string, name: string, evidence: Evidence[]): Promise<DetectionSignal[]> {
+    const manifestEvidence = evidence.find(e => e.type === 'config_file' || e.type === 'dependency');
+    const signals = (await this.collect(ecosystemToLanguage(ecosystem), [name], manifestEvidence?.source))
+      .filter(signal => signal.kind !== 'manifest' || manifestEvidence);
+
+    if (!signals.some(signal => signal.kind === 'readme') && evidence.some(e => e.type === 'text_mention')) {
+      signals.push({ kind: 'readme', source: 'README' });
+    }
+
+    return signals;
+  }
+
+  private mentions(name: string): boolean {
+    const escaped = name.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+    return new RegExp(`(^|[^a-z0-9])${escaped}([^a-z0-9]|$)`, 'i').test(this.readme);
+  }
+
+  private async findFirst(directory: string, candidates: string[]): Promise<string | undefined> {
+    const entries = await this.list(directory);
+    return candidates.find(candidate => entries.includes(candidate));
+  }
+
+  private async findSourceFile(extensions: string[]): Promise<string | undefined> {
+    for (const directory of ['.', ...SOURCE_DIRECTORIES]) {
+      const match = (await this.list(directory)).find(entry => extensions.some(extension => entry.endsWith(extension)));
+      if (match) {
+        return directory === '.' ? match : `${directory}/${match}`;
+      }
+    }
+    return undefined;
+  }
+
+  private list(directory: string): Promise<string[]> {
+    let listing = this.listings.get(directory);
+    if (!listing) {
+      listing = this.files.readdir(directory).then(entries => entries.map(entry => entry.name), () => []);
+      this.listings.set(directory, listing);
+    }
+    return listing;
+  }
+}
@@ -1,5 +1,6 @@
 import { Evidence, EvidenceType, EvidenceCollector, EvidenceFilter, EvidenceAggregation } from '../interfaces/evidence';
 import { ProjectInfo } from '../interfaces/framework-detector';
+import { ProjectFileSystem } from './project-fs';
 
 /**
  * Evidence collection and analysis implementation
@@ -8,7 +9,7 @@ export class EvidenceCollectorImpl implements EvidenceCollector {
   /**
    * Collect evidence from project information
    */
-  async collectEvidence(projectInfo: ProjectInfo, projectPath?: string): Promise<Evidence[]> {
+  async collectEvidence(projectInfo: ProjectInfo, project?: string | ProjectFileSystem): Promise<Evidence[]> {
     const evidence: Evidence[] = [];
     
     // Collect dependency evidence
@@ -23,8 +24,8 @@ export class EvidenceCollectorImpl implements EvidenceCollector {
     // Collect text mention evidence
     evidence.push(...this.collectTextEvidence(projectInfo));
     
-    // TODO: Collect file system evidence if projectPath is provided
-    if (projectPath) {
+    // TODO: Collect file system evidence if project is provided
+    if (project) {
       // This will be implemented when file scanner is enhanced
     }
     
@@ -1,19 +1,22 @@
 import { promises as fs } from 'fs';
-import { join } from 'path';
+import { posix } from 'path';
 import * as yaml from 'js-yaml';
 import * as toml from '@iarna/toml';
 import { parseString as parseXml } from 'xml2js';
+import { stripBOM } from '../../shared/input-normalization';
+import { ProjectFileSystem, toProjectFileSystem } from './project-fs';
 
 /**
- * File system scanner for project analysis
+ * File system scanner for project analysis. A project is a directory path or a ProjectFileSystem;
+ * the paths found in it are relative to its root.
  */
 export class FileSystemScanner {
   /**
    * Scan project directory for configuration files
    */
-  async scanProjectFiles(projectPath: string): Promise<string[]> {
+  async scanProjectFiles(project: string | ProjectFileSystem): Promise<string[]> {
     try {
-      const files = await this.readDirectoryRecursive(projectPath);
+      const files = await this.readDirectoryRecursive(toProjectFileSystem(project), '.');
       return files.filter(file => this.isConfigurationFile(file));
     } catch (error) {
       throw new Error(`Failed to scan project files: ${error instanceof Error ? error.message : 'Unknown error'}`);
@@ -23,21 +26,19 @@ export class FileSystemScanner {
   /**
    * Check if file exists in project
    */
-  async fileExists(projectPath: string, fileName: string): Promise<boolean> {
-    try {
-      await fs.access(join(projectPath, fileName));
-      return true;
-    } catch {
-      return false;
-    }
+  async fileExists(project: string | ProjectFileSystem, fileName: string): Promise<boolean> {
+    return toProjectFileSystem(project).exists(fileName);
   }
 
   /**
-   * Read and parse configuration file
+   * Read and parse configuration file, without the byte order mark it may start with. The path
+   * is relative to project when one is given.
    */
-  async readConfigFile(filePath: string): Promise<any> {
+  async readConfigFile(filePath: string, project?: string | ProjectFileSystem): Promise<any> {
     try {
-      const content = await fs.readFile(filePath, 'utf-8');
+      const content = project === undefined
+        ? stripBOM(await fs.readFile(filePath, 'utf-8'))
+        : await toProjectFileSystem(project).readFile(filePath);
       
       if (filePath.endsWith('.json')) {
         return JSON.parse(content);
@@ -66,26 +67,26 @@ export class FileSystemScanner {
   /**
    * Recursively read directory contents
    */
-  private async readDirectoryRecursive(dirPath: string, maxDepth: number = 3): Promise<string[]> {
+  private async readDirectoryRecursive(files: ProjectFileSystem, dirPath: string, maxDepth: number = 3): Promise<string[]> {
     if (maxDepth <= 0) return [];
     
-    const files: string[] = [];
+    const found: string[] = [];
     
     try {
-      const entries = await fs.readdir(dirPath, { withFileTypes: true });
+      const entries = await files.readdir(dirPath);
       
       for (const entry of entries) {
-        const fullPath = join(dirPath, entry.name);
+        const fullPath = dirPath === '.' ? entry.name : posix.join(dirPath, entry.name);
         
         if (entry.isDirectory() && !this.shouldSkipDirectory(entry.name)) {
           try {
-            const subFiles = await this.readDirectoryRecursive(fullPath, maxDepth - 1);
-            files.push(...subFiles);
+            const subFiles = await this.readDirectoryRecursive(files, fullPath, maxDepth - 1);
+            found.push(...subFiles);
           } catch (error) {
             // Skip subdirectories that can't be read (permission issues)
           }
         } else if (entry.isFile()) {
-          files.push(fullPath);
+          found.push(fullPath);
         }
       }
     } catch (error) {
@@ -93,7 +94,7 @@ export class FileSystemScanner {
       throw error;
     }
     
-    return files;
+    return found;
   }
 
   /**
@@ -160,7 +161,7 @@ export class FileSystemScanner {
   /**
    * Get project directory structure for analysis
    */
-  async getProjectStructure(projectPath: string): Promise<ProjectStructure> {
+  async getProjectStructure(project: string | ProjectFileSystem): Promise<ProjectStructure> {
     const structure: ProjectStructure = {
       rootFiles: [],
       directories: [],
@@ -170,11 +171,9 @@ export class FileSystemScanner {
     };
 
     try {
-      const entries = await fs.readdir(projectPath, { withFileTypes: true });
+      const entries = await toProjectFileSystem(project).readdir('.');
       
       for (const entry of entries) {
-        const fullPath = join(projectPath, entry.name);
-        
         if (entry.isDirectory() && !this.shouldSkipDirectory(entry.name)) {
           structure.directories.push(entry.name);
         } else if (entry.isFile()) {
@@ -203,11 +202,12 @@ export class FileSystemScanner {
   /**
    * Find specific configuration files in project
    */
-  async findConfigFiles(projectPath: string, patterns: string[]): Promise<string[]> {
+  async findConfigFiles(project: string | ProjectFileSystem, patterns: string[]): Promise<string[]> {
+    const files = toProjectFileSystem(project);
     const foundFiles: string[] = [];
     
     for (const pattern of patterns) {
-      if (await this.fileExists(projectPath, pattern)) {
+      if (await this.fileExists(files, pattern)) {
         foundFiles.push(pattern);
       }
     }
@@ -215,6 +215,18 @@ export class FileSystemScanner {
     return foundFiles;
   }
 
+  /**
+   * Find files with the given extensions anywhere in the project
+   */
+  async findFilesByExtension(project: string | ProjectFileSystem, extensions: string[], maxDepth: number = 5): Promise<string[]> {
+    try {
+      const files = await this.readDirectoryRecursive(toProjectFileSystem(project), '.', maxDepth);
+      return files.filter(file => extensions.some(ext => file.endsWith(ext)));
+    } catch (error) {
+      throw new Error(`Failed to scan project files: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
   /**
    * Check if file is a source code file
    */
@@ -0,0 +1,370 @@
+/**
+ * Go build constraint scanning utilities
+ *
+ * Parses `//go:build` expressions and legacy `// +build` lines and resolves
+ * them into the GOOS/GOARCH combinations a project actually targets.
+ */
+
+/**
+ * Every GOOS value recognised by the Go toolchain
+ */
+export const GO_OPERATING_SYSTEMS = [
+  'aix', 'android', 'darwin', 'dragonfly', 'freebsd', 'hurd', 'illumos', 'ios',
+  'js', 'linux', 'netbsd', 'openbsd', 'plan9', 'solaris', 'wasip1', 'windows', 'zos'
+];
+
+/**
+ * Every GOARCH value recognised by the Go toolchain
+ */
+export const GO_ARCHITECTURES = [
+  '386', 'amd64', 'arm', 'arm64', 'loong64', 'mips', 'mipsle', 'mips64', 'mips64le',
+  'ppc64', 'ppc64le', 'riscv64', 's390x', 'wasm'
+];
+
+/**
+ * Operating systems negated constraints expand against (e.g. `!windows`)
+ */
+export const KNOWN_GOOS = ['linux', 'darwin', 'windows', 'freebsd', 'openbsd', 'netbsd'];
+
+/**
+ * Architectures negated constraints expand against (e.g. `!arm64`)
+ */
+export const KNOWN_GOARCH = ['amd64', 'arm64'];
+
+/** Defaults used for a dimension the constraint does not mention */
+export const DEFAULT_GOOS = 'linux';
+export const DEFAULT_GOARCH = 'amd64';
+
+/** Operating systems that satisfy the `unix` build tag */
+const UNIX_GOOS = new Set([
+  'aix', 'android', 'darwin', 'dragonfly', 'freebsd', 'hurd', 'illumos', 'ios',
+  'linux', 'netbsd', 'openbsd', 'solaris'
+]);
+
+/** Operating systems that imply another GOOS tag */
+const IMPLIED_GOOS: Record<string, string> = {
+  android: 'linux',
+  illumos: 'solaris',
+  ios: 'darwin'
+};
+
+/** Tags set by the toolchain itself rather than by `-tags` */
+const TOOLCHAIN_TAGS = new Set(['asan', 'cgo', 'gc', 'gccgo', 'ignore', 'msan', 'race', 'unix']);
+
+/** Upper bound on free tags enumerated when checking satisfiability */
+const MAX_FREE_TAGS = 8;
+
+/**
+ * A single GOOS/GOARCH target
+ */
+export interface GoPlatform {
+  goos: string;
+  goarch: string;
+}
+
+/**
+ * Build constraint expression tree
+ */
+export type ConstraintExpression =
+  | { type: 'tag'; name: string }
+  | { type: 'not'; operand: ConstraintExpression }
+  | { type: 'and'; left: ConstraintExpression; right: ConstraintExpression }
+  | { type: 'or'; left: ConstraintExpression; right: ConstraintExpression };
+
+/**
+ * Constraint found in a single source file
+ */
+export interface GoFileConstraint {
+  file: string;
+  expression: string;
+  /** Resolved targets, or null when the constraint does not restrict GOOS/GOARCH */
+  platforms: GoPlatform[] | null;
+  /** Custom tags referenced by the constraint (e.g. `integration`) */
+  tags: string[];
+}
+
+/**
+ * Aggregated build constraints for a project
+ */
+export interface GoBuildConstraints {
+  /** Distinct platforms referenced across all constrained files */
+  platforms: GoPlatform[];
+  /** Distinct custom build tags referenced across all files */
+  tags: string[];
+  /** Custom build tags of `_test.go` files, the tests `go test` skips without `-tags` */
+  testTags: string[];
+  files: GoFileConstraint[];
+}
+
+/**
+ * Scanner that extracts and evaluates Go build constraints
+ */
+export class GoBuildConstraintScanner {
+  /**
+   * Scan source files and aggregate the platforms they constrain to
+   */
+  scan(sources: Array<{ path: string; content: string }>): GoBuildConstraints {
+    const files: GoFileConstraint[] = [];
+
+    for (const source of sources) {
+      const constraint = this.scanFile(source.path, source.content);
+      if (constraint) {
+        files.push(constraint);
+      }
+    }
+
+    const platforms = new Map<string, GoPlatform>();
+    const tags = new Set<string>();
+    const testTags = new Set<string>();
+
+    for (const file of files) {
+      file.tags.forEach(tag => tags.add(tag));
+      if (file.file.endsWith('_test.go')) {
+        file.tags.forEach(tag => testTags.add(tag));
+      }
+      for (const platform of file.platforms || []) {
+        platforms.set(`${platform.goos}/${platform.goarch}`, platform);
+      }
+    }
+
+    return {
+      platforms: Array.from(platforms.values()).sort(comparePlatforms),
+      tags: Array.from(tags).sort(),
+      testTags: Array.from(testTags).sort(),
+      files
+    };
+  }
+
+  /**
+   * Extract and resolve the constraint in a single file, if any
+   */
+  scanFile(path: string, content: string): GoFileConstraint | null {
+    const expression = this.extractConstraint(content);
+    if (!expression) {
+      return null;
+    }
+
+    let tree: ConstraintExpression;
+    try {
+      tree = this.parseExpression(expression);
+    } catch (error) {
+      // Malformed constraints are ignored, matching `go vet` leniency
+      return null;
+    }
+
+    return {
+      file: path,
+      expression,
+      platforms: this.resolvePlatforms(tree),
+      tags: this.collectTags(tree).filter(tag => isCustomTag(tag)).sort()
+    };
+  }
+
+  /**
+   * Extract the effective constraint from a file header.
+   * `//go:build` wins over `// +build`; multiple `+build` lines are ANDed.
+   */
+  extractConstraint(content: string): string | null {
+    let goBuild: string | null = null;
+    const plusBuild: string[] = [];
+
+    for (const rawLine of content.split(/\r?\n/)) {
+      const line = rawLine.trim();
+      if (line === '') continue;
+      // Constraints must precede the package clause
+      if (!line.startsWith('//')) break;
+
+      if (line.startsWith('//go:build ')) {
+        goBuild = goBuild ?? line.slice('//go:build '.length).trim();
+      } else if (/^\/\/\s*\+build\s/.test(line)) {
+        plusBuild.push(this.convertPlusBuildLine(line.replace(/^\/\/\s*\+build\s+/, '')));
+      }
+    }
+
+    if (goBuild) {
+      return goBuild;
+    }
+
+    if (plusBuild.length === 0) {
+      return null;
+    }
+
+    return plusBuild.length === 1
+      ? plusBuild[0] ?? null
+      : plusBuild.map(group => `(${group})`).join(' && ');
+  }
+
+  /**
+   * Parse a `//go:build` expression into a tree
+   */
+  parseExpression(expression: string): ConstraintExpression {
+    const tokens = expression.match(/\|\||&&|!|\(|\)|[A-Za-z0-9_.]+|\S/g) || [];
+    let position = 0;
+
+    const peek = (): string | undefined => tokens[position];
+    const next = (): string | undefined => tokens[position++];
+
+    const parseOr = (): ConstraintExpression => {
+      let left = parseAnd();
+      while (peek() === '||') {
+        next();
+        left = { type: 'or', left, right: parseAnd() };
+      }
+      return left;
+    };
+
+    const parseAnd = (): ConstraintExpression => {
+      let left = parseNot();
+      while (peek() === '&&') {
+        next();
+        left = { type: 'and', left, right: parseNot() };
+      }
+      return left;
+    };
+
+    const parseNot = (): ConstraintExpression => {
+      const token = next();
+      if (token === '!') {
+        return { type: 'not', operand: parseNot() };
+      }
+      if (token === '(') {
+        const inner = parseOr();
+        if (next() !== ')') {
+          throw new Error(`Unbalanced parentheses in build constraint: ${expression}`);
+        }
+        return inner;
+      }
+      if (!token || !/^[A-Za-z0-9_.]+$/.test(token)) {
+        throw new Error(`Unexpected token '${token ?? 'end of input'}' in build constraint: ${expression}`);
+      }
+      return { type: 'tag', name: token };
+    };
+
+    const tree = parseOr();
+    if (position < tokens.length) {
+      throw new Error(`Unexpected token '${tokens[position]}' in build constraint: ${expression}`);
+    }
+    return tree;
+  }
+
+  /**
+   * Resolve the GOOS/GOARCH combinations that satisfy a constraint.
+   * Returns null when the constraint references neither GOOS nor GOARCH.
+   */
+  resolvePlatforms(tree: ConstraintExpression): GoPlatform[] | null {
+    const tags = this.collectTags(tree);
+    const osTags = tags.filter(tag => GO_OPERATING_SYSTEMS.includes(tag) || tag === 'unix');
+    const archTags = tags.filter(tag => GO_ARCHITECTURES.includes(tag));
+
+    if (osTags.length === 0 && archTags.length === 0) {
+      return null;
+    }
+
+    const osUniverse = osTags.length > 0
+      ? unique([...KNOWN_GOOS, ...osTags.filter(tag => tag !== 'unix')])
+      : [DEFAULT_GOOS];
+    const archUniverse = archTags.length > 0
+      ? unique([...KNOWN_GOARCH, ...archTags])
+      : [DEFAULT_GOARCH];
+
+    const freeTags = tags
+      .filter(tag => !osTags.includes(tag) && !archTags.includes(tag))
+      .slice(0, MAX_FREE_TAGS);
+
+    const platforms: GoPlatform[] = [];
+    for (const goos of osUniverse) {
+      for (const goarch of archUniverse) {
+        if (this.isSatisfiable(tree, goos, goarch, freeTags)) {
+          platforms.push({ goos, goarch });
+        }
+      }
+    }
+
+    return platforms.sort(comparePlatforms);
+  }
+
+  /**
+   * Convert a legacy `+build` line to `//go:build` syntax.
+   * Space-separated options are ORed, comma-separated terms are ANDed.
+   */
+  private convertPlusBuildLine(line: string): string {
+    const options = line.trim().split(/\s+/).filter(Boolean);
+    if (options.length === 1) {
+      return (options[0] ?? '').split(',').filter(Boolean).join(' && ');
+    }
+    return options.map(option => {
+      const terms = option.split(',').filter(Boolean);
+      return terms.length > 1 ? `(${terms.join(' && ')})` : terms[0] ?? '';
+    }).join(' || ');
+  }
+
+  /**
+   * Check whether some assignment of free tags satisfies the constraint on a platform
+   */
+  private isSatisfiable(
+    tree: ConstraintExpression,
+    goos: string,
+    goarch: string,
+    freeTags: string[]
+  ): boolean {
+    const combinations = 1 << freeTags.length;
+
+    for (let mask = 0; mask < combinations; mask++) {
+      const enabled = new Set<string>([goos, goarch]);
+      if (UNIX_GOOS.has(goos)) enabled.add('unix');
+      const implied = IMPLIED_GOOS[goos];
+      if (implied) enabled.add(implied);
+      freeTags.forEach((tag, index) => {
+        if (mask & (1 << index)) enabled.add(tag);
+      });
+
+      if (evaluate(tree, enabled)) {
+        return true;
+      }
+    }
+
+    return false;
+  }
+
+  /**
+   * Collect every tag name referenced in a constraint
+   */
+  private collectTags(tree: ConstraintExpression): string[] {
+    switch (tree.type) {
+      case 'tag':
+        return [tree.name];
+      case 'not':
+        return this.collectTags(tree.operand);
+      default:
+        return unique([...this.collectTags(tree.left), ...this.collectTags(tree.right)]);
+    }
+  }
+}
+
+function evaluate(tree: ConstraintExpression, enabled: Set<string>): boolean {
+  switch (tree.type) {
+    case 'tag':
+      return enabled.has(tree.name);
+    case 'not':
+      return !evaluate(tree.operand, enabled);
+    case 'and':
+      return evaluate(tree.left, enabled) && evaluate(tree.right, enabled);
+    case 'or':
+      return evaluate(tree.left, enabled) || evaluate(tree.right, enabled);
+  }
+}
+
+function isCustomTag(tag: string): boolean {
+  return !GO_OPERATING_SYSTEMS.includes(tag) &&
+    !GO_ARCHITECTURES.includes(tag) &&
+    !TOOLCHAIN_TAGS.has(tag) &&
+    !/^go1\.\d+$/.test(tag);
+}
+
+function unique<T>(values: T[]): T[] {
+  return Array.from(new Set(values));
+}
+
+function comparePlatforms(a: GoPlatform, b: GoPlatform): number {
+  return a.goos.localeCompare(b.goos) || a.goarch.localeCompare(b.goarch);
+}
@@ -0,0 +1,144 @@
+/**
+ * Ignore patterns - Paths left out of detection, with the semantics of .gitignore
+ */
+
+import { posix } from 'path';
+import { ProjectFileSystem, toProjectFileSystem } from './project-fs';
+
+/**
+ * File at the repository root whose patterns detection always respects, as a checkout lacks what it ignores
+ */
+export const IGNORE_FILE = '.gitignore';
+
+/**
+ * A parsed pattern: what it matches and whether it re-includes or only applies to directories
+ */
+interface IgnoreRule {
+  pattern: RegExp;
+  negated: boolean;
+  directoryOnly: boolean;
+}
+
+/**
+ * Decides which paths gitignore-style patterns leave out. A leading slash, or a slash within the
+ * pattern, anchors it to the root; a trailing slash only matches directories; `!` re-includes what
+ * an earlier pattern left out and the last matching pattern wins. Like git, nothing under an
+ * ignored directory comes back, so excluding vendor/ never hides the module at the root.
+ */
+export class IgnoreMatcher {
+  private rules: IgnoreRule[];
+
+  /**
+   * @param patterns - Patterns relative to the root, such as the lines of a .gitignore
+   * @param base - Directory, relative to the root, the paths asked about are relative to
+   */
+  constructor(readonly patterns: string[], readonly base: string = '.') {
+    this.rules = patterns.map(parseIgnorePattern).filter((rule): rule is IgnoreRule => rule !== undefined);
+  }
+
+  /**
+   * Whether a path relative to base is left out, it or a directory it is in being ignored
+   */
+  ignores(path: string, isDirectory: boolean): boolean {
+    if (this.rules.length === 0) {
+      return false;
+    }
+
+    const target = posix.join(this.base, path.replace(/\\/g, '/')).replace(/^\.\/|\/+$/g, '');
+    if (target === '.' || target.startsWith('../')) {
+      return false;
+    }
+
+    const segments = target.split('/');
+    for (let depth = 1; depth < segments.length; depth++) {
+      if (this.matches(segments.slice(0, depth).join('/'), true)) {
+        return true;
+      }
+    }
+    return this.matches(target, isDirectory);
+  }
+
+  /**
+   * The same patterns for paths relative to a subdirectory of base
+   */
+  within(directory: string): IgnoreMatcher {
+    return new IgnoreMatcher(this.patterns, posix.join(this.base, directory));
+  }
+
+  private matches(path: string, isDirectory: boolean): boolean {
+    let ignored = false;
+    for (const rule of this.rules) {
+      if ((isDirectory || !rule.directoryOnly) && rule.pattern.test(path)) {
+        ignored = !rule.negated;
+      }
+    }
+    return ignored;
+  }
+}
+
+/**
+ * Patterns of an ignore file's content; blank lines and comments are left out
+ */
+export function parseIgnoreFile(content: string): string[] {
+  return content
+    .split(/\r?\n/)
+    .map(line => line.replace(/(?<!\\)\s+$/, ''))
+    .filter(line => line !== '' && !line.startsWith('#'));
+}
+
+/**
+ * Matcher for a project, given as a directory or a file system: the patterns of its .gitignore,
+ * then the extra ones, which can re-include what .gitignore leaves out
+ */
+export async function loadIgnoreMatcher(project: string | ProjectFileSystem, extra: string[] = []): Promise<IgnoreMatcher> {
+  const gitignore = await toProjectFileSystem(project).readFile(IGNORE_FILE).catch(() => '');
+  return new IgnoreMatcher([...parseIgnoreFile(gitignore), ...extra]);
+}
+
+function parseIgnorePattern(line: string): IgnoreRule | undefined {
+  const negated = line.startsWith('!');
+  let pattern = (negated ? line.slice(1) : line).replace(/^\\([#!])/, '$1').trim();
+  const directoryOnly = pattern.endsWith('/');
+  pattern = pattern.replace(/\/+$/, '');
+  if (pattern === '') {
+    return undefined;
+  }
+
+  // Patterns without a slash but the trailing one match at any depth
+  const anchored = pattern.includes('/');
+  pattern = pattern.replace(/^\//, '');
+  return { pattern: new RegExp(`^${anchored ? '' : '(?:.*/)?'}${globToSource(pattern)}$`), negated, directoryOnly };
+}
+
+function globToSource(pattern: string): string {
+  let source = '';
+  for (let index = 0; index < pattern.length; index++) {
+    const char = pattern[index]!;
+    if (char === '*' && pattern[index + 1] === '*') {
+      const leading = index === 0 || pattern[index - 1] === '/';
+      const trailing = index + 2 === pattern.length || pattern[index + 2] === '/';
+      if (leading && trailing) {
+        // a/**/b also matches a/b, a/** everything in a
+        source += index + 2 === pattern.length ? '.*' : '(?:.*/)?';
+        index += 2;
+        continue;
+      }
+      source += '[^/]*';
+      index += 1;
+    } else if (char === '*') {
+      source += '[^/]*';
+    } else if (char === '?') {
+      source += '[^/]';
+    } else if (char === '[' && pattern.indexOf(']', index + 2) !== -1) {
+      const end = pattern.indexOf(']', index + 2);
+      source += `[${pattern.slice(index + 1, end).replace(/^!/, '^').replace(/\\/g, '\\\\')}]`;
+      index = end;
+    } else if (char === '\\' && index + 1 < pattern.length) {
+      source += pattern[index + 1]!.replace(/[.*+?^${}()|[\]\\/]/g, '\\$&');
+      index += 1;
+    } else {
+      source += char.replace(/[.+^${}()|[\]\\]/g, '\\$&');
+    }
+  }
+  return source;
+}
@@ -2,4 +2,9 @@
 export * from './file-scanner';
 export * from './confidence-calculator';
 export * from './evidence-collector';
-export * from './result-aggregator';
\ No newline at end of file
+export * from './result-aggregator';
+export * from './go-build-constraints';
+export * from './version-constraint';
+export * from './detection-signals';
+export * from './project-fs';
+export * from './ignore-patterns';
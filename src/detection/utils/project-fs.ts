@@ -0,0 +1,291 @@
+import { promises as fs } from 'fs';
+import { join, posix } from 'path';
+import { isWellKnownFile, stripBOM, toPosixPath } from '../../shared/input-normalization';
+import type { IgnoreMatcher } from './ignore-patterns';
+import type { DetectionWarning } from '../interfaces/detection-result';
+import { ErrorRecovery, RetryConfig } from '../errors/error-recovery';
+
+/**
+ * Directory entry returned by ProjectFileSystem.readdir
+ */
+export interface ProjectEntry {
+  name: string;
+  isFile(): boolean;
+  isDirectory(): boolean;
+}
+
+/**
+ * Read-only view of a project tree that detectors scan, so detection can run against a checkout,
+ * an in-memory tree or files extracted from a tarball or git object alike.
+ * Paths are POSIX paths relative to the project root, '.' being the root itself.
+ */
+export interface ProjectFileSystem {
+  /** Where the tree comes from, for messages */
+  readonly root: string;
+  /** Read a file as UTF-8; rejects when it does not exist */
+  readFile(path: string): Promise<string>;
+  /** List a directory; rejects when it does not exist */
+  readdir(path: string): Promise<ProjectEntry[]>;
+  /** Whether a file or directory exists */
+  exists(path: string): Promise<boolean>;
+}
+
+/**
+ * Project tree on disk under root
+ */
+export class DirectoryFileSystem implements ProjectFileSystem {
+  constructor(readonly root: string) {}
+
+  async readFile(path: string): Promise<string> {
+    return fs.readFile(this.resolve(path), 'utf-8');
+  }
+
+  async readdir(path: string): Promise<ProjectEntry[]> {
+    // Sorted like MemoryFileSystem, as the order fs.readdir lists entries in differs between file systems
+    const entries = await fs.readdir(this.resolve(path), { withFileTypes: true });
+    return entries.sort((a, b) => a.name.localeCompare(b.name));
+  }
+
+  async exists(path: string): Promise<boolean> {
+    try {
+      await fs.access(this.resolve(path));
+      return true;
+    } catch {
+      return false;
+    }
+  }
+
+  private resolve(path: string): string {
+    return path === '.' ? this.root : join(this.root, path);
+  }
+}
+
+/**
+ * Project tree held in memory, from file paths to contents; directories are implied by the paths
+ */
+export class MemoryFileSystem implements ProjectFileSystem {
+  readonly root = '<memory>';
+  private files = new Map<string, string>();
+
+  constructor(files: Record<string, string> = {}) {
+    for (const [path, content] of Object.entries(files)) {
+      this.files.set(normalize(path), content);
+    }
+  }
+
+  async readFile(path: string): Promise<string> {
+    const content = this.files.get(normalize(path));
+    if (content === undefined) {
+      throw new Error(`ENOENT: no such file '${path}'`);
+    }
+    return content;
+  }
+
+  async readdir(path: string): Promise<ProjectEntry[]> {
+    const directory = normalize(path);
+    const prefix = directory === '.' ? '' : `${directory}/`;
+    const entries = new Map<string, boolean>();
+
+    for (const file of this.files.keys()) {
+      if (file.startsWith(prefix)) {
+        const [name, ...rest] = file.slice(prefix.length).split('/');
+        entries.set(name!, entries.get(name!) || rest.length > 0);
+      }
+    }
+    if (entries.size === 0 && directory !== '.') {
+      throw new Error(`ENOENT: no such directory '${path}'`);
+    }
+
+    return [...entries].sort(([a], [b]) => a.localeCompare(b)).map(([name, isDirectory]) => ({
+      name,
+      isFile: () => !isDirectory,
+      isDirectory: () => isDirectory
+    }));
+  }
+
+  async exists(path: string): Promise<boolean> {
+    const target = normalize(path);
+    return target === '.' || this.files.has(target) || [...this.files.keys()].some(file => file.startsWith(`${target}/`));
+  }
+}
+
+/**
+ * Subtree of another project tree, for scanning a project kept in a subdirectory of its repository
+ */
+export class SubdirectoryFileSystem implements ProjectFileSystem {
+  readonly root: string;
+
+  constructor(private files: ProjectFileSystem, private directory: string) {
+    this.root = posix.join(files.root, normalize(directory));
+  }
+
+  async readFile(path: string): Promise<string> {
+    return this.files.readFile(this.resolve(path));
+  }
+
+  async readdir(path: string): Promise<ProjectEntry[]> {
+    return this.files.readdir(this.resolve(path));
+  }
+
+  async exists(path: string): Promise<boolean> {
+    return this.files.exists(this.resolve(path));
+  }
+
+  private resolve(path: string): string {
+    return posix.join(normalize(this.directory), normalize(path));
+  }
+}
+
+/**
+ * Another project tree without the paths ignore patterns leave out: listings skip them and they
+ * read as missing, so detectors neither walk nor find what is vendored, generated or gitignored
+ */
+export class IgnoredFileSystem implements ProjectFileSystem {
+  readonly root: string;
+
+  constructor(private files: ProjectFileSystem, private matcher: IgnoreMatcher) {
+    this.root = files.root;
+  }
+
+  async readFile(path: string): Promise<string> {
+    if (this.matcher.ignores(normalize(path), false)) {
+      throw new Error(`ENOENT: no such file '${path}'`);
+    }
+    return this.files.readFile(path);
+  }
+
+  async readdir(path: string): Promise<ProjectEntry[]> {
+    const directory = normalize(path);
+    if (this.matcher.ignores(directory, true)) {
+      throw new Error(`ENOENT: no such directory '${path}'`);
+    }
+    const entries = await this.files.readdir(path);
+    return entries.filter(entry => !this.matcher.ignores(posix.join(directory, entry.name), entry.isDirectory()));
+  }
+
+  async exists(path: string): Promise<boolean> {
+    const target = normalize(path);
+    if (this.matcher.ignores(target, false)) {
+      return false;
+    }
+    // Patterns ending in a slash only hide directories
+    if (this.matcher.ignores(target, true) && await this.files.readdir(target).then(() => true, () => false)) {
+      return false;
+    }
+    return this.files.exists(path);
+  }
+}
+
+/**
+ * Another project tree read the same way whatever file system or editor it comes from: paths may
+ * use either separator, well-known files such as README.md and package.json are found in any
+ * case when the exact name is missing, and file contents lose their byte order mark
+ */
+export class NormalizedFileSystem implements ProjectFileSystem {
+  readonly root: string;
+  private listings = new Map<string, Promise<ProjectEntry[]>>();
+
+  constructor(private files: ProjectFileSystem) {
+    this.root = files.root;
+  }
+
+  async readFile(path: string): Promise<string> {
+    return stripBOM(await this.files.readFile(await this.resolve(path)));
+  }
+
+  async readdir(path: string): Promise<ProjectEntry[]> {
+    return this.files.readdir(normalize(path));
+  }
+
+  async exists(path: string): Promise<boolean> {
+    return this.files.exists(await this.resolve(path));
+  }
+
+  /**
+   * The file a path names: itself when it exists or is not a well-known file, else the file of
+   * its directory with the same name in another case, the first by name when there are several
+   */
+  private async resolve(path: string): Promise<string> {
+    const target = normalize(path);
+    const name = posix.basename(target);
+    if (!isWellKnownFile(name) || await this.files.exists(target)) {
+      return target;
+    }
+
+    const directory = posix.dirname(target);
+    if (!this.listings.has(directory)) {
+      this.listings.set(directory, this.files.readdir(directory).catch(() => []));
+    }
+    const match = (await this.listings.get(directory)!)
+      .filter(entry => entry.isFile() && entry.name.toLowerCase() === name.toLowerCase())
+      .map(entry => entry.name)
+      .sort()[0];
+    return match ? posix.join(directory, match) : target;
+  }
+}
+
+/**
+ * Error codes of reads that may succeed when tried again: descriptors running out, a busy or
+ * stale file, a network file system timing out or dropping the connection
+ */
+const TRANSIENT_ERROR_CODES = new Set(['EAGAIN', 'EBUSY', 'EMFILE', 'ENFILE', 'EIO', 'ETIMEDOUT', 'ESTALE', 'ECONNRESET']);
+
+/**
+ * Another project tree whose reads are retried with backoff when they fail transiently. A read
+ * still failing once the retries run out rejects as before, so detectors treat the file as
+ * missing, and is recorded as a FILE_READ_FAILED warning for the detection result.
+ */
+export class RetryingFileSystem implements ProjectFileSystem {
+  readonly root: string;
+  /** Reads given up on, once per path */
+  readonly warnings: DetectionWarning[] = [];
+
+  constructor(private files: ProjectFileSystem, private retry: Partial<RetryConfig> = {}) {
+    this.root = files.root;
+  }
+
+  async readFile(path: string): Promise<string> {
+    return this.withRetry(path, () => this.files.readFile(path));
+  }
+
+  async readdir(path: string): Promise<ProjectEntry[]> {
+    return this.withRetry(path, () => this.files.readdir(path));
+  }
+
+  async exists(path: string): Promise<boolean> {
+    return this.files.exists(path);
+  }
+
+  private async withRetry<T>(path: string, operation: () => Promise<T>): Promise<T> {
+    const transient = (error: unknown) => TRANSIENT_ERROR_CODES.has((error as any)?.code);
+    try {
+      return await ErrorRecovery.withBackoff(operation, transient, this.retry);
+    } catch (error) {
+      const target = normalize(path);
+      if (transient(error) && !this.warnings.some(warning => warning.path === target)) {
+        this.warnings.push({
+          type: 'incomplete',
+          code: 'FILE_READ_FAILED',
+          message: `Could not read ${target}: ${(error as Error).message}; detected as if it were missing`,
+          affected: [target],
+          path: target
+        });
+      }
+      throw error;
+    }
+  }
+}
+
+/**
+ * File system for a project given as a directory path or as a file system already, normalized
+ */
+export function toProjectFileSystem(project: string | ProjectFileSystem): ProjectFileSystem {
+  if (project instanceof NormalizedFileSystem) {
+    return project;
+  }
+  return new NormalizedFileSystem(typeof project === 'string' ? new DirectoryFileSystem(project) : project);
+}
+
+function normalize(path: string): string {
+  return posix.normalize(toPosixPath(path)).replace(/^\.\/|\/+$/g, '') || '.';
+}
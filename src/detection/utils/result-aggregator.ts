@@ -1,6 +1,7 @@
 import { DetectionResult } from '../interfaces/detection-result';
 import { FrameworkInfo } from '../interfaces/framework-info';
 import { LanguageDetectionResult } from '../interfaces/language-analyzer';
+import { VersionConstraint } from '../interfaces/version-constraint';
 
 /**
  * Result aggregation utilities for combining analyzer outputs
@@ -13,12 +14,16 @@ export class ResultAggregator {
     const allFrameworks: FrameworkInfo[] = [];
     const allBuildTools: any[] = [];
     const allWarnings: string[] = [];
+    const versionConstraints: VersionConstraint[] = [];
     
     // Combine all results
     results.forEach(result => {
       allFrameworks.push(...(result.frameworks || []));
       allBuildTools.push(...(result.buildTools || []));
       allWarnings.push(...(result.metadata?.warnings || []));
+      if (result.versionConstraint) {
+        versionConstraints.push(result.versionConstraint);
+      }
     });
     
     // Remove duplicates and resolve conflicts
@@ -28,7 +33,8 @@ export class ResultAggregator {
     return {
       frameworks: uniqueFrameworks,
       buildTools: uniqueBuildTools,
-      warnings: this.createWarningsFromConflicts(allFrameworks, uniqueFrameworks)
+      warnings: this.createWarningsFromConflicts(allFrameworks, uniqueFrameworks),
+      ...(versionConstraints.length > 0 && { versionConstraints })
     };
   }
 
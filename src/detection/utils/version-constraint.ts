@@ -0,0 +1,306 @@
+/**
+ * Parsing of language version constraints declared in project manifests
+ */
+
+import { ConstraintRuntime, VersionComparator, VersionConstraint } from '../interfaces/version-constraint';
+
+/**
+ * Release lines CI images can install, oldest first. Node lines are majors, Go and Python minors.
+ */
+export const SUPPORTED_VERSION_LINES: Record<ConstraintRuntime, string[]> = {
+  node: ['16', '18', '20', '22', '24'],
+  go: ['1.20', '1.21', '1.22', '1.23', '1.24', '1.25'],
+  python: ['3.8', '3.9', '3.10', '3.11', '3.12', '3.13']
+};
+
+const VERSION_PATTERN = /^v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(?:[-+][0-9A-Za-z.-]+)?$/;
+
+/**
+ * Parse a manifest version constraint. Never throws; unparseable input is reported through `error`.
+ */
+export function parseVersionConstraint(runtime: ConstraintRuntime, raw: string, source: string): VersionConstraint {
+  const constraint: VersionConstraint = { runtime, source, raw, ranges: [], versions: [] };
+
+  try {
+    switch (runtime) {
+      case 'node':
+        constraint.ranges = parseSemverRange(raw);
+        break;
+      case 'python':
+        constraint.ranges = [parsePep440Specifiers(raw)];
+        break;
+      case 'go':
+        constraint.ranges = [parseGoDirective(raw)];
+        break;
+    }
+  } catch (error) {
+    return {
+      ...constraint,
+      error: `Invalid ${runtime} version constraint '${raw}' in ${source}: ${error instanceof Error ? error.message : 'Unknown error'}`
+    };
+  }
+
+  const exact = getExactVersion(constraint.ranges);
+  if (exact) {
+    constraint.exact = exact;
+    constraint.versions = [exact];
+  } else if (runtime === 'go') {
+    // The directive names one line, which may be newer than the supported list
+    constraint.versions = [constraint.ranges[0]![0]!.version.join('.')];
+  } else {
+    constraint.versions = SUPPORTED_VERSION_LINES[runtime].filter(line =>
+      satisfiesVersionLine(constraint, line.split('.').map(Number))
+    );
+    if (constraint.versions.length === 0) {
+      constraint.versions = getNarrowRangeVersion(constraint);
+    }
+  }
+
+  if (constraint.versions.length === 0) {
+    constraint.error = `No supported ${runtime} release satisfies '${raw}' in ${source}`;
+  }
+
+  return constraint;
+}
+
+/**
+ * Check whether the newest release of a version line satisfies a constraint.
+ * `line` is a partial version such as [20] or [3, 12]; missing components mean "latest".
+ */
+export function satisfiesVersionLine(constraint: VersionConstraint, line: number[]): boolean {
+  if (constraint.error) {
+    return false;
+  }
+
+  return constraint.ranges.some(set => set.every(comparator => compareLine(line, comparator)));
+}
+
+/**
+ * npm semver ranges: `||` alternatives of space-separated comparators, with caret, tilde,
+ * x-ranges and hyphen ranges
+ */
+function parseSemverRange(raw: string): VersionComparator[][] {
+  const alternatives = raw.split('||').map(part => part.trim());
+
+  return alternatives.map(alternative => {
+    if (alternative === '' || alternative === '*' || /^[xX]$/.test(alternative)) {
+      return [];
+    }
+
+    const hyphen = alternative.match(/^(\S+)\s+-\s+(\S+)$/);
+    if (hyphen) {
+      const from = parsePartial(hyphen[1]!);
+      const to = parsePartial(hyphen[2]!);
+      return [{ operator: '>=', version: pad(from) }, upperBound(to, true)];
+    }
+
+    const comparators: VersionComparator[] = [];
+    const tokens = alternative.replace(/(>=|<=|>|<|=|\^|~)\s+/g, '$1').split(/\s+/);
+    for (const token of tokens) {
+      const match = token.match(/^(>=|<=|>|<|=|\^|~>?)?(.+)$/);
+      if (!match) {
+        throw new Error(`unrecognised comparator '${token}'`);
+      }
+      comparators.push(...expandSemverComparator(match[1] || '', parsePartial(match[2]!)));
+    }
+
+    return comparators;
+  });
+}
+
+function expandSemverComparator(operator: string, version: number[]): VersionComparator[] {
+  if (version.length === 0) {
+    return [];
+  }
+
+  switch (operator) {
+    case '^': {
+      // Bump the first non-zero component given
+      const index = Math.max(0, version.findIndex(part => part !== 0));
+      const bumpAt = Math.min(index, version.length - 1);
+      const upper = version.slice(0, bumpAt + 1);
+      upper[bumpAt] = upper[bumpAt]! + 1;
+      return [{ operator: '>=', version: pad(version) }, { operator: '<', version: pad(upper) }];
+    }
+    case '~':
+    case '~>': {
+      const upper = version.length > 1 ? version.slice(0, 2) : version.slice(0, 1);
+      upper[upper.length - 1] = upper[upper.length - 1]! + 1;
+      return [{ operator: '>=', version: pad(version) }, { operator: '<', version: pad(upper) }];
+    }
+    case '>=':
+      return [{ operator: '>=', version: pad(version) }];
+    case '>':
+      return version.length === 3
+        ? [{ operator: '>', version }]
+        : [{ operator: '>=', version: pad(increment(version)) }];
+    case '<':
+      return [{ operator: '<', version: pad(version) }];
+    case '<=':
+      return [upperBound(version, true)];
+    default:
+      // A full version pins a release; a partial one is an x-range
+      return version.length === 3
+        ? [{ operator: '=', version }]
+        : [{ operator: '>=', version: pad(version) }, { operator: '<', version: pad(increment(version)) }];
+  }
+}
+
+/**
+ * PEP 440 specifiers as used by requires-python: comma-separated, all must hold
+ */
+function parsePep440Specifiers(raw: string): VersionComparator[] {
+  const comparators: VersionComparator[] = [];
+
+  for (const specifier of raw.split(',').map(part => part.trim()).filter(Boolean)) {
+    const match = specifier.match(/^(~=|===|==|!=|>=|<=|>|<)\s*(.+)$/);
+    if (!match) {
+      throw new Error(`unrecognised specifier '${specifier}'`);
+    }
+
+    const operator = match[1]!;
+    const isPrefix = match[2]!.endsWith('.*');
+    const version = parsePartial(match[2]!.replace(/\.\*$/, ''));
+    if (version.length === 0) {
+      throw new Error(`missing version in '${specifier}'`);
+    }
+
+    switch (operator) {
+      case '~=': {
+        if (version.length < 2) {
+          throw new Error(`'~=' needs at least two version components in '${specifier}'`);
+        }
+        const upper = version.slice(0, -1);
+        upper[upper.length - 1] = upper[upper.length - 1]! + 1;
+        comparators.push({ operator: '>=', version: pad(version) }, { operator: '<', version: pad(upper) });
+        break;
+      }
+      case '==':
+      case '===':
+        comparators.push(isPrefix || version.length < 3
+          ? { operator: '=', version }
+          : { operator: '=', version: pad(version) });
+        break;
+      case '!=':
+        comparators.push({ operator: '!=', version });
+        break;
+      case '<=':
+        comparators.push(upperBound(version, true));
+        break;
+      default:
+        comparators.push({ operator: operator as VersionComparator['operator'], version: pad(version) });
+    }
+  }
+
+  return comparators;
+}
+
+/**
+ * The go.mod `go` directive names the minimum language version; CI tracks the latest patch of that line
+ */
+function parseGoDirective(raw: string): VersionComparator[] {
+  const version = parsePartial(raw.trim().replace(/^go/, ''));
+  if (version.length < 2) {
+    throw new Error('expected a major.minor version');
+  }
+
+  return [{ operator: '=', version: version.slice(0, 2) }];
+}
+
+function parsePartial(text: string): number[] {
+  const match = text.trim().match(VERSION_PATTERN);
+  if (!match) {
+    throw new Error(`unrecognised version '${text}'`);
+  }
+
+  const parts: number[] = [];
+  for (const part of match.slice(1, 4)) {
+    if (part === undefined || /^[xX*]$/.test(part)) {
+      break;
+    }
+    parts.push(Number(part));
+  }
+  return parts;
+}
+
+function pad(version: number[]): number[] {
+  return [...version, 0, 0, 0].slice(0, 3);
+}
+
+function increment(version: number[]): number[] {
+  const next = [...version];
+  next[next.length - 1] = next[next.length - 1]! + 1;
+  return next;
+}
+
+/**
+ * `<= 20` allows every 20.x release, so partial upper bounds become `< 21`
+ */
+function upperBound(version: number[], inclusive: boolean): VersionComparator {
+  if (version.length === 3) {
+    return { operator: inclusive ? '<=' : '<', version };
+  }
+  return { operator: '<', version: pad(increment(version)) };
+}
+
+/**
+ * A range narrower than a release line (e.g. `~20.1`) resolves to its lower bound,
+ * provided that bound falls on a supported line
+ */
+function getNarrowRangeVersion(constraint: VersionConstraint): string[] {
+  const lower = constraint.ranges[0]?.find(comparator => comparator.operator === '>=')?.version;
+  if (!lower) {
+    return [];
+  }
+
+  const granularity = constraint.runtime === 'node' ? 1 : 2;
+  const line = lower.slice(0, granularity).join('.');
+  if (!SUPPORTED_VERSION_LINES[constraint.runtime].includes(line)) {
+    return [];
+  }
+
+  const significant = [...lower];
+  while (significant.length > granularity && significant[significant.length - 1] === 0) {
+    significant.pop();
+  }
+  return [significant.join('.')];
+}
+
+function getExactVersion(ranges: VersionComparator[][]): string | undefined {
+  const only = ranges.length === 1 && ranges[0]!.length === 1 ? ranges[0]![0] : undefined;
+  if (only && only.operator === '=' && only.version.length === 3) {
+    return only.version.join('.');
+  }
+  return undefined;
+}
+
+function compareLine(line: number[], comparator: VersionComparator): boolean {
+  const { operator, version } = comparator;
+
+  if (operator === '=' || operator === '!=') {
+    // Compare only the components both sides specify; an exact release matches its line
+    const length = Math.min(line.length, version.length);
+    const samePrefix = version.slice(0, length).every((part, i) => part === line[i]);
+    const matches = samePrefix && (version.length <= line.length || operator === '=');
+    return operator === '=' ? matches : !matches;
+  }
+
+  const order = compareVersions(line, version);
+  switch (operator) {
+    case '>=': return order >= 0;
+    case '>': return order > 0;
+    case '<=': return order <= 0;
+    case '<': return order < 0;
+  }
+}
+
+function compareVersions(line: number[], version: number[]): number {
+  for (let i = 0; i < version.length; i++) {
+    const left = line[i] ?? Number.POSITIVE_INFINITY;
+    const right = version[i]!;
+    if (left !== right) {
+      return left < right ? -1 : 1;
+    }
+  }
+  return 0;
+}
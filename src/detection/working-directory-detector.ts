@@ -0,0 +1,38 @@
+import { hasManifest } from './language-detector';
+import { SKIPPED_DIRECTORIES } from './monorepo-detector';
+import { ProjectFileSystem, toProjectFileSystem } from './utils/project-fs';
+
+/**
+ * Finds where a project lives when its repository keeps it in a subdirectory, e.g. the app
+ * under app/ with only docs at the root
+ */
+export class WorkingDirectoryDetector {
+  /**
+   * Detect the top-level directory holding a project's manifests, given the repository as a
+   * directory or a file system. Returns undefined when there are manifests at the root, or
+   * when none or several top-level directories have them.
+   */
+  async detect(project: string | ProjectFileSystem): Promise<string | undefined> {
+    const files = toProjectFileSystem(project);
+    if (await hasManifest(files, '.')) {
+      return undefined;
+    }
+
+    let entries;
+    try {
+      entries = await files.readdir('.');
+    } catch {
+      return undefined;
+    }
+
+    const candidates: string[] = [];
+    for (const entry of entries) {
+      if (entry.isDirectory() && !entry.name.startsWith('.') && !SKIPPED_DIRECTORIES.has(entry.name) &&
+        await hasManifest(files, entry.name)) {
+        candidates.push(entry.name);
+      }
+    }
+
+    return candidates.length === 1 ? candidates[0] : undefined;
+  }
+}
@@ -611,7 +611,7 @@ export class EnvironmentManager {
   private generateAzureOIDCStep(environment: EnvironmentConfig, config: OIDCConfig): StepTemplate {
     return {
       name: `Azure Login for ${environment.name}`,
-      uses: 'azure/login@v1',
+      uses: 'azure/login@v2',
       with: {
         'client-id': `\${{ secrets.AZURE_CLIENT_ID }}`,
         'tenant-id': `\${{ secrets.AZURE_TENANT_ID }}`,
@@ -17,6 +17,27 @@ export { DeploymentGenerator } from './templates/deployment-generator';
 export { SecurityStepGenerator } from './templates/security-step-generator';
 export { PerformanceMonitoringGenerator } from './templates/performance-monitoring-generator';
 export { CacheStrategyGenerator } from './utils/cache-utils';
+export { getExistingCIProviders } from './utils/ci-badges';
+export { detectExistingCI, resolveExistingProvider, PROVIDER_NAMES } from './utils/existing-ci';
+export { diffWorkflowFile, workflowsEquivalent } from './utils/workflow-diff';
+export { readManagedBlock } from './utils/workflow-merge';
+export { collectSecrets, SECRETS_MANIFEST_FILENAME } from './utils/secrets-manifest';
+export { planSummary, PlanSummaryOptions } from './utils/plan-summary';
+export { repairWorkflow, findUnpinnedActions, REPAIR_FIXES, RepairFixId, RepairFix, RepairOptions, RepairResult } from './utils/workflow-repair';
+export {
+  collectUpdateTargets,
+  renderDependabotConfig,
+  renderRenovateConfig,
+  DEPENDABOT_CONFIG_PATH,
+  RENOVATE_CONFIG_PATH,
+  DependencyEcosystem,
+  DependencyUpdateTarget
+} from './utils/dependency-updates';
+export { CI_JOB_IDS, CI_JOB_CATEGORIES, isKnownJobId } from './utils/job-ids';
+export { LATEST_RUNNER_IMAGES, isKnownRunnerImage, pinRunnerImage } from './utils/runner-images';
+export { STEP_CATEGORIES, StepCategory, getStepCategory, orderSteps } from './utils/step-order';
+export { ACTION_VERSIONS, DEPRECATED_ACTIONS, DeprecatedAction, ResolvedAction, ActionVersionOptions, ActionVersionResult, resolveActionVersion, applyActionVersions, findPinnableActions } from './utils/action-versions';
+export { validateWorkflowStructure, validateCompositeActionStructure } from './validators/structure-validator';
 
 // Export workflow specialization types
 export * from './workflow-specialization';
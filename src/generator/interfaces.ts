@@ -2,7 +2,7 @@
  * Core interfaces for the YAML Generator component
  */
 
-import { WorkflowTemplate, JobTemplate, StepTemplate } from './types';
+import { WorkflowTemplate, JobTemplate, JobEnvironmentConfig, StepTemplate } from './types';
 
 /**
  * Main YAML Generator interface
@@ -16,7 +16,37 @@ export interface YAMLGenerator {
   /**
    * Generate multiple workflows for different types
    */
-  generateMultipleWorkflows(detectionResult: DetectionResult, workflowTypes: WorkflowType[]): Promise<WorkflowOutput[]>;
+  generateMultipleWorkflows(detectionResult: DetectionResult, workflowTypes: WorkflowType[], options?: GenerationOptions): Promise<WorkflowOutput[]>;
+  
+  /**
+   * Generate CI workflows for the packages of a monorepo
+   */
+  generateMonorepoWorkflows(packages: MonorepoPackage[], options?: GenerationOptions): Promise<WorkflowOutput[]>;
+
+  /**
+   * Explain which changed files make each monorepo CI job run, through which path filter pattern
+   */
+  whyTriggered(packages: MonorepoPackage[], changedFiles: string[], options?: GenerationOptions): Record<string, string[]>;
+  
+  /**
+   * Generate workflows in memory, keyed by repository-relative output path
+   */
+  generateToMap(detectionResult: DetectionResult, workflowTypes: WorkflowType[], options?: GenerationOptions): Promise<Record<string, string>>;
+  
+  /**
+   * Generate workflows and write them below a repository root
+   */
+  generate(detectionResult: DetectionResult, workflowTypes: WorkflowType[], rootDir: string, options?: GenerationOptions): Promise<string[]>;
+  
+  /**
+   * Compare the workflows that would be generated with the ones committed below a repository root
+   */
+  check(detectionResult: DetectionResult, workflowTypes: WorkflowType[], rootDir: string, options?: GenerationOptions): Promise<CheckResult>;
+  
+  /**
+   * Generate a workflow and merge it into the content of an existing one
+   */
+  generateWithMerge(detectionResult: DetectionResult, existing: string, options?: GenerationOptions): Promise<string>;
   
   /**
    * Validate generated YAML workflow
@@ -34,6 +64,15 @@ export interface WorkflowOutput {
   metadata: WorkflowMetadata;
 }
 
+/**
+ * Outcome of comparing generated workflows with the committed ones
+ */
+export interface CheckResult {
+  upToDate: boolean;
+  /** Unified diff per out-of-date file, from the committed content to the generated one */
+  diff: string;
+}
+
 /**
  * Options for workflow generation
  */
@@ -49,6 +88,281 @@ export interface GenerationOptions {
   testingStrategy?: TestingStrategyConfig;
   agentHooksEnabled?: boolean;
   environmentManagement?: EnvironmentManagementOptions;
+  provider?: Provider;
+  /** Let CircleCI configs use orbs (e.g. circleci/node) for dependency installation */
+  useOrbs?: boolean;
+  monorepoLayout?: MonorepoLayout;
+  monorepoChangeDetection?: MonorepoChangeDetection;
+  /** Registry detected Dockerfiles are pushed to (default ghcr.io) */
+  containerRegistry?: string;
+  testRunnerLayout?: TestRunnerLayout;
+  /** Whether a ci workflow is still generated when README badges show existing CI (default generate) */
+  existingCI?: ExistingCIAction;
+  /** Run the Makefile's ci target as the whole pipeline when it has one */
+  makeCI?: boolean;
+  /** Runner label that replaces ubuntu-latest on every job not spread over a runner matrix */
+  runnerOS?: string;
+  /** Self-hosted runner labels replacing runs-on; takes precedence over runnerOS and disables runner matrices */
+  runnerLabels?: RunnerLabels;
+  /** Exact hosted image label (ubuntu-22.04, macos-14) each operating system's -latest alias is pinned to, runner matrices included. GitHub Actions only */
+  runnerImages?: Partial<Record<HostedOS, string>>;
+  /** Refs (v4.2.2) or mirrors and refs (my-org/checkout@v4) replacing ACTION_VERSIONS', by action path or repository. GitHub Actions only */
+  actionVersions?: Record<string, string>;
+  /** Pin the actions to the commit SHAs resolveActionSha finds, as `actions/checkout@<sha> # v4`; mirrored actions keep their ref */
+  pinActions?: boolean;
+  /** Commit SHA of an action's ref, undefined when it cannot be resolved (the action is then not pinned, with a warning) */
+  resolveActionSha?: ActionShaResolver;
+  /** Hosted runner operating systems the unit tests run on, replacing the GOOS matrix build constraints give them */
+  testOS?: HostedOS[];
+  /** Combinations left out of the job matrices, as matrix.exclude entries */
+  matrixExclude?: MatrixRule[];
+  /** Combinations added to or extending the job matrices, as matrix.include entries */
+  matrixInclude?: MatrixRule[];
+  /** Ids (CI_JOB_IDS) or categories (CI_JOB_CATEGORIES) of generated CI jobs to leave out */
+  disabledJobs?: string[];
+  /** Environment variables set on every generated CI job */
+  jobEnv?: Record<string, string>;
+  /** Variables of the example environment file to leave out of the job environment */
+  envExampleExclude?: string[];
+  /** Submodules checkout steps fetch (recursive) or false for none, instead of what .gitmodules asks for */
+  checkoutSubmodules?: boolean | 'recursive';
+  /** Commits checkout steps fetch, 0 for the whole history, instead of what the detected versioning tools need */
+  checkoutFetchDepth?: number;
+  cargoWorkspaceLayout?: CargoWorkspaceLayout;
+  nodeWorkspaceLayout?: NodeWorkspaceLayout;
+  /** Add the root package's Cargo features as a matrix dimension of build and test jobs */
+  cargoFeatureMatrix?: boolean;
+  /** Append a job publishing the detected static site to GitHub Pages from the default branch */
+  deployPages?: boolean;
+  /** Directory uploaded to GitHub Pages instead of the generator's default output directory */
+  pagesOutputDir?: string;
+  /** GitHub environment of the deploy job instead of github-pages; {url} in its url is the URL deployed to */
+  deployEnvironment?: JobEnvironmentConfig;
+  /** Categories of CI jobs to generate (default full) */
+  preset?: GenerationPreset;
+  /** Service unit test coverage is uploaded to (default none) */
+  coverage?: CoverageService;
+  /** Repository's default branch; pushes to it trigger CI and CD instead of the usual trunk names */
+  defaultBranch?: string;
+  /** Cancel a ref's in-progress CI run when a newer one starts (default true); deployments are never cancelled */
+  cancelInProgress?: boolean;
+  /** Declare least-privilege token permissions, read-only unless a job's steps need more (default true); false leaves them to the organization defaults. GitHub Actions only */
+  permissions?: boolean;
+  /** Cron expression of an extra nightly workflow running the build and test jobs; GitHub Actions only */
+  schedule?: string;
+  /** Times a failed test command runs again in the test jobs (default 0); at most 2 on GitLab, which retries the job */
+  testRetries?: number;
+  /** Minutes after which a test job is cancelled (default 30) */
+  jobTimeout?: number;
+  /** GOPRIVATE patterns of private Go modules (e.g. github.com/acme/*); a go.mod requiring one gets authenticated git access. GitHub Actions only */
+  privateModules?: string[];
+  /** Repository secret holding the token private Go modules are fetched with (default GO_MODULES_TOKEN) */
+  privateModulesSecret?: string;
+  /** Also write release.yml, building tagged commits and attaching the build output to a GitHub Release. GitHub Actions only */
+  tagRelease?: boolean;
+  /** Prefix of release tags before the version (default v; empty for tags like 1.2.3) */
+  releaseTagPrefix?: string;
+  /** Upload the build job's output as an artifact */
+  artifacts?: boolean;
+  /** Paths to upload instead of the language's build output (dist/, bin/, target/release/...) */
+  artifactPath?: string;
+  /** Artifact name prefix, followed by the build matrix values (default build-artifacts) */
+  artifactName?: string;
+  /** Days uploaded build artifacts are kept (default 7) */
+  artifactRetentionDays?: number;
+  /** Add a job running pre-commit's hooks when the repository configures them; replace-lint also drops the lint job they duplicate */
+  preCommit?: 'job' | 'replace-lint';
+  /** Add a job checking the detected Terraform configuration, planning it on pushes to the default branch. GitHub Actions only */
+  terraform?: boolean;
+  /** Add a license-check job failing when a dependency's license is outside licenseAllowlist, for Node, Python and Go */
+  licenseCheck?: boolean;
+  /** SPDX ids the license check allows; defaults to the common permissive licenses and the project's own */
+  licenseAllowlist?: string[];
+  /** Add a changelog job failing pull requests that leave the changelog unchanged, unless they carry changelogSkipLabel */
+  changelogCheck?: boolean;
+  /** Label exempting a pull request from the changelog check; defaults to no-changelog */
+  changelogSkipLabel?: string;
+  /** Add a codeowners job commenting on pull requests which CODEOWNERS rules the changed files fall under */
+  codeownersComment?: boolean;
+  /** Add an api-lint job running spectral lint over the OpenAPI documents and graphql-schema-linter over the GraphQL schemas */
+  apiLint?: boolean;
+  /** Add a buf job linting the .proto files, checking pull requests for breaking changes and, with buf.gen.yaml, that generated code is committed */
+  protobuf?: boolean;
+  /** Branch buf breaking compares pull requests against; defaults to the default branch */
+  bufBreakingBranch?: string;
+  /** Create and migrate the test database of a Phoenix app using Ecto with Postgres before its tests, against a postgres service container */
+  phoenixDatabase?: boolean;
+  /** Build and test CMake projects with both gcc and clang through a compiler matrix */
+  compilerMatrix?: boolean;
+  /** Build and test Swift packages on Linux in the official swift container too, besides macOS. GitHub Actions only */
+  swiftLinux?: boolean;
+  /** Build Flutter apps for each platform they have a directory for, in a matrix of their runners. GitHub Actions only */
+  flutterBuild?: boolean;
+  /** End each step with a `# source:` comment naming where it comes from, `default` for the generator's own. GitHub Actions only */
+  provenance?: boolean;
+  /**
+   * Leanest CI workflow that still builds and tests: no comments, workflow-level permissions or
+   * concurrency, and only build and test jobs unless a preset is chosen. Provenance comments are
+   * not added. Per-job permissions, deployment concurrency and the managed block stay.
+   */
+  minimal?: boolean;
+  /** Emit ci.yml as a reusable workflow (on: workflow_call) with runner and version inputs. GitHub Actions only */
+  reusable?: boolean;
+  /** Write the CI jobs to one ci.yml (default), or to build.yml, test.yml and lint.yml. GitHub Actions only */
+  split?: WorkflowSplit;
+  /** How split workflows get the build their tests need (default reusable) */
+  splitSetup?: SplitSetup;
+  /** Names of workflows in the output directory the generator did not write; split workflows are named clear of them */
+  userWorkflows?: string[];
+  /** Steps spliced into the CI jobs at named anchors; steps sharing an anchor keep their order */
+  injectSteps?: InjectedStep[];
+  /** Write and compare generated files even when they fail structural validation */
+  force?: boolean;
+  /**
+   * Last transformation of the files `generate` writes and `check` compares, keyed by
+   * repository-relative path like generateToMap's: adding a header, renaming or dropping files.
+   * The generated files are validated before it runs, and it only runs when they pass; the files
+   * it returns are validated again before anything is written. A throw or rejection aborts the
+   * write. force skips both refusals.
+   */
+  postProcess?: PostProcessHook;
+}
+
+/**
+ * Lookup of the commit SHA an action repository's ref (`actions/checkout`, `v4`) points to
+ */
+export type ActionShaResolver = (repository: string, ref: string) => string | undefined | Promise<string | undefined>;
+
+/**
+ * Transformation of the generated files by repository-relative path
+ */
+export type PostProcessHook = (files: Record<string, string>) => Record<string, string> | Promise<Record<string, string>>;
+
+/**
+ * Coverage reporting services the unit test job can upload to
+ */
+export type CoverageService = 'codecov' | 'coveralls' | 'none';
+
+/**
+ * Which categories of CI jobs are generated: lint and static analysis only,
+ * build and test only, or the full pipeline
+ */
+export enum GenerationPreset {
+  Lint = 'lint',
+  Test = 'test',
+  Full = 'full'
+}
+
+/**
+ * Operating systems of GitHub-hosted runners the test matrix can cover
+ */
+export const HOSTED_OS_NAMES = ['linux', 'macos', 'windows'] as const;
+
+export type HostedOS = typeof HOSTED_OS_NAMES[number];
+
+/**
+ * Places in the CI jobs steps can be injected at: before the build job's build steps, after
+ * the test jobs' test steps, and before the deploy step of a job deploying to an environment
+ */
+export const STEP_ANCHORS = ['before-build', 'after-test', 'pre-deploy'] as const;
+
+export type StepAnchor = typeof STEP_ANCHORS[number];
+
+/**
+ * Step of the repository config spliced into the CI jobs at an anchor
+ */
+export interface InjectedStep {
+  position: StepAnchor;
+  step: StepTemplate;
+}
+
+/**
+ * Matrix combination of the repository config, by the values of the matrix dimensions (os,
+ * node-version); an os value may be an operating system, standing for its hosted runner
+ */
+export interface MatrixRule {
+  /** Id of the job whose matrix the rule applies to; unset, every job with the rule's dimensions */
+  job?: string;
+  values: Record<string, string | number | boolean>;
+}
+
+/**
+ * Runner labels for every job, or per job name; jobs missing from a map keep their runner
+ */
+export type RunnerLabels = string[] | Record<string, string[]>;
+
+/**
+ * How a Cargo workspace is tested: one job over the whole workspace, or one test job per member crate
+ */
+export type CargoWorkspaceLayout = 'workspace' | 'per-crate';
+
+/**
+ * How an npm or pnpm workspace is tested: workspace-wide commands in the usual jobs, or one
+ * test job per package
+ */
+export type NodeWorkspaceLayout = 'workspace' | 'per-package';
+
+/**
+ * How several detected test runners are run:
+ * one job per runner, or one job with a matrix dimension over them
+ */
+export type TestRunnerLayout = 'jobs' | 'matrix';
+
+/**
+ * What to do when the README advertises CI the project already runs:
+ * generate anyway with a warning, or skip the ci workflow
+ */
+export type ExistingCIAction = 'generate' | 'skip';
+
+/**
+ * How CI jobs are spread over workflow files: all in ci.yml, or one file per kind of job
+ * (build.yml, test.yml, lint.yml)
+ */
+export type WorkflowSplit = 'none' | 'by-kind';
+
+/**
+ * How split workflows share the build jobs: called from a reusable setup.yml, or duplicated
+ * into every workflow needing them
+ */
+export type SplitSetup = 'reusable' | 'duplicate';
+
+/**
+ * How monorepo packages are laid out across generated workflows:
+ * one workflow with per-package jobs, or one workflow file per package
+ */
+export type MonorepoLayout = 'single' | 'per-package';
+
+/**
+ * How monorepo jobs are limited to the packages whose files changed:
+ * static `paths` filters on the workflow triggers, or a dorny/paths-filter job
+ * whose outputs gate each package's jobs within a single workflow
+ */
+export type MonorepoChangeDetection = 'path-triggers' | 'paths-filter';
+
+/**
+ * A package within a monorepo together with its detection results
+ */
+export interface MonorepoPackage {
+  /** Repository-relative path of the package ('.' for the root) */
+  path: string;
+  name: string;
+  detectionResult: DetectionResult;
+  /** Nested package paths whose changes belong to those packages instead */
+  excludePaths?: string[];
+  /** Paths of the packages this one depends on; their changes run its jobs too */
+  dependsOn?: string[];
+}
+
+/**
+ * CI providers the generator can emit configuration for
+ */
+export enum Provider {
+  GitHubActions = 'github',
+  GitLab = 'gitlab',
+  CircleCI = 'circleci',
+  AzurePipelines = 'azure',
+  BitbucketPipelines = 'bitbucket',
+  Jenkins = 'jenkins'
 }
 
 /**
@@ -73,6 +387,82 @@ export interface DetectionResult {
   testingFrameworks: TestingFrameworkDetection[];
   deploymentTargets: DeploymentTargetDetection[];
   projectMetadata: ProjectMetadata;
+  buildConstraints?: BuildConstraintDetection;
+  versionConstraints?: VersionConstraintDetection[];
+  /** Lockfiles committed to the repository; undefined when the file system was not scanned */
+  lockFiles?: string[];
+  dockerImages?: DockerImageDetection[];
+  testRunners?: TestRunnerDetection[];
+  /** Makefile at the project root; its targets replace the guessed build, test and lint commands */
+  makefile?: MakefileDetection;
+  /** Cargo workspace at the project root; its crates are built with --workspace */
+  cargoWorkspace?: CargoWorkspaceDetection;
+  /** npm or pnpm workspace at the project root; packages are built and tested from the root install */
+  nodeWorkspace?: NodeWorkspaceDetection;
+  /** Nx or Turborepo at the project root; one job runs its tasks for the affected packages instead of per-package jobs */
+  taskGraph?: TaskGraphDetection;
+  /** Static site generator whose build output can be deployed to GitHub Pages */
+  staticSite?: StaticSiteDetection;
+  /** Maven or Gradle build at the project root, and the JDK release its manifest targets */
+  javaBuild?: JavaBuildDetection;
+  /** Coverage tools the project already configures, at most one per language */
+  coverageTools?: CoverageDetection[];
+  /** Configured linters; the lint job runs these instead of the language's usual checks */
+  linters?: LinterDetection[];
+  /** Python package layout; decides how pytest finds the project's packages */
+  pythonLayout?: PythonLayoutDetection;
+  /** Root go.mod; its requirements decide whether private module access is set up */
+  goModule?: GoModuleDetection;
+  /** GoReleaser config; tag releases of Go projects run GoReleaser instead of the build steps */
+  goReleaser?: GoReleaserDetection;
+  /** pre-commit configuration, run by a pre-commit job when options.preCommit asks for one */
+  preCommit?: PreCommitDetection;
+  /** Terraform root modules, checked by a terraform job when options.terraform asks for one */
+  terraform?: TerraformDetection;
+  /** Mix project; its pinned Elixir and OTP versions set up the BEAM */
+  elixirProject?: ElixirProjectDetection;
+  /** Bundler project; picks the Ruby version, the rspec or rake tests, rubocop and a Rails test database */
+  rubyProject?: RubyProjectDetection;
+  /** Swift package; its tools version sets up Swift and its test targets decide whether swift test runs */
+  swiftPackage?: SwiftPackageDetection;
+  /** .NET solution or projects; the SDK, the framework matrix and what the dotnet commands run on */
+  dotnetProject?: DotnetProjectDetection;
+  /** Dart package or Flutter app; the SDK, the tool the steps run and the platforms flutter build targets */
+  dartProject?: DartProjectDetection;
+  /** Stack or Cabal project; the tool the steps run, the GHC set up, the cached store and HLint */
+  haskellProject?: HaskellProjectDetection;
+  /** CMake project; C/C++ jobs configure, build and run ctest with it */
+  cmakeProject?: CMakeProjectDetection;
+  /** Shell scripts; the lint job runs shellcheck and shfmt over them, the bats tests are the unit tests */
+  shellProject?: ShellProjectDetection;
+  /** OpenAPI documents and GraphQL schemas; the api-lint job lints every one of them */
+  apiSchemas?: ApiSchemaDetection;
+  /** Scripts of the root package.json; Node build, test and lint steps run these instead of guessed commands */
+  packageScripts?: PackageScriptsDetection;
+  /** Subdirectory the project lives in; jobs run their steps there */
+  workingDirectory?: string;
+  /** CI status badges found in the README */
+  ciBadges?: CIBadgeDetection[];
+  /** Environment variables the README requires, provided to jobs from repository secrets */
+  requiredSecrets?: RequiredSecretDetection[];
+  /** Variables an example environment file documents, provided to the jobs the README's secrets are */
+  envExample?: EnvExampleDetection;
+  /** Submodules and git-versioning tools; checkout steps fetch the submodules and, for the tools, the full history */
+  gitCheckout?: GitCheckoutDetection;
+  /** The project's own license; the license check allows it besides the default permissive licenses */
+  license?: LicenseDetection;
+  /** Changelog at the root; the changelog check requires pull requests to change it */
+  changelog?: ChangelogDetection;
+  /** .proto files and buf configuration; the buf job lints, checks and regenerates them */
+  protobuf?: ProtobufDetection;
+  /** Generated Go files and bundled JavaScript; golangci-lint and ESLint are told to leave them out */
+  generatedCode?: GeneratedCodeDetection;
+  /** CODEOWNERS rules; the codeowners job comments on pull requests with those their changes fall under */
+  codeowners?: CodeownersDetection;
+  /** System packages the README lists as prerequisites, installed with apt-get on Linux runners */
+  systemPackages?: string[];
+  /** Services from docker-compose files and the README's prerequisites, run as service containers next to the test jobs */
+  services?: ServiceDetection[];
 }
 
 /**
@@ -94,6 +484,8 @@ export interface LanguageDetection {
   version?: string;
   confidence: number;
   primary: boolean;
+  /** Directory the language's manifest and sources live in, when not the repository root */
+  directory?: string;
 }
 
 /**
@@ -111,6 +503,8 @@ export interface BuildToolDetection {
 export interface PackageManagerDetection {
   name: string;
   lockFile?: string;
+  /** Version pinned by the project, e.g. from the packageManager field of package.json */
+  version?: string;
   confidence: number;
 }
 
@@ -132,6 +526,403 @@ export interface DeploymentTargetDetection {
   confidence: number;
 }
 
+/**
+ * Build constraint detection information (Go `//go:build` tags)
+ */
+export interface BuildConstraintDetection {
+  platforms: PlatformTarget[];
+  tags: string[];
+  /** Custom tags `_test.go` files are constrained to, each tested in a job of its own */
+  testTags?: string[];
+}
+
+/**
+ * Language version constraint declared in a manifest (engines.node, go.mod, requires-python)
+ */
+export interface VersionConstraintDetection {
+  runtime: 'node' | 'go' | 'python';
+  source: string;
+  raw: string;
+  /** Set when the constraint pins a single release */
+  exact?: string;
+  /** Supported release lines satisfying the constraint, oldest first */
+  versions: string[];
+  /** Set when the versions were listed explicitly (config file); all of them are tested */
+  listed?: boolean;
+}
+
+/**
+ * Dockerfile the CI workflow builds and pushes an image from
+ */
+export interface DockerImageDetection {
+  /** Repository-relative path of the Dockerfile */
+  dockerfile: string;
+  /** Build context directory */
+  context: string;
+  baseImage?: string;
+  /** Final stage of a multi-stage build */
+  target?: string;
+  hasDockerignore: boolean;
+}
+
+/**
+ * README badge showing the project already runs CI
+ */
+export interface CIBadgeDetection {
+  /** CI provider the badge reports on (github-actions, travis, circleci, gitlab, azure-pipelines, appveyor) */
+  provider: string;
+  imageUrl: string;
+  targetUrl?: string;
+}
+
+/**
+ * Secret a job needs in its environment
+ */
+export interface RequiredSecretDetection {
+  /** Environment variable name, also used as the secret name */
+  name: string;
+}
+
+/**
+ * Example environment file and its variables; secret ones have no value
+ */
+export interface EnvExampleDetection {
+  file: string;
+  variables: Array<{ name: string; value?: string; secret: boolean }>;
+}
+
+/**
+ * Submodules a checkout fetches and the tools that read the version from git tags
+ */
+export interface GitCheckoutDetection {
+  submodules: Array<{ name: string; path: string; url: string }>;
+  versionFromGit: string[];
+}
+
+/**
+ * SPDX id and file of the project's own license
+ */
+export interface LicenseDetection {
+  spdxId?: string;
+  file?: string;
+  source: string;
+}
+
+/**
+ * Changelog file of a project
+ */
+export interface ChangelogDetection {
+  file: string;
+}
+
+/**
+ * Protocol Buffers definitions of a project and where buf runs for them
+ */
+export interface ProtobufDetection {
+  protoFiles: string[];
+  /** Directory holding buf.yaml or buf.work.yaml, else '.' */
+  bufDirectory: string;
+  bufConfig?: string;
+  bufGenConfig?: string;
+}
+
+/**
+ * CODEOWNERS file of a repository, its rules in file order
+ */
+export interface CodeownersDetection {
+  file: string;
+  rules: Array<{
+    pattern: string;
+    /** paths-filter globs matching what the pattern does */
+    globs: string[];
+    /** None leaves the paths unowned */
+    owners: string[];
+  }>;
+}
+
+/**
+ * Generated code paths of a project, relative to its root
+ */
+export interface GeneratedCodeDetection {
+  goFiles: string[];
+  goDirectories: string[];
+  bundleDirectories: string[];
+}
+
+/**
+ * Test runner the CI workflow invokes
+ */
+export interface TestRunnerDetection {
+  name: string;
+  language: string;
+  /** Command that runs the tests */
+  command: string;
+  /** Commands that install the runner first */
+  setup?: string[];
+  /** Higher-level runner that already invokes this one; no job is generated for it */
+  suppressedBy?: string;
+  /** File the runner was detected from */
+  source?: string;
+}
+
+/**
+ * Makefile whose targets CI steps invoke
+ */
+export interface MakefileDetection {
+  /** Makefile name (Makefile, makefile or GNUmakefile) */
+  file: string;
+  targets: string[];
+}
+
+/**
+ * Cargo workspace whose member crates CI builds and tests
+ */
+export interface CargoWorkspaceDetection {
+  members: Array<{ name: string; path: string }>;
+  /** Features declared by the root package, excluding default */
+  features: string[];
+}
+
+/**
+ * npm or pnpm workspace and the scripts of its packages
+ */
+export interface NodeWorkspaceDetection {
+  manager: 'npm' | 'pnpm';
+  members: Array<{ name: string; path: string; scripts: string[] }>;
+}
+
+/**
+ * Nx or Turborepo task runner of a JavaScript monorepo and the tasks CI runs through it
+ */
+export interface TaskGraphDetection {
+  tool: 'nx' | 'turbo';
+  configFile: string;
+  tasks: string[];
+}
+
+/**
+ * Static site generator and the directory its build writes to
+ */
+export interface StaticSiteDetection {
+  /** hugo, jekyll, nextjs, vitepress or mkdocs */
+  generator: string;
+  configFile: string;
+  outputDir: string;
+}
+
+/**
+ * Service such as postgres or redis, with the version the README asks for or the image a
+ * docker-compose file runs it from
+ */
+export interface ServiceDetection {
+  name: string;
+  version?: string;
+  /** Compose image, tag included when it pins one */
+  image?: string;
+  /** Environment the compose file sets for the container */
+  env?: Record<string, string>;
+}
+
+/**
+ * Java build tool and the JDK release read from pom.xml or the Gradle scripts
+ */
+export interface JavaBuildDetection {
+  buildSystem: 'maven' | 'gradle';
+  javaVersion?: string;
+  /** mvnw or gradlew is committed and runs instead of an installed tool */
+  wrapper: boolean;
+  /** Module directories built by the root aggregator */
+  modules: string[];
+}
+
+/**
+ * Coverage tool a project's tests are instrumented with
+ */
+export interface CoverageDetection {
+  tool: 'go-cover' | 'jest' | 'vitest' | 'nyc' | 'c8' | 'pytest-cov';
+  language: string;
+  /** Report file the project's own commands write, when they name one */
+  reportFile?: string;
+}
+
+/**
+ * Linter a project has configured and the command running it
+ */
+export interface LinterDetection {
+  name: 'golangci-lint' | 'eslint' | 'ruff' | 'flake8' | 'black' | 'clippy';
+  language: string;
+  command: string;
+  /** False when the lint job has to install it before running */
+  installed: boolean;
+  /** File the linter was detected from */
+  source?: string;
+}
+
+/**
+ * Python package layout: packages under src/ or at the repository root
+ */
+export interface PythonLayoutDetection {
+  layout: 'src' | 'flat';
+  packages: string[];
+  /** Packages without an __init__.py */
+  namespacePackages: string[];
+  /** Whether pyproject.toml or setup.py allows an editable install */
+  installable: boolean;
+  requirementsFile: boolean;
+}
+
+/**
+ * Module path and required modules from go.mod
+ */
+export interface GoModuleDetection {
+  path: string;
+  requires: string[];
+}
+
+/**
+ * GoReleaser config file
+ */
+export interface GoReleaserDetection {
+  file: string;
+}
+
+/**
+ * Terraform root module directories and the required_version constraint
+ */
+export interface TerraformDetection {
+  directories: string[];
+  requiredVersion?: string;
+}
+
+/**
+ * Elixir and OTP versions of a Mix project and whether it is a Phoenix app on Postgres
+ */
+export interface ElixirProjectDetection {
+  elixirVersion?: string;
+  otpVersion?: string;
+  phoenix: boolean;
+  postgres: boolean;
+}
+
+/**
+ * Ruby version of a Bundler project, the gems that pick its test and lint steps and the
+ * database of a Rails app
+ */
+export interface RubyProjectDetection {
+  rubyVersion?: string;
+  gemspec?: string;
+  rspec: boolean;
+  rake: boolean;
+  rubocop: boolean;
+  rails: boolean;
+  database?: 'postgres' | 'sqlite';
+}
+
+/**
+ * Tools version of a Swift package and its executable, library and test targets
+ */
+export interface SwiftPackageDetection {
+  toolsVersion?: string;
+  executables: string[];
+  libraries: string[];
+  testTargets: string[];
+}
+
+/**
+ * Solution, projects, target frameworks and pinned SDK of a .NET project
+ */
+export interface DotnetProjectDetection {
+  solution?: string;
+  projects: string[];
+  testProjects: string[];
+  targetFrameworks: string[];
+  sdkVersion?: string;
+  lockFile: boolean;
+}
+
+/**
+ * Whether a Dart project is a Flutter app, its SDK constraints, platforms and tests
+ */
+export interface DartProjectDetection {
+  name?: string;
+  flutter: boolean;
+  sdkConstraint?: string;
+  flutterConstraint?: string;
+  platforms: string[];
+  tests: boolean;
+}
+
+/**
+ * Build tool of a Haskell project, its snapshot or GHC release, test suites and HLint configuration
+ */
+export interface HaskellProjectDetection {
+  tool: 'stack' | 'cabal';
+  resolver?: string;
+  ghcVersion?: string;
+  cabalFiles: string[];
+  tests: boolean;
+  hlint: boolean;
+}
+
+/**
+ * CMake version and C++ standard a CMake project asks for, its presets and its build directory
+ */
+export interface CMakeProjectDetection {
+  minimumVersion?: string;
+  cxxStandard?: string;
+  tests: boolean;
+  presets?: {
+    configure: string;
+    build?: string;
+    test?: string;
+    binaryDir?: string;
+    requiredVersion?: string;
+  };
+  binaryDir: string;
+  clangFormat: boolean;
+}
+
+/**
+ * Scripts of a shell project, the directories of its bats tests and whether it formats with shfmt
+ */
+export interface ShellProjectDetection {
+  scripts: string[];
+  batsTests: string[];
+  shfmt: boolean;
+}
+
+/**
+ * OpenAPI documents and GraphQL schema files of a project, and its Spectral ruleset
+ */
+export interface ApiSchemaDetection {
+  openapi: string[];
+  graphql: string[];
+  spectralRuleset?: string;
+}
+
+/**
+ * Script names of package.json and their commands
+ */
+export interface PackageScriptsDetection {
+  scripts: Record<string, string>;
+}
+
+/**
+ * pre-commit config file and the ids of its hooks
+ */
+export interface PreCommitDetection {
+  file: string;
+  hooks: string[];
+}
+
+/**
+ * GOOS/GOARCH target referenced by build constraints
+ */
+export interface PlatformTarget {
+  goos: string;
+  goarch: string;
+}
+
 /**
  * Project metadata from README parsing
  */
@@ -0,0 +1,665 @@
+/**
+ * Azure Pipelines Renderer for converting workflow templates to azure-pipelines.yml
+ */
+
+import { FormattingOptions, RenderingResult, Renderer, RenderedFile } from './renderer-types';
+import {
+  STAGE_ORDER,
+  actionName,
+  dumpYaml,
+  expandMatrix,
+  getStage,
+  installsDependencies,
+  matrixAxes,
+  resolveDependencyTool,
+  toArtifactPaths,
+  toParameterName,
+  toRenderingResult,
+  translateExpression,
+  translateSetupAction,
+  unwrapExpression,
+  withGenerationInfo
+} from './provider-translation';
+import { WorkflowTemplate, JobTemplate, StepTemplate, TriggerConfig, MatrixStrategy } from '../types';
+import { DetectionResult } from '../interfaces';
+import { CACHE_DATE_STEP_ID } from '../utils/cache-utils';
+
+/**
+ * Microsoft-hosted images per GitHub-hosted runner label
+ */
+const VM_IMAGES: Record<string, string> = {
+  'ubuntu-latest': 'ubuntu-latest',
+  'ubuntu-22.04': 'ubuntu-22.04',
+  'ubuntu-20.04': 'ubuntu-20.04',
+  'windows-latest': 'windows-latest',
+  'windows-2022': 'windows-2022',
+  'windows-2019': 'windows-2019',
+  'macos-latest': 'macOS-latest',
+  'macos-14': 'macOS-14',
+  'macos-13': 'macOS-13'
+};
+
+/**
+ * Setup actions mapped to the tool installer task and the input carrying the version
+ */
+const SETUP_TASKS: Record<string, { task: string; versionInput: string; input: string; inputs?: Record<string, string> }> = {
+  'actions/setup-node': { task: 'UseNode@1', versionInput: 'node-version', input: 'version' },
+  'actions/setup-python': { task: 'UsePythonVersion@0', versionInput: 'python-version', input: 'versionSpec' },
+  'actions/setup-go': { task: 'GoTool@0', versionInput: 'go-version', input: 'version' },
+  'actions/setup-java': {
+    task: 'JavaToolInstaller@0',
+    versionInput: 'java-version',
+    input: 'versionSpec',
+    inputs: { jdkArchitectureOption: 'x64', jdkSourceOption: 'PreInstalled' }
+  }
+};
+
+/**
+ * Build.Reason values per GitHub event name
+ */
+const BUILD_REASONS: Record<string, string> = {
+  push: 'IndividualCI',
+  pull_request: 'PullRequest',
+  schedule: 'Schedule',
+  workflow_dispatch: 'Manual'
+};
+
+/**
+ * Cache configuration per package manager, keyed on its lockfile.
+ * Cache@2 caches one directory per task, so tool caches are redirected below the pipeline workspace.
+ */
+const CACHE_CONFIGS: Record<string, { lockFile: string; paths: string[]; variables: Record<string, string> }> = {
+  npm: { lockFile: 'package-lock.json', paths: ['$(Pipeline.Workspace)/.npm'], variables: { npm_config_cache: '$(Pipeline.Workspace)/.npm' } },
+  yarn: { lockFile: 'yarn.lock', paths: ['$(Pipeline.Workspace)/.yarn'], variables: { YARN_CACHE_FOLDER: '$(Pipeline.Workspace)/.yarn' } },
+  pnpm: { lockFile: 'pnpm-lock.yaml', paths: ['$(Pipeline.Workspace)/.pnpm-store'], variables: { npm_config_store_dir: '$(Pipeline.Workspace)/.pnpm-store' } },
+  bun: { lockFile: 'bun.lockb', paths: ['$(Pipeline.Workspace)/.bun'], variables: { BUN_INSTALL_CACHE_DIR: '$(Pipeline.Workspace)/.bun' } },
+  pip: { lockFile: 'requirements.txt', paths: ['$(Pipeline.Workspace)/.pip'], variables: { PIP_CACHE_DIR: '$(Pipeline.Workspace)/.pip' } },
+  poetry: { lockFile: 'poetry.lock', paths: ['$(Pipeline.Workspace)/.poetry'], variables: { POETRY_CACHE_DIR: '$(Pipeline.Workspace)/.poetry' } },
+  pipenv: { lockFile: 'Pipfile.lock', paths: ['$(Pipeline.Workspace)/.pipenv'], variables: { PIPENV_CACHE_DIR: '$(Pipeline.Workspace)/.pipenv' } },
+  go: { lockFile: 'go.sum', paths: ['$(Pipeline.Workspace)/.gomodcache'], variables: { GOMODCACHE: '$(Pipeline.Workspace)/.gomodcache' } },
+  cargo: { lockFile: 'Cargo.lock', paths: ['$(Pipeline.Workspace)/.cargo', 'target'], variables: { CARGO_HOME: '$(Pipeline.Workspace)/.cargo' } },
+  maven: { lockFile: 'pom.xml', paths: ['$(Pipeline.Workspace)/.m2/repository'], variables: { MAVEN_OPTS: '-Dmaven.repo.local=$(Pipeline.Workspace)/.m2/repository' } },
+  gradle: { lockFile: 'build.gradle', paths: ['$(Pipeline.Workspace)/.gradle'], variables: { GRADLE_USER_HOME: '$(Pipeline.Workspace)/.gradle' } }
+};
+
+/**
+ * Per-render state shared across job conversions
+ */
+interface ConversionContext {
+  cache: { tool: string; lockFile: string; paths: string[] } | undefined;
+  serviceContainers: Record<string, any>;
+  warnings: string[];
+}
+
+/**
+ * Matrix axes translated to Azure Pipelines variables
+ */
+interface ConvertedMatrix {
+  /** Named matrix entries, each a set of variables */
+  entries: Record<string, Record<string, string>>;
+  variables: Record<string, string>;
+}
+
+/**
+ * Azure Pipelines renderer that maps workflow templates onto stages, jobs and steps
+ */
+export class AzurePipelinesRenderer implements Renderer {
+  private options: FormattingOptions;
+
+  constructor(options: FormattingOptions) {
+    this.options = options;
+  }
+
+  /**
+   * Render workflow template to the azure-pipelines.yml file at the repository root
+   */
+  render(workflow: WorkflowTemplate, detectionResult: DetectionResult): RenderedFile {
+    const rendered = this.renderWorkflow(workflow, detectionResult);
+    return { path: 'azure-pipelines.yml', content: rendered.yaml, warnings: rendered.warnings };
+  }
+
+  /**
+   * Render workflow template to an azure-pipelines.yml string
+   */
+  renderWorkflow(workflow: WorkflowTemplate, detectionResult: DetectionResult): RenderingResult {
+    const startTime = Date.now();
+    const warnings: string[] = [];
+
+    try {
+      const pipeline = this.convertToAzureFormat(workflow, detectionResult, warnings);
+      const content = withGenerationInfo(dumpYaml(pipeline, this.options), workflow.name, this.options, 'pipeline');
+      return toRenderingResult(content, startTime, this.getAppliedOptimizations(pipeline), warnings);
+    } catch (error) {
+      throw new Error(`Azure Pipelines rendering failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  /**
+   * Convert workflow template to Azure Pipelines format
+   */
+  private convertToAzureFormat(
+    workflow: WorkflowTemplate,
+    detectionResult: DetectionResult,
+    warnings: string[]
+  ): any {
+    const context: ConversionContext = {
+      cache: this.resolveCache(detectionResult),
+      serviceContainers: {},
+      warnings
+    };
+
+    const stages: Record<string, any[]> = {};
+    const jobStages: Record<string, string> = {};
+    for (const job of workflow.jobs) {
+      const stage = getStage(job.name);
+      jobStages[this.toIdentifier(job.name)] = stage;
+    }
+
+    for (const job of workflow.jobs) {
+      const converted = this.convertJob(job, jobStages, context);
+      if (converted) {
+        const stage = getStage(job.name);
+        (stages[stage] = stages[stage] || []).push(converted);
+      } else {
+        delete jobStages[this.toIdentifier(job.name)];
+        warnings.push(`Job '${job.name}' has no Azure Pipelines equivalent and was omitted`);
+      }
+    }
+
+    // Drop dependencies on omitted jobs
+    for (const jobs of Object.values(stages)) {
+      for (const job of jobs) {
+        if (job.dependsOn) {
+          job.dependsOn = job.dependsOn.filter((dependency: string) => dependency in jobStages);
+          if (job.dependsOn.length === 0) {
+            delete job.dependsOn;
+          }
+        }
+      }
+    }
+
+    const pipeline: any = this.convertTriggers(workflow.triggers, workflow.concurrency?.cancelInProgress);
+
+    if (Object.keys(context.serviceContainers).length > 0) {
+      pipeline.resources = {
+        containers: Object.entries(context.serviceContainers).map(([name, container]) => ({ container: name, ...container }))
+      };
+    }
+
+    const cacheVariables = context.cache ? CACHE_CONFIGS[context.cache.tool]?.variables : undefined;
+    if (cacheVariables) {
+      pipeline.variables = cacheVariables;
+    }
+
+    // Stages run in the listed order, each after the previous one
+    pipeline.stages = STAGE_ORDER
+      .filter(stage => stages[stage])
+      .map(stage => ({ stage, jobs: stages[stage] }));
+
+    return pipeline;
+  }
+
+  /**
+   * Convert job template to an Azure Pipelines job, or null when nothing translates.
+   * Dependencies on jobs in earlier stages are met by stage ordering, so only same-stage ones are kept.
+   */
+  private convertJob(job: JobTemplate, jobStages: Record<string, string>, context: ConversionContext): any | null {
+    const stage = getStage(job.name);
+    const matrix = job.strategy ? this.convertMatrix(job.strategy) : undefined;
+    const variables = matrix?.variables || {};
+    const workingDirectory = job.defaults?.run?.workingDirectory;
+    const cache = installsDependencies(job) ? context.cache : undefined;
+    const steps: any[] = [];
+    let hasCommands = false;
+
+    for (const step of job.steps) {
+      if (step.uses) {
+        const translated = this.translateAction(step, variables, context);
+        steps.push(...translated);
+        hasCommands = hasCommands || translated.some(entry => !entry.checkout && entry.task !== 'PublishPipelineArtifact@1');
+
+        // Cache@2 restores here and saves in a post-job step
+        if (cache && step.uses.startsWith('actions/checkout')) {
+          steps.push(...this.createCacheSteps(cache));
+        }
+        continue;
+      }
+
+      // The date only feeds the actions/cache key, which this provider replaces with its own cache
+      if (!step.run || step.id === CACHE_DATE_STEP_ID) {
+        continue;
+      }
+
+      steps.push(this.translateRunStep(step, workingDirectory, variables, context.warnings));
+      hasCommands = true;
+    }
+
+    if (!hasCommands) {
+      return null;
+    }
+
+    const converted: any = {
+      job: this.toIdentifier(job.name),
+      displayName: job.name
+    };
+
+    const dependsOn = (job.needs || [])
+      .map(need => this.toIdentifier(need))
+      .filter(need => jobStages[need] === stage);
+    if (dependsOn.length > 0) {
+      converted.dependsOn = dependsOn;
+    }
+
+    const condition = this.translateCondition(job.if, `job '${job.name}'`, variables, context.warnings);
+    if (condition) {
+      converted.condition = condition;
+    }
+
+    converted.pool = { vmImage: this.resolveVmImage(job.runsOn, variables, context.warnings) };
+
+    if (matrix) {
+      converted.strategy = { matrix: matrix.entries };
+      if (job.strategy?.maxParallel) {
+        converted.strategy.maxParallel = job.strategy.maxParallel;
+      }
+    }
+
+    const services = this.convertServices(job.services, context);
+    if (services) {
+      converted.services = services;
+    }
+
+    if (job.timeout) {
+      converted.timeoutInMinutes = job.timeout;
+    }
+
+    if (job.continueOnError) {
+      converted.continueOnError = true;
+    }
+
+    if (job.env && Object.keys(job.env).length > 0) {
+      converted.variables = Object.fromEntries(Object.entries(job.env).map(([name, value]) =>
+        [name, this.translateExpression(String(value), variables)]));
+    }
+
+    converted.steps = steps;
+
+    return converted;
+  }
+
+  /**
+   * Translate a GitHub Action step into Azure Pipelines steps
+   */
+  private translateAction(step: StepTemplate, variables: Record<string, string>, context: ConversionContext): any[] {
+    const action = actionName(step);
+
+    if (action === 'actions/checkout') {
+      return [{
+        checkout: 'self',
+        ...(step.with?.submodules && { submodules: step.with.submodules === 'recursive' ? 'recursive' : true }),
+        ...(step.with?.['fetch-depth'] !== undefined && { fetchDepth: Number(step.with['fetch-depth']) })
+      }];
+    }
+
+    const setup = SETUP_TASKS[action];
+    if (setup) {
+      let version = this.translateExpression(String(step.with?.[setup.versionInput] ?? ''), variables);
+      if (action === 'actions/setup-go') {
+        version = this.toGoToolVersion(version);
+      }
+      return [{
+        task: setup.task,
+        displayName: step.name,
+        inputs: { ...(version && { [setup.input]: version }), ...setup.inputs }
+      }];
+    }
+
+    // Dependencies are cached with Cache@2 keyed on the lockfile
+    if (action === 'actions/cache' || action === 'actions/download-artifact') {
+      return [];
+    }
+
+    const commands = translateSetupAction(step, value => this.translateExpression(value, variables));
+    if (commands) {
+      return commands.length > 0 ? [{ script: commands.join('\n'), displayName: step.name }] : [];
+    }
+
+    if (action === 'actions/upload-artifact') {
+      let name = this.translateExpression(String(step.with?.name ?? 'artifact'), variables);
+      // Every matrix leg publishes its own artifact and names must be unique per run
+      if (Object.keys(variables).length > 0) {
+        name = `${name}-$(System.JobPositionInPhase)`;
+      }
+      const paths = toArtifactPaths(String(step.with?.path ?? ''));
+      const condition = this.translateCondition(step.if, `step '${step.name}'`, variables, context.warnings);
+      // Pipeline artifacts hold one path each and their names must be unique per run
+      return paths.map(path => ({
+        task: 'PublishPipelineArtifact@1',
+        displayName: step.name,
+        ...(condition && { condition }),
+        inputs: {
+          targetPath: path,
+          artifact: paths.length > 1 ? `${name}-${path.replace(/[^A-Za-z0-9_.-]+/g, '-').replace(/^-|-$/g, '')}` : name
+        }
+      }));
+    }
+
+    context.warnings.push(`Step '${step.name}' uses ${step.uses}, which has no Azure Pipelines equivalent; skipped`);
+    return [];
+  }
+
+  /**
+   * Translate a run step into a `script` step
+   */
+  private translateRunStep(
+    step: StepTemplate,
+    jobWorkingDirectory: string | undefined,
+    variables: Record<string, string>,
+    warnings: string[]
+  ): any {
+    const script: any = {
+      script: this.translateExpression(step.run || '', variables),
+      displayName: step.name
+    };
+
+    const condition = this.translateCondition(step.if, `step '${step.name}'`, variables, warnings);
+    if (condition) {
+      script.condition = condition;
+    }
+
+    if (step.continueOnError) {
+      script.continueOnError = true;
+    }
+
+    const workingDirectory = step.workingDirectory || jobWorkingDirectory;
+    if (workingDirectory && workingDirectory !== '.') {
+      script.workingDirectory = workingDirectory;
+    }
+
+    if (step.env && Object.keys(step.env).length > 0) {
+      script.env = Object.fromEntries(Object.entries(step.env).map(([name, value]) =>
+        [name, this.translateExpression(String(value), variables)]));
+    }
+
+    if (step.timeout) {
+      script.timeoutInMinutes = step.timeout;
+    }
+
+    if (step.retries) {
+      script.retryCountOnTaskFailure = step.retries;
+    }
+
+    return script;
+  }
+
+  /**
+   * Translate a job or step condition into an Azure Pipelines condition; success() is the default and omitted
+   */
+  private translateCondition(
+    expression: string | undefined,
+    subject: string,
+    variables: Record<string, string>,
+    warnings: string[]
+  ): string | undefined {
+    const condition = expression ? unwrapExpression(expression) : undefined;
+    if (!condition || condition === 'success()') {
+      return undefined;
+    }
+    if (condition === 'always()') {
+      return 'always()';
+    }
+    if (condition === 'failure()') {
+      return 'failed()';
+    }
+
+    const comparison = condition.match(/^matrix\.([\w-]+)\s*(==|!=)\s*'([^']*)'$/);
+    const flag = condition.match(/^(!?)\s*matrix\.([\w-]+)$/);
+    const variable = variables[comparison?.[1] ?? flag?.[2] ?? ''];
+    if (comparison && variable) {
+      return `and(succeeded(), ${comparison[2] === '==' ? 'eq' : 'ne'}(variables['${variable}'], '${comparison[3]}'))`;
+    }
+    if (flag && variable) {
+      return `and(succeeded(), ${flag[1] ? 'ne' : 'eq'}(variables['${variable}'], 'true'))`;
+    }
+
+    const event = condition.match(/^github\.event_name\s*(==|!=)\s*'([^']*)'$/);
+    const reason = event ? BUILD_REASONS[event[2]!] : undefined;
+    if (event && reason) {
+      return `and(succeeded(), ${event[1] === '==' ? 'eq' : 'ne'}(variables['Build.Reason'], '${reason}'))`;
+    }
+
+    warnings.push(`Condition '${expression}' on ${subject} has no Azure Pipelines equivalent; it always runs`);
+    return undefined;
+  }
+
+  /**
+   * Translate GitHub expressions embedded in a string to Azure Pipelines macros
+   */
+  private translateExpression(value: string, variables: Record<string, string>): string {
+    return translateExpression(value, {
+      matrix: key => variables[key] ? `$(${variables[key]})` : undefined,
+      secret: name => `$(${name})`,
+      sha: '$(Build.SourceVersion)',
+      refName: '$(Build.SourceBranchName)',
+      os: '$(Agent.OS)'
+    });
+  }
+
+  /**
+   * Convert a matrix strategy to named entries; Azure has no matrix product, so every combination is listed
+   */
+  private convertMatrix(strategy: MatrixStrategy): ConvertedMatrix | undefined {
+    const axes = matrixAxes(strategy);
+    if (axes.length === 0) {
+      return undefined;
+    }
+
+    const variables: Record<string, string> = {};
+    for (const [key] of axes) {
+      variables[key] = toParameterName(key);
+    }
+
+    const entries: Record<string, Record<string, string>> = {};
+    for (const combination of expandMatrix(strategy)) {
+      // Microsoft-hosted macOS agents are x64, so arm64 targets are cross-compiled there
+      if ('cross-compile' in combination && /^macos/i.test(String(combination.runner)) && combination.goarch === 'arm64') {
+        combination['cross-compile'] = true;
+      }
+
+      const set: Record<string, string> = {};
+      for (const [key, value] of Object.entries(combination)) {
+        variables[key] = variables[key] || toParameterName(key);
+        set[variables[key] as string] = key === 'runner'
+          ? this.toVmImage(String(value))
+          : key === 'go-version' ? this.toGoToolVersion(String(value)) : String(value);
+      }
+
+      const axisValues = axes.map(([key]) => combination[key]).filter(value => value !== undefined);
+      let name = this.toIdentifier(axisValues.map(value => String(value)).join('_')).replace(/-/g, '_');
+      if (!/^[A-Za-z]/.test(name)) {
+        name = `${variables[axes[0]![0]]}_${name}`;
+      }
+      entries[name] = set;
+    }
+
+    return { entries, variables };
+  }
+
+  /**
+   * Convert workflow triggers to `trigger`, `pr` and `schedules`. Azure cannot cancel a running
+   * CI build, so cancelling superseded runs batches pushes instead and auto-cancels pull request builds.
+   */
+  private convertTriggers(triggers: TriggerConfig | undefined, cancelInProgress?: boolean | string): any {
+    const converted: any = {};
+    const push = triggers?.push;
+
+    if (push) {
+      const trigger: any = {};
+      if (push.branches?.length || push.branchesIgnore?.length) {
+        trigger.branches = {
+          ...(push.branches?.length && { include: push.branches }),
+          ...(push.branchesIgnore?.length && { exclude: push.branchesIgnore })
+        };
+      }
+      if (push.tags) {
+        trigger.tags = { include: push.tags.length > 0 ? push.tags : ['*'] };
+      }
+      if (push.paths?.length || push.pathsIgnore?.length) {
+        trigger.paths = {
+          ...(push.paths?.length && { include: push.paths }),
+          ...(push.pathsIgnore?.length && { exclude: push.pathsIgnore })
+        };
+      }
+      converted.trigger = Object.keys(trigger).length > 0 ? trigger : { branches: { include: ['*'] } };
+      // An expression only cancels some runs; pushes that may deploy keep one build per commit
+      if (cancelInProgress === true) {
+        converted.trigger.batch = true;
+      }
+    } else {
+      converted.trigger = 'none';
+    }
+
+    const pullRequest = triggers?.pullRequest;
+    if (pullRequest) {
+      const pr: any = {};
+      if (pullRequest.branches?.length) {
+        pr.branches = { include: pullRequest.branches };
+      }
+      if (pullRequest.paths?.length || pullRequest.pathsIgnore?.length) {
+        pr.paths = {
+          ...(pullRequest.paths?.length && { include: pullRequest.paths }),
+          ...(pullRequest.pathsIgnore?.length && { exclude: pullRequest.pathsIgnore })
+        };
+      }
+      // Only GitHub and Bitbucket repositories honour `pr`; Azure Repos uses branch policies instead
+      converted.pr = Object.keys(pr).length > 0 ? pr : { branches: { include: ['*'] } };
+      if (cancelInProgress !== undefined) {
+        converted.pr.autoCancel = Boolean(cancelInProgress);
+      }
+    } else {
+      converted.pr = 'none';
+    }
+
+    if (triggers?.schedule && triggers.schedule.length > 0) {
+      const branches = push?.branches?.length ? push.branches : ['main'];
+      converted.schedules = triggers.schedule.map(schedule => ({
+        cron: schedule.cron,
+        displayName: `Scheduled build (${schedule.cron})`,
+        branches: { include: branches },
+        always: true
+      }));
+    }
+
+    return converted;
+  }
+
+  /**
+   * Resolve the Microsoft-hosted image for a job's runner, which may come from a matrix variable
+   */
+  private resolveVmImage(runsOn: JobTemplate['runsOn'], variables: Record<string, string>, warnings: string[]): string {
+    if (typeof runsOn !== 'string') {
+      warnings.push('Self-hosted runner labels have no Azure Pipelines equivalent; using ubuntu-latest');
+      return 'ubuntu-latest';
+    }
+    return /\$\{\{/.test(runsOn) ? this.translateExpression(runsOn, variables) : this.toVmImage(runsOn);
+  }
+
+  private toVmImage(runner: string): string {
+    return VM_IMAGES[runner.toLowerCase()] || runner;
+  }
+
+  /**
+   * GoTool@0 downloads exact releases; since Go 1.21 the first release of a line is x.y.0
+   */
+  private toGoToolVersion(version: string): string {
+    const match = version.match(/^(\d+)\.(\d+)$/);
+    return match && Number(match[1]) === 1 && Number(match[2]) >= 21 ? `${version}.0` : version;
+  }
+
+  /**
+   * Build the Cache@2 steps for the dependency cache, one per cached directory
+   */
+  private createCacheSteps(cache: NonNullable<ConversionContext['cache']>): any[] {
+    return cache.paths.map((path, i) => {
+      const prefix = i === 0 ? cache.tool : `${cache.tool}-${path.replace(/[^A-Za-z0-9]+/g, '-').replace(/^-|-$/g, '')}`;
+      return {
+        task: 'Cache@2',
+        displayName: 'Cache dependencies',
+        inputs: {
+          key: `${prefix} | "$(Agent.OS)" | ${cache.lockFile}`,
+          restoreKeys: `${prefix} | "$(Agent.OS)"`,
+          path
+        }
+      };
+    });
+  }
+
+  /**
+   * Convert job services to service containers declared under `resources`
+   */
+  private convertServices(services: Record<string, any> | undefined, context: ConversionContext): Record<string, string> | undefined {
+    const converted: Record<string, string> = {};
+
+    for (const [name, service] of Object.entries(services || {})) {
+      if (!service || typeof service.image !== 'string') {
+        continue;
+      }
+      const container = this.toIdentifier(name);
+      context.serviceContainers[container] = {
+        image: service.image,
+        ...(service.ports && { ports: service.ports.map((port: any) => String(port)) }),
+        ...(service.env && { env: service.env })
+      };
+      converted[container] = container;
+    }
+
+    return Object.keys(converted).length > 0 ? converted : undefined;
+  }
+
+  /**
+   * Resolve the lockfile-keyed cache for the detected package manager
+   */
+  private resolveCache(detectionResult: DetectionResult): ConversionContext['cache'] {
+    const tool = resolveDependencyTool(detectionResult);
+    const config = tool ? CACHE_CONFIGS[tool] : undefined;
+    if (!tool || !config) {
+      return undefined;
+    }
+
+    const detected = detectionResult.packageManagers.find(pm => pm.name.toLowerCase() === tool);
+    const lockFile = detected?.lockFile && tool !== 'pip' ? detected.lockFile : config.lockFile;
+
+    return { tool, lockFile, paths: config.paths };
+  }
+
+  /**
+   * Convert a job name to an identifier; Azure only allows letters, digits and underscores
+   */
+  private toIdentifier(name: string): string {
+    return name
+      .replace(/[^A-Za-z0-9_]+/g, '_')
+      .replace(/^_+|_+$/g, '');
+  }
+
+  /**
+   * Get applied optimizations for metadata
+   */
+  private getAppliedOptimizations(pipeline: any): string[] {
+    const optimizations: string[] = [];
+    const jobs = (pipeline.stages || []).flatMap((stage: any) => stage.jobs) as any[];
+
+    if (jobs.some(job => job.steps.some((step: any) => step.task === 'Cache@2'))) {
+      optimizations.push('dependency-caching');
+    }
+
+    if (jobs.some(job => job.strategy?.matrix)) {
+      optimizations.push('matrix-builds');
+    }
+
+    if ((pipeline.stages || []).length > 1 || jobs.some(job => job.dependsOn)) {
+      optimizations.push('dag-execution');
+    }
+
+    return optimizations;
+  }
+}
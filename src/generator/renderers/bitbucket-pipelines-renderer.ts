@@ -0,0 +1,536 @@
+/**
+ * Bitbucket Pipelines Renderer for converting workflow templates to bitbucket-pipelines.yml
+ */
+
+import { FormattingOptions, RenderingResult, Renderer, RenderedFile } from './renderer-types';
+import {
+  actionName,
+  dumpYaml,
+  evaluateMatrixCondition,
+  expandMatrix,
+  groupByNeeds,
+  installsDependencies,
+  isImageSetupAction,
+  isNonLinuxRunner,
+  matrixAxes,
+  matrixInclude,
+  resolveDependencyTool,
+  resolveLanguageImage,
+  sanitizeJobName,
+  toArtifactGlobs,
+  toRenderingResult,
+  translateExpression,
+  translateSetupAction,
+  unwrapExpression,
+  withGenerationInfo
+} from './provider-translation';
+import { WorkflowTemplate, JobTemplate, StepTemplate, TriggerConfig, MatrixStrategy } from '../types';
+import { DetectionResult } from '../interfaces';
+import { CACHE_DATE_STEP_ID } from '../utils/cache-utils';
+import { withRetries } from '../utils/retry';
+
+/**
+ * Image used when the primary language has no official image
+ */
+const DEFAULT_IMAGE = 'atlassian/default-image:4';
+
+/**
+ * Package managers covered by a cache Bitbucket predefines
+ */
+const BUILTIN_CACHES: Record<string, string> = {
+  npm: 'node',
+  yarn: 'node',
+  pip: 'pip',
+  maven: 'maven',
+  gradle: 'gradle'
+};
+
+/**
+ * Custom caches per package manager: the lockfile that invalidates them and the path each one saves.
+ * The official images run as root, so home-relative paths resolve under /root.
+ */
+const CUSTOM_CACHES: Record<string, { lockFile: string; caches: Record<string, string> }> = {
+  pnpm: { lockFile: 'pnpm-lock.yaml', caches: { 'pnpm-store': '~/.local/share/pnpm/store' } },
+  bun: { lockFile: 'bun.lockb', caches: { 'bun-install': '~/.bun/install/cache' } },
+  poetry: { lockFile: 'poetry.lock', caches: { poetry: '~/.cache/pypoetry' } },
+  pipenv: { lockFile: 'Pipfile.lock', caches: { pipenv: '~/.cache/pipenv' } },
+  go: { lockFile: 'go.sum', caches: { 'go-modules': '/go/pkg/mod' } },
+  cargo: { lockFile: 'Cargo.lock', caches: { 'cargo-registry': '~/.cargo/registry', 'cargo-git': '~/.cargo/git', 'cargo-target': 'target' } }
+};
+
+/**
+ * Commands that need the Docker daemon Bitbucket provides as the `docker` service
+ */
+const DOCKER_COMMAND = /(^|[\s;&|(])docker\s/m;
+
+/**
+ * Per-render state shared across job conversions
+ */
+interface ConversionContext {
+  detectionResult: DetectionResult;
+  cache: { names: string[]; definitions: Record<string, any> } | undefined;
+  services: Record<string, any>;
+  usedCaches: Set<string>;
+  /** Jobs whose matrix was expanded into a step per combination */
+  matrixJobs: string[];
+  warnings: string[];
+}
+
+/**
+ * A job converted to Bitbucket steps, one per matrix combination
+ */
+interface ConvertedJob {
+  name: string;
+  needs: string[];
+  steps: any[];
+}
+
+/**
+ * Bitbucket Pipelines renderer that maps workflow templates onto sequential step groups
+ */
+export class BitbucketPipelinesRenderer implements Renderer {
+  private options: FormattingOptions;
+
+  constructor(options: FormattingOptions) {
+    this.options = options;
+  }
+
+  /**
+   * Render workflow template to the bitbucket-pipelines.yml file at the repository root
+   */
+  render(workflow: WorkflowTemplate, detectionResult: DetectionResult): RenderedFile {
+    const rendered = this.renderWorkflow(workflow, detectionResult);
+    return { path: 'bitbucket-pipelines.yml', content: rendered.yaml, warnings: rendered.warnings };
+  }
+
+  /**
+   * Render workflow template to a bitbucket-pipelines.yml string
+   */
+  renderWorkflow(workflow: WorkflowTemplate, detectionResult: DetectionResult): RenderingResult {
+    const startTime = Date.now();
+    const context: ConversionContext = {
+      detectionResult,
+      cache: this.resolveCache(detectionResult),
+      services: {},
+      usedCaches: new Set(),
+      matrixJobs: [],
+      warnings: []
+    };
+
+    try {
+      const config = this.convertToBitbucketFormat(workflow, context);
+
+      // Every trigger runs the same steps, so later pipelines refer to the first one with an alias
+      const yamlContent = dumpYaml(config, this.options, { noRefs: false })
+        .replace(/ ([&*])ref_(\d+)$/gm, (_, sigil: string, index: string) => ` ${sigil}${index === '0' ? 'steps' : `steps-${index}`}`);
+      const content = withGenerationInfo(yamlContent, workflow.name, this.options, 'pipeline');
+      return toRenderingResult(content, startTime, this.getAppliedOptimizations(config, context), context.warnings);
+    } catch (error) {
+      throw new Error(`Bitbucket Pipelines rendering failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  /**
+   * Convert workflow template to Bitbucket Pipelines format. Steps of a pipeline run one after
+   * another, so jobs are grouped by how deep they sit in the needs graph and each group with
+   * more than one step, matrix combinations included, runs as a `parallel:` group.
+   */
+  private convertToBitbucketFormat(workflow: WorkflowTemplate, context: ConversionContext): any {
+    const warnings = context.warnings;
+    const image = this.resolveImage(context.detectionResult, {});
+
+    const converted: ConvertedJob[] = [];
+    for (const job of workflow.jobs) {
+      const steps = this.convertJob(job, image, context);
+      if (steps.length > 0) {
+        converted.push({ name: job.name, needs: job.needs || [], steps });
+      } else {
+        warnings.push(`Job '${job.name}' has no Bitbucket Pipelines equivalent and was omitted`);
+      }
+    }
+
+    if (workflow.concurrency?.cancelInProgress) {
+      warnings.push('Bitbucket Pipelines does not cancel superseded pipelines; stop them from the Pipelines page instead');
+    }
+
+    const pipeline = groupByNeeds(converted)
+      .map(group => group.flatMap(job => job.steps))
+      .map(group => group.length === 1 ? { step: group[0] } : { parallel: group.map(step => ({ step })) });
+
+    const config: any = { image };
+    const definitions: any = {};
+    const caches = Object.entries(context.cache?.definitions || {}).filter(([name]) => context.usedCaches.has(name));
+    if (caches.length > 0) {
+      definitions.caches = Object.fromEntries(caches);
+    }
+    if (Object.keys(context.services).length > 0) {
+      definitions.services = context.services;
+    }
+    if (Object.keys(definitions).length > 0) {
+      config.definitions = definitions;
+    }
+    config.pipelines = this.convertTriggers(workflow.triggers, pipeline, warnings);
+
+    return config;
+  }
+
+  /**
+   * Convert a job template to Bitbucket steps, one per matrix combination; empty when nothing translates
+   */
+  private convertJob(job: JobTemplate, defaultImage: string, context: ConversionContext): any[] {
+    const combinations = job.strategy ? this.expandMatrix(job, context.warnings) : [undefined];
+    if (combinations[0]) {
+      context.matrixJobs.push(job.name);
+    }
+    const steps = combinations
+      .map(values => this.convertStep(job, values, defaultImage, context))
+      .filter(step => step !== null);
+
+    if (steps.length > 0 && job.if) {
+      context.warnings.push(`Condition '${job.if}' on job '${job.name}' has no Bitbucket Pipelines equivalent; the step always runs`);
+    }
+    if (steps.length > 0 && !job.strategy && isNonLinuxRunner(job.runsOn)) {
+      context.warnings.push(`Job '${job.name}' runs on ${job.runsOn}, but Bitbucket Cloud steps run in Linux containers`);
+    }
+    return steps;
+  }
+
+  /**
+   * Convert one run of a job, with the values of its matrix combination, to a Bitbucket step
+   */
+  private convertStep(
+    job: JobTemplate,
+    values: Record<string, string> | undefined,
+    defaultImage: string,
+    context: ConversionContext
+  ): any | null {
+    const matrix = values || {};
+    const script: string[] = [];
+    const afterScript: string[] = [];
+    const artifacts: string[] = [];
+    const jobWorkingDirectory = job.defaults?.run?.workingDirectory;
+    const installs = installsDependencies(job);
+    let directory = '.';
+    let fullClone = false;
+    let hasCommands = false;
+
+    // Bitbucket steps have no per-command environment, so variables are exported for the rest of the script
+    script.push(...this.toExports(job.env, matrix));
+
+    for (const step of job.steps) {
+      const condition = step.if ? unwrapExpression(step.if) : undefined;
+      // Matrix conditions are decided per combination; status checks map onto after-script
+      const matches = condition && !['success()', 'always()', 'failure()'].includes(condition)
+        ? evaluateMatrixCondition(condition, matrix)
+        : true;
+      if (matches === false) {
+        continue;
+      }
+
+      let commands: string[];
+      if (step.uses) {
+        const action = actionName(step);
+        if (action === 'actions/checkout') {
+          // Bitbucket clones the repository before every step, without its submodules
+          fullClone = fullClone || String(step.with?.['fetch-depth'] ?? '') === '0';
+          if (step.with?.submodules) {
+            const recursive = step.with.submodules === 'recursive' ? ' --recursive' : '';
+            script.push(`git submodule sync${recursive} && git submodule update --init${recursive}`);
+          }
+          continue;
+        }
+        if (action === 'actions/upload-artifact') {
+          artifacts.push(...toArtifactGlobs(String(step.with?.path ?? '')));
+          continue;
+        }
+        commands = this.translateAction(step, matrix, context);
+      } else if (step.run && step.id !== CACHE_DATE_STEP_ID) {
+        // The date only feeds the actions/cache key, which this provider replaces with its own cache
+        commands = [this.translateRunStep(step, matrix)];
+      } else {
+        continue;
+      }
+      if (commands.length === 0) {
+        continue;
+      }
+      if (matches === undefined) {
+        context.warnings.push(`Condition '${step.if}' on step '${step.name}' has no Bitbucket Pipelines equivalent; the step always runs`);
+      }
+
+      const target = step.workingDirectory || jobWorkingDirectory || '.';
+      const lines = [...this.toExports(step.env, matrix), ...commands];
+      if (condition === 'always()' || condition === 'failure()') {
+        const after = condition === 'failure()'
+          ? lines.map(line => `if [ "$BITBUCKET_EXIT_CODE" -ne 0 ]; then ${line}; fi`)
+          : lines;
+        afterScript.push(...(target !== '.' ? [this.toDirectoryChange(target)] : []), ...after);
+        continue;
+      }
+
+      if (target !== directory) {
+        script.push(this.toDirectoryChange(target));
+        directory = target;
+      }
+      script.push(...lines);
+      hasCommands = true;
+    }
+
+    if (!hasCommands) {
+      return null;
+    }
+
+    // Flags such as cross-compile tell combinations apart only together with the other values
+    const label = Object.values(matrix).filter(value => value && value !== 'true' && value !== 'false').join(', ');
+    const converted: any = { name: label ? `${job.name} (${label})` : job.name };
+
+    const image = this.resolveImage(context.detectionResult, matrix);
+    if (image !== defaultImage) {
+      converted.image = image;
+    }
+    if (fullClone) {
+      converted.clone = { depth: 'full' };
+    }
+    if (job.timeout) {
+      converted['max-time'] = job.timeout;
+    }
+
+    if (installs && context.cache) {
+      converted.caches = [...context.cache.names];
+      context.cache.names.forEach(name => context.usedCaches.add(name));
+    }
+
+    const services = this.convertServices(job.services, context);
+    if (DOCKER_COMMAND.test(script.join('\n'))) {
+      services.push('docker');
+    }
+    if (services.length > 0) {
+      converted.services = services;
+    }
+
+    converted.script = script;
+    if (afterScript.length > 0) {
+      converted['after-script'] = afterScript;
+    }
+    if (artifacts.length > 0) {
+      converted.artifacts = artifacts;
+    }
+
+    return converted;
+  }
+
+  /**
+   * Translate a GitHub Action step into script commands
+   */
+  private translateAction(step: StepTemplate, matrix: Record<string, string>, context: ConversionContext): string[] {
+    const action = actionName(step);
+
+    // Language setup comes from the step image; caching is emitted from the lockfile
+    if (action === 'actions/download-artifact' || isImageSetupAction(action)) {
+      return [];
+    }
+
+    const commands = translateSetupAction(step, value => this.translateExpression(value, matrix));
+    if (commands) {
+      return commands;
+    }
+
+    context.warnings.push(`Step '${step.name}' uses ${step.uses}, which has no Bitbucket Pipelines equivalent; skipped`);
+    return [];
+  }
+
+  /**
+   * Translate a run step into a script command
+   */
+  private translateRunStep(step: StepTemplate, matrix: Record<string, string>): string {
+    let command = this.translateExpression(step.run || '', matrix);
+
+    if (step.retries) {
+      command = withRetries(command, step.retries);
+    }
+
+    if (step.continueOnError) {
+      command = `${command} || true`;
+    }
+
+    return command;
+  }
+
+  /**
+   * Translate GitHub expressions embedded in a string to matrix values and Bitbucket variables
+   */
+  private translateExpression(value: string, matrix: Record<string, string>): string {
+    return translateExpression(value, {
+      matrix: key => matrix[key],
+      secret: name => `$${name}`,
+      sha: '$BITBUCKET_COMMIT',
+      refName: '${BITBUCKET_BRANCH:-$BITBUCKET_TAG}',
+      os: 'Linux'
+    });
+  }
+
+  /**
+   * Export lines for job or step variables
+   */
+  private toExports(env: Record<string, string> | undefined, matrix: Record<string, string>): string[] {
+    return Object.entries(env || {}).map(([name, value]) =>
+      `export ${name}="${this.translateExpression(String(value), matrix).replace(/["\\]/g, '\\$&')}"`);
+  }
+
+  /**
+   * Change to a repository-relative directory; each script line runs in the directory the previous one left
+   */
+  private toDirectoryChange(directory: string): string {
+    return directory === '.' ? 'cd "$BITBUCKET_CLONE_DIR"' : `cd "$BITBUCKET_CLONE_DIR/${directory.replace(/^\.\/|\/+$/g, '')}"`;
+  }
+
+  /**
+   * Expand a matrix strategy into the values of each combination. Bitbucket has no matrix, so
+   * every combination becomes its own step; combinations on hosted macOS or Windows runners are
+   * left out, since Bitbucket Cloud steps run in Linux containers, unless they cross-compile.
+   */
+  private expandMatrix(job: JobTemplate, warnings: string[]): Array<Record<string, string> | undefined> {
+    const strategy = job.strategy as MatrixStrategy;
+    if (matrixAxes(strategy).length === 0 && matrixInclude(strategy).length === 0) {
+      return [undefined];
+    }
+
+    const expanded: Record<string, string>[] = [];
+    for (const combination of expandMatrix(strategy)) {
+      const { runner, ...values } = combination;
+      if (typeof runner === 'string' && isNonLinuxRunner(runner)) {
+        if (!('cross-compile' in values)) {
+          warnings.push(`Job '${job.name}' combinations on ${runner} were left out - Bitbucket Cloud steps run in Linux containers`);
+          continue;
+        }
+        values['cross-compile'] = true;
+      }
+      expanded.push(Object.fromEntries(Object.entries(values).map(([key, value]) => [key, String(value)])));
+    }
+
+    return expanded;
+  }
+
+  /**
+   * Convert workflow triggers to pipelines: branch pipelines for the pushed branches (the default
+   * pipeline when every branch builds), pull request and tag pipelines
+   */
+  private convertTriggers(triggers: TriggerConfig | undefined, pipeline: any[], warnings: string[]): any {
+    const pipelines: any = {};
+    const branches = triggers?.push?.branches || [];
+
+    if (!triggers || (triggers.push && branches.length === 0)) {
+      pipelines.default = pipeline;
+    } else if (branches.length > 0) {
+      pipelines.branches = { [this.toGlobKey(branches)]: pipeline };
+    }
+
+    if (triggers?.pullRequest) {
+      // Pull request pipelines match the source branch, so every pull request builds
+      pipelines['pull-requests'] = { '**': pipeline };
+    }
+
+    if (triggers?.push?.tags) {
+      const tags = triggers.push.tags.length > 0 ? triggers.push.tags : ['*'];
+      pipelines.tags = { [this.toGlobKey(tags)]: pipeline };
+    }
+
+    if (triggers?.schedule && triggers.schedule.length > 0) {
+      pipelines.custom = { scheduled: pipeline };
+      warnings.push(`Schedules (${triggers.schedule.map(s => s.cron).join(', ')}) must be set up for the 'scheduled' custom pipeline in the Bitbucket repository settings`);
+    } else if (triggers?.workflowDispatch) {
+      pipelines.custom = { manual: pipeline };
+    }
+
+    if (Object.keys(pipelines).length === 0) {
+      pipelines.default = pipeline;
+    }
+
+    return pipelines;
+  }
+
+  /**
+   * Combine branch or tag globs into one pipeline key; Bitbucket globs support `{a,b}` alternatives
+   */
+  private toGlobKey(patterns: string[]): string {
+    return patterns.length === 1 ? patterns[0]! : `{${patterns.join(',')}}`;
+  }
+
+  /**
+   * Resolve the step image from the language version, or the combination's version value
+   */
+  private resolveImage(detectionResult: DetectionResult, matrix: Record<string, string>): string {
+    return resolveLanguageImage(detectionResult, versionInput => matrix[versionInput]) || DEFAULT_IMAGE;
+  }
+
+  /**
+   * Define job services once for the whole file and return the names the step refers to
+   */
+  private convertServices(services: Record<string, any> | undefined, context: ConversionContext): string[] {
+    const names: string[] = [];
+    for (const [name, service] of Object.entries(services || {})) {
+      if (!service || typeof service.image !== 'string') {
+        continue;
+      }
+      const key = sanitizeJobName(name);
+      context.services[key] = context.services[key] || {
+        image: service.image,
+        ...(service.env && { variables: service.env })
+      };
+      names.push(key);
+    }
+    return names;
+  }
+
+  /**
+   * Resolve the caches for the detected package manager: the predefined one when Bitbucket has it,
+   * else custom caches keyed on the lockfile
+   */
+  private resolveCache(detectionResult: DetectionResult): ConversionContext['cache'] {
+    const tool = resolveDependencyTool(detectionResult);
+    if (!tool) {
+      return undefined;
+    }
+
+    const builtin = BUILTIN_CACHES[tool];
+    if (builtin) {
+      return { names: [builtin], definitions: {} };
+    }
+
+    const config = CUSTOM_CACHES[tool];
+    if (!config) {
+      return undefined;
+    }
+
+    const detected = detectionResult.packageManagers.find(pm => pm.name.toLowerCase() === tool);
+    const lockFile = detected?.lockFile || config.lockFile;
+    return {
+      names: Object.keys(config.caches),
+      definitions: Object.fromEntries(Object.entries(config.caches).map(([name, path]) =>
+        [name, { key: { files: [lockFile] }, path }]))
+    };
+  }
+
+  /**
+   * Get applied optimizations for metadata
+   */
+  private getAppliedOptimizations(config: any, context: ConversionContext): string[] {
+    const optimizations: string[] = [];
+    const pipelines = Object.values(config.pipelines || {}) as any[];
+    const pipeline: any[] = Array.isArray(pipelines[0]) ? pipelines[0] : Object.values(pipelines[0] || {})[0] as any[] || [];
+    const steps = pipeline.flatMap(entry => entry.parallel ? entry.parallel.map((parallel: any) => parallel.step) : [entry.step]);
+
+    if (steps.some(step => step.caches)) {
+      optimizations.push('dependency-caching');
+    }
+
+    if (context.matrixJobs.length > 0) {
+      optimizations.push('matrix-builds');
+    }
+
+    if (pipeline.some(entry => entry.parallel)) {
+      optimizations.push('parallel-execution');
+    }
+
+    return optimizations;
+  }
+}
@@ -0,0 +1,574 @@
+/**
+ * CircleCI Renderer for converting workflow templates to .circleci/config.yml
+ */
+
+import { FormattingOptions, RenderingResult, Renderer, RenderedFile, RenderOptions } from './renderer-types';
+import {
+  LANGUAGE_IMAGES,
+  actionName,
+  dumpYaml,
+  expandMatrix,
+  installsDependencies,
+  isImageSetupAction,
+  matrixAxes,
+  matrixExclude,
+  matrixInclude,
+  resolveDependencyTool,
+  resolveLanguageImage,
+  sanitizeJobName,
+  toArtifactPaths,
+  toParameterName,
+  toRenderingResult,
+  translateExpression,
+  translateSetupAction,
+  translateShellGuard,
+  unwrapExpression,
+  withGenerationInfo
+} from './provider-translation';
+import { WorkflowTemplate, JobTemplate, StepTemplate, TriggerConfig, MatrixStrategy } from '../types';
+import { DetectionResult } from '../interfaces';
+import { CACHE_DATE_STEP_ID } from '../utils/cache-utils';
+import { withRetries } from '../utils/retry';
+
+/**
+ * CircleCI configuration version; 2.1 is required for parameters, matrices and orbs
+ */
+const CONFIG_VERSION = 2.1;
+
+/**
+ * Executor image used when the primary language has no official image
+ */
+const DEFAULT_IMAGE = 'cimg/base:stable';
+
+/**
+ * Cache configuration per package manager: the lockfile the key is checksummed on and the paths to save.
+ * The official images run as root, so home-relative paths resolve under /root.
+ */
+const CACHE_CONFIGS: Record<string, { lockFile: string; paths: string[] }> = {
+  npm: { lockFile: 'package-lock.json', paths: ['~/.npm'] },
+  yarn: { lockFile: 'yarn.lock', paths: ['~/.cache/yarn'] },
+  pnpm: { lockFile: 'pnpm-lock.yaml', paths: ['~/.local/share/pnpm/store'] },
+  bun: { lockFile: 'bun.lockb', paths: ['~/.bun/install/cache'] },
+  pip: { lockFile: 'requirements.txt', paths: ['~/.cache/pip'] },
+  poetry: { lockFile: 'poetry.lock', paths: ['~/.cache/pypoetry'] },
+  pipenv: { lockFile: 'Pipfile.lock', paths: ['~/.cache/pipenv'] },
+  go: { lockFile: 'go.sum', paths: ['/go/pkg/mod'] },
+  cargo: { lockFile: 'Cargo.lock', paths: ['~/.cargo/registry', '~/.cargo/git', 'target'] },
+  maven: { lockFile: 'pom.xml', paths: ['~/.m2/repository'] },
+  gradle: { lockFile: 'build.gradle', paths: ['~/.gradle/caches'] }
+};
+
+/**
+ * Orbs that replace dependency installation and caching, with the package managers they support
+ */
+const INSTALL_ORBS: Record<string, { alias: string; orb: string; packageManagers: string[] }> = {
+  node: { alias: 'node', orb: 'circleci/node@5', packageManagers: ['npm', 'yarn', 'pnpm'] },
+  python: { alias: 'python', orb: 'circleci/python@2', packageManagers: ['pip', 'poetry', 'pipenv'] }
+};
+
+/**
+ * Per-render state shared across job conversions
+ */
+interface ConversionContext {
+  detectionResult: DetectionResult;
+  cache: { key: string; paths: string[] } | undefined;
+  orb: { alias: string; orb: string; packageManager: string } | undefined;
+  usedOrbs: Record<string, string>;
+  warnings: string[];
+}
+
+/**
+ * Matrix axes translated to CircleCI job parameters
+ */
+interface ConvertedMatrix {
+  parameters: Record<string, string>;
+  /** Parameters of the matrix axes, which name explicit parameter sets */
+  axes: string[];
+  /** Parameter product for `matrix:`, when the axes combine freely */
+  matrix?: { parameters: Record<string, string[]>; exclude?: Record<string, string>[] };
+  /** Explicit parameter sets, when include entries add per-combination values */
+  combinations?: Record<string, string>[];
+}
+
+/**
+ * CircleCI renderer that maps workflow templates onto jobs and a workflow
+ */
+export class CircleCIRenderer implements Renderer {
+  private options: FormattingOptions;
+
+  constructor(options: FormattingOptions) {
+    this.options = options;
+  }
+
+  /**
+   * Render workflow template to the config.yml file CircleCI reads from .circleci
+   */
+  render(workflow: WorkflowTemplate, detectionResult: DetectionResult, options: RenderOptions = {}): RenderedFile {
+    const rendered = this.renderWorkflow(workflow, detectionResult, options.useOrbs);
+    return { path: 'config.yml', content: rendered.yaml, warnings: rendered.warnings };
+  }
+
+  /**
+   * Render workflow template to a .circleci/config.yml string.
+   * Orbs are only referenced when `useOrbs` is set, since some organizations disallow third-party orbs.
+   */
+  renderWorkflow(workflow: WorkflowTemplate, detectionResult: DetectionResult, useOrbs = false): RenderingResult {
+    const startTime = Date.now();
+    const warnings: string[] = [];
+
+    try {
+      const config = this.convertToCircleCIFormat(workflow, detectionResult, useOrbs, warnings);
+      const content = withGenerationInfo(dumpYaml(config, this.options), workflow.name, this.options, 'configuration');
+      return toRenderingResult(content, startTime, this.getAppliedOptimizations(config), warnings);
+    } catch (error) {
+      throw new Error(`CircleCI rendering failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  /**
+   * Convert workflow template to CircleCI format
+   */
+  private convertToCircleCIFormat(
+    workflow: WorkflowTemplate,
+    detectionResult: DetectionResult,
+    useOrbs: boolean,
+    warnings: string[]
+  ): any {
+    const context: ConversionContext = {
+      detectionResult,
+      cache: this.resolveCache(detectionResult),
+      orb: useOrbs ? this.resolveInstallOrb(detectionResult) : undefined,
+      usedOrbs: {},
+      warnings
+    };
+
+    const jobs: Record<string, any> = {};
+    const matrices: Record<string, ConvertedMatrix | undefined> = {};
+    for (const job of workflow.jobs) {
+      const name = sanitizeJobName(job.name);
+      const matrix = job.strategy ? this.convertMatrix(job.strategy) : undefined;
+      const converted = this.convertJob(job, matrix, context);
+      if (converted) {
+        jobs[name] = converted;
+        matrices[name] = matrix;
+      } else {
+        warnings.push(`Job '${job.name}' has no CircleCI equivalent and was omitted`);
+      }
+    }
+
+    const filters = this.convertTriggers(workflow.triggers, warnings);
+    if (workflow.concurrency?.cancelInProgress) {
+      warnings.push('CircleCI cancels superseded pipelines only with "Auto-cancel redundant workflows" enabled in the project settings');
+    }
+    const invocations: any[] = [];
+    // Jobs expanded into explicit parameter sets are required by each generated name
+    const invocationNames: Record<string, string[]> = {};
+
+    for (const job of workflow.jobs) {
+      const name = sanitizeJobName(job.name);
+      if (!(name in jobs)) {
+        continue;
+      }
+
+      if (job.if) {
+        warnings.push(`Condition '${job.if}' on job '${job.name}' has no CircleCI equivalent; the job always runs`);
+      }
+
+      const requires = (job.needs || [])
+        .map(need => sanitizeJobName(need))
+        .filter(need => need in jobs)
+        .flatMap(need => invocationNames[need] || [need]);
+
+      const settings: any = {};
+      if (requires.length > 0) {
+        settings.requires = requires;
+      }
+      if (filters) {
+        settings.filters = filters;
+      }
+
+      const matrix = matrices[name];
+      if (matrix?.combinations) {
+        invocationNames[name] = matrix.combinations.map(combination =>
+          sanitizeJobName([name, ...matrix.axes.map(axis => combination[axis]).filter(Boolean)].join('-')));
+        matrix.combinations.forEach((combination, i) => {
+          invocations.push({ [name]: { name: invocationNames[name]![i], ...combination, ...settings } });
+        });
+        continue;
+      }
+
+      if (matrix?.matrix) {
+        settings.matrix = matrix.matrix;
+      }
+
+      invocations.push(Object.keys(settings).length > 0 ? { [name]: settings } : name);
+    }
+
+    const config: any = { version: CONFIG_VERSION };
+    if (Object.keys(context.usedOrbs).length > 0) {
+      config.orbs = context.usedOrbs;
+    }
+    config.jobs = jobs;
+    config.workflows = {
+      [sanitizeJobName(workflow.type || 'ci')]: { jobs: invocations }
+    };
+
+    return config;
+  }
+
+  /**
+   * Convert job template to a CircleCI job, or null when nothing translates
+   */
+  private convertJob(job: JobTemplate, matrix: ConvertedMatrix | undefined, context: ConversionContext): any | null {
+    const parameters = matrix?.parameters || {};
+    const steps: any[] = [];
+    const workingDirectory = job.defaults?.run?.workingDirectory;
+    const installs = installsDependencies(job);
+    const cache = installs && !context.orb ? context.cache : undefined;
+    let hasCommands = false;
+    let savedCache = false;
+
+    for (const step of job.steps) {
+      if (step.uses) {
+        const translated = this.translateAction(step, parameters, context);
+        steps.push(...translated);
+        hasCommands = hasCommands || (!step.uses.startsWith('actions/checkout') && translated.some(entry => !entry.store_artifacts));
+
+        if (cache && step.uses.startsWith('actions/checkout')) {
+          steps.push({ restore_cache: { keys: [cache.key, cache.key.replace(/\{\{.*\}\}$/, '')] } });
+        }
+        continue;
+      }
+
+      // The date only feeds the actions/cache key, which this provider replaces with its own cache
+      if (!step.run || step.id === CACHE_DATE_STEP_ID) {
+        continue;
+      }
+
+      if (context.orb && installs && step.name === 'Install dependencies') {
+        const command = `${context.orb.alias}/install-packages`;
+        context.usedOrbs[context.orb.alias] = context.orb.orb;
+        steps.push({ [command]: { 'pkg-manager': context.orb.packageManager } });
+        hasCommands = true;
+        continue;
+      }
+
+      steps.push({ run: this.translateRunStep(step, workingDirectory, parameters, context.warnings) });
+      hasCommands = true;
+
+      if (cache && !savedCache && step.name === 'Install dependencies') {
+        steps.push({ save_cache: { key: cache.key, paths: cache.paths } });
+        savedCache = true;
+      }
+    }
+
+    if (!hasCommands) {
+      return null;
+    }
+
+    if (cache && !savedCache) {
+      steps.push({ save_cache: { key: cache.key, paths: cache.paths } });
+    }
+
+    const converted: any = {};
+
+    if (Object.keys(parameters).length > 0) {
+      const always = matrix?.combinations
+        ? (value: string) => matrix.combinations!.every(combination => value in combination)
+        : () => true;
+      converted.parameters = Object.fromEntries(Object.values(parameters).map(parameter => [
+        parameter,
+        always(parameter) ? { type: 'string' } : { type: 'string', default: '' }
+      ]));
+    }
+
+    converted.docker = [
+      { image: this.resolveImage(context.detectionResult, parameters) },
+      ...this.convertServices(job.services)
+    ];
+
+    if (job.env && Object.keys(job.env).length > 0) {
+      converted.environment = Object.fromEntries(Object.entries(job.env).map(([name, value]) =>
+        [name, this.translateExpression(String(value), parameters)]));
+    }
+
+    converted.steps = steps;
+
+    return converted;
+  }
+
+  /**
+   * Translate a GitHub Action step into CircleCI steps
+   */
+  private translateAction(step: StepTemplate, parameters: Record<string, string>, context: ConversionContext): any[] {
+    const action = actionName(step);
+
+    // CircleCI's checkout fetches the whole history but no submodules
+    if (action === 'actions/checkout') {
+      const recursive = step.with?.submodules === 'recursive' ? ' --recursive' : '';
+      return step.with?.submodules
+        ? ['checkout', { run: { name: 'Checkout submodules', command: `git submodule sync${recursive} && git submodule update --init${recursive}` } }]
+        : ['checkout'];
+    }
+
+    // Language setup comes from the executor image; caching is emitted from the lockfile
+    if (action === 'actions/download-artifact' || isImageSetupAction(action)) {
+      return [];
+    }
+
+    const commands = translateSetupAction(step, value => this.translateExpression(value, parameters));
+    if (commands) {
+      return commands.length > 0 ? [{ run: { name: step.name, command: commands.join('\n') } }] : [];
+    }
+
+    if (action === 'actions/upload-artifact') {
+      const name = step.with?.name ? this.translateExpression(String(step.with.name), parameters) : undefined;
+      return toArtifactPaths(String(step.with?.path ?? ''))
+        .map(path => ({ store_artifacts: { path, ...(name && { destination: `${name}/${path.replace(/\/+$/, '')}` }) } }));
+    }
+
+    context.warnings.push(`Step '${step.name}' uses ${step.uses}, which has no CircleCI equivalent; skipped`);
+    return [];
+  }
+
+  /**
+   * Translate a run step into a CircleCI `run` entry
+   */
+  private translateRunStep(
+    step: StepTemplate,
+    jobWorkingDirectory: string | undefined,
+    parameters: Record<string, string>,
+    warnings: string[]
+  ): any {
+    let command = this.translateExpression(step.run || '', parameters);
+    const run: any = { name: step.name };
+
+    if (step.retries) {
+      command = withRetries(command, step.retries);
+    }
+
+    if (step.continueOnError) {
+      command = `${command} || true`;
+    }
+
+    const condition = step.if ? unwrapExpression(step.if) : undefined;
+    if (condition === 'always()') {
+      run.when = 'always';
+    } else if (condition === 'failure()') {
+      run.when = 'on_fail';
+    } else if (condition && condition !== 'success()') {
+      const guard = translateShellGuard(condition, key => this.toParameterReference(key, parameters));
+      if (guard) {
+        command = `if ${guard}; then ${command}; fi`;
+      } else {
+        warnings.push(`Condition '${step.if}' on step '${step.name}' has no CircleCI equivalent; the step always runs`);
+      }
+    }
+
+    run.command = command;
+
+    const workingDirectory = step.workingDirectory || jobWorkingDirectory;
+    if (workingDirectory && workingDirectory !== '.') {
+      run.working_directory = workingDirectory;
+    }
+
+    if (step.env && Object.keys(step.env).length > 0) {
+      run.environment = Object.fromEntries(Object.entries(step.env).map(([name, value]) =>
+        [name, this.translateExpression(String(value), parameters)]));
+    }
+
+    return run;
+  }
+
+  /**
+   * Reference to the job parameter carrying a matrix value
+   */
+  private toParameterReference(key: string, parameters: Record<string, string>): string | undefined {
+    const parameter = parameters[key];
+    return parameter ? `<< parameters.${parameter} >>` : undefined;
+  }
+
+  /**
+   * Translate GitHub expressions embedded in a string to CircleCI parameters and variables
+   */
+  private translateExpression(value: string, parameters: Record<string, string>): string {
+    return translateExpression(value, {
+      matrix: key => this.toParameterReference(key, parameters),
+      secret: name => `$${name}`,
+      sha: '$CIRCLE_SHA1',
+      refName: '${CIRCLE_BRANCH:-$CIRCLE_TAG}',
+      os: 'Linux'
+    });
+  }
+
+  /**
+   * Convert a matrix strategy to job parameters plus a `matrix:` product or explicit parameter sets
+   */
+  private convertMatrix(strategy: MatrixStrategy): ConvertedMatrix | undefined {
+    const axes = matrixAxes(strategy);
+    if (axes.length === 0) {
+      return undefined;
+    }
+
+    const parameters: Record<string, string> = {};
+    for (const [key] of axes) {
+      parameters[key] = toParameterName(key);
+    }
+    const axisParameters = Object.values(parameters);
+
+    if (matrixInclude(strategy).length === 0) {
+      const product: Record<string, string[]> = {};
+      for (const [key, values] of axes) {
+        product[parameters[key] as string] = values.map(value => String(value));
+      }
+      const excluded = matrixExclude(strategy).map((entry: Record<string, any>) => Object.fromEntries(
+        Object.entries(entry).map(([key, value]) => [parameters[key] || toParameterName(key), String(value)])));
+      return {
+        parameters,
+        axes: axisParameters,
+        matrix: excluded.length > 0 ? { parameters: product, exclude: excluded } : { parameters: product }
+      };
+    }
+
+    // CircleCI matrices cannot attach extra values to one combination, so list each set explicitly
+    const sets = expandMatrix(strategy).map(combination => {
+      const set: Record<string, string> = {};
+      const { runner, ...values } = combination;
+      // Docker executors are Linux, so other hosted targets are cross-compiled
+      if ('cross-compile' in values && typeof runner === 'string' && !runner.startsWith('ubuntu')) {
+        values['cross-compile'] = true;
+      }
+      for (const [key, value] of Object.entries(values)) {
+        parameters[key] = parameters[key] || toParameterName(key);
+        set[parameters[key] as string] = String(value);
+      }
+      return set;
+    });
+
+    return { parameters, axes: axisParameters, combinations: sets };
+  }
+
+  /**
+   * Convert workflow triggers to job filters, which CircleCI applies per workflow job
+   */
+  private convertTriggers(triggers: TriggerConfig | undefined, warnings: string[]): any | undefined {
+    if (!triggers) {
+      return undefined;
+    }
+
+    const filters: any = {};
+    const branches = triggers.push?.branches || [];
+
+    // Pull request branches have arbitrary names, so branch filters would stop them building
+    if (branches.length > 0 && !triggers.pullRequest) {
+      filters.branches = { only: branches.map(branch => this.toFilterPattern(branch)) };
+    } else if (branches.length > 0) {
+      warnings.push(`CircleCI builds every pushed branch; enable "Only build pull requests" in the project settings to limit builds to ${branches.join(', ')} and pull requests`);
+    }
+
+    if (triggers.push?.tags) {
+      const tags = triggers.push.tags.length > 0 ? triggers.push.tags : ['*'];
+      filters.tags = { only: tags.map(tag => this.toFilterPattern(tag)) };
+    }
+
+    if (triggers.schedule && triggers.schedule.length > 0) {
+      warnings.push(`Schedules (${triggers.schedule.map(s => s.cron).join(', ')}) must be configured as scheduled pipelines in the CircleCI project settings`);
+    }
+
+    return Object.keys(filters).length > 0 ? filters : undefined;
+  }
+
+  /**
+   * Convert a branch or tag glob to a CircleCI filter; globs become anchored regular expressions
+   */
+  private toFilterPattern(pattern: string): string {
+    if (!/[*?]/.test(pattern)) {
+      return pattern;
+    }
+    const regex = pattern
+      .replace(/[.+^${}()|[\]\\/]/g, '\\$&')
+      .replace(/\*+/g, '.*')
+      .replace(/\?/g, '.');
+    return `/^${regex}$/`;
+  }
+
+  /**
+   * Resolve the executor image from the language version, or the job's version parameter
+   */
+  private resolveImage(detectionResult: DetectionResult, parameters: Record<string, string>): string {
+    return resolveLanguageImage(detectionResult, versionInput => this.toParameterReference(versionInput, parameters)) || DEFAULT_IMAGE;
+  }
+
+  /**
+   * Convert job services to secondary Docker containers
+   */
+  private convertServices(services: Record<string, any> | undefined): any[] {
+    return Object.values(services || {})
+      .filter(service => service && typeof service.image === 'string')
+      .map(service => ({
+        image: service.image,
+        ...(service.env && { environment: service.env })
+      }));
+  }
+
+  /**
+   * Resolve the lockfile-keyed cache for the detected package manager
+   */
+  private resolveCache(detectionResult: DetectionResult): { key: string; paths: string[] } | undefined {
+    const tool = resolveDependencyTool(detectionResult);
+    const config = tool ? CACHE_CONFIGS[tool] : undefined;
+    if (!tool || !config) {
+      return undefined;
+    }
+
+    const detected = detectionResult.packageManagers.find(pm => pm.name.toLowerCase() === tool);
+    const lockFile = detected?.lockFile && tool !== 'pip' ? detected.lockFile : config.lockFile;
+
+    return {
+      key: `v1-${tool}-{{ checksum "${lockFile}" }}`,
+      paths: config.paths
+    };
+  }
+
+  /**
+   * Resolve the orb that installs dependencies, when the package manager is one it supports
+   */
+  private resolveInstallOrb(detectionResult: DetectionResult): ConversionContext['orb'] {
+    const tool = resolveDependencyTool(detectionResult);
+    const language = detectionResult.languages.find(l => l.primary);
+    const runtime = language ? LANGUAGE_IMAGES[language.name.toLowerCase()]?.runtime : undefined;
+    const orb = runtime ? INSTALL_ORBS[runtime] : undefined;
+
+    if (!tool || !orb || !orb.packageManagers.includes(tool)) {
+      return undefined;
+    }
+
+    return { alias: orb.alias, orb: orb.orb, packageManager: tool };
+  }
+
+  /**
+   * Get applied optimizations for metadata
+   */
+  private getAppliedOptimizations(config: any): string[] {
+    const optimizations: string[] = [];
+    const jobs = Object.values(config.jobs || {}) as any[];
+    const invocations = (Object.values(config.workflows || {}) as any[]).flatMap(workflow => workflow.jobs);
+    const settings = invocations
+      .filter(invocation => typeof invocation === 'object')
+      .map(invocation => Object.values(invocation)[0] as any);
+
+    if (config.orbs || jobs.some(job => job.steps.some((step: any) => step.save_cache))) {
+      optimizations.push('dependency-caching');
+    }
+
+    if (jobs.some(job => job.parameters)) {
+      optimizations.push('matrix-builds');
+    }
+
+    if (settings.some(setting => setting.requires)) {
+      optimizations.push('dag-execution');
+    }
+
+    return optimizations;
+  }
+}
@@ -0,0 +1,560 @@
+/**
+ * GitLab CI Renderer for converting workflow templates to .gitlab-ci.yml
+ */
+
+import { FormattingOptions, RenderingResult, Renderer, RenderedFile } from './renderer-types';
+import {
+  STAGE_ORDER,
+  actionName,
+  dumpYaml,
+  expandMatrix,
+  getStage,
+  isImageSetupAction,
+  matrixAxes,
+  matrixExclude,
+  matrixInclude,
+  resolveDependencyTool,
+  resolveLanguageImage,
+  sanitizeJobName,
+  toArtifactPaths,
+  toRenderingResult,
+  toVariableName,
+  translateExpression,
+  translateSetupAction,
+  translateShellGuard,
+  unwrapExpression,
+  withGenerationInfo
+} from './provider-translation';
+import { WorkflowTemplate, JobTemplate, StepTemplate, TriggerConfig, MatrixStrategy } from '../types';
+import { DetectionResult } from '../interfaces';
+import { CACHE_DATE_STEP_ID } from '../utils/cache-utils';
+import { GITLAB_MAX_RETRIES } from '../utils/retry';
+
+/**
+ * Cache configuration per package manager, keyed on its lockfile.
+ * GitLab only caches paths inside the project directory, so tool caches are redirected there.
+ */
+const CACHE_CONFIGS: Record<string, { lockFiles: string[]; paths: string[]; variables: Record<string, string> }> = {
+  npm: { lockFiles: ['package-lock.json'], paths: ['.npm/'], variables: { npm_config_cache: '$CI_PROJECT_DIR/.npm' } },
+  yarn: { lockFiles: ['yarn.lock'], paths: ['.yarn-cache/'], variables: { YARN_CACHE_FOLDER: '$CI_PROJECT_DIR/.yarn-cache' } },
+  pnpm: { lockFiles: ['pnpm-lock.yaml'], paths: ['.pnpm-store/'], variables: { npm_config_store_dir: '$CI_PROJECT_DIR/.pnpm-store' } },
+  bun: { lockFiles: ['bun.lockb'], paths: ['.bun-cache/'], variables: { BUN_INSTALL_CACHE_DIR: '$CI_PROJECT_DIR/.bun-cache' } },
+  pip: { lockFiles: ['requirements.txt'], paths: ['.cache/pip/'], variables: { PIP_CACHE_DIR: '$CI_PROJECT_DIR/.cache/pip' } },
+  poetry: { lockFiles: ['poetry.lock'], paths: ['.cache/pypoetry/'], variables: { POETRY_CACHE_DIR: '$CI_PROJECT_DIR/.cache/pypoetry' } },
+  pipenv: { lockFiles: ['Pipfile.lock'], paths: ['.cache/pipenv/'], variables: { PIPENV_CACHE_DIR: '$CI_PROJECT_DIR/.cache/pipenv' } },
+  go: { lockFiles: ['go.sum'], paths: ['.go/pkg/mod/'], variables: { GOPATH: '$CI_PROJECT_DIR/.go' } },
+  cargo: { lockFiles: ['Cargo.lock'], paths: ['.cargo/', 'target/'], variables: { CARGO_HOME: '$CI_PROJECT_DIR/.cargo' } },
+  maven: { lockFiles: ['pom.xml'], paths: ['.m2/repository/'], variables: { MAVEN_OPTS: '-Dmaven.repo.local=$CI_PROJECT_DIR/.m2/repository' } },
+  gradle: { lockFiles: ['build.gradle'], paths: ['.gradle/'], variables: { GRADLE_USER_HOME: '$CI_PROJECT_DIR/.gradle' } }
+};
+
+/**
+ * GitHub event names mapped to GitLab pipeline sources
+ */
+const PIPELINE_SOURCES: Record<string, string> = {
+  push: 'push',
+  pull_request: 'merge_request_event',
+  schedule: 'schedule',
+  workflow_dispatch: 'web'
+};
+
+/**
+ * Per-render state shared across job conversions
+ */
+interface ConversionContext {
+  detectionResult: DetectionResult;
+  defaultImage: string | undefined;
+  warnings: string[];
+  includeSAST: boolean;
+  /** Whether a newer pipeline on the same ref may cancel this one's jobs */
+  interruptible: boolean;
+}
+
+/**
+ * Matrix axes translated to GitLab variables
+ */
+interface ConvertedMatrix {
+  entries: Record<string, string | string[]>[];
+  variables: Record<string, string>;
+}
+
+/**
+ * GitLab CI renderer that maps workflow templates onto stages and jobs
+ */
+export class GitLabCIRenderer implements Renderer {
+  private options: FormattingOptions;
+
+  constructor(options: FormattingOptions) {
+    this.options = options;
+  }
+
+  /**
+   * Render workflow template to the .gitlab-ci.yml file GitLab reads from the repository root
+   */
+  render(workflow: WorkflowTemplate, detectionResult: DetectionResult): RenderedFile {
+    const rendered = this.renderWorkflow(workflow, detectionResult);
+    return { path: '.gitlab-ci.yml', content: rendered.yaml, warnings: rendered.warnings };
+  }
+
+  /**
+   * Render workflow template to a .gitlab-ci.yml string
+   */
+  renderWorkflow(workflow: WorkflowTemplate, detectionResult: DetectionResult): RenderingResult {
+    const startTime = Date.now();
+    const warnings: string[] = [];
+
+    try {
+      const pipeline = this.convertToGitLabFormat(workflow, detectionResult, warnings);
+      const content = withGenerationInfo(dumpYaml(pipeline, this.options), workflow.name, this.options, 'pipeline');
+      return toRenderingResult(content, startTime, this.getAppliedOptimizations(pipeline), warnings);
+    } catch (error) {
+      throw new Error(`GitLab CI rendering failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  /**
+   * Convert workflow template to GitLab CI format
+   */
+  private convertToGitLabFormat(
+    workflow: WorkflowTemplate,
+    detectionResult: DetectionResult,
+    warnings: string[]
+  ): any {
+    const context: ConversionContext = {
+      detectionResult,
+      defaultImage: resolveLanguageImage(detectionResult),
+      warnings,
+      includeSAST: false,
+      interruptible: Boolean(workflow.concurrency?.cancelInProgress)
+    };
+
+    const jobs: Record<string, any> = {};
+    for (const job of workflow.jobs) {
+      const converted = this.convertJob(job, context);
+      if (converted) {
+        jobs[sanitizeJobName(job.name)] = converted;
+      } else {
+        warnings.push(`Job '${job.name}' has no GitLab CI equivalent and was omitted`);
+      }
+    }
+
+    // Drop needs that point at omitted jobs
+    for (const job of Object.values(jobs)) {
+      if (job.needs) {
+        job.needs = job.needs.filter((need: string) => need in jobs);
+        if (job.needs.length === 0) {
+          delete job.needs;
+        }
+      }
+    }
+
+    const usedStages = new Set(Object.values(jobs).map(job => job.stage));
+    const pipeline: any = {
+      stages: STAGE_ORDER.filter(stage => usedStages.has(stage))
+    };
+
+    const cache = this.resolveCache(detectionResult);
+    if (cache && Object.keys(cache.variables).length > 0) {
+      pipeline.variables = cache.variables;
+    }
+
+    const defaults: any = {};
+    if (context.defaultImage) {
+      defaults.image = context.defaultImage;
+    }
+    if (cache) {
+      defaults.cache = {
+        key: { files: cache.lockFiles },
+        paths: cache.paths
+      };
+    }
+    if (Object.keys(defaults).length > 0) {
+      pipeline.default = defaults;
+    }
+
+    const rules = this.convertTriggers(workflow.triggers, warnings);
+    if (rules.length > 0) {
+      pipeline.workflow = { rules };
+    }
+
+    if (context.includeSAST) {
+      pipeline.include = [{ template: 'Security/SAST.gitlab-ci.yml' }];
+    }
+
+    return { ...pipeline, ...jobs };
+  }
+
+  /**
+   * Convert job template to a GitLab job, or null when nothing translates
+   */
+  private convertJob(job: JobTemplate, context: ConversionContext): any | null {
+    const matrix = job.strategy ? this.convertMatrix(job.strategy) : undefined;
+    const matrixVariables = matrix?.variables || {};
+    const script: string[] = [];
+    const afterScript: string[] = [];
+    const variables: Record<string, string> = Object.fromEntries(Object.entries(job.env || {}).map(([name, value]) =>
+      [name, this.translateExpression(String(value), matrixVariables)]));
+    let artifacts: any | undefined;
+
+    for (const step of job.steps) {
+      // GitLab clones before the script runs; its clone settings are variables
+      if (actionName(step) === 'actions/checkout') {
+        if (step.with?.submodules) {
+          variables.GIT_SUBMODULE_STRATEGY = step.with.submodules === 'recursive' ? 'recursive' : 'normal';
+        }
+        if (step.with?.['fetch-depth'] !== undefined) {
+          variables.GIT_DEPTH = String(step.with['fetch-depth']);
+        }
+      }
+
+      if (step.uses) {
+        const translated = this.translateAction(step, matrixVariables, context);
+        script.push(...translated.script);
+        if (translated.artifacts) {
+          artifacts = this.mergeArtifacts(artifacts, translated.artifacts);
+        }
+        continue;
+      }
+
+      // The date only feeds the actions/cache key, which this provider replaces with its own cache
+      if (!step.run || step.id === CACHE_DATE_STEP_ID) {
+        continue;
+      }
+
+      for (const [name, value] of Object.entries(step.env || {})) {
+        const translatedValue = this.translateExpression(String(value), matrixVariables);
+        // Matrix entries already expose themselves as variables of the same name
+        if (translatedValue !== `$${name}`) {
+          variables[name] = translatedValue;
+        }
+      }
+
+      const line = this.translateRunStep(step, matrixVariables, context.warnings);
+      if (step.if && /^\s*(\$\{\{\s*)?always\(\)(\s*\}\})?\s*$/.test(step.if)) {
+        afterScript.push(line);
+      } else {
+        script.push(line);
+      }
+    }
+
+    if (script.length === 0) {
+      return null;
+    }
+
+    // GitLab has no per-job working directory; every script line shares one shell
+    const workingDirectory = job.defaults?.run?.workingDirectory;
+    if (workingDirectory && workingDirectory !== '.') {
+      script.unshift(`cd ${workingDirectory}`);
+    }
+
+    const converted: any = {
+      stage: getStage(job.name)
+    };
+
+    const image = this.resolveImage(context.detectionResult, matrixVariables);
+    if (image && image !== context.defaultImage) {
+      converted.image = image;
+    }
+
+    if (job.needs && job.needs.length > 0) {
+      converted.needs = job.needs.map(need => sanitizeJobName(need));
+    }
+
+    // GitLab waits for a service's exposed port instead of running a health command
+    const services = Object.entries(job.services || {}).filter(([, service]) => service && typeof service.image === 'string');
+    if (services.length > 0) {
+      converted.services = services.map(([name, service]) => ({
+        name: service.image,
+        alias: name,
+        ...(service.env && { variables: service.env })
+      }));
+    }
+
+    const rules = job.if ? this.convertCondition(job.if, job.name, context.warnings) : [];
+    if (rules.length > 0) {
+      converted.rules = rules;
+    }
+
+    if (Object.keys(variables).length > 0) {
+      converted.variables = variables;
+    }
+
+    if (matrix) {
+      converted.parallel = { matrix: matrix.entries };
+    }
+
+    converted.script = script;
+
+    if (afterScript.length > 0) {
+      converted.after_script = afterScript;
+    }
+
+    if (artifacts) {
+      converted.artifacts = artifacts;
+    }
+
+    if (job.timeout) {
+      converted.timeout = `${job.timeout} minutes`;
+    }
+
+    // GitLab only retries whole jobs, at most twice
+    const retries = Math.min(Math.max(0, ...job.steps.map(step => step.retries || 0)), GITLAB_MAX_RETRIES);
+    if (retries > 0) {
+      converted.retry = retries;
+    }
+
+    if (job.continueOnError) {
+      converted.allow_failure = true;
+    }
+
+    // GitLab stops auto-cancelling a pipeline once a non-interruptible job starts, which keeps deployments whole
+    if (context.interruptible && !job.environment) {
+      converted.interruptible = true;
+    }
+
+    return converted;
+  }
+
+  /**
+   * Translate a GitHub Action step into script lines or artifacts
+   */
+  private translateAction(
+    step: StepTemplate,
+    matrixVariables: Record<string, string>,
+    context: ConversionContext
+  ): { script: string[]; artifacts?: any } {
+    const action = actionName(step);
+
+    // Checkout, language setup and caching are handled by GitLab itself, the image and cache:
+    if (action === 'actions/checkout' || action === 'actions/download-artifact' || isImageSetupAction(action)) {
+      return { script: [] };
+    }
+
+    const commands = translateSetupAction(step, value => this.translateExpression(value, matrixVariables));
+    if (commands) {
+      return { script: commands };
+    }
+
+    if (action === 'actions/upload-artifact') {
+      const artifacts: any = {
+        paths: toArtifactPaths(String(step.with?.path ?? ''))
+      };
+      if (step.with?.name) {
+        artifacts.name = this.translateExpression(String(step.with.name), matrixVariables);
+      }
+      if (step.with?.['retention-days']) {
+        artifacts.expire_in = `${step.with['retention-days']} days`;
+      }
+      if (step.if && step.if.includes('always()')) {
+        artifacts.when = 'always';
+      }
+      return { script: [], artifacts };
+    }
+
+    if (action.startsWith('github/codeql-action') || action === 'github/dependency-review-action') {
+      context.includeSAST = true;
+      return { script: [] };
+    }
+
+    context.warnings.push(`Step '${step.name}' uses ${step.uses}, which has no GitLab CI equivalent; skipped`);
+    return { script: [] };
+  }
+
+  /**
+   * Translate a run step into a single script entry
+   */
+  private translateRunStep(
+    step: StepTemplate,
+    matrixVariables: Record<string, string>,
+    warnings: string[]
+  ): string {
+    let command = this.translateExpression(step.run || '', matrixVariables);
+
+    if (step.workingDirectory) {
+      command = `(cd ${step.workingDirectory} && ${command})`;
+    }
+
+    if (step.continueOnError) {
+      command = `${command} || true`;
+    }
+
+    if (step.if && !/^\s*(\$\{\{\s*)?(success|always)\(\)(\s*\}\})?\s*$/.test(step.if)) {
+      const guard = translateShellGuard(step.if, key => this.toMatrixReference(key, matrixVariables));
+      if (guard) {
+        command = `if ${guard}; then ${command}; fi`;
+      } else {
+        warnings.push(`Condition '${step.if}' on step '${step.name}' has no GitLab CI equivalent; the step always runs`);
+      }
+    }
+
+    return command;
+  }
+
+  /**
+   * Reference to the variable carrying a matrix value
+   */
+  private toMatrixReference(key: string, matrixVariables: Record<string, string>): string | undefined {
+    const variable = matrixVariables[key];
+    return variable ? `$${variable}` : undefined;
+  }
+
+  /**
+   * Translate GitHub expressions embedded in a string to GitLab variables
+   */
+  private translateExpression(value: string, matrixVariables: Record<string, string>): string {
+    return translateExpression(value, {
+      matrix: key => this.toMatrixReference(key, matrixVariables),
+      secret: name => `$${name}`,
+      sha: '$CI_COMMIT_SHA',
+      refName: '$CI_COMMIT_REF_NAME',
+      os: 'Linux'
+    });
+  }
+
+  /**
+   * Convert a matrix strategy to `parallel: matrix` entries
+   */
+  private convertMatrix(strategy: MatrixStrategy): ConvertedMatrix | undefined {
+    const axes = matrixAxes(strategy);
+    if (axes.length === 0) {
+      return undefined;
+    }
+
+    const variables: Record<string, string> = {};
+    for (const [key] of axes) {
+      variables[key] = toVariableName(key);
+    }
+
+    if (matrixInclude(strategy).length === 0 && matrixExclude(strategy).length === 0) {
+      const entry: Record<string, string[]> = {};
+      for (const [key, values] of axes) {
+        entry[variables[key] as string] = values.map(value => String(value));
+      }
+      return { entries: [entry], variables };
+    }
+
+    // Explicit combinations: expand the full product, drop exclusions and merge include extras
+    const entries = expandMatrix(strategy).map(combination => {
+      const entry: Record<string, string> = {};
+      const { runner, ...values } = combination;
+      // GitLab jobs run on the Linux runner image, so other hosted targets are cross-compiled
+      if ('cross-compile' in values && typeof runner === 'string' && !runner.startsWith('ubuntu')) {
+        values['cross-compile'] = true;
+      }
+      for (const [key, value] of Object.entries(values)) {
+        variables[key] = variables[key] || toVariableName(key);
+        entry[variables[key] as string] = String(value);
+      }
+      return entry;
+    });
+
+    return { entries, variables };
+  }
+
+  /**
+   * Convert workflow triggers to `workflow: rules`
+   */
+  private convertTriggers(triggers: TriggerConfig | undefined, warnings: string[]): any[] {
+    const rules: any[] = [];
+    if (!triggers) {
+      return rules;
+    }
+
+    if (triggers.pullRequest) {
+      rules.push({ if: '$CI_PIPELINE_SOURCE == "merge_request_event"' });
+    }
+
+    if (triggers.push?.branches && triggers.push.branches.length > 0) {
+      const pattern = triggers.push.branches
+        .map(branch => branch.replace(/[.+?^${}()|[\]\\/]/g, '\\$&').replace(/\*+/g, '.*'))
+        .join('|');
+      rules.push({ if: `$CI_COMMIT_BRANCH =~ /^(${pattern})$/` });
+    } else if (triggers.push && !triggers.push.tags) {
+      rules.push({ if: '$CI_COMMIT_BRANCH' });
+    }
+
+    if (triggers.push?.tags) {
+      rules.push({ if: '$CI_COMMIT_TAG' });
+    }
+
+    if (triggers.schedule && triggers.schedule.length > 0) {
+      rules.push({ if: '$CI_PIPELINE_SOURCE == "schedule"' });
+      warnings.push(`Schedules (${triggers.schedule.map(s => s.cron).join(', ')}) must be configured under CI/CD > Schedules in GitLab`);
+    }
+
+    if (triggers.workflowDispatch) {
+      rules.push({ if: '$CI_PIPELINE_SOURCE == "web"' });
+    }
+
+    return rules;
+  }
+
+  /**
+   * Convert a job-level `if` to GitLab rules
+   */
+  private convertCondition(condition: string, jobName: string, warnings: string[]): any[] {
+    const expression = unwrapExpression(condition);
+    const match = expression.match(/^github\.event_name\s*(==|!=)\s*'([\w-]+)'$/);
+    const source = match ? PIPELINE_SOURCES[match[2] ?? ''] : undefined;
+
+    if (!match || !source) {
+      warnings.push(`Condition '${condition}' on job '${jobName}' has no GitLab CI equivalent; the job always runs`);
+      return [];
+    }
+
+    return match[1] === '=='
+      ? [{ if: `$CI_PIPELINE_SOURCE == "${source}"` }]
+      : [{ if: `$CI_PIPELINE_SOURCE == "${source}"`, when: 'never' }, { when: 'on_success' }];
+  }
+
+  /**
+   * Resolve the container image from the language version (or the job's version matrix)
+   */
+  private resolveImage(detectionResult: DetectionResult, matrixVariables: Record<string, string>): string | undefined {
+    return resolveLanguageImage(detectionResult, versionInput => this.toMatrixReference(versionInput, matrixVariables));
+  }
+
+  /**
+   * Resolve lockfile-keyed cache configuration for the detected package manager
+   */
+  private resolveCache(detectionResult: DetectionResult): { lockFiles: string[]; paths: string[]; variables: Record<string, string> } | undefined {
+    const tool = resolveDependencyTool(detectionResult);
+    return tool ? CACHE_CONFIGS[tool] : undefined;
+  }
+
+  /**
+   * Merge artifacts from multiple upload steps in one job
+   */
+  private mergeArtifacts(existing: any | undefined, next: any): any {
+    if (!existing) {
+      return next;
+    }
+    return {
+      ...existing,
+      ...next,
+      paths: [...existing.paths, ...next.paths]
+    };
+  }
+
+  /**
+   * Get applied optimizations for metadata
+   */
+  private getAppliedOptimizations(pipeline: any): string[] {
+    const optimizations: string[] = [];
+    const jobs = Object.values(pipeline).filter((value: any) => value && typeof value === 'object' && 'script' in value) as any[];
+
+    if (pipeline.default?.cache) {
+      optimizations.push('dependency-caching');
+    }
+
+    if (jobs.some(job => job.parallel?.matrix)) {
+      optimizations.push('matrix-builds');
+    }
+
+    if (jobs.some(job => job.needs)) {
+      optimizations.push('dag-execution');
+    }
+
+    return optimizations;
+  }
+}
@@ -1,3 +1,8 @@
 // YAML rendering exports
 export * from './yaml-renderer';
-export * from './renderer-types';
\ No newline at end of file
+export * from './renderer-types';
+export * from './gitlab-renderer';
+export * from './circleci-renderer';
+export * from './azure-pipelines-renderer';
+export * from './bitbucket-pipelines-renderer';
+export * from './jenkins-renderer';
@@ -0,0 +1,760 @@
+/**
+ * Jenkins Renderer for converting workflow templates to a declarative Jenkinsfile
+ */
+
+import { FormattingOptions, RenderingResult, Renderer, RenderedFile } from './renderer-types';
+import {
+  actionName,
+  expandMatrix,
+  groupByNeeds,
+  installsDependencies,
+  isImageSetupAction,
+  isNonLinuxRunner,
+  matrixAxes,
+  matrixExclude,
+  matrixInclude,
+  resolveDependencyTool,
+  resolveLanguageImage,
+  toArtifactGlobs,
+  toRenderingResult,
+  toVariableName,
+  translateSetupAction,
+  unwrapExpression,
+  withGenerationInfo
+} from './provider-translation';
+import { WorkflowTemplate, JobTemplate, StepTemplate, TriggerConfig, MatrixStrategy } from '../types';
+import { DetectionResult } from '../interfaces';
+import { CACHE_DATE_STEP_ID } from '../utils/cache-utils';
+
+/**
+ * Image used when the primary language has no official image
+ */
+const DEFAULT_IMAGE = 'buildpack-deps:bookworm';
+
+/**
+ * Variables pointing each package manager's downloads into the workspace, which Jenkins keeps on
+ * the agent between builds. Containers of a docker agent run as the Jenkins user, which cannot
+ * write to the home directory of the official images, and are removed after every stage.
+ */
+const WORKSPACE_CACHES: Record<string, Record<string, string>> = {
+  npm: { npm_config_cache: '${env.WORKSPACE}/.cache/npm' },
+  yarn: { YARN_CACHE_FOLDER: '${env.WORKSPACE}/.cache/yarn' },
+  pnpm: { npm_config_store_dir: '${env.WORKSPACE}/.cache/pnpm-store' },
+  bun: { BUN_INSTALL_CACHE_DIR: '${env.WORKSPACE}/.cache/bun' },
+  pip: { PIP_CACHE_DIR: '${env.WORKSPACE}/.cache/pip' },
+  poetry: { POETRY_CACHE_DIR: '${env.WORKSPACE}/.cache/pypoetry' },
+  pipenv: { PIPENV_CACHE_DIR: '${env.WORKSPACE}/.cache/pipenv' },
+  maven: { MAVEN_OPTS: '-Dmaven.repo.local=${env.WORKSPACE}/.cache/m2' },
+  gradle: { GRADLE_USER_HOME: '${env.WORKSPACE}/.cache/gradle' },
+  go: { GOMODCACHE: '${env.WORKSPACE}/.cache/go-mod', GOCACHE: '${env.WORKSPACE}/.cache/go-build' },
+  cargo: { CARGO_HOME: '${env.WORKSPACE}/.cache/cargo' }
+};
+
+/**
+ * `when` conditions for the events that start a workflow
+ */
+const EVENT_CONDITIONS: Record<string, string> = {
+  pull_request: 'changeRequest()',
+  push: 'not { changeRequest() }',
+  schedule: "triggeredBy 'TimerTrigger'",
+  workflow_dispatch: "triggeredBy cause: 'UserIdCause'"
+};
+
+/**
+ * Commands that need a Docker daemon, which the containers of a docker agent do not have
+ */
+const DOCKER_COMMAND = /(^|[\s;&|(])docker\s/m;
+
+/**
+ * A Groovy statement, or a block with its body. Continuation lines of a statement, those of a
+ * multi-line script literal, are written as they are.
+ */
+type GroovyNode = string | GroovyBlock;
+
+interface GroovyBlock {
+  header: string;
+  body: GroovyNode[];
+}
+
+/**
+ * Per-render state shared across job conversions
+ */
+interface ConversionContext {
+  detectionResult: DetectionResult;
+  /** Variables keeping the detected package manager's cache in the workspace */
+  cacheEnvironment: Record<string, string>;
+  /** Secrets the steps refer to, bound from the Jenkins credentials with the same id */
+  credentials: Set<string>;
+  /** Names of the stashes saved so far, as Groovy string literals */
+  stashes: Set<string>;
+  cachedJobs: string[];
+  /** Jobs whose matrix became a matrix directive or a parallel stage per combination */
+  matrixJobs: string[];
+  parallelGroups: number;
+  warnings: string[];
+}
+
+/**
+ * Matrix values one run of a job sees: those of a combination, known when rendering, or the
+ * axes a matrix directive sets as environment variables of each cell
+ */
+interface MatrixValues {
+  values: Record<string, string>;
+  axes: Set<string>;
+}
+
+/**
+ * A job converted to a stage
+ */
+interface ConvertedJob {
+  name: string;
+  needs: string[];
+  /** Stages holding a matrix or parallel block cannot run inside another parallel block */
+  nested: boolean;
+  /** The stage, on an agent of its own when it runs in parallel with others */
+  toStage(ownAgent: boolean): GroovyBlock;
+}
+
+/**
+ * Jenkins renderer that maps workflow templates onto the stages of a declarative pipeline
+ */
+export class JenkinsRenderer implements Renderer {
+  private options: FormattingOptions;
+
+  constructor(options: FormattingOptions) {
+    this.options = options;
+  }
+
+  /**
+   * Render workflow template to the Jenkinsfile a multibranch job reads from the repository root
+   */
+  render(workflow: WorkflowTemplate, detectionResult: DetectionResult): RenderedFile {
+    const rendered = this.renderWorkflow(workflow, detectionResult);
+    return { path: 'Jenkinsfile', content: rendered.yaml, warnings: rendered.warnings };
+  }
+
+  /**
+   * Render workflow template to a Jenkinsfile string
+   */
+  renderWorkflow(workflow: WorkflowTemplate, detectionResult: DetectionResult): RenderingResult {
+    const startTime = Date.now();
+    const tool = resolveDependencyTool(detectionResult);
+    const context: ConversionContext = {
+      detectionResult,
+      cacheEnvironment: (tool && WORKSPACE_CACHES[tool]) || {},
+      credentials: new Set(),
+      stashes: new Set(),
+      cachedJobs: [],
+      matrixJobs: [],
+      parallelGroups: 0,
+      warnings: []
+    };
+
+    try {
+      const pipeline = this.convertToJenkinsFormat(workflow, context);
+
+      const content = this.toLines(pipeline, 0).join('\n') + '\n';
+      const jenkinsfile = withGenerationInfo(content, workflow.name, this.options, 'Jenkinsfile', '//');
+      return toRenderingResult(jenkinsfile, startTime, this.getAppliedOptimizations(context), context.warnings);
+    } catch (error) {
+      throw new Error(`Jenkinsfile rendering failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  /**
+   * Convert workflow template to a declarative pipeline. Stages run one after another in the
+   * workspace of the pipeline's agent, so jobs are grouped by how deep they sit in the needs
+   * graph and each group of more than one job runs as a `parallel` block, every stage of it on
+   * an agent and workspace of its own.
+   */
+  private convertToJenkinsFormat(workflow: WorkflowTemplate, context: ConversionContext): GroovyNode {
+    const image = this.resolveImage(context.detectionResult, {});
+
+    const converted: ConvertedJob[] = [];
+    for (const job of workflow.jobs) {
+      const stage = this.convertJob(job, image, context);
+      if (stage) {
+        converted.push(stage);
+      } else {
+        context.warnings.push(`Job '${job.name}' has no Jenkins equivalent and was omitted`);
+      }
+    }
+
+    const stages: GroovyNode[] = [];
+    for (const group of groupByNeeds(converted)) {
+      // Jenkins does not nest matrix or parallel blocks inside a parallel block
+      if (group.length > 1 && group.every(job => !job.nested)) {
+        context.parallelGroups++;
+        stages.push(this.block(`stage(${this.quote(group.map(job => job.name).join(' + '))})`, [
+          this.block('parallel', group.map(job => job.toStage(true)))
+        ]));
+      } else {
+        stages.push(...group.map(job => job.toStage(false)));
+      }
+    }
+
+    const pipeline: GroovyNode[] = [this.toAgent(image, false)];
+    if (workflow.concurrency?.cancelInProgress) {
+      pipeline.push(this.block('options', ['disableConcurrentBuilds(abortPrevious: true)']));
+    }
+    const triggers = this.convertTriggers(workflow.triggers, context.warnings);
+    if (triggers.length > 0) {
+      pipeline.push(this.block('triggers', triggers));
+    }
+    if (context.credentials.size > 0) {
+      const credentials = [...context.credentials].sort();
+      pipeline.push(this.block('environment', credentials.map(name => `${name} = credentials(${this.quote(name)})`)));
+      context.warnings.push(`Add ${credentials.join(', ')} as secret text credentials in Jenkins - the pipeline binds them by id`);
+    }
+    pipeline.push(this.block('stages', stages));
+
+    return this.block('pipeline', pipeline);
+  }
+
+  /**
+   * Convert a job template to a stage: a matrix directive for its strategy, or a parallel stage
+   * per combination when matrix include entries add values Jenkins axes cannot express. Null
+   * when nothing translates.
+   */
+  private convertJob(job: JobTemplate, defaultImage: string, context: ConversionContext): ConvertedJob | null {
+    const strategy = job.strategy as MatrixStrategy | undefined;
+    const runnerKey = typeof job.runsOn === 'string'
+      ? job.runsOn.match(/^\$\{\{\s*matrix\.([\w-]+)\s*\}\}$/)?.[1]
+      : undefined;
+    const include = strategy ? matrixInclude(strategy) : [];
+
+    const directives: GroovyNode[] = [];
+    const condition = job.if ? this.convertJobCondition(job.if) : undefined;
+    if (condition) {
+      directives.push(this.block('when', ['beforeAgent true', condition]));
+    }
+    if (job.timeout) {
+      directives.push(this.block('options', [`timeout(time: ${job.timeout}, unit: 'MINUTES')`]));
+    }
+    const title = `stage(${this.quote(job.name)})`;
+    const failFast = strategy && strategy.failFast !== false ? ['failFast true'] : [];
+
+    let converted: ConvertedJob | null;
+    const axes = strategy && include.length === 0 ? this.convertAxes(job, runnerKey, context.warnings) : undefined;
+    if (axes === null) {
+      return null;
+    }
+    if (axes && axes.axes.length > 0) {
+      const keys = new Set(axes.axes.map(([key]) => key));
+      const cell = this.convertStage(job, { values: {}, axes: keys }, context);
+      const image = job.container || this.resolveImage(context.detectionResult, Object.fromEntries(
+        [...keys].map(key => [key, `\${${toVariableName(key)}}`])));
+      converted = cell && {
+        name: job.name,
+        needs: job.needs || [],
+        nested: true,
+        toStage: () => this.block(title, [...directives, this.block('matrix', [
+          ...failFast,
+          this.toAgent(image, false),
+          this.block('axes', axes.axes.map(([key, values]) => this.block('axis', [
+            `name ${this.quote(toVariableName(key))}`,
+            `values ${values.map(value => this.quote(value)).join(', ')}`
+          ]))),
+          ...(axes.excludes.length > 0 ? [this.block('excludes', axes.excludes.map(exclude => this.block('exclude',
+            Object.entries(exclude).map(([key, value]) => this.block('axis', [
+              `name ${this.quote(toVariableName(key))}`,
+              `values ${this.quote(value)}`
+            ])))))] : []),
+          this.block('stages', [this.block(title, cell)])
+        ])])
+      };
+    } else if (strategy && !axes) {
+      const cells = this.expandMatrix(job, runnerKey, context.warnings).flatMap(values => {
+        const cell = this.convertStage(job, { values, axes: new Set() }, context);
+        // Flags such as cross-compile tell combinations apart only together with the other values
+        const label = Object.values(values).filter(value => value && value !== 'true' && value !== 'false').join(', ');
+        const image = job.container || this.resolveImage(context.detectionResult, values);
+        return cell ? [this.block(`stage(${this.quote(label ? `${job.name} (${label})` : job.name)})`, [this.toAgent(image, false), ...cell])] : [];
+      });
+      converted = cells.length > 0 ? {
+        name: job.name,
+        needs: job.needs || [],
+        nested: true,
+        toStage: () => this.block(title, [...directives, ...failFast, this.block('parallel', cells)])
+      } : null;
+    } else {
+      const cell = this.convertStage(job, { values: {}, axes: new Set() }, context);
+      const image = job.container || defaultImage;
+      converted = cell && {
+        name: job.name,
+        needs: job.needs || [],
+        nested: false,
+        // Stages after one another share the pipeline's workspace, in the job's image when it differs
+        toStage: (ownAgent: boolean) => this.block(title, [
+          ...(ownAgent || image !== defaultImage ? [this.toAgent(image, !ownAgent)] : []),
+          ...directives,
+          ...cell
+        ])
+      };
+    }
+
+    if (!converted) {
+      return null;
+    }
+    if (job.if && !condition) {
+      context.warnings.push(`Condition '${job.if}' on job '${job.name}' has no Jenkins equivalent; the stage always runs`);
+    }
+    if (job.services && Object.keys(job.services).length > 0) {
+      context.warnings.push(`Service containers of job '${job.name}' (${Object.keys(job.services).join(', ')}) have no Jenkins equivalent; start them on the agent`);
+    }
+    if (!strategy && isNonLinuxRunner(job.runsOn)) {
+      context.warnings.push(`Job '${job.name}' runs on ${job.runsOn}, but the Jenkins docker agent runs Linux containers`);
+    }
+    if (converted.nested) {
+      context.matrixJobs.push(job.name);
+    }
+    return converted;
+  }
+
+  /**
+   * Convert one run of a job to the directives and steps of its stage: the environment, the
+   * steps and a post section for always() and failure() steps. Null when no command translates.
+   */
+  private convertStage(job: JobTemplate, matrix: MatrixValues, context: ConversionContext): GroovyNode[] | null {
+    const steps: GroovyNode[] = [];
+    const always: GroovyNode[] = [];
+    const failure: GroovyNode[] = [];
+    const jobWorkingDirectory = job.defaults?.run?.workingDirectory;
+    let hasCommands = false;
+
+    for (const step of job.steps) {
+      const condition = step.if ? unwrapExpression(step.if) : undefined;
+      // Matrix conditions are decided per combination, or per cell of a matrix directive; status checks map onto post
+      const matches = condition && !['success()', 'always()', 'failure()'].includes(condition)
+        ? this.evaluateMatrixCondition(condition, matrix)
+        : true;
+      if (matches === false) {
+        continue;
+      }
+
+      let nodes: GroovyNode[];
+      let runsCommands = true;
+      if (step.uses) {
+        const action = actionName(step);
+        if (action === 'actions/checkout') {
+          // Jenkins checks out the repository, with its history, when the agent of a stage starts
+          if (!step.with?.submodules) {
+            continue;
+          }
+          const recursive = step.with.submodules === 'recursive' ? ' --recursive' : '';
+          nodes = [this.toShell(step.name, `git submodule sync${recursive} && git submodule update --init${recursive}`)];
+        } else if (action === 'actions/upload-artifact') {
+          // Stashes carry files to the stages that run on other agents
+          const name = this.toGroovyValue(String(step.with?.name ?? 'artifact'), matrix, context);
+          const includes = this.toGroovyValue(this.toStashIncludes(String(step.with?.path ?? '')), matrix, context);
+          context.stashes.add(name);
+          nodes = [`stash name: ${name}, includes: ${includes}`];
+          runsCommands = false;
+        } else if (action === 'actions/download-artifact') {
+          const names = step.with?.name ? [this.toGroovyValue(String(step.with.name), matrix, context)] : [...context.stashes];
+          nodes = names.map(name => `unstash ${name}`);
+          runsCommands = false;
+        } else {
+          nodes = this.translateAction(step, matrix, context).map(command => this.toShell(step.name, command));
+        }
+      } else if (step.run && step.id !== CACHE_DATE_STEP_ID) {
+        // The date only feeds the actions/cache key, which the workspace cache replaces
+        nodes = [this.translateRunStep(step, matrix, context)];
+      } else {
+        continue;
+      }
+      if (nodes.length === 0) {
+        continue;
+      }
+      if (matches === undefined) {
+        context.warnings.push(`Condition '${step.if}' on step '${step.name}' has no Jenkins equivalent; the step always runs`);
+      }
+
+      // Job defaults apply to run steps only, as in GitHub Actions
+      const directory = step.workingDirectory || (step.run ? jobWorkingDirectory : undefined) || '.';
+      if (step.env && Object.keys(step.env).length > 0) {
+        const variables = Object.entries(step.env).map(([name, value]) => this.toGroovyValue(`${name}=${value}`, matrix, context));
+        nodes = [this.block(`withEnv([${variables.join(', ')}])`, nodes)];
+      }
+      if (directory !== '.') {
+        nodes = [this.block(`dir(${this.quote(directory.replace(/^\.\/|\/+$/g, ''))})`, nodes)];
+      }
+      if (typeof matches === 'string') {
+        nodes = [this.block('script', [this.block(`if (${matches})`, nodes)])];
+      }
+
+      if (condition === 'always()') {
+        always.push(...nodes);
+      } else if (condition === 'failure()') {
+        failure.push(...nodes);
+      } else {
+        steps.push(...nodes);
+        hasCommands = hasCommands || runsCommands;
+      }
+    }
+
+    if (!hasCommands) {
+      return null;
+    }
+
+    if (job.steps.some(step => DOCKER_COMMAND.test(step.run || ''))) {
+      context.warnings.push(`Job '${job.name}' runs docker, which needs the Docker CLI and socket in its agent's container`);
+    }
+
+    const environment: Record<string, string> = {};
+    if (installsDependencies(job) && Object.keys(context.cacheEnvironment).length > 0) {
+      Object.assign(environment, context.cacheEnvironment);
+      context.cachedJobs.push(job.name);
+    }
+    const variables = [
+      ...Object.entries(environment).map(([name, value]) => `${name} = "${value}"`),
+      ...Object.entries(job.env || {}).map(([name, value]) => `${name} = ${this.toGroovyValue(String(value), matrix, context)}`)
+    ];
+
+    const post: GroovyNode[] = [
+      ...(always.length > 0 ? [this.block('always', always)] : []),
+      ...(failure.length > 0 ? [this.block('failure', failure)] : [])
+    ];
+
+    return [
+      ...(variables.length > 0 ? [this.block('environment', variables)] : []),
+      this.block('steps', steps),
+      ...(post.length > 0 ? [this.block('post', post)] : [])
+    ];
+  }
+
+  /**
+   * Translate a GitHub Action step into shell commands
+   */
+  private translateAction(step: StepTemplate, matrix: MatrixValues, context: ConversionContext): string[] {
+    // Language setup comes from the agent image; caching is kept in the workspace
+    if (isImageSetupAction(actionName(step))) {
+      return [];
+    }
+
+    const commands = translateSetupAction(step, value => this.translateExpression(value, matrix, context));
+    if (commands) {
+      return commands;
+    }
+
+    context.warnings.push(`Step '${step.name}' uses ${step.uses}, which has no Jenkins equivalent; skipped`);
+    return [];
+  }
+
+  /**
+   * Translate a run step into an sh step, retried, allowed to fail and timed out with the Jenkins steps for it
+   */
+  private translateRunStep(step: StepTemplate, matrix: MatrixValues, context: ConversionContext): GroovyNode {
+    let node: GroovyNode = this.toShell(step.name, this.translateExpression(step.run || '', matrix, context));
+
+    if (step.retries) {
+      node = this.block(`retry(${step.retries + 1})`, [node]);
+    }
+
+    if (step.continueOnError) {
+      node = this.block("catchError(buildResult: 'SUCCESS', stageResult: 'UNSTABLE')", [node]);
+    }
+
+    if (step.timeout) {
+      node = this.block(`timeout(time: ${step.timeout}, unit: 'MINUTES')`, [node]);
+    }
+
+    return node;
+  }
+
+  /**
+   * Evaluate a matrix boolean condition or string comparison: against a combination's values,
+   * or as a Groovy condition on the environment of a matrix directive cell. Undefined when the
+   * condition depends on anything else.
+   */
+  private evaluateMatrixCondition(expression: string, matrix: MatrixValues): boolean | string | undefined {
+    const comparison = expression.match(/^(?:matrix\.([\w-]+)|runner\.(os))\s*(==|!=)\s*'([^']*)'$/);
+    if (comparison) {
+      const [, key, os, operator, expected] = comparison;
+      if (key && matrix.axes.has(key)) {
+        return `env.${toVariableName(key)} ${operator} ${this.quote(expected ?? '')}`;
+      }
+      // Every stage runs in a Linux container
+      const value = os ? 'Linux' : matrix.values[key ?? ''] ?? '';
+      return operator === '==' ? value === expected : value !== expected;
+    }
+    const match = expression.match(/^(!?)\s*matrix\.([\w-]+)$/);
+    if (!match) {
+      return undefined;
+    }
+    const key = match[2] ?? '';
+    if (matrix.axes.has(key)) {
+      return `env.${toVariableName(key)} ${match[1] ? '!=' : '=='} 'true'`;
+    }
+    const value = matrix.values[key] === 'true';
+    return match[1] ? !value : value;
+  }
+
+  /**
+   * Translate a job condition to a `when` condition; undefined when one of its terms has no equivalent
+   */
+  private convertJobCondition(expression: string): GroovyNode | undefined {
+    const condition = unwrapExpression(expression);
+    const anyOf = condition.split(/\s*\|\|\s*/).map(alternative => {
+      const allOf = alternative.split(/\s*&&\s*/).map(term => this.convertConditionTerm(term));
+      if (allOf.some(term => term === undefined)) {
+        return undefined;
+      }
+      return allOf.length === 1 ? allOf[0]! : this.block('allOf', allOf as string[]);
+    });
+    if (anyOf.some(alternative => alternative === undefined)) {
+      return undefined;
+    }
+    return anyOf.length === 1 ? anyOf[0]! : this.block('anyOf', anyOf as GroovyNode[]);
+  }
+
+  /**
+   * Translate a comparison of the event or ref the workflow runs for to a `when` condition
+   */
+  private convertConditionTerm(term: string): string | undefined {
+    const event = term.match(/^github\.event_name\s*(==|!=)\s*'(\w+)'$/);
+    const condition = event ? EVENT_CONDITIONS[event[2] ?? ''] : undefined;
+    if (event) {
+      if (!condition || event[1] === '==') {
+        return condition;
+      }
+      return condition.startsWith('not ') ? condition.replace(/^not \{ (.*) \}$/, '$1') : `not { ${condition} }`;
+    }
+
+    const branch = term.match(/^github\.ref\s*==\s*'refs\/heads\/([^']+)'$/);
+    if (branch) {
+      return `branch ${this.quote(branch[1] ?? '')}`;
+    }
+    return /^startsWith\(github\.ref,\s*'refs\/tags\/'\)$/.test(term) ? 'buildingTag()' : undefined;
+  }
+
+  /**
+   * Translate the GitHub expressions of a value to the variables Jenkins sets, matrix values and
+   * bound credentials. Variables are given as `${NAME}` for the shell to expand.
+   */
+  private translateExpression(value: string, matrix: MatrixValues, context: ConversionContext): string {
+    return value.replace(/\$\{\{\s*(.*?)\s*\}\}/g, (expression, inner: string) => {
+      const resolved = this.resolveExpression(inner, matrix, context);
+      if (!resolved) {
+        context.warnings.push(`Expression '${expression}' has no Jenkins equivalent and was left as it is`);
+        return expression;
+      }
+      return 'variable' in resolved ? `\${${resolved.variable}}` : resolved.value;
+    });
+  }
+
+  /**
+   * A value as a Groovy string literal, one interpolating env when it refers to variables
+   */
+  private toGroovyValue(value: string, matrix: MatrixValues, context: ConversionContext): string {
+    let interpolated = false;
+    const parts = value.split(/(\$\{\{.*?\}\})/).map((part, index) => {
+      const resolved = index % 2 === 1 ? this.resolveExpression(part.slice(3, -2).trim(), matrix, context) : { value: part };
+      if (resolved && 'variable' in resolved) {
+        interpolated = true;
+        return { variable: resolved.variable };
+      }
+      if (!resolved) {
+        context.warnings.push(`Expression '${part}' has no Jenkins equivalent and was left as it is`);
+      }
+      return { value: resolved ? resolved.value : part };
+    });
+
+    if (!interpolated) {
+      return this.quote(parts.map(part => 'value' in part ? part.value : '').join(''));
+    }
+    return `"${parts.map(part => 'variable' in part ? `\${env.${part.variable}}` : part.value.replace(/[\\"$]/g, '\\$&')).join('')}"`;
+  }
+
+  /**
+   * Resolve an expression to a value known when rendering or a variable set when the stage runs
+   */
+  private resolveExpression(
+    expression: string,
+    matrix: MatrixValues,
+    context: ConversionContext
+  ): { value: string } | { variable: string } | undefined {
+    const matrixValue = expression.match(/^matrix\.([\w-]+)(?:\s*\|\|\s*'([^']*)')?$/);
+    if (matrixValue) {
+      const key = matrixValue[1] ?? '';
+      return matrix.axes.has(key) ? { variable: toVariableName(key) } : { value: matrix.values[key] ?? matrixValue[2] ?? '' };
+    }
+    const secret = expression.match(/^secrets\.(\w+)$/);
+    if (secret) {
+      context.credentials.add(secret[1]!);
+      return { variable: secret[1]! };
+    }
+    const variable = expression.match(/^env\.(\w+)$/);
+    if (variable) {
+      return { variable: variable[1]! };
+    }
+
+    switch (expression) {
+      case 'github.sha':
+        return { variable: 'GIT_COMMIT' };
+      case 'github.ref_name':
+        return { variable: 'BRANCH_NAME' };
+      case 'runner.os':
+        return { value: 'Linux' };
+      default:
+        return undefined;
+    }
+  }
+
+  /**
+   * Convert the axes of a matrix strategy to matrix directive axes and excludes; undefined when
+   * include entries add values, which Jenkins axes cannot express, and null when every runner
+   * the matrix picks is macOS or Windows. The axis picking the runner is dropped, with its macOS
+   * and Windows values, since every cell runs in a Linux container.
+   */
+  private convertAxes(
+    job: JobTemplate,
+    runnerKey: string | undefined,
+    warnings: string[]
+  ): { axes: Array<[string, string[]]>; excludes: Record<string, string>[] } | null {
+    const strategy = job.strategy as MatrixStrategy;
+    const axes = matrixAxes(strategy).map(([key, values]): [string, string[]] => [key, values.map(value => String(value))]);
+    const exclude = matrixExclude(strategy);
+
+    const runners = axes.find(([key]) => key === runnerKey)?.[1] || [];
+    const nonLinux = runners.filter(runner => isNonLinuxRunner(runner));
+    if (nonLinux.length > 0) {
+      if (nonLinux.length === runners.length) {
+        return null;
+      }
+      warnings.push(`Job '${job.name}' combinations on ${nonLinux.join(', ')} were left out - the Jenkins docker agent runs Linux containers`);
+    }
+
+    const excludes = exclude
+      .filter(entry => !runnerKey || !isNonLinuxRunner(String(entry[runnerKey] ?? '')))
+      .map(entry => Object.fromEntries(Object.entries(entry)
+        .filter(([key]) => key !== runnerKey)
+        .map(([key, value]) => [key, String(value)])))
+      .filter(entry => Object.keys(entry).length > 0);
+
+    return { axes: axes.filter(([key]) => key !== runnerKey), excludes };
+  }
+
+  /**
+   * Expand a matrix strategy with include entries into the values of each combination;
+   * combinations on hosted macOS or Windows runners are left out, since every stage runs in a
+   * Linux container, unless they cross-compile.
+   */
+  private expandMatrix(job: JobTemplate, runnerKey: string | undefined, warnings: string[]): Record<string, string>[] {
+    const expanded: Record<string, string>[] = [];
+    for (const combination of expandMatrix(job.strategy as MatrixStrategy)) {
+      const { [runnerKey ?? '']: runner, ...values } = combination;
+      if (typeof runner === 'string' && isNonLinuxRunner(runner)) {
+        if (!('cross-compile' in values)) {
+          warnings.push(`Job '${job.name}' combinations on ${runner} were left out - the Jenkins docker agent runs Linux containers`);
+          continue;
+        }
+        values['cross-compile'] = true;
+      }
+      expanded.push(Object.fromEntries(Object.entries(values).map(([key, value]) => [key, String(value)])));
+    }
+
+    return expanded;
+  }
+
+  /**
+   * Convert workflow triggers. The multibranch job decides which branches, pull requests and tags
+   * build, so only schedules go in the Jenkinsfile.
+   */
+  private convertTriggers(triggers: TriggerConfig | undefined, warnings: string[]): GroovyNode[] {
+    const crons = (triggers?.schedule || []).map(schedule => schedule.cron);
+
+    if (triggers?.push?.tags) {
+      warnings.push('Tags only build when tag discovery is enabled in the branch sources of the Jenkins multibranch job');
+    }
+
+    return crons.length > 0 ? [`cron(${this.quote(crons.join('\n'))})`] : [];
+  }
+
+  /**
+   * Resolve the agent image from the language version, or the combination's version value
+   */
+  private resolveImage(detectionResult: DetectionResult, matrix: Record<string, string>): string {
+    return resolveLanguageImage(detectionResult, versionInput => matrix[versionInput]) || DEFAULT_IMAGE;
+  }
+
+  /**
+   * A docker agent running the image; one reusing the node keeps the pipeline's workspace
+   */
+  private toAgent(image: string, reuseNode: boolean): GroovyBlock {
+    return this.block('agent', [this.block('docker', [
+      // Images naming a matrix axis are interpolated for each cell
+      `image ${image.includes('${') ? `"${image}"` : this.quote(image)}`,
+      ...(reuseNode ? ['reuseNode true'] : [])
+    ])]);
+  }
+
+  /**
+   * An sh step labelled with the step name; multi-line scripts are kept as they are
+   */
+  private toShell(label: string, script: string): string {
+    const literal = script.trimEnd().includes('\n')
+      ? `'''\n${this.escape(script.trimEnd())}\n'''`
+      : this.quote(script.trim());
+    return `sh label: ${this.quote(label)}, script: ${literal}`;
+  }
+
+  /**
+   * Convert upload-artifact paths to the comma-separated Ant patterns of a stash; directories keep everything below them
+   */
+  private toStashIncludes(paths: string): string {
+    return toArtifactGlobs(paths).join(',');
+  }
+
+  private block(header: string, body: GroovyNode[]): GroovyBlock {
+    return { header, body };
+  }
+
+  /**
+   * A single-quoted Groovy string, which leaves `$` to the shell
+   */
+  private quote(value: string): string {
+    return `'${this.escape(value)}'`;
+  }
+
+  private escape(value: string): string {
+    return value.replace(/[\\']/g, '\\$&');
+  }
+
+  /**
+   * Lines of Groovy nodes, blocks indented below their header
+   */
+  private toLines(node: GroovyNode, depth: number): string[] {
+    const indent = ' '.repeat(this.options.yamlConfig.indent * depth);
+    if (typeof node === 'string') {
+      const [first, ...continuation] = node.split('\n');
+      return [`${indent}${first}`, ...continuation];
+    }
+    return [
+      `${indent}${node.header} {`,
+      ...node.body.flatMap(child => this.toLines(child, depth + 1)),
+      `${indent}}`
+    ];
+  }
+
+  /**
+   * Get applied optimizations for metadata
+   */
+  private getAppliedOptimizations(context: ConversionContext): string[] {
+    const optimizations: string[] = [];
+
+    if (context.cachedJobs.length > 0) {
+      optimizations.push('dependency-caching');
+    }
+
+    if (context.matrixJobs.length > 0) {
+      optimizations.push('matrix-builds');
+    }
+
+    if (context.parallelGroups > 0) {
+      optimizations.push('parallel-execution');
+    }
+
+    return optimizations;
+  }
+}
@@ -0,0 +1,406 @@
+/**
+ * Translation of workflow templates shared by the renderers for providers other than GitHub Actions
+ */
+
+import * as yaml from 'js-yaml';
+import { FormattingOptions, RenderingResult } from './renderer-types';
+import { JobTemplate, StepTemplate, MatrixStrategy } from '../types';
+import { DetectionResult } from '../interfaces';
+
+/**
+ * Pipeline stages in execution order
+ */
+export const STAGE_ORDER = ['lint', 'build', 'test', 'security', 'deploy'];
+
+/**
+ * Official images per language with the setup input that carries the version
+ */
+export const LANGUAGE_IMAGES: Record<string, { image: string; versionInput: string; defaultVersion: string; runtime?: string }> = {
+  javascript: { image: 'node', versionInput: 'node-version', defaultVersion: '18', runtime: 'node' },
+  typescript: { image: 'node', versionInput: 'node-version', defaultVersion: '18', runtime: 'node' },
+  python: { image: 'python', versionInput: 'python-version', defaultVersion: '3.11', runtime: 'python' },
+  go: { image: 'golang', versionInput: 'go-version', defaultVersion: '1.21', runtime: 'go' },
+  rust: { image: 'rust', versionInput: 'toolchain', defaultVersion: 'latest' },
+  java: { image: 'maven:3-eclipse-temurin', versionInput: 'java-version', defaultVersion: '17' }
+};
+
+/**
+ * Actions whose work the image of a job or the provider's own cache takes over
+ */
+const IMAGE_SETUP_ACTION = /^actions\/(cache|setup-(node|python|go|java))$/;
+
+/**
+ * How a provider refers to the values GitHub expressions name
+ */
+export interface ExpressionSyntax {
+  /** Reference to a matrix value; undefined when the job has no such matrix key */
+  matrix(key: string): string | undefined;
+  secret(name: string): string;
+  sha: string;
+  refName: string;
+  os: string;
+}
+
+/**
+ * Action a step uses, without its version
+ */
+export function actionName(step: StepTemplate): string {
+  return (step.uses || '').split('@')[0] || '';
+}
+
+/**
+ * Whether a job installs dependencies, which the provider caches in its own way
+ */
+export function installsDependencies(job: JobTemplate): boolean {
+  return job.steps.some(step => IMAGE_SETUP_ACTION.test(actionName(step)));
+}
+
+/**
+ * Whether the image of a job provides what the action sets up
+ */
+export function isImageSetupAction(action: string): boolean {
+  return IMAGE_SETUP_ACTION.test(action);
+}
+
+/**
+ * A condition without the `${{ }}` around it
+ */
+export function unwrapExpression(condition: string): string {
+  return condition.replace(/^\s*\$\{\{\s*|\s*\}\}\s*$/g, '').trim();
+}
+
+/**
+ * Translate the GitHub expressions embedded in a string to the provider's matrix references and variables
+ */
+export function translateExpression(value: string, syntax: ExpressionSyntax): string {
+  return value
+    .replace(/\$\{\{\s*matrix\.([\w-]+)(?:\s*\|\|\s*'([^']*)')?\s*\}\}/g, (_, key: string, fallback?: string) =>
+      syntax.matrix(key) ?? fallback ?? '')
+    .replace(/\$\{\{\s*secrets\.(\w+)\s*\}\}/g, (_, name: string) => syntax.secret(name))
+    .replace(/\$\{\{\s*github\.sha\s*\}\}/g, () => syntax.sha)
+    .replace(/\$\{\{\s*github\.ref_name\s*\}\}/g, () => syntax.refName)
+    .replace(/\$\{\{\s*runner\.os\s*\}\}/g, () => syntax.os);
+}
+
+/**
+ * Translate a matrix boolean condition or string comparison into a shell test on the reference
+ * the provider has for the matrix value; null when the condition depends on anything else
+ */
+export function translateShellGuard(condition: string, reference: (key: string) => string | undefined): string | null {
+  const expression = unwrapExpression(condition);
+  const comparison = expression.match(/^matrix\.([\w-]+)\s*(==|!=)\s*'([^']*)'$/);
+  if (comparison) {
+    const value = reference(comparison[1] ?? '');
+    return value ? `[ "${value}" ${comparison[2] === '==' ? '=' : '!='} "${comparison[3]}" ]` : null;
+  }
+  const match = expression.match(/^(!?)\s*matrix\.([\w-]+)$/);
+  const value = match ? reference(match[2] ?? '') : undefined;
+  if (!match || !value) {
+    return null;
+  }
+  return match[1] ? `[ "${value}" != "true" ]` : `[ "${value}" = "true" ]`;
+}
+
+/**
+ * Evaluate a matrix boolean condition or string comparison against a combination's values;
+ * undefined when the condition depends on anything else
+ */
+export function evaluateMatrixCondition(expression: string, values: Record<string, string>): boolean | undefined {
+  const comparison = expression.match(/^matrix\.([\w-]+)\s*(==|!=)\s*'([^']*)'$/);
+  if (comparison) {
+    const value = values[comparison[1] ?? ''] ?? '';
+    return comparison[2] === '==' ? value === comparison[3] : value !== comparison[3];
+  }
+  const match = expression.match(/^(!?)\s*matrix\.([\w-]+)$/);
+  if (!match) {
+    return undefined;
+  }
+  const value = values[match[2] ?? ''] === 'true';
+  return match[1] ? !value : value;
+}
+
+/**
+ * Shell commands installing what a tool setup action installs on GitHub-hosted runners, empty
+ * when the image already has it; undefined for other actions
+ */
+export function translateSetupAction(step: StepTemplate, translate: (value: string) => string): string[] | undefined {
+  switch (actionName(step)) {
+    case 'dtolnay/rust-toolchain': {
+      // The rust images and hosted runners come with the stable toolchain
+      const toolchain = translate(String(step.with?.toolchain ?? 'stable'));
+      return toolchain === 'stable' ? [] : [`rustup toolchain install ${toolchain} && rustup default ${toolchain}`];
+    }
+    case 'pnpm/action-setup':
+      return [step.with?.version ? `npm install -g pnpm@${step.with.version}` : 'corepack enable'];
+    case 'oven-sh/setup-bun':
+      return [`npm install -g bun@${step.with?.['bun-version'] ?? 'latest'}`];
+    case 'snok/install-poetry':
+      return ['pip install poetry'];
+    case 'golangci/golangci-lint-action': {
+      const args = step.with?.args ? ` ${step.with.args}` : '';
+      return ['go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest', `"$(go env GOPATH)/bin/golangci-lint" run${args}`];
+    }
+    default:
+      return undefined;
+  }
+}
+
+/**
+ * Axes of a matrix strategy, without its include and exclude entries
+ */
+export function matrixAxes(strategy: MatrixStrategy): Array<[string, any[]]> {
+  return Object.entries(strategy.matrix || {})
+    .filter(([key, values]) => key !== 'include' && key !== 'exclude' && Array.isArray(values));
+}
+
+/**
+ * Include entries of a matrix strategy, given beside the matrix or inside it
+ */
+export function matrixInclude(strategy: MatrixStrategy): Record<string, any>[] {
+  return strategy.include || (strategy.matrix as any)?.include || [];
+}
+
+/**
+ * Exclude entries of a matrix strategy, given beside the matrix or inside it
+ */
+export function matrixExclude(strategy: MatrixStrategy): Record<string, any>[] {
+  return strategy.exclude || (strategy.matrix as any)?.exclude || [];
+}
+
+/**
+ * Expand a matrix strategy into its combinations the way GitHub does: the product of the axes,
+ * less the excluded ones, with include entries merged into the combinations they match or added
+ */
+export function expandMatrix(strategy: MatrixStrategy): Record<string, any>[] {
+  const axes = matrixAxes(strategy);
+  const exclude = matrixExclude(strategy);
+
+  let combinations: Record<string, any>[] = axes.length > 0 ? [{}] : [];
+  for (const [key, values] of axes) {
+    combinations = combinations.flatMap(combination =>
+      values.map(value => ({ ...combination, [key]: value }))
+    );
+  }
+  combinations = combinations.filter(combination =>
+    !exclude.some(excluded =>
+      Object.entries(excluded).every(([key, value]) => combination[key] === value)));
+
+  const axisKeys = new Set(axes.map(([key]) => key));
+  for (const extra of matrixInclude(strategy)) {
+    const matches = combinations.filter(combination =>
+      Object.entries(extra).every(([key, value]) => !axisKeys.has(key) || combination[key] === value));
+    if (matches.length > 0) {
+      matches.forEach(combination => Object.assign(combination, extra));
+    } else {
+      combinations.push({ ...extra });
+    }
+  }
+
+  return combinations;
+}
+
+/**
+ * Whether a job runs on a hosted macOS or Windows runner
+ */
+export function isNonLinuxRunner(runsOn: JobTemplate['runsOn']): boolean {
+  return typeof runsOn === 'string' && /^(macos|windows)/.test(runsOn);
+}
+
+/**
+ * Group jobs by how deep they sit in the needs graph, for providers that run groups one after another
+ */
+export function groupByNeeds<T extends { name: string; needs: string[] }>(jobs: T[]): T[][] {
+  const levels = new Map<string, number>();
+  const levelOf = (job: T): number => {
+    if (!levels.has(job.name)) {
+      const needs = jobs.filter(other => job.needs.includes(other.name) && other !== job);
+      levels.set(job.name, needs.length > 0 ? Math.max(...needs.map(levelOf)) + 1 : 0);
+    }
+    return levels.get(job.name)!;
+  };
+
+  const groups: T[][] = [];
+  for (const job of jobs) {
+    const level = levelOf(job);
+    groups[level] = [...(groups[level] || []), job];
+  }
+  return groups.filter(group => group && group.length > 0);
+}
+
+/**
+ * Resolve the image of the primary language: the release manifest constraints name, else the
+ * README version, or the version a matrix value gives. Undefined for languages without an
+ * official image.
+ */
+export function resolveLanguageImage(
+  detectionResult: DetectionResult,
+  matrixVersion: (versionInput: string) => string | undefined = () => undefined
+): string | undefined {
+  const language = detectionResult.languages.find(l => l.primary);
+  const config = language ? LANGUAGE_IMAGES[language.name.toLowerCase()] : undefined;
+  if (!language || !config) {
+    return undefined;
+  }
+
+  // Manifest constraints name the release CI should run; the README version is a fallback
+  const constraint = detectionResult.versionConstraints?.find(c => c.runtime === config.runtime);
+  const constrainedVersion = constraint?.exact || constraint?.versions[constraint.versions.length - 1];
+  const readmeVersion = language.version && /^\d+(\.\d+)*$/.test(language.version) ? language.version : undefined;
+  const detectedVersion = constrainedVersion || readmeVersion;
+
+  if (config.image === 'rust') {
+    // Toolchain channels are installed with rustup rather than selected by tag
+    return `rust:${detectedVersion || config.defaultVersion}`;
+  }
+
+  const version = matrixVersion(config.versionInput) || detectedVersion || config.defaultVersion;
+
+  if (config.image.startsWith('maven')) {
+    const usesGradle = detectionResult.buildTools.some(bt => bt.name === 'gradle');
+    return usesGradle ? `gradle:jdk${version}` : `${config.image}-${version}`;
+  }
+
+  return `${config.image}:${version}`;
+}
+
+/**
+ * Determine the tool that downloads dependencies for the primary language
+ */
+export function resolveDependencyTool(detectionResult: DetectionResult): string | undefined {
+  const language = detectionResult.languages.find(l => l.primary)?.name.toLowerCase();
+  const packageManagers = detectionResult.packageManagers.map(pm => pm.name.toLowerCase());
+  const buildTools = detectionResult.buildTools.map(bt => bt.name.toLowerCase());
+
+  switch (language) {
+    case 'javascript':
+    case 'typescript':
+      return ['pnpm', 'yarn', 'bun', 'npm'].find(pm => packageManagers.includes(pm)) || 'npm';
+    case 'python':
+      return ['poetry', 'pipenv', 'pip'].find(pm => packageManagers.includes(pm)) || 'pip';
+    case 'java':
+      return buildTools.includes('gradle') ? 'gradle' : 'maven';
+    case 'rust':
+      return 'cargo';
+    case 'go':
+      return 'go';
+    default:
+      return undefined;
+  }
+}
+
+/**
+ * Paths of an upload-artifact step, one per line
+ */
+export function toArtifactPaths(paths: string): string[] {
+  return paths
+    .split('\n')
+    .map(p => p.trim())
+    .filter(Boolean);
+}
+
+/**
+ * Convert upload-artifact paths to globs; directories keep everything below them
+ */
+export function toArtifactGlobs(paths: string): string[] {
+  return toArtifactPaths(paths)
+    .map(path => /[*?]/.test(path) || /\.\w+$/.test(path) ? path : `${path.replace(/\/+$/, '')}/**`);
+}
+
+/**
+ * Map a job name onto a pipeline stage
+ */
+export function getStage(jobName: string): string {
+  const name = jobName.toLowerCase();
+  if (name.includes('lint')) return 'lint';
+  if (name.includes('build')) return 'build';
+  if (name.includes('security') || name.includes('scan')) return 'security';
+  if (name.includes('deploy') || name.includes('release') || name.includes('publish')) return 'deploy';
+  return 'test';
+}
+
+/**
+ * Convert a matrix key to an environment variable name (e.g. go-version -> GO_VERSION)
+ */
+export function toVariableName(key: string): string {
+  return key.toUpperCase().replace(/[^A-Z0-9_]/g, '_');
+}
+
+/**
+ * Convert a matrix key to a parameter name (e.g. node-version -> node_version)
+ */
+export function toParameterName(key: string): string {
+  return key.toLowerCase().replace(/[^a-z0-9_]/g, '_');
+}
+
+/**
+ * Sanitize a job or service name for use as YAML key
+ */
+export function sanitizeJobName(name: string): string {
+  return name
+    .toLowerCase()
+    .replace(/[^a-z0-9-_]/g, '-')
+    .replace(/-+/g, '-')
+    .replace(/^-|-$/g, '');
+}
+
+/**
+ * Dump a provider configuration with the formatting options, ending in one newline
+ */
+export function dumpYaml(document: any, options: FormattingOptions, overrides: yaml.DumpOptions = {}): string {
+  return yaml.dump(document, {
+    indent: options.yamlConfig.indent,
+    lineWidth: options.yamlConfig.lineWidth,
+    noRefs: options.yamlConfig.noRefs,
+    noCompatMode: options.yamlConfig.noCompatMode,
+    condenseFlow: options.yamlConfig.condenseFlow,
+    quotingType: options.yamlConfig.quotingType === 'auto' ? undefined : options.yamlConfig.quotingType,
+    forceQuotes: options.yamlConfig.forceQuotes,
+    sortKeys: options.yamlConfig.sortKeys,
+    skipInvalid: false,
+    flowLevel: -1,
+    ...overrides
+  }).trimEnd() + '\n';
+}
+
+/**
+ * Prefix a rendered file with the generation info comments, when the options ask for them
+ */
+export function withGenerationInfo(
+  content: string,
+  workflowName: string,
+  options: FormattingOptions,
+  subject: string,
+  commentPrefix = '#'
+): string {
+  if (!options.commentConfig.enabled || !options.commentConfig.includeGenerationInfo) {
+    return content;
+  }
+  return [
+    `${commentPrefix} This ${subject} was automatically generated by README-to-CICD`,
+    `${commentPrefix} Generated at: ${new Date().toISOString()}`,
+    `${commentPrefix} ${workflowName}`,
+    '',
+    content
+  ].join('\n');
+}
+
+/**
+ * Rendering result for a rendered file, with each warning once
+ */
+export function toRenderingResult(
+  content: string,
+  startTime: number,
+  optimizationsApplied: string[],
+  warnings: string[]
+): RenderingResult {
+  return {
+    yaml: content,
+    metadata: {
+      linesCount: content.split('\n').length,
+      charactersCount: content.length,
+      renderingTime: Date.now() - startTime,
+      optimizationsApplied
+    },
+    warnings: [...new Set(warnings)]
+  };
+}
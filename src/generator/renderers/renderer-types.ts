@@ -2,6 +2,9 @@
  * Renderer-specific type definitions
  */
 
+import { WorkflowTemplate } from '../types';
+import { DetectionResult } from '../interfaces';
+
 /**
  * YAML rendering configuration
  */
@@ -54,4 +57,29 @@ export interface RenderingMetadata {
   charactersCount: number;
   renderingTime: number;
   optimizationsApplied: string[];
-}
\ No newline at end of file
+}
+
+/**
+ * Options a renderer for another provider takes from the generation options
+ */
+export interface RenderOptions {
+  /** Reference CircleCI orbs for dependency installation */
+  useOrbs?: boolean;
+}
+
+/**
+ * Configuration file rendered for a provider
+ */
+export interface RenderedFile {
+  /** File name, relative to the directory the provider reads its configuration from */
+  path: string;
+  content: string;
+  warnings: string[];
+}
+
+/**
+ * Renderer translating a workflow template into the configuration file of a provider other than GitHub Actions
+ */
+export interface Renderer {
+  render(workflow: WorkflowTemplate, detectionResult: DetectionResult, options?: RenderOptions): RenderedFile;
+}
@@ -4,7 +4,8 @@
 
 import * as yaml from 'js-yaml';
 import { FormattingOptions, RenderingResult, RenderingMetadata } from './renderer-types';
-import { WorkflowTemplate } from '../types';
+import { CompositeActionTemplate, StepTemplate, WorkflowTemplate } from '../types';
+import { withRetries } from '../utils/retry';
 
 /**
  * YAML Renderer class with yaml library integration
@@ -28,18 +29,7 @@ export class YAMLRenderer {
       const githubWorkflow = this.convertToGitHubActionsFormat(workflow);
       
       // Generate YAML with configured options
-      const yamlContent = yaml.dump(githubWorkflow, {
-        indent: this.options.yamlConfig.indent,
-        lineWidth: this.options.yamlConfig.lineWidth,
-        noRefs: this.options.yamlConfig.noRefs,
-        noCompatMode: this.options.yamlConfig.noCompatMode,
-        condenseFlow: this.options.yamlConfig.condenseFlow,
-        quotingType: this.options.yamlConfig.quotingType === 'auto' ? undefined : this.options.yamlConfig.quotingType,
-        forceQuotes: this.options.yamlConfig.forceQuotes,
-        sortKeys: this.options.yamlConfig.sortKeys,
-        skipInvalid: false,
-        flowLevel: -1
-      });
+      const yamlContent = this.dump(githubWorkflow);
 
       // Apply formatting and comments
       let formattedYaml = this.formatYAML(yamlContent);
@@ -67,6 +57,44 @@ export class YAMLRenderer {
     }
   }
 
+  /**
+   * Render a composite action to action.yml content: its inputs, then the steps under
+   * runs.steps with runs.using composite
+   */
+  renderCompositeAction(action: CompositeActionTemplate): string {
+    try {
+      return this.formatYAML(this.dump({
+        name: action.name,
+        description: action.description,
+        inputs: action.inputs,
+        runs: {
+          using: 'composite',
+          steps: action.steps.map(step => this.convertStep(step))
+        }
+      }));
+    } catch (error) {
+      throw new Error(`YAML rendering failed: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  /**
+   * Dump a document with the configured YAML options
+   */
+  private dump(document: any): string {
+    return yaml.dump(document, {
+      indent: this.options.yamlConfig.indent,
+      lineWidth: this.options.yamlConfig.lineWidth,
+      noRefs: this.options.yamlConfig.noRefs,
+      noCompatMode: this.options.yamlConfig.noCompatMode,
+      condenseFlow: this.options.yamlConfig.condenseFlow,
+      quotingType: this.options.yamlConfig.quotingType === 'auto' ? undefined : this.options.yamlConfig.quotingType,
+      forceQuotes: this.options.yamlConfig.forceQuotes,
+      sortKeys: this.options.yamlConfig.sortKeys,
+      skipInvalid: false,
+      flowLevel: -1
+    });
+  }
+
   /**
    * Add comments to YAML content
    */
@@ -96,8 +124,8 @@ export class YAMLRenderer {
       if (!line) continue;
       const trimmedLine = line.trim();
 
-      // Add workflow-level comments
-      if (trimmedLine.startsWith('name:')) {
+      // Add workflow-level comments; indented name keys belong to jobs or environments
+      if (line.startsWith('name:')) {
         commentedLines.push(`# ${workflow.name} - ${this.getWorkflowDescription(workflow)}`);
       }
 
@@ -162,6 +190,46 @@ export class YAMLRenderer {
     return commentedLines.join('\n');
   }
 
+  /**
+   * End the first line of each step with a `# source:` comment naming where the step comes
+   * from, `default` for steps without one. Steps are matched to the workflow's jobs in the
+   * order they were rendered.
+   */
+  annotateStepSources(content: string, workflow: WorkflowTemplate): string {
+    const jobs = new Map(workflow.jobs.map(job => [this.sanitizeJobName(job.name), job]));
+    let inJobs = false;
+    let steps: StepTemplate[] | undefined;
+    let stepsIndent = -1;
+    let itemIndent = -1;
+    let next = 0;
+
+    return content.split('\n').map(line => {
+      const trimmed = line.trim();
+      if (trimmed === '' || trimmed.startsWith('#')) {
+        return line;
+      }
+      const indent = line.length - line.trimStart().length;
+
+      if (indent === 0) {
+        inJobs = trimmed === 'jobs:';
+        steps = undefined;
+      } else if (inJobs && indent === this.options.yamlConfig.indent && trimmed.endsWith(':')) {
+        steps = jobs.get(trimmed.slice(0, -1))?.steps;
+        stepsIndent = -1;
+      } else if (steps && stepsIndent === -1 && trimmed === 'steps:') {
+        [stepsIndent, itemIndent, next] = [indent, -1, 0];
+      } else if (steps && stepsIndent !== -1) {
+        if (indent <= stepsIndent) {
+          stepsIndent = -1;
+        } else if (trimmed.startsWith('- ') && (itemIndent === -1 || indent === itemIndent)) {
+          itemIndent = indent;
+          return `${line} # source: ${steps[next++]?.source || 'default'}`;
+        }
+      }
+      return line;
+    }).join('\n');
+  }
+
   /**
    * Format YAML with proper indentation and structure
    */
@@ -239,7 +307,7 @@ export class YAMLRenderer {
 
     // Add defaults
     if (workflow.defaults) {
-      githubWorkflow.defaults = workflow.defaults;
+      githubWorkflow.defaults = this.convertDefaults(workflow.defaults);
     }
 
     // Add environment
@@ -264,11 +332,11 @@ export class YAMLRenderer {
     const converted: any = {};
 
     if (triggers.push) {
-      converted.push = triggers.push;
+      converted.push = this.convertEventFilters(triggers.push);
     }
 
     if (triggers.pullRequest) {
-      converted.pull_request = triggers.pullRequest;
+      converted.pull_request = this.convertEventFilters(triggers.pullRequest);
     }
 
     if (triggers.schedule) {
@@ -279,6 +347,10 @@ export class YAMLRenderer {
       converted.workflow_dispatch = triggers.workflowDispatch;
     }
 
+    if (triggers.workflowCall) {
+      converted.workflow_call = triggers.workflowCall;
+    }
+
     if (triggers.release) {
       converted.release = triggers.release;
     }
@@ -298,10 +370,58 @@ export class YAMLRenderer {
     return converted;
   }
 
+  /**
+   * Convert camelCase push/pull_request filters to GitHub Actions keys
+   */
+  private convertEventFilters(trigger: any): any {
+    const converted: any = { ...trigger };
+    const keys: Record<string, string> = {
+      branchesIgnore: 'branches-ignore',
+      tagsIgnore: 'tags-ignore',
+      pathsIgnore: 'paths-ignore'
+    };
+
+    for (const [key, githubKey] of Object.entries(keys)) {
+      if (converted[key] !== undefined) {
+        converted[githubKey] = converted[key];
+        delete converted[key];
+      }
+    }
+
+    return converted;
+  }
+
+  /**
+   * Convert defaults configuration to GitHub Actions format
+   */
+  private convertDefaults(defaults: any): any {
+    if (!defaults.run) {
+      return defaults;
+    }
+
+    const run: any = { ...defaults.run };
+    if (run.workingDirectory !== undefined) {
+      run['working-directory'] = run.workingDirectory;
+      delete run.workingDirectory;
+    }
+
+    return { ...defaults, run };
+  }
+
   /**
    * Convert job template to GitHub Actions format
    */
   private convertJob(job: any): any {
+    // A job calling a reusable workflow has no runner or steps of its own
+    if (job.uses) {
+      return {
+        ...(job.needs && job.needs.length > 0 && { needs: job.needs }),
+        ...(job.if && { if: job.if }),
+        uses: job.uses,
+        ...(job.secrets && { secrets: job.secrets })
+      };
+    }
+
     const converted: any = {
       'runs-on': job.runsOn
     };
@@ -317,6 +437,18 @@ export class YAMLRenderer {
         strategy['max-parallel'] = strategy.maxParallel;
         delete strategy.maxParallel;
       }
+      // GitHub Actions expects include/exclude nested under matrix
+      if (strategy.include || strategy.exclude) {
+        strategy.matrix = { ...strategy.matrix };
+        if (strategy.include) {
+          strategy.matrix.include = strategy.include;
+          delete strategy.include;
+        }
+        if (strategy.exclude) {
+          strategy.matrix.exclude = strategy.exclude;
+          delete strategy.exclude;
+        }
+      }
       converted.strategy = strategy;
     }
 
@@ -333,7 +465,17 @@ export class YAMLRenderer {
     }
 
     if (job.permissions) {
-      converted.permissions = job.permissions;
+      // GitHub spells multi-word scopes in kebab-case (id-token, pull-requests)
+      converted.permissions = Object.fromEntries(Object.entries(job.permissions).map(([scope, access]) =>
+        [scope.replace(/[A-Z]/g, letter => `-${letter.toLowerCase()}`), access]));
+    }
+
+    if (job.container) {
+      converted.container = job.container;
+    }
+
+    if (job.services && Object.keys(job.services).length > 0) {
+      converted.services = job.services;
     }
 
     if (job.timeout) {
@@ -348,6 +490,14 @@ export class YAMLRenderer {
       converted.outputs = job.outputs;
     }
 
+    if (job.defaults) {
+      converted.defaults = this.convertDefaults(job.defaults);
+    }
+
+    if (job.env && Object.keys(job.env).length > 0) {
+      converted.env = job.env;
+    }
+
     // Convert steps
     converted.steps = job.steps.map((step: any) => this.convertStep(step));
 
@@ -398,6 +548,12 @@ export class YAMLRenderer {
       converted.shell = step.shell;
     }
 
+    // The retry loop is bash, which Windows runners only use when asked to
+    if (step.retries && step.run) {
+      converted.run = withRetries(step.run, step.retries);
+      converted.shell = converted.shell || 'bash';
+    }
+
     if (step.workingDirectory) {
       converted['working-directory'] = step.workingDirectory;
     }
@@ -8,13 +8,18 @@ import { WorkflowTemplate, StepTemplate } from '../types';
 import { TemplateManager } from './template-manager';
 import * as yaml from 'yaml';
 
+/**
+ * Node.js package managers with distinct install and run commands
+ */
+type NodePackageManager = 'npm' | 'yarn' | 'pnpm' | 'bun';
+
 /**
  * Node.js framework detection information
  */
 interface NodeJSFramework {
   name: string;
   version?: string | undefined;
-  packageManager: 'npm' | 'yarn' | 'pnpm';
+  packageManager: NodePackageManager;
   hasTypeScript: boolean;
   hasLinting: boolean;
   hasTesting: boolean;
@@ -149,7 +154,7 @@ export class NodeJSWorkflowGenerator {
   /**
    * Detect package manager from detection results
    */
-  private detectPackageManager(detectionResult: DetectionResult): 'npm' | 'yarn' | 'pnpm' {
+  private detectPackageManager(detectionResult: DetectionResult): NodePackageManager {
     const packageManagers = detectionResult.packageManagers || [];
     
     // Check for specific package managers
@@ -159,6 +164,9 @@ export class NodeJSWorkflowGenerator {
     if (packageManagers.some(pm => pm.name.toLowerCase() === 'yarn')) {
       return 'yarn';
     }
+    if (packageManagers.some(pm => pm.name.toLowerCase() === 'bun')) {
+      return 'bun';
+    }
     
     // Default to npm
     return 'npm';
@@ -231,22 +239,31 @@ export class NodeJSWorkflowGenerator {
   /**
    * Get install command for package manager
    */
-  private getInstallCommand(packageManager: 'npm' | 'yarn' | 'pnpm'): string {
+  private getInstallCommand(packageManager: NodePackageManager): string {
     switch (packageManager) {
       case 'yarn':
         return 'yarn install --frozen-lockfile';
       case 'pnpm':
         return 'pnpm install --frozen-lockfile';
+      case 'bun':
+        return 'bun install --frozen-lockfile';
       default:
         return 'npm ci';
     }
   }
 
+  /**
+   * Prefix for running package.json scripts; `bun build` and `bun test` are bun's own tools
+   */
+  private getRunCommand(packageManager: NodePackageManager): string {
+    return packageManager === 'npm' || packageManager === 'bun' ? `${packageManager} run` : packageManager;
+  }
+
   /**
    * Get build command for framework
    */
-  private getBuildCommand(framework: string, packageManager: 'npm' | 'yarn' | 'pnpm'): string {
-    const runCommand = packageManager === 'npm' ? 'npm run' : packageManager;
+  private getBuildCommand(framework: string, packageManager: NodePackageManager): string {
+    const runCommand = this.getRunCommand(packageManager);
     
     switch (framework.toLowerCase()) {
       case 'react':
@@ -266,8 +283,8 @@ export class NodeJSWorkflowGenerator {
   /**
    * Get test command
    */
-  private getTestCommand(packageManager: 'npm' | 'yarn' | 'pnpm', testingFrameworks: any[]): string {
-    const runCommand = packageManager === 'npm' ? 'npm run' : packageManager;
+  private getTestCommand(packageManager: NodePackageManager, testingFrameworks: any[]): string {
+    const runCommand = this.getRunCommand(packageManager);
     
     // Check for specific test frameworks
     if (testingFrameworks.some(fw => fw.name.toLowerCase() === 'jest')) {
@@ -284,24 +301,24 @@ export class NodeJSWorkflowGenerator {
   /**
    * Get lint command
    */
-  private getLintCommand(packageManager: 'npm' | 'yarn' | 'pnpm', hasLinting: boolean): string {
+  private getLintCommand(packageManager: NodePackageManager, hasLinting: boolean): string {
     if (!hasLinting) {
       return '';
     }
     
-    const runCommand = packageManager === 'npm' ? 'npm run' : packageManager;
+    const runCommand = this.getRunCommand(packageManager);
     return `${runCommand} lint`;
   }
 
   /**
    * Get type check command
    */
-  private getTypeCheckCommand(packageManager: 'npm' | 'yarn' | 'pnpm', hasTypeScript: boolean): string {
+  private getTypeCheckCommand(packageManager: NodePackageManager, hasTypeScript: boolean): string {
     if (!hasTypeScript) {
       return '';
     }
     
-    const runCommand = packageManager === 'npm' ? 'npm run' : packageManager;
+    const runCommand = this.getRunCommand(packageManager);
     return `${runCommand} type-check`;
   }
 
@@ -156,9 +156,8 @@ export class SecurityStepGenerator {
     if (securityLevel === 'standard' || securityLevel === 'enterprise') {
       steps.push({
         name: 'SonarCloud Scan',
-        uses: 'SonarSource/sonarcloud-github-action@master',
+        uses: 'SonarSource/sonarqube-scan-action@v5',
         env: {
-          GITHUB_TOKEN: '${{ secrets.GITHUB_TOKEN }}',
           SONAR_TOKEN: '${{ secrets.SONAR_TOKEN }}',
         },
       });
@@ -168,10 +167,7 @@ export class SecurityStepGenerator {
     if (securityLevel === 'enterprise') {
       steps.push({
         name: 'Run Semgrep',
-        uses: 'returntocorp/semgrep-action@v1',
-        with: {
-          config: 'auto',
-        },
+        run: 'pipx run semgrep ci',
         env: {
           SEMGREP_APP_TOKEN: '${{ secrets.SEMGREP_APP_TOKEN }}',
         },
@@ -644,7 +644,7 @@ export class TemplateFallbackManager {
             },
             {
               name: 'Upload coverage',
-              uses: 'codecov/codecov-action@v4',
+              uses: 'codecov/codecov-action@v5',
               if: 'always()'
             }
           ]
@@ -726,7 +726,7 @@ export class TemplateFallbackManager {
             },
             {
               name: 'Upload coverage',
-              uses: 'codecov/codecov-action@v4',
+              uses: 'codecov/codecov-action@v5',
               if: 'always()'
             }
           ]
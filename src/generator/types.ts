@@ -21,7 +21,7 @@ export interface WorkflowTemplate {
  */
 export interface JobTemplate {
   name: string;
-  runsOn: string | RunsOnConfig;
+  runsOn: string | string[] | RunsOnConfig;
   strategy?: MatrixStrategy;
   steps: StepTemplate[];
   needs?: string[];
@@ -29,9 +29,27 @@ export interface JobTemplate {
   environment?: string | JobEnvironmentConfig;
   permissions?: PermissionConfig;
   services?: Record<string, any>;
+  /** Container image the job's steps run in on a Linux runner; an empty image runs them on the runner itself */
+  container?: string;
   timeout?: number;
   continueOnError?: boolean;
   outputs?: Record<string, string>;
+  defaults?: DefaultsConfig;
+  env?: Record<string, string>;
+  /** Reusable workflow the job calls (./.github/workflows/setup.yml) instead of running steps on runsOn */
+  uses?: string;
+  /** Secrets passed to the called workflow */
+  secrets?: 'inherit';
+}
+
+/**
+ * GitHub composite action (action.yml): steps the calling job runs in place of one of its own
+ */
+export interface CompositeActionTemplate {
+  name: string;
+  description: string;
+  inputs: Record<string, Pick<WorkflowInput, 'description' | 'required' | 'default'>>;
+  steps: StepTemplate[];
 }
 
 /**
@@ -47,8 +65,12 @@ export interface StepTemplate {
   if?: string;
   continueOnError?: boolean;
   timeout?: number;
+  /** Times the step runs again after failing; GitLab retries the whole job instead */
+  retries?: number;
   shell?: string;
   workingDirectory?: string;
+  /** Where the step comes from (package.json scripts.test, README prerequisites); unset for the generator's defaults */
+  source?: string;
 }
 
 /**
@@ -59,6 +81,7 @@ export interface TriggerConfig {
   pullRequest?: PullRequestTrigger;
   schedule?: ScheduleTrigger[];
   workflowDispatch?: WorkflowDispatchTrigger;
+  workflowCall?: WorkflowCallTrigger;
   release?: ReleaseTrigger;
   issues?: IssuesTrigger;
   pullRequestTarget?: PullRequestTargetTrigger;
@@ -102,6 +125,31 @@ export interface WorkflowDispatchTrigger {
   inputs?: Record<string, WorkflowInput>;
 }
 
+/**
+ * Reusable workflow (workflow_call) trigger configuration
+ */
+export interface WorkflowCallTrigger {
+  inputs?: Record<string, WorkflowInput>;
+  outputs?: Record<string, WorkflowCallOutput>;
+  secrets?: Record<string, WorkflowCallSecret>;
+}
+
+/**
+ * Value a reusable workflow returns to its caller, read from a job output
+ */
+export interface WorkflowCallOutput {
+  description: string;
+  value: string;
+}
+
+/**
+ * Secret a reusable workflow reads, passed by its caller
+ */
+export interface WorkflowCallSecret {
+  description?: string;
+  required: boolean;
+}
+
 /**
  * Workflow input configuration
  */
@@ -196,7 +244,8 @@ export interface PermissionConfig {
  */
 export interface ConcurrencyConfig {
   group: string;
-  cancelInProgress?: boolean;
+  /** true, false, or an expression deciding per run (e.g. cancel everything but default branch pushes) */
+  cancelInProgress?: boolean | string;
 }
 
 /**
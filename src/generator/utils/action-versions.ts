@@ -0,0 +1,221 @@
+/**
+ * Action versions - The one place the ref of every action generated workflows use is kept
+ */
+
+/**
+ * A `uses:` line referencing an action or reusable workflow by ref, with an optional trailing comment
+ */
+export const USES_LINE = /^(\s*(?:-\s+)?uses:\s*)(['"]?)([\w.-]+\/[\w./-]+)@([\w./-]+)\2(\s+#.*)?$/;
+
+export const COMMIT_SHA = /^[0-9a-f]{40}$/;
+
+/**
+ * Refs that are release tags (v4, v0.10.0), the only ones pinned; a branch such as main or
+ * stable is followed on purpose, and dtolnay/rust-toolchain reads the toolchain from its ref
+ */
+const RELEASE_TAG = /^v\d+(\.\d+)*$/;
+
+/**
+ * Ref each action is used at, by repository; the actions of a repository (github/codeql-action/init
+ * and /analyze) share its ref. Workflows referencing an older ref, which may run on a deprecated
+ * Node runtime, are moved to this one.
+ */
+export const ACTION_VERSIONS: Record<string, string> = {
+  '8398a7/action-slack': 'v3',
+  'Azure/static-web-apps-deploy': 'v1',
+  'SonarSource/sonarqube-scan-action': 'v5',
+  'actions/cache': 'v4',
+  'actions/checkout': 'v4',
+  'actions/configure-pages': 'v5',
+  'actions/deploy-pages': 'v4',
+  'actions/download-artifact': 'v4',
+  'actions/github-script': 'v7',
+  'actions/setup-dotnet': 'v4',
+  'actions/setup-go': 'v5',
+  'actions/setup-java': 'v4',
+  'actions/setup-node': 'v4',
+  'actions/setup-python': 'v5',
+  'actions/upload-artifact': 'v4',
+  'actions/upload-pages-artifact': 'v3',
+  'amondnet/vercel-action': 'v25',
+  'aquasecurity/trivy-action': 'master',
+  'aws-actions/configure-aws-credentials': 'v4',
+  'azure/login': 'v2',
+  'bufbuild/buf-action': 'v1',
+  'codecov/codecov-action': 'v5',
+  'coverallsapp/github-action': 'v2',
+  'cypress-io/github-action': 'v6',
+  'dart-lang/setup-dart': 'v1',
+  'dawidd6/action-send-mail': 'v3',
+  'docker/build-push-action': 'v6',
+  'docker/login-action': 'v3',
+  'docker/setup-buildx-action': 'v3',
+  'dorny/paths-filter': 'v3',
+  'dtolnay/rust-toolchain': 'stable',
+  'erlef/setup-beam': 'v1',
+  'fossas/fossa-action': 'main',
+  'github/codeql-action': 'v3',
+  'github/dependabot-action': 'v1',
+  'github/dependency-review-action': 'v4',
+  'gitleaks/gitleaks-action': 'v2',
+  'golangci/golangci-lint-action': 'v6',
+  'google-github-actions/auth': 'v2',
+  'goreleaser/goreleaser-action': 'v6',
+  'gradle/actions': 'v4',
+  'hashicorp/setup-terraform': 'v3',
+  'haskell-actions/hlint-setup': 'v2',
+  'haskell-actions/setup': 'v2',
+  'jwlawson/actions-setup-cmake': 'v2',
+  'mikepenz/release-changelog-builder-action': 'v5',
+  'nrwl/nx-set-shas': 'v4',
+  'nwtgck/actions-netlify': 'v3',
+  'oven-sh/setup-bun': 'v2',
+  'paambaati/codeclimate-action': 'v5.0.0',
+  'peaceiris/actions-hugo': 'v3',
+  'peter-evans/create-pull-request': 'v7',
+  'peter-evans/repository-dispatch': 'v3',
+  'pnpm/action-setup': 'v4',
+  'ruby/setup-ruby': 'v1',
+  'securecodewarrior/github-action-add-sarif': 'v1',
+  'snok/install-poetry': 'v1',
+  'snyk/actions': 'master',
+  'softprops/action-gh-release': 'v2',
+  'subosito/flutter-action': 'v2',
+  'swift-actions/setup-swift': 'v2',
+  'trstringer/manual-approval': 'v1',
+  'trufflesecurity/trufflehog': 'main',
+  'zaproxy/action-baseline': 'v0.10.0',
+  'zaproxy/action-full-scan': 'v0.10.0'
+};
+
+/**
+ * An action that is no longer maintained, and what to use instead
+ */
+export interface DeprecatedAction {
+  /** Why it is deprecated: archived by its owners, or superseded by another action */
+  reason: 'archived' | 'superseded';
+  replacement: string;
+}
+
+/**
+ * Actions workflows should move off, by repository. They are not in ACTION_VERSIONS, so they keep
+ * the ref they are used at: their replacements take other inputs, so a workflow using one is
+ * reported rather than rewritten.
+ */
+export const DEPRECATED_ACTIONS: Record<string, DeprecatedAction> = {
+  'SonarSource/sonarcloud-github-action': { reason: 'superseded', replacement: 'SonarSource/sonarqube-scan-action' },
+  'actions-rs/cargo': { reason: 'archived', replacement: 'cargo run after dtolnay/rust-toolchain' },
+  'actions-rs/toolchain': { reason: 'archived', replacement: 'dtolnay/rust-toolchain' },
+  'bufbuild/buf-setup-action': { reason: 'superseded', replacement: 'bufbuild/buf-action' },
+  'returntocorp/semgrep-action': { reason: 'superseded', replacement: 'semgrep ci' }
+};
+
+/**
+ * Where an action is used from, as the registry and its overrides resolve it
+ */
+export interface ResolvedAction {
+  /** Action path, its repository replaced when an override names another (my-org/checkout) */
+  action: string;
+  ref: string;
+  /** Whether an override replaced the action's repository, whose commits are then unknown */
+  mirrored: boolean;
+}
+
+/**
+ * Resolve an action of the registry: its ref, or what an override keyed by its path or repository
+ * replaces it with - a ref (v4.2.2), or a repository and ref (my-org/checkout@v4) for teams that
+ * mirror actions. Undefined for actions neither knows, which keep the ref they are used at.
+ */
+export function resolveActionVersion(action: string, overrides: Record<string, string> = {}): ResolvedAction | undefined {
+  const repository = action.split('/').slice(0, 2).join('/');
+  const override = overrides[action] ?? overrides[repository];
+  if (override === undefined) {
+    const ref = ACTION_VERSIONS[repository];
+    return ref ? { action, ref, mirrored: false } : undefined;
+  }
+
+  const at = override.lastIndexOf('@');
+  if (at < 0) {
+    return { action, ref: override, mirrored: false };
+  }
+  const mirror = override.slice(0, at);
+  // A repository override keeps the path of the action within it (github/codeql-action/init)
+  const path = overrides[action] === undefined ? `${mirror}${action.slice(repository.length)}` : mirror;
+  return { action: path, ref: override.slice(at + 1), mirrored: mirror !== repository && mirror !== action };
+}
+
+export interface ActionVersionOptions {
+  /** Refs, or repositories and refs (my-org/checkout@v4), replacing the registry's by action path or repository */
+  overrides?: Record<string, string>;
+  /** Pin the actions of the registry to the commit SHAs in shas, as `@<sha> # v4` */
+  pin?: boolean;
+  /** Commit SHAs of action references (`actions/checkout@v4`) */
+  shas?: Record<string, string>;
+}
+
+export interface ActionVersionResult {
+  content: string;
+  /** Deprecated actions the workflow uses, and actions left unpinned as no commit SHA is known for them */
+  warnings: string[];
+}
+
+/**
+ * Rewrite the `uses:` lines of a workflow to the refs of the registry and its overrides, pinning
+ * them to commit SHAs when asked. Only release tags of actions the registry knows are pinned:
+ * mirrored actions, actions it does not know and branch refs keep their ref. Actions of
+ * DEPRECATED_ACTIONS are reported with their replacement. The file is edited line by line, and
+ * lines already pinned to a SHA are left as they are.
+ */
+export function applyActionVersions(content: string, options: ActionVersionOptions = {}): ActionVersionResult {
+  const warnings: string[] = [];
+  const newline = content.includes('\r\n') ? '\r\n' : '\n';
+
+  const lines = content.split(/\r?\n/).map(line => {
+    const match = line.match(USES_LINE);
+    if (!match) {
+      return line;
+    }
+
+    const [, prefix, quote, action, ref, comment] = match;
+    const deprecated = DEPRECATED_ACTIONS[action!.split('/').slice(0, 2).join('/')];
+    if (deprecated) {
+      warnings.push(`${action}@${ref} is deprecated (${deprecated.reason}) - use ${deprecated.replacement} instead`);
+    }
+    if (COMMIT_SHA.test(ref!)) {
+      return line;
+    }
+
+    const resolved = resolveActionVersion(action!, options.overrides);
+    if (!resolved) {
+      return line;
+    }
+
+    const reference = `${resolved.action}@${resolved.ref}`;
+    if (options.pin && !resolved.mirrored && RELEASE_TAG.test(resolved.ref)) {
+      const sha = options.shas?.[reference];
+      if (sha) {
+        return `${prefix}${quote}${resolved.action}@${sha}${quote} # ${resolved.ref}${comment ? ` ${comment.trim()}` : ''}`;
+      }
+      warnings.push(`${reference} is not pinned - no commit SHA is known for it`);
+    }
+    return reference === `${action}@${ref}` ? line : `${prefix}${quote}${reference}${quote}${comment || ''}`;
+  });
+
+  return { content: lines.join(newline), warnings: [...new Set(warnings)] };
+}
+
+/**
+ * Action references (`actions/checkout@v4`) applyActionVersions would pin in a workflow,
+ * deduplicated in the order they appear, for resolving their commit SHAs beforehand
+ */
+export function findPinnableActions(content: string, overrides: Record<string, string> = {}): string[] {
+  const references = new Set<string>();
+  for (const line of content.split(/\r?\n/)) {
+    const match = line.match(USES_LINE);
+    const resolved = match && !COMMIT_SHA.test(match[4]!) ? resolveActionVersion(match[3]!, overrides) : undefined;
+    if (resolved && !resolved.mirrored && RELEASE_TAG.test(resolved.ref)) {
+      references.add(`${resolved.action}@${resolved.ref}`);
+    }
+  }
+  return [...references];
+}
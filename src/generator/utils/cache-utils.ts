@@ -2,7 +2,47 @@
  * Cache utilities for workflow generation optimization
  */
 
-import { CacheStrategy, CacheConfig } from '../types';
+import { CacheStrategy, CacheConfig, StepTemplate } from '../types';
+
+/**
+ * Step id of the date lookup that keys caches for projects without a committed lockfile
+ */
+export const CACHE_DATE_STEP_ID = 'cache-date';
+
+/**
+ * Dependency cache locations per package manager. `keyFiles` are hashed into the key;
+ * `lockFiles` must be committed for that key to be meaningful (empty when the tool has no lockfile).
+ */
+const DEPENDENCY_CACHES: Record<string, { paths: string[]; keyFiles: string[]; lockFiles: string[] }> = {
+  npm: { paths: ['~/.npm'], keyFiles: ['package-lock.json'], lockFiles: ['package-lock.json'] },
+  yarn: { paths: ['~/.cache/yarn', '.yarn/cache'], keyFiles: ['yarn.lock'], lockFiles: ['yarn.lock'] },
+  pnpm: { paths: ['~/.local/share/pnpm/store'], keyFiles: ['pnpm-lock.yaml'], lockFiles: ['pnpm-lock.yaml'] },
+  bun: { paths: ['~/.bun/install/cache'], keyFiles: ['bun.lockb', 'bun.lock'], lockFiles: ['bun.lockb', 'bun.lock'] },
+  pip: { paths: ['~/.cache/pip'], keyFiles: ['requirements*.txt'], lockFiles: ['requirements.txt'] },
+  poetry: { paths: ['~/.cache/pypoetry'], keyFiles: ['poetry.lock'], lockFiles: ['poetry.lock'] },
+  pipenv: { paths: ['~/.cache/pipenv'], keyFiles: ['Pipfile.lock'], lockFiles: ['Pipfile.lock'] },
+  go: { paths: ['~/go/pkg/mod'], keyFiles: ['go.sum'], lockFiles: ['go.sum'] },
+  cargo: { paths: ['~/.cargo', 'target'], keyFiles: ['Cargo.lock'], lockFiles: ['Cargo.lock'] },
+  mix: { paths: ['deps', '_build'], keyFiles: ['mix.lock'], lockFiles: ['mix.lock'] },
+  swiftpm: { paths: ['.build'], keyFiles: ['Package.resolved'], lockFiles: ['Package.resolved'] },
+  pub: { paths: ['~/.pub-cache'], keyFiles: ['pubspec.lock'], lockFiles: ['pubspec.lock'] },
+  stack: { paths: ['~/.stack', '.stack-work'], keyFiles: ['stack.yaml', 'stack.yaml.lock'], lockFiles: [] },
+  cabal: { paths: ['~/.cabal/store', 'dist-newstyle'], keyFiles: ['cabal.project.freeze'], lockFiles: ['cabal.project.freeze'] },
+  nuget: { paths: ['~/.nuget/packages'], keyFiles: ['*.csproj', '*.fsproj', '*.vbproj'], lockFiles: [] },
+  cmake: { paths: ['build'], keyFiles: ['CMakeLists.txt', 'CMakePresets.json'], lockFiles: [] },
+  maven: { paths: ['~/.m2/repository'], keyFiles: ['pom.xml'], lockFiles: [] },
+  gradle: { paths: ['~/.gradle/caches', '~/.gradle/wrapper'], keyFiles: ['*.gradle*', 'gradle-wrapper.properties'], lockFiles: [] }
+};
+
+/**
+ * Dependency cache resolved for a language and package manager
+ */
+export interface DependencyCacheStrategy {
+  tool: string;
+  strategy: CacheStrategy;
+  /** Set when no lockfile is committed and the key falls back to the current date */
+  warning?: string;
+}
 
 /**
  * Cache strategy generator for different package managers and build tools
@@ -298,6 +338,117 @@ export class CacheStrategyGenerator {
     ];
   }
 
+  /**
+   * Resolve the dependency cache for a language and package manager (or Java build tool).
+   * `lockFiles` lists the lockfiles committed to the repository; when it is known and the
+   * expected lockfile is missing, the key rotates daily instead of hashing a file that does not exist.
+   */
+  resolveDependencyCache(language: string, packageManager?: string, lockFiles?: string[]): DependencyCacheStrategy | undefined {
+    const tool = this.getDependencyTool(language, packageManager);
+    const config = tool ? DEPENDENCY_CACHES[tool] : undefined;
+    if (!tool || !config) {
+      return undefined;
+    }
+
+    const prefix = `${tool}-\${{ runner.os }}-`;
+    const missingLockFile = lockFiles !== undefined &&
+      config.lockFiles.length > 0 &&
+      !config.lockFiles.some(lockFile => lockFiles.includes(lockFile));
+
+    if (missingLockFile) {
+      return {
+        tool,
+        strategy: {
+          type: 'dependencies',
+          paths: config.paths,
+          key: `${prefix}\${{ steps.${CACHE_DATE_STEP_ID}.outputs.date }}`,
+          restoreKeys: [prefix]
+        },
+        warning: `No ${config.lockFiles.join(' or ')} is committed, so the ${tool} cache key rotates daily. Commit the lockfile for reproducible installs and stable caching.`
+      };
+    }
+
+    const keyFiles = config.keyFiles.map(file => `'**/${file}'`).join(', ');
+    return {
+      tool,
+      strategy: {
+        type: 'dependencies',
+        paths: config.paths,
+        key: `${prefix}\${{ hashFiles(${keyFiles}) }}`,
+        restoreKeys: [prefix]
+      }
+    };
+  }
+
+  /**
+   * Create the actions/cache step for a resolved dependency cache,
+   * preceded by the date lookup when the key is date-based
+   */
+  createDependencyCacheSteps(cache: DependencyCacheStrategy): StepTemplate[] {
+    const steps: StepTemplate[] = [];
+
+    if (cache.strategy.key.includes(`steps.${CACHE_DATE_STEP_ID}.`)) {
+      steps.push({
+        name: 'Get cache date',
+        id: CACHE_DATE_STEP_ID,
+        run: 'echo "date=$(date -u +%Y-%m-%d)" >> "$GITHUB_OUTPUT"',
+        shell: 'bash'
+      });
+    }
+
+    steps.push({
+      name: 'Cache dependencies',
+      uses: 'actions/cache@v4',
+      with: {
+        path: cache.strategy.paths.join('\n'),
+        key: cache.strategy.key,
+        'restore-keys': cache.strategy.restoreKeys.join('\n')
+      }
+    });
+
+    return steps;
+  }
+
+  /**
+   * Map a language and package manager onto the tool whose cache is restored
+   */
+  private getDependencyTool(language: string, packageManager?: string): string | undefined {
+    const manager = packageManager?.toLowerCase();
+
+    switch (language.toLowerCase()) {
+      case 'node':
+      case 'javascript':
+      case 'typescript':
+        return manager && ['npm', 'yarn', 'pnpm', 'bun'].includes(manager) ? manager : 'npm';
+      case 'python':
+        return manager && ['pip', 'poetry', 'pipenv'].includes(manager) ? manager : 'pip';
+      case 'java':
+        return manager === 'gradle' ? 'gradle' : 'maven';
+      case 'rust':
+        return 'cargo';
+      case 'go':
+      case 'golang':
+        return 'go';
+      case 'elixir':
+        return 'mix';
+      case 'ruby':
+        // setup-ruby installs and caches the bundle itself
+        return undefined;
+      case 'swift':
+        return 'swiftpm';
+      case 'c#':
+        return 'nuget';
+      case 'dart':
+        return 'pub';
+      case 'haskell':
+        return manager === 'cabal' ? 'cabal' : 'stack';
+      case 'c/c++':
+        return 'cmake';
+      default:
+        return undefined;
+    }
+  }
+
   /**
    * Generate cache key from components with proper escaping
    */
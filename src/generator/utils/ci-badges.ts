@@ -0,0 +1,31 @@
+/**
+ * Existing CI advertised through README status badges
+ */
+
+import { DetectionResult } from '../interfaces';
+
+/**
+ * Display names of the CI providers badges are recognized for
+ */
+const CI_PROVIDER_NAMES: Record<string, string> = {
+  'github-actions': 'GitHub Actions',
+  travis: 'Travis CI',
+  circleci: 'CircleCI',
+  gitlab: 'GitLab CI',
+  'azure-pipelines': 'Azure Pipelines',
+  appveyor: 'AppVeyor'
+};
+
+/**
+ * Names of the CI providers the README has status badges for, in badge order without duplicates
+ */
+export function getExistingCIProviders(detectionResult: DetectionResult): string[] {
+  const names: string[] = [];
+  for (const badge of detectionResult.ciBadges || []) {
+    const name = CI_PROVIDER_NAMES[badge.provider] || badge.provider;
+    if (!names.includes(name)) {
+      names.push(name);
+    }
+  }
+  return names;
+}
@@ -0,0 +1,62 @@
+/**
+ * Validation of the 5-field cron expressions GitHub Actions schedules accept
+ */
+
+const MONTH_NAMES = ['JAN', 'FEB', 'MAR', 'APR', 'MAY', 'JUN', 'JUL', 'AUG', 'SEP', 'OCT', 'NOV', 'DEC'];
+
+const WEEKDAY_NAMES = ['SUN', 'MON', 'TUE', 'WED', 'THU', 'FRI', 'SAT'];
+
+/**
+ * Fields in order, with their allowed range and the names that may replace numbers
+ */
+const CRON_FIELDS: Array<{ name: string; min: number; max: number; names?: string[] }> = [
+  { name: 'minute', min: 0, max: 59 },
+  { name: 'hour', min: 0, max: 23 },
+  { name: 'day of month', min: 1, max: 31 },
+  { name: 'month', min: 1, max: 12, names: MONTH_NAMES },
+  { name: 'day of week', min: 0, max: 6, names: WEEKDAY_NAMES }
+];
+
+/**
+ * Check a cron expression; returns what is wrong with it, or undefined when it is valid.
+ * Each field is a comma separated list of `*`, a value or a range, optionally stepped with `/n`.
+ */
+export function validateCron(expression: string): string | undefined {
+  const fields = expression.trim().split(/\s+/).filter(field => field !== '');
+  if (fields.length !== CRON_FIELDS.length) {
+    return `expected 5 fields (minute hour day-of-month month day-of-week), got ${fields.length}`;
+  }
+
+  for (let index = 0; index < fields.length; index++) {
+    const spec = CRON_FIELDS[index]!;
+    const field = fields[index]!;
+    const parse = (value: string): number | undefined => {
+      const named = spec.names?.indexOf(value.toUpperCase()) ?? -1;
+      if (named >= 0) {
+        return spec.min + named;
+      }
+      return /^\d+$/.test(value) ? Number(value) : undefined;
+    };
+
+    for (const item of field.split(',')) {
+      const match = item.match(/^([^/]+)(?:\/(\d+))?$/);
+      const step = match?.[2] !== undefined ? Number(match[2]) : undefined;
+      if (!match || step === 0) {
+        return `invalid ${spec.name} '${field}'`;
+      }
+
+      if (match[1] === '*') {
+        continue;
+      }
+      const [from, to, extra] = match[1]!.split('-');
+      const start = parse(from!);
+      const end = to === undefined ? start : parse(to);
+      if (extra !== undefined || start === undefined || end === undefined ||
+        start < spec.min || end > spec.max || start > end) {
+        return `invalid ${spec.name} '${field}' (allowed ${spec.min}-${spec.max})`;
+      }
+    }
+  }
+
+  return undefined;
+}
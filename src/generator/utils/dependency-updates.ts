@@ -0,0 +1,127 @@
+/**
+ * Dependabot and Renovate configuration for the ecosystems a project was detected to use
+ */
+
+import * as yaml from 'js-yaml';
+import { DetectionResult } from '../interfaces';
+
+/**
+ * Where Dependabot reads its configuration, relative to the repository root
+ */
+export const DEPENDABOT_CONFIG_PATH = '.github/dependabot.yml';
+
+/**
+ * Where Renovate reads its configuration, relative to the repository root
+ */
+export const RENOVATE_CONFIG_PATH = 'renovate.json';
+
+export type DependencyEcosystem = 'github-actions' | 'npm' | 'pip' | 'gomod' | 'cargo' | 'docker';
+
+/**
+ * An ecosystem to keep up to date and the repository directory holding its manifest
+ */
+export interface DependencyUpdateTarget {
+  ecosystem: DependencyEcosystem;
+  /** Repository-relative directory, "/" for the root */
+  directory: string;
+}
+
+/**
+ * Ecosystems of each language, by lowercase language name
+ */
+const LANGUAGE_ECOSYSTEMS: Record<string, DependencyEcosystem> = {
+  javascript: 'npm',
+  typescript: 'npm',
+  python: 'pip',
+  go: 'gomod',
+  rust: 'cargo'
+};
+
+/**
+ * Renovate managers reading the manifests of each ecosystem
+ */
+const RENOVATE_MANAGERS: Record<DependencyEcosystem, string[]> = {
+  'github-actions': ['github-actions'],
+  npm: ['npm'],
+  pip: ['pip_requirements', 'pep621', 'poetry', 'pipenv'],
+  gomod: ['gomod'],
+  cargo: ['cargo'],
+  docker: ['dockerfile']
+};
+
+const RENOVATE_SCHEDULE = ['before 6am on monday'];
+
+/**
+ * What to keep up to date for projects (the repository-relative path of each, "." for the root,
+ * and its detection): the ecosystem of each detected language in the directory of its manifest,
+ * the directory of each Dockerfile, and always the workflows' own actions. Workspace packages
+ * share the root install, so only monorepo packages add directories of their own. Sorted by
+ * ecosystem, then directory.
+ */
+export function collectUpdateTargets(projects: Array<{ path: string; detectionResult: DetectionResult }>): DependencyUpdateTarget[] {
+  const targets = new Map<string, DependencyUpdateTarget>();
+  const add = (ecosystem: DependencyEcosystem, directory: string) => {
+    targets.set(`${ecosystem}:${directory}`, { ecosystem, directory });
+  };
+
+  add('github-actions', '/');
+  for (const { path, detectionResult } of projects) {
+    for (const language of detectionResult.languages) {
+      const ecosystem = LANGUAGE_ECOSYSTEMS[language.name.toLowerCase()];
+      if (ecosystem) {
+        add(ecosystem, toDirectory(path, language.directory ?? detectionResult.workingDirectory ?? '.'));
+      }
+    }
+    for (const image of detectionResult.dockerImages || []) {
+      add('docker', toDirectory(path, image.dockerfile.includes('/') ? image.dockerfile.slice(0, image.dockerfile.lastIndexOf('/')) : '.'));
+    }
+  }
+
+  return [...targets.values()].sort((a, b) => a.ecosystem.localeCompare(b.ecosystem) || a.directory.localeCompare(b.directory));
+}
+
+/**
+ * .github/dependabot.yml checking every target weekly
+ */
+export function renderDependabotConfig(targets: DependencyUpdateTarget[]): string {
+  return yaml.dump({
+    version: 2,
+    updates: targets.map(target => ({
+      'package-ecosystem': target.ecosystem,
+      directory: target.directory,
+      schedule: { interval: 'weekly' }
+    }))
+  }, { lineWidth: -1, noRefs: true });
+}
+
+/**
+ * renovate.json enabling the managers of the targets, with a package rule per ecosystem
+ * limiting it to the target directories on a weekly schedule
+ */
+export function renderRenovateConfig(targets: DependencyUpdateTarget[]): string {
+  const ecosystems = [...new Set(targets.map(target => target.ecosystem))];
+  const config = {
+    $schema: 'https://docs.renovatebot.com/renovate-schema.json',
+    extends: ['config:recommended'],
+    enabledManagers: ecosystems.flatMap(ecosystem => RENOVATE_MANAGERS[ecosystem]),
+    packageRules: ecosystems.map(ecosystem => ({
+      matchManagers: RENOVATE_MANAGERS[ecosystem],
+      ...(ecosystem !== 'github-actions' && {
+        matchFileNames: targets
+          .filter(target => target.ecosystem === ecosystem)
+          .map(target => target.directory === '/' ? '**' : `${target.directory.slice(1)}/**`)
+      }),
+      schedule: RENOVATE_SCHEDULE
+    }))
+  };
+  return `${JSON.stringify(config, null, 2)}\n`;
+}
+
+/**
+ * Dependabot directory ("/", "/services/api") of a directory relative to a project
+ */
+function toDirectory(projectPath: string, directory: string): string {
+  const segments = [...projectPath.split('/'), ...directory.split('/')]
+    .filter(segment => segment !== '' && segment !== '.');
+  return `/${segments.join('/')}`;
+}
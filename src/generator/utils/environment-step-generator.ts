@@ -163,7 +163,7 @@ export class EnvironmentStepGenerator {
         case 'azure':
           steps.push({
             name: `Azure Login for ${env.name}`,
-            uses: 'azure/login@v1',
+            uses: 'azure/login@v2',
             with: {
               'client-id': `\${{ secrets.AZURE_CLIENT_ID }}`,
               'tenant-id': `\${{ secrets.AZURE_TENANT_ID }}`,
@@ -0,0 +1,74 @@
+/**
+ * CI configuration already committed to a repository
+ */
+
+import { promises as fs } from 'fs';
+import * as path from 'path';
+import { Provider } from '../interfaces';
+
+/**
+ * Files each provider reads its configuration from, relative to the repository root
+ */
+const PROVIDER_CONFIG_FILES: Array<[Provider, string]> = [
+  [Provider.GitLab, '.gitlab-ci.yml'],
+  [Provider.CircleCI, '.circleci/config.yml'],
+  [Provider.AzurePipelines, 'azure-pipelines.yml'],
+  [Provider.BitbucketPipelines, 'bitbucket-pipelines.yml'],
+  [Provider.Jenkins, 'Jenkinsfile']
+];
+
+/**
+ * Display names of the providers, for messages
+ */
+export const PROVIDER_NAMES: Record<Provider, string> = {
+  [Provider.GitHubActions]: 'GitHub Actions',
+  [Provider.GitLab]: 'GitLab CI',
+  [Provider.CircleCI]: 'CircleCI',
+  [Provider.AzurePipelines]: 'Azure Pipelines',
+  [Provider.BitbucketPipelines]: 'Bitbucket Pipelines',
+  [Provider.Jenkins]: 'Jenkins'
+};
+
+/**
+ * Providers the repository at root already has configuration for: GitHub Actions when
+ * .github/workflows holds a workflow file, the others when their config file exists.
+ * In Provider order.
+ */
+export async function detectExistingCI(root: string): Promise<Provider[]> {
+  const providers: Provider[] = [];
+
+  try {
+    const workflows = await fs.readdir(path.join(root, '.github', 'workflows'));
+    if (workflows.some(file => /\.ya?ml$/.test(file))) {
+      providers.push(Provider.GitHubActions);
+    }
+  } catch {
+    // No workflows directory
+  }
+
+  for (const [provider, file] of PROVIDER_CONFIG_FILES) {
+    try {
+      await fs.access(path.join(root, ...file.split('/')));
+      providers.push(provider);
+    } catch {
+      // Not configured
+    }
+  }
+
+  return providers;
+}
+
+/**
+ * The provider to generate for when none was chosen: the one a repository is already
+ * configured for. Without CI configuration, or with configuration for several providers,
+ * there is no safe guess, so they must be chosen with --provider.
+ */
+export function resolveExistingProvider(existing: Provider[]): Provider {
+  if (existing.length === 0) {
+    throw new Error('Found no CI configuration - pass --provider to choose which provider to generate for');
+  }
+  if (existing.length > 1) {
+    throw new Error(`Found CI configuration for ${existing.map(provider => PROVIDER_NAMES[provider]).join(' and ')} - pass --provider to choose which one to generate for`);
+  }
+  return existing[0]!;
+}
@@ -2,4 +2,14 @@
 export * from './cache-utils';
 export * from './optimization-utils';
 export * from './formatting-utils';
-export * from './yaml-utils';
\ No newline at end of file
+export * from './yaml-utils';
+export * from './workflow-merge';
+export * from './ci-badges';
+export * from './cron';
+export * from './workflow-diff';
+export * from './retry';
+export * from './path-filters';
+export * from './plan-summary';
+export * from './runner-images';
+export * from './step-order';
+export * from './action-versions';
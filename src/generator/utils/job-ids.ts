@@ -0,0 +1,52 @@
+/**
+ * Stable ids of generated CI jobs, for configuration that refers to them
+ */
+
+/**
+ * Ids of the jobs CI workflows are made of, which disabledJobs refers to them by. Per-crate and
+ * per-package test jobs are unit-tests-<name>, Go build tag test jobs integration-tests-<tag>;
+ * disabling coverage also drops the upload steps.
+ */
+export const CI_JOB_IDS = [
+  'lint',
+  'pre-commit',
+  'ci',
+  'affected',
+  'build',
+  'unit-tests',
+  'integration-tests',
+  'e2e-tests',
+  'security-scan',
+  'docker',
+  'pages',
+  'terraform',
+  'license-check',
+  'api-lint',
+  'buf',
+  'changelog',
+  'codeowners',
+  'coverage'
+];
+
+/**
+ * Categories disabledJobs can name to leave out every job of that kind
+ */
+export const CI_JOB_CATEGORIES: Record<string, RegExp> = {
+  test: /^(unit|integration|e2e)-tests(-.+)?$/
+};
+
+/**
+ * Whether a disabledJobs entry names a generated job: an id, a category, a per-crate or
+ * per-package test job or a Go build tag test job
+ */
+export function isKnownJobId(id: string): boolean {
+  return CI_JOB_IDS.includes(id) || Object.prototype.hasOwnProperty.call(CI_JOB_CATEGORIES, id) || /^(unit|integration)-tests-.+$/.test(id);
+}
+
+/**
+ * Whether disabledJobs leaves out the job called name, by its id or its category
+ */
+export function isJobDisabled(name: string, disabledJobs: string[] = []): boolean {
+  return disabledJobs.some(id => id === name ||
+    (Object.prototype.hasOwnProperty.call(CI_JOB_CATEGORIES, id) && CI_JOB_CATEGORIES[id]!.test(name)));
+}
@@ -0,0 +1,50 @@
+/**
+ * Matching changed files against the path filters of generated workflows
+ */
+
+/**
+ * Regular expression for a GitHub Actions path filter pattern: `*` matches within a path
+ * segment, `?` one character of it and `**` any number of segments, none included
+ */
+export function pathPatternToRegExp(pattern: string): RegExp {
+  let source = '';
+  for (let index = 0; index < pattern.length; index++) {
+    const char = pattern[index]!;
+    if (char === '*' && pattern[index + 1] === '*') {
+      const leading = index === 0 || pattern[index - 1] === '/';
+      const trailing = pattern[index + 2] === '/';
+      if (leading && trailing) {
+        // dir/**/file also matches dir/file
+        source += '(?:.*/)?';
+        index += 2;
+      } else {
+        source += '.*';
+        index += 1;
+      }
+    } else if (char === '*') {
+      source += '[^/]*';
+    } else if (char === '?') {
+      source += '[^/]';
+    } else {
+      source += char.replace(/[.+^${}()|[\]\\]/g, '\\$&');
+    }
+  }
+  // dir/** matches dir itself too
+  return new RegExp(`^${source.replace(/\/\.\*$/, '(?:/.*)?')}$`);
+}
+
+/**
+ * Pattern deciding that a file passes a list of path filters, or undefined when it does not.
+ * Like GitHub, patterns apply in order and a `!` pattern excludes what the earlier ones
+ * included, so the last matching pattern wins.
+ */
+export function matchPathFilters(patterns: string[], file: string): string | undefined {
+  let decided: string | undefined;
+  for (const pattern of patterns) {
+    const negated = pattern.startsWith('!');
+    if (pathPatternToRegExp(negated ? pattern.slice(1) : pattern).test(file)) {
+      decided = negated ? undefined : pattern;
+    }
+  }
+  return decided;
+}
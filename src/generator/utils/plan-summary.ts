@@ -0,0 +1,112 @@
+/**
+ * Plan summary - What generation found and is about to write, told in a few lines for the operator
+ */
+
+import { posix } from 'path';
+import * as yaml from 'js-yaml';
+import { DetectionResult, GenerationOptions, WorkflowOutput } from '../interfaces';
+
+/**
+ * Where the workflows go and what was left out, besides what the generation options say
+ */
+export interface PlanSummaryOptions {
+  /** Directory the workflow files are written to, relative to the repository root */
+  outputDirectory?: string;
+  /** Workflow files left out because nothing applied to them */
+  skippedWorkflows?: string[];
+}
+
+/**
+ * Top-level GitLab CI keywords, which are not jobs
+ */
+const GITLAB_KEYWORDS = new Set([
+  'default', 'include', 'stages', 'variables', 'workflow', 'image', 'services', 'cache', 'before_script', 'after_script'
+]);
+
+/**
+ * Terse narrative of a generation: the languages and tools detected, each workflow file with
+ * its jobs, the jobs disabledJobs suppressed, the workflows left out and the warnings raised.
+ * Only depends on its inputs, in their order, so the same run always reads the same.
+ */
+export function planSummary(
+  detectionResult: DetectionResult,
+  workflows: WorkflowOutput[],
+  options: GenerationOptions,
+  summaryOptions: PlanSummaryOptions = {}
+): string {
+  const lines = [`Detected: ${describeDetection(detectionResult)}.`];
+
+  for (const workflow of workflows.filter(workflow => workflow.content !== '')) {
+    const file = posix.join(summaryOptions.outputDirectory || '.', workflow.filename);
+    const jobs = listJobs(workflow.content);
+    lines.push(jobs.length > 0 ? `Will generate ${file} with jobs: ${jobs.join(', ')}.` : `Will generate ${file}.`);
+  }
+  if (options.disabledJobs?.length) {
+    lines.push(`Suppressed jobs: ${options.disabledJobs.join(', ')} (disabledJobs).`);
+  }
+  const skipped = [...workflows.filter(workflow => workflow.content === '').map(workflow => workflow.filename), ...(summaryOptions.skippedWorkflows || [])];
+  if (skipped.length > 0) {
+    lines.push(`Not generated: ${[...new Set(skipped)].join(', ')}.`);
+  }
+
+  const warnings = [...new Set(workflows.flatMap(workflow => workflow.metadata.warnings))];
+  if (warnings.length > 0) {
+    lines.push('Warnings:', ...warnings.map(warning => `  - ${warning}`));
+  }
+
+  return lines.join('\n');
+}
+
+/**
+ * Languages with their version and confidence, primary first, then how the project is linted and
+ * tested and the API schemas found
+ */
+function describeDetection(detectionResult: DetectionResult): string {
+  const languages = [...detectionResult.languages]
+    .sort((a, b) => Number(b.primary) - Number(a.primary))
+    .map(language => `${language.name}${language.version ? ` ${language.version}` : ''} (confidence ${Number(language.confidence.toFixed(2))})`);
+  const parts = languages.length > 0 ? languages : ['no languages'];
+
+  const linters = [...new Set((detectionResult.linters || []).map(linter => linter.name))];
+  if (linters.length > 0) {
+    parts.push(`lint via ${linters.join(' and ')}`);
+  }
+
+  const runners = (detectionResult.testRunners || []).filter(runner => !runner.suppressedBy).map(runner => runner.name);
+  const tests = [...new Set(runners.length > 0 ? runners : detectionResult.testingFrameworks.map(framework => framework.name))];
+  if (tests.length > 0) {
+    parts.push(`test via ${tests.join(' and ')}`);
+  }
+
+  const schemas = [...(detectionResult.apiSchemas?.openapi || []), ...(detectionResult.apiSchemas?.graphql || [])];
+  if (schemas.length > 0) {
+    parts.push(`API schemas ${schemas.join(' and ')}`);
+  }
+
+  return parts.join(', ');
+}
+
+/**
+ * Job names of a workflow file in the order it declares them: the jobs map of GitHub Actions
+ * and CircleCI, the jobs of each Azure Pipelines stage, the top-level jobs of GitLab; none when
+ * the file is not YAML
+ */
+function listJobs(content: string): string[] {
+  let document: any;
+  try {
+    document = yaml.load(content);
+  } catch {
+    return [];
+  }
+  if (!document || typeof document !== 'object') {
+    return [];
+  }
+  if (document.jobs && typeof document.jobs === 'object' && !Array.isArray(document.jobs)) {
+    return Object.keys(document.jobs);
+  }
+  if (Array.isArray(document.stages) && document.stages.some((stage: any) => Array.isArray(stage?.jobs))) {
+    return document.stages.flatMap((stage: any) => (stage?.jobs || []).map((job: any) => job.job).filter(Boolean));
+  }
+  return Object.keys(document).filter(key =>
+    !key.startsWith('.') && !GITLAB_KEYWORDS.has(key) && document[key] && typeof document[key] === 'object' && !Array.isArray(document[key]));
+}
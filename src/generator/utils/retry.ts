@@ -0,0 +1,29 @@
+/**
+ * Retrying flaky commands in providers without a native step retry
+ */
+
+/**
+ * Most times GitLab retries a failed job
+ */
+export const GITLAB_MAX_RETRIES = 2;
+
+/**
+ * Wrap a shell command so it runs again up to retries times after failing. The command runs
+ * in a subshell, so a multi-line script stops at its first failing line and starts over.
+ * Needs bash or another POSIX shell.
+ */
+export function withRetries(command: string, retries: number): string {
+  const attempts = retries + 1;
+  const body = command.trim().split('\n').map(line => line === '' ? line : `  ${line}`).join('\n');
+
+  return [
+    'attempt=1',
+    'until (',
+    body,
+    '); do',
+    `  if [ "$attempt" -ge ${attempts} ]; then exit 1; fi`,
+    '  attempt=$((attempt + 1))',
+    `  echo "Command failed, retrying (attempt $attempt of ${attempts})"`,
+    'done'
+  ].join('\n');
+}
@@ -0,0 +1,40 @@
+/**
+ * Runner images - Exact GitHub-hosted runner image labels pinned in place of the -latest aliases
+ */
+
+import { HostedOS } from '../interfaces';
+
+/**
+ * Alias each operating system's hosted runners are generated with, which moves to each new image
+ */
+export const LATEST_RUNNER_IMAGES: Record<HostedOS, string> = {
+  linux: 'ubuntu-latest',
+  macos: 'macos-latest',
+  windows: 'windows-latest'
+};
+
+/**
+ * Shapes of the image labels GitHub hosts for each operating system: ubuntu-22.04 and its arm
+ * variant, macos-14 and its large and xlarge sizes, windows-2022 and windows-11-arm
+ */
+const KNOWN_RUNNER_IMAGES: Record<HostedOS, RegExp> = {
+  linux: /^ubuntu-(latest|\d{2}\.04)(-arm)?$/,
+  macos: /^macos-(latest|\d{2})(-large|-xlarge)?$/,
+  windows: /^(windows-(latest|20\d{2})|windows-11-arm)$/
+};
+
+/**
+ * Whether a label looks like one of GitHub's hosted images for the operating system. Unknown
+ * labels are still used, as GitHub adds images faster than this list is updated.
+ */
+export function isKnownRunnerImage(os: HostedOS, label: string): boolean {
+  return KNOWN_RUNNER_IMAGES[os].test(label);
+}
+
+/**
+ * The image pinned for a runner label when it is a -latest alias the map replaces, else the label
+ */
+export function pinRunnerImage(label: string, images: Partial<Record<HostedOS, string>>): string {
+  const os = (Object.keys(LATEST_RUNNER_IMAGES) as HostedOS[]).find(os => LATEST_RUNNER_IMAGES[os] === label);
+  return (os && images[os]) || label;
+}
@@ -0,0 +1,33 @@
+/**
+ * Secrets referenced by generated workflows, for provisioning them before the first run
+ */
+
+/**
+ * File the secrets manifest is written to, next to the workflows
+ */
+export const SECRETS_MANIFEST_FILENAME = 'required-secrets.json';
+
+/**
+ * Secrets every run gets without anyone provisioning them
+ */
+const BUILT_IN_SECRETS = new Set(['GITHUB_TOKEN']);
+
+/**
+ * Names of the secrets the workflows (file path to content) reference in `${{ }}` expressions,
+ * as `secrets.NAME` or `secrets['NAME']`, deduplicated and sorted. GITHUB_TOKEN is left out
+ * since GitHub provides it to every run.
+ */
+export function collectSecrets(workflows: Record<string, string>): string[] {
+  const names = new Set<string>();
+  for (const content of Object.values(workflows)) {
+    for (const expression of content.matchAll(/\$\{\{([\s\S]*?)\}\}/g)) {
+      for (const reference of expression[1]!.matchAll(/\bsecrets(?:\.([A-Za-z_][\w-]*)|\[\s*['"]([^'"]+)['"]\s*\])/g)) {
+        const name = reference[1] || reference[2]!;
+        if (!BUILT_IN_SECRETS.has(name)) {
+          names.add(name);
+        }
+      }
+    }
+  }
+  return [...names].sort();
+}
@@ -0,0 +1,58 @@
+/**
+ * Step order - A fixed precedence of step categories, so regenerating never reshuffles a job's steps
+ */
+
+import { StepTemplate } from '../types';
+
+/**
+ * Categories of steps in the order a job runs them
+ */
+export const STEP_CATEGORIES = ['checkout', 'setup', 'cache', 'install', 'build', 'lint', 'test', 'upload'] as const;
+
+export type StepCategory = typeof STEP_CATEGORIES[number];
+
+/**
+ * How steps of each category are recognized, most specific first
+ */
+const CATEGORY_MATCHERS: Array<[StepCategory, (step: StepTemplate) => boolean]> = [
+  ['checkout', step => /^actions\/checkout@/.test(step.uses || '')],
+  ['cache', step => /^actions\/cache(\/restore)?@/.test(step.uses || '') || /^Cache\b/.test(step.name)],
+  // Actions set a toolchain up and apt-get the machine's packages; a run step named Setup
+  // (playwright browsers) needs the installed packages
+  ['setup', step => step.uses !== undefined
+    ? /^(Setup|Set up)\b/.test(step.name) || /(^|\/)(setup-[\w-]+|action-setup|flutter-action|rust-toolchain)@/.test(step.uses)
+    : /^sudo apt-get\b/.test(step.run || '')],
+  ['upload', step => /(^|\/)(upload-artifact|upload-pages-artifact|codecov-action)@/.test(step.uses || '') || /^Upload\b/.test(step.name)],
+  ['install', step => /^(Install|Restore)\b/.test(step.name)],
+  ['build', step => /^(Build|Compile)\b/.test(step.name) || step.name === 'Run make build'],
+  ['lint', step => /\b(lint|eslint|clippy|rubocop|flake8|black|vet|shellcheck|pre-commit)\b/i.test(step.name) || ['Check formatting', 'Analyze', 'Type check'].includes(step.name)],
+  ['test', step => /^Run\b.*\btests?$/i.test(step.name)]
+];
+
+/**
+ * Category of a step, undefined for steps of none (configuring git, waiting for a service)
+ */
+export function getStepCategory(step: StepTemplate): StepCategory | undefined {
+  return CATEGORY_MATCHERS.find(([, matches]) => matches(step))?.[0];
+}
+
+/**
+ * Steps in category order: checkout, setup, cache, install, build, lint, test, upload. Steps of
+ * the same category keep the order they were added in, which only depends on the detection
+ * result and options, so a new step takes its category's place without moving the others.
+ * A step of no category stays right after the step before it, which it may depend on, and so
+ * do the steps anchored holds for.
+ */
+export function orderSteps(steps: StepTemplate[], anchored: (step: StepTemplate) => boolean = () => false): StepTemplate[] {
+  let rank = -1;
+  return steps
+    .map((step, index) => {
+      const category = anchored(step) ? undefined : getStepCategory(step);
+      if (category) {
+        rank = STEP_CATEGORIES.indexOf(category);
+      }
+      return { step, index, rank };
+    })
+    .sort((a, b) => a.rank - b.rank || a.index - b.index)
+    .map(({ step }) => step);
+}
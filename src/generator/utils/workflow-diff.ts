@@ -0,0 +1,153 @@
+/**
+ * Comparison of generated workflows with the files committed to a repository
+ */
+
+import * as yaml from 'js-yaml';
+
+/**
+ * Lines of context shown around each change
+ */
+const DIFF_CONTEXT = 3;
+
+/**
+ * Header comment renderers stamp with the generation time, which never matches a committed file;
+ * YAML files start it with '#', the Jenkinsfile with '//'
+ */
+const TIMESTAMP_LINE = /^(#|\/\/)\s*Generated at: /;
+
+/**
+ * Whether a committed workflow is what the generator produces now. Line endings, trailing
+ * whitespace, comments and the order of mapping keys are ignored; content that is not valid
+ * YAML is compared line by line.
+ */
+export function workflowsEquivalent(existing: string, generated: string): boolean {
+  const existingLines = normalizeLines(existing);
+  const generatedLines = normalizeLines(generated);
+  if (existingLines.length === generatedLines.length &&
+    existingLines.every((line, index) => comparable(line) === comparable(generatedLines[index]!))) {
+    return true;
+  }
+
+  try {
+    return deepEqual(yaml.load(existing), yaml.load(generated));
+  } catch {
+    return false;
+  }
+}
+
+/**
+ * Unified diff of a committed workflow (undefined when it does not exist) against the generated one,
+ * with `--- a/path` / `+++ b/path` headers and `@@ -line,count +line,count @@` hunks.
+ * Returns an empty string when the files only differ in whitespace at line ends.
+ */
+export function diffWorkflowFile(filePath: string, existing: string | undefined, generated: string): string {
+  const before = existing === undefined ? [] : normalizeLines(existing);
+  const after = normalizeLines(generated);
+  const operations = diffLines(before, after);
+  if (operations.every(operation => operation.type === ' ')) {
+    return '';
+  }
+
+  const output = [existing === undefined ? '--- /dev/null' : `--- a/${filePath}`, `+++ b/${filePath}`];
+  let index = 0;
+  while (index < operations.length) {
+    const firstChange = operations.findIndex((operation, position) => position >= index && operation.type !== ' ');
+    if (firstChange < 0) {
+      break;
+    }
+
+    // Extend the hunk while the next change is close enough for the contexts to touch
+    let end = firstChange;
+    for (let position = firstChange; position < operations.length && position - end <= 2 * DIFF_CONTEXT + 1; position++) {
+      if (operations[position]!.type !== ' ') {
+        end = position;
+      }
+    }
+
+    const start = Math.max(index, firstChange - DIFF_CONTEXT);
+    const stop = Math.min(operations.length, end + DIFF_CONTEXT + 1);
+    const hunk = operations.slice(start, stop);
+    const oldCount = hunk.filter(operation => operation.type !== '+').length;
+    const newCount = hunk.filter(operation => operation.type !== '-').length;
+    const oldStart = oldCount === 0 ? hunk[0]!.before : hunk[0]!.before + 1;
+    const newStart = newCount === 0 ? hunk[0]!.after : hunk[0]!.after + 1;
+
+    output.push(`@@ -${oldStart},${oldCount} +${newStart},${newCount} @@`);
+    output.push(...hunk.map(operation => `${operation.type}${operation.text}`));
+    index = stop;
+  }
+
+  return output.join('\n');
+}
+
+interface DiffOperation {
+  type: ' ' | '-' | '+';
+  text: string;
+  /** Lines of the old and new file preceding this one */
+  before: number;
+  after: number;
+}
+
+/**
+ * Line operations turning before into after, from their longest common subsequence
+ */
+function diffLines(before: string[], after: string[]): DiffOperation[] {
+  const lengths: number[][] = Array.from({ length: before.length + 1 }, () => new Array<number>(after.length + 1).fill(0));
+  for (let i = before.length - 1; i >= 0; i--) {
+    for (let j = after.length - 1; j >= 0; j--) {
+      lengths[i]![j] = comparable(before[i]!) === comparable(after[j]!)
+        ? lengths[i + 1]![j + 1]! + 1
+        : Math.max(lengths[i + 1]![j]!, lengths[i]![j + 1]!);
+    }
+  }
+
+  const operations: DiffOperation[] = [];
+  let i = 0;
+  let j = 0;
+  while (i < before.length || j < after.length) {
+    if (i < before.length && j < after.length && comparable(before[i]!) === comparable(after[j]!)) {
+      operations.push({ type: ' ', text: after[j]!, before: i++, after: j++ });
+    } else if (i < before.length && (j === after.length || lengths[i + 1]![j]! >= lengths[i]![j + 1]!)) {
+      operations.push({ type: '-', text: before[i]!, before: i++, after: j });
+    } else {
+      operations.push({ type: '+', text: after[j]!, before: i, after: j++ });
+    }
+  }
+
+  return operations;
+}
+
+function normalizeLines(content: string): string[] {
+  const lines = content.replace(/\r\n?/g, '\n').split('\n').map(line => line.trimEnd());
+  while (lines.length > 0 && lines[lines.length - 1] === '') {
+    lines.pop();
+  }
+  return lines;
+}
+
+/**
+ * Line as compared, with the generation timestamp blanked out
+ */
+function comparable(line: string): string {
+  return TIMESTAMP_LINE.test(line) ? line.replace(/Generated at: .*/, 'Generated at:') : line;
+}
+
+function deepEqual(a: unknown, b: unknown): boolean {
+  if (a === b) {
+    return true;
+  }
+  if (a instanceof Date && b instanceof Date) {
+    return a.getTime() === b.getTime();
+  }
+  if (typeof a !== 'object' || typeof b !== 'object' || a === null || b === null || Array.isArray(a) !== Array.isArray(b)) {
+    return false;
+  }
+  if (Array.isArray(a) && Array.isArray(b)) {
+    return a.length === b.length && a.every((item, index) => deepEqual(item, b[index]));
+  }
+
+  const aKeys = Object.keys(a);
+  const bRecord = b as Record<string, unknown>;
+  return aKeys.length === Object.keys(b).length &&
+    aKeys.every(key => Object.prototype.hasOwnProperty.call(b, key) && deepEqual((a as Record<string, unknown>)[key], bRecord[key]));
+}
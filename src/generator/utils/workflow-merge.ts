@@ -0,0 +1,333 @@
+/**
+ * Merging of regenerated GitHub Actions workflows into existing, hand-edited ones
+ */
+
+import * as yaml from 'js-yaml';
+
+const MANAGED_BLOCK_BEGIN = '# >>> readme-to-cicd managed >>>';
+const MANAGED_BLOCK_END = '# <<< readme-to-cicd managed <<<';
+
+/**
+ * What the generator authored in a workflow: top-level keys and, per job, step identities.
+ * Entries stay listed after the user deletes them so they are not re-added on the next run.
+ */
+export interface ManagedBlock {
+  keys: string[];
+  jobs: Record<string, string[]>;
+}
+
+/**
+ * Identity of a step within its job: its id, otherwise its name. Unnamed steps are identified by
+ * their position among the job's steps instead, see getStepKeys.
+ */
+export function getStepKey(step: any): string | undefined {
+  if (!step || typeof step !== 'object') {
+    return undefined;
+  }
+  return step.id || step.name || undefined;
+}
+
+/**
+ * Identities of a job's steps, in order. Steps without id or name are identified by what they
+ * do, the action without its ref or the first line of the command, numbered from the second
+ * occurrence on, so a bare `uses: actions/checkout@v4` is managed like a named step and stays
+ * the same step when the action's version changes.
+ */
+export function getStepKeys(steps: any[]): Array<string | undefined> {
+  const occurrences = new Map<string, number>();
+  return steps.map(step => {
+    const key = getStepKey(step);
+    if (key || !step || typeof step !== 'object') {
+      return key;
+    }
+
+    const action = typeof step.uses === 'string' ? `uses: ${step.uses.split('@')[0]}` : undefined;
+    const command = typeof step.run === 'string' ? `run: ${step.run.trim().split('\n')[0]}` : undefined;
+    const content = action || command;
+    if (!content) {
+      return undefined;
+    }
+    const occurrence = (occurrences.get(content) || 0) + 1;
+    occurrences.set(content, occurrence);
+    return occurrence === 1 ? content : `${content} #${occurrence}`;
+  });
+}
+
+/**
+ * Record everything in a generated workflow as managed
+ */
+export function createManagedBlock(workflow: any): ManagedBlock {
+  const block: ManagedBlock = { keys: [], jobs: {} };
+
+  for (const key of Object.keys(workflow || {})) {
+    if (key !== 'jobs') {
+      block.keys.push(key);
+    }
+  }
+
+  for (const [jobId, job] of Object.entries<any>(workflow?.jobs || {})) {
+    block.jobs[jobId] = getJobStepKeys(job);
+  }
+
+  return block;
+}
+
+/**
+ * Render a managed block as YAML comments appended to a workflow
+ */
+export function renderManagedBlock(block: ManagedBlock): string {
+  const lines = [
+    MANAGED_BLOCK_BEGIN,
+    '# Lists what readme-to-cicd generated. Listed entries are refreshed on merge; deleted ones stay deleted.',
+    `# workflow: ${JSON.stringify(block.keys)}`
+  ];
+  for (const [jobId, steps] of Object.entries(block.jobs)) {
+    lines.push(`# jobs.${jobId}: ${JSON.stringify(steps)}`);
+  }
+  lines.push(MANAGED_BLOCK_END);
+
+  return lines.join('\n');
+}
+
+/**
+ * Read the managed block from workflow content, or undefined when the file has none
+ */
+export function readManagedBlock(content: string): ManagedBlock | undefined {
+  const lines = content.split(/\r?\n/);
+  const begin = lines.indexOf(MANAGED_BLOCK_BEGIN);
+  const end = lines.indexOf(MANAGED_BLOCK_END, begin + 1);
+  if (begin === -1 || end === -1) {
+    return undefined;
+  }
+
+  const block: ManagedBlock = { keys: [], jobs: {} };
+  for (const line of lines.slice(begin + 1, end)) {
+    const match = line.match(/^# (workflow|jobs\.(.+?)): (\[.*\])$/);
+    if (!match) {
+      continue;
+    }
+
+    let entries: unknown;
+    try {
+      entries = JSON.parse(match[3]!);
+    } catch {
+      throw new Error(`Malformed managed block entry: ${line}`);
+    }
+    if (!Array.isArray(entries) || !entries.every(entry => typeof entry === 'string')) {
+      throw new Error(`Malformed managed block entry: ${line}`);
+    }
+
+    if (match[2] !== undefined) {
+      block.jobs[match[2]] = entries;
+    } else {
+      block.keys = entries;
+    }
+  }
+
+  return block;
+}
+
+/**
+ * Append a managed block describing the workflow itself to generated content
+ */
+export function appendManagedBlock(content: string): string {
+  const workflow = yaml.load(content);
+  const body = stripManagedBlock(content).replace(/\s*$/, '');
+  return `${body}\n\n${renderManagedBlock(createManagedBlock(workflow))}\n`;
+}
+
+/**
+ * Merge freshly generated workflow content into an existing workflow.
+ * Keys, jobs and steps listed in the existing managed block are replaced by their generated versions,
+ * or dropped when the generator no longer produces them. Anything else in the existing file is
+ * user-authored and kept in place; generated entries that would collide with it are skipped.
+ * Managed entries the user deleted are not re-added. Comments in the existing file are not preserved.
+ */
+export function mergeWorkflow(existingContent: string, generatedContent: string): string {
+  const existing = parseWorkflow(existingContent, 'existing');
+  const generated = parseWorkflow(generatedContent, 'generated');
+  const previous = readManagedBlock(existingContent) || { keys: [], jobs: {} };
+  const block: ManagedBlock = { keys: [], jobs: {} };
+
+  const merged = mergeEntries(existing, generated, previous.keys.filter(key => key !== 'jobs'), block.keys, ['jobs']);
+
+  const mergedJobs: Record<string, any> = {};
+  const existingJobs = existing.jobs || {};
+  const generatedJobs = generated.jobs || {};
+  const previousJobs = Object.keys(previous.jobs);
+  const managedJobs: string[] = [];
+  const ownedJobs = mergeEntries(existingJobs, generatedJobs, previousJobs, managedJobs);
+
+  for (const [jobId, job] of Object.entries<any>(ownedJobs)) {
+    const generatedJob = generatedJobs[jobId];
+    if (!managedJobs.includes(jobId) || !generatedJob) {
+      mergedJobs[jobId] = job;
+      continue;
+    }
+
+    if (!existingJobs[jobId] || !previous.jobs[jobId]) {
+      // Newly generated job
+      mergedJobs[jobId] = generatedJob;
+      block.jobs[jobId] = getJobStepKeys(generatedJob);
+      continue;
+    }
+
+    const steps = mergeSteps(existingJobs[jobId].steps || [], generatedJob.steps || [], previous.jobs[jobId]!);
+    mergedJobs[jobId] = { ...existingJobs[jobId], ...generatedJob, steps: steps.steps };
+    block.jobs[jobId] = steps.managed;
+  }
+
+  // Managed jobs the user deleted stay tracked so they are not re-added
+  for (const jobId of previousJobs) {
+    if (!existingJobs[jobId] && generatedJobs[jobId]) {
+      block.jobs[jobId] = getJobStepKeys(generatedJobs[jobId]);
+    }
+  }
+
+  merged.jobs = mergedJobs;
+
+  const header = getLeadingComments(generatedContent);
+  const body = yaml.dump(merged, { indent: 2, lineWidth: 120, noRefs: true });
+  return `${header}${body}\n${renderManagedBlock(block)}\n`;
+}
+
+/**
+ * Merge one level of a workflow map. Previously managed entries are refreshed from the generated map,
+ * dropped when no longer generated, and not re-added when the user deleted them.
+ * New generated entries are added unless they collide with a user entry; `managed` collects what the
+ * generator owns afterwards. New entries are inserted before the first of `insertBefore` present.
+ */
+function mergeEntries(
+  existing: Record<string, any>,
+  generated: Record<string, any>,
+  previous: string[],
+  managed: string[],
+  insertBefore: string[] = []
+): Record<string, any> {
+  const merged: Record<string, any> = {};
+  const added = Object.keys(generated).filter(key =>
+    !insertBefore.includes(key) && !previous.includes(key) && !(key in existing)
+  );
+  const emitAdded = () => {
+    for (const key of added.splice(0)) {
+      merged[key] = generated[key];
+      managed.push(key);
+    }
+  };
+
+  for (const [key, value] of Object.entries(existing)) {
+    if (insertBefore.includes(key)) {
+      emitAdded();
+      merged[key] = value;
+    } else if (!previous.includes(key)) {
+      merged[key] = value;
+    } else if (key in generated) {
+      merged[key] = generated[key];
+      managed.push(key);
+    }
+  }
+  emitAdded();
+
+  for (const key of previous) {
+    if (!(key in existing) && key in generated && !insertBefore.includes(key)) {
+      managed.push(key);
+    }
+  }
+
+  return merged;
+}
+
+/**
+ * Merge the steps of a managed job, keeping user steps where they are and
+ * inserting newly generated steps after the generated step that precedes them
+ */
+function mergeSteps(existingSteps: any[], generatedSteps: any[], previous: string[]): { steps: any[]; managed: string[] } {
+  const generatedKeys = getStepKeys(generatedSteps);
+  const generatedByKey = new Map<string, any>();
+  generatedSteps.forEach((step, index) => {
+    const key = generatedKeys[index];
+    if (key) {
+      generatedByKey.set(key, step);
+    }
+  });
+
+  const existingKeys = getStepKeys(existingSteps);
+  const userKeys = new Set<string>();
+  const steps: any[] = [];
+  existingSteps.forEach((step, index) => {
+    const key = existingKeys[index];
+    if (key && previous.includes(key)) {
+      if (generatedByKey.has(key)) {
+        steps.push(generatedByKey.get(key));
+      }
+    } else {
+      steps.push(step);
+      if (key) {
+        userKeys.add(key);
+      }
+    }
+  });
+
+  const managed: string[] = [];
+  let anchor: any;
+  generatedSteps.forEach((step, index) => {
+    const key = generatedKeys[index];
+    if (!key || userKeys.has(key)) {
+      return;
+    }
+    managed.push(key);
+
+    if (!previous.includes(key)) {
+      const index = anchor === undefined ? -1 : steps.indexOf(anchor);
+      steps.splice(index + 1, 0, step);
+    }
+    if (steps.includes(step)) {
+      anchor = step;
+    }
+  });
+
+  return { steps, managed };
+}
+
+function getJobStepKeys(job: any): string[] {
+  return getStepKeys(Array.isArray(job?.steps) ? job.steps : []).filter((key): key is string => key !== undefined);
+}
+
+function parseWorkflow(content: string, label: string): Record<string, any> {
+  let workflow: unknown;
+  try {
+    workflow = yaml.load(content);
+  } catch (error) {
+    throw new Error(`Failed to parse ${label} workflow: ${error instanceof Error ? error.message : 'Unknown error'}`);
+  }
+
+  // An empty file has nothing to preserve
+  if (workflow === null || workflow === undefined) {
+    return {};
+  }
+  if (typeof workflow !== 'object' || Array.isArray(workflow)) {
+    throw new Error(`Failed to parse ${label} workflow: expected a mapping at the top level`);
+  }
+  return workflow as Record<string, any>;
+}
+
+function stripManagedBlock(content: string): string {
+  const begin = content.indexOf(MANAGED_BLOCK_BEGIN);
+  const end = content.indexOf(MANAGED_BLOCK_END, begin);
+  if (begin === -1 || end === -1) {
+    return content;
+  }
+  return content.slice(0, begin) + content.slice(end + MANAGED_BLOCK_END.length);
+}
+
+function getLeadingComments(content: string): string {
+  const lines = content.split('\n');
+  const header: string[] = [];
+  for (const line of lines) {
+    if (!line.startsWith('#') && line.trim() !== '') {
+      break;
+    }
+    header.push(line);
+  }
+  return header.length > 0 ? `${header.join('\n').replace(/\s*$/, '')}\n\n` : '';
+}
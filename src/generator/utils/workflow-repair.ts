@@ -0,0 +1,174 @@
+/**
+ * Safe fixes for the usual problems of hand-written GitHub Actions workflows
+ */
+
+import * as yaml from 'js-yaml';
+import { USES_LINE, COMMIT_SHA } from './action-versions';
+
+/**
+ * What a repair can change, in the order fixes are applied
+ */
+export const REPAIR_FIXES = ['pin-actions', 'permissions', 'concurrency'] as const;
+
+export type RepairFixId = typeof REPAIR_FIXES[number];
+
+/**
+ * A change a repair made
+ */
+export interface RepairFix {
+  id: RepairFixId;
+  description: string;
+}
+
+export interface RepairOptions {
+  /** Fixes to leave out */
+  skip?: RepairFixId[];
+  /** Commit SHAs of action references (`actions/checkout@v4`); actions without one keep their ref */
+  actionShas?: Record<string, string>;
+}
+
+export interface RepairResult {
+  content: string;
+  fixes: RepairFix[];
+  /** Problems left in place because fixing them could change what the workflow does */
+  warnings: string[];
+}
+
+/**
+ * Actions that write to the repository, its releases, packages or Pages with the workflow token
+ */
+const WRITING_ACTIONS = [
+  'actions/deploy-pages', 'actions/create-release', 'actions/stale', 'softprops/action-gh-release',
+  'ncipollo/release-action', 'peaceiris/actions-gh-pages', 'github/codeql-action', 'docker/login-action',
+  'peter-evans/create-pull-request', 'stefanzweifel/git-auto-commit-action', 'changesets/action',
+  'googleapis/release-please-action', 'google-github-actions/release-please-action'
+];
+
+/**
+ * Commands in run steps that write with the workflow token
+ */
+const WRITING_COMMANDS = /\b(git push|gh (release|pr|issue|api|workflow))\b/;
+
+/**
+ * Cancel superseded pull request runs only; a cancelled push run can leave a deployment half done
+ */
+const CONCURRENCY_LINES = [
+  'concurrency:',
+  '  group: ${{ github.workflow }}-${{ github.ref }}',
+  "  cancel-in-progress: ${{ github.event_name == 'pull_request' }}"
+];
+
+/**
+ * Apply the safe fixes to an existing workflow: pin action refs to the commit SHAs in
+ * options.actionShas (keeping the ref as a comment), add a read-only top-level `permissions:`
+ * block and a `concurrency:` group when the workflow has none. The file is edited line by line,
+ * so comments and formatting stay as they are, and repairing a repaired workflow changes nothing.
+ * Permissions are only added when nothing in the workflow writes with its token.
+ */
+export function repairWorkflow(content: string, options: RepairOptions = {}): RepairResult {
+  const workflow = parseWorkflow(content);
+  const skip = new Set(options.skip || []);
+  const fixes: RepairFix[] = [];
+  const warnings: string[] = [];
+  const newline = content.includes('\r\n') ? '\r\n' : '\n';
+  let lines = content.split(/\r?\n/);
+
+  if (!skip.has('pin-actions')) {
+    lines = lines.map(line => {
+      const match = line.match(USES_LINE);
+      if (!match || COMMIT_SHA.test(match[4]!)) {
+        return line;
+      }
+
+      const [, prefix, quote, action, ref, comment] = match;
+      const reference = `${action}@${ref}`;
+      const sha = options.actionShas?.[reference];
+      if (!sha) {
+        warnings.push(`${reference} is not pinned - no commit SHA is known for it`);
+        return line;
+      }
+      fixes.push({ id: 'pin-actions', description: `Pinned ${reference} to ${sha}` });
+      return `${prefix}${quote}${action}@${sha}${quote} # ${ref}${comment ? ` ${comment.trim()}` : ''}`;
+    });
+  }
+
+  const jobs = Object.values<any>(workflow.jobs && typeof workflow.jobs === 'object' ? workflow.jobs : {});
+  const jobsLine = lines.findIndex(line => /^jobs:\s*(#.*)?$/.test(line));
+  const indent = lines.slice(jobsLine + 1).find(line => /^ +\S/.test(line))?.match(/^ +/)![0] || '  ';
+  const insert: string[] = [];
+
+  if (!skip.has('permissions') && workflow.permissions === undefined && !jobs.some(job => job?.permissions !== undefined)) {
+    const writer = findWriter(jobs);
+    if (writer) {
+      warnings.push(`permissions: not added - ${writer} may need write access`);
+    } else {
+      insert.push('permissions:', `${indent}contents: read`, '');
+      fixes.push({ id: 'permissions', description: 'Limited the workflow token to reading the repository contents' });
+    }
+  }
+
+  if (!skip.has('concurrency') && workflow.concurrency === undefined) {
+    insert.push(...CONCURRENCY_LINES.map(line => line.replace(/^ {2}/, indent)), '');
+    fixes.push({ id: 'concurrency', description: 'Added a concurrency group cancelling superseded pull request runs' });
+  }
+
+  if (insert.length > 0) {
+    if (jobsLine < 0) {
+      throw new Error('Failed to repair workflow: no top-level jobs key');
+    }
+    lines.splice(jobsLine, 0, ...insert);
+  }
+
+  return { content: lines.join(newline), fixes, warnings: [...new Set(warnings)] };
+}
+
+/**
+ * Action references (`actions/checkout@v4`) of a workflow not pinned to a commit SHA yet,
+ * deduplicated in the order they appear
+ */
+export function findUnpinnedActions(content: string): string[] {
+  const references = new Set<string>();
+  for (const line of content.split(/\r?\n/)) {
+    const match = line.match(USES_LINE);
+    if (match && !COMMIT_SHA.test(match[4]!)) {
+      references.add(`${match[3]}@${match[4]}`);
+    }
+  }
+  return [...references];
+}
+
+/**
+ * First action or command found in the jobs that writes with the workflow token
+ */
+function findWriter(jobs: any[]): string | undefined {
+  for (const job of jobs) {
+    // A called workflow's token is capped by the caller's permissions
+    if (typeof job?.uses === 'string') {
+      return job.uses;
+    }
+    for (const step of Array.isArray(job?.steps) ? job.steps : []) {
+      const action = typeof step?.uses === 'string' ? step.uses.split('@')[0] : undefined;
+      if (action && WRITING_ACTIONS.some(writing => action === writing || action.startsWith(`${writing}/`))) {
+        return action;
+      }
+      const command = typeof step?.run === 'string' ? step.run.match(WRITING_COMMANDS)?.[0] : undefined;
+      if (command) {
+        return `\`${command}\``;
+      }
+    }
+  }
+  return undefined;
+}
+
+function parseWorkflow(content: string): Record<string, any> {
+  let workflow: unknown;
+  try {
+    workflow = yaml.load(content);
+  } catch (error) {
+    throw new Error(`Failed to repair workflow: ${error instanceof Error ? error.message : 'Unknown error'}`);
+  }
+  if (!workflow || typeof workflow !== 'object' || Array.isArray(workflow)) {
+    throw new Error('Failed to repair workflow: expected a mapping at the top level');
+  }
+  return workflow as Record<string, any>;
+}
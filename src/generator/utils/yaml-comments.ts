@@ -0,0 +1,16 @@
+/**
+ * Dropping the explanatory comments from rendered workflows
+ */
+
+/**
+ * Workflow content without its comment lines at column 0: the generation info, section
+ * headings and header notes every renderer starts with. YAML indents block scalar content, so
+ * such a line is a comment whatever the provider, while an indented `#` may be a script's own.
+ */
+export function stripComments(content: string): string {
+  const lines = content.split('\n').filter(line => !line.startsWith('#'));
+  while (lines.length > 0 && lines[0]!.trim() === '') {
+    lines.shift();
+  }
+  return lines.join('\n');
+}
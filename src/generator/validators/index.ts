@@ -7,6 +7,12 @@ export {
   WorkflowValidator
 } from './workflow-validator';
 
+// Structural checks of generated configuration per provider
+export {
+  validateWorkflowStructure,
+  validateCompositeActionStructure
+} from './structure-validator';
+
 // Enhanced validation with detailed feedback
 export {
   EnhancedWorkflowValidator,
@@ -0,0 +1,693 @@
+/**
+ * Structure Validator - Catches malformed generated configuration before it is written
+ */
+
+import * as yaml from 'yaml';
+import { Provider, ValidationError } from '../interfaces';
+
+/**
+ * GitHub Actions job ids: a letter or underscore, then letters, digits, '-' or '_'
+ */
+const GITHUB_JOB_ID = /^[A-Za-z_][A-Za-z0-9_-]*$/;
+
+/**
+ * Azure Pipelines job and stage names: letters, digits and '_', not starting with a digit
+ */
+const AZURE_IDENTIFIER = /^[A-Za-z_][A-Za-z0-9_]*$/;
+
+/**
+ * CircleCI job names
+ */
+const CIRCLECI_JOB_NAME = /^[A-Za-z0-9_-]+$/;
+
+/**
+ * Pipelines of bitbucket-pipelines.yml keyed by branch, tag, pull request source or custom name
+ */
+const BITBUCKET_PIPELINE_GROUPS = ['branches', 'tags', 'pull-requests', 'custom'];
+
+/**
+ * Caches Bitbucket Pipelines defines without a `definitions` entry
+ */
+const BITBUCKET_PREDEFINED_CACHES = new Set(['docker', 'composer', 'dotnetcore', 'gradle', 'ivy2', 'maven', 'node', 'pip', 'sbt']);
+
+/**
+ * Blocks of a Jenkinsfile stage holding what it runs; a stage has exactly one of them
+ */
+const JENKINS_STAGE_BODIES = ['steps', 'stages', 'parallel', 'matrix'];
+
+/**
+ * Top-level .gitlab-ci.yml keys that configure the pipeline rather than define a job
+ */
+const GITLAB_RESERVED_KEYS = new Set([
+  'stages', 'variables', 'default', 'include', 'workflow', 'image', 'services', 'cache', 'before_script', 'after_script'
+]);
+
+/**
+ * Stages GitLab defines when a pipeline declares none
+ */
+const GITLAB_DEFAULT_STAGES = ['.pre', 'build', 'test', 'deploy', '.post'];
+
+/**
+ * Azure Pipelines step keys; a step is exactly one of them
+ */
+const AZURE_STEP_KEYS = ['script', 'bash', 'pwsh', 'powershell', 'task', 'checkout', 'template', 'download', 'publish', 'downloadBuild', 'getPackage', 'reviewApp'];
+
+/**
+ * Check generated configuration against the basic structural rules of its provider: required
+ * top-level keys, valid job ids, a runner for every job, steps that are a single kind, and
+ * references (needs, matrix variables, parameters) that resolve. These are the mistakes
+ * generator bugs produce; the provider's own validation covers the rest. A Jenkinsfile is
+ * Groovy rather than YAML, so only the blocks of its declarative pipeline are checked.
+ */
+export function validateWorkflowStructure(content: string, provider: Provider = Provider.GitHubActions): ValidationError[] {
+  if (provider === Provider.Jenkins) {
+    return validateJenkinsfile(content);
+  }
+
+  let document: any;
+  try {
+    document = yaml.parse(content);
+  } catch (error) {
+    return [schemaError(`YAML parsing failed: ${error instanceof Error ? error.message : String(error)}`, 'syntax')];
+  }
+
+  if (!isMapping(document)) {
+    return [schemaError('Configuration must be a mapping of top-level keys', 'syntax')];
+  }
+
+  switch (provider) {
+    case Provider.GitLab:
+      return validateGitLab(document);
+    case Provider.CircleCI:
+      return validateCircleCI(document);
+    case Provider.AzurePipelines:
+      return validateAzure(document);
+    case Provider.BitbucketPipelines:
+      return validateBitbucket(document);
+    default:
+      return validateGitHub(document);
+  }
+}
+
+/**
+ * Check generated action.yml content against the rules GitHub applies to composite actions only:
+ * runs.using composite with at least one step, a shell on every run step, no reusable workflows
+ * among the uses steps (those are called by jobs, not steps), inputs.* references that resolve
+ * to declared inputs and no secrets context, which actions cannot read.
+ */
+export function validateCompositeActionStructure(content: string): ValidationError[] {
+  let action: any;
+  try {
+    action = yaml.parse(content);
+  } catch (error) {
+    return [schemaError(`YAML parsing failed: ${error instanceof Error ? error.message : String(error)}`, 'syntax')];
+  }
+
+  if (!isMapping(action) || !isMapping(action.runs)) {
+    return [schemaError('Composite action must define "runs"', 'syntax')];
+  }
+
+  const errors: ValidationError[] = [];
+  if (action.runs.using !== 'composite') {
+    errors.push(schemaError('Composite action must set runs.using to "composite"'));
+  }
+  if (!Array.isArray(action.runs.steps) || action.runs.steps.length === 0) {
+    errors.push(schemaError('Composite action must have at least one step under runs.steps'));
+    return errors;
+  }
+
+  const declared = new Set(Object.keys(isMapping(action.inputs) ? action.inputs : {}));
+  action.runs.steps.forEach((step: any, index: number) => {
+    const label = `Step ${index + 1}${isMapping(step) && step.name ? ` ("${step.name}")` : ''}`;
+    const hasUses = isMapping(step) && step.uses !== undefined;
+    const hasRun = isMapping(step) && step.run !== undefined;
+    if (hasUses === hasRun) {
+      errors.push(schemaError(`${label} must have exactly one of "uses" or "run"`));
+      return;
+    }
+    if (hasRun && (typeof step.shell !== 'string' || step.shell.trim() === '')) {
+      errors.push(schemaError(`${label} runs a command and must specify "shell"`));
+    }
+    if (hasUses && /(^|\/)\.github\/workflows\/[^@]+\.ya?ml(@|$)/.test(String(step.uses))) {
+      errors.push(schemaError(`${label} uses the reusable workflow ${step.uses}, which only jobs can call`));
+    }
+
+    const expressions = JSON.stringify(step);
+    for (const input of new Set([...expressions.matchAll(/(?<![\w.])inputs\.([A-Za-z_][\w-]*)/g)].map(match => match[1]!))) {
+      if (!declared.has(input)) {
+        errors.push(schemaError(`${label} references inputs.${input}, which the action does not declare`));
+      }
+    }
+    if (/(?<![\w.])secrets\./.test(expressions)) {
+      errors.push(schemaError(`${label} reads the secrets context, which composite actions cannot access - pass secrets as inputs`));
+    }
+  });
+
+  return errors;
+}
+
+function validateGitHub(workflow: Record<string, any>): ValidationError[] {
+  const errors: ValidationError[] = [];
+
+  if (workflow.on === undefined) {
+    errors.push(schemaError('Workflow must define "on" triggers'));
+  }
+  if (!isMapping(workflow.jobs) || Object.keys(workflow.jobs).length === 0) {
+    errors.push(schemaError('Workflow must define at least one job under "jobs"'));
+    return errors;
+  }
+
+  const jobIds = new Set(Object.keys(workflow.jobs));
+  for (const [jobId, job] of Object.entries<any>(workflow.jobs)) {
+    if (!GITHUB_JOB_ID.test(jobId)) {
+      errors.push(schemaError(`Job id "${jobId}" must start with a letter or '_' and contain only letters, digits, '-' and '_'`));
+    }
+    if (!isMapping(job)) {
+      errors.push(schemaError(`Job "${jobId}" must be a mapping`));
+      continue;
+    }
+
+    for (const need of toList(job.needs)) {
+      if (!jobIds.has(need)) {
+        errors.push(schemaError(`Job "${jobId}" needs unknown job "${need}"`));
+      }
+    }
+
+    // Reusable workflow calls bring their own runner and steps
+    if (job.uses !== undefined) {
+      continue;
+    }
+
+    if (!hasRunner(job['runs-on'])) {
+      errors.push(schemaError(`Job "${jobId}" must specify a non-empty "runs-on"`));
+    }
+
+    if (!Array.isArray(job.steps) || job.steps.length === 0) {
+      errors.push(schemaError(`Job "${jobId}" must have at least one step`));
+    } else {
+      job.steps.forEach((step: any, index: number) => {
+        const hasUses = isMapping(step) && step.uses !== undefined;
+        const hasRun = isMapping(step) && step.run !== undefined;
+        if (hasUses === hasRun) {
+          errors.push(schemaError(`Step ${index + 1} of job "${jobId}" must have exactly one of "uses" or "run"`));
+        }
+      });
+    }
+
+    for (const variable of findUndefinedMatrixVariables(job)) {
+      errors.push(schemaError(`Job "${jobId}" references matrix.${variable}, which its matrix does not define`));
+    }
+  }
+
+  errors.push(...validateGitHubInputsAndOutputs(workflow));
+  return errors;
+}
+
+/**
+ * inputs.* references need an input declared by workflow_call or workflow_dispatch, and the
+ * outputs a reusable workflow returns have to name an output of one of its jobs
+ */
+function validateGitHubInputsAndOutputs(workflow: Record<string, any>): ValidationError[] {
+  const errors: ValidationError[] = [];
+  const triggers = isMapping(workflow.on) ? workflow.on : {};
+  const declared = new Set([triggers.workflow_call, triggers.workflow_dispatch]
+    .flatMap(trigger => Object.keys(isMapping(trigger?.inputs) ? trigger.inputs : {})));
+
+  const jobs = Object.entries<any>(workflow.jobs).filter(([, job]) => isMapping(job));
+  for (const [jobId, job] of jobs) {
+    const referenced = new Set([...JSON.stringify(job).matchAll(/(?<![\w.])inputs\.([A-Za-z_][\w-]*)/g)].map(match => match[1]!));
+    for (const input of referenced) {
+      if (!declared.has(input)) {
+        errors.push(schemaError(`Job "${jobId}" references inputs.${input}, which no workflow_call or workflow_dispatch input declares`));
+      }
+    }
+  }
+
+  const outputs = isMapping(triggers.workflow_call?.outputs) ? triggers.workflow_call.outputs : {};
+  for (const [name, output] of Object.entries<any>(outputs)) {
+    const reference = String(output?.value ?? '').match(/\bjobs\.([A-Za-z_][\w-]*)\.outputs\.([A-Za-z_][\w-]*)/);
+    const job = reference ? workflow.jobs[reference[1]!] : undefined;
+    if (!reference || !isMapping(job) || !isMapping(job.outputs) || job.outputs[reference[2]!] === undefined) {
+      errors.push(schemaError(`Workflow output "${name}" must read an output its jobs declare, as \${{ jobs.<job>.outputs.<output> }}`));
+    }
+  }
+
+  return errors;
+}
+
+function validateGitLab(pipeline: Record<string, any>): ValidationError[] {
+  const errors: ValidationError[] = [];
+  const jobs = Object.entries<any>(pipeline).filter(([name]) => !GITLAB_RESERVED_KEYS.has(name) && !name.startsWith('.'));
+
+  if (jobs.length === 0) {
+    errors.push(schemaError('Pipeline must define at least one job'));
+    return errors;
+  }
+
+  const stages = Array.isArray(pipeline.stages) ? pipeline.stages : GITLAB_DEFAULT_STAGES;
+  const jobNames = new Set(jobs.map(([name]) => name));
+
+  for (const [name, job] of jobs) {
+    if (!isMapping(job)) {
+      errors.push(schemaError(`Job "${name}" must be a mapping`));
+      continue;
+    }
+    if (job.trigger === undefined && job.extends === undefined && toList(job.script).length === 0) {
+      errors.push(schemaError(`Job "${name}" must have a non-empty "script"`));
+    }
+    if (job.stage !== undefined && !stages.includes(job.stage)) {
+      errors.push(schemaError(`Job "${name}" uses stage "${job.stage}", which "stages" does not list`));
+    }
+    for (const need of toList(job.needs).map((entry: any) => (isMapping(entry) ? entry.job : entry))) {
+      if (typeof need === 'string' && !jobNames.has(need)) {
+        errors.push(schemaError(`Job "${name}" needs unknown job "${need}"`));
+      }
+    }
+  }
+
+  return errors;
+}
+
+function validateCircleCI(config: Record<string, any>): ValidationError[] {
+  const errors: ValidationError[] = [];
+
+  if (config.version === undefined) {
+    errors.push(schemaError('Configuration must set "version"'));
+  }
+  if (!isMapping(config.jobs) || Object.keys(config.jobs).length === 0) {
+    errors.push(schemaError('Configuration must define at least one job under "jobs"'));
+    return errors;
+  }
+  if (!isMapping(config.workflows) || Object.keys(config.workflows).length === 0) {
+    errors.push(schemaError('Configuration must define at least one workflow under "workflows"'));
+  }
+
+  for (const [name, job] of Object.entries<any>(config.jobs)) {
+    if (!CIRCLECI_JOB_NAME.test(name)) {
+      errors.push(schemaError(`Job name "${name}" must contain only letters, digits, '-' and '_'`));
+    }
+    if (!isMapping(job)) {
+      errors.push(schemaError(`Job "${name}" must be a mapping`));
+      continue;
+    }
+    if (!['docker', 'machine', 'macos', 'executor'].some(key => job[key] !== undefined)) {
+      errors.push(schemaError(`Job "${name}" must specify an executor (docker, machine, macos or executor)`));
+    }
+    if (!Array.isArray(job.steps) || job.steps.length === 0) {
+      errors.push(schemaError(`Job "${name}" must have at least one step`));
+    } else {
+      job.steps.forEach((step: any, index: number) => {
+        if (typeof step !== 'string' && !(isMapping(step) && Object.keys(step).length === 1)) {
+          errors.push(schemaError(`Step ${index + 1} of job "${name}" must be a command name or a mapping with a single command`));
+        }
+      });
+    }
+
+    const parameters = new Set(Object.keys(isMapping(job.parameters) ? job.parameters : {}));
+    for (const parameter of new Set([...JSON.stringify(job).matchAll(/<<\s*parameters\.([\w-]+)\s*>>/g)].map(match => match[1]!))) {
+      if (!parameters.has(parameter)) {
+        errors.push(schemaError(`Job "${name}" references parameters.${parameter}, which it does not declare`));
+      }
+    }
+  }
+
+  for (const [workflowName, workflow] of Object.entries<any>(isMapping(config.workflows) ? config.workflows : {})) {
+    if (workflowName === 'version' || !isMapping(workflow)) {
+      continue;
+    }
+    const invocations = toList(workflow.jobs).map((entry: any) => {
+      const [jobName, settings] = typeof entry === 'string' ? [entry, {}] : Object.entries<any>(entry)[0] || ['', {}];
+      return { jobName, settings: isMapping(settings) ? settings : {} };
+    });
+    // Invocations are referred to by their name setting when they have one
+    const invoked = new Set(invocations.map(({ jobName, settings }) => settings.name || jobName));
+
+    for (const { jobName, settings } of invocations) {
+      // Orb jobs (namespace/job) are defined by the orb
+      if (!jobName.includes('/') && !(jobName in config.jobs)) {
+        errors.push(schemaError(`Workflow "${workflowName}" runs unknown job "${jobName}"`));
+      }
+      for (const required of toList(settings.requires)) {
+        if (!invoked.has(required)) {
+          errors.push(schemaError(`Job "${jobName}" in workflow "${workflowName}" requires unknown job "${required}"`));
+        }
+      }
+    }
+  }
+
+  return errors;
+}
+
+function validateAzure(pipeline: Record<string, any>): ValidationError[] {
+  const errors: ValidationError[] = [];
+
+  const stages = Array.isArray(pipeline.stages) ? pipeline.stages : undefined;
+  if (!stages && !Array.isArray(pipeline.jobs) && !Array.isArray(pipeline.steps)) {
+    errors.push(schemaError('Pipeline must define "stages", "jobs" or "steps"'));
+    return errors;
+  }
+
+  if (Array.isArray(pipeline.steps)) {
+    errors.push(...validateAzureSteps(pipeline.steps, 'the pipeline'));
+  }
+
+  const groups: Array<{ label: string; jobs: any[] }> = stages
+    ? stages.map((stage: any) => ({ label: `stage "${stage?.stage}"`, jobs: Array.isArray(stage?.jobs) ? stage.jobs : [] }))
+    : [{ label: 'the pipeline', jobs: Array.isArray(pipeline.jobs) ? pipeline.jobs : [] }];
+
+  if (stages) {
+    const stageNames = new Set(stages.map((stage: any) => stage?.stage));
+    for (const stage of stages.filter((entry: any) => entry?.template === undefined)) {
+      if (typeof stage?.stage !== 'string' || !AZURE_IDENTIFIER.test(stage.stage)) {
+        errors.push(schemaError(`Stage name "${stage?.stage}" must start with a letter or '_' and contain only letters, digits and '_'`));
+      }
+      for (const dependency of toList(stage?.dependsOn)) {
+        if (!stageNames.has(dependency)) {
+          errors.push(schemaError(`Stage "${stage.stage}" depends on unknown stage "${dependency}"`));
+        }
+      }
+    }
+  }
+
+  for (const { label, jobs } of groups) {
+    const jobNames = new Set(jobs.map(job => job?.job ?? job?.deployment));
+    for (const job of jobs) {
+      if (job?.template !== undefined) {
+        continue;
+      }
+      const name = job?.job ?? job?.deployment;
+      if (typeof name !== 'string' || !AZURE_IDENTIFIER.test(name)) {
+        errors.push(schemaError(`Job name "${name}" in ${label} must start with a letter or '_' and contain only letters, digits and '_'`));
+        continue;
+      }
+      for (const dependency of toList(job.dependsOn)) {
+        if (!jobNames.has(dependency)) {
+          errors.push(schemaError(`Job "${name}" depends on unknown job "${dependency}"`));
+        }
+      }
+      if (job.pool !== undefined && !hasRunner(typeof job.pool === 'string' ? job.pool : job.pool?.vmImage ?? job.pool?.name)) {
+        errors.push(schemaError(`Job "${name}" must set a non-empty pool vmImage or name`));
+      }
+      // Deployment jobs put their steps under a strategy
+      if (job.job !== undefined) {
+        if (!Array.isArray(job.steps) || job.steps.length === 0) {
+          errors.push(schemaError(`Job "${name}" must have at least one step`));
+        } else {
+          errors.push(...validateAzureSteps(job.steps, `job "${name}"`));
+        }
+      }
+    }
+  }
+
+  return errors;
+}
+
+function validateAzureSteps(steps: any[], owner: string): ValidationError[] {
+  const errors: ValidationError[] = [];
+  steps.forEach((step, index) => {
+    const kinds = isMapping(step) ? AZURE_STEP_KEYS.filter(key => step[key] !== undefined) : [];
+    if (kinds.length !== 1) {
+      errors.push(schemaError(`Step ${index + 1} of ${owner} must have exactly one step key such as script, bash or task`));
+    }
+  });
+  return errors;
+}
+
+function validateBitbucket(config: Record<string, any>): ValidationError[] {
+  const errors: ValidationError[] = [];
+
+  if (!isMapping(config.pipelines) || Object.keys(config.pipelines).length === 0) {
+    errors.push(schemaError('Configuration must define at least one pipeline under "pipelines"'));
+    return errors;
+  }
+
+  const pipelines: Array<[string, any]> = [];
+  for (const [key, value] of Object.entries<any>(config.pipelines)) {
+    if (key === 'default') {
+      pipelines.push(['default', value]);
+    } else if (BITBUCKET_PIPELINE_GROUPS.includes(key) && isMapping(value)) {
+      pipelines.push(...Object.entries<any>(value).map(([name, pipeline]): [string, any] => [`${key} "${name}"`, pipeline]));
+    } else {
+      errors.push(schemaError(`Unknown pipelines key "${key}"`));
+    }
+  }
+
+  const caches = new Set(Object.keys(isMapping(config.definitions?.caches) ? config.definitions.caches : {}));
+  const services = new Set(Object.keys(isMapping(config.definitions?.services) ? config.definitions.services : {}));
+  for (const [label, pipeline] of pipelines) {
+    if (!Array.isArray(pipeline) || pipeline.length === 0) {
+      errors.push(schemaError(`Pipeline ${label} must be a non-empty list of steps`));
+      continue;
+    }
+    pipeline.forEach((entry: any, index: number) => {
+      const steps = isMapping(entry) && Array.isArray(entry.parallel)
+        ? entry.parallel.map((parallel: any) => parallel?.step)
+        : [isMapping(entry) ? entry.step : undefined];
+      for (const step of steps) {
+        if (!isMapping(step) || !Array.isArray(step.script) || step.script.length === 0) {
+          errors.push(schemaError(`Step ${index + 1} of pipeline ${label} must have a non-empty "script"`));
+          continue;
+        }
+        for (const cache of toList(step.caches)) {
+          if (!BITBUCKET_PREDEFINED_CACHES.has(cache) && !caches.has(cache)) {
+            errors.push(schemaError(`Step "${step.name}" uses cache "${cache}", which "definitions" does not define`));
+          }
+        }
+        for (const service of toList(step.services)) {
+          if (service !== 'docker' && !services.has(service)) {
+            errors.push(schemaError(`Step "${step.name}" uses service "${service}", which "definitions" does not define`));
+          }
+        }
+      }
+    });
+  }
+
+  return errors;
+}
+
+/**
+ * A block of Groovy: the text before its brace, its statements and the blocks it holds
+ */
+interface GroovyBlock {
+  header: string;
+  statements: string[];
+  blocks: GroovyBlock[];
+}
+
+function validateJenkinsfile(content: string): ValidationError[] {
+  const root = parseGroovyBlocks(content);
+  if (typeof root === 'string') {
+    return [schemaError(root, 'syntax')];
+  }
+
+  const pipeline = root.blocks.find(block => block.header === 'pipeline');
+  if (!pipeline) {
+    return [schemaError('Jenkinsfile must declare a "pipeline" block')];
+  }
+
+  const errors: ValidationError[] = [];
+  if (!pipeline.blocks.some(block => block.header === 'agent') && !pipeline.statements.some(statement => /^agent\s/.test(statement))) {
+    errors.push(schemaError('Pipeline must declare an "agent"'));
+  }
+  const stages = pipeline.blocks.find(block => block.header === 'stages');
+  if (stages) {
+    errors.push(...validateJenkinsStages(stages, 'the pipeline'));
+  } else {
+    errors.push(schemaError('Pipeline must have a "stages" block'));
+  }
+
+  return errors;
+}
+
+/**
+ * Check the stages of a stages or parallel block: unique names, exactly one body each, steps
+ * that are not empty and matrix directives with axes
+ */
+function validateJenkinsStages(stages: GroovyBlock, owner: string): ValidationError[] {
+  const errors: ValidationError[] = [];
+  const list = stages.blocks.filter(block => /^stage\s*\(/.test(block.header));
+  if (list.length === 0) {
+    errors.push(schemaError(`"${stages.header}" of ${owner} must contain at least one stage`));
+  }
+
+  const names = new Set<string>();
+  for (const stage of list) {
+    const name = stage.header.match(/^stage\s*\(\s*(['"])(.*)\1\s*\)$/)?.[2] ?? stage.header;
+    if (names.has(name)) {
+      errors.push(schemaError(`Stage name "${name}" is used more than once in ${owner}`));
+    }
+    names.add(name);
+
+    const bodies = stage.blocks.filter(block => JENKINS_STAGE_BODIES.includes(block.header));
+    if (bodies.length !== 1) {
+      errors.push(schemaError(`Stage "${name}" must have exactly one of steps, stages, parallel or matrix`));
+      continue;
+    }
+    const body = bodies[0]!;
+    if (body.header === 'steps' && body.statements.length === 0 && body.blocks.length === 0) {
+      errors.push(schemaError(`Stage "${name}" has no steps`));
+    } else if (body.header === 'stages' || body.header === 'parallel') {
+      errors.push(...validateJenkinsStages(body, `stage "${name}"`));
+    } else if (body.header === 'matrix') {
+      const axes = body.blocks.find(block => block.header === 'axes');
+      if (!axes || !axes.blocks.some(block => block.header === 'axis')) {
+        errors.push(schemaError(`Matrix of stage "${name}" must declare at least one axis`));
+      }
+      const cells = body.blocks.find(block => block.header === 'stages');
+      if (cells) {
+        errors.push(...validateJenkinsStages(cells, `the matrix of stage "${name}"`));
+      } else {
+        errors.push(schemaError(`Matrix of stage "${name}" must have a "stages" block`));
+      }
+    }
+  }
+
+  return errors;
+}
+
+/**
+ * Parse Groovy into its blocks, skipping strings and comments; the error message when braces
+ * or strings are left open
+ */
+function parseGroovyBlocks(content: string): GroovyBlock | string {
+  const root: GroovyBlock = { header: '', statements: [], blocks: [] };
+  const stack = [root];
+  let text = '';
+  let line = 1;
+
+  const flush = () => {
+    if (text.trim()) {
+      stack[stack.length - 1]!.statements.push(text.trim());
+    }
+    text = '';
+  };
+
+  for (let index = 0; index < content.length; index++) {
+    const char = content[index]!;
+    const triple = content.slice(index, index + 3);
+    if (triple === "'''" || triple === '"""') {
+      let end = index + 3;
+      while (end < content.length && (content.slice(end, end + 3) !== triple || content[end - 1] === '\\')) {
+        end++;
+      }
+      if (end >= content.length) {
+        return `Unterminated string starting on line ${line}`;
+      }
+      const literal = content.slice(index, end + 3);
+      text += literal;
+      line += literal.split('\n').length - 1;
+      index = end + 2;
+    } else if (char === "'" || char === '"') {
+      let end = index + 1;
+      while (end < content.length && content[end] !== char && content[end] !== '\n') {
+        end += content[end] === '\\' ? 2 : 1;
+      }
+      if (content[end] !== char) {
+        return `Unterminated string on line ${line}`;
+      }
+      text += content.slice(index, end + 1);
+      index = end;
+    } else if (content.startsWith('//', index)) {
+      const end = content.indexOf('\n', index);
+      index = (end < 0 ? content.length : end) - 1;
+    } else if (content.startsWith('/*', index)) {
+      const end = content.indexOf('*/', index + 2);
+      if (end < 0) {
+        return `Unterminated comment starting on line ${line}`;
+      }
+      line += content.slice(index, end).split('\n').length - 1;
+      index = end + 1;
+    } else if (char === '{') {
+      const block: GroovyBlock = { header: text.trim(), statements: [], blocks: [] };
+      stack[stack.length - 1]!.blocks.push(block);
+      stack.push(block);
+      text = '';
+    } else if (char === '}') {
+      if (stack.length === 1) {
+        return `Unexpected "}" on line ${line}`;
+      }
+      flush();
+      stack.pop();
+    } else if (char === '\n' || char === ';') {
+      flush();
+      line += char === '\n' ? 1 : 0;
+    } else {
+      text += char;
+    }
+  }
+
+  if (stack.length > 1) {
+    return `Block "${stack[stack.length - 1]!.header}" is not closed`;
+  }
+  return root;
+}
+
+/**
+ * matrix.* references with no matching matrix key. The generator shares steps between matrix
+ * and single-version jobs, so a reference with an `||` fallback may name an absent key.
+ */
+function findUndefinedMatrixVariables(job: Record<string, any>): string[] {
+  const matrix = job.strategy?.matrix;
+  // A matrix built from an expression (fromJSON) can only be checked at run time
+  if (typeof matrix === 'string') {
+    return [];
+  }
+
+  const defined = new Set<string>();
+  if (isMapping(matrix)) {
+    for (const [key, value] of Object.entries<any>(matrix)) {
+      if (key === 'include') {
+        toList(value).filter(isMapping).forEach((entry: any) => Object.keys(entry).forEach(name => defined.add(name)));
+      } else if (key !== 'exclude') {
+        defined.add(key);
+      }
+    }
+  }
+
+  const { strategy, ...rest } = job;
+  const expressions = [
+    ...[...JSON.stringify(rest).matchAll(/\$\{\{(.*?)\}\}/g)].map(match => match[1]!),
+    // `if` conditions are expressions without the ${{ }} wrapper
+    ...[rest, ...(Array.isArray(rest.steps) ? rest.steps : [])]
+      .map(entry => entry?.if)
+      .filter((condition): condition is string => typeof condition === 'string' && !condition.includes('${{'))
+  ];
+
+  const missing = new Set<string>();
+  for (const expression of expressions) {
+    for (const reference of expression.matchAll(/\bmatrix\.([A-Za-z_][\w-]*)(\s*\|\|)?/g)) {
+      if (!reference[2] && !defined.has(reference[1]!)) {
+        missing.add(reference[1]!);
+      }
+    }
+  }
+  return [...missing];
+}
+
+function hasRunner(runsOn: any): boolean {
+  if (typeof runsOn === 'string') {
+    return runsOn.trim().length > 0;
+  }
+  if (Array.isArray(runsOn)) {
+    return runsOn.length > 0 && runsOn.every(label => typeof label === 'string' && label.trim().length > 0);
+  }
+  if (isMapping(runsOn)) {
+    return hasRunner(runsOn.group) || hasRunner(runsOn.labels);
+  }
+  return false;
+}
+
+function toList(value: any): any[] {
+  if (value === undefined || value === null) {
+    return [];
+  }
+  return Array.isArray(value) ? value : [value];
+}
+
+function isMapping(value: any): value is Record<string, any> {
+  return typeof value === 'object' && value !== null && !Array.isArray(value);
+}
+
+function schemaError(message: string, type: ValidationError['type'] = 'schema'): ValidationError {
+  return { type, message, severity: 'error' };
+}
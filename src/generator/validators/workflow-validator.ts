@@ -48,6 +48,7 @@ const GITHUB_ACTIONS_SCHEMA = {
               }
             },
             workflow_dispatch: { type: 'object' },
+            workflow_call: { type: 'object' },
             repository_dispatch: { type: 'object' }
           }
         }
@@ -96,6 +97,7 @@ const GITHUB_ACTIONS_SCHEMA = {
           type: 'object',
           properties: {
             name: { type: 'string' },
+            uses: { type: 'string' },
             'runs-on': {
               oneOf: [
                 { type: 'string' },
@@ -271,7 +273,11 @@ const GITHUB_ACTIONS_SCHEMA = {
               }
             }
           },
-          required: ['runs-on', 'steps']
+          // Jobs calling a reusable workflow bring their runner and steps with it
+          anyOf: [
+            { required: ['runs-on', 'steps'] },
+            { required: ['uses'] }
+          ]
         }
       },
       additionalProperties: false
@@ -551,9 +551,8 @@ export class AdvancedSecurityGenerator {
     if (securityLevel === 'standard' || securityLevel === 'enterprise') {
       steps.push({
         name: 'SonarCloud Scan',
-        uses: 'SonarSource/sonarcloud-github-action@master',
+        uses: 'SonarSource/sonarqube-scan-action@v5',
         env: {
-          GITHUB_TOKEN: '${{ secrets.GITHUB_TOKEN }}',
           SONAR_TOKEN: '${{ secrets.SONAR_TOKEN }}'
         },
         with: {
@@ -566,11 +565,7 @@ export class AdvancedSecurityGenerator {
     if (securityLevel === 'enterprise') {
       steps.push({
         name: 'Run Semgrep',
-        uses: 'returntocorp/semgrep-action@v1',
-        with: {
-          config: 'auto',
-          generateSarif: 'true'
-        },
+        run: 'pipx run semgrep ci --sarif --output=semgrep.sarif',
         env: {
           SEMGREP_APP_TOKEN: '${{ secrets.SEMGREP_APP_TOKEN }}'
         }
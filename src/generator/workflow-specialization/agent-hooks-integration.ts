@@ -746,7 +746,7 @@ export class AgentHooksIntegration {
       },
       {
         name: 'Create security PR',
-        uses: 'peter-evans/create-pull-request@v5',
+        uses: 'peter-evans/create-pull-request@v7',
         with: {
           token: '${{ secrets.GITHUB_TOKEN }}',
           'commit-message': 'security: fix ${{ steps.analyze-alert.outputs.vulnerability }} in ${{ steps.analyze-alert.outputs.package }}',
@@ -1416,7 +1416,7 @@ update-candidates.json`
       },
       {
         name: 'Create update PR',
-        uses: 'peter-evans/create-pull-request@v5',
+        uses: 'peter-evans/create-pull-request@v7',
         with: {
           token: '${{ secrets.GITHUB_TOKEN }}',
           'commit-message': 'deps: intelligent dependency updates via Agent Hooks',
@@ -1755,7 +1755,7 @@ optimization-recommendations.md`
       },
       {
         name: 'Create optimization PR',
-        uses: 'peter-evans/create-pull-request@v5',
+        uses: 'peter-evans/create-pull-request@v7',
         with: {
           token: '${{ secrets.GITHUB_TOKEN }}',
           'commit-message': 'perf: workflow optimizations via Agent Hooks',
@@ -2168,7 +2168,7 @@ recovery-recommendations.md`
       },
       {
         name: 'Create recovery PR',
-        uses: 'peter-evans/create-pull-request@v5',
+        uses: 'peter-evans/create-pull-request@v7',
         with: {
           token: '${{ secrets.GITHUB_TOKEN }}',
           'commit-message': 'feat: implement intelligent recovery mechanisms via Agent Hooks',
@@ -51,7 +51,7 @@ export class CDWorkflowGenerator {
         packages: 'write'
       },
       concurrency: {
-        group: 'cd-${{ github.ref }}',
+        group: '${{ github.workflow }}-${{ github.ref }}',
         cancelInProgress: false // Don't cancel deployments
       }
     };
@@ -79,9 +79,9 @@ export class CDWorkflowGenerator {
       }
     };
 
-    // Add push trigger for main branch (staging deployment)
+    // Add push trigger for the default branch (staging deployment)
     triggers.push = {
-      branches: ['main', 'master'],
+      branches: options.defaultBranch ? [options.defaultBranch] : ['main', 'master'],
       paths: ['src/**', 'package.json', 'requirements.txt', 'Cargo.toml', 'go.mod', 'pom.xml', 'build.gradle']
     };
 
@@ -529,7 +529,7 @@ export class CDWorkflowGenerator {
       },
       {
         name: 'Build and push Docker image',
-        uses: 'docker/build-push-action@v5',
+        uses: 'docker/build-push-action@v6',
         with: {
           context: '.',
           push: true,
@@ -584,7 +584,7 @@ export class CDWorkflowGenerator {
 
       steps.push({
         name: 'Azure Login',
-        uses: 'azure/login@v1',
+        uses: 'azure/login@v2',
         with: {
           'client-id': '${{ secrets.AZURE_CLIENT_ID }}',
           'tenant-id': '${{ secrets.AZURE_TENANT_ID }}',
@@ -709,7 +709,7 @@ export class CDWorkflowGenerator {
         uses: 'nwtgck/actions-netlify@v3',
         with: {
           'publish-dir': 'dist',
-          'production-branch': environment.type === 'production' ? 'main' : undefined,
+          'production-branch': environment.type === 'production' ? options.defaultBranch || 'main' : undefined,
           'github-token': '${{ secrets.GITHUB_TOKEN }}',
           'deploy-message': 'Deploy from GitHub Actions'
         },
@@ -3,103 +3,2880 @@
  * Focuses on build and test optimization
  */
 
-import { DetectionResult, GenerationOptions, WorkflowOutput } from '../interfaces';
-import { WorkflowTemplate, JobTemplate, StepTemplate, TriggerConfig, MatrixStrategy } from '../types';
+import { posix } from 'path';
+import { DetectionResult, GenerationOptions, GenerationPreset, HostedOS, WorkflowOutput, PlatformTarget, Provider, MonorepoPackage, DockerImageDetection, TestRunnerDetection, StaticSiteDetection, JavaBuildDetection, ServiceDetection, CoverageDetection, LinterDetection, PythonLayoutDetection, PreCommitDetection, TerraformDetection, ApiSchemaDetection, TaskGraphDetection, ChangelogDetection, CodeownersDetection, ProtobufDetection, GeneratedCodeDetection, StepAnchor, MatrixRule, HOSTED_OS_NAMES } from '../interfaces';
+import { WorkflowTemplate, CompositeActionTemplate, JobTemplate, StepTemplate, TriggerConfig, MatrixStrategy, ConcurrencyConfig, WorkflowInput, WorkflowCallOutput, PermissionConfig } from '../types';
+import { YAMLRenderer } from '../renderers/yaml-renderer';
+import { GitLabCIRenderer } from '../renderers/gitlab-renderer';
+import { CircleCIRenderer } from '../renderers/circleci-renderer';
+import { AzurePipelinesRenderer } from '../renderers/azure-pipelines-renderer';
+import { BitbucketPipelinesRenderer } from '../renderers/bitbucket-pipelines-renderer';
+import { JenkinsRenderer } from '../renderers/jenkins-renderer';
+import { FormattingOptions, Renderer } from '../renderers/renderer-types';
+import { CacheStrategyGenerator, DependencyCacheStrategy } from '../utils/cache-utils';
+import { getExistingCIProviders } from '../utils/ci-badges';
+import { validateCron } from '../utils/cron';
+import { stripComments } from '../utils/yaml-comments';
+import { matchPathFilters } from '../utils/path-filters';
+import { toPosixPath } from '../../shared/input-normalization';
+import { isJobDisabled } from '../utils/job-ids';
+import { isKnownRunnerImage, pinRunnerImage } from '../utils/runner-images';
+import { orderSteps } from '../utils/step-order';
+import { validateCompositeActionStructure } from '../validators/structure-validator';
+
+/**
+ * GitHub-hosted runners per GOOS and the GOARCH values they can execute natively: the x64
+ * runners run 386 binaries too, while macos-latest is Apple silicon only
+ */
+const GO_HOSTED_RUNNERS: Record<string, { runner: string; architectures: string[] }> = {
+  linux: { runner: 'ubuntu-latest', architectures: ['amd64', '386'] },
+  darwin: { runner: 'macos-latest', architectures: ['arm64'] },
+  windows: { runner: 'windows-latest', architectures: ['amd64', '386'] }
+};
+
+/**
+ * Runner image and GOOS of each operating system the test matrix can cover
+ */
+const HOSTED_OS_RUNNERS: Record<HostedOS, { runner: string; goos: string }> = {
+  linux: { runner: 'ubuntu-latest', goos: 'linux' },
+  macos: { runner: 'macos-latest', goos: 'darwin' },
+  windows: { runner: 'windows-latest', goos: 'windows' }
+};
+
+/**
+ * Runtimes whose manifest version constraints drive the version matrix
+ */
+const CONSTRAINED_RUNTIMES: Record<string, { runtime: string; matrixKey: string; defaultVersion: string }> = {
+  javascript: { runtime: 'node', matrixKey: 'node-version', defaultVersion: '18' },
+  typescript: { runtime: 'node', matrixKey: 'node-version', defaultVersion: '18' },
+  python: { runtime: 'python', matrixKey: 'python-version', defaultVersion: '3.11' },
+  go: { runtime: 'go', matrixKey: 'go-version', defaultVersion: '1.21' }
+};
+
+/**
+ * Newest supported release lines tested when a constraint allows a range
+ */
+const MAX_CONSTRAINED_VERSIONS = 3;
+
+/**
+ * Elixir and OTP releases set up when the Mix project pins none
+ */
+const DEFAULT_ELIXIR_VERSION = '1.16';
+const DEFAULT_OTP_VERSION = '26';
+
+/**
+ * Ruby release set up when the project pins none
+ */
+const DEFAULT_RUBY_VERSION = '3.3';
+
+/**
+ * Swift release set up when Package.swift names no tools version
+ */
+const DEFAULT_SWIFT_VERSION = '5.10';
+
+/**
+ * GHC release Cabal projects are built with when their tested-with field names none
+ */
+const DEFAULT_GHC_VERSION = '9.6';
+
+/**
+ * .NET SDK set up when neither global.json nor the target frameworks name one
+ */
+const DEFAULT_DOTNET_VERSION = '8.0.x';
+
+/**
+ * Target frameworks tests can run on a runner, as opposed to netstandard and OS-specific ones
+ */
+const RUNNABLE_DOTNET_FRAMEWORK = /^net(?:coreapp)?(\d+\.\d+)$/;
+
+/**
+ * Oldest CMake release set up for a project: the first with `cmake -B`
+ */
+const MIN_CMAKE_VERSION = '3.13';
+
+/**
+ * C and C++ sources clang-format checks
+ */
+const CLANG_FORMAT_SOURCES = ['*.c', '*.cc', '*.cpp', '*.cxx', '*.h', '*.hh', '*.hpp', '*.hxx'];
+
+/**
+ * File a composite action is read from, in the directory its `uses:` path names
+ */
+const COMPOSITE_ACTION_FILENAME = 'action.yml';
+
+/**
+ * Registry images built from detected Dockerfiles are pushed to unless configured otherwise
+ */
+const DEFAULT_CONTAINER_REGISTRY = 'ghcr.io';
+
+/**
+ * Runners the language's default test steps already invoke; a lone one keeps those steps
+ */
+const DEFAULT_TEST_RUNNERS = new Set(['pytest', 'jest', 'vitest', 'mocha', 'go test', 'cargo test', 'maven', 'gradle']);
+
+/**
+ * Makefile targets that take over a step, most specific first
+ */
+const MAKE_TARGET_INTENTS: Record<'build' | 'test' | 'lint', string[]> = {
+  build: ['build'],
+  test: ['test'],
+  lint: ['lint', 'check']
+};
+
+/**
+ * package.json scripts that take over a Node step, preferred first
+ */
+const PACKAGE_SCRIPT_INTENTS: Record<'build' | 'test' | 'lint' | 'typecheck', string[]> = {
+  build: ['build'],
+  test: ['test:ci', 'test', 'test:unit'],
+  lint: ['lint'],
+  typecheck: ['typecheck', 'type-check']
+};
+
+/**
+ * Makefile target that runs the whole pipeline
+ */
+const MAKE_CI_TARGET = 'ci';
+
+/**
+ * Job running the Nx or Turborepo tasks of the packages a change affects
+ */
+const TASK_GRAPH_JOB = 'affected';
+
+/**
+ * Jobs that run the project's own code and so get the secrets and system packages the README requires
+ */
+const SECRET_JOB_PATTERN = /^(ci|affected|build|(unit|integration|e2e)-tests(-.+)?)$/;
+
+/**
+ * Condition limiting publishing jobs to pushes to the default branch
+ */
+const DEFAULT_BRANCH_PUSH = "github.event_name == 'push' && github.ref == format('refs/heads/{0}', github.event.repository.default_branch)";
+
+/**
+ * Runner and flutter build target of each platform a Flutter app can have a directory for
+ */
+const FLUTTER_BUILD_TARGETS: Record<string, { os: HostedOS; build: string }> = {
+  android: { os: 'linux', build: 'apk' },
+  ios: { os: 'macos', build: 'ios --no-codesign' },
+  web: { os: 'linux', build: 'web' },
+  linux: { os: 'linux', build: 'linux' },
+  macos: { os: 'macos', build: 'macos' },
+  windows: { os: 'windows', build: 'windows' }
+};
+
+/**
+ * Packages the Linux desktop embedder of Flutter builds with
+ */
+const FLUTTER_LINUX_PACKAGES = ['clang', 'cmake', 'ninja-build', 'pkg-config', 'libgtk-3-dev'];
+
+/**
+ * Families of languages sharing setup, build and test steps; a workflow gets one set of jobs per family
+ */
+const LANGUAGE_FAMILIES: Record<string, string> = {
+  javascript: 'node',
+  typescript: 'node',
+  python: 'python',
+  go: 'go',
+  rust: 'rust',
+  java: 'java',
+  elixir: 'elixir',
+  ruby: 'ruby',
+  swift: 'swift',
+  'c#': 'dotnet',
+  dart: 'dart',
+  haskell: 'haskell',
+  'c/c++': 'cpp',
+  shell: 'shell'
+};
+
+/**
+ * Language families of package managers and build tools, for splitting them between language jobs
+ */
+const TOOL_FAMILIES: Record<string, string> = {
+  npm: 'node', yarn: 'node', pnpm: 'node', bun: 'node', webpack: 'node', vite: 'node',
+  pip: 'python', poetry: 'python', pipenv: 'python',
+  go: 'go',
+  cargo: 'rust',
+  maven: 'java', gradle: 'java',
+  mix: 'elixir',
+  bundler: 'ruby',
+  swiftpm: 'swift',
+  dotnet: 'dotnet', nuget: 'dotnet',
+  pub: 'dart', flutter: 'dart',
+  stack: 'haskell', cabal: 'haskell',
+  cmake: 'cpp'
+};
+
+/**
+ * Language families of test frameworks, recognized by name
+ */
+const TEST_FRAMEWORK_FAMILIES: Array<[RegExp, string]> = [
+  [/^(jest|vitest|mocha|jasmine|ava|karma|cypress|playwright|supertest|@?testing[- ]library)\b/i, 'node'],
+  [/^(pytest|unittest|nose2?|tox|nox|hypothesis)\b/i, 'python'],
+  [/^(go test|testify|ginkgo)\b/i, 'go'],
+  [/^cargo\b/i, 'rust'],
+  [/^(junit|testng|spock|maven|gradle)\b/i, 'java'],
+  [/^(exunit|mix test)\b/i, 'elixir'],
+  [/^(rspec|minitest|test-unit|rake)\b/i, 'ruby'],
+  [/^(xctest|swift test|swift-testing)\b/i, 'swift'],
+  [/^(xunit|nunit|mstest|dotnet test)\b/i, 'dotnet'],
+  [/^(flutter test|dart test|package:test)\b/i, 'dart'],
+  [/^(hspec|tasty|hunit|quickcheck|stack test|cabal test)\b/i, 'haskell'],
+  [/^(ctest|googletest|gtest|catch2?)\b/i, 'cpp'],
+  [/^bats\b/i, 'shell']
+];
+
+/**
+ * Test framework standing in for a language that has no detected one, so its jobs still run its tests
+ */
+const DEFAULT_FAMILY_TESTS: Record<string, string> = {
+  node: 'npm test',
+  python: 'pytest',
+  go: 'go test',
+  rust: 'cargo test',
+  java: 'junit',
+  elixir: 'mix test',
+  ruby: 'rake',
+  swift: 'swift test',
+  dotnet: 'dotnet test',
+  dart: 'dart test',
+  haskell: 'cabal test',
+  cpp: 'ctest'
+};
+
+/**
+ * Condition keeping slow or noisy jobs out of the weekly scheduled CI run
+ */
+const NOT_SCHEDULED = "github.event_name != 'schedule'";
+
+/**
+ * One concurrency group per workflow and ref, so a newer run supersedes an older one
+ */
+const CONCURRENCY_GROUP = '${{ github.workflow }}-${{ github.ref }}';
+
+/**
+ * Jobs each preset keeps: the formatting, lint and static analysis job for lint, build and test jobs for test
+ */
+const PRESET_JOB_PATTERNS: Record<Exclude<GenerationPreset, GenerationPreset.Full>, RegExp> = {
+  [GenerationPreset.Lint]: /^lint$/,
+  [GenerationPreset.Test]: /^(build|(unit|integration|e2e)-tests(-.+)?)$/
+};
+
+/**
+ * Jobs that run the project's tests and so get the services the README requires
+ */
+const SERVICE_JOB_PATTERN = /^(ci|(unit|integration|e2e)-tests(-.+)?)$/;
+
+/**
+ * Service containers for services a README or compose file can require, with the tag used when
+ * neither names a version, and the connection variable tests read from the container env
+ */
+const SERVICE_CONTAINERS: Record<string, {
+  image: string;
+  defaultTag: string;
+  port: number;
+  env?: Record<string, string>;
+  healthCmd: string;
+  connection: (host: string, env: Record<string, string>) => [string, string];
+}> = {
+  postgres: {
+    image: 'postgres', defaultTag: '16', port: 5432, env: { POSTGRES_PASSWORD: 'postgres' }, healthCmd: 'pg_isready',
+    connection: (host, env) => {
+      const user = env.POSTGRES_USER || 'postgres';
+      return ['DATABASE_URL', `postgresql://${user}:${env.POSTGRES_PASSWORD}@${host}:5432/${env.POSTGRES_DB || user}`];
+    }
+  },
+  mysql: {
+    image: 'mysql', defaultTag: '8.0', port: 3306, env: { MYSQL_ROOT_PASSWORD: 'root', MYSQL_DATABASE: 'test' }, healthCmd: '"mysqladmin ping -h localhost"',
+    connection: (host, env) => {
+      const [user, password] = env.MYSQL_USER ? [env.MYSQL_USER, env.MYSQL_PASSWORD] : ['root', env.MYSQL_ROOT_PASSWORD];
+      return ['DATABASE_URL', `mysql://${user}:${password}@${host === 'localhost' ? '127.0.0.1' : host}:3306/${env.MYSQL_DATABASE}`];
+    }
+  },
+  redis: { image: 'redis', defaultTag: '7', port: 6379, healthCmd: '"redis-cli ping"', connection: host => ['REDIS_URL', `redis://${host}:6379`] },
+  mongodb: {
+    image: 'mongo', defaultTag: '7', port: 27017, healthCmd: '"mongosh --quiet --eval \'db.adminCommand({ ping: 1 })\'"',
+    connection: (host, env) => {
+      const credentials = env.MONGO_INITDB_ROOT_USERNAME ? `${env.MONGO_INITDB_ROOT_USERNAME}:${env.MONGO_INITDB_ROOT_PASSWORD}@` : '';
+      return ['MONGODB_URI', `mongodb://${credentials}${host}:27017`];
+    }
+  },
+  rabbitmq: {
+    image: 'rabbitmq', defaultTag: '3', port: 5672, healthCmd: '"rabbitmq-diagnostics -q ping"',
+    connection: (host, env) => ['AMQP_URL', `amqp://${env.RABBITMQ_DEFAULT_USER || 'guest'}:${env.RABBITMQ_DEFAULT_PASS || 'guest'}@${host}:5672`]
+  }
+};
+
+/**
+ * Steps that run a test job's tests and get the services' connection variables
+ */
+const TEST_STEP_PATTERN = /^Run (.+ )?tests$|^Run make /i;
+
+/**
+ * Jobs running nothing but tests, which get the test timeout and retries
+ */
+const TEST_JOB_PATTERN = /^(unit|integration|e2e)-tests(-.+)?$/;
+
+/**
+ * Repository secret private Go modules are fetched with, unless options.privateModulesSecret names another
+ */
+const DEFAULT_PRIVATE_MODULES_SECRET = 'GO_MODULES_TOKEN';
+
+/**
+ * Build artifact name and days it is kept, unless options.artifactName and options.artifactRetentionDays say otherwise
+ */
+const DEFAULT_ARTIFACT_NAME = 'build-artifacts';
+const DEFAULT_ARTIFACT_RETENTION_DAYS = 7;
+
+/**
+ * Commands installing a configured linter the project's dependencies do not bring along
+ */
+const LINTER_INSTALL_COMMANDS: Record<LinterDetection['name'], string> = {
+  'golangci-lint': 'curl -sSfL https://raw.githubusercontent.com/golangci/golangci-lint/HEAD/install.sh | sh -s -- -b "$(go env GOPATH)/bin"',
+  eslint: 'npm install --no-save eslint',
+  ruff: 'pip install ruff',
+  flake8: 'pip install flake8',
+  black: 'pip install black',
+  clippy: 'rustup component add clippy'
+};
+
+/**
+ * What release tags start with before the version, unless options.releaseTagPrefix says otherwise
+ */
+const DEFAULT_RELEASE_TAG_PREFIX = 'v';
+
+/**
+ * Minutes a test job may run before it is cancelled, unless options.jobTimeout says otherwise
+ */
+const DEFAULT_TEST_JOB_TIMEOUT = 30;
+
+/**
+ * Report each coverage tool writes once instrumented, its Coveralls format when that is not
+ * inferred from the file, and how to install it when the project does not already have it
+ */
+const COVERAGE_TOOLS: Record<CoverageDetection['tool'], { file: string; format?: string; install?: string }> = {
+  'go-cover': { file: 'coverage.out', format: 'golang' },
+  jest: { file: 'coverage/lcov.info' },
+  vitest: { file: 'coverage/lcov.info', install: 'npm install --no-save @vitest/coverage-v8' },
+  nyc: { file: 'coverage/lcov.info', install: 'npm install --no-save nyc' },
+  c8: { file: 'coverage/lcov.info', install: 'npm install --no-save c8' },
+  'pytest-cov': { file: 'coverage.xml', format: 'cobertura', install: 'pip install pytest-cov' }
+};
+
+/**
+ * Jobs whose test step is instrumented for coverage
+ */
+const COVERAGE_JOB_PATTERN = /^unit-tests(-.+)?$/;
+
+/**
+ * Shell lines reading the line coverage percentage out of each report format into $percentage
+ */
+const COVERAGE_PERCENTAGE_COMMANDS: Array<{ file: RegExp; command: (file: string) => string }> = [
+  { file: /\.out$/, command: file => `percentage=$(go tool cover -func=${file} | awk '/^total:/ { sub("%", "", $3); print $3 }')` },
+  {
+    file: /\.info$/,
+    command: file => `percentage=$(awk -F: '/^LF:/ { found += $2 } /^LH:/ { hit += $2 } END { if (found > 0) printf "%.2f", hit * 100 / found }' ${file})`
+  },
+  {
+    file: /\.xml$/,
+    command: file => `percentage=$(python3 -c "import xml.etree.ElementTree as tree; print(round(float(tree.parse('${file}').getroot().get('line-rate')) * 100, 2))")`
+  }
+];
+
+/**
+ * Lint jobs, including those of each language in a mixed repository
+ */
+const LINT_JOB_PATTERN = /^(.+-)?lint$/;
+
+/**
+ * Job running the repository's pre-commit hooks
+ */
+const PRE_COMMIT_JOB = 'pre-commit';
+
+/**
+ * Job that closes a parallel Coveralls build once every matrix entry has uploaded
+ */
+const COVERALLS_FINISH_JOB = 'coverage';
+
+/**
+ * Job checking and planning the Terraform configuration
+ */
+const TERRAFORM_JOB = 'terraform';
+
+/**
+ * Job failing when a dependency's license is outside the allowlist
+ */
+const LICENSE_CHECK_JOB = 'license-check';
+
+/**
+ * Job linting the OpenAPI documents and GraphQL schemas
+ */
+const API_LINT_JOB = 'api-lint';
+
+/**
+ * Job linting the protobuf definitions with buf and checking them for breaking changes
+ */
+const BUF_JOB = 'buf';
+
+/**
+ * Job deploying the static site to GitHub Pages
+ */
+const PAGES_JOB = 'pages';
+
+/**
+ * URL deploy-pages published the site at
+ */
+const PAGES_URL = '${{ steps.deployment.outputs.page_url }}';
+
+/**
+ * Placeholder of the deployEnvironment url for the URL deployed to
+ */
+const DEPLOYED_URL = '{url}';
+
+/**
+ * Job failing pull requests that leave the changelog unchanged
+ */
+const CHANGELOG_JOB = 'changelog';
+
+/**
+ * Label exempting a pull request from the changelog check when none is configured
+ */
+const DEFAULT_CHANGELOG_SKIP_LABEL = 'no-changelog';
+
+/**
+ * Job commenting on pull requests with the CODEOWNERS rules their changes fall under
+ */
+const CODEOWNERS_JOB = 'codeowners';
+
+/**
+ * First line of the codeowners comment, by which later runs find it to update it
+ */
+const CODEOWNERS_COMMENT_MARKER = '<!-- readme-to-cicd:codeowners -->';
+
+/**
+ * Licenses the license check allows when no allowlist is configured, besides the project's own
+ */
+const DEFAULT_LICENSE_ALLOWLIST = ['MIT', 'Apache-2.0', 'BSD-2-Clause', 'BSD-3-Clause', 'ISC'];
+
+/**
+ * Jobs holding each anchor injected steps are spliced in at, as warnings name them
+ */
+const STEP_ANCHOR_JOBS: Record<StepAnchor, string> = {
+  'before-build': 'build job',
+  'after-test': 'test job',
+  'pre-deploy': 'deploy job'
+};
+
+/**
+ * Job whose dorny/paths-filter outputs say which monorepo packages changed
+ */
+const MONOREPO_CHANGES_JOB = 'changes';
+
+/**
+ * Workflow files split CI jobs are written to, one per kind of job
+ */
+type SplitKind = 'build' | 'test' | 'lint';
+
+const SPLIT_KINDS: SplitKind[] = ['build', 'test', 'lint'];
+
+/**
+ * Reusable workflow the build jobs of split workflows move to, and the job calling it
+ */
+const SPLIT_SETUP_WORKFLOW = 'setup';
+const SPLIT_SETUP_JOB = 'setup';
+
+/**
+ * Token permissions each action needs beyond reading the repository, by action reference
+ */
+const ACTION_PERMISSIONS: Array<[RegExp, PermissionConfig]> = [
+  [/^actions\/deploy-pages@/, { pages: 'write', idToken: 'write' }],
+  [/^(softprops\/action-gh-release|goreleaser\/goreleaser-action)@/, { contents: 'write' }],
+  [/^github\/codeql-action\//, { securityEvents: 'write' }],
+  [/^dorny\/paths-filter@/, { pullRequests: 'read' }]
+];
 
 export class CIWorkflowGenerator {
+  private yamlRenderer: YAMLRenderer;
+  /** Renderers for the providers other than GitHub Actions, which the YAML renderer covers */
+  private renderers: Partial<Record<Provider, Renderer>>;
+  private cacheStrategyGenerator: CacheStrategyGenerator;
+
+  constructor() {
+    const formattingOptions: FormattingOptions = {
+      yamlConfig: {
+        indent: 2,
+        lineWidth: 120,
+        noRefs: true,
+        noCompatMode: false,
+        condenseFlow: false,
+        quotingType: 'auto',
+        forceQuotes: false,
+        sortKeys: false
+      },
+      commentConfig: {
+        enabled: true,
+        includeGenerationInfo: true,
+        includeStepDescriptions: false,
+        includeOptimizationNotes: false,
+        customComments: {}
+      },
+      preserveComments: true,
+      addBlankLines: false
+    };
+
+    this.yamlRenderer = new YAMLRenderer(formattingOptions);
+    this.renderers = {
+      [Provider.GitLab]: new GitLabCIRenderer(formattingOptions),
+      [Provider.CircleCI]: new CircleCIRenderer(formattingOptions),
+      [Provider.AzurePipelines]: new AzurePipelinesRenderer(formattingOptions),
+      [Provider.BitbucketPipelines]: new BitbucketPipelinesRenderer(formattingOptions),
+      [Provider.Jenkins]: new JenkinsRenderer(formattingOptions)
+    };
+    this.cacheStrategyGenerator = new CacheStrategyGenerator();
+  }
+
+  /**
+   * Generate CI-focused workflow optimized for build and test steps
+   */
+  async generateCIWorkflow(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput> {
+    const workflow = this.asReusableWorkflow(this.createCIWorkflowTemplate(detectionResult, options), options);
+    const warnings = this.getWarnings(detectionResult);
+    const preset = this.getPreset(options);
+    if (workflow.jobs.length === 0 && preset) {
+      // Nothing is written rather than a workflow without jobs
+      return {
+        filename: 'ci.yml',
+        content: '',
+        type: 'ci',
+        metadata: {
+          generatedAt: new Date(),
+          generatorVersion: '1.0.0',
+          detectionSummary: this.createDetectionSummary(detectionResult),
+          optimizations: [],
+          warnings: [...warnings, `The ${preset} preset found no ${preset === GenerationPreset.Lint ? 'linters' : 'build or test steps'} for this project - no ci workflow generated`]
+        }
+      };
+    }
+    if (detectionResult.taskGraph && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`The ${detectionResult.taskGraph.tool} affected job is only generated for GitHub Actions - the lint, build and test jobs cover every package`);
+    }
+    if (options.makeCI && !detectionResult.makefile?.targets.includes(MAKE_CI_TARGET)) {
+      warnings.push(`No ${MAKE_CI_TARGET} target in the Makefile - generating separate lint, build and test jobs`);
+    }
+    if (options.terraform && !detectionResult.terraform) {
+      warnings.push('No Terraform configuration (*.tf) found - no terraform job generated');
+    } else if (options.terraform && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push('The terraform job is only generated for GitHub Actions');
+    } else if (workflow.jobs.some(job => job.name === TERRAFORM_JOB)) {
+      warnings.push('terraform plan runs on pushes to the default branch - provide the backend and provider credentials to the terraform job as secrets or OIDC');
+    }
+    if (options.apiLint && !detectionResult.apiSchemas) {
+      warnings.push('No OpenAPI or GraphQL schema found - no api-lint job generated');
+    }
+    if (options.protobuf && !detectionResult.protobuf) {
+      warnings.push('No .proto files found - no buf job generated');
+    } else if (options.protobuf && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`The buf job is set up with bufbuild/buf-action - no buf job generated for ${options.provider}`);
+    }
+    if (options.changelogCheck && !detectionResult.changelog) {
+      warnings.push('No changelog found - no changelog job generated');
+    } else if (options.changelogCheck && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`The changelog check reads GitHub pull request labels - no changelog job generated for ${options.provider}`);
+    }
+    if (options.codeownersComment && !detectionResult.codeowners) {
+      warnings.push('No CODEOWNERS file found - no codeowners job generated');
+    } else if (options.codeownersComment && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`The codeowners comment is posted on GitHub pull requests - no codeowners job generated for ${options.provider}`);
+    } else if (options.codeownersComment && detectionResult.codeowners!.rules.length === 0) {
+      warnings.push(`${detectionResult.codeowners!.file} has no rule - no codeowners job generated`);
+    }
+    if (options.deployPages && !detectionResult.staticSite) {
+      warnings.push('No static site generator detected - no GitHub Pages deploy job generated');
+    } else if (options.deployPages && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push('GitHub Pages deployment is only generated for GitHub Actions');
+    }
+    if (options.deployEnvironment && !workflow.jobs.some(job => job.name === PAGES_JOB)) {
+      warnings.push(`deployEnvironment ${options.deployEnvironment.name} is ignored - no deploy job is generated`);
+    }
+    if (options.runnerLabels && detectionResult.buildConstraints?.platforms.length) {
+      warnings.push('Build constraints target several platforms - no GOOS/GOARCH runner matrix is generated for self-hosted runner labels');
+    }
+    const github = !options.provider || options.provider === Provider.GitHubActions;
+    if (options.testOS?.length && !github) {
+      warnings.push(`The unit test operating systems are only selected for GitHub Actions - ${options.provider} jobs run on their usual image`);
+    } else if (options.testOS?.length && options.runnerLabels) {
+      warnings.push(`Self-hosted runner labels replace the hosted ${options.testOS.join(', ')} runners the unit tests were asked to run on`);
+    }
+    const targeted = new Set((detectionResult.buildConstraints?.platforms || []).map(platform => platform.goos));
+    const untargeted = targeted.size > 0 ? (options.testOS || []).filter(os => !targeted.has(HOSTED_OS_RUNNERS[os].goos)) : [];
+    if (untargeted.length > 0 && github && !options.runnerLabels) {
+      warnings.push(`Build constraints only target ${[...targeted].join(', ')} - unit tests run on ${untargeted.join(', ')} anyway, as asked`);
+    }
+    if (options.licenseCheck) {
+      // Each language of a mixed repository gets its own license-check job
+      for (const languageResult of this.splitByLanguage(detectionResult)?.map(unit => unit.detectionResult) || [detectionResult]) {
+        if (!this.createLicenseCheckJob(languageResult, options)) {
+          const language = languageResult.languages.find(l => l.primary)?.name || 'the detected languages';
+          warnings.push(`No license scanner for ${language} - the license-check job is skipped`);
+        }
+      }
+    }
+    const images = Object.entries(options.runnerImages || {}) as Array<[HostedOS, string]>;
+    if (images.length > 0 && !github) {
+      warnings.push(`Runner images are only pinned for GitHub Actions - ${options.provider} jobs run on their usual image`);
+    } else if (images.length > 0 && Array.isArray(options.runnerLabels)) {
+      warnings.push('Self-hosted runner labels take precedence over the runner images, which are not used');
+    }
+    for (const [os, label] of images) {
+      if (!isKnownRunnerImage(os, label)) {
+        warnings.push(`Runner image ${label} for ${os} is not a known GitHub-hosted image label - it is used as given`);
+      }
+    }
+    if (detectionResult.swiftPackage && detectionResult.swiftPackage.testTargets.length === 0) {
+      warnings.push('Package.swift declares no test targets - swift test is not run');
+    }
+    if (detectionResult.dotnetProject && detectionResult.dotnetProject.projects.length > 0 && detectionResult.dotnetProject.testProjects.length === 0) {
+      warnings.push('No .NET project references a test framework - dotnet test is not run');
+    }
+    if (detectionResult.haskellProject && !detectionResult.haskellProject.tests) {
+      warnings.push(`The Haskell project declares no test suite - ${detectionResult.haskellProject.tool} test is not run`);
+    }
+    if (detectionResult.dartProject && !detectionResult.dartProject.tests) {
+      warnings.push(`The Dart project has no test directory - ${this.getDartTool(detectionResult)} test is not run`);
+    }
+    if (detectionResult.shellProject && detectionResult.shellProject.batsTests.length === 0) {
+      warnings.push('No bats tests (*.bats) found - the shell scripts are only checked with shellcheck');
+    }
+    if (options.flutterBuild && detectionResult.dartProject?.flutter && this.getFlutterPlatforms(detectionResult).length === 0) {
+      warnings.push('The Flutter app has no platform directories - flutter build is not run');
+    }
+    const secrets = this.getSecretNames(detectionResult, options);
+    if (secrets.length > 0 && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`Required secrets ${secrets.join(', ')} are not added to ${options.provider} pipelines - define them as CI variables`);
+    }
+    if (this.getPrivateModulePatterns(detectionResult, options).length > 0 && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`Private Go module access is only configured for GitHub Actions - set GOPRIVATE and git credentials in ${options.provider} pipelines`);
+    }
+    if (detectionResult.systemPackages?.length && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`System packages ${detectionResult.systemPackages.join(', ')} are not installed in ${options.provider} pipelines - add them to the job image`);
+    }
+    warnings.push(...this.getCoverageWarnings(workflow, detectionResult, options));
+    warnings.push(...this.getDisabledJobWarnings(workflow, detectionResult, options));
+    warnings.push(...this.getInjectedStepWarnings(workflow, options));
+    warnings.push(...this.getMatrixRuleWarnings(workflow, options));
+    if (this.lacksPackageTestScript(detectionResult) && this.getTestRunners(detectionResult).length === 0) {
+      warnings.push(`package.json has no test script (${PACKAGE_SCRIPT_INTENTS.test.join(', ')}) - no unit test job generated`);
+    }
+    for (const member of this.getPerPackageMembers(detectionResult, options) || []) {
+      if (!member.scripts.includes('test')) {
+        warnings.push(`Workspace package ${member.name} has no test script - ${member.scripts.includes('build') ? 'its job only builds it' : 'no test job generated for it'}`);
+      }
+    }
+    if (options.preCommit && !detectionResult.preCommit) {
+      warnings.push('No .pre-commit-config.yaml found - no pre-commit job generated');
+    } else if (options.preCommit === 'replace-lint' && workflow.jobs.some(job => job.name === PRE_COMMIT_JOB)) {
+      warnings.push(`Lint jobs left out: the pre-commit job runs the hooks in ${detectionResult.preCommit!.file} instead`);
+    }
+    if (options.reusable && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`Reusable workflows are only generated for GitHub Actions - ${options.provider} configuration runs on its usual triggers`);
+    }
+    if (options.schedule && options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`The nightly workflow is only generated for GitHub Actions - create a scheduled pipeline for '${options.schedule}' in ${options.provider} instead`);
+    }
+    let filename = 'ci.yml';
+    let content: string;
+    const renderer = options.provider ? this.renderers[options.provider] : undefined;
+    if (renderer) {
+      const rendered = renderer.render(workflow, detectionResult, { useOrbs: options.useOrbs });
+      filename = rendered.path;
+      content = rendered.content;
+      warnings.push(...rendered.warnings);
+    } else {
+      content = this.withRequiredSecretsComment(await this.renderWorkflow(workflow, options), this.getWorkflowSecrets(detectionResult, options), this.getEnvExampleComment(detectionResult, options), options);
+    }
+    if (options.minimal) {
+      content = stripComments(content);
+    }
+    
+    return {
+      filename,
+      content,
+      type: 'ci',
+      metadata: {
+        generatedAt: new Date(),
+        generatorVersion: '1.0.0',
+        detectionSummary: this.createDetectionSummary(detectionResult),
+        optimizations: this.getAppliedOptimizations(detectionResult, options),
+        warnings
+      }
+    };
+  }
+
+  /**
+   * Generate CI workflows for monorepo packages, either as one workflow with
+   * per-package jobs or as one path-filtered workflow file per package.
+   * Changes to a package also run the jobs of the packages depending on it.
+   * With Nx or Turborepo at the root, one workflow leaves that to the task runner instead.
+   */
+  async generateMonorepoCIWorkflows(
+    packages: MonorepoPackage[],
+    options: GenerationOptions
+  ): Promise<WorkflowOutput[]> {
+    const outputs: WorkflowOutput[] = [];
+    for (const { filename, workflow, packages: covered } of this.createMonorepoWorkflows(packages, options)) {
+      const affected = workflow.jobs.some(job => job.name === TASK_GRAPH_JOB) ? covered.find(pkg => pkg.path === '.')?.detectionResult.taskGraph : undefined;
+      outputs.push(this.createMonorepoOutput(filename, await this.renderWorkflow(workflow, options), covered, options, affected));
+    }
+    return outputs;
+  }
+
+  /**
+   * Explain, per job of the monorepo CI workflows, which files changed by a pull request make it
+   * run and why: the pattern of its workflow's pull_request paths they match, or of the changes
+   * job filter gating it. The filters are the ones generateMonorepoCIWorkflows writes. Jobs that
+   * would not run get an empty list; jobs and files are sorted, so the result is stable.
+   */
+  whyTriggered(packages: MonorepoPackage[], changedFiles: string[], options: GenerationOptions): Record<string, string[]> {
+    const files = [...new Set(changedFiles.map(file => toPosixPath(file).replace(/^\.\//, '')))].sort();
+    const reasons = new Map<string, string[]>();
+
+    for (const { workflow, gatedBy } of this.createMonorepoWorkflows(packages, options)) {
+      for (const job of workflow.jobs) {
+        const gate = gatedBy?.get(job.name);
+        const filters = gate ? this.getPackageFilters(gate, packages) : undefined;
+        reasons.set(job.name, files.flatMap(file => {
+          const trigger = this.explainPullRequestTrigger(workflow.triggers, file);
+          const pattern = trigger && filters ? matchPathFilters(filters, file) : undefined;
+          if (!trigger || (filters && !pattern)) {
+            return [];
+          }
+          return [`${file} (${pattern ? `${MONOREPO_CHANGES_JOB} filter ${pattern}` : trigger})`];
+        }));
+      }
+    }
+
+    return Object.fromEntries([...reasons.keys()].sort().map(name => [name, reasons.get(name)!]));
+  }
+
+  /**
+   * Why a changed file lets a workflow run on a pull request, or undefined when it does not
+   */
+  private explainPullRequestTrigger(triggers: TriggerConfig, file: string): string | undefined {
+    const trigger = triggers.pullRequest;
+    if (!trigger) {
+      return undefined;
+    }
+    if (trigger.paths) {
+      const pattern = matchPathFilters(trigger.paths, file);
+      return pattern ? `paths ${pattern}` : undefined;
+    }
+    if (trigger.pathsIgnore) {
+      return matchPathFilters(trigger.pathsIgnore, file) ? undefined : 'not in paths-ignore';
+    }
+    return 'no path filter';
+  }
+
+  /**
+   * The monorepo CI workflows and the packages each covers. With paths-filter change detection,
+   * gatedBy names the package whose changes job filter each gated job waits for.
+   */
+  private createMonorepoWorkflows(
+    packages: MonorepoPackage[],
+    options: GenerationOptions
+  ): Array<{ filename: string; workflow: WorkflowTemplate; packages: MonorepoPackage[]; gatedBy?: Map<string, MonorepoPackage> }> {
+    if (options.provider && options.provider !== Provider.GitHubActions) {
+      throw new Error('Monorepo workflows are only supported for the github provider');
+    }
+
+    const [primary] = packages;
+    if (!primary) {
+      throw new Error('No monorepo packages to generate workflows for');
+    }
+
+    // Nx or Turborepo at the root knows which packages a change affects, so path filters give way to it
+    const root = packages.find(pkg => pkg.path === '.');
+    const rootJobs = root?.detectionResult.taskGraph ? this.createCIJobs(this.withPackageDockerImages(root), options) : [];
+    if (root && rootJobs.some(job => job.name === TASK_GRAPH_JOB)) {
+      const workflow: WorkflowTemplate = {
+        ...this.createCIWorkflowTemplate(root.detectionResult, options),
+        triggers: this.createCITriggers(options),
+        jobs: rootJobs
+      };
+      workflow.concurrency = this.createCIConcurrency(workflow.jobs, options);
+      return [{ filename: 'ci.yml', workflow, packages }];
+    }
+
+    const scoped = packages.map(pkg => ({
+      pkg,
+      jobs: this.createCIJobs(this.withPackageDockerImages(pkg), options).map(job => this.scopeJobToPackage(job, pkg))
+    }));
+
+    if (options.monorepoChangeDetection === 'paths-filter') {
+      if (options.monorepoLayout === 'per-package') {
+        throw new Error('paths-filter change detection gates jobs within one workflow and cannot be combined with the per-package layout');
+      }
+
+      const workflow: WorkflowTemplate = {
+        ...this.createCIWorkflowTemplate(primary.detectionResult, options),
+        triggers: this.createCITriggers(options),
+        jobs: [
+          ...this.applyJobOverrides([this.createChangesJob(packages)], { ...options, disabledJobs: [] }),
+          ...scoped.flatMap(({ pkg, jobs }) => jobs.map(job => this.gateJobOnChanges(job, pkg)))
+        ]
+      };
+      workflow.concurrency = this.createCIConcurrency(workflow.jobs, options);
+
+      const gatedBy = new Map(scoped.flatMap(({ pkg, jobs }) => jobs.map(job => [job.name, pkg] as const)));
+      return [{ filename: 'ci.yml', workflow, packages, gatedBy }];
+    }
+
+    if (options.monorepoLayout === 'per-package') {
+      return scoped.map(({ pkg, jobs }) => ({
+        filename: `ci-${this.getPackageSlug(pkg)}.yml`,
+        workflow: {
+          ...this.createCIWorkflowTemplate(pkg.detectionResult, options),
+          name: `CI (${pkg.name})`,
+          triggers: this.createPackageTriggers([pkg], packages, true, options),
+          jobs,
+          concurrency: this.createCIConcurrency(jobs, options)
+        },
+        packages: [pkg]
+      }));
+    }
+
+    const workflow: WorkflowTemplate = {
+      ...this.createCIWorkflowTemplate(primary.detectionResult, options),
+      triggers: this.createPackageTriggers(packages, packages, false, options),
+      jobs: scoped.flatMap(entry => entry.jobs)
+    };
+    workflow.concurrency = this.createCIConcurrency(workflow.jobs, options);
+
+    return [{ filename: 'ci.yml', workflow, packages }];
+  }
+
+  /**
+   * Generate the CI jobs split by kind into build.yml, test.yml and lint.yml, leaving out files
+   * without jobs. A job loses its dependencies on jobs of other files, except on the build jobs
+   * tests and lint need: with options.splitSetup 'reusable' (default) those move to setup.yml,
+   * which every workflow needing them calls, and with 'duplicate' each such workflow gets its own
+   * copy. Names taken by options.userWorkflows get a ci- prefix. The warnings of the combined
+   * workflow go with the first file.
+   */
+  async generateSplitCIWorkflows(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput[]> {
+    if (options.provider && options.provider !== Provider.GitHubActions) {
+      throw new Error('Split workflows are only supported for the github provider');
+    }
+    if (options.reusable) {
+      throw new Error('Split workflows cannot be combined with a reusable ci.yml');
+    }
+
+    const combined = await this.generateCIWorkflow(detectionResult, options);
+    const template = this.createCIWorkflowTemplate(detectionResult, options);
+    const warnings = [...combined.metadata.warnings];
+    const kinds = new Map(template.jobs.map(job => [job.name, this.getSplitKind(job.name)]));
+    const shared = new Set(template.jobs
+      .filter(job => kinds.get(job.name) !== 'build')
+      .flatMap(job => (job.needs || []).filter(need => kinds.get(need) === 'build')));
+    const reusable = options.splitSetup !== 'duplicate' && shared.size > 0;
+    const setupFilename = reusable ? this.getSplitFilename(SPLIT_SETUP_WORKFLOW, options, warnings) : undefined;
+
+    const workflows: Array<{ filename: string; workflow: WorkflowTemplate }> = [];
+    for (const kind of SPLIT_KINDS) {
+      let jobs = template.jobs.filter(job => kinds.get(job.name) === kind);
+      const needsShared = jobs.some(job => shared.has(job.name) || job.needs?.some(need => shared.has(need)));
+      if (reusable && needsShared) {
+        jobs = [
+          { name: SPLIT_SETUP_JOB, runsOn: 'ubuntu-latest', steps: [], uses: `./.github/workflows/${setupFilename}`, secrets: 'inherit' },
+          ...jobs.filter(job => !shared.has(job.name)).map(job => job.needs
+            ? { ...job, needs: [...new Set(job.needs.map(need => shared.has(need) ? SPLIT_SETUP_JOB : need))] }
+            : job)
+        ];
+      } else if (needsShared) {
+        jobs = [...template.jobs.filter(job => shared.has(job.name) && kinds.get(job.name) !== kind), ...jobs];
+      }
+      if (jobs.length === 0) {
+        continue;
+      }
+
+      jobs = this.withinWorkflow(jobs);
+      workflows.push({
+        filename: this.getSplitFilename(kind, options, warnings),
+        workflow: { ...template, name: `${template.name} (${kind})`, jobs, concurrency: this.createCIConcurrency(jobs, options) }
+      });
+    }
+    if (setupFilename) {
+      // The callers own concurrency: a called workflow sharing their group would wait on them forever
+      workflows.push({
+        filename: setupFilename,
+        workflow: {
+          name: `${template.name} (${SPLIT_SETUP_WORKFLOW})`,
+          type: 'ci',
+          triggers: { workflowCall: {} },
+          jobs: this.withinWorkflow(template.jobs.filter(job => shared.has(job.name)))
+        }
+      });
+    }
+
+    const secrets = this.getWorkflowSecrets(detectionResult, options);
+    const envExample = this.getEnvExampleComment(detectionResult, options);
+    const outputs: WorkflowOutput[] = [];
+    for (const { filename, workflow } of workflows) {
+      const used = JSON.stringify(workflow.jobs);
+      outputs.push({
+        filename,
+        content: this.withRequiredSecretsComment(
+          await this.renderWorkflow(workflow, options),
+          secrets.filter(secret => used.includes(`secrets.${secret}`)),
+          workflow.jobs.some(job => SECRET_JOB_PATTERN.test(job.name)) ? envExample : undefined,
+          options
+        ),
+        type: 'ci',
+        metadata: {
+          ...combined.metadata,
+          optimizations: [...combined.metadata.optimizations, `CI jobs split into ${workflows.map(entry => entry.filename).join(', ')}`],
+          warnings: outputs.length === 0 ? warnings : []
+        }
+      });
+    }
+    return outputs;
+  }
+
+  /**
+   * Which split workflow a CI job goes to: lint and static analysis, tests and their coverage,
+   * or build for everything else
+   */
+  private getSplitKind(jobName: string): SplitKind {
+    if (LINT_JOB_PATTERN.test(jobName) || jobName === PRE_COMMIT_JOB) {
+      return 'lint';
+    }
+    if (TEST_JOB_PATTERN.test(jobName) || jobName === COVERALLS_FINISH_JOB) {
+      return 'test';
+    }
+    return 'build';
+  }
+
+  /**
+   * `<name>.yml`, or the first of `ci-<name>.yml`, `ci-<name>-2.yml`... not among options.userWorkflows
+   */
+  private getSplitFilename(name: string, options: GenerationOptions, warnings: string[]): string {
+    const taken = new Set(options.userWorkflows || []);
+    let filename = `${name}.yml`;
+    for (let attempt = 1; taken.has(filename); attempt++) {
+      filename = attempt === 1 ? `ci-${name}.yml` : `ci-${name}-${attempt}.yml`;
+    }
+    if (filename !== `${name}.yml`) {
+      warnings.push(`${name}.yml is a workflow of your own - the ${name} jobs are written to ${filename} instead`);
+    }
+    return filename;
+  }
+
+  /**
+   * Drop dependencies on jobs that are not part of the same workflow
+   */
+  private withinWorkflow(jobs: JobTemplate[]): JobTemplate[] {
+    const names = new Set(jobs.map(job => job.name));
+    return jobs.map(job => {
+      if (!job.needs) {
+        return job;
+      }
+      const { needs, ...rest } = job;
+      const kept = needs.filter(need => names.has(need));
+      return kept.length > 0 ? { ...rest, needs: kept } : rest;
+    });
+  }
+
+  /**
+   * Generate nightly.yml: the build and test jobs on options.schedule, for catching breakage
+   * from dependencies that changed without a commit. Lint, publishing and deployment jobs are
+   * left out; jobs the CI workflow skips on its own schedule run here.
+   */
+  async generateNightlyWorkflow(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput> {
+    const problem = options.schedule ? validateCron(options.schedule) : 'no cron expression given';
+    if (problem) {
+      throw new Error(`Invalid schedule '${options.schedule || ''}': ${problem}`);
+    }
+
+    const jobs = this.createProjectJobs(detectionResult, { ...options, preset: GenerationPreset.Test, coverage: undefined, deployPages: false })
+      .map(({ if: condition, ...job }) => condition === NOT_SCHEDULED || condition === undefined ? job : { ...job, if: condition });
+    const warnings = this.getWarnings(detectionResult);
+    const metadata = {
+      generatedAt: new Date(),
+      generatorVersion: '1.0.0',
+      detectionSummary: this.createDetectionSummary(detectionResult),
+      optimizations: [] as string[],
+      warnings
+    };
+
+    if (jobs.length === 0) {
+      warnings.push('No build or test steps found for this project - no nightly workflow generated');
+      return { filename: 'nightly.yml', content: '', type: 'ci', metadata };
+    }
+
+    const workflow: WorkflowTemplate = {
+      name: 'Nightly',
+      type: 'ci',
+      triggers: {
+        schedule: [{ cron: options.schedule! }],
+        workflowDispatch: {}
+      },
+      jobs,
+      concurrency: {
+        group: CONCURRENCY_GROUP,
+        cancelInProgress: false
+      }
+    };
+
+    return {
+      filename: 'nightly.yml',
+      content: this.withRequiredSecretsComment(await this.renderWorkflow(workflow, options), this.getWorkflowSecrets(detectionResult, options), this.getEnvExampleComment(detectionResult, options), options),
+      type: 'ci',
+      metadata: { ...metadata, optimizations: [`Scheduled build and test run (${options.schedule})`] }
+    };
+  }
+
+  /**
+   * Generate release.yml for repositories that release by pushing version tags: the tagged
+   * commit is built and its output attached to a GitHub Release named after the version.
+   * Go projects with a GoReleaser config hand the whole release to GoReleaser instead.
+   * Tags with a hyphen (v1.2.0-rc.1) become prereleases.
+   */
+  async generateTagReleaseWorkflow(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput> {
+    const prefix = options.releaseTagPrefix ?? DEFAULT_RELEASE_TAG_PREFIX;
+    const primaryLanguage = detectionResult.languages.find(l => l.primary);
+    const goReleaser = primaryLanguage?.name.toLowerCase() === 'go' ? detectionResult.goReleaser : undefined;
+    const version: StepTemplate = {
+      name: 'Read version from tag',
+      id: 'version',
+      run: `echo "version=\${GITHUB_REF_NAME#${prefix}}" >> "$GITHUB_OUTPUT"`
+    };
+
+    const steps: StepTemplate[] = [
+      {
+        name: 'Checkout code',
+        uses: 'actions/checkout@v4',
+        // GoReleaser writes the changelog from the history since the previous tag
+        ...(goReleaser && { with: { 'fetch-depth': 0 } })
+      },
+      version
+    ];
+
+    if (goReleaser) {
+      steps.push(
+        ...this.createLanguageSetupSteps(primaryLanguage!.name, detectionResult, true),
+        {
+          name: 'Run GoReleaser',
+          uses: 'goreleaser/goreleaser-action@v6',
+          with: {
+            distribution: 'goreleaser',
+            version: '~> v2',
+            // GoReleaser finds each of the config names it is detected by on its own
+            args: 'release --clean'
+          },
+          env: { GITHUB_TOKEN: '${{ secrets.GITHUB_TOKEN }}' }
+        }
+      );
+    } else {
+      const build = primaryLanguage
+        ? this.withBuildOutput(this.createMakeSteps(detectionResult, 'build') || this.createBuildSteps(primaryLanguage.name, detectionResult), detectionResult, options)
+        : undefined;
+      if (primaryLanguage) {
+        steps.push(...this.createLanguageSetupSteps(primaryLanguage.name, detectionResult, true));
+      }
+      steps.push(...(build?.steps || []), {
+        name: 'Create GitHub Release',
+        uses: 'softprops/action-gh-release@v2',
+        with: {
+          name: '${{ steps.version.outputs.version }}',
+          prerelease: "${{ contains(steps.version.outputs.version, '-') }}",
+          generate_release_notes: true,
+          ...(build && {
+            files: build.path.split('\n').map(path => path.trim()).filter(Boolean).map(path => path.endsWith('/') ? `${path}*` : path).join('\n'),
+            fail_on_unmatched_files: false
+          })
+        }
+      });
+    }
+
+    const workflow: WorkflowTemplate = {
+      name: 'Release',
+      type: 'release',
+      triggers: {
+        push: { tags: [`${prefix}[0-9]+.[0-9]+.[0-9]+*`] }
+      },
+      jobs: this.applyCheckout(this.applyWorkingDirectory([{ name: 'release', runsOn: 'ubuntu-latest', steps }], detectionResult), detectionResult, options),
+      concurrency: {
+        group: CONCURRENCY_GROUP,
+        // A cancelled run can leave a release half-published
+        cancelInProgress: false
+      }
+    };
+
+    return {
+      filename: 'release.yml',
+      content: await this.renderWorkflow(workflow, options),
+      type: 'release',
+      metadata: {
+        generatedAt: new Date(),
+        generatorVersion: '1.0.0',
+        detectionSummary: this.createDetectionSummary(detectionResult),
+        optimizations: [goReleaser ? `Tag releases run GoReleaser (${goReleaser.file})` : `Tagged builds published as GitHub Releases (${prefix}*)`],
+        warnings: this.getWarnings(detectionResult)
+      }
+    };
+  }
+
+  /**
+   * Generate action.yml, a composite action with the setup, build and unit test steps of the CI
+   * workflow, for workflows of this and other repositories to run as one step after checking the
+   * code out. The language versions and the directory the commands run in become inputs
+   * defaulting to what was generated. The action is checked against the rules of composite
+   * actions, which differ from those of the jobs of a reusable workflow.
+   */
+  async generateCompositeAction(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput> {
+    const warnings = this.getWarnings(detectionResult);
+    const metadata = {
+      generatedAt: new Date(),
+      generatorVersion: '1.0.0',
+      detectionSummary: this.createDetectionSummary(detectionResult),
+      optimizations: [] as string[],
+      warnings
+    };
+
+    const primaryLanguage = detectionResult.languages.find(l => l.primary);
+    if (options.provider && options.provider !== Provider.GitHubActions) {
+      warnings.push(`Composite actions are only generated for GitHub Actions, not ${options.provider} - no action.yml generated`);
+      return { filename: COMPOSITE_ACTION_FILENAME, content: '', type: 'ci', metadata };
+    }
+    if (!primaryLanguage) {
+      warnings.push('No build or test steps found for this project - no action.yml generated');
+      return { filename: COMPOSITE_ACTION_FILENAME, content: '', type: 'ci', metadata };
+    }
+
+    const runners = this.getTestRunners(detectionResult);
+    const tested = runners.length > 0 || detectionResult.cmakeProject?.tests ||
+      (detectionResult.testingFrameworks.some(tf => tf.type === 'unit') && !this.lacksPackageTestScript(detectionResult));
+    const candidates = [
+      ...this.createLanguageSetupSteps(primaryLanguage.name, detectionResult, true),
+      ...(this.createMakeSteps(detectionResult, 'build') || this.createBuildSteps(primaryLanguage.name, detectionResult)),
+      ...(!tested ? [] : runners.length > 0
+        ? this.createTestRunnerSteps(runners, false)
+        : this.createMakeSteps(detectionResult, 'test') || this.createTestSteps(primaryLanguage.name, detectionResult, 'unit'))
+    ];
+    // Test steps that configure and build again (CMake) repeat what the build steps just did
+    const steps = candidates.filter((step, index) =>
+      candidates.findIndex(other => other.run === step.run && other.uses === step.uses && other.name === step.name) === index);
+
+    const inputs: CompositeActionTemplate['inputs'] = {
+      'working-directory': { description: 'Directory the build and test commands run in', required: false, default: detectionResult.workingDirectory || '.' }
+    };
+    const action: CompositeActionTemplate = {
+      name: `${detectionResult.projectMetadata.name || 'Project'} CI`,
+      description: `Set up, build and test ${detectionResult.projectMetadata.name || 'the project'}; check the code out first`,
+      inputs,
+      steps: steps.map(({ timeout: _timeout, ...step }) => {
+        // Steps of an action run in the caller's job, which has no version matrix and no default shell
+        if (step.run) {
+          return {
+            ...step,
+            shell: step.shell || 'bash',
+            workingDirectory: step.workingDirectory && step.workingDirectory !== '.'
+              ? `\${{ inputs.working-directory }}/${step.workingDirectory}`
+              : '${{ inputs.working-directory }}'
+          };
+        }
+        if (!step.with) {
+          return step;
+        }
+        const parameters = Object.fromEntries(Object.entries(step.with).map(([key, value]) => [key, typeof value !== 'string' ? value :
+          value.replace(/\$\{\{ matrix\.([\w-]+-version) \|\| '([^']*)' \}\}/g, (_match, name: string, version: string) => {
+            inputs[name] = inputs[name] || { description: `${name.replace(/-version$/, '')} version to set up`, required: false, default: version };
+            return `\${{ inputs.${name} }}`;
+          })]));
+        return { ...step, with: parameters };
+      })
+    };
+
+    const content = this.yamlRenderer.renderCompositeAction(action);
+    const errors = validateCompositeActionStructure(content);
+    if (errors.length > 0) {
+      throw new Error(`Invalid composite action: ${errors.map(error => error.message).join('; ')}`);
+    }
+
+    return {
+      filename: COMPOSITE_ACTION_FILENAME,
+      content,
+      type: 'ci',
+      metadata: { ...metadata, optimizations: [`Setup, build and test steps bundled as a composite action (${Object.keys(inputs).join(', ')} inputs)`] }
+    };
+  }
+
+  /**
+   * Create CI workflow template with build and test focus
+   */
+  private createCIWorkflowTemplate(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): WorkflowTemplate {
+    const jobs = this.createProjectJobs(detectionResult, options);
+
+    return {
+      name: 'Continuous Integration',
+      type: 'ci',
+      triggers: this.createCITriggers(options),
+      jobs,
+      concurrency: this.createCIConcurrency(jobs, options)
+    };
+  }
+
+  /**
+   * Turn the CI workflow into one other workflows call (on: workflow_call) when options.reusable
+   * is set. The runner and the language versions of jobs without a version matrix become inputs
+   * defaulting to what was generated; the build artifact and the coverage percentage are returned
+   * as outputs, and the secrets the jobs read are declared for the caller to pass. The caller owns
+   * concurrency: a called workflow sharing its group would wait on the caller forever.
+   */
+  private asReusableWorkflow(workflow: WorkflowTemplate, options: GenerationOptions): WorkflowTemplate {
+    if (!options.reusable || (options.provider && options.provider !== Provider.GitHubActions)) {
+      return workflow;
+    }
+
+    const inputs: Record<string, WorkflowInput> = {};
+    const outputs: Record<string, WorkflowCallOutput> = {};
+    const runner = workflow.jobs.map(job => job.runsOn).find((runsOn): runsOn is string => typeof runsOn === 'string' && !runsOn.includes('${{'));
+    if (runner) {
+      inputs['runs-on'] = { description: 'Runner label the jobs run on', type: 'string', required: false, default: runner };
+    }
+
+    const jobs = workflow.jobs.map(job => {
+      const steps = job.steps.map(step => {
+        if (!step.with) {
+          return step;
+        }
+        // Setup steps fall back to the generated version outside a version matrix
+        const parameters = Object.fromEntries(Object.entries(step.with).map(([key, value]) => [key, typeof value !== 'string' ? value :
+          value.replace(/\$\{\{ matrix\.([\w-]+-version) \|\| '([^']*)' \}\}/g, (_match, name: string, version: string) => {
+            inputs[name] = inputs[name] || { description: `${name.replace(/-version$/, '')} version of jobs without a version matrix`, type: 'string', required: false, default: version };
+            return `\${{ matrix.${name} || inputs.${name} }}`;
+          })]));
+        return { ...step, with: parameters };
+      });
+      const reusable: JobTemplate = { ...job, steps, ...(runner && job.runsOn === runner && { runsOn: '${{ inputs.runs-on }}' }) };
+
+      const artifact = steps.find(step => step.name === 'Upload build artifacts' && step.with);
+      if (artifact && !outputs['artifact-name']) {
+        reusable.outputs = { ...reusable.outputs, 'artifact-name': artifact.with!.name, 'artifact-path': artifact.with!.path };
+        outputs['artifact-name'] = { description: 'Name of the uploaded build artifact', value: `\${{ jobs.${job.name}.outputs.artifact-name }}` };
+        outputs['artifact-path'] = { description: 'Paths the build artifact was uploaded from', value: `\${{ jobs.${job.name}.outputs.artifact-path }}` };
+      }
+
+      const upload = steps.findIndex(step => step.name.startsWith('Upload coverage to'));
+      const report = upload >= 0 ? String(steps[upload]!.with?.files || steps[upload]!.with?.file || '') : '';
+      const reader = COVERAGE_PERCENTAGE_COMMANDS.find(entry => entry.file.test(report));
+      if (reader && !outputs['coverage']) {
+        steps.splice(upload + 1, 0, {
+          name: 'Report coverage percentage',
+          id: 'coverage-percentage',
+          run: [reader.command(report), 'echo "percentage=$percentage" >> "$GITHUB_OUTPUT"'].join('\n')
+        });
+        reusable.outputs = { ...reusable.outputs, coverage: '${{ steps.coverage-percentage.outputs.percentage }}' };
+        outputs['coverage'] = { description: 'Line coverage percentage of the unit tests', value: `\${{ jobs.${job.name}.outputs.coverage }}` };
+      }
+
+      return reusable;
+    });
+
+    const secrets = [...new Set([...JSON.stringify(jobs).matchAll(/secrets\.([A-Za-z_][A-Za-z0-9_]*)/g)].map(match => match[1]!))]
+      .filter(secret => secret !== 'GITHUB_TOKEN');
+
+    return {
+      ...workflow,
+      concurrency: undefined,
+      triggers: {
+        workflowCall: {
+          inputs,
+          ...(Object.keys(outputs).length > 0 && { outputs }),
+          ...(secrets.length > 0 && { secrets: Object.fromEntries(secrets.map(secret => [secret, { required: false }])) })
+        }
+      },
+      jobs
+    };
+  }
+
+  /**
+   * Cancel superseded runs unless options.cancelInProgress is false. Once a job deploys to an
+   * environment (e.g. GitHub Pages), default branch pushes always run to completion so a newer
+   * commit cannot abort a half-finished deployment. Minimal workflows only keep that guard.
+   */
+  private createCIConcurrency(jobs: JobTemplate[], options: GenerationOptions): ConcurrencyConfig | undefined {
+    if (options.minimal && !jobs.some(job => job.environment)) {
+      return undefined;
+    }
+    if (options.cancelInProgress === false) {
+      return { group: CONCURRENCY_GROUP, cancelInProgress: false };
+    }
+
+    return {
+      group: CONCURRENCY_GROUP,
+      cancelInProgress: jobs.some(job => job.environment) ? `\${{ !(${DEFAULT_BRANCH_PUSH}) }}` : true
+    };
+  }
+
+  /**
+   * Create CI-specific triggers (push, PR, schedule for dependency checks).
+   * A known default branch replaces the guessed trunk names; develop keeps running CI either way.
+   */
+  private createCITriggers(options: GenerationOptions): TriggerConfig {
+    const branches = options.defaultBranch
+      ? [...new Set([options.defaultBranch, 'develop'])]
+      : ['main', 'develop', 'master'];
+
+    return {
+      push: {
+        branches,
+        pathsIgnore: ['docs/**', '*.md', '.gitignore']
+      },
+      pullRequest: {
+        branches,
+        types: ['opened', 'synchronize', 'reopened']
+      },
+      schedule: [
+        {
+          cron: '0 2 * * 1' // Weekly dependency check on Mondays at 2 AM
+        }
+      ]
+    };
+  }
+
+  /**
+   * CI jobs for the project, run from the subdirectory it lives in when that is not the repository root
+   */
+  private createProjectJobs(detectionResult: DetectionResult, options: GenerationOptions): JobTemplate[] {
+    const directory = detectionResult.workingDirectory;
+    const projectResult = directory && directory !== '.'
+      ? this.withPackageDockerImages({ path: directory, name: directory, detectionResult })
+      : detectionResult;
+    return this.applyCheckout(this.applyWorkingDirectory(this.createCIJobs(projectResult, options), detectionResult), detectionResult, options);
+  }
+
+  /**
+   * Fetch what the jobs need besides the latest commit on every checkout: the submodules, recursively,
+   * when .gitmodules declares any, and the whole history when GoReleaser or a tool deriving the
+   * version from git tags is found. options.checkoutSubmodules and options.checkoutFetchDepth
+   * override both; false and 1 turn them off, including where a job asked for them itself.
+   */
+  private applyCheckout(jobs: JobTemplate[], detectionResult: DetectionResult, options: GenerationOptions): JobTemplate[] {
+    const submodules = options.checkoutSubmodules ?? (detectionResult.gitCheckout?.submodules.length ? 'recursive' : undefined);
+    const fetchDepth = options.checkoutFetchDepth ??
+      (detectionResult.goReleaser || detectionResult.gitCheckout?.versionFromGit.length ? 0 : undefined);
+    if (submodules === undefined && fetchDepth === undefined) {
+      return jobs;
+    }
+
+    return jobs.map(job => ({
+      ...job,
+      steps: job.steps.map(step => {
+        if (step.uses?.split('@')[0] !== 'actions/checkout') {
+          return step;
+        }
+        const { with: current, ...rest } = step;
+        const inputs: Record<string, any> = { ...current };
+        if (submodules) {
+          inputs.submodules = submodules;
+        } else if (submodules === false) {
+          delete inputs.submodules;
+        }
+        if (fetchDepth === 1) {
+          delete inputs['fetch-depth'];
+        } else if (fetchDepth !== undefined) {
+          inputs['fetch-depth'] = fetchDepth;
+        }
+        return Object.keys(inputs).length > 0 ? { ...rest, with: inputs } : rest;
+      })
+    }));
+  }
+
+  /**
+   * Create CI jobs focused on build and test optimization
+   */
+  private createCIJobs(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): JobTemplate[] {
+    const jobs: JobTemplate[] = [];
+
+    // Each language of a mixed repository gets its own jobs, running side by side
+    const languages = this.splitByLanguage(detectionResult);
+    if (languages) {
+      // pre-commit checks the whole repository, so it runs once rather than per language
+      return this.applyStepOrder(this.applyPreCommit(languages.flatMap(({ slug, directory, detectionResult: languageResult }) =>
+        this.createCIJobs({ ...languageResult, preCommit: undefined }, options).map(job =>
+          this.scopeJobToPackage(job, { path: directory, name: slug, detectionResult: languageResult }, slug))), detectionResult, options));
+    }
+
+    // The Makefile's ci target already strings lint, build and test together
+    const requested = this.getPreset(options);
+    const preset = requested && requested !== GenerationPreset.Full ? requested : undefined;
+    if (options.makeCI && !preset && detectionResult.makefile?.targets.includes(MAKE_CI_TARGET)) {
+      return this.applyStepOrder(this.applyPrivateModules(
+        this.applyPrerequisites(this.applyJobOverrides(this.applyRequiredSecrets([this.createMakeCIJob(detectionResult)], detectionResult, options), options), detectionResult, options),
+        detectionResult,
+        options
+      ));
+    }
+
+    // Nx and Turborepo work out which packages a change affects, so one job runs their tasks
+    const github = !options.provider || options.provider === Provider.GitHubActions;
+    if (detectionResult.taskGraph && !preset && github) {
+      jobs.push(this.createTaskGraphJob(detectionResult.taskGraph, detectionResult, options));
+    } else {
+      // Add lint job for code quality
+      jobs.push(this.createLintJob(detectionResult));
+
+      // Add build job with matrix strategy if multiple versions detected; shell scripts run as they are
+      if (!detectionResult.shellProject) {
+        jobs.push(this.createBuildJob(detectionResult, options));
+      }
+
+      // Add test jobs with parallel execution
+      jobs.push(...this.createTestJobs(detectionResult, options));
+    }
+
+    // Add security scanning job
+    if (options.securityLevel !== 'basic') {
+      jobs.push(this.createSecurityScanJob(detectionResult));
+    }
+
+    // Container images are built with GitHub-specific actions
+    if (detectionResult.dockerImages?.length && github) {
+      const needs = jobs.map(job => job.name).filter(name => name !== 'lint');
+      jobs.push(this.createDockerJob(detectionResult.dockerImages, needs, options));
+    }
+
+    if (options.deployPages && detectionResult.staticSite && (!options.provider || options.provider === Provider.GitHubActions)) {
+      jobs.push(this.createPagesJob(detectionResult.staticSite, detectionResult, options));
+    }
+
+    if (options.terraform && detectionResult.terraform && (!options.provider || options.provider === Provider.GitHubActions)) {
+      jobs.push(this.createTerraformJob(detectionResult.terraform));
+    }
+
+    const licenseCheck = options.licenseCheck ? this.createLicenseCheckJob(detectionResult, options) : undefined;
+    if (licenseCheck) {
+      jobs.push(licenseCheck);
+    }
+
+    if (options.apiLint && detectionResult.apiSchemas) {
+      jobs.push(this.createApiLintJob(detectionResult.apiSchemas));
+    }
+
+    if (options.protobuf && detectionResult.protobuf && github) {
+      jobs.push(this.createBufJob(detectionResult.protobuf, detectionResult, options));
+    }
+
+    if (options.changelogCheck && detectionResult.changelog && github) {
+      jobs.push(this.createChangelogJob(detectionResult.changelog, detectionResult, options));
+    }
+
+    if (options.codeownersComment && detectionResult.codeowners?.rules.length && github) {
+      jobs.push(this.createCodeownersJob(detectionResult.codeowners));
+    }
+
+    return this.applyStepOrder(this.applyPreCommit(
+      this.applyTestLimits(
+        this.applyPrivateModules(
+          this.applyPrerequisites(
+            this.applyMatrixRules(this.applyJobOverrides(
+              this.applyCoverage(this.applyRequiredSecrets(this.applyPreset(jobs, detectionResult, preset), detectionResult, options), detectionResult, options),
+              options
+            ), options),
+            detectionResult,
+            options
+          ),
+          detectionResult,
+          options
+        ),
+        options
+      ),
+      detectionResult,
+      options
+    ));
+  }
+
+  /**
+   * Put each job's steps in category order, so regenerating from the same project lists them
+   * the same way and a newly detected step only takes its own place. Injected steps stay at
+   * their anchor.
+   */
+  private applyStepOrder(jobs: JobTemplate[]): JobTemplate[] {
+    return jobs.map(job => ({
+      ...job,
+      steps: orderSteps(job.steps, step => step.source?.startsWith('injectSteps') === true)
+    }));
+  }
+
+  /**
+   * Run the repository's pre-commit hooks in a job of their own when options.preCommit asks
+   * for it. With replace-lint the lint jobs go, and jobs that waited for them wait for
+   * pre-commit instead. The job gets the same overrides as the others (a disabled pre-commit
+   * job leaves the lint jobs in place); the test preset has no place for it.
+   */
+  private applyPreCommit(jobs: JobTemplate[], detectionResult: DetectionResult, options: GenerationOptions): JobTemplate[] {
+    if (!options.preCommit || !detectionResult.preCommit || this.getPreset(options) === GenerationPreset.Test) {
+      return jobs;
+    }
+
+    const [job] = this.applyJobOverrides([this.createPreCommitJob(detectionResult.preCommit, detectionResult)], options);
+    if (!job) {
+      return jobs;
+    }
+    if (options.preCommit !== 'replace-lint') {
+      return [job, ...jobs];
+    }
+
+    const lint = new Set(jobs.filter(other => LINT_JOB_PATTERN.test(other.name)).map(other => other.name));
+    return [
+      job,
+      ...jobs.filter(other => !lint.has(other.name)).map(other => other.needs?.some(need => lint.has(need))
+        ? { ...other, needs: [...new Set(other.needs.map(need => lint.has(need) ? PRE_COMMIT_JOB : need))] }
+        : other)
+    ];
+  }
+
+  /**
+   * Job running every pre-commit hook over all files. The hook environments pre-commit builds
+   * are cached by the config's hash, so they are only rebuilt when the pinned hooks change.
+   */
+  private createPreCommitJob(preCommit: PreCommitDetection, detectionResult: DetectionResult): JobTemplate {
+    return {
+      name: PRE_COMMIT_JOB,
+      runsOn: 'ubuntu-latest',
+      steps: [
+        {
+          name: 'Checkout code',
+          uses: 'actions/checkout@v4'
+        },
+        {
+          name: 'Setup Python',
+          uses: 'actions/setup-python@v5',
+          with: { 'python-version': this.getDefaultVersion(detectionResult, 'python') }
+        },
+        {
+          name: 'Install pre-commit',
+          run: 'pip install pre-commit'
+        },
+        {
+          name: 'Cache pre-commit environments',
+          uses: 'actions/cache@v4',
+          with: {
+            path: '~/.cache/pre-commit',
+            key: `pre-commit-\${{ runner.os }}-\${{ hashFiles('${preCommit.file}') }}`
+          }
+        },
+        {
+          name: 'Run pre-commit',
+          run: `pre-commit run --all-files --show-diff-on-failure${preCommit.file === '.pre-commit-config.yaml' ? '' : ` --config ${preCommit.file}`}`
+        }
+      ],
+      if: NOT_SCHEDULED
+    };
+  }
+
+  /**
+   * Let Go jobs download the private modules go.mod requires: GOPRIVATE keeps the go command
+   * away from the module proxy and checksum database, and git fetches the modules' repositories
+   * with the token secret. GitHub Actions only.
+   */
+  private applyPrivateModules(jobs: JobTemplate[], detectionResult: DetectionResult, options: GenerationOptions): JobTemplate[] {
+    const patterns = this.getPrivateModulePatterns(detectionResult, options);
+    if (patterns.length === 0 || (options.provider && options.provider !== Provider.GitHubActions)) {
+      return jobs;
+    }
+
+    const secret = options.privateModulesSecret || DEFAULT_PRIVATE_MODULES_SECRET;
+    const prefixes = patterns.map(pattern => this.getPrivateModulePrefix(pattern)).filter((prefix): prefix is string => prefix !== undefined);
+    const configure: StepTemplate = {
+      name: 'Configure private Go modules',
+      run: [...new Set(prefixes)]
+        .map(prefix => `git config --global url."https://x-access-token:\${${secret}}@${prefix}/".insteadOf "https://${prefix}/"`)
+        .join('\n'),
+      env: { [secret]: `\${{ secrets.${secret} }}` }
+    };
+
+    return jobs.map(job => {
+      const setup = job.steps.findIndex(step => step.uses?.startsWith('actions/setup-go'));
+      if (setup < 0) {
+        return job;
+      }
+
+      const steps = [...job.steps];
+      if (prefixes.length > 0) {
+        steps.splice(setup + 1, 0, configure);
+      }
+      return { ...job, env: { GOPRIVATE: patterns.join(','), ...job.env }, steps };
+    });
+  }
+
+  /**
+   * options.privateModules patterns that match a module go.mod requires. A pattern without a
+   * host, like acme/*, is taken to be on github.com.
+   */
+  private getPrivateModulePatterns(detectionResult: DetectionResult, options: GenerationOptions): string[] {
+    const requires = detectionResult.goModule?.requires || [];
+    return (options.privateModules || [])
+      .map(pattern => pattern.trim().replace(/\/+$/, ''))
+      .filter(pattern => pattern !== '')
+      .map(pattern => pattern.split('/')[0]!.includes('.') ? pattern : `github.com/${pattern}`)
+      .filter((pattern, index, all) => all.indexOf(pattern) === index)
+      .filter(pattern => requires.some(modulePath => this.matchesGoPrivatePattern(pattern, modulePath)));
+  }
+
+  /**
+   * Whether a GOPRIVATE glob matches a module path: like the go command, the pattern matches
+   * the path's leading elements, one element per pattern element
+   */
+  private matchesGoPrivatePattern(pattern: string, modulePath: string): boolean {
+    const patternElements = pattern.split('/');
+    const pathElements = modulePath.split('/');
+    if (pathElements.length < patternElements.length) {
+      return false;
+    }
+
+    return patternElements.every((element, index) => {
+      const glob = element.replace(/[.+^${}()|\\]/g, '\\$&').replace(/\*/g, '[^/]*').replace(/\?/g, '[^/]');
+      return new RegExp(`^${glob}$`).test(pathElements[index]!);
+    });
+  }
+
+  /**
+   * Leading pattern elements without wildcards, which git rewrites the URLs of;
+   * undefined when the host itself is a wildcard
+   */
+  private getPrivateModulePrefix(pattern: string): string | undefined {
+    const elements = pattern.split('/');
+    const wildcard = elements.findIndex(element => /[*?[]/.test(element));
+    const fixed = wildcard < 0 ? elements : elements.slice(0, wildcard);
+    return fixed.length > 0 ? fixed.join('/') : undefined;
+  }
+
+  /**
+   * Cap test jobs at the job timeout and retry their test commands, leaving checkout, setup and
+   * install steps to fail for good. Retries are counted on the steps; GitLab turns them into a job retry.
+   */
+  private applyTestLimits(jobs: JobTemplate[], options: GenerationOptions): JobTemplate[] {
+    return jobs.map(job => {
+      if (!TEST_JOB_PATTERN.test(job.name)) {
+        return job;
+      }
+
+      return {
+        ...job,
+        timeout: options.jobTimeout || DEFAULT_TEST_JOB_TIMEOUT,
+        steps: options.testRetries
+          ? job.steps.map(step => step.run && TEST_STEP_PATTERN.test(step.name) ? { ...step, retries: options.testRetries } : step)
+          : job.steps
+      };
+    });
+  }
+
+  /**
+   * Collect coverage in the unit test jobs and upload the report. The report path has to be the
+   * one the instrumented command writes, so the flags are added here rather than assumed; tools
+   * the project does not already use are installed just for the run. GitHub Actions only.
+   */
+  private applyCoverage(jobs: JobTemplate[], detectionResult: DetectionResult, options: GenerationOptions): JobTemplate[] {
+    const service = options.coverage;
+    const tool = this.getCoverageTool(detectionResult);
+    if (!service || service === 'none' || !tool || (options.provider && options.provider !== Provider.GitHubActions) ||
+      isJobDisabled(COVERALLS_FINISH_JOB, options.disabledJobs)) {
+      return jobs;
+    }
+
+    const parallelJobs: string[] = [];
+    const covered = jobs.map(job => {
+      const index = COVERAGE_JOB_PATTERN.test(job.name) ? job.steps.findIndex(step => step.name === 'Run unit tests' && step.run) : -1;
+      const instrumented = index >= 0 ? this.instrumentForCoverage(job.steps[index]!.run!, tool.tool) : undefined;
+      if (!instrumented) {
+        return job;
+      }
+
+      const settings = COVERAGE_TOOLS[tool.tool];
+      const file = instrumented.file || tool.reportFile || settings.file;
+      const parallel = service === 'coveralls' && !!job.strategy;
+      if (parallel) {
+        parallelJobs.push(job.name);
+      }
+
+      const upload: StepTemplate = service === 'codecov'
+        ? {
+          name: 'Upload coverage to Codecov',
+          uses: 'codecov/codecov-action@v5',
+          with: { files: file, token: '${{ secrets.CODECOV_TOKEN }}' }
+        }
+        : {
+          name: 'Upload coverage to Coveralls',
+          uses: 'coverallsapp/github-action@v2',
+          with: {
+            file,
+            ...(settings.format && { format: settings.format }),
+            ...(parallel && { parallel: true, 'flag-name': `${job.name}-\${{ join(matrix.*, '-') }}` })
+          }
+        };
+
+      const steps = [...job.steps];
+      steps.splice(index, 1, { ...job.steps[index]!, run: instrumented.command }, upload);
+      if (settings.install && !tool.detected) {
+        steps.splice(index, 0, { name: 'Install coverage tooling', run: settings.install });
+      }
+      return { ...job, steps };
+    });
+
+    if (parallelJobs.length > 0) {
+      covered.push({
+        name: COVERALLS_FINISH_JOB,
+        runsOn: 'ubuntu-latest',
+        needs: parallelJobs,
+        if: 'always()',
+        steps: [{ name: 'Finish Coveralls build', uses: 'coverallsapp/github-action@v2', with: { 'parallel-finished': true } }]
+      });
+    }
+
+    return covered;
+  }
+
+  /**
+   * Name each job disabledJobs left out of the workflow, found by generating it once more
+   * without them. The coverage job is reported with the coverage upload.
+   */
+  private getDisabledJobWarnings(workflow: WorkflowTemplate, detectionResult: DetectionResult, options: GenerationOptions): string[] {
+    if (!options.disabledJobs?.length) {
+      return [];
+    }
+
+    const generated = new Set(workflow.jobs.map(job => job.name));
+    return this.createCIWorkflowTemplate(detectionResult, { ...options, disabledJobs: [] }).jobs
+      .filter(job => !generated.has(job.name) && job.name !== COVERALLS_FINISH_JOB)
+      .map(job => `Job ${job.name} left out: disabled by the config`);
+  }
+
+  /**
+   * Explain why a requested coverage upload is missing from the workflow
+   */
+  private getCoverageWarnings(workflow: WorkflowTemplate, detectionResult: DetectionResult, options: GenerationOptions): string[] {
+    if (!options.coverage || options.coverage === 'none') {
+      return [];
+    }
+    if (options.provider && options.provider !== Provider.GitHubActions) {
+      return [`Coverage upload is only generated for GitHub Actions - no ${options.coverage} upload added to ${options.provider} pipelines`];
+    }
+    if (isJobDisabled(COVERALLS_FINISH_JOB, options.disabledJobs)) {
+      return [`Coverage upload left out: ${COVERALLS_FINISH_JOB} is disabled by the config`];
+    }
+
+    const primaryLanguage = detectionResult.languages.find(l => l.primary)?.name;
+    if (!this.getCoverageTool(detectionResult)) {
+      return [`No coverage tool is known for ${primaryLanguage || 'this project'} - no ${options.coverage} upload generated`];
+    }
+    const uploaded = workflow.jobs.some(job => job.steps.some(step => step.name.startsWith('Upload coverage to')));
+    const testJobs = workflow.jobs.some(job => COVERAGE_JOB_PATTERN.test(job.name));
+    if (!uploaded && testJobs) {
+      return [`The unit test command could not be instrumented for coverage - no ${options.coverage} upload generated`];
+    }
+
+    return [];
+  }
+
+  /**
+   * Coverage tool for the primary language: the one the project configures, otherwise the test
+   * runner's built-in coverage, falling back to nyc for other JavaScript runners
+   */
+  private getCoverageTool(detectionResult: DetectionResult): (CoverageDetection & { detected: boolean }) | undefined {
+    const primaryLanguage = detectionResult.languages.find(l => l.primary)?.name.toLowerCase();
+    const family = (language: string) => language.toLowerCase() === 'typescript' ? 'javascript' : language.toLowerCase();
+    if (!primaryLanguage) {
+      return undefined;
+    }
+
+    const configured = detectionResult.coverageTools?.find(coverage => family(coverage.language) === family(primaryLanguage));
+    if (configured) {
+      return { ...configured, detected: true };
+    }
+
+    const runners = [
+      ...detectionResult.testingFrameworks.map(tf => tf.name.toLowerCase()),
+      ...(detectionResult.testRunners || []).map(runner => runner.name.toLowerCase())
+    ];
+    switch (family(primaryLanguage)) {
+      case 'go':
+        return { tool: 'go-cover', language: 'Go', detected: false };
+      case 'python':
+        return { tool: 'pytest-cov', language: 'Python', detected: false };
+      case 'javascript': {
+        const tool = runners.includes('vitest') ? 'vitest' : runners.includes('jest') ? 'jest' : 'nyc';
+        return { tool, language: 'JavaScript', detected: false };
+      }
+      default:
+        return undefined;
+    }
+  }
+
+  /**
+   * Add a tool's coverage flags to a test command, keeping flags it already passes.
+   * Returns undefined when the command does not run the tool's tests; a report file
+   * the command already names is returned so the upload reads that one.
+   */
+  private instrumentForCoverage(command: string, tool: CoverageDetection['tool']): { command: string; file?: string } | undefined {
+    // npm test forwards flags after --; other package managers pass them straight through
+    const forward = (flags: string) => /^npm (run )?test\b/.test(command) && !/ -- /.test(command) ? ` -- ${flags}` : ` ${flags}`;
+    const runsScript = /^(npm|yarn|pnpm)( run)? test\b/.test(command);
+
+    switch (tool) {
+      case 'go-cover': {
+        if (!/\bgo test\b/.test(command)) {
+          return undefined;
+        }
+        const profile = command.match(/-coverprofile[= ](\S+)/)?.[1];
+        return profile
+          ? { command, file: profile }
+          : { command: command.replace(/\bgo test\b/, 'go test -coverprofile=coverage.out') };
+      }
+      case 'jest':
+        if (!/\bjest\b/.test(command) && !runsScript) {
+          return undefined;
+        }
+        return { command: /--coverage\b/.test(command) ? command : `${command}${forward('--coverage')}` };
+      case 'vitest':
+        if (!/\bvitest\b/.test(command) && !runsScript) {
+          return undefined;
+        }
+        // Vitest writes no lcov report by default
+        return { command: `${command.replace(/ --coverage\b(?![.\w])/, '')}${forward('--coverage.enabled --coverage.reporter=lcov')}` };
+      case 'nyc':
+      case 'c8':
+        return { command: new RegExp(`\\b${tool}\\b`).test(command) ? command : `npx ${tool} --reporter=lcov ${command}` };
+      case 'pytest-cov':
+        if (!/\bpytest\b/.test(command)) {
+          return undefined;
+        }
+        if (/--cov-report[= ]xml\b/.test(command)) {
+          return { command, file: command.match(/--cov-report[= ]xml:(\S+)/)?.[1] };
+        }
+        return { command: /--cov\b/.test(command) ? `${command} --cov-report=xml` : `${command} --cov --cov-report=xml` };
+    }
+  }
+
+  /**
+   * Preset whose jobs are kept, undefined when none was chosen. Minimal workflows build and test
+   * unless a preset says otherwise.
+   */
+  private getPreset(options: GenerationOptions): GenerationPreset | undefined {
+    return options.preset || (options.minimal ? GenerationPreset.Test : undefined);
+  }
+
+  /**
+   * Keep the jobs of a preset's category. A lint job without lint steps would only check out
+   * and set up the language, so the lint preset drops it.
+   */
+  private applyPreset(jobs: JobTemplate[], detectionResult: DetectionResult, preset?: GenerationPreset): JobTemplate[] {
+    if (!preset || preset === GenerationPreset.Full) {
+      return jobs;
+    }
+
+    const primaryLanguage = detectionResult.languages.find(l => l.primary);
+    const hasLintSteps = primaryLanguage !== undefined && (this.createMakeSteps(detectionResult, 'lint') ||
+      this.createLintSteps(primaryLanguage.name, detectionResult)).length > 0;
+
+    return jobs.filter(job => PRESET_JOB_PATTERNS[preset].test(job.name) && (job.name !== 'lint' || hasLintSteps));
+  }
+
+  /**
+   * Install the README's system packages in the jobs that build or test the project, and run
+   * its services as containers next to the test jobs, whose test steps get the connection
+   * variables. apt-get only exists on Linux runners, so jobs that may run elsewhere guard the
+   * install step; other providers keep their own images. With options.phoenixDatabase, a Phoenix
+   * app on Postgres gets a postgres service and creates and migrates its test database first. A
+   * Rails app always prepares its test database, on a postgres service when it uses pg.
+   */
+  private applyPrerequisites(jobs: JobTemplate[], detectionResult: DetectionResult, options: GenerationOptions): JobTemplate[] {
+    const packages = detectionResult.systemPackages || [];
+    const phoenixDatabase = Boolean(options.phoenixDatabase && detectionResult.elixirProject?.phoenix && detectionResult.elixirProject.postgres);
+    const railsDatabase = detectionResult.rubyProject?.rails ? detectionResult.rubyProject.database : undefined;
+    const postgres = phoenixDatabase || railsDatabase === 'postgres';
+    const services = this.createServiceContainers([
+      ...(detectionResult.services || []),
+      ...(postgres && !detectionResult.services?.some(service => service.name === 'postgres') ? [{ name: 'postgres' }] : [])
+    ]);
+    const github = !options.provider || options.provider === Provider.GitHubActions;
+    // GitLab reaches services by their alias; elsewhere published ports are on localhost
+    const connections = this.createServiceConnections(services, options.provider === Provider.GitLab);
+
+    return jobs.map(job => {
+      let updated = job;
+
+      if (packages.length > 0 && github && SECRET_JOB_PATTERN.test(job.name)) {
+        const linux = typeof job.runsOn === 'string' && job.runsOn.startsWith('ubuntu-');
+        const install: StepTemplate = {
+          name: 'Install system packages',
+          run: `sudo apt-get update && sudo apt-get install -y --no-install-recommends ${packages.join(' ')}`,
+          ...(!linux && { if: "runner.os == 'Linux'" }),
+          source: 'README prerequisites'
+        };
+        const checkout = job.steps.findIndex(step => step.uses?.startsWith('actions/checkout'));
+        const steps = [...job.steps];
+        steps.splice(checkout + 1, 0, install);
+        updated = { ...updated, steps };
+      }
+
+      if (Object.keys(services).length > 0 && SERVICE_JOB_PATTERN.test(job.name)) {
+        const testSteps = updated.steps.filter(step => step.run && step.name && TEST_STEP_PATTERN.test(step.name));
+        updated = {
+          ...updated,
+          services: { ...services, ...job.services },
+          ...(testSteps.length === 0 && { env: { ...connections, ...updated.env } }),
+          steps: updated.steps.map(step => testSteps.includes(step) ? { ...step, env: { ...connections, ...step.env } } : step)
+        };
+      }
+
+      const mixTest = updated.steps.findIndex(step => step.run?.startsWith('mix test'));
+      if (phoenixDatabase && mixTest !== -1 && SERVICE_JOB_PATTERN.test(job.name)) {
+        const env = { MIX_ENV: 'test', ...connections };
+        const steps = [...updated.steps];
+        steps.splice(mixTest, 0,
+          { name: 'Create database', run: 'mix ecto.create', env, source: 'mix.exs deps' },
+          { name: 'Run migrations', run: 'mix ecto.migrate', env, source: 'mix.exs deps' });
+        updated = { ...updated, steps };
+      }
+
+      const bundleTest = updated.steps.findIndex(step => /^bundle exec (rspec|rake)\b/.test(step.run || ''));
+      if (railsDatabase && bundleTest !== -1 && SERVICE_JOB_PATTERN.test(job.name)) {
+        const steps = [...updated.steps];
+        steps.splice(bundleTest, 0,
+          { name: 'Prepare database', run: 'bin/rails db:prepare', env: { RAILS_ENV: 'test', ...connections }, source: 'Gemfile' });
+        updated = { ...updated, steps };
+      }
+
+      return updated;
+    });
+  }
+
+  /**
+   * Service containers with health checks, so jobs only start once the services accept connections.
+   * A compose image keeps its tag, or gets the default one; its environment overrides the defaults.
+   * Services without a container definition (such as docker itself) are left to the runner.
+   */
+  private createServiceContainers(services: ServiceDetection[]): Record<string, any> {
+    const containers: Record<string, any> = {};
+
+    for (const service of services) {
+      const container = SERVICE_CONTAINERS[service.name];
+      if (!container) {
+        continue;
+      }
+      const image = service.image
+        ? (/:[^/]+$/.test(service.image.split('@')[0]!) || service.image.includes('@') ? service.image : `${service.image}:${container.defaultTag}`)
+        : `${container.image}:${service.version || container.defaultTag}`;
+      const env = { ...container.env, ...service.env };
+      containers[service.name] = {
+        image,
+        ...(Object.keys(env).length > 0 && { env }),
+        ports: [`${container.port}:${container.port}`],
+        options: `--health-cmd ${container.healthCmd} --health-interval 10s --health-timeout 5s --health-retries 5`
+      };
+    }
+
+    return containers;
+  }
+
+  /**
+   * Connection variables for the service containers, such as DATABASE_URL, built from their env.
+   * The first database claims DATABASE_URL.
+   */
+  private createServiceConnections(containers: Record<string, any>, byAlias: boolean): Record<string, string> {
+    const connections: Record<string, string> = {};
+
+    for (const [name, container] of Object.entries(containers)) {
+      const [variable, url] = SERVICE_CONTAINERS[name]!.connection(byAlias ? name : 'localhost', container.env || {});
+      if (!(variable in connections)) {
+        connections[variable] = url;
+      }
+    }
+
+    return connections;
+  }
+
+  /**
+   * Expose the secrets the README requires and the variables the example environment file
+   * documents to the build and test jobs as environment variables: secrets and secret-looking
+   * variables from `secrets`, the others set to their example value. Only GitHub Actions reads
+   * `secrets`; other providers take CI variables as they are and only get the example values.
+   */
+  private applyRequiredSecrets(jobs: JobTemplate[], detectionResult: DetectionResult, options: GenerationOptions): JobTemplate[] {
+    const github = !options.provider || options.provider === Provider.GitHubActions;
+    const secrets = github ? this.getSecretNames(detectionResult, options) : [];
+    const env: Record<string, string> = {
+      ...Object.fromEntries(this.getEnvExampleDefaults(detectionResult, options).map(variable => [variable.name, variable.value])),
+      ...Object.fromEntries(secrets.map(secret => [secret, `\${{ secrets.${secret} }}`]))
+    };
+    if (Object.keys(env).length === 0) {
+      return jobs;
+    }
+
+    return jobs.map(job => SECRET_JOB_PATTERN.test(job.name) ? { ...job, env: { ...env, ...job.env } } : job);
+  }
+
+  /**
+   * Secrets the jobs read: the README's, then the secret variables of the example environment file
+   */
+  private getSecretNames(detectionResult: DetectionResult, options: GenerationOptions): string[] {
+    const excluded = new Set(options.envExampleExclude || []);
+    return [...new Set([
+      ...(detectionResult.requiredSecrets || []).map(secret => secret.name),
+      ...(detectionResult.envExample?.variables || [])
+        .filter(variable => variable.secret && !excluded.has(variable.name))
+        .map(variable => variable.name)
+    ])];
+  }
+
+  /**
+   * Variables of the example environment file set to their example value: those that are not
+   * secret, have a value and are neither excluded nor required by the README as secrets
+   */
+  private getEnvExampleDefaults(detectionResult: DetectionResult, options: GenerationOptions): Array<{ name: string; value: string }> {
+    const excluded = new Set([...(options.envExampleExclude || []), ...(detectionResult.requiredSecrets || []).map(secret => secret.name)]);
+    return (detectionResult.envExample?.variables || [])
+      .filter((variable): variable is { name: string; value: string; secret: boolean } =>
+        !variable.secret && variable.value !== undefined && !excluded.has(variable.name))
+      .map(variable => ({ name: variable.name, value: variable.value }));
+  }
+
+  /**
+   * Repository secrets a GitHub Actions workflow reads: the README's, the example environment
+   * file's and the private Go modules token
+   */
+  private getWorkflowSecrets(detectionResult: DetectionResult, options: GenerationOptions): string[] {
+    const secrets = this.getSecretNames(detectionResult, options);
+    if (this.getPrivateModulePatterns(detectionResult, options).length > 0) {
+      const token = options.privateModulesSecret || DEFAULT_PRIVATE_MODULES_SECRET;
+      if (!secrets.includes(token)) {
+        secrets.push(token);
+      }
+    }
+    return secrets;
+  }
+
+  /**
+   * Prefix rendered YAML with the repository secrets the workflow reads and that have to be
+   * created, and the job environment values copied from the example environment file. Minimal
+   * workflows go without.
+   */
+  private withRequiredSecretsComment(content: string, secrets: string[], envExample: { file: string; names: string[] } | undefined, options: GenerationOptions): string {
+    if (options.minimal || (secrets.length === 0 && !envExample)) {
+      return content;
+    }
+
+    const lines = [
+      ...(secrets.length > 0
+        ? ['# Required secrets - create these under Settings > Secrets and variables > Actions:', ...secrets.map(secret => `#   ${secret}`)]
+        : []),
+      ...(envExample
+        ? [`# Job environment defaults copied from ${envExample.file} - change them under env: if CI needs other values:`, `#   ${envExample.names.join(', ')}`]
+        : []),
+      ''
+    ];
+    return `${lines.join('\n')}\n${content}`;
+  }
+
+  /**
+   * Example environment file and the variables the jobs got its example values of, for the
+   * workflow's header comment; undefined when there are none
+   */
+  private getEnvExampleComment(detectionResult: DetectionResult, options: GenerationOptions): { file: string; names: string[] } | undefined {
+    const names = this.getEnvExampleDefaults(detectionResult, options).map(variable => variable.name);
+    return detectionResult.envExample && names.length > 0 ? { file: detectionResult.envExample.file, names } : undefined;
+  }
+
+  /**
+   * Drop disabled jobs (by id or category) along with dependencies on them, pin the runner label and set extra
+   * environment variables. Self-hosted labels replace any runner; a plain runner label leaves
+   * jobs spread over a runner matrix alone. Runner images replace the -latest aliases of the
+   * other jobs, in their runner matrices too.
+   */
+  private applyJobOverrides(jobs: JobTemplate[], options: GenerationOptions): JobTemplate[] {
+    const kept = jobs.filter(job => !isJobDisabled(job.name, options.disabledJobs));
+    const names = new Set(kept.map(job => job.name));
+
+    return kept.map(job => {
+      const overridden: JobTemplate = { ...job };
+
+      if (job.needs) {
+        const needs = job.needs.filter(need => names.has(need));
+        if (needs.length > 0) {
+          overridden.needs = needs;
+        } else {
+          delete overridden.needs;
+        }
+      }
+
+      const labels = Array.isArray(options.runnerLabels) ? options.runnerLabels : options.runnerLabels?.[job.name];
+      if (labels) {
+        overridden.runsOn = labels;
+      } else if (options.runnerOS && job.runsOn === 'ubuntu-latest') {
+        overridden.runsOn = options.runnerOS;
+      }
+      if (!labels && options.runnerImages && (!options.provider || options.provider === Provider.GitHubActions)) {
+        this.applyRunnerImages(overridden, options.runnerImages);
+      }
+
+      if (options.jobEnv && Object.keys(options.jobEnv).length > 0) {
+        overridden.env = { ...job.env, ...options.jobEnv };
+      }
+
+      return overridden;
+    });
+  }
+
+  /**
+   * Pin the runner images a job and its runner matrix name in place of the -latest aliases
+   */
+  private applyRunnerImages(job: JobTemplate, images: Partial<Record<HostedOS, string>>): void {
+    const pin = (value: any) => typeof value === 'string' ? pinRunnerImage(value, images) : value;
+    if (typeof job.runsOn === 'string') {
+      job.runsOn = pin(job.runsOn);
+    }
+    if (job.strategy) {
+      job.strategy = {
+        ...job.strategy,
+        matrix: Object.fromEntries(Object.entries(job.strategy.matrix).map(([key, values]) => [key, Array.isArray(values) ? values.map(pin) : values])),
+        ...(job.strategy.include && {
+          include: job.strategy.include.map(entry => Object.fromEntries(Object.entries(entry).map(([key, value]) => [key, pin(value)])))
+        }),
+        ...(job.strategy.exclude && {
+          exclude: job.strategy.exclude.map(entry => Object.fromEntries(Object.entries(entry).map(([key, value]) => [key, pin(value)])))
+        })
+      };
+    }
+  }
+
+  /**
+   * Create a job that builds and pushes an image per Dockerfile on pushes to the default branch,
+   * tagged with the commit SHA and latest
+   */
+  private createDockerJob(images: DockerImageDetection[], needs: string[], options: GenerationOptions): JobTemplate {
+    const registry = options.containerRegistry || DEFAULT_CONTAINER_REGISTRY;
+    const steps: StepTemplate[] = [
+      {
+        name: 'Checkout code',
+        uses: 'actions/checkout@v4'
+      },
+      {
+        // Registries reject uppercase repository names
+        name: 'Resolve image name',
+        id: 'image',
+        run: 'echo "name=${GITHUB_REPOSITORY,,}" >> "$GITHUB_OUTPUT"'
+      },
+      {
+        name: 'Set up Docker Buildx',
+        uses: 'docker/setup-buildx-action@v3'
+      },
+      {
+        name: 'Login to container registry',
+        uses: 'docker/login-action@v3',
+        with: registry === DEFAULT_CONTAINER_REGISTRY
+          ? { registry, username: '${{ github.actor }}', password: '${{ secrets.GITHUB_TOKEN }}' }
+          : { registry, username: '${{ secrets.REGISTRY_USERNAME }}', password: '${{ secrets.REGISTRY_PASSWORD }}' }
+      }
+    ];
+
+    for (const image of images) {
+      const suffix = this.getDockerImageSuffix(image);
+      const repository = `${registry}/\${{ steps.image.outputs.name }}${suffix ? `-${suffix}` : ''}`;
+      const cacheScope = images.length > 1 ? `,scope=${suffix || 'root'}` : '';
+
+      steps.push({
+        name: images.length > 1 ? `Build and push ${image.dockerfile}` : 'Build and push image',
+        uses: 'docker/build-push-action@v6',
+        with: {
+          context: image.context,
+          file: image.dockerfile,
+          ...(image.target && { target: image.target }),
+          push: true,
+          tags: `${repository}:\${{ github.sha }}\n${repository}:latest`,
+          'cache-from': `type=gha${cacheScope}`,
+          'cache-to': `type=gha,mode=max${cacheScope}`
+        }
+      });
+    }
+
+    return {
+      name: 'docker',
+      runsOn: 'ubuntu-latest',
+      steps,
+      needs,
+      if: DEFAULT_BRANCH_PUSH
+    };
+  }
+
+  /**
+   * Create a job that builds the static site and deploys it to GitHub Pages on pushes to the default
+   * branch, in the github-pages environment or options.deployEnvironment
+   */
+  private createPagesJob(site: StaticSiteDetection, detectionResult: DetectionResult, options: GenerationOptions): JobTemplate {
+    const steps: StepTemplate[] = [
+      {
+        name: 'Checkout code',
+        uses: 'actions/checkout@v4',
+        // Hugo themes are usually submodules; full history lets generators read last-modified dates
+        ...(site.generator === 'hugo' && { with: { submodules: 'recursive', 'fetch-depth': 0 } })
+      },
+      ...this.createStaticSiteSetupSteps(site, detectionResult),
+      {
+        name: 'Configure GitHub Pages',
+        id: 'pages',
+        uses: 'actions/configure-pages@v5'
+      },
+      this.createStaticSiteBuildStep(site),
+      {
+        name: 'Upload Pages artifact',
+        uses: 'actions/upload-pages-artifact@v3',
+        with: {
+          path: options.pagesOutputDir || site.outputDir
+        }
+      },
+      ...this.getInjectedSteps(options, 'pre-deploy'),
+      {
+        name: 'Deploy to GitHub Pages',
+        id: 'deployment',
+        uses: 'actions/deploy-pages@v4'
+      }
+    ];
+
+    const environment = options.deployEnvironment;
+    return {
+      name: PAGES_JOB,
+      runsOn: 'ubuntu-latest',
+      steps,
+      needs: ['build'],
+      if: DEFAULT_BRANCH_PUSH,
+      environment: {
+        name: environment?.name || 'github-pages',
+        url: environment?.url ? environment.url.split(DEPLOYED_URL).join(PAGES_URL) : PAGES_URL
+      }
+    };
+  }
+
+  /**
+   * Steps options.injectSteps splices in at an anchor, in the order the config lists them
+   */
+  private getInjectedSteps(options: GenerationOptions, position: StepAnchor): StepTemplate[] {
+    return (options.injectSteps || [])
+      .filter(injected => injected.position === position)
+      .map(injected => ({ ...injected.step, source: `injectSteps ${position}` }));
+  }
+
+  /**
+   * Add options.matrixExclude and options.matrixInclude to the matrices they apply to. Runner
+   * images are pinned by then, so an os rule names the runner the matrix ends up with.
+   */
+  private applyMatrixRules(jobs: JobTemplate[], options: GenerationOptions): JobTemplate[] {
+    if (!options.matrixExclude?.length && !options.matrixInclude?.length) {
+      return jobs;
+    }
+
+    return jobs.map(job => {
+      const entries = (rules: MatrixRule[] | undefined, kind: 'exclude' | 'include') => (rules || [])
+        .map(rule => this.toMatrixEntry(job, rule, kind, options))
+        .filter((entry): entry is Record<string, any> => entry !== undefined);
+      const exclude = entries(options.matrixExclude, 'exclude');
+      const include = entries(options.matrixInclude, 'include');
+      if (exclude.length === 0 && include.length === 0) {
+        return job;
+      }
+      return {
+        ...job,
+        strategy: {
+          ...job.strategy!,
+          ...(exclude.length > 0 && { exclude: [...(job.strategy!.exclude || []), ...exclude] }),
+          ...(include.length > 0 && { include: [...(job.strategy!.include || []), ...include] })
+        }
+      };
+    });
+  }
+
+  /**
+   * Matrix entry a rule makes for a job, with the values written the way the matrix has them.
+   * Undefined when the rule names another job or the job has no matrix, and for an exclusion
+   * when a value is not in the matrix, or for an inclusion when it shares no dimension with it.
+   */
+  private toMatrixEntry(job: JobTemplate, rule: MatrixRule, kind: 'exclude' | 'include', options: GenerationOptions): Record<string, any> | undefined {
+    const matrix = job.strategy?.matrix;
+    if (!matrix || (rule.job && rule.job !== job.name)) {
+      return undefined;
+    }
+
+    const entry: Record<string, any> = {};
+    let shared = false;
+    for (const [key, value] of Object.entries(rule.values)) {
+      const os = key === 'os' && (HOSTED_OS_NAMES as readonly unknown[]).includes(value) ? HOSTED_OS_RUNNERS[value as HostedOS] : undefined;
+      const wanted = os ? this.pinRunner(os.runner, options) : String(value);
+      const dimension = Array.isArray(matrix[key]) ? matrix[key] : undefined;
+      const existing = dimension?.find(item => String(item) === wanted);
+      if (kind === 'exclude' && existing === undefined) {
+        return undefined;
+      }
+      shared = shared || dimension !== undefined;
+      entry[key] = existing ?? (os ? wanted : value);
+    }
+    return kind === 'exclude' || shared ? entry : undefined;
+  }
+
   /**
-   * Generate CI-focused workflow optimized for build and test steps
+   * Runner label a -latest alias ends up as once options.runnerImages pins it
    */
-  async generateCIWorkflow(
-    detectionResult: DetectionResult,
-    options: GenerationOptions
-  ): Promise<WorkflowOutput> {
-    const workflow = this.createCIWorkflowTemplate(detectionResult, options);
-    const content = await this.renderWorkflow(workflow);
-    
+  private pinRunner(label: string, options: GenerationOptions): string {
+    return options.runnerImages && !Array.isArray(options.runnerLabels) && (!options.provider || options.provider === Provider.GitHubActions)
+      ? pinRunnerImage(label, options.runnerImages)
+      : label;
+  }
+
+  /**
+   * Name the matrix rules no job matrix of the workflow took, so they change nothing. Jobs of
+   * several languages or packages are named after them, so the entries are looked for instead.
+   */
+  private getMatrixRuleWarnings(workflow: WorkflowTemplate, options: GenerationOptions): string[] {
+    const describe = (rule: MatrixRule) => Object.entries(rule.values).map(([key, value]) => `${key}: ${value}`).join(', ');
+    const applied = (job: JobTemplate, rule: MatrixRule, kind: 'exclude' | 'include') => {
+      const entry = this.toMatrixEntry(job, { values: rule.values }, kind, options);
+      return entry !== undefined && (job.strategy?.[kind] || []).some(existing => JSON.stringify(existing) === JSON.stringify(entry));
+    };
+    return (['exclude', 'include'] as const).flatMap(kind =>
+      ((kind === 'exclude' ? options.matrixExclude : options.matrixInclude) || [])
+        .filter(rule => !workflow.jobs.some(job => applied(job, rule, kind)))
+        .map(rule => `matrix${kind === 'exclude' ? 'Exclude' : 'Include'} rule { ${describe(rule)} } matches no ${rule.job ? `${rule.job} ` : ''}job matrix - it is not applied`));
+  }
+
+  /**
+   * Name the anchors of injected steps that no job of the workflow holds, so their steps were not added
+   */
+  private getInjectedStepWarnings(workflow: WorkflowTemplate, options: GenerationOptions): string[] {
+    const injected = new Set(workflow.jobs.flatMap(job => job.steps.map(step => step.source)));
+    return [...new Set((options.injectSteps || []).map(step => step.position))]
+      .filter(position => !injected.has(`injectSteps ${position}`))
+      .map(position => `No ${STEP_ANCHOR_JOBS[position]} generated - the steps injected at ${position} are not added`);
+  }
+
+  /**
+   * Create a job checking the formatting and configuration of each Terraform root module without
+   * touching its backend, then planning against the backend on pushes to the default branch.
+   * Applying the plan is left to people.
+   */
+  private createTerraformJob(terraform: TerraformDetection): JobTemplate {
+    const matrix = terraform.directories.length > 1;
+    const directory = matrix ? '${{ matrix.directory }}' : terraform.directories[0]!;
+    const inDirectory = (step: StepTemplate): StepTemplate => directory === '.' ? step : { ...step, workingDirectory: directory };
+    const version = terraform.requiredVersion ? this.toSetupTerraformVersion(terraform.requiredVersion) : undefined;
+
+    const steps: StepTemplate[] = [
+      {
+        name: 'Checkout code',
+        uses: 'actions/checkout@v4'
+      },
+      {
+        name: 'Set up Terraform',
+        uses: 'hashicorp/setup-terraform@v3',
+        ...(version && { with: { terraform_version: version } })
+      },
+      inDirectory({ name: 'Check formatting', run: 'terraform fmt -check -recursive' }),
+      inDirectory({ name: 'Initialize without backend', run: 'terraform init -backend=false -input=false' }),
+      inDirectory({ name: 'Validate configuration', run: 'terraform validate -no-color' }),
+      inDirectory({ name: 'Initialize backend', run: 'terraform init -input=false', if: DEFAULT_BRANCH_PUSH }),
+      inDirectory({ name: 'Plan', run: 'terraform plan -input=false -no-color', if: DEFAULT_BRANCH_PUSH })
+    ];
+
     return {
-      filename: 'ci.yml',
-      content,
-      type: 'ci',
-      metadata: {
-        generatedAt: new Date(),
-        generatorVersion: '1.0.0',
-        detectionSummary: this.createDetectionSummary(detectionResult),
-        optimizations: this.getAppliedOptimizations(detectionResult, options),
-        warnings: this.getWarnings(detectionResult)
-      }
+      name: TERRAFORM_JOB,
+      runsOn: 'ubuntu-latest',
+      steps,
+      ...(matrix && { strategy: { matrix: { directory: terraform.directories }, failFast: false } })
     };
   }
 
   /**
-   * Create CI workflow template with build and test focus
+   * Create a job installing the dependencies and failing when one has a license outside the
+   * allowlist, with the scanner of the language's ecosystem: license-checker for npm packages,
+   * pip-licenses for Python and go-licenses for Go modules. Undefined for other languages.
    */
-  private createCIWorkflowTemplate(
-    detectionResult: DetectionResult,
-    options: GenerationOptions
-  ): WorkflowTemplate {
+  private createLicenseCheckJob(detectionResult: DetectionResult, options: GenerationOptions): JobTemplate | undefined {
+    const language = detectionResult.languages.find(l => l.primary)?.name;
+    const allowed = this.getLicenseAllowlist(detectionResult, options);
+    const source = options.licenseAllowlist?.length ? { source: 'licenseAllowlist' } : {};
+
+    let checks: StepTemplate[];
+    switch (language?.toLowerCase()) {
+      case 'javascript':
+      case 'typescript':
+        checks = [{ name: 'Check dependency licenses', run: `npx --yes license-checker --production --onlyAllow '${allowed.join(';')}'`, ...source }];
+        break;
+      case 'python':
+        checks = [
+          { name: 'Install pip-licenses', run: 'pip install pip-licenses' },
+          { name: 'Check dependency licenses', run: `pip-licenses --partial-match --allow-only '${allowed.join(';')}'`, ...source }
+        ];
+        break;
+      case 'go':
+        checks = [
+          { name: 'Install go-licenses', run: 'go install github.com/google/go-licenses@latest' },
+          { name: 'Check dependency licenses', run: `go-licenses check ./... --allowed_licenses=${allowed.join(',')}`, ...source }
+        ];
+        break;
+      default:
+        return undefined;
+    }
+
     return {
-      name: 'Continuous Integration',
-      type: 'ci',
-      triggers: this.createCITriggers(),
-      jobs: this.createCIJobs(detectionResult, options),
-      permissions: {
-        contents: 'read',
-        checks: 'write',
-        pullRequests: 'write'
+      name: LICENSE_CHECK_JOB,
+      runsOn: 'ubuntu-latest',
+      steps: [
+        {
+          name: 'Checkout code',
+          uses: 'actions/checkout@v4'
+        },
+        ...this.createLanguageSetupSteps(language!, detectionResult, true),
+        ...checks
+      ]
+    };
+  }
+
+  /**
+   * Create a job linting every OpenAPI document with spectral lint, against the project's
+   * Spectral ruleset or else the OpenAPI one, and the GraphQL schema files with
+   * graphql-schema-linter, which reads them together as a single schema.
+   */
+  private createApiLintJob(apiSchemas: ApiSchemaDetection): JobTemplate {
+    const steps: StepTemplate[] = [
+      {
+        name: 'Checkout code',
+        uses: 'actions/checkout@v4'
       },
-      concurrency: {
-        group: 'ci-${{ github.ref }}',
-        cancelInProgress: true
+      {
+        name: 'Setup Node.js',
+        uses: 'actions/setup-node@v4',
+        with: { 'node-version': '20' }
+      }
+    ];
+
+    if (apiSchemas.openapi.length > 0) {
+      steps.push({ name: 'Install Spectral', run: 'npm install -g @stoplight/spectral-cli' });
+      if (!apiSchemas.spectralRuleset) {
+        steps.push({ name: 'Use the OpenAPI ruleset', run: 'echo \'extends: ["spectral:oas"]\' > .spectral.yaml' });
       }
+      steps.push({ name: 'Lint OpenAPI schemas', run: `spectral lint ${apiSchemas.openapi.join(' ')}` });
+    }
+    if (apiSchemas.graphql.length > 0) {
+      steps.push(
+        { name: 'Install graphql-schema-linter', run: 'npm install -g graphql graphql-schema-linter' },
+        { name: 'Lint GraphQL schema', run: `graphql-schema-linter ${apiSchemas.graphql.join(' ')}` }
+      );
+    }
+
+    return {
+      name: API_LINT_JOB,
+      runsOn: 'ubuntu-latest',
+      steps
     };
   }
 
   /**
-   * Create CI-specific triggers (push, PR, schedule for dependency checks)
+   * Create a job running buf lint and, on pull requests, buf breaking against the branch they target
+   * as the local clone has it, which needs the full history. buf runs where buf.yaml or
+   * buf.work.yaml is, from which the clone is reached and that directory picked as the input to
+   * compare. With a buf.gen.yaml, the code is regenerated and the job fails when that changes
+   * committed files.
    */
-  private createCITriggers(): TriggerConfig {
-    return {
-      push: {
-        branches: ['main', 'develop', 'master'],
-        pathsIgnore: ['docs/**', '*.md', '.gitignore']
+  private createBufJob(protobuf: ProtobufDetection, detectionResult: DetectionResult, options: GenerationOptions): JobTemplate {
+    const inDirectory = (step: StepTemplate, directory: string): StepTemplate => directory === '.' ? step : { ...step, workingDirectory: directory };
+    const branch = options.bufBreakingBranch || options.defaultBranch || 'main';
+    // The git input is read relative to where buf runs, and its subdir from the repository root
+    const subdir = [detectionResult.workingDirectory, protobuf.bufDirectory].filter(path => path && path !== '.').join('/');
+    const clone = `${subdir ? `${subdir.split('/').map(() => '..').join('/')}/` : ''}.git#branch=${branch}${subdir ? `,subdir=${subdir}` : ''}`;
+
+    const steps: StepTemplate[] = [
+      {
+        name: 'Checkout code',
+        uses: 'actions/checkout@v4',
+        with: { 'fetch-depth': 0 }
       },
-      pullRequest: {
-        branches: ['main', 'develop', 'master'],
-        types: ['opened', 'synchronize', 'reopened']
+      {
+        name: 'Set up buf',
+        uses: 'bufbuild/buf-action@v1',
+        with: { setup_only: true, github_token: '${{ github.token }}' }
       },
-      schedule: [
+      inDirectory({ name: 'Lint protobuf files', run: 'buf lint' }, protobuf.bufDirectory),
+      inDirectory({
+        name: 'Check for breaking changes',
+        if: "github.event_name == 'pull_request'",
+        run: `buf breaking --against '${clone}'`
+      }, protobuf.bufDirectory)
+    ];
+
+    if (protobuf.bufGenConfig) {
+      const directory = posix.dirname(protobuf.bufGenConfig);
+      const input = posix.relative(directory, protobuf.bufDirectory);
+      steps.push(
+        inDirectory({ name: 'Generate code', run: input ? `buf generate ${input}` : 'buf generate' }, directory),
+        { name: 'Check generated code is up to date', run: 'git diff --exit-code' }
+      );
+    }
+
+    return {
+      name: BUF_JOB,
+      runsOn: 'ubuntu-latest',
+      steps
+    };
+  }
+
+  /**
+   * Flags, quoted for the shell, leaving generated code out of a linter: exclude-dirs and
+   * exclude-files regexps for golangci-lint (skip-dirs and skip-files before v1.57), which only
+   * recognizes generated files by their header itself, and ignore patterns for ESLint. None for
+   * other linters.
+   */
+  private getLintExcludes(linter: string, generatedCode?: GeneratedCodeDetection): string[] {
+    const exactPath = (path: string) => `^${path.replace(/[.*+?^${}()|[\]\\]/g, '\\$&')}$`;
+    switch (linter) {
+      case 'golangci-lint':
+        return [
+          ...(generatedCode?.goDirectories || []).map(directory => `'--exclude-dirs=${exactPath(directory)}'`),
+          ...(generatedCode?.goFiles || []).map(file => `'--exclude-files=${exactPath(file)}'`)
+        ];
+      case 'eslint':
+        return (generatedCode?.bundleDirectories || []).map(directory => `--ignore-pattern '${directory}/'`);
+      default:
+        return [];
+    }
+  }
+
+  /**
+   * Create a job failing pull requests that do not change the changelog, which paths-filter
+   * tells from the files the pull request changes. It runs for pull requests only, and not for
+   * those labelled with options.changelogSkipLabel; as adding the label triggers no run, the
+   * failed check passes when it is run again.
+   */
+  private createChangelogJob(changelog: ChangelogDetection, detectionResult: DetectionResult, options: GenerationOptions): JobTemplate {
+    const label = options.changelogSkipLabel || DEFAULT_CHANGELOG_SKIP_LABEL;
+    // paths-filter matches paths from the repository root
+    const directory = detectionResult.workingDirectory;
+    const path = directory && directory !== '.' ? `${directory}/${changelog.file}` : changelog.file;
+
+    return {
+      name: CHANGELOG_JOB,
+      runsOn: 'ubuntu-latest',
+      if: `github.event_name == 'pull_request' && !contains(github.event.pull_request.labels.*.name, '${label}')`,
+      steps: [
         {
-          cron: '0 2 * * 1' // Weekly dependency check on Mondays at 2 AM
+          // Pull request changes are listed through the API, so nothing is checked out
+          name: 'Detect changelog changes',
+          id: 'filter',
+          uses: 'dorny/paths-filter@v3',
+          with: {
+            filters: `changelog:\n  - '${path}'`
+          }
+        },
+        {
+          name: 'Require a changelog entry',
+          if: "steps.filter.outputs.changelog != 'true'",
+          run: `echo "::error file=${path}::Add an entry to ${path}, or label the pull request ${label} if it needs none" && exit 1`
         }
       ]
     };
   }
 
   /**
-   * Create CI jobs focused on build and test optimization
+   * Create a job commenting on pull requests with the CODEOWNERS rules the files they change
+   * fall under; paths-filter matches the changed files against each rule's globs and, as on
+   * GitHub, the last rule matching a file decides who owns it. The comment is updated on later
+   * runs rather than added again, and names owners without mentioning them, as GitHub requests
+   * their reviews itself. Pull requests from forks get a read-only token and are left alone.
    */
-  private createCIJobs(
-    detectionResult: DetectionResult,
-    options: GenerationOptions
-  ): JobTemplate[] {
-    const jobs: JobTemplate[] = [];
+  private createCodeownersJob(codeowners: CodeownersDetection): JobTemplate {
+    const quote = (glob: string) => `'${glob.replace(/'/g, "''")}'`;
+    const filters = codeowners.rules.map((rule, index) =>
+      [`rule${index + 1}:`, ...rule.globs.map(glob => `  - ${quote(glob)}`)].join('\n')
+    );
 
-    // Add lint job for code quality
-    jobs.push(this.createLintJob(detectionResult));
+    return {
+      name: CODEOWNERS_JOB,
+      runsOn: 'ubuntu-latest',
+      if: "github.event_name == 'pull_request' && github.event.pull_request.head.repo.full_name == github.repository",
+      steps: [
+        {
+          // Pull request changes are listed through the API, so nothing is checked out
+          name: 'Match changed files against CODEOWNERS',
+          id: 'filter',
+          uses: 'dorny/paths-filter@v3',
+          with: {
+            'list-files': 'json',
+            filters: filters.join('\n')
+          }
+        },
+        {
+          name: 'Comment with the code owners',
+          uses: 'actions/github-script@v7',
+          env: {
+            FILTER_OUTPUTS: '${{ toJSON(steps.filter.outputs) }}',
+            CODEOWNERS_RULES: JSON.stringify(codeowners.rules.map(rule => ({ pattern: rule.pattern, owners: rule.owners })))
+          },
+          with: {
+            script: [
+              'const outputs = JSON.parse(process.env.FILTER_OUTPUTS);',
+              'const rules = JSON.parse(process.env.CODEOWNERS_RULES);',
+              '// The last rule matching a file decides who owns it',
+              'const owning = new Map();',
+              'rules.forEach((rule, index) => {',
+              "  for (const file of JSON.parse(outputs[`rule${index + 1}_files`] || '[]')) {",
+              '    owning.set(file, index);',
+              '  }',
+              '});',
+              'const touched = rules',
+              '  .map((rule, index) => ({ ...rule, files: [...owning.values()].filter(owner => owner === index).length }))',
+              '  .filter(rule => rule.files > 0 && rule.owners.length > 0);',
+              `const marker = '${CODEOWNERS_COMMENT_MARKER}';`,
+              'const body = [',
+              '  marker,',
+              "  '### Code owners',",
+              "  '',",
+              '  ...(touched.length > 0',
+              "    ? ['| Path | Owners | Files |', '| --- | --- | --- |', ...touched.map(rule => `| \\`${rule.pattern}\\` | ${rule.owners.map(owner => `\\`${owner}\\``).join(' ')} | ${rule.files} |`)]",
+              "    : ['No file this pull request changes has a code owner.'])",
+              "].join('\\n');",
+              'const { owner, repo } = context.repo;',
+              'const issue_number = context.issue.number;',
+              'const comments = await github.paginate(github.rest.issues.listComments, { owner, repo, issue_number });',
+              'const previous = comments.find(comment => comment.body?.startsWith(marker));',
+              'if (previous) {',
+              '  await github.rest.issues.updateComment({ owner, repo, comment_id: previous.id, body });',
+              '} else {',
+              '  await github.rest.issues.createComment({ owner, repo, issue_number, body });',
+              '}'
+            ].join('\n')
+          }
+        }
+      ]
+    };
+  }
 
-    // Add build job with matrix strategy if multiple versions detected
-    jobs.push(this.createBuildJob(detectionResult, options));
+  /**
+   * SPDX ids the license check allows: the configured allowlist, else the common permissive
+   * licenses and the project's own
+   */
+  private getLicenseAllowlist(detectionResult: DetectionResult, options: GenerationOptions): string[] {
+    if (options.licenseAllowlist?.length) {
+      return options.licenseAllowlist;
+    }
+    const own = detectionResult.license?.spdxId;
+    return own && !DEFAULT_LICENSE_ALLOWLIST.includes(own) ? [...DEFAULT_LICENSE_ALLOWLIST, own] : DEFAULT_LICENSE_ALLOWLIST;
+  }
 
-    // Add test jobs with parallel execution
-    jobs.push(...this.createTestJobs(detectionResult, options));
+  /**
+   * setup-terraform's terraform_version, a semver range, for a Terraform version constraint such
+   * as `>= 1.5, < 2.0`. `~> 1.6` allows 1.6 and later 1.x releases, `~> 1.6.2` later 1.6.x ones.
+   * Undefined for constraints it cannot express, leaving the latest release.
+   */
+  private toSetupTerraformVersion(constraint: string): string | undefined {
+    const ranges: string[] = [];
+    for (const condition of constraint.split(',').map(part => part.trim())) {
+      const match = condition.match(/^(~>|>=|<=|!=|>|<|=)?\s*v?(\d+(?:\.\d+){0,2})$/);
+      if (!match) {
+        return undefined;
+      }
 
-    // Add security scanning job
-    if (options.securityLevel !== 'basic') {
-      jobs.push(this.createSecurityScanJob(detectionResult));
+      const [, operator = '=', version] = match;
+      const parts = version!.split('.').map(Number);
+      if (operator === '~>') {
+        const upper = parts.length === 1 ? [parts[0]! + 1] : [...parts.slice(0, -2), parts[parts.length - 2]! + 1];
+        ranges.push(`>=${version} <${[...upper, 0, 0].slice(0, 3).join('.')}`);
+      } else if (operator !== '!=') {
+        ranges.push(operator === '=' ? version! : `${operator}${version}`);
+      }
     }
+    return ranges.length > 0 ? ranges.join(' ') : undefined;
+  }
 
-    return jobs;
+  private createStaticSiteSetupSteps(site: StaticSiteDetection, detectionResult: DetectionResult): StepTemplate[] {
+    switch (site.generator) {
+      case 'hugo':
+        return [
+          {
+            name: 'Setup Hugo',
+            uses: 'peaceiris/actions-hugo@v3',
+            with: { 'hugo-version': 'latest', extended: true }
+          }
+        ];
+      case 'jekyll':
+        return [
+          {
+            name: 'Setup Ruby',
+            uses: 'ruby/setup-ruby@v1',
+            with: { 'ruby-version': '3.3', 'bundler-cache': true }
+          }
+        ];
+      case 'mkdocs':
+        return [
+          {
+            name: 'Setup Python',
+            uses: 'actions/setup-python@v5',
+            with: { 'python-version': this.getDefaultVersion(detectionResult, 'python') }
+          },
+          {
+            name: 'Install MkDocs',
+            run: 'pip install mkdocs mkdocs-material'
+          }
+        ];
+      default:
+        return this.createLanguageSetupSteps('JavaScript', detectionResult, true);
+    }
+  }
+
+  /**
+   * Site build step; the base URL comes from configure-pages so project sites resolve under /<repo>/
+   */
+  private createStaticSiteBuildStep(site: StaticSiteDetection): StepTemplate {
+    switch (site.generator) {
+      case 'hugo':
+        return {
+          name: 'Build site with Hugo',
+          run: 'hugo --minify --baseURL "${{ steps.pages.outputs.base_url }}/"'
+        };
+      case 'jekyll':
+        return {
+          name: 'Build site with Jekyll',
+          run: 'bundle exec jekyll build --baseurl "${{ steps.pages.outputs.base_path }}"',
+          env: { JEKYLL_ENV: 'production' }
+        };
+      case 'nextjs':
+        return {
+          name: 'Build static export with Next.js',
+          run: 'npx next build'
+        };
+      case 'vitepress': {
+        const sourceDir = site.configFile.startsWith('.vitepress/') ? undefined : site.configFile.split('/.vitepress/')[0];
+        return {
+          name: 'Build site with VitePress',
+          run: sourceDir ? `npx vitepress build ${sourceDir}` : 'npx vitepress build'
+        };
+      }
+      default:
+        return {
+          name: 'Build site with MkDocs',
+          run: 'mkdocs build'
+        };
+    }
+  }
+
+  /**
+   * Image name suffix distinguishing Dockerfiles: their directory and Dockerfile.<variant> suffix
+   */
+  private getDockerImageSuffix(image: DockerImageDetection): string {
+    const fileName = image.dockerfile.split('/').pop() || '';
+    const parts = [
+      ...(image.context === '.' ? [] : [image.context]),
+      ...(fileName.startsWith('Dockerfile.') ? [fileName.slice('Dockerfile.'.length)] : [])
+    ];
+    return parts.join('-').toLowerCase().replace(/[^a-z0-9.-]+/g, '-');
+  }
+
+  /**
+   * Create the job running the tasks the Nx or Turborepo config declares for the packages a
+   * change affects, and those depending on them. It fetches the whole history to compare
+   * against: nx-set-shas finds the last commit CI passed on the default branch, and turbo
+   * filters on the pull request's base or the commit a push started from, running every
+   * package when there is none to compare with.
+   */
+  private createTaskGraphJob(taskGraph: TaskGraphDetection, detectionResult: DetectionResult, options: GenerationOptions): JobTemplate {
+    const manager = detectionResult.packageManagers.find(pm => ['npm', 'yarn', 'pnpm', 'bun'].includes(pm.name))?.name || 'npm';
+    const exec = ({ npm: 'npx', yarn: 'yarn', pnpm: 'pnpm exec', bun: 'bunx' } as Record<string, string>)[manager]!;
+    const language = detectionResult.languages.find(l => l.primary)?.name || 'JavaScript';
+    const steps: StepTemplate[] = [
+      {
+        name: 'Checkout code',
+        uses: 'actions/checkout@v4',
+        with: { 'fetch-depth': 0 }
+      },
+      ...this.createLanguageSetupSteps(language, detectionResult, true)
+    ];
+
+    if (taskGraph.tool === 'nx') {
+      const branch = options.defaultBranch || 'main';
+      steps.push(
+        {
+          name: 'Derive the affected base and head',
+          uses: 'nrwl/nx-set-shas@v4',
+          ...(branch !== 'main' && { with: { 'main-branch-name': branch } })
+        },
+        { name: 'Run affected tasks', run: `${exec} nx affected -t ${taskGraph.tasks.join(',')}` }
+      );
+    } else {
+      const run = `${exec} turbo run ${taskGraph.tasks.join(' ')}`;
+      steps.push({
+        name: 'Run affected tasks',
+        run: [
+          'if git cat-file -e "$TURBO_BASE^{commit}" 2>/dev/null; then',
+          `  ${run} --filter="...[$TURBO_BASE]"`,
+          'else',
+          `  ${run}`,
+          'fi'
+        ].join('\n'),
+        env: { TURBO_BASE: '${{ github.event.pull_request.base.sha || github.event.before }}' }
+      });
+    }
+
+    return {
+      name: TASK_GRAPH_JOB,
+      runsOn: 'ubuntu-latest',
+      steps
+    };
+  }
+
+  /**
+   * Create a single job that sets up the language and runs `make ci`
+   */
+  private createMakeCIJob(detectionResult: DetectionResult): JobTemplate {
+    const steps: StepTemplate[] = [
+      {
+        name: 'Checkout code',
+        uses: 'actions/checkout@v4'
+      }
+    ];
+
+    const primaryLanguage = detectionResult.languages.find(l => l.primary);
+    if (primaryLanguage) {
+      steps.push(...this.createLanguageSetupSteps(primaryLanguage.name, detectionResult, true));
+    }
+
+    steps.push({
+      name: `Run make ${MAKE_CI_TARGET}`,
+      run: `make ${MAKE_CI_TARGET}`
+    });
+
+    return {
+      name: 'ci',
+      runsOn: 'ubuntu-latest',
+      steps
+    };
   }
 
   /**
@@ -117,14 +2894,15 @@ export class CIWorkflowGenerator {
     const primaryLanguage = detectionResult.languages.find(l => l.primary);
     if (primaryLanguage) {
       steps.push(...this.createLanguageSetupSteps(primaryLanguage.name, detectionResult));
-      steps.push(...this.createLintSteps(primaryLanguage.name, detectionResult));
+      steps.push(...(this.createMakeSteps(detectionResult, 'lint') ||
+        this.createLintSteps(primaryLanguage.name, detectionResult)));
     }
 
     return {
       name: 'lint',
       runsOn: 'ubuntu-latest',
       steps,
-      if: "github.event_name != 'schedule'"
+      if: NOT_SCHEDULED
     };
   }
 
@@ -136,7 +2914,7 @@ export class CIWorkflowGenerator {
     options: GenerationOptions
   ): JobTemplate {
     const primaryLanguage = detectionResult.languages.find(l => l.primary);
-    const strategy = this.createMatrixStrategy(primaryLanguage, options);
+    const strategy = this.createMatrixStrategy(primaryLanguage, detectionResult, options);
     
     const steps: StepTemplate[] = [
       {
@@ -147,33 +2925,68 @@ export class CIWorkflowGenerator {
 
     if (primaryLanguage) {
       steps.push(...this.createLanguageSetupSteps(primaryLanguage.name, detectionResult, true));
-      steps.push(...this.createBuildSteps(primaryLanguage.name, detectionResult));
+      steps.push(...this.getInjectedSteps(options, 'before-build'));
+      steps.push(...(this.createMakeSteps(detectionResult, 'build') ||
+        this.createBuildSteps(primaryLanguage.name, detectionResult)));
+    }
+
+    const job: JobTemplate = {
+      name: 'build',
+      runsOn: 'ubuntu-latest',
+      steps,
+      needs: ['lint']
+    };
+
+    if (strategy) {
+      job.strategy = strategy;
+    }
+
+    this.applyGoPlatformMatrix(job, primaryLanguage?.name, detectionResult, options);
+    this.applySwiftPlatforms(job, primaryLanguage?.name, detectionResult, options);
+    this.applyFlutterBuildMatrix(job, primaryLanguage?.name, detectionResult, options);
+    this.applyCargoFeatureMatrix(job, primaryLanguage?.name, detectionResult, options);
+    this.applyCompilerMatrix(job, primaryLanguage?.name, detectionResult, options);
+
+    if (options.artifacts) {
+      job.steps = this.withArtifactUpload(job, detectionResult, options);
     }
 
-    // Add artifact upload for build outputs
+    return job;
+  }
+
+  /**
+   * Steps of the build job followed by an upload of what it built. The artifact is named after
+   * the job's matrix values, so the entries of a matrix do not collide; go build is pointed at
+   * bin/ unless it already writes somewhere with -o.
+   */
+  private withArtifactUpload(job: JobTemplate, detectionResult: DetectionResult, options: GenerationOptions): StepTemplate[] {
+    const { steps, path } = this.withBuildOutput(job.steps, detectionResult, options);
+    const matrixKeys = Object.keys(job.strategy?.matrix || {});
+
     steps.push({
       name: 'Upload build artifacts',
       uses: 'actions/upload-artifact@v4',
       with: {
-        name: 'build-artifacts-${{ matrix.version || \'default\' }}',
-        path: this.getBuildArtifactPaths(detectionResult),
-        'retention-days': 7
+        name: [options.artifactName || DEFAULT_ARTIFACT_NAME, ...matrixKeys.map(key => `\${{ matrix.${key} }}`)].join('-'),
+        path,
+        'retention-days': options.artifactRetentionDays || DEFAULT_ARTIFACT_RETENTION_DAYS
       },
       if: 'success()'
     });
+    return steps;
+  }
 
-    const job: JobTemplate = {
-      name: 'build',
-      runsOn: 'ubuntu-latest',
-      steps,
-      needs: ['lint']
-    };
-
-    if (strategy) {
-      job.strategy = strategy;
-    }
-
-    return job;
+  /**
+   * Build steps made to leave their output somewhere known, and the paths it ends up in:
+   * options.artifactPath, where go build writes with -o (bin/ unless it says otherwise) or the
+   * language's usual output directory
+   */
+  private withBuildOutput(buildSteps: StepTemplate[], detectionResult: DetectionResult, options: GenerationOptions): { steps: StepTemplate[]; path: string } {
+    const steps = buildSteps.map(step => /^go build\b/.test(step.run || '') && !/\s-o\s/.test(step.run!)
+      ? { ...step, run: step.run!.replace(/^go build\b/, 'go build -o bin/') }
+      : step);
+    const goOutput = steps.map(step => (step.run || '').match(/^go build\b.*\s-o\s+(\S+)/)?.[1]).find(Boolean);
+    return { steps, path: options.artifactPath || goOutput || this.getBuildArtifactPaths(detectionResult) };
   }
 
   /**
@@ -185,10 +2998,27 @@ export class CIWorkflowGenerator {
   ): JobTemplate[] {
     const jobs: JobTemplate[] = [];
     const testingFrameworks = detectionResult.testingFrameworks;
+    const runners = this.getTestRunners(detectionResult);
+    const crates = this.getPerCrateMembers(detectionResult, options);
+    const workspacePackages = this.getPerPackageMembers(detectionResult, options);
 
-    // Unit tests job
-    if (testingFrameworks.some(tf => tf.type === 'unit')) {
-      jobs.push(this.createUnitTestJob(detectionResult, options));
+    // Unit tests job, split per crate, workspace package or test runner unless they share a matrix job
+    if (crates) {
+      for (const crate of crates) {
+        jobs.push(this.createUnitTestJob(detectionResult, options, [], `unit-tests-${this.getCrateSlug(crate.name)}`, crate));
+      }
+    } else if (workspacePackages) {
+      // Packages with neither script have nothing to run
+      for (const member of workspacePackages.filter(member => member.scripts.includes('test') || member.scripts.includes('build'))) {
+        jobs.push(this.createUnitTestJob(detectionResult, options, [], `unit-tests-${this.getCrateSlug(member.name)}`, undefined, member));
+      }
+    } else if (runners.length > 1 && options.testRunnerLayout !== 'matrix') {
+      for (const runner of runners) {
+        jobs.push(this.createUnitTestJob(detectionResult, options, [runner], `unit-tests-${this.getTestRunnerSlug(runner)}`));
+      }
+    } else if (runners.length > 0 || detectionResult.cmakeProject?.tests || detectionResult.dotnetProject?.testProjects.length ||
+      (testingFrameworks.some(tf => tf.type === 'unit') && !this.lacksPackageTestScript(detectionResult))) {
+      jobs.push(this.createUnitTestJob(detectionResult, options, runners));
     }
 
     // Integration tests job (if detected)
@@ -196,6 +3026,14 @@ export class CIWorkflowGenerator {
       jobs.push(this.createIntegrationTestJob(detectionResult, options));
     }
 
+    // Go tests behind a build tag only run with it, each tag in its own job
+    for (const tag of this.getGoTestTags(detectionResult)) {
+      const name = tag === 'integration' ? 'integration-tests' : `integration-tests-${this.getCrateSlug(tag)}`;
+      if (!jobs.some(job => job.name === name)) {
+        jobs.push(this.createIntegrationTestJob(detectionResult, options, tag, name));
+      }
+    }
+
     // E2E tests job (if detected and enabled)
     if (testingFrameworks.some(tf => tf.type === 'e2e') && options.testingStrategy?.e2eTests) {
       jobs.push(this.createE2ETestJob(detectionResult, options));
@@ -205,14 +3043,22 @@ export class CIWorkflowGenerator {
   }
 
   /**
-   * Create unit test job
+   * Create unit test job. Detected test runners replace the language's default test steps;
+   * several runners in one job become a `test-runner` matrix dimension. A workspace crate
+   * restricts the job to that crate's tests, an npm or pnpm workspace package to building
+   * and testing that package.
    */
   private createUnitTestJob(
     detectionResult: DetectionResult,
-    options: GenerationOptions
+    options: GenerationOptions,
+    runners: TestRunnerDetection[] = [],
+    name: string = 'unit-tests',
+    crate?: { name: string; path: string },
+    workspacePackage?: { name: string; path: string; scripts: string[] }
   ): JobTemplate {
     const primaryLanguage = detectionResult.languages.find(l => l.primary);
-    const strategy = this.createMatrixStrategy(primaryLanguage, options);
+    const strategy = this.createMatrixStrategy(primaryLanguage, detectionResult, options);
+    const runnerMatrix = runners.length > 1;
     
     const steps: StepTemplate[] = [
       {
@@ -223,15 +3069,29 @@ export class CIWorkflowGenerator {
 
     if (primaryLanguage) {
       steps.push(...this.createLanguageSetupSteps(primaryLanguage.name, detectionResult, true));
-      steps.push(...this.createTestSteps(primaryLanguage.name, detectionResult, 'unit'));
+      if (crate) {
+        steps.push({ name: `Run ${crate.name} tests`, run: `cargo test -p ${crate.name}` });
+      } else if (workspacePackage) {
+        steps.push(...this.createWorkspacePackageSteps(workspacePackage, detectionResult.nodeWorkspace!.manager));
+      } else {
+        steps.push(...(runners.length > 0
+          ? this.createTestRunnerSteps(runners, runnerMatrix)
+          : this.createMakeSteps(detectionResult, 'test') || this.createTestSteps(primaryLanguage.name, detectionResult, 'unit')));
+      }
+      steps.push(...this.getInjectedSteps(options, 'after-test'));
     }
 
+    // Artifact names must be unique across the jobs and matrix entries of a run
+    const artifactPrefix = runnerMatrix
+      ? 'test-results-${{ matrix.test-runner }}'
+      : name === 'unit-tests' ? 'test-results' : `test-results-${name.slice('unit-tests-'.length)}`;
+
     // Add test results upload
     steps.push({
       name: 'Upload test results',
       uses: 'actions/upload-artifact@v4',
       with: {
-        name: 'test-results-${{ matrix.version || \'default\' }}',
+        name: `${artifactPrefix}-\${{ matrix.version || 'default' }}`,
         path: this.getTestResultPaths(detectionResult),
         'retention-days': 30
       },
@@ -239,25 +3099,102 @@ export class CIWorkflowGenerator {
     });
 
     const job: JobTemplate = {
-      name: 'unit-tests',
+      name,
       runsOn: 'ubuntu-latest',
       steps,
       needs: ['build']
     };
 
-    if (strategy) {
+    if (runnerMatrix) {
+      const testRunner = runners.map(runner => runner.name);
+      job.strategy = strategy
+        ? { ...strategy, matrix: { ...strategy.matrix, 'test-runner': testRunner } }
+        : { matrix: { 'test-runner': testRunner }, failFast: false };
+    } else if (strategy) {
       job.strategy = strategy;
     }
 
+    // Operating systems asked for replace those the build constraints target
+    if (options.testOS?.length && !options.runnerLabels && (!options.provider || options.provider === Provider.GitHubActions)) {
+      this.applyOSMatrix(job, options.testOS);
+    } else {
+      this.applyGoPlatformMatrix(job, primaryLanguage?.name, detectionResult, options);
+      this.applySwiftPlatforms(job, primaryLanguage?.name, detectionResult, options);
+    }
+    this.applyDotnetFrameworkMatrix(job, primaryLanguage?.name, detectionResult);
+    // Root package features are unknown to the other crates
+    if (!crate || crate.path === '.') {
+      this.applyCargoFeatureMatrix(job, primaryLanguage?.name, detectionResult, options);
+    }
+    this.applyCompilerMatrix(job, primaryLanguage?.name, detectionResult, options);
+
     return job;
   }
 
   /**
-   * Create integration test job
+   * Member crates that get their own test job, or undefined when the workspace is tested as a whole
    */
-  private createIntegrationTestJob(
+  private getPerCrateMembers(
     detectionResult: DetectionResult,
     options: GenerationOptions
+  ): Array<{ name: string; path: string }> | undefined {
+    const primaryLanguage = detectionResult.languages.find(l => l.primary)?.name.toLowerCase();
+    const members = detectionResult.cargoWorkspace?.members || [];
+    if (options.cargoWorkspaceLayout !== 'per-crate' || primaryLanguage !== 'rust' || members.length === 0) {
+      return undefined;
+    }
+    return members;
+  }
+
+  /**
+   * npm or pnpm workspace packages that get their own test job, or undefined when the workspace
+   * is tested as a whole
+   */
+  private getPerPackageMembers(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Array<{ name: string; path: string; scripts: string[] }> | undefined {
+    const family = LANGUAGE_FAMILIES[detectionResult.languages.find(l => l.primary)?.name.toLowerCase() || ''];
+    const members = detectionResult.nodeWorkspace?.members || [];
+    if (options.nodeWorkspaceLayout !== 'per-package' || family !== 'node' || members.length === 0) {
+      return undefined;
+    }
+    return members;
+  }
+
+  /**
+   * Build and test one workspace package with the scripts it has, from the root install
+   */
+  private createWorkspacePackageSteps(member: { name: string; scripts: string[] }, manager: 'npm' | 'pnpm'): StepTemplate[] {
+    const run = (script: string) => manager === 'pnpm' ? `pnpm --filter ${member.name} ${script}` : `npm run ${script} -w ${member.name}`;
+    return ['build', 'test']
+      .filter(script => member.scripts.includes(script))
+      .map(script => ({ name: `${script === 'build' ? 'Build' : 'Test'} ${member.name}`, run: run(script), source: `${member.name} package.json scripts.${script}` }));
+  }
+
+  private getCrateSlug(name: string): string {
+    return name.toLowerCase().replace(/[^a-z0-9]+/g, '-').replace(/^-+|-+$/g, '');
+  }
+
+  /**
+   * Custom build tags of a Go project's test files, which the untagged unit tests skip
+   */
+  private getGoTestTags(detectionResult: DetectionResult): string[] {
+    const primaryLanguage = detectionResult.languages.find(l => l.primary)?.name.toLowerCase();
+    if (LANGUAGE_FAMILIES[primaryLanguage || ''] !== 'go') {
+      return [];
+    }
+    return detectionResult.buildConstraints?.testTags || [];
+  }
+
+  /**
+   * Create integration test job. Given a Go build tag, the job runs the tests behind that tag.
+   */
+  private createIntegrationTestJob(
+    detectionResult: DetectionResult,
+    options: GenerationOptions,
+    goTestTag?: string,
+    name: string = 'integration-tests'
   ): JobTemplate {
     const primaryLanguage = detectionResult.languages.find(l => l.primary);
     
@@ -268,8 +3205,9 @@ export class CIWorkflowGenerator {
       }
     ];
 
-    // Add service dependencies if needed
-    const services = this.detectRequiredServices(detectionResult);
+    // Add service dependencies if needed; services the README requires run as service containers instead
+    const services = this.detectRequiredServices(detectionResult)
+      .filter(service => !detectionResult.services?.some(required => required.name === service));
     
     if (primaryLanguage) {
       steps.push(...this.createLanguageSetupSteps(primaryLanguage.name, detectionResult, true));
@@ -279,15 +3217,18 @@ export class CIWorkflowGenerator {
         steps.push(...this.createServiceSetupSteps(services));
       }
       
-      steps.push(...this.createTestSteps(primaryLanguage.name, detectionResult, 'integration'));
+      steps.push(...(goTestTag
+        ? [{ name: `Run ${goTestTag} tests`, run: `go test -v -tags=${goTestTag} ./...`, source: '_test.go build tags' }]
+        : this.createTestSteps(primaryLanguage.name, detectionResult, 'integration')));
+      steps.push(...this.getInjectedSteps(options, 'after-test'));
     }
 
     return {
-      name: 'integration-tests',
+      name,
       runsOn: 'ubuntu-latest',
       steps,
       needs: ['build'],
-      if: "github.event_name != 'schedule'"
+      if: NOT_SCHEDULED
     };
   }
 
@@ -318,6 +3259,7 @@ export class CIWorkflowGenerator {
       });
       
       steps.push(...this.createTestSteps(primaryLanguage.name, detectionResult, 'e2e'));
+      steps.push(...this.getInjectedSteps(options, 'after-test'));
     }
 
     return {
@@ -359,11 +3301,7 @@ export class CIWorkflowGenerator {
     return {
       name: 'security-scan',
       runsOn: 'ubuntu-latest',
-      steps,
-      permissions: {
-        contents: 'read',
-        securityEvents: 'write'
-      }
+      steps
     };
   }
 
@@ -373,41 +3311,115 @@ export class CIWorkflowGenerator {
     detectionResult: DetectionResult,
     withCache: boolean = false
   ): StepTemplate[] {
+    let steps: StepTemplate[];
     switch (language.toLowerCase()) {
       case 'javascript':
       case 'typescript':
-        return this.createNodeJSSetupSteps(detectionResult, withCache);
+        steps = this.createNodeJSSetupSteps(detectionResult);
+        break;
       case 'python':
-        return this.createPythonSetupSteps(detectionResult, withCache);
+        steps = this.createPythonSetupSteps(detectionResult);
+        break;
       case 'java':
-        return this.createJavaSetupSteps(detectionResult, withCache);
+        steps = this.createJavaSetupSteps(detectionResult);
+        break;
       case 'rust':
-        return this.createRustSetupSteps(detectionResult, withCache);
+        steps = this.createRustSetupSteps(detectionResult);
+        break;
       case 'go':
-        return this.createGoSetupSteps(detectionResult, withCache);
+        steps = this.createGoSetupSteps(detectionResult);
+        break;
+      case 'elixir':
+        steps = this.createElixirSetupSteps(detectionResult);
+        break;
+      case 'ruby':
+        steps = this.createRubySetupSteps(detectionResult);
+        break;
+      case 'swift':
+        steps = this.createSwiftSetupSteps(detectionResult);
+        break;
+      case 'c#':
+        steps = this.createDotnetSetupSteps(detectionResult);
+        break;
+      case 'dart':
+        steps = this.createDartSetupSteps(detectionResult);
+        break;
+      case 'haskell':
+        steps = this.createHaskellSetupSteps(detectionResult);
+        break;
+      case 'c/c++':
+        steps = this.createCMakeSetupSteps(detectionResult);
+        break;
       default:
         return [];
     }
+
+    const cache = withCache ? this.resolveDependencyCache(language, detectionResult) : undefined;
+    if (cache) {
+      // Restore before installing so the install step can reuse the cached downloads
+      const installIndex = steps.findIndex(step => step.name === 'Install dependencies');
+      steps.splice(installIndex === -1 ? steps.length : installIndex, 0,
+        ...this.cacheStrategyGenerator.createDependencyCacheSteps(cache));
+    }
+
+    return steps;
   }
 
-  private createNodeJSSetupSteps(
-    detectionResult: DetectionResult,
-    withCache: boolean
-  ): StepTemplate[] {
-    const packageManager = detectionResult.packageManagers.find(pm => 
-      ['npm', 'yarn', 'pnpm'].includes(pm.name)
-    )?.name || 'npm';
+  /**
+   * Resolve the dependency cache for a language from its package manager or build tool
+   */
+  private resolveDependencyCache(language: string, detectionResult: DetectionResult): DependencyCacheStrategy | undefined {
+    if (language.toLowerCase() === 'java' && detectionResult.javaBuild?.buildSystem === 'gradle') {
+      return undefined;
+    }
 
-    const steps: StepTemplate[] = [
-      {
-        name: 'Setup Node.js',
-        uses: 'actions/setup-node@v4',
-        with: {
-          'node-version': '${{ matrix.node-version || \'18\' }}',
-          cache: withCache ? packageManager : undefined
-        }
+    const packageManager = language.toLowerCase() === 'java'
+      ? detectionResult.javaBuild?.buildSystem || detectionResult.buildTools.find(bt => ['maven', 'gradle'].includes(bt.name))?.name
+      : language.toLowerCase() === 'haskell'
+        ? this.getHaskellTool(detectionResult)
+        : detectionResult.packageManagers.find(pm =>
+          ['npm', 'yarn', 'pnpm', 'bun', 'pip', 'poetry', 'pipenv'].includes(pm.name)
+        )?.name;
+
+    const cache = this.cacheStrategyGenerator.resolveDependencyCache(language, packageManager, detectionResult.lockFiles);
+    // The build directory is what a CMake project caches, wherever its preset puts it
+    if (cache?.tool === 'cmake' && detectionResult.cmakeProject) {
+      return { ...cache, strategy: { ...cache.strategy, paths: [detectionResult.cmakeProject.binaryDir] } };
+    }
+    // NuGet lockfiles pin the packages more closely than the project files list them
+    if (cache?.tool === 'nuget' && detectionResult.dotnetProject?.lockFile) {
+      return { ...cache, strategy: { ...cache.strategy, key: "nuget-${{ runner.os }}-${{ hashFiles('**/packages.lock.json') }}" } };
+    }
+    return cache;
+  }
+
+  private createNodeJSSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const detected = detectionResult.packageManagers.find(pm => 
+      ['npm', 'yarn', 'pnpm', 'bun'].includes(pm.name)
+    );
+    const packageManager = detected?.name || 'npm';
+    const steps: StepTemplate[] = [];
+
+    // A pinned version comes from the packageManager field, which the action reads itself
+    if (packageManager === 'pnpm') {
+      const pnpmStep: StepTemplate = {
+        name: 'Setup pnpm',
+        uses: 'pnpm/action-setup@v4'
+      };
+      if (!detected?.version) {
+        pnpmStep.with = { version: 'latest' };
       }
-    ];
+      steps.push(pnpmStep);
+    }
+
+    steps.push({
+      name: 'Setup Node.js',
+      uses: 'actions/setup-node@v4',
+      with: {
+        'node-version': `\${{ matrix.node-version || '${this.getDefaultVersion(detectionResult, 'javascript')}' }}`
+      },
+      ...this.getVersionSource(detectionResult, 'javascript')
+    });
 
     // Add package manager specific installation
     switch (packageManager) {
@@ -419,15 +3431,19 @@ export class CIWorkflowGenerator {
         break;
       case 'pnpm':
         steps.push({
-          name: 'Setup pnpm',
-          uses: 'pnpm/action-setup@v2',
-          with: {
-            version: 'latest'
-          }
+          name: 'Install dependencies',
+          run: 'pnpm install --frozen-lockfile'
+        });
+        break;
+      case 'bun':
+        steps.push({
+          name: 'Setup Bun',
+          uses: 'oven-sh/setup-bun@v2',
+          with: { 'bun-version': detected?.version || 'latest' }
         });
         steps.push({
           name: 'Install dependencies',
-          run: 'pnpm install --frozen-lockfile'
+          run: 'bun install --frozen-lockfile'
         });
         break;
       default:
@@ -440,10 +3456,7 @@ export class CIWorkflowGenerator {
     return steps;
   }
 
-  private createPythonSetupSteps(
-    detectionResult: DetectionResult,
-    withCache: boolean
-  ): StepTemplate[] {
+  private createPythonSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
     const packageManager = detectionResult.packageManagers.find(pm => 
       ['pip', 'poetry', 'pipenv'].includes(pm.name)
     )?.name || 'pip';
@@ -453,9 +3466,9 @@ export class CIWorkflowGenerator {
         name: 'Setup Python',
         uses: 'actions/setup-python@v5',
         with: {
-          'python-version': '${{ matrix.python-version || \'3.11\' }}',
-          cache: withCache ? packageManager : undefined
-        }
+          'python-version': `\${{ matrix.python-version || '${this.getDefaultVersion(detectionResult, 'python')}' }}`
+        },
+        ...this.getVersionSource(detectionResult, 'python')
       }
     ];
 
@@ -484,39 +3497,64 @@ export class CIWorkflowGenerator {
       default:
         steps.push({
           name: 'Install dependencies',
-          run: 'pip install -r requirements.txt'
+          run: this.getPipInstallCommand(detectionResult.pythonLayout)
         });
     }
 
     return steps;
   }
 
-  private createJavaSetupSteps(
-    detectionResult: DetectionResult,
-    withCache: boolean
-  ): StepTemplate[] {
-    const buildTool = detectionResult.buildTools.find(bt => 
-      ['maven', 'gradle'].includes(bt.name)
-    )?.name || 'maven';
+  /**
+   * Requirements plus an editable install of the project itself, so its packages import the
+   * same way in CI as they do locally. Poetry and pipenv already install the root project.
+   */
+  private getPipInstallCommand(pythonLayout?: PythonLayoutDetection): string {
+    if (!pythonLayout || (!pythonLayout.requirementsFile && !pythonLayout.installable)) {
+      return 'pip install -r requirements.txt';
+    }
 
     return [
+      ...(pythonLayout.requirementsFile ? ['pip install -r requirements.txt'] : []),
+      ...(pythonLayout.installable ? ['pip install -e .'] : [])
+    ].join('\n');
+  }
+
+  private createJavaSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const javaBuild = detectionResult.javaBuild;
+    const steps: StepTemplate[] = [
       {
         name: 'Setup JDK',
         uses: 'actions/setup-java@v4',
         with: {
-          'java-version': '${{ matrix.java-version || \'17\' }}',
-          distribution: 'temurin',
-          cache: withCache ? buildTool : undefined
+          'java-version': `\${{ matrix.java-version || '${javaBuild?.javaVersion || '17'}' }}`,
+          distribution: 'temurin'
         }
       }
     ];
+
+    // setup-gradle caches dependencies and the wrapper distribution itself
+    if (javaBuild?.buildSystem === 'gradle') {
+      steps.push({
+        name: 'Setup Gradle',
+        uses: 'gradle/actions/setup-gradle@v4'
+      });
+    }
+
+    return steps;
   }
 
-  private createRustSetupSteps(
-    detectionResult: DetectionResult,
-    withCache: boolean
-  ): StepTemplate[] {
-    const steps: StepTemplate[] = [
+  /**
+   * Command prefix invoking the detected Java build tool: its wrapper when committed, Maven in batch mode
+   */
+  private getJavaBuildCommand(javaBuild: JavaBuildDetection): string {
+    if (javaBuild.buildSystem === 'gradle') {
+      return javaBuild.wrapper ? './gradlew' : 'gradle';
+    }
+    return javaBuild.wrapper ? './mvnw -B' : 'mvn -B';
+  }
+
+  private createRustSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    return [
       {
         name: 'Setup Rust',
         uses: 'dtolnay/rust-toolchain@stable',
@@ -525,38 +3563,348 @@ export class CIWorkflowGenerator {
         }
       }
     ];
+  }
+
+  private createGoSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    return [
+      {
+        name: 'Setup Go',
+        uses: 'actions/setup-go@v5',
+        with: {
+          'go-version': `\${{ matrix.go-version || '${this.getDefaultVersion(detectionResult, 'go')}' }}`,
+          // Module caching is handled by the actions/cache step
+          cache: false
+        },
+        ...this.getVersionSource(detectionResult, 'go')
+      }
+    ];
+  }
+
+  private createElixirSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const elixirProject = detectionResult.elixirProject;
+    return [
+      {
+        name: 'Setup Elixir',
+        uses: 'erlef/setup-beam@v1',
+        with: {
+          'elixir-version': elixirProject?.elixirVersion || DEFAULT_ELIXIR_VERSION,
+          'otp-version': elixirProject?.otpVersion || DEFAULT_OTP_VERSION
+        }
+      },
+      {
+        name: 'Install dependencies',
+        run: 'mix deps.get'
+      }
+    ];
+  }
+
+  /**
+   * Set up Ruby with bundler-cache, which runs bundle install and caches the installed gems
+   */
+  private createRubySetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    return [
+      {
+        name: 'Setup Ruby',
+        uses: 'ruby/setup-ruby@v1',
+        with: {
+          'ruby-version': detectionResult.rubyProject?.rubyVersion || DEFAULT_RUBY_VERSION,
+          'bundler-cache': true
+        }
+      }
+    ];
+  }
+
+  /**
+   * Set up the Swift release the tools version of Package.swift names. The swift container of a
+   * Linux matrix entry comes with it.
+   */
+  private createSwiftSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    return [
+      {
+        name: 'Setup Swift',
+        uses: 'swift-actions/setup-swift@v2',
+        with: {
+          'swift-version': this.getSwiftVersion(detectionResult)
+        },
+        ...(detectionResult.swiftPackage?.toolsVersion && { source: 'Package.swift' })
+      }
+    ];
+  }
+
+  /**
+   * Set up the SDK global.json pins and the release lines of the runnable target frameworks,
+   * so tests can run on each of them
+   */
+  private createDotnetSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const dotnetProject = detectionResult.dotnetProject;
+    const releases = [...new Set(this.getDotnetFrameworks(detectionResult)
+      .map(framework => `${framework.match(RUNNABLE_DOTNET_FRAMEWORK)![1]}.x`))]
+      .sort((a, b) => parseFloat(a) - parseFloat(b));
+    const versions = releases.length > 0 || dotnetProject?.sdkVersion ? releases : [DEFAULT_DOTNET_VERSION];
+
+    return [
+      {
+        name: 'Setup .NET',
+        uses: 'actions/setup-dotnet@v4',
+        with: {
+          ...(versions.length > 0 && { 'dotnet-version': versions.join('\n') }),
+          ...(dotnetProject?.sdkVersion && { 'global-json-file': 'global.json' })
+        },
+        ...(dotnetProject && (dotnetProject.sdkVersion || releases.length > 0) && {
+          source: dotnetProject.sdkVersion ? 'global.json' : dotnetProject.projects[0]
+        })
+      }
+    ];
+  }
+
+  /**
+   * Set up Flutter for a Flutter app, or the Dart SDK for a package, at the lowest release the
+   * environment section of pubspec.yaml allows, then get the packages
+   */
+  private createDartSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const dartProject = detectionResult.dartProject;
+    const tool = this.getDartTool(detectionResult);
+    if (tool === 'flutter') {
+      const flutterVersion = this.getLowerBound(dartProject?.flutterConstraint);
+      return [
+        {
+          name: 'Setup Flutter',
+          uses: 'subosito/flutter-action@v2',
+          with: {
+            channel: 'stable',
+            ...(flutterVersion && { 'flutter-version': flutterVersion })
+          },
+          ...(flutterVersion && { source: 'pubspec.yaml' })
+        },
+        { name: 'Install dependencies', run: 'flutter pub get' }
+      ];
+    }
+
+    const sdk = this.getLowerBound(dartProject?.sdkConstraint);
+    return [
+      {
+        name: 'Setup Dart',
+        uses: 'dart-lang/setup-dart@v1',
+        with: {
+          sdk: sdk || 'stable'
+        },
+        ...(sdk && { source: 'pubspec.yaml' })
+      },
+      { name: 'Install dependencies', run: 'dart pub get' }
+    ];
+  }
+
+  /**
+   * Set up Stack, which installs the GHC the snapshot of stack.yaml names itself, or GHC and Cabal
+   * at the newest release the tested-with field lists, then update the Hackage index for Cabal
+   */
+  private createHaskellSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const haskellProject = detectionResult.haskellProject;
+    if (this.getHaskellTool(detectionResult) === 'stack') {
+      return [
+        {
+          name: 'Setup Stack',
+          uses: 'haskell-actions/setup@v2',
+          with: {
+            'enable-stack': true,
+            'stack-no-global': true,
+            'stack-version': 'latest'
+          },
+          ...(haskellProject?.resolver && { source: 'stack.yaml' })
+        }
+      ];
+    }
+
+    return [
+      {
+        name: 'Setup Haskell',
+        uses: 'haskell-actions/setup@v2',
+        with: {
+          'ghc-version': haskellProject?.ghcVersion || DEFAULT_GHC_VERSION,
+          'cabal-version': 'latest'
+        },
+        ...(haskellProject?.ghcVersion && { source: haskellProject.cabalFiles.join(', ') })
+      },
+      { name: 'Update package index', run: 'cabal update' }
+    ];
+  }
+
+  /**
+   * Tool a Haskell project builds with: stack when it has a stack.yaml, cabal otherwise
+   */
+  private getHaskellTool(detectionResult: DetectionResult): 'stack' | 'cabal' {
+    return detectionResult.haskellProject?.tool
+      || (detectionResult.buildTools.some(bt => bt.name === 'cabal') ? 'cabal' : 'stack');
+  }
+
+  /**
+   * Lowest release a pub version constraint allows (^3.3.0, >=3.0.0 <4.0.0 or 3.3.0)
+   */
+  private getLowerBound(constraint: string | undefined): string | undefined {
+    return constraint?.match(/^\s*(?:\^|>=)?\s*v?(\d+\.\d+\.\d+)/)?.[1];
+  }
+
+  /**
+   * Tool a Dart project is driven with: flutter for a Flutter app, dart otherwise
+   */
+  private getDartTool(detectionResult: DetectionResult): 'flutter' | 'dart' {
+    return detectionResult.dartProject?.flutter ? 'flutter' : 'dart';
+  }
+
+  /**
+   * Target frameworks of a .NET project that tests can run on
+   */
+  private getDotnetFrameworks(detectionResult: DetectionResult): string[] {
+    return (detectionResult.dotnetProject?.targetFrameworks || []).filter(framework => RUNNABLE_DOTNET_FRAMEWORK.test(framework));
+  }
+
+  /**
+   * Restore and build a .NET project, at the solution level when it has one
+   */
+  private createDotnetBuildSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const dotnetProject = detectionResult.dotnetProject;
+    const target = dotnetProject?.solution ? ` ${dotnetProject.solution}` : '';
+    const source = dotnetProject ? { source: dotnetProject.solution || dotnetProject.projects[0] || 'global.json' } : {};
+    return [
+      { name: 'Restore dependencies', run: `dotnet restore${target}`, ...source },
+      { name: 'Build', run: `dotnet build${target} --no-restore`, ...source }
+    ];
+  }
+
+  /**
+   * Major and minor Swift release of a package's tools version
+   */
+  private getSwiftVersion(detectionResult: DetectionResult): string {
+    return detectionResult.swiftPackage?.toolsVersion?.split('.').slice(0, 2).join('.') || DEFAULT_SWIFT_VERSION;
+  }
+
+  /**
+   * Set up the CMake release the project needs: the newest of its cmake_minimum_required, the
+   * one its presets schema needs and the one `ctest --test-dir` needs. Without a minimum the
+   * runner's CMake is used.
+   */
+  private createCMakeSetupSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const cmakeProject = detectionResult.cmakeProject;
+    if (!cmakeProject?.minimumVersion) {
+      return [];
+    }
+
+    const release = (version: string) => version.split('.').slice(0, 2).map(Number);
+    const newer = (a: string, b: string) => {
+      const [aMajor, aMinor] = release(a);
+      const [bMajor, bMinor] = release(b);
+      return aMajor! !== bMajor! ? aMajor! > bMajor! : aMinor! > bMinor!;
+    };
+    const needed = [
+      cmakeProject.minimumVersion,
+      cmakeProject.presets?.requiredVersion,
+      cmakeProject.tests && !cmakeProject.presets?.test ? '3.20' : undefined
+    ].filter((version): version is string => Boolean(version))
+      .reduce((newest, version) => newer(version, newest) ? version : newest, MIN_CMAKE_VERSION);
+
+    return [
+      {
+        name: 'Setup CMake',
+        uses: 'jwlawson/actions-setup-cmake@v2',
+        with: {
+          'cmake-version': `${release(needed).join('.')}.x`
+        },
+        source: 'CMakeLists.txt'
+      }
+    ];
+  }
 
-    if (withCache) {
-      steps.push({
-        name: 'Cache Rust dependencies',
-        uses: 'actions/cache@v4',
-        with: {
-          path: '~/.cargo\ntarget/',
-          key: '${{ runner.os }}-cargo-${{ hashFiles(\'**/Cargo.lock\') }}'
+  /**
+   * Configure and build a CMake project, through its presets when it has a CMakePresets.json.
+   * A preset writing somewhere the generator cannot tell is pointed at build/.
+   */
+  private createCMakeBuildSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const cmakeProject = detectionResult.cmakeProject;
+    const presets = cmakeProject?.presets;
+    const binaryDir = cmakeProject?.binaryDir || 'build';
+
+    if (presets) {
+      return [
+        {
+          name: 'Configure',
+          run: `cmake --preset ${presets.configure}${presets.binaryDir ? '' : ` -B ${binaryDir}`}`,
+          source: 'CMakePresets.json'
+        },
+        {
+          name: 'Build',
+          run: presets.build ? `cmake --build --preset ${presets.build}` : `cmake --build ${binaryDir}`,
+          source: 'CMakePresets.json'
         }
-      });
+      ];
     }
 
-    return steps;
+    return [
+      {
+        name: 'Configure',
+        run: `cmake -B ${binaryDir}${cmakeProject?.cxxStandard ? ` -DCMAKE_CXX_STANDARD=${cmakeProject.cxxStandard}` : ''}`,
+        source: 'CMakeLists.txt'
+      },
+      {
+        name: 'Build',
+        run: `cmake --build ${binaryDir}`,
+        source: 'CMakeLists.txt'
+      }
+    ];
   }
 
-  private createGoSetupSteps(
-    detectionResult: DetectionResult,
-    withCache: boolean
-  ): StepTemplate[] {
+  /**
+   * Configure and build a CMake project, then run its tests with ctest, through the test preset
+   * when there is one
+   */
+  private createCTestSteps(detectionResult: DetectionResult): StepTemplate[] {
+    const cmakeProject = detectionResult.cmakeProject;
+    const testPreset = cmakeProject?.presets?.test;
     return [
+      ...this.createCMakeBuildSteps(detectionResult),
       {
-        name: 'Setup Go',
-        uses: 'actions/setup-go@v5',
-        with: {
-          'go-version': '${{ matrix.go-version || \'1.21\' }}',
-          cache: withCache
-        }
+        name: 'Run unit tests',
+        run: testPreset
+          ? `ctest --preset ${testPreset} --output-on-failure`
+          : `ctest --test-dir ${cmakeProject?.binaryDir || 'build'} --output-on-failure`,
+        ...(cmakeProject && { source: testPreset ? 'CMakePresets.json' : 'CMakeLists.txt' })
       }
     ];
   }
 
+  /**
+   * Lint steps for a language: the linters the project configured, one after another, else the
+   * language's usual checks. Rust always gets clippy, which is what the detector reports for it.
+   * golangci-lint and ESLint leave out the generated code found.
+   */
   private createLintSteps(language: string, detectionResult: DetectionResult): StepTemplate[] {
+    const family = LANGUAGE_FAMILIES[language.toLowerCase()];
+    const linters = (detectionResult.linters || []).filter(linter => LANGUAGE_FAMILIES[linter.language.toLowerCase()] === family);
+    // Lint and type check scripts are how the project checks itself, whatever config files it has
+    if (family === 'node' && detectionResult.packageScripts) {
+      const lint = this.findPackageScript(detectionResult, 'lint');
+      const typecheck = this.findPackageScript(detectionResult, 'typecheck');
+      const steps: StepTemplate[] = [
+        ...(lint ? [{ name: 'Run lint', run: `npm run ${lint}`, continueOnError: true, source: `package.json scripts.${lint}` }] : []),
+        ...(typecheck ? [{ name: 'Type check', run: `npm run ${typecheck}`, source: `package.json scripts.${typecheck}` }] : [])
+      ];
+      if (steps.length > 0 || linters.length === 0) {
+        return steps;
+      }
+    }
+    if (linters.length > 0 && family !== 'rust') {
+      // Poetry and pipenv install the project's own linters into their virtualenv
+      const packageManager = detectionResult.packageManagers.find(pm => ['poetry', 'pipenv'].includes(pm.name))?.name;
+      const virtualenv = family === 'python' && packageManager ? `${packageManager} run ` : '';
+      return linters.flatMap(linter => {
+        const source = linter.source ? { source: linter.source } : {};
+        const excludes = this.getLintExcludes(linter.name, detectionResult.generatedCode).map(flag => ` ${flag}`).join('');
+        return [
+          ...(linter.installed ? [] : [{ name: `Install ${linter.name}`, run: LINTER_INSTALL_COMMANDS[linter.name], ...source }]),
+          { name: `Run ${linter.name}`, run: `${linter.installed ? virtualenv : ''}${linter.command}${excludes}`, ...source }
+        ];
+      });
+    }
+
     switch (language.toLowerCase()) {
       case 'javascript':
       case 'typescript':
@@ -581,27 +3929,97 @@ export class CIWorkflowGenerator {
           }
         ];
       case 'rust':
+        return detectionResult.cargoWorkspace
+          ? [
+            {
+              name: 'Run clippy',
+              run: 'cargo clippy --workspace --all-targets -- -D warnings'
+            },
+            {
+              name: 'Check formatting',
+              run: 'cargo fmt --all --check'
+            }
+          ]
+          : [
+            {
+              name: 'Run clippy',
+              run: 'cargo clippy -- -D warnings'
+            },
+            {
+              name: 'Check formatting',
+              run: 'cargo fmt --check'
+            }
+          ];
+      case 'go': {
+        const excludes = this.getLintExcludes('golangci-lint', detectionResult.generatedCode);
         return [
           {
-            name: 'Run clippy',
-            run: 'cargo clippy -- -D warnings'
+            name: 'Run go vet',
+            run: 'go vet ./...'
           },
           {
-            name: 'Check formatting',
-            run: 'cargo fmt --check'
+            name: 'Run golangci-lint',
+            uses: 'golangci/golangci-lint-action@v6',
+            ...(excludes.length > 0 && { with: { args: excludes.join(' ') } })
           }
         ];
-      case 'go':
+      }
+      case 'elixir':
         return [
           {
-            name: 'Run go vet',
-            run: 'go vet ./...'
-          },
+            name: 'Check formatting',
+            run: 'mix format --check-formatted'
+          }
+        ];
+      case 'ruby':
+        return detectionResult.rubyProject?.rubocop
+          ? [
+            {
+              name: 'Run rubocop',
+              run: 'bundle exec rubocop',
+              source: 'Gemfile'
+            }
+          ]
+          : [];
+      case 'dart':
+        return [
           {
-            name: 'Run golangci-lint',
-            uses: 'golangci/golangci-lint-action@v4'
+            name: 'Analyze',
+            run: `${this.getDartTool(detectionResult)} analyze`,
+            ...(detectionResult.dartProject && { source: 'pubspec.yaml' })
           }
         ];
+      case 'haskell':
+        // HLint reads its hints from .hlint.yaml
+        return detectionResult.haskellProject?.hlint
+          ? [
+            { name: 'Setup HLint', uses: 'haskell-actions/hlint-setup@v2', source: '.hlint.yaml' },
+            { name: 'Run HLint', run: 'hlint .', source: '.hlint.yaml' }
+          ]
+          : [];
+      case 'c/c++':
+        return detectionResult.cmakeProject?.clangFormat
+          ? [
+            {
+              name: 'Check formatting',
+              run: `git ls-files ${CLANG_FORMAT_SOURCES.map(glob => `'${glob}'`).join(' ')} | xargs clang-format --dry-run --Werror`,
+              source: '.clang-format'
+            }
+          ]
+          : [];
+      case 'shell': {
+        // shellcheck comes with the hosted runners; shfmt is installed when .editorconfig configures it
+        const scripts = detectionResult.shellProject?.scripts.join(' ');
+        const shfmt = detectionResult.shellProject?.shfmt;
+        return [
+          ...(shfmt ? [{ name: 'Install shfmt', run: 'sudo apt-get update && sudo apt-get install -y shfmt', source: '.editorconfig' }] : []),
+          {
+            name: 'Run shellcheck',
+            run: scripts ? `shellcheck ${scripts}` : "git ls-files '*.sh' '*.bash' | xargs shellcheck"
+          },
+          ...(shfmt ? [{ name: 'Check formatting', run: `shfmt -d ${scripts}`, source: '.editorconfig' }] : [])
+        ];
+      }
       default:
         return [];
     }
@@ -611,6 +4029,19 @@ export class CIWorkflowGenerator {
     switch (language.toLowerCase()) {
       case 'javascript':
       case 'typescript':
+        // Workspace packages share the root install; packages without a build script are skipped
+        if (detectionResult.nodeWorkspace) {
+          return [
+            {
+              name: 'Build workspace packages',
+              run: detectionResult.nodeWorkspace.manager === 'pnpm' ? 'pnpm -r build' : 'npm run build --workspaces --if-present'
+            }
+          ];
+        }
+        if (detectionResult.packageScripts) {
+          const script = this.findPackageScript(detectionResult, 'build');
+          return script ? [{ name: 'Build project', run: `npm run ${script}`, source: `package.json scripts.${script}` }] : [];
+        }
         return [
           {
             name: 'Build project',
@@ -625,6 +4056,16 @@ export class CIWorkflowGenerator {
           }
         ];
       case 'java':
+        // A multi-module build runs from the aggregator at the root, which builds every module
+        if (detectionResult.javaBuild) {
+          const javaBuild = detectionResult.javaBuild;
+          return [
+            {
+              name: `Build with ${javaBuild.buildSystem === 'maven' ? 'Maven' : 'Gradle'}`,
+              run: `${this.getJavaBuildCommand(javaBuild)} ${javaBuild.buildSystem === 'maven' ? 'verify' : 'build'}`
+            }
+          ];
+        }
         const buildTool = detectionResult.buildTools.find(bt => 
           ['maven', 'gradle'].includes(bt.name)
         )?.name || 'maven';
@@ -638,7 +4079,7 @@ export class CIWorkflowGenerator {
         return [
           {
             name: 'Build with Cargo',
-            run: 'cargo build --release'
+            run: detectionResult.cargoWorkspace ? 'cargo build --workspace --release' : 'cargo build --release'
           }
         ];
       case 'go':
@@ -648,11 +4089,116 @@ export class CIWorkflowGenerator {
             run: 'go build -v ./...'
           }
         ];
+      case 'elixir':
+        return [
+          {
+            name: 'Compile',
+            run: 'mix compile --warnings-as-errors'
+          }
+        ];
+      case 'swift':
+        return [
+          {
+            name: 'Build',
+            run: 'swift build',
+            ...(detectionResult.swiftPackage && { source: 'Package.swift' })
+          }
+        ];
+      case 'ruby':
+        // Only a gem has something to build
+        return detectionResult.rubyProject?.gemspec
+          ? [
+            {
+              name: 'Build gem',
+              run: `gem build ${detectionResult.rubyProject.gemspec}`,
+              source: detectionResult.rubyProject.gemspec
+            }
+          ]
+          : [];
+      case 'haskell':
+        return [
+          {
+            name: 'Build',
+            run: this.getHaskellTool(detectionResult) === 'stack' ? 'stack build' : 'cabal build all'
+          }
+        ];
+      case 'c#':
+        return this.createDotnetBuildSteps(detectionResult);
+      case 'c/c++':
+        return this.createCMakeBuildSteps(detectionResult);
       default:
         return [];
     }
   }
 
+  /**
+   * Invoke the Makefile target for a step's intent; undefined when the Makefile has none
+   */
+  private createMakeSteps(detectionResult: DetectionResult, intent: keyof typeof MAKE_TARGET_INTENTS): StepTemplate[] | undefined {
+    const targets = detectionResult.makefile?.targets || [];
+    const target = MAKE_TARGET_INTENTS[intent].find(candidate => targets.includes(candidate));
+    return target ? [{ name: `Run make ${target}`, run: `make ${target}`, source: `Makefile target ${target}` }] : undefined;
+  }
+
+  /**
+   * First package.json script of the intent's list the project defines
+   */
+  private findPackageScript(detectionResult: DetectionResult, intent: keyof typeof PACKAGE_SCRIPT_INTENTS): string | undefined {
+    const scripts = detectionResult.packageScripts?.scripts || {};
+    return PACKAGE_SCRIPT_INTENTS[intent].find(candidate => candidate in scripts);
+  }
+
+  /**
+   * Whether a Node project read from disk defines none of the test scripts, so its default unit
+   * test job would fail. A Makefile test target or a workspace runs the tests instead.
+   */
+  private lacksPackageTestScript(detectionResult: DetectionResult): boolean {
+    const primaryLanguage = detectionResult.languages.find(l => l.primary);
+    return Boolean(primaryLanguage && LANGUAGE_FAMILIES[primaryLanguage.name.toLowerCase()] === 'node' &&
+      detectionResult.packageScripts && !detectionResult.nodeWorkspace &&
+      !this.createMakeSteps(detectionResult, 'test') && !this.findPackageScript(detectionResult, 'test'));
+  }
+
+  /**
+   * Install and invoke test runners; in a runner matrix each step only runs for its own runner
+   */
+  private createTestRunnerSteps(runners: TestRunnerDetection[], runnerMatrix: boolean): StepTemplate[] {
+    const steps: StepTemplate[] = [];
+
+    for (const runner of runners) {
+      const guard = runnerMatrix ? { if: `matrix.test-runner == '${runner.name}'` } : {};
+      const source = runner.source ? { source: runner.source } : {};
+      if (runner.setup?.length) {
+        steps.push({ name: `Install ${runner.name}`, run: runner.setup.join('\n'), ...guard, ...source });
+      }
+      steps.push({ name: runnerMatrix ? `Run ${runner.name} tests` : 'Run unit tests', run: runner.command, ...guard, ...source });
+    }
+
+    return steps;
+  }
+
+  /**
+   * Test runners of the primary language that get their own test invocation.
+   * Runners invoked by another are dropped; a lone runner the default test steps
+   * already cover leaves those steps in place.
+   */
+  private getTestRunners(detectionResult: DetectionResult): TestRunnerDetection[] {
+    const primaryLanguage = detectionResult.languages.find(l => l.primary)?.name.toLowerCase();
+    const languageFamily = (language: string) => language.toLowerCase() === 'typescript' ? 'javascript' : language.toLowerCase();
+    const runners = (detectionResult.testRunners || []).filter(runner =>
+      !runner.suppressedBy && primaryLanguage !== undefined && languageFamily(runner.language) === languageFamily(primaryLanguage)
+    );
+
+    if (runners.length === 1 && DEFAULT_TEST_RUNNERS.has(runners[0]!.name)) {
+      return [];
+    }
+    return runners;
+  }
+
+  private getTestRunnerSlug(runner: TestRunnerDetection): string {
+    return runner.name.toLowerCase().replace(/[^a-z0-9]+/g, '-');
+  }
+
   private createTestSteps(
     language: string,
     detectionResult: DetectionResult,
@@ -661,27 +4207,73 @@ export class CIWorkflowGenerator {
     switch (language.toLowerCase()) {
       case 'javascript':
       case 'typescript':
-        return this.createNodeJSTestSteps(testType);
+        return this.createNodeJSTestSteps(detectionResult, testType);
       case 'python':
-        return this.createPythonTestSteps(testType);
+        return this.createPythonTestSteps(detectionResult, testType);
       case 'java':
         return this.createJavaTestSteps(detectionResult, testType);
       case 'rust':
-        return this.createRustTestSteps(testType);
+        return this.createRustTestSteps(detectionResult, testType);
       case 'go':
         return this.createGoTestSteps(testType);
+      case 'elixir':
+        return testType === 'unit' ? [{ name: 'Run unit tests', run: 'mix test' }] : [];
+      case 'ruby':
+        return testType === 'unit'
+          ? [{ name: 'Run unit tests', run: detectionResult.rubyProject?.rspec ? 'bundle exec rspec' : 'bundle exec rake' }]
+          : [];
+      case 'swift':
+        // A package of executables alone may declare no test targets for swift test to run
+        return testType === 'unit' && (!detectionResult.swiftPackage || detectionResult.swiftPackage.testTargets.length > 0)
+          ? [{ name: 'Run unit tests', run: 'swift test' }]
+          : [];
+      case 'dart':
+        // Without a test directory there is nothing for dart test or flutter test to run
+        return testType === 'unit' && (!detectionResult.dartProject || detectionResult.dartProject.tests)
+          ? [{ name: 'Run unit tests', run: `${this.getDartTool(detectionResult)} test` }]
+          : [];
+      case 'haskell':
+        // A project of executables alone may declare no test suite
+        return testType === 'unit' && (!detectionResult.haskellProject || detectionResult.haskellProject.tests)
+          ? [{ name: 'Run unit tests', run: this.getHaskellTool(detectionResult) === 'stack' ? 'stack test' : 'cabal test all' }]
+          : [];
+      case 'c#': {
+        // dotnet test runs what the job's own build produced
+        const dotnetProject = detectionResult.dotnetProject;
+        return testType === 'unit' && (!dotnetProject || dotnetProject.testProjects.length > 0)
+          ? [...this.createDotnetBuildSteps(detectionResult), { name: 'Run unit tests', run: `dotnet test${dotnetProject?.solution ? ` ${dotnetProject.solution}` : ''} --no-build` }]
+          : [];
+      }
+      case 'c/c++':
+        return testType === 'unit' ? this.createCTestSteps(detectionResult) : [];
       default:
         return [];
     }
   }
 
-  private createNodeJSTestSteps(testType: 'unit' | 'integration' | 'e2e'): StepTemplate[] {
+  private createNodeJSTestSteps(detectionResult: DetectionResult, testType: 'unit' | 'integration' | 'e2e'): StepTemplate[] {
     switch (testType) {
       case 'unit':
+        if (detectionResult.nodeWorkspace) {
+          return [
+            {
+              name: 'Run unit tests',
+              run: detectionResult.nodeWorkspace.manager === 'pnpm' ? 'pnpm -r test' : 'npm test --workspaces --if-present'
+            }
+          ];
+        }
+        // A plain test script keeps collecting coverage; test:ci and test:unit are run as they are
+        if (detectionResult.packageScripts) {
+          const script = this.findPackageScript(detectionResult, 'test');
+          if (script !== 'test') {
+            return script ? [{ name: 'Run unit tests', run: `npm run ${script}`, source: `package.json scripts.${script}` }] : [];
+          }
+        }
         return [
           {
             name: 'Run unit tests',
-            run: 'npm test -- --coverage'
+            run: 'npm test -- --coverage',
+            ...(detectionResult.packageScripts && { source: 'package.json scripts.test' })
           }
         ];
       case 'integration':
@@ -701,79 +4293,118 @@ export class CIWorkflowGenerator {
     }
   }
 
-  private createPythonTestSteps(testType: 'unit' | 'integration' | 'e2e'): StepTemplate[] {
+  private createPythonTestSteps(
+    detectionResult: DetectionResult,
+    testType: 'unit' | 'integration' | 'e2e'
+  ): StepTemplate[] {
+    const pythonLayout = detectionResult.pythonLayout;
+    const pytest = this.getPytestInvocation(pythonLayout);
+    const env = pythonLayout?.layout === 'src' ? { env: { PYTHONPATH: 'src' } } : {};
+
     switch (testType) {
       case 'unit':
         return [
           {
             name: 'Run unit tests',
-            run: 'pytest tests/unit/ --cov=src --cov-report=xml'
+            run: `${pytest} tests/unit/ ${this.getPytestCoverageFlags(pythonLayout)} --cov-report=xml`,
+            ...env
           }
         ];
       case 'integration':
         return [
           {
             name: 'Run integration tests',
-            run: 'pytest tests/integration/'
+            run: `${pytest} tests/integration/`,
+            ...env
           }
         ];
       case 'e2e':
         return [
           {
             name: 'Run E2E tests',
-            run: 'pytest tests/e2e/'
+            run: `${pytest} tests/e2e/`,
+            ...env
           }
         ];
     }
   }
 
+  /**
+   * src layouts import from PYTHONPATH=src in importlib mode, so tests never pick up a stale
+   * copy from the checkout root. Flat layouts without an editable install, or with namespace
+   * packages (no __init__.py for pytest to anchor on), run through python -m so the root is on sys.path.
+   */
+  private getPytestInvocation(pythonLayout?: PythonLayoutDetection): string {
+    if (!pythonLayout) {
+      return 'pytest';
+    }
+    if (pythonLayout.layout === 'src') {
+      return 'pytest --import-mode=importlib';
+    }
+    return !pythonLayout.installable || pythonLayout.namespacePackages.length > 0 ? 'python -m pytest' : 'pytest';
+  }
+
+  private getPytestCoverageFlags(pythonLayout?: PythonLayoutDetection): string {
+    if (pythonLayout?.layout !== 'flat') {
+      return '--cov=src';
+    }
+    return pythonLayout.packages.length > 0
+      ? pythonLayout.packages.map(pkg => `--cov=${pkg}`).join(' ')
+      : '--cov=.';
+  }
+
   private createJavaTestSteps(
     detectionResult: DetectionResult,
     testType: 'unit' | 'integration' | 'e2e'
   ): StepTemplate[] {
-    const buildTool = detectionResult.buildTools.find(bt => 
+    const buildTool = detectionResult.javaBuild?.buildSystem || detectionResult.buildTools.find(bt => 
       ['maven', 'gradle'].includes(bt.name)
     )?.name || 'maven';
+    const command = detectionResult.javaBuild
+      ? this.getJavaBuildCommand(detectionResult.javaBuild)
+      : buildTool === 'maven' ? 'mvn' : './gradlew';
 
     switch (testType) {
       case 'unit':
         return [
           {
             name: 'Run unit tests',
-            run: buildTool === 'maven' ? 'mvn test' : './gradlew test'
+            run: `${command} test`
           }
         ];
       case 'integration':
         return [
           {
             name: 'Run integration tests',
-            run: buildTool === 'maven' ? 'mvn verify -Dskip.unit.tests=true' : './gradlew integrationTest'
+            run: buildTool === 'maven' ? `${command} verify -Dskip.unit.tests=true` : `${command} integrationTest`
           }
         ];
       case 'e2e':
         return [
           {
             name: 'Run E2E tests',
-            run: buildTool === 'maven' ? 'mvn verify -Dskip.unit.tests=true -Dskip.integration.tests=true' : './gradlew e2eTest'
+            run: buildTool === 'maven' ? `${command} verify -Dskip.unit.tests=true -Dskip.integration.tests=true` : `${command} e2eTest`
           }
         ];
     }
   }
 
-  private createRustTestSteps(testType: 'unit' | 'integration' | 'e2e'): StepTemplate[] {
+  private createRustTestSteps(detectionResult: DetectionResult, testType: 'unit' | 'integration' | 'e2e'): StepTemplate[] {
+    // Not every workspace crate has a library target, so --lib would fail on binary-only members
+    const workspace = !!detectionResult.cargoWorkspace;
     switch (testType) {
       case 'unit':
         return [
           {
             name: 'Run unit tests',
-            run: 'cargo test --lib'
+            run: workspace ? 'cargo test --workspace' : 'cargo test --lib'
           }
         ];
       case 'integration':
         return [
           {
             name: 'Run integration tests',
-            run: 'cargo test --test "*"'
+            run: workspace ? 'cargo test --workspace --test "*"' : 'cargo test --test "*"'
           }
         ];
       case 'e2e':
@@ -812,54 +4443,421 @@ export class CIWorkflowGenerator {
     }
   }
 
-  private createMatrixStrategy(
-    primaryLanguage: any,
+  private createMatrixStrategy(
+    primaryLanguage: any,
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): MatrixStrategy | undefined {
+    if (!primaryLanguage || options.optimizationLevel === 'basic') {
+      return undefined;
+    }
+
+    const constrained = this.getConstrainedVersions(detectionResult, primaryLanguage.name);
+    if (constrained) {
+      // An exact pin runs one job on that release; the setup step falls back to it
+      return constrained.length > 1
+        ? { matrix: { [CONSTRAINED_RUNTIMES[primaryLanguage.name.toLowerCase()]!.matrixKey]: constrained }, failFast: false }
+        : undefined;
+    }
+
+    switch (primaryLanguage.name.toLowerCase()) {
+      case 'javascript':
+      case 'typescript':
+        return {
+          matrix: {
+            'node-version': ['18', '20', '21']
+          },
+          failFast: false
+        };
+      case 'python':
+        return {
+          matrix: {
+            'python-version': ['3.9', '3.10', '3.11', '3.12']
+          },
+          failFast: false
+        };
+      case 'java':
+        // The release read from the manifest is the one JDK the build targets
+        if (detectionResult.javaBuild?.javaVersion) {
+          return undefined;
+        }
+        return {
+          matrix: {
+            'java-version': ['11', '17', '21']
+          },
+          failFast: false
+        };
+      case 'rust':
+        return {
+          matrix: {
+            'rust-version': ['stable', 'beta']
+          },
+          failFast: false
+        };
+      case 'go':
+        return {
+          matrix: {
+            'go-version': ['1.20', '1.21', '1.22']
+          },
+          failFast: false
+        };
+      default:
+        return undefined;
+    }
+  }
+
+  /**
+   * Versions allowed by the manifest constraint for a language: the exact pin,
+   * or the newest supported release lines of a range
+   */
+  private getConstrainedVersions(detectionResult: DetectionResult, language: string): string[] | undefined {
+    const runtime = CONSTRAINED_RUNTIMES[language.toLowerCase()]?.runtime;
+    const constraint = runtime ? detectionResult.versionConstraints?.find(c => c.runtime === runtime) : undefined;
+    if (!constraint || constraint.versions.length === 0) {
+      return undefined;
+    }
+
+    if (constraint.exact) {
+      return [constraint.exact];
+    }
+    return constraint.listed ? constraint.versions : constraint.versions.slice(-MAX_CONSTRAINED_VERSIONS);
+  }
+
+  /**
+   * Version used by setup steps outside a version matrix
+   */
+  private getDefaultVersion(detectionResult: DetectionResult, language: string): string {
+    const constrained = this.getConstrainedVersions(detectionResult, language);
+    return constrained?.[constrained.length - 1] || CONSTRAINED_RUNTIMES[language]!.defaultVersion;
+  }
+
+  /**
+   * Provenance of a setup step whose versions come from a version constraint
+   */
+  private getVersionSource(detectionResult: DetectionResult, language: string): Pick<StepTemplate, 'source'> {
+    const runtime = CONSTRAINED_RUNTIMES[language]!.runtime;
+    const constraint = detectionResult.versionConstraints?.find(c => c.runtime === runtime && c.versions.length > 0);
+    return constraint ? { source: constraint.source } : {};
+  }
+
+  /**
+   * Spread a Rust job over the root package's features, building and testing one feature per entry
+   */
+  private applyCargoFeatureMatrix(
+    job: JobTemplate,
+    language: string | undefined,
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): void {
+    const features = detectionResult.cargoWorkspace?.features || [];
+    if (!options.cargoFeatureMatrix || language?.toLowerCase() !== 'rust' || features.length === 0) {
+      return;
+    }
+
+    job.strategy = {
+      ...job.strategy,
+      matrix: {
+        ...job.strategy?.matrix,
+        features
+      },
+      failFast: job.strategy?.failFast ?? false
+    };
+
+    job.steps = job.steps.map(step => /^cargo (build|test)\b/.test(step.run || '')
+      ? { ...step, run: `${step.run} --features \${{ matrix.features }}` }
+      : step);
+  }
+
+  /**
+   * Spread a .NET test job over the runnable frameworks its projects target, when there are
+   * several: the build covers them all and each entry tests one
+   */
+  private applyDotnetFrameworkMatrix(job: JobTemplate, language: string | undefined, detectionResult: DetectionResult): void {
+    const frameworks = this.getDotnetFrameworks(detectionResult);
+    if (language?.toLowerCase() !== 'c#' || frameworks.length < 2) {
+      return;
+    }
+
+    job.strategy = {
+      ...job.strategy,
+      matrix: {
+        ...job.strategy?.matrix,
+        framework: frameworks
+      },
+      failFast: job.strategy?.failFast ?? false
+    };
+
+    job.steps = job.steps.map(step => /^dotnet test\b/.test(step.run || '')
+      ? { ...step, run: `${step.run} --framework \${{ matrix.framework }}` }
+      : step);
+  }
+
+  /**
+   * Spread a CMake job over gcc and clang: each entry installs its compiler on Linux, configures
+   * with it through CC and CXX and keeps its own build directory cache
+   */
+  private applyCompilerMatrix(
+    job: JobTemplate,
+    language: string | undefined,
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): void {
+    if (!options.compilerMatrix || language?.toLowerCase() !== 'c/c++' || !detectionResult.cmakeProject) {
+      return;
+    }
+
+    job.strategy = {
+      ...job.strategy,
+      matrix: {
+        ...job.strategy?.matrix,
+        compiler: ['gcc', 'clang']
+      },
+      failFast: job.strategy?.failFast ?? false,
+      include: [
+        ...(job.strategy?.include || []),
+        { compiler: 'gcc', cc: 'gcc', cxx: 'g++' },
+        { compiler: 'clang', cc: 'clang', cxx: 'clang++' }
+      ]
+    };
+
+    const steps = job.steps.map(step => {
+      if (step.name === 'Configure') {
+        return { ...step, env: { ...step.env, CC: '${{ matrix.cc }}', CXX: '${{ matrix.cxx }}' } };
+      }
+      if (step.uses?.startsWith('actions/cache@') && typeof step.with?.key === 'string') {
+        // A build directory configured with one compiler cannot be reused by the other
+        const withCompiler = (key: string) => key.replace(/^cmake-\$\{\{ runner\.os \}\}-/, '$&${{ matrix.compiler }}-');
+        return {
+          ...step,
+          with: {
+            ...step.with,
+            key: withCompiler(step.with.key),
+            'restore-keys': String(step.with['restore-keys'] || '').split('\n').map(withCompiler).join('\n')
+          }
+        };
+      }
+      return step;
+    });
+
+    const checkout = steps.findIndex(step => step.uses?.startsWith('actions/checkout@'));
+    steps.splice(checkout + 1, 0, {
+      name: 'Install compiler',
+      run: "sudo apt-get update && sudo apt-get install -y ${{ matrix.compiler == 'clang' && 'clang' || 'g++' }}",
+      if: "runner.os == 'Linux'"
+    });
+    job.steps = steps;
+  }
+
+  /**
+   * Expand a Go job into a GOOS/GOARCH matrix derived from build constraints.
+   * Targets without a GitHub-hosted runner are cross-compiled on Linux and skip tests.
+   * Self-hosted runner labels don't map onto hosted images, so they leave the job as it is.
+   */
+  private applyGoPlatformMatrix(
+    job: JobTemplate,
+    language: string | undefined,
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): void {
+    const platforms = detectionResult.buildConstraints?.platforms || [];
+    if (language?.toLowerCase() !== 'go' || platforms.length === 0 || options.runnerLabels) {
+      return;
+    }
+
+    const goos = [...new Set(platforms.map(p => p.goos))];
+    const goarch = [...new Set(platforms.map(p => p.goarch))];
+    const isTargeted = (os: string, arch: string) =>
+      platforms.some(p => p.goos === os && p.goarch === arch);
+
+    const exclude: Record<string, any>[] = [];
+    for (const os of goos) {
+      for (const arch of goarch) {
+        if (!isTargeted(os, arch)) {
+          exclude.push({ goos: os, goarch: arch });
+        }
+      }
+    }
+
+    job.runsOn = '${{ matrix.runner }}';
+    job.strategy = {
+      ...job.strategy,
+      matrix: {
+        ...job.strategy?.matrix,
+        goos,
+        goarch
+      },
+      failFast: job.strategy?.failFast ?? false,
+      include: platforms.map(p => this.createGoPlatformEntry(p))
+    };
+    if (exclude.length > 0) {
+      job.strategy.exclude = exclude;
+    }
+
+    // make targets inherit GOOS/GOARCH from the environment like go build does
+    job.steps = job.steps.map(step => {
+      if (/^(go|make) build\b/.test(step.run || '')) {
+        return {
+          ...step,
+          env: {
+            ...step.env,
+            GOOS: '${{ matrix.goos }}',
+            GOARCH: '${{ matrix.goarch }}'
+          }
+        };
+      }
+      // Native targets may differ from the runner's default architecture (386 on x64)
+      if (/^(go|make) test\b/.test(step.run || '')) {
+        return {
+          ...step,
+          if: '${{ !matrix.cross-compile }}',
+          env: {
+            ...step.env,
+            GOARCH: '${{ matrix.goarch }}'
+          }
+        };
+      }
+      return step;
+    });
+  }
+
+  /**
+   * Build and test a Swift package on macOS, where Xcode's toolchain makes it at home. With
+   * options.swiftLinux a Linux matrix entry runs the same steps in the official swift container
+   * of the package's Swift release, which needs no setup step. GitHub Actions only; runner
+   * labels and runner matrices of the job are kept.
+   */
+  private applySwiftPlatforms(
+    job: JobTemplate,
+    language: string | undefined,
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): void {
+    const github = !options.provider || options.provider === Provider.GitHubActions;
+    if (language?.toLowerCase() !== 'swift' || !github || options.runnerLabels || job.runsOn !== 'ubuntu-latest') {
+      return;
+    }
+
+    if (!options.swiftLinux) {
+      job.runsOn = HOSTED_OS_RUNNERS.macos.runner;
+      return;
+    }
+
+    this.applyOSMatrix(job, ['macos', 'linux']);
+    job.strategy = {
+      ...job.strategy!,
+      include: [...(job.strategy!.include || []), { os: HOSTED_OS_RUNNERS.linux.runner, container: `swift:${this.getSwiftVersion(detectionResult)}` }]
+    };
+    job.container = '${{ matrix.container }}';
+    job.steps = job.steps.map(step => step.uses?.startsWith('swift-actions/setup-swift@')
+      ? { ...step, if: '${{ !matrix.container }}' }
+      : step);
+  }
+
+  /**
+   * With options.flutterBuild, build a Flutter app for each platform it has a directory for, in a
+   * `platform` matrix whose entries pick the runner and the flutter build target. Android builds
+   * get a JDK and Linux desktop builds the packages the embedder needs. GitHub Actions only;
+   * runner labels and runner matrices of the job are kept.
+   */
+  private applyFlutterBuildMatrix(
+    job: JobTemplate,
+    language: string | undefined,
+    detectionResult: DetectionResult,
     options: GenerationOptions
-  ): MatrixStrategy | undefined {
-    if (!primaryLanguage || options.optimizationLevel === 'basic') {
-      return undefined;
+  ): void {
+    const github = !options.provider || options.provider === Provider.GitHubActions;
+    const platforms = this.getFlutterPlatforms(detectionResult);
+    if (!options.flutterBuild || language?.toLowerCase() !== 'dart' || platforms.length === 0 ||
+      !github || options.runnerLabels || job.runsOn !== 'ubuntu-latest') {
+      return;
     }
 
-    switch (primaryLanguage.name.toLowerCase()) {
-      case 'javascript':
-      case 'typescript':
-        return {
-          matrix: {
-            'node-version': ['18', '20', '21']
-          },
-          failFast: false
-        };
-      case 'python':
-        return {
-          matrix: {
-            'python-version': ['3.9', '3.10', '3.11', '3.12']
-          },
-          failFast: false
-        };
-      case 'java':
-        return {
-          matrix: {
-            'java-version': ['11', '17', '21']
-          },
-          failFast: false
-        };
-      case 'rust':
-        return {
-          matrix: {
-            'rust-version': ['stable', 'beta']
-          },
-          failFast: false
-        };
-      case 'go':
-        return {
-          matrix: {
-            'go-version': ['1.20', '1.21', '1.22']
-          },
-          failFast: false
-        };
-      default:
-        return undefined;
+    job.runsOn = '${{ matrix.os }}';
+    job.strategy = {
+      ...job.strategy,
+      matrix: { ...job.strategy?.matrix, platform: platforms },
+      include: [
+        ...(job.strategy?.include || []),
+        ...platforms.map(platform => ({
+          platform,
+          os: HOSTED_OS_RUNNERS[FLUTTER_BUILD_TARGETS[platform]!.os].runner,
+          build: FLUTTER_BUILD_TARGETS[platform]!.build
+        }))
+      ],
+      failFast: job.strategy?.failFast ?? false
+    };
+    if (platforms.some(platform => FLUTTER_BUILD_TARGETS[platform]!.os === 'windows')) {
+      job.defaults = { ...job.defaults, run: { ...job.defaults?.run, shell: 'bash' } };
+    }
+
+    job.steps.push(
+      ...(platforms.includes('android') ? [{
+        name: 'Setup Java',
+        uses: 'actions/setup-java@v4',
+        with: { distribution: 'temurin', 'java-version': '17' },
+        if: "matrix.platform == 'android'"
+      }] : []),
+      ...(platforms.includes('linux') ? [{
+        name: 'Install Linux build dependencies',
+        run: `sudo apt-get update && sudo apt-get install -y ${FLUTTER_LINUX_PACKAGES.join(' ')}`,
+        if: "matrix.platform == 'linux'"
+      }] : []),
+      {
+        name: 'Build ${{ matrix.platform }}',
+        run: 'flutter build ${{ matrix.build }}',
+        source: `${platforms.join(', ')} directories`
+      }
+    );
+  }
+
+  /**
+   * Platforms of a Flutter app that flutter build has a target for
+   */
+  private getFlutterPlatforms(detectionResult: DetectionResult): string[] {
+    const dartProject = detectionResult.dartProject;
+    return dartProject?.flutter ? dartProject.platforms.filter(platform => FLUTTER_BUILD_TARGETS[platform]) : [];
+  }
+
+  /**
+   * Run a test job on the hosted runners of the given operating systems, as an `os` matrix
+   * dimension when there are several. Windows runners default to PowerShell, so jobs reaching
+   * them run their steps with bash (Git Bash) like on the others; test results are uploaded
+   * per runner.
+   */
+  private applyOSMatrix(job: JobTemplate, systems: HostedOS[]): void {
+    const runners = [...new Set(systems)].map(os => HOSTED_OS_RUNNERS[os].runner);
+    if (runners.length === 1) {
+      job.runsOn = runners[0]!;
+    } else {
+      job.runsOn = '${{ matrix.os }}';
+      job.strategy = {
+        ...job.strategy,
+        matrix: { ...job.strategy?.matrix, os: runners },
+        failFast: job.strategy?.failFast ?? false
+      };
+      job.steps = job.steps.map(step => step.name === 'Upload test results' && step.with
+        ? { ...step, with: { ...step.with, name: `${step.with.name}-\${{ matrix.os }}` } }
+        : step);
     }
+
+    if (systems.includes('windows')) {
+      job.defaults = { ...job.defaults, run: { ...job.defaults?.run, shell: 'bash' } };
+    }
+  }
+
+  /**
+   * Map a GOOS/GOARCH target to its runner, flagging targets that must be cross-compiled
+   */
+  private createGoPlatformEntry(platform: PlatformTarget): Record<string, any> {
+    const hosted = GO_HOSTED_RUNNERS[platform.goos];
+    const native = hosted?.architectures.includes(platform.goarch) ?? false;
+
+    return {
+      goos: platform.goos,
+      goarch: platform.goarch,
+      runner: hosted?.runner ?? 'ubuntu-latest',
+      'cross-compile': !native
+    };
   }
 
   private createServiceSetupSteps(services: string[]): StepTemplate[] {
@@ -916,15 +4914,20 @@ export class CIWorkflowGenerator {
     switch (primaryLanguage?.name.toLowerCase()) {
       case 'javascript':
       case 'typescript':
-        return 'dist/\nbuild/';
       case 'python':
-        return 'dist/\nbuild/';
+        return 'dist/';
       case 'java':
-        return 'target/\nbuild/libs/';
+        return detectionResult.javaBuild?.modules.length ? '**/target/*.jar\n**/build/libs/' : 'target/\nbuild/libs/';
       case 'rust':
         return 'target/release/';
       case 'go':
         return 'bin/';
+      case 'c#':
+        return '**/bin/';
+      case 'dart':
+        return 'build/';
+      case 'c/c++':
+        return `${detectionResult.cmakeProject?.binaryDir || 'build'}/`;
       default:
         return 'build/\ndist/';
     }
@@ -940,11 +4943,18 @@ export class CIWorkflowGenerator {
       case 'python':
         return 'coverage.xml\npytest-results.xml';
       case 'java':
-        return 'target/surefire-reports/\ntarget/site/jacoco/';
+        if (detectionResult.javaBuild?.buildSystem === 'gradle') {
+          return detectionResult.javaBuild.modules.length ? '**/build/test-results/\n**/build/reports/jacoco/' : 'build/test-results/\nbuild/reports/jacoco/';
+        }
+        return detectionResult.javaBuild?.modules.length
+          ? '**/target/surefire-reports/\n**/target/site/jacoco/'
+          : 'target/surefire-reports/\ntarget/site/jacoco/';
       case 'rust':
         return 'target/coverage/';
       case 'go':
         return 'coverage.out';
+      case 'c/c++':
+        return `${detectionResult.cmakeProject?.binaryDir || 'build'}/Testing/`;
       default:
         return 'test-results/';
     }
@@ -969,6 +4979,7 @@ export class CIWorkflowGenerator {
           languages.push('go');
           break;
         case 'csharp':
+        case 'c#':
           languages.push('csharp');
           break;
         case 'cpp':
@@ -988,6 +4999,404 @@ export class CIWorkflowGenerator {
     return `Languages: ${languages}; Frameworks: ${frameworks}`;
   }
 
+  /**
+   * Split a repository mixing several language families into one detection result per family,
+   * primary language first; undefined when there is only one. Family-specific detections go to
+   * their language; repository-wide ones (Dockerfiles, static site) to the primary language, and
+   * the Makefile to the language built at the root. A language without a detected test framework
+   * runs its default tests.
+   */
+  private splitByLanguage(detectionResult: DetectionResult): Array<{ slug: string; directory: string; detectionResult: DetectionResult }> | undefined {
+    const familyOf = (language: string) => LANGUAGE_FAMILIES[language.toLowerCase()];
+    const seen = new Set<string>();
+    const languages = [...detectionResult.languages]
+      .sort((a, b) => Number(b.primary) - Number(a.primary))
+      .filter(language => {
+        const family = familyOf(language.name);
+        if (!family || seen.has(family)) {
+          return false;
+        }
+        seen.add(family);
+        return true;
+      });
+
+    if (languages.length < 2) {
+      return undefined;
+    }
+
+    const rootLanguage = languages.find(language => !language.directory || language.directory === '.');
+
+    return languages.map((language, index) => {
+      const family = familyOf(language.name)!;
+      const primary = index === 0;
+      // Detections of an unknown family stay with the primary language
+      const keep = (itemFamily: string | undefined) => itemFamily === undefined ? primary : itemFamily === family;
+      const testFamily = (name: string) => TEST_FRAMEWORK_FAMILIES.find(([pattern]) => pattern.test(name))?.[1];
+
+      const testingFrameworks = detectionResult.testingFrameworks.filter(tf => keep(testFamily(tf.name)));
+      const testRunners = detectionResult.testRunners?.filter(runner => familyOf(runner.language) === family);
+      if (!testingFrameworks.some(tf => tf.type === 'unit') && !testRunners?.length) {
+        testingFrameworks.push({ name: DEFAULT_FAMILY_TESTS[family]!, type: 'unit', confidence: language.confidence });
+      }
+
+      const languageResult: DetectionResult = {
+        ...detectionResult,
+        languages: [{ ...language, primary: true }],
+        buildTools: detectionResult.buildTools.filter(bt => keep(TOOL_FAMILIES[bt.name.toLowerCase()])),
+        packageManagers: detectionResult.packageManagers.filter(pm => keep(TOOL_FAMILIES[pm.name.toLowerCase()])),
+        testingFrameworks,
+        ...(detectionResult.testRunners && { testRunners }),
+        ...(detectionResult.coverageTools && { coverageTools: detectionResult.coverageTools.filter(coverage => familyOf(coverage.language) === family) }),
+        ...(detectionResult.linters && { linters: detectionResult.linters.filter(linter => familyOf(linter.language) === family) }),
+        ...(detectionResult.versionConstraints && {
+          versionConstraints: detectionResult.versionConstraints.filter(constraint => constraint.runtime === family)
+        }),
+        buildConstraints: family === 'go' ? detectionResult.buildConstraints : undefined,
+        cargoWorkspace: family === 'rust' ? detectionResult.cargoWorkspace : undefined,
+        nodeWorkspace: family === 'node' ? detectionResult.nodeWorkspace : undefined,
+        taskGraph: family === 'node' ? detectionResult.taskGraph : undefined,
+        packageScripts: family === 'node' ? detectionResult.packageScripts : undefined,
+        javaBuild: family === 'java' ? detectionResult.javaBuild : undefined,
+        pythonLayout: family === 'python' ? detectionResult.pythonLayout : undefined,
+        goModule: family === 'go' ? detectionResult.goModule : undefined,
+        elixirProject: family === 'elixir' ? detectionResult.elixirProject : undefined,
+        rubyProject: family === 'ruby' ? detectionResult.rubyProject : undefined,
+        swiftPackage: family === 'swift' ? detectionResult.swiftPackage : undefined,
+        dotnetProject: family === 'dotnet' ? detectionResult.dotnetProject : undefined,
+        dartProject: family === 'dart' ? detectionResult.dartProject : undefined,
+        haskellProject: family === 'haskell' ? detectionResult.haskellProject : undefined,
+        cmakeProject: family === 'cpp' ? detectionResult.cmakeProject : undefined,
+        makefile: language === rootLanguage ? detectionResult.makefile : undefined,
+        dockerImages: primary ? detectionResult.dockerImages : undefined,
+        terraform: primary ? detectionResult.terraform : undefined,
+        apiSchemas: primary ? detectionResult.apiSchemas : undefined,
+        changelog: primary ? detectionResult.changelog : undefined,
+        codeowners: primary ? detectionResult.codeowners : undefined,
+        protobuf: primary ? detectionResult.protobuf : undefined,
+        generatedCode: detectionResult.generatedCode && this.rebaseGeneratedCode(detectionResult.generatedCode, language.directory || '.'),
+        staticSite: primary ? detectionResult.staticSite : undefined
+      };
+
+      return { slug: family === 'node' ? language.name.toLowerCase() : family, directory: language.directory || '.', detectionResult: languageResult };
+    });
+  }
+
+  /**
+   * Generated code paths relative to a language's directory, which its lint steps run in,
+   * leaving out the paths outside it
+   */
+  private rebaseGeneratedCode(generatedCode: GeneratedCodeDetection, directory: string): GeneratedCodeDetection {
+    const rebase = (paths: string[]) => directory === '.'
+      ? paths
+      : paths.filter(path => path.startsWith(`${directory}/`)).map(path => path.slice(directory.length + 1));
+    return {
+      goFiles: rebase(generatedCode.goFiles),
+      goDirectories: rebase(generatedCode.goDirectories),
+      bundleDirectories: rebase(generatedCode.bundleDirectories)
+    };
+  }
+
+  /**
+   * Rename a job for its package and point its steps at the package directory.
+   * A working directory the job already has is taken to be relative to the package.
+   */
+  private scopeJobToPackage(job: JobTemplate, pkg: MonorepoPackage, slug: string = this.getPackageSlug(pkg)): JobTemplate {
+    const scoped: JobTemplate = {
+      ...job,
+      name: `${slug}-${job.name}`,
+      steps: job.steps.map(step => this.scopeStepToPackage(step, pkg, slug))
+    };
+
+    if (job.needs) {
+      scoped.needs = job.needs.map(need => `${slug}-${need}`);
+    }
+
+    if (pkg.path !== '.') {
+      const nested = job.defaults?.run?.workingDirectory;
+      scoped.defaults = { ...job.defaults, run: { ...job.defaults?.run, workingDirectory: nested && nested !== '.' ? `${pkg.path}/${nested}` : pkg.path } };
+    }
+
+    return scoped;
+  }
+
+  /**
+   * Make a package's Dockerfile paths repository-relative, leaving those of nested packages to them
+   */
+  private withPackageDockerImages(pkg: MonorepoPackage): DetectionResult {
+    const images = pkg.detectionResult.dockerImages;
+    if (!images || (pkg.path === '.' && !pkg.excludePaths?.length)) {
+      return pkg.detectionResult;
+    }
+
+    const prefix = (relative: string) => (pkg.path === '.' ? relative : relative === '.' ? pkg.path : `${pkg.path}/${relative}`);
+    const dockerImages = images
+      .map(image => ({ ...image, dockerfile: prefix(image.dockerfile), context: prefix(image.context) }))
+      .filter(image => !(pkg.excludePaths || []).some(excluded => image.dockerfile.startsWith(`${excluded}/`)));
+
+    return { ...pkg.detectionResult, dockerImages };
+  }
+
+  /**
+   * Rewrite action inputs that are resolved relative to the repository root
+   */
+  private scopeStepToPackage(step: StepTemplate, pkg: MonorepoPackage, slug: string): StepTemplate {
+    const rebased = pkg.path === '.' ? step : this.rebaseStep(step, pkg.path);
+    if (!rebased.uses || !rebased.with) {
+      return rebased;
+    }
+
+    const inputs: Record<string, any> = { ...rebased.with };
+    const action = rebased.uses.split('@')[0];
+
+    if (action === 'actions/upload-artifact' || action === 'actions/download-artifact') {
+      if (inputs.name) {
+        inputs.name = `${slug}-${inputs.name}`;
+      }
+    }
+
+    if (pkg.path !== '.') {
+      if (action === 'codecov/codecov-action' && typeof inputs.files === 'string') {
+        inputs.flags = slug;
+      }
+
+      // Keep packages from restoring each other's caches
+      if (action === 'actions/cache' && typeof inputs.key === 'string') {
+        inputs.key = `${slug}-${inputs.key}`;
+        if (typeof inputs['restore-keys'] === 'string') {
+          inputs['restore-keys'] = inputs['restore-keys']
+            .split('\n')
+            .map((key: string) => `${slug}-${key}`)
+            .join('\n');
+        }
+      }
+    }
+
+    return { ...rebased, with: inputs };
+  }
+
+  /**
+   * Point action inputs that are resolved relative to the repository root at directory,
+   * where the step's run commands work
+   */
+  private rebaseStep(step: StepTemplate, directory: string): StepTemplate {
+    if (!step.uses || !step.with) {
+      return step;
+    }
+
+    const inputs: Record<string, any> = { ...step.with };
+    const action = step.uses.split('@')[0];
+    const rebasePaths = (paths: string) => paths
+      .split('\n')
+      .map((entry: string) => (!entry || /^[~/$]/.test(entry) ? entry : `${directory}/${entry}`))
+      .join('\n');
+
+    // Coverage reports are written inside the working directory
+    if (action === 'codecov/codecov-action' && typeof inputs.files === 'string') {
+      inputs.files = `${directory}/${inputs.files}`;
+    }
+    if (action === 'coverallsapp/github-action' && typeof inputs.file === 'string') {
+      inputs.file = `${directory}/${inputs.file}`;
+    }
+
+    if ((action === 'actions/upload-artifact' || action === 'actions/cache') && typeof inputs.path === 'string') {
+      inputs.path = rebasePaths(inputs.path);
+    }
+    if (action === 'softprops/action-gh-release' && typeof inputs.files === 'string') {
+      inputs.files = rebasePaths(inputs.files);
+    }
+
+    // Hash the lockfiles under directory only
+    if (action === 'actions/cache' && typeof inputs.key === 'string') {
+      inputs.key = inputs.key.replace(/'((?:[\w.-]+\/)*)\*\*\//g, `'${directory}/$1**/`);
+    }
+
+    if (action === 'golangci/golangci-lint-action') {
+      inputs['working-directory'] = directory;
+    }
+    if (action === 'goreleaser/goreleaser-action') {
+      inputs.workdir = directory;
+    }
+
+    return { ...step, with: inputs };
+  }
+
+  /**
+   * Run the jobs of a project kept in a subdirectory of its repository (detectionResult.workingDirectory)
+   * from there. A working directory a job already has is taken to be relative to it.
+   */
+  private applyWorkingDirectory(jobs: JobTemplate[], detectionResult: DetectionResult): JobTemplate[] {
+    const directory = detectionResult.workingDirectory;
+    if (!directory || directory === '.') {
+      return jobs;
+    }
+
+    return jobs.map(job => {
+      const nested = job.defaults?.run?.workingDirectory;
+      return {
+        ...job,
+        defaults: { ...job.defaults, run: { ...job.defaults?.run, workingDirectory: nested && nested !== '.' ? `${directory}/${nested}` : directory } },
+        steps: job.steps.map(step => this.rebaseStep(step, directory))
+      };
+    });
+  }
+
+  /**
+   * Create push/pull_request triggers filtered to package paths and the paths of their dependencies.
+   * Per-package filters exclude nested packages the package does not depend on; a combined workflow uses the union.
+   */
+  private createPackageTriggers(
+    packages: MonorepoPackage[],
+    allPackages: MonorepoPackage[],
+    excludeNested: boolean,
+    options: GenerationOptions
+  ): TriggerConfig {
+    const triggers = this.createCITriggers(options);
+    const changePaths = packages.map(pkg => ({ pkg, paths: this.getPackageChangePaths(pkg, allPackages) }));
+    const includes = changePaths.flatMap(entry => entry.paths.map(path => (path === '.' ? '**' : `${path}/**`)));
+
+    // The root package already covers every path, so no filter is needed
+    if (includes.includes('**') && !excludeNested) {
+      return triggers;
+    }
+
+    const paths = [...new Set(includes)];
+    if (excludeNested) {
+      for (const { pkg, paths: dependencies } of changePaths) {
+        // Later patterns win, so excluding a nested dependency would drop it again
+        const excluded = (pkg.excludePaths || []).filter(path =>
+          !dependencies.some(dependency => dependency !== pkg.path && (path === dependency || path.startsWith(`${dependency}/`)))
+        );
+        paths.push(...excluded.map(path => `!${path}/**`));
+      }
+    }
+    // GitHub rejects paths combined with paths-ignore, so fold the ignores in as negations
+    paths.push(...(triggers.push?.pathsIgnore || []).map(path => `!${path}`));
+
+    const { pathsIgnore, ...push } = triggers.push || {};
+    return {
+      ...triggers,
+      push: { ...push, paths },
+      pullRequest: { ...triggers.pullRequest, paths }
+    };
+  }
+
+  /**
+   * A package's own path followed by the paths of every package it depends on, directly or transitively.
+   * Dependencies missing from packages, such as the unchanged ones --since leaves out, still count.
+   */
+  private getPackageChangePaths(pkg: MonorepoPackage, packages: MonorepoPackage[]): string[] {
+    const byPath = new Map(packages.map(candidate => [candidate.path, candidate]));
+    const paths = [pkg.path];
+
+    for (let index = 0; index < paths.length; index++) {
+      for (const dependency of byPath.get(paths[index]!)?.dependsOn || []) {
+        if (!paths.includes(dependency)) {
+          paths.push(dependency);
+        }
+      }
+    }
+
+    return paths;
+  }
+
+  /**
+   * Patterns of the changes job filter saying a package or one of its dependencies changed
+   */
+  private getPackageFilters(pkg: MonorepoPackage, packages: MonorepoPackage[]): string[] {
+    return this.getPackageChangePaths(pkg, packages).map(path => (path === '.' ? '**' : `${path}/**`));
+  }
+
+  /**
+   * Create the job that reports, per package, whether the package or one of its dependencies changed
+   */
+  private createChangesJob(packages: MonorepoPackage[]): JobTemplate {
+    const filters = packages.flatMap(pkg => [
+      `${this.getPackageSlug(pkg)}:`,
+      ...this.getPackageFilters(pkg, packages).map(pattern => `  - '${pattern}'`)
+    ]);
+
+    return {
+      name: MONOREPO_CHANGES_JOB,
+      runsOn: 'ubuntu-latest',
+      outputs: Object.fromEntries(packages.map(pkg => {
+        const slug = this.getPackageSlug(pkg);
+        return [slug, `\${{ steps.filter.outputs.${slug} }}`];
+      })),
+      steps: [
+        {
+          name: 'Checkout code',
+          uses: 'actions/checkout@v4'
+        },
+        {
+          name: 'Detect changed packages',
+          id: 'filter',
+          uses: 'dorny/paths-filter@v3',
+          with: {
+            filters: filters.join('\n')
+          }
+        }
+      ]
+    };
+  }
+
+  /**
+   * Skip a package job unless the changes job saw the package change; scheduled runs test everything
+   */
+  private gateJobOnChanges(job: JobTemplate, pkg: MonorepoPackage): JobTemplate {
+    const changed = `github.event_name == 'schedule' || needs.${MONOREPO_CHANGES_JOB}.outputs.${this.getPackageSlug(pkg)} == 'true'`;
+
+    return {
+      ...job,
+      needs: [MONOREPO_CHANGES_JOB, ...(job.needs || [])],
+      if: job.if ? `(${changed}) && (${job.if})` : changed
+    };
+  }
+
+  private createMonorepoOutput(
+    filename: string,
+    content: string,
+    packages: MonorepoPackage[],
+    options: GenerationOptions,
+    taskGraph?: TaskGraphDetection
+  ): WorkflowOutput {
+    const warnings: string[] = [];
+    const optimizations = new Set<string>();
+
+    for (const pkg of packages) {
+      warnings.push(...this.getWarnings(pkg.detectionResult).map(warning => `${pkg.path}: ${warning}`));
+      this.getAppliedOptimizations(pkg.detectionResult, options).forEach(optimization => optimizations.add(optimization));
+    }
+    if (taskGraph) {
+      optimizations.add(`${taskGraph.tool === 'nx' ? 'Nx' : 'Turborepo'} runs ${taskGraph.tasks.join(', ')} for the affected monorepo packages`);
+    } else if (options.monorepoChangeDetection === 'paths-filter') {
+      optimizations.add(`Jobs gated on changed files for ${packages.length} monorepo package(s)`);
+    } else {
+      optimizations.add(`Path-filtered jobs for ${packages.length} monorepo package(s)`);
+    }
+
+    return {
+      filename,
+      content,
+      type: 'ci',
+      metadata: {
+        generatedAt: new Date(),
+        generatorVersion: '1.0.0',
+        detectionSummary: packages
+          .map(pkg => `${pkg.path}: ${this.createDetectionSummary(pkg.detectionResult)}`)
+          .join(' | '),
+        optimizations: Array.from(optimizations),
+        warnings
+      }
+    };
+  }
+
+  /**
+   * Stable, filename-safe identifier for a package derived from its path
+   */
+  private getPackageSlug(pkg: MonorepoPackage): string {
+    return pkg.path === '.' ? 'root' : pkg.path.replace(/[^A-Za-z0-9]+/g, '-').replace(/^-|-$/g, '').toLowerCase();
+  }
+
   private getAppliedOptimizations(
     detectionResult: DetectionResult,
     options: GenerationOptions
@@ -1006,6 +5415,10 @@ export class CIWorkflowGenerator {
       optimizations.push('Security scanning integrated');
     }
 
+    if (detectionResult.buildConstraints?.platforms.length && !options.runnerLabels) {
+      optimizations.push('GOOS/GOARCH matrix from build constraints');
+    }
+
     return optimizations;
   }
 
@@ -1020,12 +5433,85 @@ export class CIWorkflowGenerator {
       warnings.push('No testing frameworks detected - basic test commands used');
     }
 
+    const primaryLanguage = detectionResult.languages.find(l => l.primary);
+    const cache = primaryLanguage ? this.resolveDependencyCache(primaryLanguage.name, detectionResult) : undefined;
+    if (cache?.warning) {
+      warnings.push(cache.warning);
+    }
+
+    const existingCI = getExistingCIProviders(detectionResult);
+    if (existingCI.length > 0) {
+      warnings.push(`An existing CI badge for ${existingCI.join(', ')} was found in the README - generating anyway`);
+    }
+
+    for (const runner of detectionResult.testRunners || []) {
+      if (runner.suppressedBy) {
+        warnings.push(`${runner.name} is run through ${runner.suppressedBy} - no separate ${runner.name} test job generated`);
+      }
+    }
+
+    for (const image of detectionResult.dockerImages || []) {
+      if (!image.hasDockerignore) {
+        warnings.push(`No .dockerignore for ${image.dockerfile} - the whole build context is sent to the Docker daemon`);
+      }
+    }
+
+    // The workflow token only reads this repository, and checkout cannot use it over SSH
+    const privateSubmodules = (detectionResult.gitCheckout?.submodules || []).filter(submodule => /^(git@|ssh:\/\/)/.test(submodule.url));
+    if (privateSubmodules.length > 0) {
+      warnings.push(`Submodules ${privateSubmodules.map(submodule => submodule.path).join(', ')} are fetched over SSH and may be private - ` +
+        'give the checkout step a token or ssh-key with access to them');
+    }
+
     return warnings;
   }
 
-  private async renderWorkflow(workflow: WorkflowTemplate): Promise<string> {
-    // This would use the YAML renderer to convert the workflow template to YAML
-    // For now, returning a placeholder
-    return `# Generated CI Workflow\n# ${workflow.name}\n# Generated at: ${new Date().toISOString()}`;
+  private async renderWorkflow(workflow: WorkflowTemplate, options: GenerationOptions): Promise<string> {
+    const permitted = this.applyPermissions(workflow, options);
+    const content = this.yamlRenderer.renderWorkflow(permitted).yaml;
+    if (options.minimal) {
+      return stripComments(content);
+    }
+    return options.provenance ? this.yamlRenderer.annotateStepSources(content, permitted) : content;
+  }
+
+  /**
+   * Least-privilege token permissions: read-only for the workflow, raised per job to what its
+   * steps need. With options.permissions false, no permissions are declared at all; minimal
+   * workflows only declare the ones jobs need beyond the default token's.
+   */
+  private applyPermissions(workflow: WorkflowTemplate, options: GenerationOptions): WorkflowTemplate {
+    const enabled = options.permissions !== false;
+    return {
+      ...workflow,
+      permissions: enabled && !options.minimal ? { contents: 'read' } : undefined,
+      jobs: workflow.jobs.map(job => ({ ...job, permissions: enabled ? this.getJobPermissions(job) : undefined }))
+    };
+  }
+
+  /**
+   * Permissions a job's steps need, undefined when reading the repository is enough. Pushing
+   * to GitHub Packages is recognized from a registry login with the workflow token, commenting
+   * on pull requests from a github-script creating comments.
+   */
+  private getJobPermissions(job: JobTemplate): PermissionConfig | undefined {
+    const needed: PermissionConfig = {};
+    for (const step of job.steps) {
+      if (!step.uses) {
+        continue;
+      }
+      for (const [action, permissions] of ACTION_PERMISSIONS) {
+        if (action.test(step.uses)) {
+          Object.assign(needed, permissions);
+        }
+      }
+      if (step.uses.startsWith('docker/login-action@') && String(step.with?.password || '').includes('secrets.GITHUB_TOKEN')) {
+        needed.packages = 'write';
+      }
+      if (step.uses.startsWith('actions/github-script@') && String(step.with?.script || '').includes('github.rest.issues.createComment')) {
+        needed.pullRequests = 'write';
+      }
+    }
+    return Object.keys(needed).length > 0 ? { contents: 'read', ...needed } : undefined;
   }
 }
\ No newline at end of file
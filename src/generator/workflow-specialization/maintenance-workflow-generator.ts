@@ -867,7 +867,7 @@ export class MaintenanceWorkflowGenerator {
     return [
       {
         name: 'Create Pull Request',
-        uses: 'peter-evans/create-pull-request@v5',
+        uses: 'peter-evans/create-pull-request@v7',
         with: {
           token: '${{ secrets.GITHUB_TOKEN }}',
           'commit-message': `chore: ${updateType} - automated maintenance`,
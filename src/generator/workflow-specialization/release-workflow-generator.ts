@@ -49,6 +49,10 @@ export class ReleaseWorkflowGenerator {
         packages: 'write',
         pullRequests: 'write',
         issues: 'write'
+      },
+      concurrency: {
+        group: '${{ github.workflow }}-${{ github.ref }}',
+        cancelInProgress: false // A cancelled run can leave a release half-published
       }
     };
   }
@@ -157,7 +161,7 @@ export class ReleaseWorkflowGenerator {
         'previous-version': '${{ steps.calculate-version.outputs.previous-version }}',
         'release-notes': '${{ steps.generate-notes.outputs.release-notes }}'
       },
-      if: "github.event_name == 'workflow_dispatch' || (github.event_name == 'schedule' && github.ref == 'refs/heads/main')"
+      if: `github.event_name == 'workflow_dispatch' || (github.event_name == 'schedule' && github.ref == 'refs/heads/${options.defaultBranch || 'main'}')`
     };
   }
 
@@ -180,7 +184,7 @@ export class ReleaseWorkflowGenerator {
       {
         name: 'Generate changelog',
         id: 'changelog',
-        uses: 'mikepenz/release-changelog-builder-action@v4',
+        uses: 'mikepenz/release-changelog-builder-action@v5',
         with: {
           configuration: '.github/changelog-config.json',
           fromTag: '${{ needs.prepare-version.outputs.previous-version }}',
@@ -1317,10 +1317,9 @@ export class TestingStrategyGenerator {
         case 'rust':
           steps.push({
             name: 'Setup Rust',
-            uses: 'actions-rs/toolchain@v1',
+            uses: 'dtolnay/rust-toolchain@stable',
             with: {
-              toolchain: language.version || 'stable',
-              override: true
+              toolchain: language.version || 'stable'
             }
           });
           break;
@@ -3,7 +3,7 @@
  * Coordinates CI, CD, release, and maintenance workflow generation
  */
 
-import { DetectionResult, GenerationOptions, WorkflowOutput, WorkflowType } from '../interfaces';
+import { DetectionResult, GenerationOptions, WorkflowOutput, WorkflowType, MonorepoPackage } from '../interfaces';
 import { CIWorkflowGenerator } from './ci-workflow-generator';
 import { CDWorkflowGenerator } from './cd-workflow-generator';
 import { ReleaseWorkflowGenerator } from './release-workflow-generator';
@@ -63,6 +63,63 @@ export class WorkflowSpecializationManager {
     }
   }
 
+  /**
+   * Generate CI workflows for the packages of a monorepo
+   */
+  async generateMonorepoWorkflows(
+    packages: MonorepoPackage[],
+    options: GenerationOptions
+  ): Promise<WorkflowOutput[]> {
+    return this.ciGenerator.generateMonorepoCIWorkflows(packages, { ...options, workflowType: 'ci' });
+  }
+
+  /**
+   * Explain which changed files of a pull request make each monorepo CI job run
+   */
+  whyTriggered(packages: MonorepoPackage[], changedFiles: string[], options: GenerationOptions): Record<string, string[]> {
+    return this.ciGenerator.whyTriggered(packages, changedFiles, { ...options, workflowType: 'ci' });
+  }
+
+  /**
+   * Generate the nightly workflow running the CI build and test jobs on options.schedule
+   */
+  async generateNightlyWorkflow(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput> {
+    return this.ciGenerator.generateNightlyWorkflow(detectionResult, { ...options, workflowType: 'ci' });
+  }
+
+  /**
+   * Generate the CI workflow split into one file per kind of job
+   */
+  async generateSplitCIWorkflows(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput[]> {
+    return this.ciGenerator.generateSplitCIWorkflows(detectionResult, { ...options, workflowType: 'ci' });
+  }
+
+  /**
+   * Generate the release workflow building and publishing pushed version tags
+   */
+  async generateTagReleaseWorkflow(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput> {
+    return this.ciGenerator.generateTagReleaseWorkflow(detectionResult, { ...options, workflowType: 'release' });
+  }
+
+  /**
+   * Generate the composite action bundling the CI setup, build and unit test steps
+   */
+  async generateCompositeAction(
+    detectionResult: DetectionResult,
+    options: GenerationOptions
+  ): Promise<WorkflowOutput> {
+    return this.ciGenerator.generateCompositeAction(detectionResult, { ...options, workflowType: 'ci' });
+  }
+
   /**
    * Generate multiple specialized workflows
    */
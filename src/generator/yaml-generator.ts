@@ -3,7 +3,7 @@
  * Orchestrates all specialized generators and provides the main entry point for workflow generation
  */
 
-import { YAMLGenerator, DetectionResult, GenerationOptions, WorkflowOutput, WorkflowType, ValidationResult, TemplateOverrides, PolicyConfig } from './interfaces';
+import { YAMLGenerator, CheckResult, DetectionResult, GenerationOptions, WorkflowOutput, WorkflowType, ValidationResult, TemplateOverrides, PolicyConfig, Provider, MonorepoPackage } from './interfaces';
 import { WorkflowValidator } from './validators/workflow-validator';
 import { WorkflowSpecializationManager } from './workflow-specialization/workflow-specialization-manager';
 import { TemplateManager } from './templates/template-manager';
@@ -17,6 +17,13 @@ import { DeploymentGenerator } from './templates/deployment-generator';
 import { SecurityStepGenerator } from './templates/security-step-generator';
 import { PerformanceMonitoringGenerator } from './templates/performance-monitoring-generator';
 import { CacheStrategyGenerator } from './utils/cache-utils';
+import { appendManagedBlock, mergeWorkflow } from './utils/workflow-merge';
+import { applyActionVersions, findPinnableActions } from './utils/action-versions';
+import { getExistingCIProviders } from './utils/ci-badges';
+import { validateCron } from './utils/cron';
+import { diffWorkflowFile, workflowsEquivalent } from './utils/workflow-diff';
+import { GITLAB_MAX_RETRIES } from './utils/retry';
+import { validateWorkflowStructure } from './validators/structure-validator';
 // Advanced generators
 import { AdvancedPatternGenerator, AdvancedPatternConfig } from './workflow-specialization/advanced-pattern-generator';
 import { AdvancedSecurityGenerator } from './workflow-specialization/advanced-security-generator';
@@ -26,7 +33,51 @@ import { MultiEnvironmentGenerator } from './workflow-specialization/multi-envir
 import { TestingStrategyGenerator } from './workflow-specialization/testing-strategy-generator';
 import { EnhancedWorkflowValidator } from './validators/enhanced-validator';
 import { SimpleWorkflowGenerator } from './simple-workflow-generator';
+import { PipelineLogger, NOOP_LOGGER } from '../shared/logging/pipeline-logger';
 import * as path from 'path';
+import { promises as fs } from 'fs';
+
+/**
+ * Directory GitHub Actions reads workflows from, relative to the repository root
+ */
+export const GITHUB_WORKFLOWS_DIRECTORY = '.github/workflows';
+
+/**
+ * Where --emit composite-action writes the composite action, relative to the repository root;
+ * workflows run it with `uses: ./.github/actions/ci`
+ */
+export const COMPOSITE_ACTION_PATH = '.github/actions/ci/action.yml';
+
+/**
+ * Directory CircleCI reads its config from, relative to the repository root
+ */
+export const CIRCLECI_CONFIG_DIRECTORY = '.circleci';
+
+/**
+ * Providers whose whole configuration is a single CI pipeline file
+ */
+export const SINGLE_PIPELINE_PROVIDERS: Provider[] = [
+  Provider.GitLab, Provider.CircleCI, Provider.AzurePipelines, Provider.BitbucketPipelines, Provider.Jenkins
+];
+
+/**
+ * Repository-relative POSIX path a generated workflow is written to.
+ * GitLab, Azure Pipelines, Bitbucket Pipelines and Jenkins read their pipeline from the repository
+ * root, CircleCI from .circleci and GitHub from .github/workflows.
+ */
+export function getWorkflowOutputPath(filename: string, provider?: Provider): string {
+  switch (provider) {
+    case Provider.GitLab:
+    case Provider.AzurePipelines:
+    case Provider.BitbucketPipelines:
+    case Provider.Jenkins:
+      return filename;
+    case Provider.CircleCI:
+      return `${CIRCLECI_CONFIG_DIRECTORY}/${filename}`;
+    default:
+      return `${GITHUB_WORKFLOWS_DIRECTORY}/${filename}`;
+  }
+}
 
 /**
  * Main YAML Generator class that orchestrates workflow generation
@@ -55,6 +106,7 @@ export class YAMLGeneratorImpl implements YAMLGenerator {
   private multiEnvironmentGenerator: MultiEnvironmentGenerator;
   private testingStrategyGenerator: TestingStrategyGenerator;
   private simpleWorkflowGenerator: SimpleWorkflowGenerator;
+  private logger: PipelineLogger;
   private generatorVersion: string = '2.0.0';
 
   constructor(options?: {
@@ -63,7 +115,11 @@ export class YAMLGeneratorImpl implements YAMLGenerator {
     cacheEnabled?: boolean;
     agentHooksConfig?: Partial<AgentHooksConfig>;
     advancedPatternsEnabled?: boolean;
+    /** Receives each generation step at debug level; nothing is logged without one */
+    logger?: PipelineLogger;
   }) {
+    this.logger = options?.logger || NOOP_LOGGER;
+
     // Initialize validators
     this.validator = new WorkflowValidator({
       strictMode: true,
@@ -120,6 +176,16 @@ export class YAMLGeneratorImpl implements YAMLGenerator {
       // Set default options
       const workflowOptions = this.setDefaultOptions(options);
 
+      if (workflowOptions.provider && SINGLE_PIPELINE_PROVIDERS.includes(workflowOptions.provider) && workflowOptions.workflowType !== 'ci') {
+        throw new Error(`The ${workflowOptions.provider} provider only supports ci workflows, got '${workflowOptions.workflowType}'`);
+      }
+
+      this.logger.debug('Generating workflow', {
+        workflowType: workflowOptions.workflowType,
+        provider: workflowOptions.provider || Provider.GitHubActions,
+        preset: workflowOptions.preset
+      });
+
       // Apply organization policies
       const processedOptions = this.applyOrganizationPolicies(workflowOptions, detectionResult);
 
@@ -133,18 +199,35 @@ export class YAMLGeneratorImpl implements YAMLGenerator {
         processedOptions
       );
 
+      // A preset can leave the workflow without jobs; there is nothing to enhance or write
+      if (workflow.content === '') {
+        this.logger.debug('Workflow has no jobs - nothing to write', { workflowType: processedOptions.workflowType, warnings: workflow.metadata.warnings });
+        return workflow;
+      }
+      this.logger.debug('Specialized workflow generated', {
+        filename: workflow.filename,
+        optimizations: workflow.metadata.optimizations,
+        warnings: workflow.metadata.warnings
+      });
+
       // Apply framework-specific enhancements
       const enhancedWorkflow = await this.applyFrameworkEnhancements(workflow, detectionResult, processedOptions);
 
       // Apply environment management
       const finalWorkflow = await this.applyEnvironmentManagement(enhancedWorkflow, detectionResult, processedOptions);
 
-      // Validate the generated workflow
-      const validationResult = this.validateWorkflow(finalWorkflow.content);
-      if (!validationResult.isValid) {
-        finalWorkflow.metadata.warnings.push(...validationResult.errors.map(e => e.message));
+      // Validate the generated workflow (the validator only understands the GitHub Actions schema)
+      if (!processedOptions.provider || processedOptions.provider === Provider.GitHubActions) {
+        const validationResult = this.validateWorkflow(finalWorkflow.content);
+        if (!validationResult.isValid) {
+          this.logger.debug('Workflow failed validation', { filename: finalWorkflow.filename, errors: validationResult.errors.map(e => e.message) });
+          finalWorkflow.metadata.warnings.push(...validationResult.errors.map(e => e.message));
+        }
+        finalWorkflow.content = await this.withActionVersions(finalWorkflow, processedOptions);
+        finalWorkflow.content = this.withManagedBlock(finalWorkflow);
       }
 
+      this.logger.debug('Workflow generated', { filename: finalWorkflow.filename, size: finalWorkflow.content.length });
       return finalWorkflow;
     } catch (error) {
       throw new Error(`Failed to generate workflow: ${error instanceof Error ? error.message : 'Unknown error'}`);
@@ -155,13 +238,17 @@ export class YAMLGeneratorImpl implements YAMLGenerator {
    * Generate multiple workflows for different types
    * Implements requirement 10.2: Multiple workflow type generation
    */
-  async generateMultipleWorkflows(detectionResult: DetectionResult, workflowTypes: WorkflowType[]): Promise<WorkflowOutput[]> {
+  async generateMultipleWorkflows(
+    detectionResult: DetectionResult,
+    workflowTypes: WorkflowType[],
+    options?: GenerationOptions
+  ): Promise<WorkflowOutput[]> {
     try {
       const workflows: WorkflowOutput[] = [];
       const errors: string[] = [];
 
       // Validate workflow compatibility
-      const baseOptions = this.setDefaultOptions();
+      const baseOptions = this.setDefaultOptions(options);
       const compatibility = this.workflowSpecializationManager.validateWorkflowCompatibility(
         workflowTypes,
         detectionResult,
@@ -172,10 +259,44 @@ export class YAMLGeneratorImpl implements YAMLGenerator {
         throw new Error(`Incompatible workflow types: ${compatibility.errors.join(', ')}`);
       }
 
+      // Reject a bad schedule before anything is generated
+      const scheduleProblem = baseOptions.schedule ? validateCron(baseOptions.schedule) : undefined;
+      if (scheduleProblem) {
+        throw new Error(`Invalid schedule '${baseOptions.schedule}': ${scheduleProblem}`);
+      }
+      this.validateLimits(baseOptions);
+
+      // README badges show the project already has CI; skip it on request
+      const existingCI = getExistingCIProviders(detectionResult);
+      const skipCI = existingCI.length > 0 && baseOptions.existingCI === 'skip';
+      if (skipCI && workflowTypes.includes('ci')) {
+        console.warn(`Skipping ci workflow: an existing CI badge for ${existingCI.join(', ')} was found`);
+      }
+
+      // The tag release workflow is written as release.yml, in place of the release workflow type
+      const github = !baseOptions.provider || baseOptions.provider === Provider.GitHubActions;
+      const tagRelease = baseOptions.tagRelease && github;
+      if (baseOptions.tagRelease && !github) {
+        console.warn(`Skipping release.yml: tag releases are only generated for GitHub Actions, not ${baseOptions.provider}`);
+      }
+      const split = baseOptions.split === 'by-kind' && github;
+      if (baseOptions.split === 'by-kind' && !github) {
+        console.warn(`Not splitting the ci workflow: split workflows are only generated for GitHub Actions, not ${baseOptions.provider}`);
+      }
+
       // Generate each workflow type
       for (const workflowType of workflowTypes) {
+        if ((skipCI && workflowType === 'ci') || (tagRelease && workflowType === 'release')) {
+          this.logger.debug('Skipping workflow type', { workflowType, reason: skipCI && workflowType === 'ci' ? 'existing CI badge' : 'replaced by the tag release workflow' });
+          continue;
+        }
+
         try {
           const workflowOptions = { ...baseOptions, workflowType };
+          if (split && workflowType === 'ci') {
+            workflows.push(...await this.generateSplitCIWorkflows(detectionResult, workflowOptions));
+            continue;
+          }
           const workflow = await this.generateWorkflow(detectionResult, workflowOptions);
           workflows.push(workflow);
         } catch (error) {
@@ -184,6 +305,26 @@ export class YAMLGeneratorImpl implements YAMLGenerator {
         }
       }
 
+      // The nightly workflow reuses the CI jobs, so it comes with the ci workflow
+      if (baseOptions.schedule && github && workflowTypes.includes('ci') && !skipCI) {
+        try {
+          const nightly = await this.generateNightlyWorkflow(detectionResult, baseOptions);
+          if (nightly.content !== '') {
+            workflows.push(nightly);
+          }
+        } catch (error) {
+          errors.push(`Failed to generate nightly workflow: ${error instanceof Error ? error.message : 'Unknown error'}`);
+        }
+      }
+
+      if (tagRelease) {
+        try {
+          workflows.push(await this.generateTagReleaseWorkflow(detectionResult, baseOptions));
+        } catch (error) {
+          errors.push(`Failed to generate release workflow: ${error instanceof Error ? error.message : 'Unknown error'}`);
+        }
+      }
+
       // Add coordination metadata
       const coordination = this.workflowSpecializationManager.generateWorkflowCoordination(workflows);
       workflows.forEach(workflow => {
@@ -206,6 +347,252 @@ export class YAMLGeneratorImpl implements YAMLGenerator {
     }
   }
 
+  /**
+   * Generate nightly.yml, the CI build and test jobs on the options.schedule cron
+   */
+  async generateNightlyWorkflow(detectionResult: DetectionResult, options?: GenerationOptions): Promise<WorkflowOutput> {
+    const workflowOptions = this.setDefaultOptions(options);
+    const workflow = await this.workflowSpecializationManager.generateNightlyWorkflow(detectionResult, workflowOptions);
+
+    if (workflow.content !== '') {
+      const validationResult = this.validateWorkflow(workflow.content);
+      if (!validationResult.isValid) {
+        workflow.metadata.warnings.push(...validationResult.errors.map(e => e.message));
+      }
+      workflow.content = await this.withActionVersions(workflow, workflowOptions);
+      workflow.content = this.withManagedBlock(workflow);
+    }
+
+    return workflow;
+  }
+
+  /**
+   * Generate the CI workflow as build.yml, test.yml and lint.yml (options.split by-kind), with the
+   * build jobs they share in setup.yml unless options.splitSetup duplicates them
+   */
+  async generateSplitCIWorkflows(detectionResult: DetectionResult, options?: GenerationOptions): Promise<WorkflowOutput[]> {
+    const workflowOptions = this.setDefaultOptions(options);
+    const workflows = await this.workflowSpecializationManager.generateSplitCIWorkflows(detectionResult, workflowOptions);
+
+    for (const workflow of workflows) {
+      const validationResult = this.validateWorkflow(workflow.content);
+      if (!validationResult.isValid) {
+        workflow.metadata.warnings.push(...validationResult.errors.map(e => e.message));
+      }
+      workflow.content = await this.withActionVersions(workflow, workflowOptions);
+      workflow.content = this.withManagedBlock(workflow);
+    }
+
+    return workflows;
+  }
+
+  /**
+   * Generate release.yml, building pushed version tags and publishing them as GitHub Releases
+   */
+  async generateTagReleaseWorkflow(detectionResult: DetectionResult, options?: GenerationOptions): Promise<WorkflowOutput> {
+    const workflowOptions = this.setDefaultOptions(options);
+    const workflow = await this.workflowSpecializationManager.generateTagReleaseWorkflow(detectionResult, workflowOptions);
+
+    const validationResult = this.validateWorkflow(workflow.content);
+    if (!validationResult.isValid) {
+      workflow.metadata.warnings.push(...validationResult.errors.map(e => e.message));
+    }
+    workflow.content = await this.withActionVersions(workflow, workflowOptions);
+    workflow.content = this.withManagedBlock(workflow);
+
+    return workflow;
+  }
+
+  /**
+   * Generate action.yml, a composite action with the CI setup, build and unit test steps
+   * (--emit composite-action). Its content is empty, with a warning, when there is nothing to bundle.
+   */
+  async generateCompositeAction(detectionResult: DetectionResult, options?: GenerationOptions): Promise<WorkflowOutput> {
+    const actionOptions = this.setDefaultOptions(options);
+    const action = await this.workflowSpecializationManager.generateCompositeAction(detectionResult, actionOptions);
+    if (action.content !== '') {
+      action.content = await this.withActionVersions(action, actionOptions);
+    }
+    return action;
+  }
+
+  /**
+   * Generate CI workflows for monorepo packages detected by the framework detector.
+   * `monorepoLayout` selects one combined workflow or one workflow file per package.
+   */
+  async generateMonorepoWorkflows(packages: MonorepoPackage[], options?: GenerationOptions): Promise<WorkflowOutput[]> {
+    try {
+      const workflowOptions = this.setDefaultOptions(options);
+      const workflows = await this.workflowSpecializationManager.generateMonorepoWorkflows(packages, workflowOptions);
+
+      for (const workflow of workflows) {
+        const validationResult = this.validateWorkflow(workflow.content);
+        if (!validationResult.isValid) {
+          workflow.metadata.warnings.push(...validationResult.errors.map(e => e.message));
+        }
+        workflow.content = await this.withActionVersions(workflow, workflowOptions);
+        workflow.content = this.withManagedBlock(workflow);
+      }
+
+      return workflows;
+    } catch (error) {
+      throw new Error(`Failed to generate monorepo workflows: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  /**
+   * Explain, per job of the monorepo CI workflows, which of the files a pull request changes
+   * match its path filters and through which pattern, for checking the generated filters against
+   * real pull requests. Jobs the files would not run are listed with no files.
+   */
+  whyTriggered(packages: MonorepoPackage[], changedFiles: string[], options?: GenerationOptions): Record<string, string[]> {
+    return this.workflowSpecializationManager.whyTriggered(packages, changedFiles, this.setDefaultOptions(options));
+  }
+
+  /**
+   * Generate workflows without touching the file system.
+   * Keys are the repository-relative paths `generate` writes to, so callers can diff against existing files.
+   * Workflows a preset leaves without jobs have nothing to write and are left out.
+   */
+  async generateToMap(
+    detectionResult: DetectionResult,
+    workflowTypes: WorkflowType[],
+    options?: GenerationOptions
+  ): Promise<Record<string, string>> {
+    const workflows = await this.generateMultipleWorkflows(detectionResult, workflowTypes, options);
+    const files: Record<string, string> = {};
+
+    for (const workflow of workflows.filter(workflow => workflow.content !== '')) {
+      const outputPath = getWorkflowOutputPath(workflow.filename, options?.provider);
+      if (files[outputPath] !== undefined) {
+        throw new Error(`Failed to generate workflow files: more than one workflow maps to ${outputPath}`);
+      }
+      files[outputPath] = workflow.content;
+    }
+
+    return files;
+  }
+
+  /**
+   * Generate workflows and write them below rootDir, as the postProcess hook leaves them.
+   * Returns the absolute paths of the files written.
+   */
+  async generate(
+    detectionResult: DetectionResult,
+    workflowTypes: WorkflowType[],
+    rootDir: string,
+    options?: GenerationOptions
+  ): Promise<string[]> {
+    const files = await this.prepareFiles(await this.generateToMap(detectionResult, workflowTypes, options), options);
+    const written: string[] = [];
+
+    try {
+      for (const [outputPath, content] of Object.entries(files)) {
+        const filePath = path.join(rootDir, ...outputPath.split('/'));
+        await fs.mkdir(path.dirname(filePath), { recursive: true });
+        await fs.writeFile(filePath, content, 'utf8');
+        written.push(filePath);
+      }
+    } catch (error) {
+      throw new Error(`Failed to write workflow files: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+
+    return written;
+  }
+
+  /**
+   * Files generate writes and check compares. The generated ones are validated first; the
+   * postProcess hook then gets them and what it returns is validated again, as it may have
+   * changed their structure. Without a hook the generated files are returned as they are.
+   * Files failing validation are refused unless options.force is set.
+   */
+  private async prepareFiles(files: Record<string, string>, options?: GenerationOptions): Promise<Record<string, string>> {
+    const invalid = this.validateFiles(files, options);
+    if (invalid.length > 0 && !options?.force) {
+      throw new Error(`Generated workflow files failed validation and were not written (set force to write them anyway):\n  ${invalid.join('\n  ')}`);
+    }
+    if (!options?.postProcess) {
+      return files;
+    }
+
+    let processed: Record<string, string>;
+    try {
+      processed = await options.postProcess({ ...files });
+    } catch (error) {
+      throw new Error(`Failed to post-process workflow files: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+    if (!processed || typeof processed !== 'object' || Array.isArray(processed)) {
+      throw new Error('Failed to post-process workflow files: postProcess must return a map of output paths to file contents');
+    }
+
+    const missing = Object.entries(processed).filter(([, content]) => typeof content !== 'string');
+    if (missing.length > 0) {
+      throw new Error(`Failed to post-process workflow files: postProcess returned no file contents for ${missing.map(([outputPath]) => outputPath).join(', ')}`);
+    }
+    const problems = this.validateFiles(processed, options);
+    if (problems.length > 0 && !options.force) {
+      throw new Error(`Post-processed workflow files failed validation and were not written (set force to write them anyway):\n  ${problems.join('\n  ')}`);
+    }
+    return processed;
+  }
+
+  /**
+   * Structural problems of the workflow files among files, prefixed with their paths
+   */
+  private validateFiles(files: Record<string, string>, options?: GenerationOptions): string[] {
+    return Object.entries(files)
+      .filter(([outputPath]) => /(\.ya?ml|(^|\/)Jenkinsfile)$/.test(outputPath))
+      .flatMap(([outputPath, content]) => validateWorkflowStructure(content, options?.provider).map(error => `${outputPath}: ${error.message}`));
+  }
+
+  /**
+   * Compare the files generate would write with the ones committed below rootDir, for failing
+   * CI when the workflows are out of date. A missing file counts as out of date.
+   */
+  async check(
+    detectionResult: DetectionResult,
+    workflowTypes: WorkflowType[],
+    rootDir: string,
+    options?: GenerationOptions
+  ): Promise<CheckResult> {
+    const files = await this.prepareFiles(await this.generateToMap(detectionResult, workflowTypes, options), options);
+    const diffs: string[] = [];
+
+    for (const [outputPath, content] of Object.entries(files)) {
+      let existing: string | undefined;
+      try {
+        existing = await fs.readFile(path.join(rootDir, ...outputPath.split('/')), 'utf8');
+      } catch {
+        existing = undefined;
+      }
+
+      if (existing === undefined || !workflowsEquivalent(existing, content)) {
+        diffs.push(diffWorkflowFile(outputPath, existing, content));
+      }
+    }
+
+    return { upToDate: diffs.length === 0, diff: diffs.join('\n') };
+  }
+
+  /**
+   * Generate a workflow and merge it into an existing one instead of overwriting it.
+   * Only jobs and steps recorded in the existing file's managed block are updated; user-added
+   * jobs and steps are preserved and managed steps the user deleted are not re-added.
+   */
+  async generateWithMerge(detectionResult: DetectionResult, existing: string, options?: GenerationOptions): Promise<string> {
+    if (options?.provider && options.provider !== Provider.GitHubActions) {
+      throw new Error(`Failed to merge workflow: merging is only supported for GitHub Actions, got '${options.provider}'`);
+    }
+
+    const workflow = await this.generateWorkflow(detectionResult, options);
+
+    try {
+      return mergeWorkflow(existing, workflow.content);
+    } catch (error) {
+      throw new Error(`Failed to merge workflow: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
   /**
    * Generate advanced workflow patterns (monorepo, microservices, canary, etc.)
    * Implements requirements 15.1, 15.2, 15.3, 15.4, 15.5
@@ -576,6 +963,25 @@ jobs:
     };
   }
 
+  /**
+   * Reject test retries, job timeouts and artifact retention that are not positive integers, and
+   * more retries than GitLab allows since it retries the whole job
+   */
+  private validateLimits(options: GenerationOptions): void {
+    if (options.testRetries !== undefined && (!Number.isInteger(options.testRetries) || options.testRetries < 1)) {
+      throw new Error(`Invalid test retries '${options.testRetries}': must be a positive integer`);
+    }
+    if (options.jobTimeout !== undefined && (!Number.isInteger(options.jobTimeout) || options.jobTimeout < 1)) {
+      throw new Error(`Invalid job timeout '${options.jobTimeout}': must be a positive whole number of minutes`);
+    }
+    if (options.artifactRetentionDays !== undefined && (!Number.isInteger(options.artifactRetentionDays) || options.artifactRetentionDays < 1)) {
+      throw new Error(`Invalid artifact retention '${options.artifactRetentionDays}': must be a positive whole number of days`);
+    }
+    if (options.provider === Provider.GitLab && (options.testRetries || 0) > GITLAB_MAX_RETRIES) {
+      throw new Error(`Invalid test retries '${options.testRetries}': GitLab retries a job at most ${GITLAB_MAX_RETRIES} times`);
+    }
+  }
+
   /**
    * Set default generation options
    * Implements requirement 10.5: Default configuration management
@@ -612,6 +1018,206 @@ jobs:
     if (options?.testingStrategy) {
       result.testingStrategy = options.testingStrategy;
     }
+    if (options?.provider) {
+      result.provider = options.provider;
+    }
+    if (options?.useOrbs) {
+      result.useOrbs = options.useOrbs;
+    }
+    if (options?.monorepoLayout) {
+      result.monorepoLayout = options.monorepoLayout;
+    }
+
+    if (options?.preset) {
+      result.preset = options.preset;
+    }
+    if (options?.coverage) {
+      result.coverage = options.coverage;
+    }
+    if (options?.defaultBranch) {
+      result.defaultBranch = options.defaultBranch;
+    }
+    if (options?.cancelInProgress !== undefined) {
+      result.cancelInProgress = options.cancelInProgress;
+    }
+    if (options?.permissions !== undefined) {
+      result.permissions = options.permissions;
+    }
+    if (options?.split !== undefined) {
+      result.split = options.split;
+    }
+    if (options?.splitSetup !== undefined) {
+      result.splitSetup = options.splitSetup;
+    }
+    if (options?.userWorkflows !== undefined) {
+      result.userWorkflows = options.userWorkflows;
+    }
+    if (options?.schedule) {
+      result.schedule = options.schedule;
+    }
+    if (options?.testRetries !== undefined) {
+      result.testRetries = options.testRetries;
+    }
+    if (options?.jobTimeout !== undefined) {
+      result.jobTimeout = options.jobTimeout;
+    }
+    if (options?.privateModules) {
+      result.privateModules = options.privateModules;
+    }
+    if (options?.privateModulesSecret) {
+      result.privateModulesSecret = options.privateModulesSecret;
+    }
+    if (options?.artifacts) {
+      result.artifacts = options.artifacts;
+    }
+    if (options?.artifactPath) {
+      result.artifactPath = options.artifactPath;
+    }
+    if (options?.artifactName) {
+      result.artifactName = options.artifactName;
+    }
+    if (options?.artifactRetentionDays !== undefined) {
+      result.artifactRetentionDays = options.artifactRetentionDays;
+    }
+    if (options?.preCommit) {
+      result.preCommit = options.preCommit;
+    }
+    if (options?.terraform) {
+      result.terraform = options.terraform;
+    }
+    if (options?.licenseCheck) {
+      result.licenseCheck = options.licenseCheck;
+    }
+    if (options?.licenseAllowlist) {
+      result.licenseAllowlist = options.licenseAllowlist;
+    }
+    if (options?.changelogCheck) {
+      result.changelogCheck = options.changelogCheck;
+    }
+    if (options?.changelogSkipLabel) {
+      result.changelogSkipLabel = options.changelogSkipLabel;
+    }
+    if (options?.codeownersComment) {
+      result.codeownersComment = options.codeownersComment;
+    }
+    if (options?.apiLint) {
+      result.apiLint = options.apiLint;
+    }
+    if (options?.protobuf) {
+      result.protobuf = options.protobuf;
+    }
+    if (options?.bufBreakingBranch) {
+      result.bufBreakingBranch = options.bufBreakingBranch;
+    }
+    if (options?.postProcess) {
+      result.postProcess = options.postProcess;
+    }
+    if (options?.phoenixDatabase) {
+      result.phoenixDatabase = options.phoenixDatabase;
+    }
+    if (options?.compilerMatrix) {
+      result.compilerMatrix = options.compilerMatrix;
+    }
+    if (options?.swiftLinux) {
+      result.swiftLinux = options.swiftLinux;
+    }
+    if (options?.flutterBuild) {
+      result.flutterBuild = options.flutterBuild;
+    }
+    if (options?.provenance) {
+      result.provenance = options.provenance;
+    }
+    if (options?.minimal) {
+      result.minimal = options.minimal;
+    }
+    if (options?.injectSteps) {
+      result.injectSteps = options.injectSteps;
+    }
+    if (options?.reusable) {
+      result.reusable = options.reusable;
+    }
+    if (options?.tagRelease) {
+      result.tagRelease = options.tagRelease;
+    }
+    if (options?.releaseTagPrefix !== undefined) {
+      result.releaseTagPrefix = options.releaseTagPrefix;
+    }
+
+    if (options?.monorepoChangeDetection) {
+      result.monorepoChangeDetection = options.monorepoChangeDetection;
+    }
+    if (options?.containerRegistry) {
+      result.containerRegistry = options.containerRegistry;
+    }
+    if (options?.testRunnerLayout) {
+      result.testRunnerLayout = options.testRunnerLayout;
+    }
+    if (options?.existingCI) {
+      result.existingCI = options.existingCI;
+    }
+    if (options?.makeCI) {
+      result.makeCI = options.makeCI;
+    }
+    if (options?.runnerOS) {
+      result.runnerOS = options.runnerOS;
+    }
+    if (options?.runnerLabels) {
+      result.runnerLabels = options.runnerLabels;
+    }
+    if (options?.runnerImages) {
+      result.runnerImages = options.runnerImages;
+    }
+    if (options?.actionVersions) {
+      result.actionVersions = options.actionVersions;
+    }
+    if (options?.pinActions) {
+      result.pinActions = options.pinActions;
+    }
+    if (options?.resolveActionSha) {
+      result.resolveActionSha = options.resolveActionSha;
+    }
+    if (options?.matrixExclude) {
+      result.matrixExclude = options.matrixExclude;
+    }
+    if (options?.matrixInclude) {
+      result.matrixInclude = options.matrixInclude;
+    }
+    if (options?.disabledJobs) {
+      result.disabledJobs = options.disabledJobs;
+    }
+    if (options?.jobEnv) {
+      result.jobEnv = options.jobEnv;
+    }
+    if (options?.testOS) {
+      result.testOS = options.testOS;
+    }
+    if (options?.envExampleExclude) {
+      result.envExampleExclude = options.envExampleExclude;
+    }
+    if (options?.checkoutSubmodules !== undefined) {
+      result.checkoutSubmodules = options.checkoutSubmodules;
+    }
+    if (options?.checkoutFetchDepth !== undefined) {
+      result.checkoutFetchDepth = options.checkoutFetchDepth;
+    }
+    if (options?.cargoWorkspaceLayout) {
+      result.cargoWorkspaceLayout = options.cargoWorkspaceLayout;
+    }
+    if (options?.nodeWorkspaceLayout) {
+      result.nodeWorkspaceLayout = options.nodeWorkspaceLayout;
+    }
+    if (options?.cargoFeatureMatrix) {
+      result.cargoFeatureMatrix = options.cargoFeatureMatrix;
+    }
+    if (options?.deployPages) {
+      result.deployPages = options.deployPages;
+    }
+    if (options?.pagesOutputDir) {
+      result.pagesOutputDir = options.pagesOutputDir;
+    }
+    if (options?.deployEnvironment) {
+      result.deployEnvironment = options.deployEnvironment;
+    }
 
     return result;
   }
@@ -978,6 +1584,43 @@ jobs:
   /**
    * Render workflow template to YAML content
    */
+  /**
+   * Record the generated jobs and steps so a later merge knows which ones it owns
+   */
+  /**
+   * Content of a GitHub workflow with its actions at the refs of the action version registry and
+   * options.actionVersions, pinned to commit SHAs with options.pinActions. Actions whose SHA
+   * cannot be resolved keep their ref, with a warning.
+   */
+  private async withActionVersions(workflow: WorkflowOutput, options: GenerationOptions): Promise<string> {
+    const shas: Record<string, string> = {};
+    if (options.pinActions) {
+      for (const reference of findPinnableActions(workflow.content, options.actionVersions)) {
+        const [action, ref] = reference.split('@') as [string, string];
+        const repository = action.split('/').slice(0, 2).join('/');
+        const sha = await Promise.resolve()
+          .then(() => options.resolveActionSha?.(repository, ref))
+          .catch(() => undefined);
+        if (sha) {
+          shas[reference] = sha;
+        }
+      }
+    }
+
+    const result = applyActionVersions(workflow.content, { overrides: options.actionVersions, pin: options.pinActions, shas });
+    workflow.metadata.warnings.push(...result.warnings);
+    return result.content;
+  }
+
+  private withManagedBlock(workflow: WorkflowOutput): string {
+    try {
+      return appendManagedBlock(workflow.content);
+    } catch (error) {
+      workflow.metadata.warnings.push(`Managed block not added: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      return workflow.content;
+    }
+  }
+
   private async renderWorkflowTemplate(workflow: any): Promise<string> {
     // This is a simplified implementation
     // In a real implementation, you would use a proper YAML renderer
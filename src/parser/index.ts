@@ -12,6 +12,8 @@ export { ReadmeParserImpl } from './readme-parser';
 export { LanguageDetector } from './analyzers/language-detector';
 export { CommandExtractor } from './analyzers/command-extractor';
 export { MarkdownParser } from './utils/markdown-parser';
+export { findReadme } from './utils/readme-locator';
+export { MarkupParser, registerMarkupParser, getReadmeFormat, toMarkdown } from './utils/markup-parser';
 
 // Base analyzer classes
 export { BaseAnalyzer } from './analyzers/base-analyzer';
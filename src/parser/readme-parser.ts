@@ -9,13 +9,21 @@ import {
   ProjectInfo, 
   ParseError,
   ContentAnalyzer,
-  AnalysisResult
+  AnalysisResult,
+  CommandKeywordMap
 } from './types';
 import { logger } from '../shared/logging/central-logger';
+import { stripBOM } from '../shared/input-normalization';
 import { AnalyzerRegistry } from './analyzers/analyzer-registry';
 import { FileReader } from './utils/file-reader';
 import { MarkdownParser } from './utils/markdown-parser';
 import { ResultAggregator } from './utils/result-aggregator';
+import { ShellCommandClassifier } from './utils/shell-command-classifier';
+import { BadgeExtractor } from './utils/badge-extractor';
+import { SecretExtractor } from './utils/secret-extractor';
+import { PrerequisiteExtractor } from './utils/prerequisite-extractor';
+import { TestedVersionExtractor } from './utils/tested-version-extractor';
+import { getReadmeFormat, toMarkdown } from './utils/markup-parser';
 import { 
   LanguageDetectorAdapter,
   DependencyExtractorAdapter,
@@ -56,6 +64,11 @@ export class ReadmeParserImpl implements ReadmeParser {
   private streamingFileReader: StreamingFileReader;
   private markdownParser: MarkdownParser;
   private resultAggregator: ResultAggregator;
+  private shellCommandClassifier: ShellCommandClassifier;
+  private badgeExtractor: BadgeExtractor;
+  private secretExtractor: SecretExtractor;
+  private prerequisiteExtractor: PrerequisiteExtractor;
+  private testedVersionExtractor: TestedVersionExtractor;
   private astCache: ASTCache;
   private performanceMonitor: PerformanceMonitor;
   private integrationPipeline?: IntegrationPipeline | null;
@@ -69,6 +82,7 @@ export class ReadmeParserImpl implements ReadmeParser {
       cacheOptions?: any;
       performanceOptions?: any;
       useIntegrationPipeline?: boolean;
+      commandKeywords?: Partial<CommandKeywordMap>;
     }
   ) {
     this.analyzerRegistry = new AnalyzerRegistry();
@@ -76,6 +90,11 @@ export class ReadmeParserImpl implements ReadmeParser {
     this.streamingFileReader = new StreamingFileReader();
     this.markdownParser = new MarkdownParser();
     this.resultAggregator = new ResultAggregator();
+    this.shellCommandClassifier = new ShellCommandClassifier(options?.commandKeywords);
+    this.badgeExtractor = new BadgeExtractor();
+    this.secretExtractor = new SecretExtractor();
+    this.prerequisiteExtractor = new PrerequisiteExtractor();
+    this.testedVersionExtractor = new TestedVersionExtractor();
     
     // Initialize performance features
     this.astCache = options?.enableCaching !== false ? 
@@ -248,7 +267,7 @@ export class ReadmeParserImpl implements ReadmeParser {
         }
 
         // Parse the content using the file content
-        return await this.parseContent(readResult.data.content);
+        return await this.parseReadmeContent(filePath, readResult.data.content);
       } else {
         // Use regular FileReader for small files
         const readResult = await this.fileReader.readFile(filePath);
@@ -266,11 +285,24 @@ export class ReadmeParserImpl implements ReadmeParser {
         }
 
         // Parse the content using the file content
-        return await this.parseContent(readResult.data.content);
+        return await this.parseReadmeContent(filePath, readResult.data.content);
       }
     }, { filePath });
   }
 
+  /**
+   * Parse the content of a README file, without its byte order mark and converted to Markdown
+   * when written in another markup, recording the file in the result
+   */
+  private async parseReadmeContent(filePath: string, content: string): Promise<ParseResult> {
+    const format = getReadmeFormat(filePath);
+    const result = await this.parseContent(toMarkdown(stripBOM(content), format));
+    if (result.success && result.data) {
+      result.data.readme = { path: filePath, format };
+    }
+    return result;
+  }
+
   /**
    * Parse README content directly from a string and extract structured project information.
    * 
@@ -528,6 +560,11 @@ export class ReadmeParserImpl implements ReadmeParser {
         success: true,
         data: {
           ...projectInfo,
+          classifiedCommands: this.shellCommandClassifier.classify(ast),
+          badges: this.badgeExtractor.extract(content),
+          requiredSecrets: this.secretExtractor.extract(content),
+          prerequisites: this.prerequisiteExtractor.extract(content),
+          testedVersions: this.testedVersionExtractor.extract(content),
           confidence: {
             ...projectInfo.confidence,
             overall: Math.max(projectInfo.confidence.overall * confidenceAdjustment, 0.75) // Higher minimum for pipeline
@@ -850,6 +887,11 @@ export class ReadmeParserImpl implements ReadmeParser {
         success: true,
         data: {
           ...projectInfo,
+          classifiedCommands: this.shellCommandClassifier.classify(ast),
+          badges: this.badgeExtractor.extract(content),
+          requiredSecrets: this.secretExtractor.extract(content),
+          prerequisites: this.prerequisiteExtractor.extract(content),
+          testedVersions: this.testedVersionExtractor.extract(content),
           confidence: {
             ...projectInfo.confidence,
             overall: Math.max(projectInfo.confidence.overall * finalConfidenceMultiplier, 0.7) // Ensure minimum confidence
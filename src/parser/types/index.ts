@@ -50,10 +50,22 @@ export interface ProjectInfo {
   dependencies: DependencyInfo;
   /** Extracted commands (build, test, run, install) */
   commands: CommandInfo;
+  /** Commands documented in shell fences, classified by intent */
+  classifiedCommands?: ClassifiedCommand[];
   /** Testing framework and tool information */
   testing: TestingInfo;
   /** CI/CD information */
   cicd?: CICDInfo;
+  /** Status badges shown in the README */
+  badges?: Badge[];
+  /** Secrets the README tells users to set in their environment */
+  requiredSecrets?: RequiredSecret[];
+  /** Items of the README's Requirements/Prerequisites section */
+  prerequisites?: Prerequisite[];
+  /** Language versions the README says the project is tested on or supports */
+  testedVersions?: TestedVersions[];
+  /** README file the information was read from, when parsed from a file */
+  readme?: ReadmeLocation;
   /** Confidence scores for each analysis category */
   confidence: ConfidenceScores;
 }
@@ -137,6 +149,30 @@ export interface Command {
   context?: string;
 }
 
+/**
+ * Intent bucket a documented shell command is classified into
+ */
+export type CommandBucket = 'install' | 'build' | 'test' | 'lint' | 'run';
+
+/**
+ * Keyword prefixes used to classify shell commands, per bucket
+ */
+export type CommandKeywordMap = Record<CommandBucket, string[]>;
+
+/**
+ * Shell command found in a fenced code block, classified by intent
+ */
+export interface ClassifiedCommand {
+  /** Line(s) as written in the README, including prompts and continuations */
+  raw: string;
+  /** Normalized command with prompts stripped and continuations joined */
+  command: string;
+  /** Detected intent bucket */
+  bucket: CommandBucket;
+  /** Language hint of the enclosing fence (e.g. bash, sh) */
+  language: string;
+}
+
 /**
  * Command associated with language context for context-aware extraction
  */
@@ -225,6 +261,82 @@ export interface CICDInfo {
   confidence: number;
 }
 
+/**
+ * CI provider a status badge reports on
+ */
+export type BadgeProvider = 'github-actions' | 'travis' | 'circleci' | 'gitlab' | 'azure-pipelines' | 'appveyor';
+
+/**
+ * Badge image found in the README, with the link it points at
+ */
+export interface Badge {
+  /** Alt text of the image */
+  alt: string;
+  /** Badge image URL */
+  imageUrl: string;
+  /** URL the badge links to, if it is wrapped in a link */
+  targetUrl?: string;
+  /** CI provider inferred from the image or target host; unset for non-CI badges */
+  provider?: BadgeProvider;
+}
+
+/**
+ * How the README introduces an environment variable: `export NAME=...`, an `env:` block or "set NAME"
+ */
+export type SecretContext = 'export' | 'env' | 'set';
+
+/**
+ * Environment variable the README requires, to be provided to CI as a secret
+ */
+export interface RequiredSecret {
+  name: string;
+  context: SecretContext;
+  /** 1-based README line of the first mention */
+  line: number;
+}
+
+/**
+ * What a prerequisite is: a package from the system package manager, a language runtime, or a service the project connects to
+ */
+export type PrerequisiteKind = 'system-package' | 'runtime' | 'service';
+
+/**
+ * Item listed under a Requirements/Prerequisites heading
+ */
+export interface Prerequisite {
+  /** Normalized name: the package name, or a runtime/service id such as python or postgres */
+  name: string;
+  kind: PrerequisiteKind;
+  /** Version written next to a runtime or service, e.g. 3.10 for "Python 3.10+" */
+  version?: string;
+  /** 1-based README line of the first mention */
+  line: number;
+}
+
+/**
+ * Versions of a language runtime the README enumerates, e.g. "Tested on Go 1.21, 1.22 and 1.23"
+ */
+export interface TestedVersions {
+  runtime: 'node' | 'go' | 'python';
+  /** Release lines oldest first, ranges expanded: "3.9–3.12" lists 3.9, 3.10, 3.11 and 3.12 */
+  versions: string[];
+  /** 1-based README line of the list */
+  line: number;
+}
+
+/**
+ * Markup a README is written in
+ */
+export type ReadmeFormat = 'markdown' | 'restructuredtext' | 'asciidoc' | 'text';
+
+/**
+ * README file of a project and its format
+ */
+export interface ReadmeLocation {
+  path: string;
+  format: ReadmeFormat;
+}
+
 // Environment variables
 export interface EnvironmentVariable {
   name: string;
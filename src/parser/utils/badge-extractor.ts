@@ -0,0 +1,174 @@
+/**
+ * BadgeExtractor - Extracts status badges from README content and infers the CI provider behind them
+ */
+
+import { Badge, BadgeProvider } from '../types';
+
+/**
+ * Path patterns of shields.io badges that report a CI provider's build status
+ */
+const SHIELDS_CI_PATHS: Array<{ pattern: RegExp; provider: BadgeProvider }> = [
+  { pattern: /^\/github\/(actions\/)?workflow\/status\//, provider: 'github-actions' },
+  { pattern: /^\/github\/checks-status\//, provider: 'github-actions' },
+  { pattern: /^\/travis\//, provider: 'travis' },
+  { pattern: /^\/circleci\//, provider: 'circleci' },
+  { pattern: /^\/gitlab\/pipeline(-status)?\//, provider: 'gitlab' },
+  { pattern: /^\/azure-devops\/(build|tests|coverage)\//, provider: 'azure-pipelines' },
+  { pattern: /^\/appveyor\//, provider: 'appveyor' }
+];
+
+/**
+ * Markdown image: inline `![alt](src)`, full reference `![alt][ref]`, collapsed `![alt][]` or shortcut `![alt]`
+ */
+const IMAGE_PATTERN = /!\[([^\]]*)\](?:\(\s*<?([^\s)>]+)>?(?:\s+"[^"]*")?\s*\)|\[([^\]]*)\])?/;
+
+/**
+ * Link reference definition: `[ref]: url "title"`
+ */
+const DEFINITION_PATTERN = /^ {0,3}\[([^\]]+)\]:\s*<?(\S+?)>?(?:\s+["'(].*["')])?\s*$/gm;
+
+/**
+ * Extracts badge images, their link targets and the CI provider they report on
+ */
+export class BadgeExtractor {
+  /**
+   * Extract the badges in README content. Badges pointing at the same target are reported once.
+   */
+  extract(content: string): Badge[] {
+    const definitions = this.readDefinitions(content);
+    const badges: Badge[] = [];
+    const seen = new Set<string>();
+
+    const add = (alt: string, imageUrl: string | undefined, targetUrl: string | undefined) => {
+      if (!imageUrl) {
+        return;
+      }
+
+      const key = targetUrl || imageUrl;
+      if (seen.has(key)) {
+        return;
+      }
+      seen.add(key);
+
+      const provider = this.inferProvider(imageUrl) || (targetUrl ? this.inferProvider(targetUrl) : undefined);
+      badges.push({
+        alt,
+        imageUrl,
+        ...(targetUrl && { targetUrl }),
+        ...(provider && { provider })
+      });
+    };
+
+    // Linked images: [![alt](src)](target), [![alt][img]][target] and mixes of both
+    const linked = new RegExp(`\\[\\s*(${IMAGE_PATTERN.source})\\s*\\](?:\\(\\s*<?([^\\s)>]+)>?(?:\\s+"[^"]*")?\\s*\\)|\\[([^\\]]*)\\])`, 'g');
+    const consumed: Array<[number, number]> = [];
+    let match: RegExpExecArray | null;
+    while ((match = linked.exec(content)) !== null) {
+      const imageUrl = this.resolve(match[3], match[4], match[2]!, definitions);
+      const targetUrl = this.resolve(match[5], match[6], match[1]!, definitions);
+      add(match[2]!, imageUrl, targetUrl);
+      consumed.push([match.index, match.index + match[0].length]);
+    }
+
+    // Images without a link
+    const standalone = new RegExp(IMAGE_PATTERN.source, 'g');
+    while ((match = standalone.exec(content)) !== null) {
+      const index = match.index;
+      if (consumed.some(([start, end]) => index >= start && index < end)) {
+        continue;
+      }
+      add(match[1]!, this.resolve(match[2], match[3], match[1]!, definitions), undefined);
+    }
+
+    // HTML badges: <a href="target"><img src="src" alt="alt"></a>
+    const html = /<a\s[^>]*href=["']([^"']+)["'][^>]*>\s*<img\s([^>]*)>\s*<\/a>/gi;
+    while ((match = html.exec(content)) !== null) {
+      const src = match[2]!.match(/src=["']([^"']+)["']/i)?.[1];
+      const alt = match[2]!.match(/alt=["']([^"']*)["']/i)?.[1] || '';
+      add(alt, src, match[1]);
+    }
+
+    // Only badges that report on something; plain screenshots and logos are not badges
+    return badges.filter(badge => badge.provider || this.isBadgeImage(badge.imageUrl));
+  }
+
+  /**
+   * Infer the CI provider a badge or link URL belongs to
+   */
+  inferProvider(url: string): BadgeProvider | undefined {
+    let parsed: URL;
+    try {
+      parsed = new URL(url);
+    } catch {
+      return undefined;
+    }
+
+    const host = parsed.hostname.toLowerCase();
+    const pathname = parsed.pathname;
+
+    if (host === 'img.shields.io' || host === 'shields.io') {
+      // Endpoint badges render JSON served elsewhere; the provider is the one serving it
+      if (pathname.startsWith('/endpoint')) {
+        const endpoint = parsed.searchParams.get('url');
+        return endpoint ? this.inferProvider(endpoint) : undefined;
+      }
+      return SHIELDS_CI_PATHS.find(({ pattern }) => pattern.test(pathname))?.provider;
+    }
+
+    if (host === 'github.com' && /^\/[^/]+\/[^/]+\/(actions|workflows)(\/|$)/.test(pathname)) {
+      return 'github-actions';
+    }
+    if (/(^|\.)travis-ci\.(com|org)$/.test(host)) {
+      return 'travis';
+    }
+    if (/(^|\.)circleci\.com$/.test(host)) {
+      return 'circleci';
+    }
+    if (host === 'gitlab.com' && /\/(-\/)?(badges\/[^/]+\/pipeline\.svg|pipelines)/.test(pathname)) {
+      return 'gitlab';
+    }
+    if ((host === 'dev.azure.com' || host.endsWith('.visualstudio.com')) && /_apis\/build\/status|_build/.test(pathname)) {
+      return 'azure-pipelines';
+    }
+    if (host === 'ci.appveyor.com') {
+      return 'appveyor';
+    }
+
+    return undefined;
+  }
+
+  /**
+   * Resolve an inline URL or a reference label; collapsed and shortcut references use the text as label
+   */
+  private resolve(
+    inline: string | undefined,
+    reference: string | undefined,
+    text: string,
+    definitions: Map<string, string>
+  ): string | undefined {
+    if (inline) {
+      return inline;
+    }
+    return definitions.get(this.normalizeLabel(reference || text));
+  }
+
+  private readDefinitions(content: string): Map<string, string> {
+    const definitions = new Map<string, string>();
+    for (const match of content.matchAll(DEFINITION_PATTERN)) {
+      const label = this.normalizeLabel(match[1]!);
+      // The first definition of a label wins, as in CommonMark
+      if (!definitions.has(label)) {
+        definitions.set(label, match[2]!);
+      }
+    }
+    return definitions;
+  }
+
+  private normalizeLabel(label: string): string {
+    return label.trim().replace(/\s+/g, ' ').toLowerCase();
+  }
+
+  private isBadgeImage(url: string): boolean {
+    return /(^|\/\/)(img\.)?shields\.io\/|badge|\.svg(\?|$)/i.test(url);
+  }
+}
@@ -42,6 +42,25 @@ export {
 // Result aggregation utilities
 export { ResultAggregator } from './result-aggregator';
 
+// Shell command classification
+export { ShellCommandClassifier, DEFAULT_COMMAND_KEYWORDS, SHELL_FENCE_LANGUAGES } from './shell-command-classifier';
+
+// README badge extraction
+export { BadgeExtractor } from './badge-extractor';
+
+// Required secret extraction
+export { SecretExtractor } from './secret-extractor';
+
+// Prerequisites section extraction
+export { PrerequisiteExtractor } from './prerequisite-extractor';
+
+// Tested version list extraction
+export { TestedVersionExtractor } from './tested-version-extractor';
+
+// README discovery and non-Markdown README formats
+export { findReadme, README_NAMES, README_DIRECTORIES } from './readme-locator';
+export { MarkupParser, registerMarkupParser, getReadmeFormat, toMarkdown, restructuredTextParser, asciiDocParser } from './markup-parser';
+
 // Performance optimization utilities
 export { ASTCache, globalASTCache, createASTCache } from './ast-cache';
 export { PerformanceMonitor, globalPerformanceMonitor, createPerformanceMonitor, timed } from './performance-monitor';
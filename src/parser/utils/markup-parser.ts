@@ -0,0 +1,221 @@
+/**
+ * Markup parsers - Convert README formats other than Markdown to Markdown for analysis
+ */
+
+import * as path from 'path';
+import { ReadmeFormat } from '../types';
+
+/**
+ * Converts a README written in some markup to Markdown. Converters only need to carry over what
+ * the analyzers read: section headings, code blocks and prose. They keep one output line per
+ * input line where the markup allows, so line numbers reported from the Markdown still point
+ * into the original file.
+ */
+export interface MarkupParser {
+  /** File extensions (lowercase, with the dot) written in this format */
+  extensions: string[];
+  toMarkdown(content: string): string;
+}
+
+const parsers = new Map<ReadmeFormat, MarkupParser>();
+
+/**
+ * Register the parser for a format, replacing the built-in one
+ */
+export function registerMarkupParser(format: ReadmeFormat, parser: MarkupParser): void {
+  parsers.set(format, parser);
+}
+
+/**
+ * Format of a README from its file name: Markdown for .md and .markdown, plain text
+ * for extensions no parser is registered for
+ */
+export function getReadmeFormat(filePath: string): ReadmeFormat {
+  const extension = path.extname(filePath).toLowerCase();
+  if (extension === '.md' || extension === '.markdown') {
+    return 'markdown';
+  }
+  for (const [format, parser] of parsers) {
+    if (parser.extensions.includes(extension)) {
+      return format;
+    }
+  }
+  return 'text';
+}
+
+/**
+ * Markdown for README content in a format. Markdown and plain text are returned unchanged.
+ */
+export function toMarkdown(content: string, format: ReadmeFormat): string {
+  const parser = parsers.get(format);
+  return parser ? parser.toMarkdown(content) : content;
+}
+
+/**
+ * Characters reStructuredText section adornments may use
+ */
+const RST_ADORNMENT = /^([=\-`:'"~^_*+#<>.])\1{2,}$/;
+
+/**
+ * reStructuredText: section titles (underlined, optionally overlined; levels follow the order
+ * adornment styles first appear in), `code-block`/`code`/`sourcecode` directives, `::` literal
+ * blocks and ``inline literals``
+ */
+export const restructuredTextParser: MarkupParser = {
+  extensions: ['.rst', '.rest'],
+  toMarkdown(content: string): string {
+    const lines = content.replace(/\r\n?/g, '\n').split('\n');
+    const output: string[] = [];
+    const styles: string[] = [];
+    const headingLevel = (style: string) => {
+      if (!styles.includes(style)) {
+        styles.push(style);
+      }
+      return Math.min(styles.indexOf(style) + 1, 6);
+    };
+
+    let index = 0;
+    while (index < lines.length) {
+      const line = lines[index]!;
+      const next = lines[index + 1];
+      const afterNext = lines[index + 2];
+
+      // Overlined title: adornment, title, same adornment
+      if (RST_ADORNMENT.test(line.trim()) && next !== undefined && next.trim() !== '' &&
+        afterNext !== undefined && afterNext.trim() === line.trim()) {
+        output.push('', `${'#'.repeat(headingLevel(`over${line.trim()[0]}`))} ${next.trim()}`, '');
+        index += 3;
+        continue;
+      }
+
+      // Underlined title: text followed by an adornment at least as long
+      if (line.trim() !== '' && !/^\s/.test(line) && next !== undefined && RST_ADORNMENT.test(next.trim()) &&
+        next.trim().length >= line.trim().length) {
+        output.push(`${'#'.repeat(headingLevel(next.trim()[0]!))} ${line.trim()}`, '');
+        index += 2;
+        continue;
+      }
+
+      const directive = line.match(/^(\s*)\.\.\s+(?:code-block|code|sourcecode)::\s*(\S*)/);
+      const literal = !directive && /::\s*$/.test(line) && !/^\s*\.\./.test(line);
+      if (directive || literal) {
+        output.push(directive ? `${directive[1]}\`\`\`${directive[2]}` : inlineLiterals(line.replace(/::\s*$/, ':')));
+        index = convertIndentedBlock(lines, index + 1, output, literal ? '```' : undefined);
+        continue;
+      }
+
+      output.push(inlineLiterals(line));
+      index++;
+    }
+
+    return output.join('\n');
+  }
+};
+
+/**
+ * ``literal`` as Markdown `code`
+ */
+function inlineLiterals(line: string): string {
+  return line.replace(/``([^`]+)``/g, '`$1`');
+}
+
+/**
+ * Fence the indented block starting at start (after optional directive options and blank lines),
+ * closing it on the blank line that ends it; returns the index of the first line after the block
+ */
+function convertIndentedBlock(lines: string[], start: number, output: string[], opening?: string): number {
+  let index = start;
+  // Directive options such as `:linenos:` come before the content
+  while (index < lines.length && /^\s+:[\w-]+:/.test(lines[index]!)) {
+    output.push('');
+    index++;
+  }
+
+  const body: string[] = [];
+  while (index < lines.length && (lines[index]!.trim() === '' || /^\s/.test(lines[index]!))) {
+    body.push(lines[index]!);
+    index++;
+  }
+  const leading = body.findIndex(line => line.trim() !== '');
+  if (leading < 0) {
+    output.push(...body);
+    if (!opening) {
+      output.push('```');
+    }
+    return index;
+  }
+
+  let trailing = body.length;
+  while (trailing > 0 && body[trailing - 1]!.trim() === '') {
+    trailing--;
+  }
+  const content = body.slice(leading, trailing);
+  const indent = Math.min(...content.filter(line => line.trim() !== '').map(line => line.match(/^\s*/)![0].length));
+
+  if (opening) {
+    // The blank line between the paragraph and a literal block becomes the opening fence
+    output.push(...body.slice(0, Math.max(leading - 1, 0)), opening);
+  } else {
+    output.push(...body.slice(0, leading));
+  }
+  output.push(...content.map(line => line.slice(indent)));
+  output.push('```', ...body.slice(trailing + 1));
+  return index;
+}
+
+/**
+ * AsciiDoc: `=` section titles, `[source,lang]` listings, `----` listing and `....` literal blocks
+ * and `// comments`
+ */
+export const asciiDocParser: MarkupParser = {
+  extensions: ['.adoc', '.asciidoc', '.asc'],
+  toMarkdown(content: string): string {
+    const lines = content.replace(/\r\n?/g, '\n').split('\n');
+    const output: string[] = [];
+    let language = '';
+    let delimiter: string | undefined;
+
+    for (const line of lines) {
+      if (delimiter) {
+        if (line.trim() === delimiter) {
+          output.push('```');
+          delimiter = undefined;
+        } else {
+          output.push(line);
+        }
+        continue;
+      }
+
+      const source = line.match(/^\[source(?:\s*,\s*([\w+#.-]+))?[^\]]*\]\s*$/);
+      if (source) {
+        language = source[1] || '';
+        output.push('');
+        continue;
+      }
+      if (/^(-{4,}|\.{4,})\s*$/.test(line)) {
+        delimiter = line.trim();
+        output.push(`\`\`\`${language}`);
+        language = '';
+        continue;
+      }
+      language = '';
+
+      const heading = line.match(/^(={1,6})\s+(.+?)\s*=*\s*$/);
+      if (heading) {
+        output.push(`${'#'.repeat(heading[1]!.length)} ${heading[2]}`);
+      } else if (/^\/\/(?!\/)/.test(line)) {
+        output.push('');
+      } else {
+        output.push(line.replace(/`\+([^`]+)\+`/g, '`$1`'));
+      }
+    }
+
+    if (delimiter) {
+      output.push('```');
+    }
+    return output.join('\n');
+  }
+};
+
+registerMarkupParser('restructuredtext', restructuredTextParser);
+registerMarkupParser('asciidoc', asciiDocParser);
@@ -0,0 +1,212 @@
+/**
+ * PrerequisiteExtractor - Reads a README's Requirements/Prerequisites section into system packages, runtimes and services
+ */
+
+import { Prerequisite, PrerequisiteKind } from '../types';
+
+/**
+ * Heading text that opens a prerequisites section
+ */
+const SECTION_PATTERN = /\b(requirements|prerequisites|pre-requisites|dependencies)\b/i;
+
+/**
+ * ATX heading: `## Prerequisites` with optional closing hashes
+ */
+const ATX_HEADING_PATTERN = /^ {0,3}(#{1,6})\s+(.*?)(?:\s+#+)?\s*$/;
+
+/**
+ * Setext underline: `===` makes the line above a level 1 heading, `---` a level 2 one
+ */
+const SETEXT_UNDERLINE_PATTERN = /^ {0,3}(=+|-+)\s*$/;
+
+const BULLET_PATTERN = /^\s*(?:[-*+]|\d+[.)])\s+(.*)$/;
+
+const FENCE_PATTERN = /^\s*(```|~~~)/;
+
+/**
+ * `apt-get install` / `apt install` with the package list that follows
+ */
+const APT_INSTALL_PATTERN = /\bapt(?:-get)?\s+install\s+([^`&|;\n]+)/;
+
+/**
+ * Optional version after a runtime or service name: `Python 3.10+`, `node >= 18`, `PostgreSQL v14`
+ */
+const VERSION_SUFFIX = '(?:\\s*(?:version\\s*)?(?:>=|=|\\^|~|v)?\\s*(\\d+(?:\\.\\d+)*)\\+?)?';
+
+/**
+ * Language runtimes; names that are common words only count when a version follows them
+ */
+const RUNTIMES: Array<{ name: string; alias: string; versionRequired?: boolean }> = [
+  { name: 'node', alias: 'node\\.?js|node' },
+  { name: 'python', alias: 'python' },
+  { name: 'go', alias: 'golang|go', versionRequired: true },
+  { name: 'rust', alias: 'rust' },
+  { name: 'java', alias: 'java|open-?jdk|jdk' },
+  { name: 'ruby', alias: 'ruby' },
+  { name: 'php', alias: 'php' },
+  { name: 'dotnet', alias: '\\.net(?:\\s+core)?|dotnet' },
+  { name: 'deno', alias: 'deno' }
+];
+
+/**
+ * Services the project talks to at runtime, which CI runs as containers
+ */
+const SERVICES: Array<{ name: string; alias: string }> = [
+  { name: 'postgres', alias: 'postgres(?:ql)?|postgis' },
+  { name: 'mysql', alias: 'mysql|mariadb' },
+  { name: 'redis', alias: 'redis' },
+  { name: 'mongodb', alias: 'mongo(?:db)?' },
+  { name: 'rabbitmq', alias: 'rabbitmq' },
+  { name: 'elasticsearch', alias: 'elasticsearch' },
+  { name: 'docker', alias: 'docker' }
+];
+
+/**
+ * Tools commonly listed as prerequisites that are installed with the system package manager
+ */
+const KNOWN_SYSTEM_PACKAGES = new Set([
+  'build-essential', 'gcc', 'g++', 'make', 'cmake', 'pkg-config', 'protobuf-compiler',
+  'ffmpeg', 'graphviz', 'imagemagick', 'sqlite3', 'libssl-dev', 'openssl', 'curl', 'jq'
+]);
+
+/**
+ * Language package managers; they come with the runtime rather than from apt
+ */
+const PACKAGE_MANAGERS = new Set([
+  'npm', 'yarn', 'pnpm', 'npx', 'pip', 'pip3', 'pipenv', 'poetry', 'uv', 'cargo', 'gem', 'bundler', 'composer', 'maven', 'gradle'
+]);
+
+/**
+ * Extracts the bullet items of prerequisites sections, classified as system packages, runtimes or services
+ */
+export class PrerequisiteExtractor {
+  /**
+   * Extract the prerequisites of README content, once per kind and name in order of first mention.
+   * A section runs from a heading matching Requirements/Prerequisites/Dependencies to the next
+   * heading of the same or a higher level; deeper headings stay inside it.
+   */
+  extract(content: string): Prerequisite[] {
+    const prerequisites = new Map<string, Prerequisite>();
+    const add = (kind: PrerequisiteKind, name: string, line: number, version?: string) => {
+      const key = `${kind}:${name}`;
+      if (!prerequisites.has(key)) {
+        prerequisites.set(key, { name, kind, ...(version && { version }), line });
+      }
+    };
+
+    const lines = content.split(/\r?\n/);
+    let sectionLevel: number | undefined;
+    let inFence = false;
+
+    for (let index = 0; index < lines.length; index++) {
+      const line = lines[index]!;
+
+      if (FENCE_PATTERN.test(line)) {
+        inFence = !inFence;
+        continue;
+      }
+
+      if (!inFence) {
+        const heading = this.readHeading(lines, index);
+        if (heading) {
+          if (SECTION_PATTERN.test(heading.text)) {
+            sectionLevel = heading.level;
+          } else if (sectionLevel !== undefined && heading.level <= sectionLevel) {
+            sectionLevel = undefined;
+          }
+          if (heading.setext) {
+            index++;
+          }
+          continue;
+        }
+      }
+
+      if (sectionLevel === undefined) {
+        continue;
+      }
+
+      // Install commands in the section name system packages directly
+      const install = line.match(APT_INSTALL_PATTERN);
+      if (install) {
+        for (const name of install[1]!.split(/\s+/)) {
+          if (/^[a-z0-9][a-z0-9.+-]*$/.test(name)) {
+            add('system-package', name, index + 1);
+          }
+        }
+        continue;
+      }
+
+      const bullet = inFence ? undefined : line.match(BULLET_PATTERN);
+      if (bullet) {
+        for (const item of this.classify(bullet[1]!)) {
+          add(item.kind, item.name, index + 1, item.version);
+        }
+      }
+    }
+
+    return [...prerequisites.values()];
+  }
+
+  /**
+   * Heading starting at a line: an ATX heading, or a paragraph line followed by a setext underline
+   */
+  private readHeading(lines: string[], index: number): { level: number; text: string; setext: boolean } | undefined {
+    const line = lines[index]!;
+    const atx = line.match(ATX_HEADING_PATTERN);
+    if (atx) {
+      return { level: atx[1]!.length, text: atx[2]!, setext: false };
+    }
+
+    const underline = lines[index + 1]?.match(SETEXT_UNDERLINE_PATTERN);
+    if (underline && line.trim() !== '' && !BULLET_PATTERN.test(line) && !SETEXT_UNDERLINE_PATTERN.test(line)) {
+      return { level: underline[1]!.startsWith('=') ? 1 : 2, text: line.trim(), setext: true };
+    }
+
+    return undefined;
+  }
+
+  /**
+   * Classify a bullet item; one item may name several prerequisites ("PostgreSQL 14 and Redis")
+   */
+  private classify(item: string): Array<{ kind: PrerequisiteKind; name: string; version?: string }> {
+    const found: Array<{ kind: PrerequisiteKind; name: string; version?: string }> = [];
+    const text = item
+      .replace(/!?\[([^\]]*)\]\([^)]*\)/g, '$1')
+      .replace(/[*_]{1,2}([^*_]+)[*_]{1,2}/g, '$1');
+    const plain = text.replace(/`/g, '');
+
+    for (const service of SERVICES) {
+      const match = plain.match(new RegExp(`(?<![\\w.-])(?:${service.alias})(?![\\w-])${VERSION_SUFFIX}`, 'i'));
+      if (match) {
+        found.push({ kind: 'service', name: service.name, ...(match[1] && { version: match[1] }) });
+      }
+    }
+
+    for (const runtime of RUNTIMES) {
+      const match = plain.match(new RegExp(`(?<![\\w.-])(?:${runtime.alias})(?![\\w-])${VERSION_SUFFIX}`, 'i'));
+      if (match && (match[1] || !runtime.versionRequired)) {
+        found.push({ kind: 'runtime', name: runtime.name, ...(match[1] && { version: match[1] }) });
+      }
+    }
+
+    // Single-word code spans name a package; in prose only library and -dev package shapes are trusted
+    const codeSpans = [...text.matchAll(/`([^`\s]+)`/g)].map(match => match[1]!.toLowerCase());
+    const words = plain.split(/[\s,;()]+/).map(word => word.replace(/[.:]+$/, '').toLowerCase());
+    const candidates = [
+      ...codeSpans,
+      ...words.filter(word => /^lib[a-z0-9.+-]+$/.test(word) || /[a-z0-9]-dev(el)?$/.test(word) || KNOWN_SYSTEM_PACKAGES.has(word))
+    ];
+
+    for (const name of candidates) {
+      if (!/^[a-z0-9][a-z0-9.+-]*$/.test(name) || /^[\d.]+$/.test(name) || PACKAGE_MANAGERS.has(name)) {
+        continue;
+      }
+      const named = [...SERVICES, ...RUNTIMES].some(entry => new RegExp(`^(?:${entry.alias})$`, 'i').test(name));
+      if (!named && !found.some(entry => entry.kind === 'system-package' && entry.name === name)) {
+        found.push({ kind: 'system-package', name });
+      }
+    }
+
+    return found;
+  }
+}
@@ -0,0 +1,54 @@
+/**
+ * ReadmeLocator - Find a project's README among the names and places projects keep it
+ */
+
+import { promises as fs } from 'fs';
+import * as path from 'path';
+import { ReadmeLocation } from '../types';
+import { getReadmeFormat } from './markup-parser';
+
+/**
+ * README file names in order of preference, compared case-insensitively
+ */
+export const README_NAMES = [
+  'README.md',
+  'README.markdown',
+  'README.rst',
+  'README.adoc',
+  'README.asciidoc',
+  'README.txt',
+  'README'
+];
+
+/**
+ * Directories searched in order, the repository root first
+ */
+export const README_DIRECTORIES = ['.', 'docs', 'doc', '.github'];
+
+/**
+ * Find the README of the project at root: the first directory of README_DIRECTORIES holding
+ * one of README_NAMES, preferring names earlier in the list, so a root README.md wins over
+ * anything else. Returns undefined when there is none.
+ */
+export async function findReadme(root: string): Promise<ReadmeLocation | undefined> {
+  for (const directory of README_DIRECTORIES) {
+    let entries: string[];
+    try {
+      entries = (await fs.readdir(path.join(root, directory), { withFileTypes: true }))
+        .filter(entry => entry.isFile())
+        .map(entry => entry.name);
+    } catch {
+      continue;
+    }
+
+    for (const name of README_NAMES) {
+      const match = entries.filter(entry => entry.toLowerCase() === name.toLowerCase()).sort()[0];
+      if (match) {
+        const filePath = path.join(root, directory, match);
+        return { path: filePath, format: getReadmeFormat(filePath) };
+      }
+    }
+  }
+
+  return undefined;
+}
@@ -0,0 +1,129 @@
+/**
+ * SecretExtractor - Finds environment variables a README asks users to provide, as CI secret candidates
+ */
+
+import { RequiredSecret, SecretContext } from '../types';
+
+/**
+ * `export NAME=...` and `set NAME=...` in commands, and "Set NAME and OTHER" in prose
+ */
+const KEYWORD_PATTERN = /\b(export|set)\s+/gi;
+
+/**
+ * `env:` key opening a block of variables, optionally as a list item
+ */
+const ENV_KEY_PATTERN = /^(\s*(?:-\s+)?)env:\s*(.*)$/;
+
+/**
+ * Variable name at the start of an `env:` block entry: `NAME: value` or `- NAME=value`
+ */
+const ENV_ENTRY_PATTERN = /^\s*(?:-\s+)?([A-Z][A-Z0-9_]*)\s*[:=]/;
+
+/**
+ * ALL_CAPS words that show up in these contexts without being variables the user must supply
+ */
+const STOP_LIST = new Set([
+  'GET', 'POST', 'PUT', 'PATCH', 'DELETE', 'HEAD', 'OPTIONS',
+  'README', 'LICENSE', 'CHANGELOG', 'TODO', 'FIXME', 'NOTE',
+  'NODE_ENV', 'NODE_OPTIONS', 'JAVA_HOME', 'RUST_LOG', 'RUST_BACKTRACE', 'LD_LIBRARY_PATH'
+]);
+
+/**
+ * Names ending like credentials qualify even without an underscore (e.g. `TOKEN`, `APIKEY`)
+ */
+const CREDENTIAL_SUFFIX = /(TOKEN|KEY|SECRET|PASSWORD)$/;
+
+/**
+ * Extracts ALL_CAPS environment variables introduced by `export`, `env:` or "set"
+ */
+export class SecretExtractor {
+  /**
+   * Extract the secrets required by README content, once per name in order of first mention
+   */
+  extract(content: string): RequiredSecret[] {
+    const secrets = new Map<string, RequiredSecret>();
+    const add = (name: string, context: SecretContext, line: number) => {
+      if (!secrets.has(name) && this.isSecretName(name)) {
+        secrets.set(name, { name, context, line });
+      }
+    };
+
+    const lines = content.split(/\r?\n/);
+    for (let index = 0; index < lines.length; index++) {
+      const line = lines[index]!;
+
+      for (const match of line.matchAll(KEYWORD_PATTERN)) {
+        const context = match[1]!.toLowerCase() as SecretContext;
+        for (const name of this.readNameList(line.slice(match.index! + match[0].length))) {
+          add(name, context, index + 1);
+        }
+      }
+
+      const env = line.match(ENV_KEY_PATTERN);
+      if (!env) {
+        continue;
+      }
+
+      const inline = env[2]!.trim();
+      if (inline.startsWith('{')) {
+        // Flow mapping: env: { API_KEY: ..., DATABASE_URL: ... }
+        for (const match of inline.matchAll(/([A-Z][A-Z0-9_]*)\s*:/g)) {
+          add(match[1]!, 'env', index + 1);
+        }
+      } else if (inline && !inline.startsWith('#')) {
+        this.readNameList(inline).forEach(name => add(name, 'env', index + 1));
+      } else {
+        // Block entries are the lines indented deeper than the env key
+        const indent = env[1]!.length;
+        for (let next = index + 1; next < lines.length; next++) {
+          const entry = lines[next]!;
+          if (entry.trim() === '') {
+            continue;
+          }
+          if (entry.length - entry.trimStart().length <= indent) {
+            break;
+          }
+          const name = entry.match(ENV_ENTRY_PATTERN)?.[1];
+          if (name) {
+            add(name, 'env', next + 1);
+          }
+        }
+      }
+    }
+
+    return [...secrets.values()];
+  }
+
+  /**
+   * Whether an ALL_CAPS identifier looks like a variable to supply rather than a constant or verb.
+   * GITHUB_ names are reserved by GitHub Actions and provided by the runner.
+   */
+  isSecretName(name: string): boolean {
+    if (name.length < 3 || STOP_LIST.has(name) || name.startsWith('GITHUB_')) {
+      return false;
+    }
+    return /^[A-Z][A-Z0-9]*(_[A-Z0-9]+)+$/.test(name) || (/^[A-Z][A-Z0-9]*$/.test(name) && CREDENTIAL_SUFFIX.test(name));
+  }
+
+  /**
+   * Names at the start of text, as in `A=1 B=2` or "`A`, `B` and `C`"; stops at the first other word
+   */
+  private readNameList(text: string): string[] {
+    const names: string[] = [];
+
+    for (const raw of text.split(/\s+/)) {
+      const token = raw.replace(/^[`'"(]+/, '').replace(/[`'",.;:)]+$/, '');
+      if (token === '' || token === 'and' || token === 'or' || token === '&') {
+        continue;
+      }
+
+      const name = token.split('=')[0]!.replace(/[`'"]+$/, '');
+      if (!/^[A-Z][A-Z0-9_]*$/.test(name)) {
+        break;
+      }
+      names.push(name);
+    }
+
+    return names;
+  }
+}
@@ -0,0 +1,286 @@
+/**
+ * ShellCommandClassifier - Classifies commands documented in shell code fences by intent
+ */
+
+import { MarkdownAST, ClassifiedCommand, CommandBucket, CommandKeywordMap } from '../types';
+
+/**
+ * Fence language hints treated as shell sessions
+ */
+export const SHELL_FENCE_LANGUAGES = ['bash', 'sh', 'shell', 'zsh', 'console', 'shell-session', 'terminal'];
+
+/**
+ * Default keyword prefixes per bucket. The longest matching prefix wins, so
+ * `npm run lint` is a lint command even though `npm run` alone would be run.
+ */
+export const DEFAULT_COMMAND_KEYWORDS: CommandKeywordMap = {
+  install: [
+    'npm install', 'npm i', 'npm ci', 'yarn install', 'yarn add', 'pnpm install', 'pnpm i', 'pnpm add',
+    'pip install', 'pip3 install', 'python -m pip install', 'python3 -m pip install',
+    'poetry install', 'pipenv install', 'go get', 'go mod download', 'cargo fetch',
+    'bundle install', 'gem install', 'composer install', 'mvn dependency:resolve', 'dotnet restore',
+    'apt-get install', 'apt install', 'brew install'
+  ],
+  build: [
+    'npm run build', 'yarn build', 'yarn run build', 'pnpm build', 'pnpm run build',
+    'go build', 'go install', 'cargo build', 'mvn compile', 'mvn package', 'mvn install',
+    'gradle build', './gradlew build', 'make', 'make build', 'make all', 'cmake', 'tsc',
+    'python -m build', 'python setup.py build', 'dotnet build', 'docker build'
+  ],
+  test: [
+    'npm test', 'npm run test', 'yarn test', 'pnpm test', 'pnpm run test',
+    'go test', 'cargo test', 'pytest', 'python -m pytest', 'python3 -m pytest', 'python -m unittest', 'tox',
+    'mvn test', 'mvn verify', 'gradle test', './gradlew test', 'make test', 'make check',
+    'dotnet test', 'rspec', 'bundle exec rspec', 'jest', 'vitest', 'phpunit'
+  ],
+  lint: [
+    'npm run lint', 'yarn lint', 'pnpm lint', 'pnpm run lint', 'eslint', 'npx eslint', 'prettier --check',
+    'golangci-lint', 'go vet', 'gofmt', 'cargo clippy', 'cargo fmt', 'flake8', 'ruff', 'pylint',
+    'black --check', 'mypy', 'rubocop', 'make lint'
+  ],
+  run: [
+    'npm start', 'npm run start', 'npm run dev', 'yarn start', 'yarn dev', 'pnpm start', 'pnpm dev',
+    'go run', 'cargo run', 'python', 'python3', 'node', 'java -jar', 'dotnet run', 'docker run',
+    'flask run', 'uvicorn', 'bundle exec rails server'
+  ]
+};
+
+/**
+ * Prompt prefixes stripped before classification
+ */
+const PROMPT_PATTERN = /^(\$|>)\s+/;
+
+/**
+ * Reserved words opening and closing compound commands, whose bodies are not split into commands
+ */
+const COMPOUND_OPENERS = new Set(['for', 'while', 'until', 'if', 'case', 'select', '{']);
+const COMPOUND_CLOSERS = new Set(['done', 'fi', 'esac', '}']);
+
+/**
+ * Reserved words after which a command follows, as after a separator
+ */
+const COMMAND_PREFIXES = new Set(['if', 'while', 'until', '{', 'do', 'then', 'else', 'elif', '!', 'time']);
+
+/**
+ * Logical command assembled from one or more fence lines
+ */
+interface LogicalLine {
+  raw: string;
+  text: string;
+}
+
+/**
+ * Classifies shell commands found in fenced code blocks into install, build, test, lint and run buckets
+ */
+export class ShellCommandClassifier {
+  private keywords: Array<{ keyword: string; bucket: CommandBucket }>;
+
+  constructor(keywordMap: Partial<CommandKeywordMap> = {}) {
+    const merged: CommandKeywordMap = { ...DEFAULT_COMMAND_KEYWORDS, ...keywordMap };
+
+    this.keywords = (Object.keys(merged) as CommandBucket[])
+      .flatMap(bucket => merged[bucket].map(keyword => ({ keyword: this.normalize(keyword), bucket })))
+      .sort((a, b) => b.keyword.length - a.keyword.length);
+  }
+
+  /**
+   * Classify every command in the shell fences of a markdown AST
+   */
+  classify(ast: MarkdownAST): ClassifiedCommand[] {
+    const commands: ClassifiedCommand[] = [];
+
+    this.visitCodeBlocks(ast, (code, language) => {
+      if (SHELL_FENCE_LANGUAGES.includes(language)) {
+        commands.push(...this.classifyBlock(code, language));
+      }
+    });
+
+    return commands;
+  }
+
+  /**
+   * Classify the commands in a single fenced block
+   */
+  classifyBlock(code: string, language: string): ClassifiedCommand[] {
+    const commands: ClassifiedCommand[] = [];
+
+    for (const line of this.joinLines(code)) {
+      // `cmd1 && cmd2` documents two commands; classify each on its own
+      for (const segment of this.splitCommands(line.text)) {
+        const command = this.normalize(segment);
+        // Loops and conditionals run their commands any number of times, so none is classified
+        const compound = COMPOUND_OPENERS.has(command.split(' ')[0]!) || command.startsWith('(');
+        const bucket = compound ? null : this.classifyCommand(command);
+        if (bucket) {
+          commands.push({ raw: line.raw, command, bucket, language });
+        }
+      }
+    }
+
+    return commands;
+  }
+
+  /**
+   * Determine the bucket of a single normalized command, if any keyword matches
+   */
+  classifyCommand(command: string): CommandBucket | null {
+    const candidate = command.replace(/^sudo\s+/, '').toLowerCase();
+
+    for (const { keyword, bucket } of this.keywords) {
+      if (candidate === keyword || candidate.startsWith(`${keyword} `)) {
+        return bucket;
+      }
+    }
+
+    return null;
+  }
+
+  /**
+   * Split a line at the `&&`, `||` and `;` between its commands. Separators inside quotes,
+   * subshells and compound commands (for ... done, if ... fi) belong to them, so a compound
+   * command stays one piece.
+   */
+  private splitCommands(text: string): string[] {
+    const segments: string[] = [];
+    let current = '';
+    let quote: string | null = null;
+    let compounds = 0;
+    let parentheses = 0;
+    let commandStart = true;
+
+    for (let index = 0; index < text.length; index++) {
+      const char = text[index]!;
+
+      if (quote) {
+        current += char;
+        if (char === '\\' && quote !== "'") {
+          current += text[++index] ?? '';
+        } else if (char === quote) {
+          quote = null;
+        }
+        continue;
+      }
+
+      const separator = text.startsWith('&&', index) || text.startsWith('||', index) ? 2 : char === ';' ? 1 : 0;
+      if (separator > 0) {
+        if (compounds === 0 && parentheses === 0) {
+          segments.push(current);
+          current = '';
+        } else {
+          current += text.slice(index, index + separator);
+        }
+        index += separator - 1;
+        commandStart = true;
+        continue;
+      }
+
+      if (commandStart && !/[\s|&;()"'`\\]/.test(char)) {
+        const word = text.slice(index).match(/^[^\s;&|()]+/)![0];
+        if (COMPOUND_OPENERS.has(word)) {
+          compounds++;
+        } else if (COMPOUND_CLOSERS.has(word)) {
+          compounds = Math.max(0, compounds - 1);
+        }
+        commandStart = COMMAND_PREFIXES.has(word);
+        current += word;
+        index += word.length - 1;
+        continue;
+      }
+
+      if (char === '"' || char === "'" || char === '`') {
+        quote = char;
+      } else if (char === '\\') {
+        current += char + (text[index + 1] ?? '');
+        index++;
+        commandStart = false;
+        continue;
+      } else if (char === '(') {
+        parentheses++;
+      } else if (char === ')') {
+        parentheses = Math.max(0, parentheses - 1);
+      }
+      if (char === '|' || char === '(') {
+        commandStart = true;
+      } else if (!/\s/.test(char)) {
+        commandStart = false;
+      }
+      current += char;
+    }
+    segments.push(current);
+
+    return segments.filter(segment => segment.trim() !== '');
+  }
+
+  /**
+   * Join backslash continuations and strip prompts.
+   * When a block uses prompts, unprompted lines are treated as program output.
+   */
+  private joinLines(code: string): LogicalLine[] {
+    const lines = code.split(/\r?\n/);
+    const usesPrompts = lines.some(line => /^\$\s+/.test(line.trim()));
+    const logical: LogicalLine[] = [];
+    let current: LogicalLine | null = null;
+
+    for (const rawLine of lines) {
+      const trimmed = rawLine.trim();
+
+      if (current) {
+        // Continuation lines may carry the secondary `> ` prompt
+        const text = trimmed.replace(/^>\s+/, '');
+        current.raw += `\n${rawLine}`;
+        current.text += ` ${text.replace(/\\$/, '').trim()}`;
+        if (!text.endsWith('\\')) {
+          logical.push(current);
+          current = null;
+        }
+        continue;
+      }
+
+      if (!trimmed || trimmed.startsWith('#')) continue;
+
+      const hasPrompt = PROMPT_PATTERN.test(trimmed);
+      if (usesPrompts && !hasPrompt) continue;
+
+      const text = trimmed.replace(PROMPT_PATTERN, '');
+      const line: LogicalLine = { raw: rawLine, text: text.replace(/\\$/, '').trim() };
+
+      if (text.endsWith('\\')) {
+        current = line;
+      } else {
+        logical.push(line);
+      }
+    }
+
+    if (current) {
+      logical.push(current);
+    }
+
+    return logical;
+  }
+
+  /**
+   * Walk the AST, including lists and blockquotes, and report each code block
+   */
+  private visitCodeBlocks(tokens: MarkdownAST, callback: (code: string, language: string) => void): void {
+    for (const token of tokens) {
+      if (token.type === 'code') {
+        callback(token.text || '', (token.lang || '').trim().split(/\s+/)[0]?.toLowerCase() || '');
+        continue;
+      }
+
+      if ('items' in token && Array.isArray(token.items)) {
+        for (const item of token.items) {
+          this.visitCodeBlocks(item.tokens || [], callback);
+        }
+      }
+
+      if ('tokens' in token && Array.isArray(token.tokens)) {
+        this.visitCodeBlocks(token.tokens, callback);
+      }
+    }
+  }
+
+  private normalize(command: string): string {
+    return command.trim().replace(/\s+/g, ' ');
+  }
+}
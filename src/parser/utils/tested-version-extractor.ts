@@ -0,0 +1,158 @@
+/**
+ * TestedVersionExtractor - Reads the language versions a README says a project is tested on or supports
+ */
+
+import { TestedVersions } from '../types';
+
+const FENCE_PATTERN = /^\s*(```|~~~)/;
+
+/**
+ * Words that make a version list a statement about what CI should cover
+ */
+const INTENT_PATTERN = /\b(tested|tests? (?:run|pass)|supports?|supported|compatible|works)\b/i;
+
+/**
+ * Runtimes and the version shapes they are written with; shapes are narrow so unrelated numbers do not match
+ */
+const RUNTIMES: Array<{ runtime: TestedVersions['runtime']; alias: string; version: string }> = [
+  { runtime: 'python', alias: 'python|cpython', version: '[23]\\.\\d{1,2}' },
+  { runtime: 'go', alias: 'golang|go', version: '1\\.\\d{1,2}' },
+  { runtime: 'node', alias: 'node\\.?js|node', version: 'v?\\d{2}(?:\\.x)?' }
+];
+
+/**
+ * Separators of an enumeration: "1.21, 1.22 and 1.23", "18/20/22"
+ */
+const LIST_SEPARATOR = '\\s*(?:,\\s*(?:and\\s+|or\\s+)?|\\s+and\\s+|\\s+or\\s+|\\/)\\s*';
+
+/**
+ * Separators of a range: "3.9–3.12", "3.9 - 3.12", "3.9 to 3.12", "3.9 through 3.12"
+ */
+const RANGE_SEPARATOR = '\\s*(?:-|–|—|\\.\\.|\\s+to\\s+|\\s+through\\s+)\\s*';
+
+/**
+ * Ranges wider than this are more likely a typo than a test plan
+ */
+const MAX_RANGE_VERSIONS = 8;
+
+/**
+ * Even Node majors, the release lines CI images install
+ */
+const NODE_LTS_STEP = 2;
+
+/**
+ * Extracts version enumerations such as "Tested on Go 1.21, 1.22 and 1.23" or "Supports Python 3.9–3.12"
+ */
+export class TestedVersionExtractor {
+  /**
+   * Extract documented version lists from README prose, the first list per runtime, oldest version
+   * first. A list only counts in a sentence saying the project is tested on, supports or is
+   * compatible with it, with the language name right before the versions and at least two versions:
+   * a single version ("Python 3.10+") is a minimum rather than a list. Lists ending in a minimum
+   * ("3.10 or later") and code blocks are ignored.
+   */
+  extract(content: string): TestedVersions[] {
+    const found = new Map<TestedVersions['runtime'], TestedVersions>();
+    const lines = content.split(/\r?\n/);
+    let inFence = false;
+
+    for (let index = 0; index < lines.length; index++) {
+      const line = lines[index]!;
+      if (FENCE_PATTERN.test(line)) {
+        inFence = !inFence;
+        continue;
+      }
+      if (inFence) {
+        continue;
+      }
+
+      const plain = line
+        .replace(/!?\[([^\]]*)\]\([^)]*\)/g, '$1')
+        .replace(/[*_`]/g, '');
+
+      for (const sentence of plain.split(/(?<=[.!?;])\s+/)) {
+        if (!INTENT_PATTERN.test(sentence)) {
+          continue;
+        }
+        for (const entry of RUNTIMES) {
+          const versions = found.has(entry.runtime) ? undefined : this.matchVersions(sentence, entry);
+          if (versions) {
+            found.set(entry.runtime, { runtime: entry.runtime, versions, line: index + 1 });
+          }
+        }
+      }
+    }
+
+    return [...found.values()];
+  }
+
+  /**
+   * Versions listed right after a runtime name in a sentence, undefined when there is no list
+   */
+  private matchVersions(sentence: string, entry: typeof RUNTIMES[number]): string[] | undefined {
+    const version = `(?:${entry.version})`;
+    const item = `${version}(?:${RANGE_SEPARATOR}${version})?`;
+    const pattern = new RegExp(
+      `(?<![\\w.-])(?:${entry.alias})(?![\\w-])\\s+(?:versions?\\s+)?(${item}(?:${LIST_SEPARATOR}${item})*)(\\+|\\s+(?:or|and)\\s+(?:later|newer|above|up))?`,
+      'i'
+    );
+    const match = sentence.match(pattern);
+    if (!match || match[2]) {
+      return undefined;
+    }
+
+    const versions: string[] = [];
+    for (const part of match[1]!.split(new RegExp(LIST_SEPARATOR, 'i'))) {
+      const bounds = part.split(new RegExp(RANGE_SEPARATOR, 'i')).map(bound => normalizeVersion(bound));
+      if (bounds.length === 1) {
+        versions.push(bounds[0]!);
+        continue;
+      }
+      const expanded = expandRange(entry.runtime, bounds[0]!, bounds[1]!);
+      if (!expanded) {
+        return undefined;
+      }
+      versions.push(...expanded);
+    }
+
+    const unique = [...new Set(versions)].sort(compareVersions);
+    return unique.length >= 2 ? unique : undefined;
+  }
+}
+
+/**
+ * "v18" and "18.x" are the Node 18 line
+ */
+function normalizeVersion(version: string): string {
+  return version.trim().replace(/^v/i, '').replace(/\.x$/i, '');
+}
+
+function compareVersions(a: string, b: string): number {
+  const left = a.split('.').map(Number);
+  const right = b.split('.').map(Number);
+  return (left[0]! - right[0]!) || ((left[1] ?? 0) - (right[1] ?? 0));
+}
+
+/**
+ * Release lines from low to high inclusive: every minor of a Go or Python major, every even Node
+ * major. Undefined for reversed, cross-major or overly wide ranges.
+ */
+function expandRange(runtime: TestedVersions['runtime'], low: string, high: string): string[] | undefined {
+  const [lowMajor, lowMinor] = low.split('.').map(Number);
+  const [highMajor, highMinor] = high.split('.').map(Number);
+  const versions: string[] = [];
+
+  if (runtime === 'node') {
+    for (let major = lowMajor!; major <= highMajor!; major++) {
+      if (major === lowMajor || major === highMajor || major % NODE_LTS_STEP === 0) {
+        versions.push(String(major));
+      }
+    }
+  } else if (lowMajor === highMajor) {
+    for (let minor = lowMinor!; minor <= highMinor!; minor++) {
+      versions.push(`${lowMajor}.${minor}`);
+    }
+  }
+
+  return versions.length >= 2 && versions.length <= MAX_RANGE_VERSIONS ? versions : undefined;
+}
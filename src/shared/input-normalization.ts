@@ -0,0 +1,44 @@
+/**
+ * Input normalization - Read project files the same way whatever file system or editor they come from
+ */
+
+/**
+ * Byte order mark Windows editors start UTF-8 files with
+ */
+const BOM = '\uFEFF';
+
+/**
+ * Lowercased names of the files the parser and detectors look up by name. A checkout on the
+ * case-insensitive file systems of macOS and Windows finds them whatever their case, so they are
+ * found that way everywhere. Makefiles are left out: make itself tells makefile from Makefile.
+ */
+export const WELL_KNOWN_FILES = new Set([
+  'readme', 'readme.md', 'readme.markdown', 'readme.rst', 'readme.adoc', 'readme.asciidoc', 'readme.txt',
+  'package.json', 'package-lock.json', 'yarn.lock', 'pnpm-lock.yaml', 'pnpm-workspace.yaml', 'bun.lockb', 'tsconfig.json',
+  'pyproject.toml', 'setup.py', 'setup.cfg', 'requirements.txt', 'pipfile', 'pipfile.lock', 'poetry.lock', 'tox.ini', 'pytest.ini',
+  'go.mod', 'go.sum', 'cargo.toml', 'cargo.lock',
+  'pom.xml', 'build.gradle', 'build.gradle.kts', 'settings.gradle', 'settings.gradle.kts',
+  'mix.exs', 'mix.lock', 'gemfile', 'gemfile.lock', 'rakefile', 'cmakelists.txt', 'cmakepresets.json',
+  'dockerfile', 'docker-compose.yml', 'docker-compose.yaml', 'compose.yml', 'compose.yaml'
+]);
+
+/**
+ * Content without the byte order mark it may start with, which breaks JSON.parse and anchored patterns
+ */
+export function stripBOM(content: string): string {
+  return content.startsWith(BOM) ? content.slice(BOM.length) : content;
+}
+
+/**
+ * Path with Windows separators turned into forward slashes
+ */
+export function toPosixPath(path: string): string {
+  return path.replace(/\\/g, '/');
+}
+
+/**
+ * Whether a file name is one of WELL_KNOWN_FILES, in any case
+ */
+export function isWellKnownFile(name: string): boolean {
+  return WELL_KNOWN_FILES.has(name.toLowerCase());
+}
@@ -14,5 +14,7 @@ export {
   LoggerFactory
 } from './central-logger';
 
+export { PipelineLogger, NOOP_LOGGER } from './pipeline-logger';
+
 // Re-export for convenience
 export { logger as default } from './central-logger';
\ No newline at end of file
@@ -0,0 +1,27 @@
+/**
+ * Pipeline Logger
+ *
+ * What the detection and generation engine reports its decisions to. Library consumers
+ * pass their own logger (the CLI's Logger and ICentralLogger both fit); without one the
+ * engine logs nothing.
+ */
+
+/**
+ * Leveled logger taking a message and structured context
+ */
+export interface PipelineLogger {
+  error(message: string, context?: Record<string, any>): void;
+  warn(message: string, context?: Record<string, any>): void;
+  info(message: string, context?: Record<string, any>): void;
+  debug(message: string, context?: Record<string, any>): void;
+}
+
+/**
+ * Logger discarding everything, the default of the detection and generation engine
+ */
+export const NOOP_LOGGER: PipelineLogger = {
+  error: () => undefined,
+  warn: () => undefined,
+  info: () => undefined,
+  debug: () => undefined
+};
@@ -140,7 +140,7 @@ describe('CI Step Generation Integration', () => {
 
       // Verify both Python and Rust setup steps
       const pythonSetupSteps = pipeline.setup.filter(step => step.uses?.includes('setup-python'));
-      const rustSetupSteps = pipeline.setup.filter(step => step.uses?.includes('actions-rs/toolchain'));
+      const rustSetupSteps = pipeline.setup.filter(step => step.uses?.includes('dtolnay/rust-toolchain'));
       
       expect(pythonSetupSteps).toHaveLength(1);
       expect(rustSetupSteps).toHaveLength(1);
@@ -249,7 +249,7 @@ describe('Environment Management Integration', () => {
 
       // Should include Azure OIDC step
       expect(steps.some(step => 
-        step.uses === 'azure/login@v1'
+        step.uses === 'azure/login@v2'
       )).toBe(true);
 
       // Should include GCP OIDC step
@@ -159,7 +159,7 @@ describe('RustWorkflowGenerator Integration Tests', () => {
       const coverageStep = steps.find((step: any) => step.run?.includes('cargo-tarpaulin'));
       expect(coverageStep).toBeDefined();
       
-      const uploadStep = steps.find((step: any) => step.uses === 'codecov/codecov-action@v4');
+      const uploadStep = steps.find((step: any) => step.uses === 'codecov/codecov-action@v5');
       expect(uploadStep).toBeDefined();
     });
   });
@@ -0,0 +1,80 @@
+/**
+ * Monorepo detection performance - how many package detections the worker pool runs at once
+ */
+
+import { describe, it, expect, beforeAll, afterAll, vi } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { MonorepoDetector } from '../../src/detection/monorepo-detector';
+import { FrameworkDetector } from '../../src/detection/interfaces/framework-detector';
+import { DetectionResult } from '../../src/detection/interfaces/detection-result';
+
+const PACKAGE_COUNT = 200;
+
+/**
+ * Time a package detection takes waiting on its files, simulated so detections overlap in the pool
+ */
+const DETECTION_LATENCY_MS = 5;
+
+describe('Monorepo Detection Performance', () => {
+  let tempDir: string;
+
+  const detectionResult: DetectionResult = {
+    frameworks: [],
+    buildTools: [],
+    containers: [],
+    confidence: { score: 0.8, level: 'high', breakdown: {} as any, factors: [], recommendations: [] },
+    alternatives: [],
+    warnings: [],
+    detectedAt: new Date(),
+    executionTime: DETECTION_LATENCY_MS
+  };
+
+  /** Detect the tree with a slow detector, recording the most detections in flight at once */
+  const detect = async (concurrency: number) => {
+    let inFlight = 0;
+    let peak = 0;
+    const frameworkDetector: FrameworkDetector = {
+      detectFrameworks: vi.fn(async () => {
+        peak = Math.max(peak, ++inFlight);
+        await new Promise(resolve => setTimeout(resolve, DETECTION_LATENCY_MS));
+        inFlight--;
+        return detectionResult;
+      }),
+      suggestCISteps: vi.fn()
+    };
+
+    const units = await new MonorepoDetector(frameworkDetector, 6, concurrency).detect(tempDir);
+    return { units, peak };
+  };
+
+  beforeAll(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'monorepo-benchmark-'));
+    // A synthetic tree of 200 packages in 20 groups, mixing ecosystems
+    for (let index = 0; index < PACKAGE_COUNT; index++) {
+      const directory = path.join(tempDir, `group-${index % 20}`, `package-${index}`);
+      fs.mkdirSync(directory, { recursive: true });
+      if (index % 2 === 0) {
+        fs.writeFileSync(path.join(directory, 'package.json'), JSON.stringify({ name: `package-${index}` }));
+      } else {
+        fs.writeFileSync(path.join(directory, 'go.mod'), `module example.com/package-${index}\n`);
+      }
+    }
+  });
+
+  afterAll(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should detect a 200-package tree with up to as many detections at once as the pool has workers', async () => {
+    const sequential = await detect(1);
+    const parallel = await detect(8);
+
+    expect(sequential.units).toHaveLength(PACKAGE_COUNT);
+    expect(parallel.units.map(unit => unit.path)).toEqual(sequential.units.map(unit => unit.path));
+    expect(sequential.peak).toBe(1);
+    expect(parallel.peak).toBeGreaterThan(1);
+    expect(parallel.peak).toBeLessThanOrEqual(8);
+  });
+});
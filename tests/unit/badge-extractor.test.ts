@@ -0,0 +1,75 @@
+/**
+ * Tests for BadgeExtractor
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import { BadgeExtractor } from '../../src/parser/utils/badge-extractor';
+
+describe('BadgeExtractor', () => {
+  let extractor: BadgeExtractor;
+
+  beforeEach(() => {
+    extractor = new BadgeExtractor();
+  });
+
+  it('should extract linked inline badges with their targets', () => {
+    const badges = extractor.extract([
+      '# App',
+      '[![CI](https://github.com/acme/app/actions/workflows/ci.yml/badge.svg)](https://github.com/acme/app/actions/workflows/ci.yml)',
+      '[![npm](https://img.shields.io/npm/v/app.svg)](https://www.npmjs.com/package/app)'
+    ].join('\n'));
+
+    expect(badges).toEqual([
+      {
+        alt: 'CI',
+        imageUrl: 'https://github.com/acme/app/actions/workflows/ci.yml/badge.svg',
+        targetUrl: 'https://github.com/acme/app/actions/workflows/ci.yml',
+        provider: 'github-actions'
+      },
+      {
+        alt: 'npm',
+        imageUrl: 'https://img.shields.io/npm/v/app.svg',
+        targetUrl: 'https://www.npmjs.com/package/app'
+      }
+    ]);
+  });
+
+  it('should resolve reference-style images and links', () => {
+    const badges = extractor.extract([
+      '[![Build Status][travis-image]][travis-url] ![Coverage]',
+      '',
+      '[travis-image]: https://travis-ci.com/acme/app.svg?branch=main',
+      '[travis-url]: https://travis-ci.com/acme/app',
+      '[coverage]: https://img.shields.io/codecov/c/github/acme/app "Coverage"'
+    ].join('\n'));
+
+    expect(badges.map(badge => [badge.imageUrl, badge.targetUrl, badge.provider])).toEqual([
+      ['https://travis-ci.com/acme/app.svg?branch=main', 'https://travis-ci.com/acme/app', 'travis'],
+      ['https://img.shields.io/codecov/c/github/acme/app', undefined, undefined]
+    ]);
+  });
+
+  it('should infer providers from shields.io paths and endpoints', () => {
+    expect(extractor.inferProvider('https://img.shields.io/github/actions/workflow/status/acme/app/ci.yml')).toBe('github-actions');
+    expect(extractor.inferProvider('https://img.shields.io/circleci/build/github/acme/app')).toBe('circleci');
+    expect(extractor.inferProvider(
+      'https://img.shields.io/endpoint?url=https%3A%2F%2Fgitlab.com%2Facme%2Fapp%2F-%2Fpipelines%2Fbadge.json'
+    )).toBe('gitlab');
+    expect(extractor.inferProvider('https://img.shields.io/badge/license-MIT-blue')).toBeUndefined();
+    expect(extractor.inferProvider('not a url')).toBeUndefined();
+  });
+
+  it('should report badges pointing at the same target once', () => {
+    const badges = extractor.extract([
+      '[![CI](https://circleci.com/gh/acme/app.svg?style=svg)](https://circleci.com/gh/acme/app)',
+      '<a href="https://circleci.com/gh/acme/app"><img src="https://circleci.com/gh/acme/app.svg?style=shield" alt="CircleCI"></a>'
+    ].join('\n'));
+
+    expect(badges).toHaveLength(1);
+    expect(badges[0]!.provider).toBe('circleci');
+  });
+
+  it('should ignore images that are not badges', () => {
+    expect(extractor.extract('![Screenshot](docs/screenshot.png)\n[![Logo](logo.png)](https://example.com)')).toEqual([]);
+  });
+});
@@ -0,0 +1,63 @@
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { ActRunner } from '../../../src/cli/lib/act-runner';
+import { Logger } from '../../../src/cli/lib/logger';
+
+describe('ActRunner', () => {
+  let actRunner: ActRunner;
+  let executeActSpy: any;
+  let tempDir: string;
+  let workflowPath: string;
+
+  beforeEach(() => {
+    const mockLogger = {
+      debug: vi.fn(),
+      info: vi.fn(),
+      warn: vi.fn(),
+      error: vi.fn()
+    } as any as Logger;
+
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'act-runner-test-'));
+    workflowPath = path.join(tempDir, 'ci.yml');
+    fs.writeFileSync(workflowPath, 'name: CI\non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n  test:\n    runs-on: ubuntu-latest\n');
+
+    actRunner = new ActRunner(mockLogger, tempDir);
+    executeActSpy = vi.spyOn(actRunner as any, 'executeAct');
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should run the first job of the workflow, or the one named', async () => {
+    executeActSpy.mockResolvedValue({ stdout: 'Job succeeded\n', stderr: '' });
+
+    expect(await actRunner.verify(workflowPath)).toEqual({ job: 'build', success: true, output: 'Job succeeded\n' });
+    expect(executeActSpy).toHaveBeenCalledWith(['-W', workflowPath, '-j', 'build'], undefined);
+
+    await actRunner.verify(workflowPath, { job: 'test' });
+    expect(executeActSpy).toHaveBeenLastCalledWith(['-W', workflowPath, '-j', 'test'], undefined);
+  });
+
+  it('should report a failing job with what act printed', async () => {
+    executeActSpy
+      .mockResolvedValueOnce({ stdout: 'act version 0.2.61', stderr: '' })
+      .mockRejectedValueOnce(Object.assign(new Error('Command failed'), { code: 1, stdout: 'Job failed\n', stderr: '' }));
+
+    expect(await actRunner.verify(workflowPath)).toEqual({ job: 'build', success: false, output: 'Job failed\n' });
+  });
+
+  it('should fail with ACT_NOT_FOUND when act is not installed, and for jobs the workflow does not have', async () => {
+    executeActSpy.mockRejectedValue(Object.assign(new Error('spawn act ENOENT'), { code: 'ENOENT' }));
+    await expect(actRunner.verify(workflowPath)).rejects.toMatchObject({
+      code: 'ACT_NOT_FOUND',
+      message: 'act not found - install it to run workflows locally'
+    });
+
+    executeActSpy.mockResolvedValue({ stdout: '', stderr: '' });
+    await expect(actRunner.verify(workflowPath, { job: 'deploy' })).rejects.toMatchObject({ code: 'JOB_NOT_FOUND' });
+  });
+});
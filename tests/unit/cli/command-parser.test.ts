@@ -44,6 +44,15 @@ describe('CommandParser', () => {
       expect(options.command).toBe('validate');
     });
 
+    it('should parse verify command with the workflow and job to run', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'verify', '.github/workflows/ci.yml', '--job', 'test']);
+
+      expect(options.command).toBe('verify');
+      expect(options.workflowPath).toBe('.github/workflows/ci.yml');
+      expect(options.job).toBe('test');
+      expect(parser.parseArguments(['node', 'cli.js', 'verify']).job).toBeUndefined();
+    });
+
     it('should parse init command', () => {
       const args = ['node', 'cli.js', 'init'];
       const options = parser.parseArguments(args);
@@ -102,6 +111,361 @@ describe('CommandParser', () => {
       expect(options.workflowType).toEqual(['ci', 'cd']);
     });
 
+    it('should leave the provider to the repository configuration without --provider', () => {
+      const args = ['node', 'cli.js', 'generate'];
+      const options = parser.parseArguments(args);
+
+      expect(options.provider).toBe('github');
+      expect(options.providerDefaulted).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--provider', 'github']).providerDefaulted).toBeUndefined();
+    });
+
+    it('should parse gitlab provider', () => {
+      const args = ['node', 'cli.js', 'generate', '--provider', 'gitlab'];
+      const options = parser.parseArguments(args);
+
+      expect(options.provider).toBe('gitlab');
+    });
+
+    it('should parse circleci provider with orbs', () => {
+      const args = ['node', 'cli.js', 'generate', '--provider', 'circleci', '--circleci-orbs'];
+      const options = parser.parseArguments(args);
+
+      expect(options.provider).toBe('circleci');
+      expect(options.circleciOrbs).toBe(true);
+    });
+
+    it('should parse make ci mode', () => {
+      const args = ['node', 'cli.js', 'generate', '--make-ci'];
+      const options = parser.parseArguments(args);
+
+      expect(options.makeCi).toBe(true);
+    });
+
+    it('should parse rust workspace layout and cargo feature matrix', () => {
+      const args = ['node', 'cli.js', 'generate', '--rust-workspace', 'per-crate', '--cargo-features-matrix'];
+      const options = parser.parseArguments(args);
+
+      expect(options.rustWorkspace).toBe('per-crate');
+      expect(options.cargoFeatureMatrix).toBe(true);
+    });
+
+    it('should parse pages deployment options', () => {
+      const args = ['node', 'cli.js', 'generate', '--deploy-pages', '--pages-dir', 'build/site'];
+      const options = parser.parseArguments(args);
+
+      expect(options.deployPages).toBe(true);
+      expect(options.pagesDir).toBe('build/site');
+    });
+
+    it('should parse and validate the confidence threshold', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--min-confidence', '0.6']);
+
+      expect(options.minConfidence).toBe(0.6);
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--min-confidence', '1.5'])).toThrow();
+    });
+
+    it('should parse runner labels given space or comma separated', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--runner-labels', 'self-hosted,linux', 'gpu']);
+
+      expect(options.runnerLabels).toEqual(['self-hosted', 'linux', 'gpu']);
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--runner-labels', ' , '])).toThrow('Runner labels must include at least one non-empty label');
+    });
+
+    it('should parse runner images given as os=label pairs', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--runner-images', 'linux=ubuntu-22.04,MacOS=macos-14', 'windows=windows-2022']);
+
+      expect(options.runnerImages).toEqual({ linux: 'ubuntu-22.04', macos: 'macos-14', windows: 'windows-2022' });
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).runnerImages).toBeUndefined();
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--runner-images', 'ubuntu-22.04'])).toThrow("Invalid --runner-images 'ubuntu-22.04'");
+    });
+
+    it('should parse the unit test operating systems given space or comma separated', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--os', 'linux,MacOS', 'windows']);
+
+      expect(options.os).toEqual(['linux', 'macos', 'windows']);
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--os', 'beos'])).toThrow("Invalid --os 'beos'");
+    });
+
+    it('should parse the detection output format', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--format', 'json']);
+
+      expect(options.format).toBe('json');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--format', 'xml'])).toThrow();
+    });
+
+    it('should parse azure provider', () => {
+      const args = ['node', 'cli.js', 'generate', '--provider', 'azure'];
+      const options = parser.parseArguments(args);
+
+      expect(options.provider).toBe('azure');
+    });
+
+    it('should parse bitbucket provider', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--provider', 'bitbucket']);
+
+      expect(options.provider).toBe('bitbucket');
+    });
+
+    it('should parse jenkins provider', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--provider', 'jenkins']);
+
+      expect(options.provider).toBe('jenkins');
+    });
+
+    it('should parse a generation preset', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--preset', 'lint']);
+
+      expect(options.preset).toBe('lint');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--preset', 'deploy'])).toThrow();
+    });
+
+    it('should parse --force', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--force']).force).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).force).toBe(false);
+    });
+
+    it('should parse private module patterns and their secret', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--private-modules', 'acme/*,gitlab.acme.dev', '--private-modules-secret', 'ACME_TOKEN']);
+
+      expect(options.privateModules).toEqual(['acme/*', 'gitlab.acme.dev']);
+      expect(options.privateModulesSecret).toBe('ACME_TOKEN');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--private-modules-secret', 'acme-token'])).toThrow('Invalid --private-modules-secret');
+    });
+
+    it('should parse test retries and job timeout as positive integers', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--test-retries', '2', '--job-timeout', '20']);
+
+      expect(options.testRetries).toBe(2);
+      expect(options.jobTimeout).toBe(20);
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--test-retries', '0'])).toThrow('Option --test-retries must be a positive integer');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--job-timeout', '1.5'])).toThrow('Option --job-timeout must be a positive integer');
+    });
+
+    it('should parse artifact upload options', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--artifacts', '--artifact-name', 'server', '--artifact-retention-days', '30']);
+
+      expect(options.artifacts).toBe(true);
+      expect(options.artifactName).toBe('server');
+      expect(options.artifactRetentionDays).toBe(30);
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--artifact-path', 'out/'])).toThrow('require --artifacts');
+    });
+
+    it('should parse --reusable', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--reusable']).reusable).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).reusable).toBe(false);
+    });
+
+    it('should parse --split and its setup mode, and reject splitting a reusable workflow', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--split', 'by-kind', '--split-setup', 'duplicate']);
+
+      expect(options.split).toBe('by-kind');
+      expect(options.splitSetup).toBe('duplicate');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--split', 'by-kind', '--reusable'])).toThrow('mutually exclusive');
+    });
+
+    it('should parse --minimal and reject it with --provenance', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--minimal']).minimal).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).minimal).toBe(false);
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--minimal', '--provenance']))
+        .toThrow('Options --minimal and --provenance are mutually exclusive');
+    });
+
+    it('should parse a working directory inside the repository', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--working-directory', 'services/app']).workingDirectory).toBe('services/app');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--working-directory', '../app'])).toThrow('Invalid --working-directory');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--working-directory', '/srv/app'])).toThrow('Invalid --working-directory');
+    });
+
+    it('should parse tag release options', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--release', '--release-tag-prefix', '']);
+
+      expect(options.release).toBe(true);
+      expect(options.releaseTagPrefix).toBe('');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--release-tag-prefix', 'release-'])).toThrow('requires --release');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--release', '--release-tag-prefix', 'v*'])).toThrow('Invalid --release-tag-prefix');
+    });
+
+    it('should parse the node workspace layout', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--node-workspace', 'per-package']).nodeWorkspace).toBe('per-package');
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).nodeWorkspace).toBeUndefined();
+    });
+
+    it('should parse --check', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--check']).check).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).check).toBe(false);
+    });
+
+    it('should parse --terraform', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--terraform']).terraform).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).terraform).toBe(false);
+    });
+
+    it('should parse --phoenix-db', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--phoenix-db']).phoenixDb).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).phoenixDb).toBe(false);
+    });
+
+    it('should parse --cxx-matrix', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--cxx-matrix']).cxxMatrix).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).cxxMatrix).toBe(false);
+    });
+
+    it('should parse --swift-linux', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--swift-linux']).swiftLinux).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).swiftLinux).toBe(false);
+    });
+
+    it('should parse --license-check', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--license-check']).licenseCheck).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).licenseCheck).toBe(false);
+    });
+
+    it('should parse --changelog-check', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--changelog-check']).changelogCheck).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).changelogCheck).toBe(false);
+    });
+
+    it('should parse --codeowners-comment', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--codeowners-comment']).codeownersComment).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).codeownersComment).toBe(false);
+    });
+
+    it('should parse --api-lint', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--api-lint']).apiLint).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).apiLint).toBe(false);
+    });
+
+    it('should parse --protobuf', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--protobuf']).protobuf).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).protobuf).toBe(false);
+    });
+
+    it('should parse --flutter-build', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--flutter-build']).flutterBuild).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).flutterBuild).toBe(false);
+    });
+
+    it('should parse --provenance', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--provenance']).provenance).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).provenance).toBe(false);
+    });
+
+    it('should parse --emit composite-action', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--emit', 'composite-action']).emit).toBe('composite-action');
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).emit).toBeUndefined();
+    });
+
+    it('should parse --emit-secrets-manifest', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--emit-secrets-manifest']).emitSecretsManifest).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).emitSecretsManifest).toBe(false);
+    });
+
+    it('should parse --pin-actions', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--pin-actions']).pinActions).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).pinActions).toBe(false);
+    });
+
+    it('should parse --repair and the fixes it skips', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--repair', '--repair-skip', 'pin-actions,concurrency']);
+
+      expect(options.repair).toBe(true);
+      expect(options.repairSkip).toEqual(['pin-actions', 'concurrency']);
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--repair-skip', 'timeouts']))
+        .toThrow("Invalid --repair-skip 'timeouts': expected one of pin-actions, permissions, concurrency");
+    });
+
+    it('should parse --dependabot and --renovate, which exclude each other', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--dependabot']).dependabot).toBe(true);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--renovate']).renovate).toBe(true);
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--dependabot', '--renovate']))
+        .toThrow('Options --dependabot and --renovate are mutually exclusive');
+    });
+
+    it('should parse a default branch override', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--default-branch', 'trunk']);
+
+      expect(options.defaultBranch).toBe('trunk');
+    });
+
+    it('should parse --no-cancel-in-progress', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--no-cancel-in-progress']).cancelInProgress).toBe(false);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).cancelInProgress).toBe(true);
+    });
+
+    it('should parse --no-permissions', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--no-permissions']).permissions).toBe(false);
+      expect(parser.parseArguments(['node', 'cli.js', 'generate']).permissions).toBe(true);
+    });
+
+    it('should parse a nightly schedule and reject malformed cron expressions', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--schedule', '0 6 * * *']).schedule).toBe('0 6 * * *');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--schedule', '0 6 * *'])).toThrow('expected 5 fields');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--schedule', '0 25 * * *'])).toThrow('invalid hour');
+    });
+
+    it('should parse a coverage upload service', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--coverage', 'coveralls']);
+
+      expect(options.coverage).toBe('coveralls');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--coverage', 'sonar'])).toThrow();
+    });
+
+    it('should parse monorepo layout', () => {
+      const args = ['node', 'cli.js', 'generate', '--monorepo', 'per-package'];
+      const options = parser.parseArguments(args);
+
+      expect(options.monorepo).toBe('per-package');
+    });
+
+    it('should parse changed-files mode for monorepos only', () => {
+      const options = parser.parseArguments(['node', 'cli.js', 'generate', '--monorepo', 'single', '--changed-files', 'paths-filter']);
+      expect(options.changedFiles).toBe('paths-filter');
+
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--changed-files', 'path-triggers'])).toThrow('Option --changed-files requires --monorepo');
+    });
+
+    it('should parse the monorepo detection worker count', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--monorepo', 'single', '--detect-workers', '3']).detectWorkers).toBe(3);
+
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--monorepo', 'single', '--detect-workers', '0'])).toThrow('Option --detect-workers must be a positive integer');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--detect-workers', '2'])).toThrow('Option --detect-workers requires --monorepo');
+    });
+
+    it('should parse the ref to regenerate changed packages since for per-package monorepos only', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--monorepo', 'per-package', '--since', 'origin/main']).since).toBe('origin/main');
+
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--monorepo', 'single', '--since', 'HEAD~1'])).toThrow('Option --since requires --monorepo per-package');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--monorepo', 'per-package', '--since', 'HEAD~1', '--dependabot']))
+        .toThrow('Option --since cannot be combined with --dependabot or --renovate');
+    });
+
+    it('should parse a container registry', () => {
+      const args = ['node', 'cli.js', 'generate', '--registry', 'registry.example.com'];
+      const options = parser.parseArguments(args);
+
+      expect(options.registry).toBe('registry.example.com');
+    });
+
+    it('should parse test runner layout', () => {
+      const args = ['node', 'cli.js', 'generate', '--test-runners', 'matrix'];
+      const options = parser.parseArguments(args);
+
+      expect(options.testRunners).toBe('matrix');
+    });
+
+    it('should parse existing CI action', () => {
+      const args = ['node', 'cli.js', 'generate', '--existing-ci', 'skip'];
+      const options = parser.parseArguments(args);
+
+      expect(options.existingCi).toBe('skip');
+    });
+
+    it('should parse pre-commit mode', () => {
+      expect(parser.parseArguments(['node', 'cli.js', 'generate', '--pre-commit', 'replace-lint']).preCommit).toBe('replace-lint');
+      expect(() => parser.parseArguments(['node', 'cli.js', 'generate', '--pre-commit', 'always'])).toThrow();
+    });
+
     it('should parse single framework override', () => {
       const args = ['node', 'cli.js', 'generate', '--framework', 'nodejs'];
       const options = parser.parseArguments(args);
@@ -146,6 +510,14 @@ describe('CommandParser', () => {
       expect(options.verbose).toBe(true);
     });
 
+    it('should treat a repeated verbose flag as debug', () => {
+      const args = ['node', 'cli.js', 'generate', '-vv'];
+      const options = parser.parseArguments(args);
+
+      expect(options.verbose).toBe(true);
+      expect(options.debug).toBe(true);
+    });
+
     it('should parse debug flag', () => {
       const args = ['node', 'cli.js', 'generate', '--debug'];
       const options = parser.parseArguments(args);
@@ -381,6 +753,13 @@ describe('CommandParser', () => {
       // Note: Examples are added via addHelpText but may not appear in helpInformation()
       expect(helpText.length).toBeGreaterThan(100);
     });
+
+    it('should describe --provider without a github default', () => {
+      const generateHelp = parser.getProgram().commands.find(command => command.name() === 'generate')!.helpInformation();
+
+      expect(generateHelp).toContain('required when it has no CI configuration or several');
+      expect(generateHelp).not.toContain('(default: "github")');
+    });
   });
 
   describe('Error Handling', () => {
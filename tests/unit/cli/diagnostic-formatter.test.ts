@@ -0,0 +1,37 @@
+/**
+ * Diagnostic Formatter Unit Tests
+ */
+
+import { describe, it, expect, vi } from 'vitest';
+import { formatDiagnostics } from '../../../src/cli/lib/diagnostic-formatter';
+
+// Mock chalk to return plain strings for easier testing
+vi.mock('chalk', () => ({
+  default: {
+    red: (str: string) => str,
+    yellow: (str: string) => str,
+    cyan: (str: string) => str,
+    gray: (str: string) => str,
+    bold: (str: string) => str
+  }
+}));
+
+describe('formatDiagnostics', () => {
+  it('should print a line per diagnostic with its severity, code and path, then the counts', () => {
+    const output = formatDiagnostics([
+      { severity: 'error', code: 'DETECTOR_FAILED', message: 'Failed to run docker detector: EACCES' },
+      { severity: 'warning', code: 'LOCKFILE_MISSING', message: 'No yarn.lock committed for yarn', path: 'web/yarn.lock' },
+      { severity: 'warning', code: 'VERSION_UNPARSEABLE', message: "Invalid node version constraint 'latest'", path: 'package.json' },
+      { severity: 'info', code: 'DOCKERIGNORE_MISSING', message: 'No .dockerignore next to Dockerfile', path: 'Dockerfile' }
+    ]);
+
+    expect(output).toBe([
+      'Detection diagnostics:',
+      '  error   DETECTOR_FAILED Failed to run docker detector: EACCES',
+      '  warning LOCKFILE_MISSING web/yarn.lock: No yarn.lock committed for yarn',
+      "  warning VERSION_UNPARSEABLE package.json: Invalid node version constraint 'latest'",
+      '  info    DOCKERIGNORE_MISSING Dockerfile: No .dockerignore next to Dockerfile',
+      '  1 error, 2 warnings, 1 info'
+    ].join('\n'));
+  });
+});
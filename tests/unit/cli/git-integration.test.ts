@@ -84,6 +84,94 @@ describe('GitIntegration', () => {
     });
   });
 
+  describe('getDefaultBranch', () => {
+    it('should prefer the branch origin/HEAD points at', async () => {
+      executeGitCommandSpy.mockResolvedValue({ stdout: 'origin/master\n', stderr: '' });
+
+      expect(await gitIntegration.getDefaultBranch()).toBe('master');
+      expect(executeGitCommandSpy).toHaveBeenCalledWith('symbolic-ref --short refs/remotes/origin/HEAD');
+    });
+
+    it('should fall back to the checked out branch and then give up', async () => {
+      executeGitCommandSpy.mockImplementation((command: string) => command.endsWith(' HEAD') && !command.includes('origin')
+        ? Promise.resolve({ stdout: 'trunk\n', stderr: '' })
+        : Promise.reject(new Error('ref refs/remotes/origin/HEAD is not a symbolic ref')));
+      expect(await gitIntegration.getDefaultBranch()).toBe('trunk');
+
+      executeGitCommandSpy.mockRejectedValue(new Error('Not a git repository'));
+      expect(await gitIntegration.getDefaultBranch()).toBeUndefined();
+    });
+  });
+
+  describe('resolveRemoteRef', () => {
+    const sha = 'a'.repeat(40);
+
+    it('should prefer the commit an annotated tag points at', async () => {
+      executeGitCommandSpy.mockResolvedValue({ stdout: `${'b'.repeat(40)}\trefs/tags/v4\n${sha}\trefs/tags/v4^{}\n`, stderr: '' });
+
+      expect(await gitIntegration.resolveRemoteRef('actions/checkout', 'v4')).toBe(sha);
+    });
+
+    it('should retry transient failures, and warn and resolve nothing once they persist', async () => {
+      const git = new GitIntegration(mockLogger, '/test/directory', { maxAttempts: 3, sleep: async () => {} });
+      const ls = vi.spyOn(git as any, 'executeGitCommand')
+        .mockRejectedValueOnce(new Error('fatal: unable to access: Could not resolve host: github.com'))
+        .mockResolvedValueOnce({ stdout: `${sha}\trefs/heads/main\n`, stderr: '' });
+
+      expect(await git.resolveRemoteRef('actions/checkout', 'main')).toBe(sha);
+      expect(ls).toHaveBeenCalledTimes(2);
+
+      ls.mockReset().mockRejectedValue(Object.assign(new Error('Command failed'), { killed: true }));
+      expect(await git.resolveRemoteRef('actions/checkout', 'v4')).toBeUndefined();
+      expect(ls).toHaveBeenCalledTimes(3);
+      expect(mockLogger.warn).toHaveBeenCalledWith('Could not reach actions/checkout to resolve v4; leaving it unresolved', { error: 'Command failed' });
+    });
+
+    it('should not retry a repository that does not exist', async () => {
+      executeGitCommandSpy.mockRejectedValue(new Error("remote: Repository not found.\nfatal: repository 'https://github.com/acme/none.git/' not found"));
+
+      expect(await gitIntegration.resolveRemoteRef('acme/none', 'v1')).toBeUndefined();
+      expect(executeGitCommandSpy).toHaveBeenCalledTimes(1);
+      expect(mockLogger.warn).not.toHaveBeenCalled();
+    });
+  });
+
+  describe('getChangedFilesSince', () => {
+    it('should list the files changed since the ref and the untracked ones', async () => {
+      executeGitCommandSpy.mockImplementation((command: string) => {
+        if (command.startsWith('diff')) {
+          return Promise.resolve({ stdout: 'libs/shared/util.go\0services/api/main.go\0', stderr: '' });
+        } else if (command.startsWith('ls-files')) {
+          return Promise.resolve({ stdout: 'services/api/new.go\0libs/shared/util.go\0', stderr: '' });
+        }
+        return Promise.resolve({ stdout: '', stderr: '' });
+      });
+
+      expect(await gitIntegration.getChangedFilesSince('origin/main')).toEqual([
+        'libs/shared/util.go',
+        'services/api/main.go',
+        'services/api/new.go'
+      ]);
+      expect(executeGitCommandSpy).toHaveBeenCalledWith("rev-parse --verify --quiet 'origin/main^{commit}'");
+      expect(executeGitCommandSpy).toHaveBeenCalledWith("diff --name-only --no-renames --relative -z 'origin/main'");
+    });
+
+    it('should fail outside a repository and for refs that do not name a commit', async () => {
+      executeGitCommandSpy.mockRejectedValue(new Error('Not a git repository'));
+      await expect(gitIntegration.getChangedFilesSince('main')).rejects.toMatchObject({ code: 'NOT_GIT_REPOSITORY' });
+
+      executeGitCommandSpy.mockImplementation((command: string) => command.startsWith('rev-parse --git-dir')
+        ? Promise.resolve({ stdout: '.git', stderr: '' })
+        : Promise.reject(new Error('fatal: Needed a single revision')));
+      await expect(gitIntegration.getChangedFilesSince('no-such-branch')).rejects.toMatchObject({
+        code: 'INVALID_REF',
+        message: "'no-such-branch' does not name a commit"
+      });
+      await expect(gitIntegration.getChangedFilesSince("main'; rm -rf ~")).rejects.toMatchObject({ code: 'INVALID_REF' });
+      expect(executeGitCommandSpy.mock.calls.some((call: string[]) => call[0]!.includes('rm -rf'))).toBe(false);
+    });
+  });
+
   describe('createCommit', () => {
     it('should create commit with descriptive message', async () => {
       const files = ['.github/workflows/ci.yml', '.github/workflows/cd.yml'];
@@ -0,0 +1,256 @@
+/**
+ * Repository Configuration Tests
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { loadConfig, REPO_CONFIG_SCHEMA } from '../../../src/cli/config/repo-config';
+import { ConfigurationError } from '../../../src/cli/config/configuration-manager';
+
+describe('loadConfig', () => {
+  let tempDir: string;
+
+  const writeConfig = (fileName: string, content: string): void => {
+    fs.writeFileSync(path.join(tempDir, fileName), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'repo-config-test-'));
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should return an empty config when the repository has none', async () => {
+    expect(await loadConfig(tempDir)).toEqual({ config: {}, warnings: [] });
+  });
+
+  it('should read overrides from .readme-to-cicd.yml', async () => {
+    writeConfig('.readme-to-cicd.yml', [
+      'provider: gitlab',
+      'runnerOS: ubuntu-22.04',
+      'versions:',
+      '  node: ["18", "20"]',
+      '  python: "3.12"',
+      'disabledJobs: [lint]',
+      'env:',
+      '  CI_MODE: strict',
+      '  RETRIES: 3'
+    ].join('\n'));
+
+    const loaded = await loadConfig(tempDir);
+
+    expect(loaded.path).toBe(path.join(tempDir, '.readme-to-cicd.yml'));
+    expect(loaded.config).toEqual({
+      provider: 'gitlab',
+      runnerOS: 'ubuntu-22.04',
+      versions: { node: ['18', '20'], python: ['3.12'] },
+      disabledJobs: ['lint'],
+      env: { CI_MODE: 'strict', RETRIES: '3' }
+    });
+    expect(loaded.warnings).toEqual([]);
+  });
+
+  it('should warn about unknown keys instead of failing', async () => {
+    writeConfig('.readme-to-cicd.yaml', 'provider: github\nfutureOption: true\nversions:\n  ruby: "3.3"\nui:\n  colorOutput: false\n');
+
+    const loaded = await loadConfig(tempDir);
+
+    expect(loaded.config).toEqual({ provider: 'github', versions: {} });
+    expect(loaded.warnings).toEqual([
+      "Unknown key 'futureOption' in .readme-to-cicd.yaml is ignored",
+      "Unknown runtime 'ruby' under versions is ignored; supported runtimes are node, python, go"
+    ]);
+  });
+
+  it('should drop unknown disabledJobs entries with a warning', async () => {
+    writeConfig('.readme-to-cicd.yml', 'disabledJobs: [docker, test, unit-tests-app_core, deploy]\n');
+
+    const loaded = await loadConfig(tempDir);
+
+    expect(loaded.config.disabledJobs).toEqual(['docker', 'test', 'unit-tests-app_core']);
+    expect(loaded.warnings).toHaveLength(1);
+    expect(loaded.warnings[0]).toContain("Unknown job 'deploy' under disabledJobs is ignored");
+  });
+
+  it('should document the job ids disabledJobs accepts in the schema', () => {
+    const ids = REPO_CONFIG_SCHEMA.properties.disabledJobs.items.anyOf[0]!.enum;
+
+    expect(ids).toContain('docker');
+    expect(ids).toContain('coverage');
+    expect(ids).toContain('test');
+  });
+
+  it('should prefer .yml and warn when both files exist', async () => {
+    writeConfig('.readme-to-cicd.yml', 'provider: circleci\n');
+    writeConfig('.readme-to-cicd.yaml', 'provider: azure\n');
+
+    const loaded = await loadConfig(tempDir);
+
+    expect(loaded.config.provider).toBe('circleci');
+    expect(loaded.warnings).toEqual(['.readme-to-cicd.yaml is ignored because .readme-to-cicd.yml exists']);
+  });
+
+  it('should reject values of the wrong type', async () => {
+    writeConfig('.readme-to-cicd.yml', 'provider: travis\n');
+
+    await expect(loadConfig(tempDir)).rejects.toThrow(ConfigurationError);
+    await expect(loadConfig(tempDir)).rejects.toThrow('Invalid .readme-to-cicd.yml: provider must be one of github, gitlab, circleci, azure, bitbucket, jenkins');
+  });
+
+  it('should read runner labels as a list or per job', async () => {
+    writeConfig('.readme-to-cicd.yml', 'runnerLabels: [self-hosted, linux, gpu]\n');
+    expect((await loadConfig(tempDir)).config.runnerLabels).toEqual(['self-hosted', 'linux', 'gpu']);
+
+    writeConfig('.readme-to-cicd.yml', 'runnerLabels:\n  build: self-hosted, linux\n  unit-tests: [self-hosted, gpu]\n');
+    expect((await loadConfig(tempDir)).config.runnerLabels).toEqual({
+      build: ['self-hosted', 'linux'],
+      'unit-tests': ['self-hosted', 'gpu']
+    });
+
+    writeConfig('.readme-to-cicd.yml', 'runnerLabels:\n  build: ["", " "]\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('runnerLabels.build must contain at least one non-empty runner label');
+  });
+
+  it('should read the runner image of each operating system', async () => {
+    writeConfig('.readme-to-cicd.yml', 'runnerImages:\n  linux: ubuntu-22.04\n  macos: macos-14\n  beos: r5\n');
+    const loaded = await loadConfig(tempDir);
+    expect(loaded.config.runnerImages).toEqual({ linux: 'ubuntu-22.04', macos: 'macos-14' });
+    expect(loaded.warnings).toEqual(["Unknown operating system 'beos' under runnerImages is ignored; supported ones are linux, macos, windows"]);
+
+    writeConfig('.readme-to-cicd.yml', 'runnerImages: ubuntu-22.04\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('runnerImages must map operating systems to runner image labels');
+  });
+
+  it('should read the matrix combinations to exclude and include', async () => {
+    writeConfig('.readme-to-cicd.yml', [
+      'matrixExclude:',
+      '  - { os: windows, node-version: 18 }',
+      'matrixInclude:',
+      '  - { job: unit-tests, os: linux, node-version: 22, experimental: true }'
+    ].join('\n'));
+    expect((await loadConfig(tempDir)).config).toEqual({
+      matrixExclude: [{ values: { os: 'windows', 'node-version': 18 } }],
+      matrixInclude: [{ job: 'unit-tests', values: { os: 'linux', 'node-version': 22, experimental: true } }]
+    });
+
+    writeConfig('.readme-to-cicd.yml', 'matrixExclude:\n  - { job: build }\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('matrixExclude[0] names no matrix dimension');
+    writeConfig('.readme-to-cicd.yml', 'matrixInclude:\n  - { os: [linux, macos] }\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('matrixInclude[0].os must be a string, number or boolean');
+  });
+
+  it('should read private Go module patterns and their secret', async () => {
+    writeConfig('.readme-to-cicd.yml', 'privateModules: acme/*, gitlab.acme.dev\nprivateModulesSecret: ACME_TOKEN\n');
+    expect((await loadConfig(tempDir)).config).toEqual({
+      privateModules: ['acme/*', 'gitlab.acme.dev'],
+      privateModulesSecret: 'ACME_TOKEN'
+    });
+
+    writeConfig('.readme-to-cicd.yml', 'privateModulesSecret: acme token\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('privateModulesSecret must be a secret name such as GO_MODULES_TOKEN');
+  });
+
+  it('should read the example environment variables to exclude', async () => {
+    writeConfig('.readme-to-cicd.yml', 'envExampleExclude: [SENTRY_DSN, ANALYTICS_ID]\n');
+    expect((await loadConfig(tempDir)).config).toEqual({ envExampleExclude: ['SENTRY_DSN', 'ANALYTICS_ID'] });
+
+    writeConfig('.readme-to-cicd.yml', 'envExampleExclude: SENTRY_DSN\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('envExampleExclude must be a list of variable names');
+  });
+
+  it('should read how checkout steps fetch submodules and history', async () => {
+    writeConfig('.readme-to-cicd.yml', 'submodules: false\nfetchDepth: 0\n');
+    expect((await loadConfig(tempDir)).config).toEqual({ submodules: false, fetchDepth: 0 });
+
+    writeConfig('.readme-to-cicd.yml', 'submodules: shallow\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('submodules must be true, false or recursive');
+    writeConfig('.readme-to-cicd.yml', 'fetchDepth: -1\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('fetchDepth must be a number of commits, or 0 for the whole history');
+  });
+
+  it('should read the paths detection ignores', async () => {
+    writeConfig('.readme-to-cicd.yml', 'detectIgnore: [vendor/, /third_party, "  "]\n');
+    expect((await loadConfig(tempDir)).config).toEqual({ detectIgnore: ['vendor/', '/third_party'] });
+
+    writeConfig('.readme-to-cicd.yml', 'detectIgnore: vendor/\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('detectIgnore must be a list of gitignore-style patterns');
+  });
+
+  it('should read the licenses the license check allows', async () => {
+    writeConfig('.readme-to-cicd.yml', 'licenseAllowlist: [MIT, Apache-2.0, MIT]\n');
+    expect((await loadConfig(tempDir)).config.licenseAllowlist).toEqual(['MIT', 'Apache-2.0']);
+
+    writeConfig('.readme-to-cicd.yml', 'licenseAllowlist: [MIT License]\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('licenseAllowlist must be a list of SPDX license ids such as [MIT, Apache-2.0]');
+  });
+
+  it('should read the label exempting pull requests from the changelog check', async () => {
+    writeConfig('.readme-to-cicd.yml', 'changelogSkipLabel: skip changelog\n');
+    expect((await loadConfig(tempDir)).config.changelogSkipLabel).toBe('skip changelog');
+
+    writeConfig('.readme-to-cicd.yml', "changelogSkipLabel: \"it's fine\"\n");
+    await expect(loadConfig(tempDir)).rejects.toThrow('changelogSkipLabel must be a label name such as skip-changelog');
+  });
+
+  it('should read the branch buf breaking compares against', async () => {
+    writeConfig('.readme-to-cicd.yml', 'bufBreakingBranch: release/v2\n');
+    expect((await loadConfig(tempDir)).config.bufBreakingBranch).toBe('release/v2');
+
+    writeConfig('.readme-to-cicd.yml', "bufBreakingBranch: 'main,subdir=proto'\n");
+    await expect(loadConfig(tempDir)).rejects.toThrow('bufBreakingBranch must be a branch name such as main');
+  });
+
+  it('should read the deploy environment as a name, or a name and url', async () => {
+    writeConfig('.readme-to-cicd.yml', 'deployEnvironment: production\n');
+    expect((await loadConfig(tempDir)).config.deployEnvironment).toEqual({ name: 'production' });
+
+    writeConfig('.readme-to-cicd.yml', "deployEnvironment:\n  name: production\n  url: '{url}'\n");
+    expect((await loadConfig(tempDir)).config.deployEnvironment).toEqual({ name: 'production', url: '{url}' });
+
+    writeConfig('.readme-to-cicd.yml', "deployEnvironment:\n  name: ''\n");
+    await expect(loadConfig(tempDir)).rejects.toThrow('deployEnvironment.name must be a non-empty environment name such as production');
+  });
+
+  it('should read the refs and mirrors actions are used at', async () => {
+    writeConfig('.readme-to-cicd.yml', 'actionVersions:\n  actions/checkout: v4.2.2\n  actions/setup-node: my-org/setup-node@v4\n');
+    expect((await loadConfig(tempDir)).config.actionVersions).toEqual({ 'actions/checkout': 'v4.2.2', 'actions/setup-node': 'my-org/setup-node@v4' });
+
+    writeConfig('.readme-to-cicd.yml', 'actionVersions:\n  actions/checkout: 4\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('actionVersions.actions/checkout must be a ref such as v4');
+  });
+
+  it('should read injected steps and check them against the step schema', async () => {
+    writeConfig('.readme-to-cicd.yml', [
+      'injectSteps:',
+      '  - position: before-build',
+      '    step:',
+      '      name: Generate code',
+      '      run: make generate',
+      '      working-directory: api',
+      '  - position: after-test',
+      '    step:',
+      '      uses: actions/upload-artifact@v4',
+      '      with: { name: logs, path: logs/ }',
+      '      continue-on-error: true'
+    ].join('\n'));
+    expect((await loadConfig(tempDir)).config.injectSteps).toEqual([
+      { position: 'before-build', step: { name: 'Generate code', run: 'make generate', workingDirectory: 'api' } },
+      { position: 'after-test', step: { name: 'Run actions/upload-artifact@v4', uses: 'actions/upload-artifact@v4', with: { name: 'logs', path: 'logs/' }, continueOnError: true } }
+    ]);
+
+    writeConfig('.readme-to-cicd.yml', 'injectSteps:\n  - position: after-deploy\n    step: { run: echo done }\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('injectSteps[0].position must be one of before-build, after-test, pre-deploy');
+    writeConfig('.readme-to-cicd.yml', 'injectSteps:\n  - position: after-test\n    step: { run: echo done, uses: actions/cache@v4 }\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('injectSteps[0].step must have exactly one of run or uses');
+    writeConfig('.readme-to-cicd.yml', 'injectSteps:\n  - position: after-test\n    step: { run: echo done, script: echo }\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow("injectSteps[0].step has unknown key 'script'");
+    writeConfig('.readme-to-cicd.yml', 'provider: gitlab\ninjectSteps:\n  - position: after-test\n    step: { uses: actions/cache@v4 }\n');
+    await expect(loadConfig(tempDir)).rejects.toThrow('injectSteps: gitlab pipelines cannot run the action actions/cache@v4; inject a run step instead');
+  });
+});
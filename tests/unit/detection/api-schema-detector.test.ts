@@ -0,0 +1,45 @@
+/**
+ * Tests for ApiSchemaDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ApiSchemaDetector } from '../../../src/detection/api-schema-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('ApiSchemaDetector', () => {
+  const detector = new ApiSchemaDetector();
+
+  it('should find nothing without schema files', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'README.md': '# service\n',
+      'config/openapi.yaml': 'generator: typescript-axios\n',
+      'src/queries.graphql': 'query Viewer { viewer { id } }\n'
+    }))).toBeUndefined();
+  });
+
+  it('should find the OpenAPI documents by name and version key and the GraphQL files defining types', async () => {
+    const apiSchemas = await detector.detect(new MemoryFileSystem({
+      'openapi.yaml': 'openapi: 3.0.3\ninfo:\n  title: Pets\n',
+      'api/v1/swagger.json': '{\n  "swagger": "2.0",\n  "info": {}\n}\n',
+      'api/billing.openapi.yml': '# Billing API\nopenapi: "3.1.0"\n',
+      'schema/types.graphqls': '# Pets\ntype Pet {\n  id: ID!\n}\n',
+      'schema/root.gql': 'extend type Query {\n  pets: [Pet!]!\n}\n',
+      'client/viewer.graphql': 'query Viewer { viewer { id } }\n',
+      'node_modules/pkg/openapi.yaml': 'openapi: 3.0.0\n'
+    }));
+
+    expect(apiSchemas).toEqual({
+      openapi: ['api/billing.openapi.yml', 'api/v1/swagger.json', 'openapi.yaml'],
+      graphql: ['schema/root.gql', 'schema/types.graphqls']
+    });
+  });
+
+  it('should find the Spectral ruleset at the root', async () => {
+    const apiSchemas = await detector.detect(new MemoryFileSystem({
+      '.spectral.yml': 'extends: ["spectral:oas"]\n',
+      'openapi.json': '{"openapi": "3.0.0"}'
+    }));
+
+    expect(apiSchemas?.spectralRuleset).toBe('.spectral.yml');
+  });
+});
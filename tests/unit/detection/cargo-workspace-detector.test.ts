@@ -0,0 +1,87 @@
+/**
+ * Tests for CargoWorkspaceDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { CargoWorkspaceDetector } from '../../../src/detection/cargo-workspace-detector';
+
+describe('CargoWorkspaceDetector', () => {
+  let tempDir: string;
+  let detector: CargoWorkspaceDetector;
+
+  const writeManifest = (dir: string, content: string) => {
+    fs.mkdirSync(path.join(tempDir, dir), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, dir, 'Cargo.toml'), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'cargo-workspace-detector-test-'));
+    detector = new CargoWorkspaceDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should expand glob members and skip excluded crates', async () => {
+    writeManifest('.', [
+      '[workspace]',
+      'members = [',
+      '  "crates/*",',
+      '  "tools/cli",',
+      ']',
+      'exclude = ["crates/legacy"]'
+    ].join('\n'));
+    writeManifest('crates/core', '[package]\nname = "app-core"');
+    writeManifest('crates/web', '[package]\nname = "app-web"');
+    writeManifest('crates/legacy', '[package]\nname = "legacy"');
+    writeManifest('tools/cli', '[package]\nname = "app-cli"');
+
+    const workspace = await detector.detect(tempDir);
+
+    expect(workspace?.virtual).toBe(true);
+    expect(workspace?.members).toEqual([
+      { name: 'app-core', path: 'crates/core' },
+      { name: 'app-web', path: 'crates/web' },
+      { name: 'app-cli', path: 'tools/cli' }
+    ]);
+    expect(workspace?.excluded).toEqual(['crates/legacy']);
+  });
+
+  it('should add path dependencies inside the workspace and report the ones outside it', async () => {
+    writeManifest('.', [
+      '[package]',
+      'name = "app"',
+      '',
+      '[workspace]',
+      '',
+      '[dependencies]',
+      'macros = { path = "macros" }',
+      'shared = { path = "../shared", version = "1" }',
+      '',
+      '[features]',
+      'default = ["tls"]',
+      'tls = []',
+      'metrics = []'
+    ].join('\n'));
+    writeManifest('macros', '[package]\nname = "app-macros"');
+
+    const workspace = await detector.detect(tempDir);
+
+    expect(workspace?.virtual).toBe(false);
+    expect(workspace?.members.map(member => member.path)).toEqual(['.', 'macros']);
+    expect(workspace?.externalPathDependencies).toEqual(['../shared']);
+    expect(workspace?.features).toEqual(['tls', 'metrics']);
+  });
+
+  it('should return undefined for a single crate without a workspace table', async () => {
+    writeManifest('.', '[package]\nname = "app"');
+
+    expect(await detector.detect(tempDir)).toBeUndefined();
+  });
+});
@@ -0,0 +1,20 @@
+/**
+ * Tests for ChangelogDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ChangelogDetector } from '../../../src/detection/changelog-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('ChangelogDetector', () => {
+  const detector = new ChangelogDetector();
+
+  it('should find nothing without a changelog at the root', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'README.md': '# app', 'docs/CHANGELOG.md': '' }))).toBeUndefined();
+  });
+
+  it('should find the changelog by its usual names, whatever their case, CHANGELOG first', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'HISTORY.md': '', 'Changelog.md': '' }))).toEqual({ file: 'Changelog.md' });
+    expect(await detector.detect(new MemoryFileSystem({ 'NEWS': '', 'CHANGES.rst': '' }))).toEqual({ file: 'CHANGES.rst' });
+  });
+});
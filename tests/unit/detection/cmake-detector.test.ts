@@ -0,0 +1,70 @@
+/**
+ * Tests for CMakeDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { CMakeDetector } from '../../../src/detection/cmake-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+const CMAKE_LISTS = [
+  'cmake_minimum_required(VERSION 3.16...3.28)',
+  'project(geometry LANGUAGES CXX)',
+  '',
+  'add_library(geometry src/shape.cpp)',
+  'target_compile_features(geometry PUBLIC cxx_std_20)',
+  '',
+  '# add_test(NAME disabled COMMAND true)',
+  'if(BUILD_TESTING)',
+  '  enable_testing()',
+  '  add_subdirectory(tests)',
+  'endif()'
+].join('\n');
+
+describe('CMakeDetector', () => {
+  const detector = new CMakeDetector();
+
+  it('should find nothing without CMakeLists.txt', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'Makefile': 'all:\n\tcc main.c\n' }))).toBeUndefined();
+  });
+
+  it('should read the minimum version, the C++ standard and whether tests are enabled', async () => {
+    const cmakeProject = await detector.detect(new MemoryFileSystem({ 'CMakeLists.txt': CMAKE_LISTS, '.clang-format': 'BasedOnStyle: LLVM\n' }));
+    const untested = await detector.detect(new MemoryFileSystem({
+      'CMakeLists.txt': 'cmake_minimum_required(VERSION 3.14)\nset(CMAKE_CXX_STANDARD 17)\n# enable_testing()\nadd_executable(app main.cpp)\n'
+    }));
+
+    expect(cmakeProject).toEqual({ minimumVersion: '3.16', cxxStandard: '20', tests: true, binaryDir: 'build', clangFormat: true });
+    expect(untested).toEqual({ minimumVersion: '3.14', cxxStandard: '17', tests: false, binaryDir: 'build', clangFormat: false });
+  });
+
+  it('should pick the CI presets and resolve their binary directory through inherits', async () => {
+    const presets = {
+      version: 3,
+      configurePresets: [
+        { name: 'base', hidden: true, generator: 'Ninja', binaryDir: '${sourceDir}/out/${presetName}' },
+        { name: 'dev', inherits: 'base' },
+        { name: 'ci', inherits: 'base', cacheVariables: { CMAKE_BUILD_TYPE: 'Release' } },
+        { name: 'vcpkg', binaryDir: '$env{VCPKG_ROOT}/build' }
+      ],
+      buildPresets: [{ name: 'dev', configurePreset: 'dev' }, { name: 'ci-build', configurePreset: 'ci' }],
+      testPresets: [{ name: 'ci-test', configurePreset: 'ci' }]
+    };
+    const chosen = await detector.detect(new MemoryFileSystem({
+      'CMakeLists.txt': 'cmake_minimum_required(VERSION 3.21)\nproject(app)\n',
+      'CMakePresets.json': JSON.stringify(presets)
+    }));
+    const unresolved = await detector.detect(new MemoryFileSystem({
+      'CMakeLists.txt': 'project(app)\n',
+      'CMakePresets.json': JSON.stringify({ version: 2, configurePresets: [presets.configurePresets[3]] })
+    }));
+
+    expect(chosen).toEqual({
+      minimumVersion: '3.21',
+      tests: true,
+      presets: { configure: 'ci', build: 'ci-build', test: 'ci-test', binaryDir: 'out/ci', requiredVersion: '3.21' },
+      binaryDir: 'out/ci',
+      clangFormat: false
+    });
+    expect(unresolved).toEqual({ tests: false, presets: { configure: 'vcpkg', requiredVersion: '3.20' }, binaryDir: 'build', clangFormat: false });
+  });
+});
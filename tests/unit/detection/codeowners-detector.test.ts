@@ -0,0 +1,76 @@
+/**
+ * Tests for CodeownersDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { CodeownersDetector, parseCodeowners, toCodeownersGlobs } from '../../../src/detection/codeowners-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('CodeownersDetector', () => {
+  const detector = new CodeownersDetector();
+
+  it('should read the CODEOWNERS file where GitHub looks for it first', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'README.md': '# app' }))).toBeUndefined();
+
+    const detected = await detector.detect(new MemoryFileSystem({
+      'CODEOWNERS': '* @root',
+      'docs/CODEOWNERS': '* @docs',
+      '.github/CODEOWNERS': '* @github'
+    }));
+    expect(detected?.codeowners).toEqual({
+      file: '.github/CODEOWNERS',
+      rules: [{ pattern: '*', globs: ['**/*'], owners: ['@github'], line: 1 }]
+    });
+  });
+
+  it('should parse owners, comments, escapes and rules without owners', () => {
+    const { codeowners, warnings } = parseCodeowners('CODEOWNERS', [
+      '# Default owners',
+      '*       @acme/core @octocat # the core team',
+      '',
+      '\\#notes/   docs@acme.dev',
+      '/My\\ Docs/ @acme/docs',
+      '/vendor'
+    ].join('\n'));
+
+    expect(codeowners.rules.map(({ pattern, owners, line }) => ({ pattern, owners, line }))).toEqual([
+      { pattern: '*', owners: ['@acme/core', '@octocat'], line: 2 },
+      { pattern: '#notes/', owners: ['docs@acme.dev'], line: 4 },
+      { pattern: '/My Docs/', owners: ['@acme/docs'], line: 5 },
+      { pattern: '/vendor', owners: [], line: 6 }
+    ]);
+    expect(warnings).toEqual([]);
+  });
+
+  it('should leave out and warn about the lines GitHub ignores', () => {
+    const { codeowners, warnings } = parseCodeowners('.github/CODEOWNERS', [
+      '!docs/internal @acme/docs',
+      '*.[ch] @acme/c',
+      'src/ octocat',
+      'src/ @acme/core'
+    ].join('\n'));
+
+    expect(codeowners.rules.map(rule => rule.pattern)).toEqual(['src/']);
+    expect(warnings.map(warning => warning.message)).toEqual([
+      '.github/CODEOWNERS line 1 is ignored: negated pattern !docs/internal - CODEOWNERS does not support negation',
+      '.github/CODEOWNERS line 2 is ignored: pattern *.[ch] uses a character range - CODEOWNERS does not support them',
+      '.github/CODEOWNERS line 3 is ignored: octocat is not a user, team or email address'
+    ]);
+    expect(warnings.every(warning => warning.code === 'CODEOWNERS_LINE_IGNORED' && warning.path === '.github/CODEOWNERS')).toBe(true);
+  });
+
+  it('should turn patterns into globs matching what CODEOWNERS does', () => {
+    // Unanchored patterns match at any depth, anchored ones from the root
+    expect(toCodeownersGlobs('*.js')).toEqual(['**/*.js']);
+    expect(toCodeownersGlobs('apps/')).toEqual(['**/apps/**']);
+    expect(toCodeownersGlobs('/docs/')).toEqual(['docs/**']);
+    expect(toCodeownersGlobs('build/logs/')).toEqual(['build/logs/**']);
+    // A name matches a file or a directory's contents
+    expect(toCodeownersGlobs('/scripts')).toEqual(['scripts', 'scripts/**']);
+    expect(toCodeownersGlobs('Makefile')).toEqual(['**/Makefile', '**/Makefile/**']);
+    // A trailing wildcard matches files only, unlike in gitignore
+    expect(toCodeownersGlobs('docs/*')).toEqual(['docs/*']);
+    expect(toCodeownersGlobs('**/logs')).toEqual(['**/logs', '**/logs/**']);
+    expect(toCodeownersGlobs('docs/**')).toEqual(['docs/**']);
+  });
+});
@@ -0,0 +1,58 @@
+/**
+ * Tests for CoverageDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { CoverageDetector } from '../../../src/detection/coverage-detector';
+
+describe('CoverageDetector', () => {
+  let tempDir: string;
+  let detector: CoverageDetector;
+
+  const writeFile = (file: string, content: string) => {
+    fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, file), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'coverage-detector-test-'));
+    detector = new CoverageDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should find nothing in a project without coverage tooling', async () => {
+    writeFile('go.mod', 'module example.com/app\n');
+    writeFile('package.json', JSON.stringify({ scripts: { test: 'jest' }, devDependencies: { jest: '^29.0.0' } }));
+
+    expect(await detector.detect(tempDir)).toEqual([]);
+  });
+
+  it('should read the Go profile and jest coverage from project commands', async () => {
+    writeFile('go.mod', 'module example.com/app\n');
+    writeFile('Makefile', 'test:\n\tgo test -race -coverprofile=cover.out ./...\n');
+    writeFile('package.json', JSON.stringify({ scripts: { 'test:cov': 'jest --coverage' } }));
+
+    expect(await detector.detect(tempDir)).toEqual([
+      { tool: 'go-cover', language: 'Go', source: 'Makefile', reportFile: 'cover.out' },
+      { tool: 'jest', language: 'JavaScript', source: 'package.json' }
+    ]);
+  });
+
+  it('should prefer nyc over the test runner and find pytest-cov in requirements', async () => {
+    writeFile('package.json', JSON.stringify({ scripts: { test: 'nyc mocha' }, devDependencies: { nyc: '^15.0.0', jest: '^29.0.0' } }));
+    writeFile('requirements-dev.txt', 'pytest>=7\npytest-cov==4.1.0\n');
+
+    expect(await detector.detect(tempDir)).toEqual([
+      { tool: 'nyc', language: 'JavaScript', source: 'package.json' },
+      { tool: 'pytest-cov', language: 'Python', source: 'requirements-dev.txt' }
+    ]);
+  });
+});
@@ -0,0 +1,53 @@
+/**
+ * Tests for DartDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { DartDetector } from '../../../src/detection/dart-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('DartDetector', () => {
+  const detector = new DartDetector();
+
+  it('should find nothing without a pubspec.yaml or when it is not a mapping', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'lib/main.dart': 'void main() {}\n' }))).toBeUndefined();
+    expect(await detector.detect(new MemoryFileSystem({ 'pubspec.yaml': '- not a pubspec\n' }))).toBeUndefined();
+  });
+
+  it('should read a Dart package and its SDK constraint', async () => {
+    const dartProject = await detector.detect(new MemoryFileSystem({
+      'pubspec.yaml': 'name: parser\nenvironment:\n  sdk: ^3.3.0\ndev_dependencies:\n  test: ^1.25.0\n',
+      'lib/parser.dart': '',
+      'test/parser_test.dart': ''
+    }));
+
+    expect(dartProject).toEqual({ name: 'parser', flutter: false, sdkConstraint: '^3.3.0', platforms: [], tests: true });
+  });
+
+  it('should recognize a Flutter app by the flutter SDK dependency and list its platform directories', async () => {
+    const dartProject = await detector.detect(new MemoryFileSystem({
+      'pubspec.yaml': [
+        'name: app',
+        'environment:',
+        "  sdk: '>=3.0.0 <4.0.0'",
+        '  flutter: ">=3.19.0"',
+        'dependencies:',
+        '  flutter:',
+        '    sdk: flutter'
+      ].join('\n'),
+      'android/build.gradle': '',
+      'ios/Runner.xcodeproj/project.pbxproj': '',
+      'web/index.html': '',
+      'docs/index.md': ''
+    }));
+
+    expect(dartProject).toEqual({
+      name: 'app',
+      flutter: true,
+      sdkConstraint: '>=3.0.0 <4.0.0',
+      flutterConstraint: '>=3.19.0',
+      platforms: ['android', 'ios', 'web'],
+      tests: false
+    });
+  });
+});
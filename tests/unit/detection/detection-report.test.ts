@@ -0,0 +1,119 @@
+/**
+ * Tests for the serializable detection report
+ */
+
+import { describe, it, expect } from 'vitest';
+import * as path from 'path';
+import { createDetectionReport, DETECTION_REPORT_SCHEMA_VERSION } from '../../../src/detection/detection-report';
+import { DetectionResult } from '../../../src/detection/interfaces/detection-result';
+import { CIPipeline } from '../../../src/detection/interfaces/ci-pipeline';
+
+const projectPath = path.resolve('/work/project');
+
+function createResult(overrides: Partial<DetectionResult> = {}): DetectionResult {
+  return {
+    frameworks: [
+      {
+        name: 'Express',
+        type: 'backend_framework',
+        version: '4.18.2',
+        confidence: 0.8,
+        evidence: [],
+        signals: [{ kind: 'manifest', source: 'package.json' }, { kind: 'readme', source: 'README' }],
+        ecosystem: 'nodejs'
+      },
+      { name: 'React', type: 'frontend_framework', confidence: 0.9, evidence: [], ecosystem: 'frontend' },
+      { name: 'FastAPI', type: 'api_framework', confidence: 0.6, evidence: [], ecosystem: 'python' }
+    ],
+    buildTools: [
+      { name: 'npm', configFile: path.join(projectPath, 'package.json'), lockFile: 'package-lock.json', commands: [], confidence: 0.9 }
+    ],
+    containers: [],
+    confidence: { score: 0.75, level: 'medium', breakdown: {} as any, factors: [], recommendations: [] },
+    alternatives: [],
+    warnings: [{ type: 'conflict', message: 'Multiple web frameworks detected', affected: ['Express', 'FastAPI'], resolution: 'Pick one' }],
+    detectedAt: new Date('2024-01-01T00:00:00Z'),
+    executionTime: 12,
+    ...overrides
+  };
+}
+
+describe('createDetectionReport', () => {
+  it('should report languages, frameworks, package managers and confidence under the schema version', () => {
+    const report = createDetectionReport(createResult(), undefined, projectPath);
+
+    expect(report.schemaVersion).toBe(DETECTION_REPORT_SCHEMA_VERSION);
+    expect(report.languages).toEqual([
+      { name: 'JavaScript', confidence: 0.9 },
+      { name: 'Python', confidence: 0.6 }
+    ]);
+    expect(report.frameworks[0]).toEqual({
+      name: 'Express',
+      type: 'backend_framework',
+      ecosystem: 'nodejs',
+      version: '4.18.2',
+      confidence: 0.8,
+      signals: [{ kind: 'manifest', source: 'package.json' }, { kind: 'readme', source: 'README' }]
+    });
+    expect(report.packageManagers).toEqual([{ name: 'npm', configFile: 'package.json', lockFile: 'package-lock.json' }]);
+    expect(report.confidence).toEqual({ score: 0.75, level: 'medium' });
+    expect(report.warnings).toEqual([
+      { type: 'conflict', message: 'Multiple web frameworks detected', affected: ['Express', 'FastAPI'] }
+    ]);
+    expect(report.plannedSteps).toEqual([]);
+    // Dates and timings differ between runs and are left out
+    expect(JSON.parse(JSON.stringify(report))).not.toHaveProperty('detectedAt');
+  });
+
+  it('should make paths repository-relative with forward slashes', () => {
+    const report = createDetectionReport(createResult({
+      versionConstraints: [{ runtime: 'node', source: path.join(projectPath, 'package.json'), raw: '>=18', ranges: [], versions: ['18', '20'] }],
+      testRunners: [{ name: 'jest', language: 'JavaScript', command: 'npx jest', setup: [], source: 'packages\\api\\jest.config.js', confidence: 0.6 }],
+      dockerImages: [{ dockerfile: './docker/Dockerfile', context: 'docker/', stageCount: 1, hasDockerignore: false }],
+      staticSite: { generator: 'mkdocs', configFile: 'mkdocs.yml', outputDir: 'site' }
+    }), undefined, projectPath);
+
+    expect(report.versions).toEqual([{ runtime: 'node', source: 'package.json', raw: '>=18', versions: ['18', '20'] }]);
+    expect(report.testRunners).toEqual([
+      { name: 'jest', language: 'JavaScript', command: 'npx jest', source: 'packages/api/jest.config.js', confidence: 0.6 }
+    ]);
+    expect(report.dockerImages).toEqual([{ dockerfile: 'docker/Dockerfile', context: 'docker' }]);
+    expect(report.staticSite).toEqual({ generator: 'mkdocs', configFile: 'mkdocs.yml', outputDir: 'site' });
+  });
+
+  it('should include diagnostics with their codes and repository-relative paths', () => {
+    const report = createDetectionReport(createResult({
+      diagnostics: [
+        { severity: 'error', code: 'DETECTOR_FAILED', message: 'Failed to run docker detector: EACCES' },
+        { severity: 'warning', code: 'LOCKFILE_MISSING', message: 'No yarn.lock committed for yarn', path: path.join(projectPath, 'web', 'yarn.lock') }
+      ]
+    }), undefined, projectPath);
+
+    expect(report.diagnostics).toEqual([
+      { severity: 'error', code: 'DETECTOR_FAILED', message: 'Failed to run docker detector: EACCES' },
+      { severity: 'warning', code: 'LOCKFILE_MISSING', message: 'No yarn.lock committed for yarn', path: 'web/yarn.lock' }
+    ]);
+    expect(createDetectionReport(createResult(), undefined, projectPath).diagnostics).toEqual([]);
+  });
+
+  it('should list planned steps in pipeline order', () => {
+    const step = (id: string, extra: Record<string, string> = {}) => ({ id, name: id, category: 'build' as const, required: true, estimatedDuration: 1, ...extra });
+    const pipeline = {
+      setup: [step('setup-node', { uses: 'actions/setup-node@v4' })],
+      build: [step('build', { command: 'npm run build', workingDirectory: path.join(projectPath, 'web') })],
+      test: [step('test', { command: 'npm test' })],
+      security: [],
+      deploy: [],
+      cache: [],
+      metadata: {}
+    } as unknown as CIPipeline;
+
+    const report = createDetectionReport(createResult(), pipeline, projectPath);
+
+    expect(report.plannedSteps).toEqual([
+      { id: 'setup-node', name: 'setup-node', stage: 'setup', uses: 'actions/setup-node@v4' },
+      { id: 'build', name: 'build', stage: 'build', command: 'npm run build', workingDirectory: 'web' },
+      { id: 'test', name: 'test', stage: 'test', command: 'npm test' }
+    ]);
+  });
+});
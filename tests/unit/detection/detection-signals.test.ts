@@ -0,0 +1,60 @@
+/**
+ * Tests for detection signal collection and scoring
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { DetectionSignalCollector, scoreSignals } from '../../../src/detection/utils/detection-signals';
+
+describe('DetectionSignalCollector', () => {
+  let tempDir: string;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'detection-signals-test-'));
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should score agreeing manifest, lockfile, source file and README signals', async () => {
+    fs.writeFileSync(path.join(tempDir, 'package.json'), '{}');
+    fs.writeFileSync(path.join(tempDir, 'pnpm-lock.yaml'), '');
+    fs.mkdirSync(path.join(tempDir, 'src'));
+    fs.writeFileSync(path.join(tempDir, 'src', 'index.ts'), '');
+
+    const collector = new DetectionSignalCollector(tempDir, 'Run the suite with `npx vitest`.');
+    const signals = await collector.collect('JavaScript', ['vitest'], 'vitest.config.ts');
+
+    expect(signals).toEqual([
+      { kind: 'manifest', source: 'vitest.config.ts' },
+      { kind: 'lockfile', source: 'pnpm-lock.yaml' },
+      { kind: 'file_extension', source: 'src/index.ts' },
+      { kind: 'readme', source: 'README' }
+    ]);
+    expect(scoreSignals(signals)).toBe(1);
+  });
+
+  it('should give a lone config file a partial score', async () => {
+    const collector = new DetectionSignalCollector(tempDir, 'A project using pytest-style fixtures');
+    const signals = await collector.collect('Python', ['tox'], 'tox.ini');
+
+    expect(signals).toEqual([{ kind: 'manifest', source: 'tox.ini' }]);
+    expect(scoreSignals(signals)).toBe(0.4);
+  });
+
+  it('should only count framework manifests backed by evidence', async () => {
+    fs.writeFileSync(path.join(tempDir, 'package.json'), '{}');
+
+    const collector = new DetectionSignalCollector(tempDir, 'Built with Express');
+    const signals = await collector.collectFromEvidence('nodejs', 'React', [
+      { type: 'text_mention', source: 'README', value: 'react', weight: 0.3 }
+    ]);
+
+    expect(signals).toEqual([{ kind: 'readme', source: 'README' }]);
+  });
+});
@@ -0,0 +1,136 @@
+/**
+ * Tests for the detector registry
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import {
+  registerDetector,
+  unregisterDetector,
+  getRegisteredDetectors,
+  runDetectors,
+  mergeDetections
+} from '../../../src/detection/detector-registry';
+import '../../../src/detection/docker-detector';
+import '../../../src/detection/makefile-detector';
+import '../../../src/detection/language-detector';
+import { DetectionResult } from '../../../src/detection/interfaces/detection-result';
+import { PipelineLogger } from '../../../src/shared/logging';
+
+describe('detector registry', () => {
+  let tempDir: string;
+
+  const emptyResult = (): DetectionResult => ({
+    frameworks: [],
+    buildTools: [],
+    containers: [],
+    confidence: {} as DetectionResult['confidence'],
+    alternatives: [],
+    warnings: [],
+    detectedAt: new Date(),
+    executionTime: 0
+  });
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'detector-registry-test-'));
+  });
+
+  afterEach(() => {
+    unregisterDetector('acme-build');
+    unregisterDetector('broken');
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should register the built-in detectors as they load', () => {
+    expect(getRegisteredDetectors()).toEqual(['docker', 'makefile', 'languages']);
+  });
+
+  it('should run registered detectors alongside the built-in ones and report failing ones', async () => {
+    fs.writeFileSync(path.join(tempDir, 'Makefile'), 'build:\n\tacme build\n');
+    fs.writeFileSync(path.join(tempDir, 'ACMEBUILD'), '');
+    registerDetector('acme-build', {
+      async detect(files) {
+        return await files.exists('ACMEBUILD')
+          ? [{ fields: { buildTools: [{ name: 'acme', configFile: 'ACMEBUILD', commands: [], confidence: 0.95 }] }, confidence: 0.95 }]
+          : [];
+      }
+    });
+    registerDetector('broken', {
+      async detect() {
+        throw new Error('disk on fire');
+      }
+    });
+
+    const result = emptyResult();
+    await runDetectors(result, tempDir);
+
+    expect(result.buildTools.map(tool => tool.name)).toEqual(['acme']);
+    expect(result.makefile?.targets).toEqual(['build']);
+    expect(result.warnings).toEqual([
+      { type: 'incomplete', message: 'Failed to run broken detector: disk on fire', affected: ['broken'], code: 'DETECTOR_FAILED' }
+    ]);
+  });
+
+  it('should run the detectors registered when a run starts in concurrent runs', async () => {
+    const ran: string[] = [];
+    registerDetector('acme-build', {
+      async detect() {
+        ran.push('acme-build');
+        // Registered while both runs are in progress
+        registerDetector('broken', { async detect() { ran.push('broken'); return []; } });
+        await new Promise(resolve => setTimeout(resolve, 5));
+        return [];
+      }
+    });
+
+    await Promise.all([runDetectors(emptyResult(), tempDir), runDetectors(emptyResult(), tempDir)]);
+
+    expect(ran).toEqual(['acme-build', 'acme-build']);
+    expect(getRegisteredDetectors()).toContain('broken');
+  });
+
+  it('should keep the more confident detection of a conflicting field or entry', () => {
+    const tool = (name: string, confidence: number) => ({ name, configFile: '', commands: [], confidence });
+    const java = (buildSystem: 'maven' | 'gradle', manifest: string) => ({ buildSystem, manifest, wrapper: false, modules: [] });
+    const result = emptyResult();
+    result.buildTools = [tool('make', 0.6)];
+
+    mergeDetections(result, [
+      { fields: { buildTools: [tool('Make', 0.8), tool('bazel', 0.5)] }, confidence: 0.8 },
+      { fields: { buildTools: [tool('bazel', 0.4)], javaBuild: java('gradle', 'build.gradle') }, confidence: 0.7 },
+      { fields: { javaBuild: java('maven', 'pom.xml') }, confidence: 0.9 },
+      { fields: { javaBuild: java('gradle', 'build.gradle.kts') }, confidence: 0.9 }
+    ]);
+
+    expect(result.buildTools).toEqual([tool('Make', 0.8), tool('bazel', 0.5)]);
+    expect(result.javaBuild).toEqual(java('maven', 'pom.xml'));
+  });
+
+  it('should log what each detector found and which detectors failed', async () => {
+    fs.writeFileSync(path.join(tempDir, 'Makefile'), 'build:\n\tmake all\n');
+    const messages: Array<[string, string, Record<string, unknown> | undefined]> = [];
+    const logger: PipelineLogger = {
+      error: (message, context) => messages.push(['error', message, context]),
+      warn: (message, context) => messages.push(['warn', message, context]),
+      info: (message, context) => messages.push(['info', message, context]),
+      debug: (message, context) => messages.push(['debug', message, context])
+    };
+    registerDetector('broken', {
+      async detect() {
+        throw new Error('disk on fire');
+      }
+    });
+
+    const result = emptyResult();
+    await runDetectors(result, tempDir, logger);
+
+    const makefile = messages.find(([, message, context]) => message === 'Detector found fields' && context?.detector === 'makefile');
+    expect(makefile?.[0]).toBe('debug');
+    expect(makefile?.[2]?.fields).toEqual({ makefile: true });
+    expect(messages).toContainEqual(['warn', 'Detector failed', { detector: 'broken', error: 'disk on fire' }]);
+  });
+});
@@ -0,0 +1,59 @@
+/**
+ * Tests for the diagnostics of detection results
+ */
+
+import { describe, it, expect } from 'vitest';
+import { collectDiagnostics } from '../../../src/detection/diagnostics';
+import { DetectionResult } from '../../../src/detection/interfaces/detection-result';
+
+function createResult(overrides: Partial<DetectionResult> = {}): DetectionResult {
+  return {
+    frameworks: [],
+    buildTools: [],
+    containers: [],
+    confidence: { score: 0.8, level: 'high', breakdown: {} as any, factors: [], recommendations: [] },
+    alternatives: [],
+    warnings: [],
+    detectedAt: new Date('2024-01-01T00:00:00Z'),
+    executionTime: 0,
+    ...overrides
+  };
+}
+
+describe('collectDiagnostics', () => {
+  it('should code warnings by their own code, else their type, errors first', () => {
+    const diagnostics = collectDiagnostics(createResult({
+      warnings: [
+        { type: 'incomplete', message: 'No .dockerignore next to Dockerfile', affected: ['Dockerfile'], code: 'DOCKERIGNORE_MISSING', path: 'Dockerfile' },
+        { type: 'conflict', message: 'Multiple web frameworks detected', affected: ['Express', 'FastAPI'] },
+        { type: 'incomplete', message: 'Failed to run docker detector: EACCES', affected: ['docker'], code: 'DETECTOR_FAILED' },
+        { type: 'version_mismatch', message: "Invalid node version constraint 'latest' in package.json", affected: ['package.json'], code: 'VERSION_UNPARSEABLE', path: 'package.json' }
+      ]
+    }), true);
+
+    expect(diagnostics).toEqual([
+      { severity: 'error', code: 'DETECTOR_FAILED', message: 'Failed to run docker detector: EACCES' },
+      { severity: 'warning', code: 'DETECTION_CONFLICT', message: 'Multiple web frameworks detected' },
+      { severity: 'warning', code: 'VERSION_UNPARSEABLE', message: "Invalid node version constraint 'latest' in package.json", path: 'package.json' },
+      { severity: 'info', code: 'DOCKERIGNORE_MISSING', message: 'No .dockerignore next to Dockerfile', path: 'Dockerfile' }
+    ]);
+  });
+
+  it('should report package managers without their lockfile only when the project was scanned', () => {
+    const result = createResult({
+      buildTools: [
+        { name: 'pnpm', configFile: 'pnpm-lock.yaml', commands: [], confidence: 0.8 },
+        { name: 'bundler', configFile: 'Gemfile', lockFile: 'Gemfile.lock', commands: [], confidence: 0.9 },
+        { name: 'make', configFile: 'Makefile', commands: [], confidence: 0.9 }
+      ]
+    });
+
+    expect(collectDiagnostics(result, true)).toEqual([{
+      severity: 'warning',
+      code: 'LOCKFILE_MISSING',
+      message: 'No pnpm-lock.yaml committed for pnpm - dependency versions are not pinned and installs are not reproducible',
+      path: 'pnpm-lock.yaml'
+    }]);
+    expect(collectDiagnostics(result, false)).toEqual([]);
+  });
+});
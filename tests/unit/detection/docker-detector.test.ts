@@ -0,0 +1,89 @@
+/**
+ * Tests for DockerDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { DockerDetector, isDockerfile, parseDockerStages } from '../../../src/detection/docker-detector';
+
+describe('DockerDetector', () => {
+  let tempDir: string;
+  let detector: DockerDetector;
+
+  const writeFile = (relativePath: string, content: string): void => {
+    const fullPath = path.join(tempDir, relativePath);
+    fs.mkdirSync(path.dirname(fullPath), { recursive: true });
+    fs.writeFileSync(fullPath, content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'docker-detector-test-'));
+    detector = new DockerDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should find Dockerfile variants in subdirectories sorted by path', async () => {
+    writeFile('Dockerfile', 'FROM node:20-alpine\n');
+    writeFile('.dockerignore', 'node_modules\n');
+    writeFile('services/api/Dockerfile.worker', 'FROM golang:1.22\n');
+    writeFile('node_modules/pkg/Dockerfile', 'FROM scratch\n');
+    writeFile('docs/Dockerfile.md', 'not a dockerfile');
+
+    const { images } = await detector.detect(tempDir);
+
+    expect(images.map(image => [image.dockerfile, image.context])).toEqual([
+      ['Dockerfile', '.'],
+      ['services/api/Dockerfile.worker', 'services/api']
+    ]);
+    expect(images[0]).toMatchObject({ baseImage: 'node:20-alpine', stageCount: 1, hasDockerignore: true });
+    expect(images[0]!.targetStage).toBeUndefined();
+  });
+
+  it('should target the final stage of a multi-stage build', async () => {
+    writeFile('Dockerfile', [
+      '# syntax=docker/dockerfile:1',
+      'FROM --platform=$BUILDPLATFORM golang:1.22 AS build',
+      'RUN go build -o /app \\',
+      '    ./cmd/server',
+      'FROM gcr.io/distroless/static AS runtime',
+      'COPY --from=build /app /app'
+    ].join('\n'));
+
+    const { images } = await detector.detect(tempDir);
+
+    expect(images[0]).toMatchObject({ baseImage: 'gcr.io/distroless/static', targetStage: 'runtime', stageCount: 2 });
+  });
+
+  it('should resolve a final stage built on an earlier stage to its external image', () => {
+    const stages = parseDockerStages('FROM python:3.12-slim AS base\nFROM base AS final\n');
+
+    expect(stages).toEqual([{ image: 'python:3.12-slim', name: 'base' }, { image: 'base', name: 'final' }]);
+  });
+
+  it('should warn when a build context has no .dockerignore', async () => {
+    writeFile('app/Dockerfile', 'FROM ruby:3.3\n');
+
+    const { images, warnings } = await detector.detect(tempDir);
+
+    expect(images[0]!.baseImage).toBe('ruby:3.3');
+    expect(images[0]!.hasDockerignore).toBe(false);
+    expect(warnings).toHaveLength(1);
+    expect(warnings[0]!.message).toContain('No .dockerignore next to app/Dockerfile');
+    expect(warnings[0]!.affected).toEqual(['app/Dockerfile']);
+  });
+
+  it('should only treat Dockerfile and Dockerfile.<variant> as Dockerfiles', () => {
+    expect(isDockerfile('Dockerfile')).toBe(true);
+    expect(isDockerfile('Dockerfile.dev')).toBe(true);
+    expect(isDockerfile('Dockerfile.')).toBe(false);
+    expect(isDockerfile('Dockerfile.md')).toBe(false);
+    expect(isDockerfile('Dockerfiles')).toBe(false);
+  });
+});
@@ -0,0 +1,60 @@
+/**
+ * Tests for DotnetDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { DotnetDetector } from '../../../src/detection/dotnet-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+const SOLUTION = [
+  'Microsoft Visual Studio Solution File, Format Version 12.00',
+  'Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "src", "src", "{9A19103F-16F7-4668-BE54-9A1E7A4F7556}"',
+  'EndProject',
+  'Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "Shop.Api", "src\\Shop.Api\\Shop.Api.csproj", "{1B0B5C1E-3F0E-4C7B-9D56-2C1F0D4B8A11}"',
+  'EndProject',
+  'Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "Shop.Tests", "tests\\Shop.Tests\\Shop.Tests.csproj", "{5E2C8A4D-7B1F-4E36-8C0A-6D9F3B2E1C44}"',
+  'EndProject'
+].join('\n');
+
+describe('DotnetDetector', () => {
+  const detector = new DotnetDetector();
+
+  it('should find nothing without a solution, project file or global.json', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'Program.cs': 'Console.WriteLine("hello");\n' }))).toBeUndefined();
+  });
+
+  it('should read the projects of a solution, their frameworks, test projects and the pinned SDK', async () => {
+    const dotnetProject = await detector.detect(new MemoryFileSystem({
+      'Shop.sln': SOLUTION,
+      'global.json': '{\n  "sdk": {\n    "version": "8.0.204",\n    "rollForward": "latestFeature"\n  }\n}\n',
+      'src/Shop.Api/Shop.Api.csproj': '<Project Sdk="Microsoft.NET.Sdk.Web">\n  <PropertyGroup>\n    <TargetFrameworks>net6.0;net8.0</TargetFrameworks>\n  </PropertyGroup>\n</Project>\n',
+      'tests/Shop.Tests/Shop.Tests.csproj': [
+        '<Project Sdk="Microsoft.NET.Sdk">',
+        '  <PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup>',
+        '  <ItemGroup>',
+        '    <PackageReference Include="Microsoft.NET.Test.Sdk" Version="17.9.0" />',
+        '    <PackageReference Include="xunit" Version="2.7.0" />',
+        '  </ItemGroup>',
+        '</Project>'
+      ].join('\n'),
+      'tests/Shop.Tests/packages.lock.json': '{}'
+    }));
+
+    expect(dotnetProject).toEqual({
+      solution: 'Shop.sln',
+      projects: ['src/Shop.Api/Shop.Api.csproj', 'tests/Shop.Tests/Shop.Tests.csproj'],
+      testProjects: ['tests/Shop.Tests/Shop.Tests.csproj'],
+      targetFrameworks: ['net6.0', 'net8.0'],
+      sdkVersion: '8.0.204',
+      lockFile: true
+    });
+  });
+
+  it('should read a lone project at the root without a solution', async () => {
+    const dotnetProject = await detector.detect(new MemoryFileSystem({
+      'Tool.csproj': '<Project Sdk="Microsoft.NET.Sdk">\n  <!-- <TargetFramework>net6.0</TargetFramework> -->\n  <PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup>\n</Project>\n'
+    }));
+
+    expect(dotnetProject).toEqual({ projects: ['Tool.csproj'], testProjects: [], targetFrameworks: ['net8.0'], lockFile: false });
+  });
+});
@@ -0,0 +1,55 @@
+/**
+ * Tests for ElixirDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ElixirDetector } from '../../../src/detection/elixir-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+const PHOENIX_MIXFILE = [
+  'defmodule Shop.MixProject do',
+  '  use Mix.Project',
+  '',
+  '  def project do',
+  '    [app: :shop, version: "0.1.0", elixir: "~> 1.15", deps: deps()]',
+  '  end',
+  '',
+  '  defp deps do',
+  '    [',
+  '      {:phoenix, "~> 1.7.10"},',
+  '      {:ecto_sql, "~> 3.10"},',
+  '      {:postgrex, ">= 0.0.0"},',
+  '      # {:myxql, ">= 0.0.0"},',
+  '      {:jason, "~> 1.2"}',
+  '    ]',
+  '  end',
+  'end'
+].join('\n');
+
+describe('ElixirDetector', () => {
+  const detector = new ElixirDetector();
+
+  it('should find nothing without mix.exs', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ '.tool-versions': 'elixir 1.16.2-otp-26\n' }))).toBeUndefined();
+  });
+
+  it('should read the elixir requirement and the Phoenix and Ecto deps of mix.exs', async () => {
+    const elixirProject = await detector.detect(new MemoryFileSystem({ 'mix.exs': PHOENIX_MIXFILE }));
+
+    expect(elixirProject).toEqual({ elixirVersion: '1.15', versionSource: 'mix.exs', phoenix: true, postgres: true });
+  });
+
+  it('should prefer the versions pinned in .tool-versions', async () => {
+    const pinned = await detector.detect(new MemoryFileSystem({
+      'mix.exs': 'defmodule Lib.MixProject do\n  def project, do: [app: :lib, elixir: "~> 1.14"]\nend\n',
+      '.tool-versions': 'erlang 26.2.1\nelixir 1.16.2-otp-26\n'
+    }));
+    const fromSuffix = await detector.detect(new MemoryFileSystem({
+      'mix.exs': 'defmodule Lib.MixProject do\nend\n',
+      '.tool-versions': 'elixir 1.17.0-otp-27\n'
+    }));
+
+    expect(pinned).toEqual({ elixirVersion: '1.16.2', otpVersion: '26.2.1', versionSource: '.tool-versions', phoenix: false, postgres: false });
+    expect(fromSuffix).toMatchObject({ elixirVersion: '1.17.0', otpVersion: '27' });
+  });
+});
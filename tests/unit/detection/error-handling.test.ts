@@ -83,6 +83,37 @@ describe('Error Handling System', () => {
       expect(attempts).toBe(1);
     });
 
+    it('should back off exponentially between attempts, up to the maximum delay', async () => {
+      const delays: number[] = [];
+      const operation = vi.fn().mockRejectedValue(new FileSystemError('EBUSY: resource busy', 'read', 'go.mod'));
+
+      const result = await ErrorRecovery.withRetry(operation, {
+        maxAttempts: 5,
+        baseDelay: 100,
+        maxDelay: 300,
+        sleep: async ms => { delays.push(ms); }
+      });
+
+      expect(result.success).toBe(false);
+      expect(operation).toHaveBeenCalledTimes(5);
+      expect(delays).toEqual([100, 200, 300, 300]);
+    });
+
+    it('should not start an attempt past the deadline', async () => {
+      const error = new Error('ETIMEDOUT');
+      const operation = vi.fn().mockRejectedValue(error);
+      const sleep = vi.fn().mockResolvedValue(undefined);
+
+      await expect(ErrorRecovery.withBackoff(operation, () => true, {
+        maxAttempts: 10,
+        baseDelay: 1000,
+        deadline: Date.now() + 1500,
+        sleep
+      })).rejects.toBe(error);
+      expect(operation).toHaveBeenCalledTimes(2);
+      expect(sleep).toHaveBeenCalledWith(1000);
+    });
+
     it('should use fallback operation when primary fails', async () => {
       const primaryOperation = vi.fn().mockRejectedValue(new DetectionFailureError('Primary failed', 'test'));
       const fallbackOperation = vi.fn().mockResolvedValue('fallback result');
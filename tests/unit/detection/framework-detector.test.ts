@@ -0,0 +1,120 @@
+/**
+ * Tests for FrameworkDetectorImpl reading the project directory
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { FrameworkDetectorImpl } from '../../../src/detection/framework-detector';
+import { ProjectInfo } from '../../../src/detection/interfaces/framework-detector';
+import { MemoryFileSystem, RetryingFileSystem, SubdirectoryFileSystem } from '../../../src/detection/utils/project-fs';
+import { IgnoreMatcher } from '../../../src/detection/utils/ignore-patterns';
+
+describe('FrameworkDetectorImpl', () => {
+  let tempDir: string;
+
+  const projectInfo: ProjectInfo = {
+    name: 'app',
+    languages: ['Go'],
+    dependencies: [],
+    buildCommands: ['go build ./...'],
+    testCommands: ['go test ./...'],
+    installationSteps: [],
+    usageExamples: [],
+    configFiles: ['go.mod'],
+    rawContent: '# app\n\n```bash\ngo test ./...\n```\n'
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'framework-detector-test-'));
+    fs.writeFileSync(path.join(tempDir, 'go.mod'), 'module example.com/app\n\ngo 1.22\n');
+    fs.writeFileSync(path.join(tempDir, 'main.go'), 'package main\n');
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  describe('project file systems', () => {
+    const tree = new MemoryFileSystem({
+      'services/api/go.mod': 'module example.com/api\n\ngo 1.22\n',
+      'services/api/main.go': 'package main\n',
+      'services/api/main_linux.go': '//go:build linux\n\npackage main\n',
+      'web/package.json': '{"name": "web"}\n'
+    });
+
+    it('should run the analyzers and detectors against a wrapped in-memory tree', async () => {
+      const result = await new FrameworkDetectorImpl()
+        .detectFrameworks(projectInfo, new RetryingFileSystem(new SubdirectoryFileSystem(tree, 'services/api')));
+
+      expect(result.buildTools.find(tool => tool.name === 'go')?.config?.buildConstraints).toMatchObject({
+        platforms: [{ goos: 'linux', goarch: 'amd64' }],
+        files: [{ file: 'main_linux.go' }]
+      });
+      expect(result.versionConstraints).toMatchObject([{ versions: ['1.22'] }]);
+      expect(result.languages).toMatchObject([{ name: 'Go', primary: true }]);
+    });
+
+    it('should detect the packages of an in-memory monorepo', async () => {
+      const units = await new FrameworkDetectorImpl().detectMonorepo(tree);
+
+      expect(units.map(unit => [unit.path, unit.name, unit.languages])).toEqual([
+        ['services/api', 'example.com/api', ['Go']],
+        ['web', 'web', ['JavaScript']]
+      ]);
+      expect(units[0]!.detection.buildTools.map(tool => tool.name)).toContain('go');
+    });
+
+    it('should leave the Go files ignore patterns leave out out of the build constraints', async () => {
+      const project = new MemoryFileSystem({
+        'go.mod': 'module example.com/app\n\ngo 1.22\n',
+        'main_linux.go': '//go:build linux\n\npackage main\n',
+        'vendor/golang.org/x/sys/windows/syscall_windows.go': '//go:build windows\n\npackage windows\n',
+        'internal/gen/api_darwin.go': '//go:build darwin\n\npackage gen\n'
+      });
+      const platforms = async (ignore?: IgnoreMatcher) => {
+        const result = await new FrameworkDetectorImpl().detectFrameworks(projectInfo, project, undefined, ignore);
+        return result.buildTools.find(tool => tool.name === 'go')?.config?.buildConstraints.platforms.map((platform: { goos: string }) => platform.goos).sort();
+      };
+
+      expect(await platforms(new IgnoreMatcher(['vendor/', 'internal/gen/']))).toEqual(['linux']);
+      expect(await platforms()).toEqual(['darwin', 'linux']);
+    });
+  });
+
+  describe('deadline', () => {
+    function failReadsOf(file: string): void {
+      const readFile = fs.promises.readFile;
+      vi.spyOn(fs.promises, 'readFile').mockImplementation((async (target: fs.PathLike, options?: unknown) => {
+        if (String(target).endsWith(file)) {
+          throw Object.assign(new Error(`EIO: i/o error, open '${target}'`), { code: 'EIO' });
+        }
+        return readFile(target, options as BufferEncoding);
+      }) as typeof fs.promises.readFile);
+    }
+
+    it('should retry reads failing transiently before the deadline', async () => {
+      const sleep = vi.fn().mockResolvedValue(undefined);
+      failReadsOf('go.mod');
+
+      const result = await new FrameworkDetectorImpl({ retry: { sleep } })
+        .detectFrameworks(projectInfo, tempDir, undefined, undefined, Date.now() + 60000);
+
+      expect(sleep).toHaveBeenCalled();
+      expect(result.warnings.filter(warning => warning.code === 'FILE_READ_FAILED')).toMatchObject([{ path: 'go.mod' }]);
+    });
+
+    it('should not retry reads once the deadline has passed', async () => {
+      const sleep = vi.fn().mockResolvedValue(undefined);
+      failReadsOf('go.mod');
+
+      const result = await new FrameworkDetectorImpl({ retry: { sleep } })
+        .detectFrameworks(projectInfo, tempDir, undefined, undefined, Date.now() - 1);
+
+      expect(sleep).not.toHaveBeenCalled();
+      expect(result.warnings.filter(warning => warning.code === 'FILE_READ_FAILED')).toMatchObject([{ path: 'go.mod' }]);
+    });
+  });
+});
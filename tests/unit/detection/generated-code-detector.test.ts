@@ -0,0 +1,46 @@
+/**
+ * Tests for GeneratedCodeDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { GeneratedCodeDetector } from '../../../src/detection/generated-code-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('GeneratedCodeDetector', () => {
+  const detector = new GeneratedCodeDetector();
+
+  it('should find nothing in hand-written code', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'go.mod': 'module example.com/app\n',
+      'main.go': '// Package main is the app.\npackage main\n\n// Code generated by hand. DO NOT EDIT.\n',
+      'build/main.js': 'console.log(1);\n'
+    }))).toBeUndefined();
+  });
+
+  it('should find generated Go files by their header first, then by their name, whole directories together', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'go.mod': 'module example.com/app\n',
+      'main.go': 'package main\n',
+      'store/store.go': 'package store\n',
+      'store/zz_generated.deepcopy.go': '//go:build !ignore_autogenerated\n\n// Code generated by controller-gen. DO NOT EDIT.\n\npackage store\n',
+      'store/enum_gen.go': 'package store\n',
+      'api/v1/service.pb.go': '// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage v1\n',
+      'api/v1/service_grpc.pb.go': 'package v1\n',
+      'vendor/lib/lib.pb.go': '// Code generated by protoc-gen-go. DO NOT EDIT.\npackage lib\n'
+    }))).toEqual({
+      goFiles: ['store/enum_gen.go', 'store/zz_generated.deepcopy.go'],
+      goDirectories: ['api/v1'],
+      bundleDirectories: []
+    });
+  });
+
+  it('should find the dist directories JavaScript packages bundle into', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'package.json': '{ "name": "app" }',
+      'dist/index.js': '"use strict";\n',
+      'packages/ui/package.json': '{ "name": "ui" }',
+      'packages/ui/dist/index.mjs': 'export {};\n',
+      'docs/dist/index.html': '<html></html>\n'
+    }))).toEqual({ goFiles: [], goDirectories: [], bundleDirectories: ['dist', 'packages/ui/dist'] });
+  });
+});
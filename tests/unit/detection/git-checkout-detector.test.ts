@@ -0,0 +1,53 @@
+/**
+ * Tests for GitCheckoutDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { GitCheckoutDetector } from '../../../src/detection/git-checkout-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('GitCheckoutDetector', () => {
+  const detector = new GitCheckoutDetector();
+
+  it('should find nothing without submodules or git-versioning tools', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'pyproject.toml': '[project]\nname = "app"\nversion = "1.0.0"\n',
+      'Makefile': 'build:\n\tgo build ./...\n'
+    }))).toBeUndefined();
+  });
+
+  it('should read the submodules of .gitmodules', async () => {
+    const gitCheckout = await detector.detect(new MemoryFileSystem({
+      '.gitmodules': [
+        '[submodule "vendor/json"]',
+        '\tpath = vendor/json',
+        '\turl = https://github.com/nlohmann/json.git',
+        '[submodule "proto"]',
+        '\tpath = third_party/proto',
+        '\turl = git@github.com:acme/proto.git',
+        '\tbranch = main',
+        '[submodule "broken"]',
+        '\tpath = broken'
+      ].join('\n')
+    }));
+
+    expect(gitCheckout).toEqual({
+      submodules: [
+        { name: 'vendor/json', path: 'vendor/json', url: 'https://github.com/nlohmann/json.git' },
+        { name: 'proto', path: 'third_party/proto', url: 'git@github.com:acme/proto.git' }
+      ],
+      versionFromGit: []
+    });
+  });
+
+  it('should find the tools that version the project from git tags', async () => {
+    const gitCheckout = await detector.detect(new MemoryFileSystem({
+      'pyproject.toml': '[build-system]\nrequires = ["setuptools>=64", "setuptools_scm>=8"]\n\n[tool.setuptools_scm]\n',
+      'setup.py': 'setup(use_scm_version=True)\n',
+      'GitVersion.yml': 'mode: ContinuousDelivery\n',
+      'Makefile': 'VERSION := $(shell git describe --tags --always)\n'
+    }));
+
+    expect(gitCheckout).toEqual({ submodules: [], versionFromGit: ['setuptools-scm', 'GitVersion', 'git describe'] });
+  });
+});
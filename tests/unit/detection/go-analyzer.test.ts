@@ -513,12 +513,41 @@ func main() {
         if (path.includes('.go')) return Promise.resolve(mockGoFile);
         return Promise.resolve('');
       });
-      mockFileScanner.scanProjectFiles.mockResolvedValue(['/test/path/main.go']);
+      mockFileScanner.findFilesByExtension.mockResolvedValue(['/test/path/main.go']);
 
       const result = await analyzer.analyze(projectInfo, '/test/path');
 
       expect(result.metadata.patternsMatched).toContain('build_constraints');
       expect(result.recommendations).toContain('Build constraints detected. Consider using appropriate build tags in CI for different environments.');
+      expect(result.buildTools[0].config?.buildConstraints.platforms).toEqual([
+        { goos: 'linux', goarch: 'amd64' }
+      ]);
+    });
+
+    it('should not attach build constraints when no files are constrained', async () => {
+      const projectInfo: ProjectInfo = {
+        name: 'unconstrained-project',
+        languages: ['Go'],
+        dependencies: [],
+        buildCommands: [],
+        testCommands: [],
+        installationSteps: [],
+        usageExamples: [],
+        configFiles: ['go.mod'],
+        rawContent: 'A plain Go project'
+      };
+
+      mockFileScanner.fileExists.mockResolvedValue(true);
+      mockFileScanner.readConfigFile.mockImplementation((path) => {
+        if (path.includes('go.mod')) return Promise.resolve('module unconstrained-project\n\ngo 1.21');
+        return Promise.resolve('package main\n\nfunc main() {}');
+      });
+      mockFileScanner.findFilesByExtension.mockResolvedValue(['/test/path/main.go']);
+
+      const result = await analyzer.analyze(projectInfo, '/test/path');
+
+      expect(result.metadata.patternsMatched).not.toContain('build_constraints');
+      expect(result.buildTools[0].config?.buildConstraints).toBeUndefined();
     });
 
     it('should generate appropriate go commands', async () => {
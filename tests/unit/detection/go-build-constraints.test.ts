@@ -0,0 +1,107 @@
+import { describe, it, expect, beforeEach } from 'vitest';
+import { readFileSync } from 'fs';
+import { join } from 'path';
+import { GoBuildConstraintScanner } from '../../../src/detection/utils/go-build-constraints';
+
+describe('GoBuildConstraintScanner', () => {
+  let scanner: GoBuildConstraintScanner;
+
+  beforeEach(() => {
+    scanner = new GoBuildConstraintScanner();
+  });
+
+  describe('extractConstraint', () => {
+    it('should prefer //go:build over legacy +build lines', () => {
+      const content = `//go:build linux && amd64
+// +build linux,amd64
+
+package main`;
+
+      expect(scanner.extractConstraint(content)).toBe('linux && amd64');
+    });
+
+    it('should convert legacy +build lines', () => {
+      const content = `// +build linux darwin
+// +build amd64,!cgo
+
+package main`;
+
+      expect(scanner.extractConstraint(content)).toBe('(linux || darwin) && (amd64 && !cgo)');
+    });
+
+    it('should ignore constraints after the package clause', () => {
+      const content = `package main
+
+//go:build linux`;
+
+      expect(scanner.extractConstraint(content)).toBeNull();
+    });
+  });
+
+  describe('resolvePlatforms', () => {
+    it('should resolve an explicit GOOS/GOARCH pair', () => {
+      const platforms = scanner.resolvePlatforms(scanner.parseExpression('linux && amd64'));
+
+      expect(platforms).toEqual([{ goos: 'linux', goarch: 'amd64' }]);
+    });
+
+    it('should expand negations against the known OS set', () => {
+      const platforms = scanner.resolvePlatforms(scanner.parseExpression('!windows'));
+      const systems = platforms?.map(p => p.goos);
+
+      expect(systems).toContain('linux');
+      expect(systems).toContain('darwin');
+      expect(systems).toContain('freebsd');
+      expect(systems).not.toContain('windows');
+      expect(platforms?.every(p => p.goarch === 'amd64')).toBe(true);
+    });
+
+    it('should treat custom tags as free variables', () => {
+      const platforms = scanner.resolvePlatforms(scanner.parseExpression('(linux && integration) || (darwin && !integration)'));
+
+      expect(platforms?.map(p => p.goos)).toEqual(['darwin', 'linux']);
+    });
+
+    it('should return null when no platform is referenced', () => {
+      expect(scanner.resolvePlatforms(scanner.parseExpression('integration'))).toBeNull();
+    });
+
+    it('should honour the unix tag', () => {
+      const platforms = scanner.resolvePlatforms(scanner.parseExpression('unix'));
+
+      expect(platforms?.map(p => p.goos)).not.toContain('windows');
+      expect(platforms?.map(p => p.goos)).toContain('linux');
+    });
+  });
+
+  describe('scan', () => {
+    it('should collect platforms from the build-constraints fixture', () => {
+      const path = join(__dirname, '../../fixtures/go/build-constraints-project/main.go');
+      const result = scanner.scan([{ path, content: readFileSync(path, 'utf-8') }]);
+
+      expect(result.platforms).toEqual([{ goos: 'linux', goarch: 'amd64' }]);
+      expect(result.files).toHaveLength(1);
+    });
+
+    it('should aggregate distinct platforms and custom tags', () => {
+      const result = scanner.scan([
+        { path: 'a_linux.go', content: '//go:build linux\n\npackage a' },
+        { path: 'b_linux.go', content: '//go:build linux && !e2e\n\npackage a' },
+        { path: 'c_test.go', content: '//go:build integration\n\npackage a' },
+        { path: 'd.go', content: 'package a' }
+      ]);
+
+      expect(result.platforms).toEqual([{ goos: 'linux', goarch: 'amd64' }]);
+      expect(result.tags).toEqual(['e2e', 'integration']);
+      expect(result.testTags).toEqual(['integration']);
+      expect(result.files).toHaveLength(3);
+    });
+
+    it('should skip malformed constraints', () => {
+      const result = scanner.scan([{ path: 'bad.go', content: '//go:build linux &&\n\npackage a' }]);
+
+      expect(result.files).toHaveLength(0);
+      expect(result.platforms).toEqual([]);
+    });
+  });
+});
@@ -0,0 +1,40 @@
+/**
+ * Tests for GoModuleDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { GoModuleDetector } from '../../../src/detection/go-module-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('GoModuleDetector', () => {
+  const detector = new GoModuleDetector();
+
+  it('should find nothing without a go.mod', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'main.go': 'package main\n' }))).toBeUndefined();
+  });
+
+  it('should read the module path and single-line and block requirements', async () => {
+    const goModule = await detector.detect(new MemoryFileSystem({
+      'go.mod': [
+        'module github.com/acme/service // the service',
+        '',
+        'go 1.22',
+        '',
+        'require github.com/acme/auth v1.4.0',
+        '',
+        'require (',
+        '\tgithub.com/spf13/cobra v1.8.0',
+        '\t// github.com/acme/old v0.1.0',
+        '\tgithub.com/acme/proto v0.3.1 // indirect',
+        ')',
+        '',
+        'replace github.com/acme/auth => ../auth'
+      ].join('\n')
+    }));
+
+    expect(goModule).toEqual({
+      path: 'github.com/acme/service',
+      requires: ['github.com/acme/auth', 'github.com/spf13/cobra', 'github.com/acme/proto']
+    });
+  });
+});
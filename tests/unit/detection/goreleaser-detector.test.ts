@@ -0,0 +1,20 @@
+/**
+ * Tests for GoReleaserDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { GoReleaserDetector } from '../../../src/detection/goreleaser-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('GoReleaserDetector', () => {
+  const detector = new GoReleaserDetector();
+
+  it('should find nothing without a GoReleaser config', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'go.mod': 'module example.com/app\n' }))).toBeUndefined();
+  });
+
+  it('should find the config under any of the names GoReleaser reads', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ '.goreleaser.yaml': 'version: 2\n' }))).toEqual({ file: '.goreleaser.yaml' });
+    expect(await detector.detect(new MemoryFileSystem({ 'goreleaser.yml': 'version: 2\n' }))).toEqual({ file: 'goreleaser.yml' });
+  });
+});
@@ -0,0 +1,45 @@
+/**
+ * Tests for HaskellDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { HaskellDetector } from '../../../src/detection/haskell-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('HaskellDetector', () => {
+  const detector = new HaskellDetector();
+
+  it('should find nothing without a stack.yaml or a .cabal file', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'src/Main.hs': 'main = pure ()\n' }))).toBeUndefined();
+  });
+
+  it('should read a Cabal project, the newest GHC it is tested with and its test suite', async () => {
+    const haskellProject = await detector.detect(new MemoryFileSystem({
+      'parser.cabal': [
+        'cabal-version: 3.0',
+        'name: parser',
+        'tested-with:',
+        '  GHC == 9.4.8',
+        '  GHC == 9.10.1',
+        '  GHC == 9.6.4',
+        'library',
+        '  exposed-modules: Parser',
+        'test-suite spec',
+        '  type: exitcode-stdio-1.0'
+      ].join('\n')
+    }));
+
+    expect(haskellProject).toEqual({ tool: 'cabal', ghcVersion: '9.10.1', cabalFiles: ['parser.cabal'], tests: true, hlint: false });
+  });
+
+  it('should prefer Stack over the .cabal file and read the snapshot, package.yaml tests and HLint configuration', async () => {
+    const haskellProject = await detector.detect(new MemoryFileSystem({
+      'stack.yaml': 'snapshot: lts-22.7\npackages:\n  - .\n',
+      'app.cabal': 'name: app\nexecutable app\n  main-is: Main.hs\n',
+      'package.yaml': 'name: app\ntests:\n  spec:\n    main: Spec.hs\n',
+      '.hlint.yaml': '- ignore: {name: Use camelCase}\n'
+    }));
+
+    expect(haskellProject).toEqual({ tool: 'stack', resolver: 'lts-22.7', cabalFiles: ['app.cabal'], tests: true, hlint: true });
+  });
+});
@@ -0,0 +1,50 @@
+/**
+ * Tests for the gitignore-style patterns detection leaves paths out by
+ */
+
+import { describe, it, expect } from 'vitest';
+import { IgnoreMatcher, loadIgnoreMatcher, parseIgnoreFile } from '../../../src/detection/utils/ignore-patterns';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('IgnoreMatcher', () => {
+  it('should anchor patterns with a leading or inner slash and match the others at any depth', () => {
+    const matcher = new IgnoreMatcher(['/third_party', 'gen/proto', 'node_modules/', '*.pb.go']);
+
+    expect(matcher.ignores('third_party', true)).toBe(true);
+    expect(matcher.ignores('libs/third_party', true)).toBe(false);
+    expect(matcher.ignores('gen/proto/api.go', false)).toBe(true);
+    expect(matcher.ignores('src/gen/proto', true)).toBe(false);
+    expect(matcher.ignores('web/node_modules/react/package.json', false)).toBe(true);
+    expect(matcher.ignores('api/v1/service.pb.go', false)).toBe(true);
+  });
+
+  it('should only match directories with a trailing slash and never the root', () => {
+    const matcher = new IgnoreMatcher(['vendor/']);
+
+    expect(matcher.ignores('vendor', true)).toBe(true);
+    expect(matcher.ignores('vendor/github.com/x/y/go.mod', false)).toBe(true);
+    expect(matcher.ignores('vendor', false)).toBe(false);
+    expect(matcher.ignores('go.mod', false)).toBe(false);
+    expect(matcher.ignores('.', true)).toBe(false);
+    expect(matcher.within('vendor').ignores('go.mod', false)).toBe(true);
+  });
+
+  it('should let a later negated pattern re-include files but not what an ignored directory holds', () => {
+    const matcher = new IgnoreMatcher(['.env*', '!.env.example', 'build/', '!build/package.json', 'docs/**/*.md', '!docs/**/keep.md']);
+
+    expect(matcher.ignores('.env.local', false)).toBe(true);
+    expect(matcher.ignores('.env.example', false)).toBe(false);
+    expect(matcher.ignores('build/package.json', false)).toBe(true);
+    expect(matcher.ignores('docs/a/b/readme.md', false)).toBe(true);
+    expect(matcher.ignores('docs/keep.md', false)).toBe(false);
+  });
+
+  it('should read .gitignore before the extra patterns', async () => {
+    expect(parseIgnoreFile('# deps\nnode_modules/\n\n\\#notes\ndist  \n')).toEqual(['node_modules/', '\\#notes', 'dist']);
+
+    const matcher = await loadIgnoreMatcher(new MemoryFileSystem({ '.gitignore': 'dist/\n', 'go.mod': 'module app\n' }), ['vendor/', '!dist/']);
+    expect(matcher.patterns).toEqual(['dist/', 'vendor/', '!dist/']);
+    expect(matcher.ignores('dist', true)).toBe(false);
+    expect(matcher.ignores('vendor', true)).toBe(true);
+  });
+});
@@ -0,0 +1,85 @@
+/**
+ * Tests for JavaBuildDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { JavaBuildDetector } from '../../../src/detection/java-build-detector';
+
+describe('JavaBuildDetector', () => {
+  let tempDir: string;
+  let detector: JavaBuildDetector;
+
+  const writeFile = (file: string, content: string) => {
+    fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, file), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'java-build-detector-test-'));
+    detector = new JavaBuildDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should return undefined without a pom.xml or Gradle script', async () => {
+    writeFile('package.json', '{}');
+
+    expect(await detector.detect(tempDir)).toBeUndefined();
+  });
+
+  it('should read the release of a multi-module Maven build', async () => {
+    writeFile('pom.xml', [
+      '<project>',
+      '  <properties><jdk.release>21</jdk.release></properties>',
+      '  <modules>',
+      '    <module>core</module>',
+      '    <!-- <module>legacy</module> -->',
+      '    <module>app/</module>',
+      '  </modules>',
+      '</project>'
+    ].join('\n'));
+    writeFile('core/pom.xml', '<project><properties><maven.compiler.source>1.8</maven.compiler.source></properties></project>');
+    writeFile('app/pom.xml', '<project><properties><maven.compiler.release>${jdk.release}</maven.compiler.release></properties></project>');
+
+    expect(await detector.detect(tempDir)).toEqual({
+      buildSystem: 'maven',
+      manifest: 'pom.xml',
+      wrapper: false,
+      modules: ['core', 'app'],
+      javaVersion: '8',
+      versionSource: 'core/pom.xml'
+    });
+
+    writeFile('pom.xml', '<project><properties><maven.compiler.release>${jdk.release}</maven.compiler.release><jdk.release>17</jdk.release></properties></project>');
+    writeFile('mvnw', '#!/bin/sh\n');
+    expect(await detector.detect(tempDir)).toMatchObject({ javaVersion: '17', versionSource: 'pom.xml', wrapper: true, modules: [] });
+  });
+
+  it('should read Gradle toolchains, source compatibility and included projects', async () => {
+    writeFile('settings.gradle.kts', 'rootProject.name = "shop"\ninclude(":core:api", ":web")\n');
+    writeFile('build.gradle.kts', 'java {\n  toolchain {\n    languageVersion.set(JavaLanguageVersion.of(21))\n  }\n}\n');
+    writeFile('gradlew', '#!/bin/sh\n');
+
+    expect(await detector.detect(tempDir)).toEqual({
+      buildSystem: 'gradle',
+      manifest: 'build.gradle.kts',
+      javaVersion: '21',
+      versionSource: 'build.gradle.kts',
+      wrapper: true,
+      modules: ['core/api', 'web']
+    });
+
+    writeFile('build.gradle.kts', 'java {\n  sourceCompatibility = JavaVersion.VERSION_1_8\n}\n');
+    expect(await detector.detect(tempDir)).toMatchObject({ javaVersion: '8' });
+
+    writeFile('pom.xml', '<project/>');
+    expect(await detector.detect(tempDir)).toMatchObject({ buildSystem: 'maven' });
+  });
+});
@@ -0,0 +1,73 @@
+/**
+ * Tests for LanguageDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { LanguageDetector } from '../../../src/detection/language-detector';
+
+describe('LanguageDetector', () => {
+  let tempDir: string;
+  let detector: LanguageDetector;
+
+  const writeFile = (file: string, content: string = '') => {
+    fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, file), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'language-detector-test-'));
+    detector = new LanguageDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should find no languages without source files', async () => {
+    writeFile('README.md', '# Empty');
+
+    expect(await detector.detect(tempDir)).toEqual([]);
+  });
+
+  it('should order languages by source files and locate the ones living in a subdirectory', async () => {
+    writeFile('go.mod', 'module example.com/app\n');
+    for (const file of ['main.go', 'server.go', 'internal/db/db.go', 'internal/db/db_test.go']) {
+      writeFile(file, 'package main\n');
+    }
+    writeFile('web/package.json', '{"name": "web"}');
+    writeFile('web/src/index.ts');
+    writeFile('web/src/app.tsx');
+    writeFile('web/vite.config.js');
+
+    expect(await detector.detect(tempDir)).toEqual([
+      { name: 'Go', files: 4, share: 0.57, directory: '.', primary: true },
+      { name: 'TypeScript', files: 3, share: 0.43, directory: 'web', primary: false }
+    ]);
+  });
+
+  it('should ignore languages with only tooling files or without a manifest', async () => {
+    writeFile('Cargo.toml', '[package]\nname = "app"\n');
+    for (let i = 0; i < 30; i++) {
+      writeFile(`src/module_${i}.rs`);
+    }
+    writeFile('package.json', '{"devDependencies": {"husky": "^9.0.0"}}');
+    writeFile('commitlint.config.js');
+    writeFile('scripts/release.py');
+    writeFile('scripts/notes.py');
+
+    expect((await detector.detect(tempDir)).map(language => language.name)).toEqual(['Rust']);
+  });
+
+  it('should recognize manifests named by extension, such as a .NET project file', async () => {
+    writeFile('api/Api.csproj', '<Project Sdk="Microsoft.NET.Sdk.Web" />');
+    writeFile('api/Program.cs');
+    writeFile('api/Controllers/OrdersController.cs');
+
+    expect(await detector.detect(tempDir)).toEqual([{ name: 'C#', files: 2, share: 1, directory: 'api', primary: true }]);
+  });
+});
@@ -0,0 +1,38 @@
+/**
+ * Tests for LicenseDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { LicenseDetector } from '../../../src/detection/license-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('LicenseDetector', () => {
+  const detector = new LicenseDetector();
+
+  it('should find nothing without a license field or file', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'package.json': '{ "name": "app" }' }))).toBeUndefined();
+  });
+
+  it('should prefer the SPDX id a manifest declares over the license text', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'Cargo.toml': '[package]\nname = "tool"\nlicense = "MIT OR Apache-2.0"\n',
+      'LICENSE': 'Permission is hereby granted, free of charge, to any person obtaining a copy'
+    }))).toEqual({ spdxId: 'MIT OR Apache-2.0', file: 'LICENSE', source: 'Cargo.toml' });
+
+    expect(await detector.detect(new MemoryFileSystem({
+      'pyproject.toml': '[project]\nname = "lib"\nlicense = { text = "BSD-3-Clause" }\n'
+    }))).toEqual({ spdxId: 'BSD-3-Clause', source: 'pyproject.toml' });
+  });
+
+  it('should recognize the license file by its SPDX identifier or text', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'LICENSE.md': '                                 Apache License\n                           Version 2.0, January 2004\n'
+    }))).toEqual({ spdxId: 'Apache-2.0', file: 'LICENSE.md', source: 'LICENSE.md' });
+
+    expect(await detector.detect(new MemoryFileSystem({ 'COPYING': 'SPDX-License-Identifier: GPL-3.0-or-later\n' })))
+      .toEqual({ spdxId: 'GPL-3.0-or-later', file: 'COPYING', source: 'COPYING' });
+
+    expect(await detector.detect(new MemoryFileSystem({ 'LICENSE': 'All rights reserved.\n' })))
+      .toEqual({ file: 'LICENSE', source: 'LICENSE' });
+  });
+});
@@ -0,0 +1,58 @@
+/**
+ * Tests for LintDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { LintDetector } from '../../../src/detection/lint-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('LintDetector', () => {
+  const detector = new LintDetector();
+
+  it('should find golangci-lint from its config and always clippy for Cargo projects', async () => {
+    const linters = await detector.detect(new MemoryFileSystem({
+      '.golangci.yml': 'linters:\n  enable:\n    - errcheck\n',
+      'Cargo.toml': '[package]\nname = "app"\n'
+    }));
+
+    expect(linters).toEqual([
+      { name: 'golangci-lint', language: 'Go', source: '.golangci.yml', command: 'golangci-lint run', installed: false },
+      { name: 'clippy', language: 'Rust', source: 'Cargo.toml', command: 'cargo clippy -- -D warnings', installed: true }
+    ]);
+  });
+
+  it('should find eslint from a config file or a dev dependency', async () => {
+    const fromConfig = await detector.detect(new MemoryFileSystem({ '.eslintrc.json': '{}', 'package.json': '{"name": "app"}' }));
+    const fromDependency = await detector.detect(new MemoryFileSystem({ 'package.json': '{"devDependencies": {"eslint": "^9.0.0"}}' }));
+
+    expect(fromConfig).toEqual([{ name: 'eslint', language: 'JavaScript', source: '.eslintrc.json', command: 'npx eslint .', installed: false }]);
+    expect(fromDependency).toEqual([{ name: 'eslint', language: 'JavaScript', source: 'package.json', command: 'npx eslint .', installed: true }]);
+  });
+
+  it('should find Python linters from their config sections', async () => {
+    const linters = await detector.detect(new MemoryFileSystem({
+      'pyproject.toml': [
+        '[project]',
+        'name = "app"',
+        '',
+        '[project.optional-dependencies]',
+        'dev = [',
+        '  "ruff>=0.4",',
+        ']',
+        '',
+        '[tool.ruff.lint]',
+        'select = ["E", "F"]',
+        '',
+        '[tool.black]',
+        'line-length = 100'
+      ].join('\n'),
+      'setup.cfg': '[flake8]\nmax-line-length = 100\n'
+    }));
+
+    expect(linters.map(linter => [linter.name, linter.source, linter.installed])).toEqual([
+      ['ruff', 'pyproject.toml', true],
+      ['flake8', 'setup.cfg', false],
+      ['black', 'pyproject.toml', false]
+    ]);
+  });
+});
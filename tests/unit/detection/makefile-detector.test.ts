@@ -0,0 +1,70 @@
+/**
+ * Tests for MakefileDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { MakefileDetector, parseMakefileTargets } from '../../../src/detection/makefile-detector';
+
+describe('MakefileDetector', () => {
+  let tempDir: string;
+  let detector: MakefileDetector;
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'makefile-detector-test-'));
+    detector = new MakefileDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should capture phony targets and targets with prerequisites', () => {
+    const { targets, phonyTargets } = parseMakefileTargets([
+      '.PHONY: build test lint',
+      '',
+      'build: deps',
+      '\tgo build ./...',
+      '',
+      'test lint: build',
+      '\tgo test ./...',
+      '',
+      'deps:',
+      '\tgo mod download'
+    ].join('\n'));
+
+    expect(targets).toEqual(['build', 'test', 'lint', 'deps']);
+    expect(phonyTargets).toEqual(['build', 'test', 'lint']);
+  });
+
+  it('should ignore pattern rules, special targets, assignments and recipe lines', () => {
+    const { targets } = parseMakefileTargets([
+      'CC := gcc',
+      'VERSION ::= 1.0',
+      '.DEFAULT_GOAL := build',
+      '%.o: %.c',
+      '\t$(CC) -c $< -o $@',
+      '.SUFFIXES:',
+      'build:: main.o',
+      '\techo done: ok'
+    ].join('\n'));
+
+    expect(targets).toEqual(['build']);
+  });
+
+  it('should read the Makefile at the project root', async () => {
+    fs.writeFileSync(path.join(tempDir, 'Makefile'), 'ci: lint test\n\nlint:\n\tnpm run lint\n\ntest:\n\tnpm test\n');
+
+    const makefile = await detector.detect(tempDir);
+
+    expect(makefile).toEqual({ file: 'Makefile', targets: ['ci', 'lint', 'test'], phonyTargets: [] });
+  });
+
+  it('should return undefined without a Makefile', async () => {
+    expect(await detector.detect(tempDir)).toBeUndefined();
+  });
+});
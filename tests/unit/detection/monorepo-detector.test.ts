@@ -0,0 +1,225 @@
+/**
+ * Tests for MonorepoDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { MonorepoDetector, MonorepoDetectionError } from '../../../src/detection/monorepo-detector';
+import { IgnoreMatcher } from '../../../src/detection/utils/ignore-patterns';
+import { FrameworkDetector } from '../../../src/detection/interfaces/framework-detector';
+import { DetectionResult } from '../../../src/detection/interfaces/detection-result';
+
+describe('MonorepoDetector', () => {
+  let tempDir: string;
+  let frameworkDetector: FrameworkDetector;
+  let detector: MonorepoDetector;
+
+  const detectionResult: DetectionResult = {
+    frameworks: [],
+    buildTools: [],
+    containers: [],
+    confidence: { score: 0.8, level: 'high', breakdown: {} as any, factors: [], recommendations: [] },
+    alternatives: [],
+    warnings: [],
+    detectedAt: new Date(),
+    executionTime: 1
+  };
+
+  const writeFile = (relativePath: string, content: string): void => {
+    const fullPath = path.join(tempDir, relativePath);
+    fs.mkdirSync(path.dirname(fullPath), { recursive: true });
+    fs.writeFileSync(fullPath, content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'monorepo-detector-test-'));
+    frameworkDetector = {
+      detectFrameworks: vi.fn().mockResolvedValue(detectionResult),
+      suggestCISteps: vi.fn()
+    };
+    detector = new MonorepoDetector(frameworkDetector);
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should return one unit per package sorted by path', async () => {
+    writeFile('services/api/go.mod', 'module example.com/services/api\n\ngo 1.22\n');
+    writeFile('services/web/package.json', JSON.stringify({ name: '@example/web' }));
+    writeFile('services/web/tsconfig.json', '{}');
+    writeFile('libs/shared/go.mod', 'module example.com/libs/shared\n');
+    writeFile('README.md', '# Example monorepo');
+
+    const units = await detector.detect(tempDir);
+
+    expect(units.map(unit => unit.path)).toEqual(['libs/shared', 'services/api', 'services/web']);
+    expect(units.map(unit => unit.name)).toEqual([
+      'example.com/libs/shared',
+      'example.com/services/api',
+      '@example/web'
+    ]);
+    expect(units[2]!.languages).toEqual(['TypeScript']);
+    expect(units[0]!.detection).toBe(detectionResult);
+  });
+
+  it('should run detection against each package directory', async () => {
+    writeFile('services/api/go.mod', 'module api\n');
+    writeFile('services/api/README.md', '# API service');
+
+    await detector.detect(tempDir);
+
+    expect(frameworkDetector.detectFrameworks).toHaveBeenCalledTimes(1);
+    const [projectInfo, projectPath] = vi.mocked(frameworkDetector.detectFrameworks).mock.calls[0]!;
+    expect(projectPath).toBe(path.join(tempDir, 'services/api'));
+    expect(projectInfo.languages).toEqual(['Go']);
+    expect(projectInfo.configFiles).toEqual(['go.mod']);
+    expect(projectInfo.rawContent).toBe('# API service');
+  });
+
+  it('should attribute nested modules to the innermost manifest', async () => {
+    writeFile('go.mod', 'module example.com/root\n');
+    writeFile('tools/go.mod', 'module example.com/root/tools\n');
+    writeFile('tools/lint/go.mod', 'module example.com/root/tools/lint\n');
+
+    const units = await detector.detect(tempDir);
+
+    expect(units.map(unit => unit.path)).toEqual(['.', 'tools', 'tools/lint']);
+    expect(units[0]!.nestedUnits).toEqual(['tools', 'tools/lint']);
+    expect(units[1]!.nestedUnits).toEqual(['tools/lint']);
+    expect(units[2]!.nestedUnits).toEqual([]);
+  });
+
+  it('should skip dependency and hidden directories', async () => {
+    writeFile('package.json', JSON.stringify({ name: 'root' }));
+    writeFile('node_modules/left-pad/package.json', JSON.stringify({ name: 'left-pad' }));
+    writeFile('vendor/github.com/x/y/go.mod', 'module y\n');
+    writeFile('.cache/pkg/Cargo.toml', '[package]\nname = "cached"\n');
+
+    const units = await detector.detect(tempDir);
+
+    expect(units.map(unit => unit.path)).toEqual(['.']);
+  });
+
+  it('should skip ignored directories and detect packages without what is ignored', async () => {
+    writeFile('go.mod', 'module example.com/root\n');
+    writeFile('third_party/proto/go.mod', 'module example.com/proto\n');
+    writeFile('tools/go.mod', 'module example.com/root/tools\n');
+
+    const units = await detector.detect(tempDir, new IgnoreMatcher(['/third_party/', 'tools/generated/']));
+
+    expect(units.map(unit => unit.path)).toEqual(['.', 'tools']);
+    const calls = vi.mocked(frameworkDetector.detectFrameworks).mock.calls;
+    const ignoreAt = (directory: string) => calls.find(call => call[1] === directory)?.[3];
+    expect(ignoreAt(tempDir)?.ignores('generated', true)).toBe(false);
+    expect(ignoreAt(path.join(tempDir, 'tools'))?.ignores('generated', true)).toBe(true);
+  });
+
+  it('should read names from Cargo.toml and pyproject.toml', async () => {
+    writeFile('crates/core/Cargo.toml', '[package]\nname = "core-lib"\nversion = "0.1.0"\n');
+    writeFile('py/tool/pyproject.toml', '[project]\nname = "tool"\n');
+
+    const units = await detector.detect(tempDir);
+
+    expect(units.map(unit => [unit.name, unit.languages])).toEqual([
+      ['core-lib', ['Rust']],
+      ['tool', ['Python']]
+    ]);
+  });
+
+  it('should record dependency edges between packages', async () => {
+    writeFile('libs/shared/package.json', JSON.stringify({ name: '@example/shared' }));
+    writeFile('apps/web/package.json', JSON.stringify({ name: 'web', dependencies: { '@example/shared': 'workspace:*', react: '^18.0.0' } }));
+    writeFile('apps/cli/package.json', JSON.stringify({ name: 'cli', devDependencies: { helpers: 'file:../../libs/helpers' } }));
+    writeFile('libs/helpers/package.json', JSON.stringify({ name: 'helpers-local' }));
+    writeFile('crates/core/Cargo.toml', '[package]\nname = "core_lib"\n');
+    writeFile('crates/app/Cargo.toml', '[package]\nname = "app"\n\n[dependencies]\ncore-lib = { path = "../core" }\nserde = "1"\n');
+    writeFile('services/api/go.mod', 'module example.com/api\n\nrequire (\n\texample.com/shared v0.0.0\n)\n\nreplace example.com/shared => ../../go/shared\n');
+    writeFile('go/shared/go.mod', 'module example.com/shared\n');
+
+    const units = await detector.detect(tempDir);
+    const edges = Object.fromEntries(units.map(unit => [unit.path, unit.dependsOn]));
+
+    expect(edges).toEqual({
+      'apps/cli': ['libs/helpers'],
+      'apps/web': ['libs/shared'],
+      'crates/app': ['crates/core'],
+      'crates/core': [],
+      'go/shared': [],
+      'libs/helpers': [],
+      'libs/shared': [],
+      'services/api': ['go/shared']
+    });
+  });
+
+  it('should only detect the packages changed files belong to, keeping their dependencies', async () => {
+    writeFile('go.mod', 'module example.com/root\n');
+    writeFile('libs/core/go.mod', 'module example.com/core\n');
+    writeFile('libs/shared/go.mod', 'module example.com/shared\n\nrequire example.com/core v0.0.0\n');
+    writeFile('services/api/go.mod', 'module example.com/api\n\nrequire example.com/shared v0.0.0\n');
+    writeFile('services/web/package.json', JSON.stringify({ name: 'web' }));
+    writeFile('.gitignore', 'generated/\n');
+
+    const units = await detector.detect(tempDir, new IgnoreMatcher(['generated/']), [
+      'services/api/handlers/users.go',
+      './services/api/go.mod',
+      'services/api/generated/client.go'
+    ]);
+
+    expect(units.map(unit => unit.path)).toEqual(['services/api']);
+    expect(units[0]!.dependsOn).toEqual(['libs/core', 'libs/shared']);
+    expect(frameworkDetector.detectFrameworks).toHaveBeenCalledTimes(1);
+
+    expect((await detector.detect(tempDir, undefined, ['docs/guide.md'])).map(unit => unit.path)).toEqual(['.']);
+    expect(await detector.detect(tempDir, undefined, [])).toEqual([]);
+  });
+
+  it('should detect packages concurrently up to the worker limit and return them sorted by path', async () => {
+    for (const name of ['a', 'b', 'c', 'd', 'e']) {
+      writeFile(`packages/${name}/package.json`, JSON.stringify({ name }));
+    }
+    let running = 0;
+    let peak = 0;
+    vi.mocked(frameworkDetector.detectFrameworks).mockImplementation(async (_projectInfo, projectPath) => {
+      running++;
+      peak = Math.max(peak, running);
+      // Later packages finish first
+      await new Promise(resolve => setTimeout(resolve, projectPath!.endsWith('a') ? 30 : 5));
+      running--;
+      return { ...detectionResult, executionTime: projectPath!.length };
+    });
+
+    const units = await new MonorepoDetector(frameworkDetector, 6, 2).detect(tempDir);
+
+    expect(peak).toBe(2);
+    expect(units.map(unit => unit.path)).toEqual(['packages/a', 'packages/b', 'packages/c', 'packages/d', 'packages/e']);
+  });
+
+  it('should detect the other packages and report every failure together', async () => {
+    writeFile('services/api/go.mod', 'module api\n');
+    writeFile('services/web/package.json', JSON.stringify({ name: 'web', dependencies: { api: '*' } }));
+    writeFile('tools/cli/go.mod', 'module cli\n');
+    vi.mocked(frameworkDetector.detectFrameworks).mockImplementation(async (_projectInfo, projectPath) => {
+      if (!projectPath!.endsWith('web')) {
+        throw new Error('analyzer crashed');
+      }
+      return detectionResult;
+    });
+
+    const error = await detector.detect(tempDir).catch(caught => caught);
+
+    expect(error).toBeInstanceOf(MonorepoDetectionError);
+    expect(error.message).toBe('Failed to detect 2 of 3 packages: services/api: analyzer crashed; tools/cli: analyzer crashed');
+    expect(error.failures.map((failure: any) => failure.path)).toEqual(['services/api', 'tools/cli']);
+    expect(error.units.map((unit: any) => unit.path)).toEqual(['services/web']);
+    expect(frameworkDetector.detectFrameworks).toHaveBeenCalledTimes(3);
+  });
+
+  it('should throw when the root cannot be read', async () => {
+    await expect(detector.detect(path.join(tempDir, 'missing'))).rejects.toThrow('Failed to scan monorepo');
+  });
+});
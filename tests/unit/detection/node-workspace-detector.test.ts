@@ -0,0 +1,49 @@
+/**
+ * Tests for NodeWorkspaceDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { NodeWorkspaceDetector } from '../../../src/detection/node-workspace-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('NodeWorkspaceDetector', () => {
+  const detector = new NodeWorkspaceDetector();
+  const manifest = (name: string, scripts: Record<string, string> = {}) => JSON.stringify({ name, scripts });
+
+  it('should find nothing without declared workspaces', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'package.json': manifest('app', { test: 'vitest' }) }))).toBeUndefined();
+  });
+
+  it('should expand package.json workspace globs and apply exclusions', async () => {
+    const workspace = await detector.detect(new MemoryFileSystem({
+      'package.json': JSON.stringify({ name: 'root', private: true, workspaces: ['packages/*', 'tools/cli', '!packages/legacy'] }),
+      'packages/core/package.json': manifest('@acme/core', { test: 'vitest', build: 'tsc' }),
+      'packages/web/package.json': manifest('@acme/web', { build: 'vite build' }),
+      'packages/legacy/package.json': manifest('@acme/legacy', { test: 'jest' }),
+      'packages/docs/README.md': '# Not a package',
+      'tools/cli/package.json': manifest('acme-cli', { test: 'node --test' })
+    }));
+
+    expect(workspace).toEqual({
+      manager: 'npm',
+      members: [
+        { name: '@acme/core', path: 'packages/core', scripts: ['build', 'test'] },
+        { name: '@acme/web', path: 'packages/web', scripts: ['build'] },
+        { name: 'acme-cli', path: 'tools/cli', scripts: ['test'] }
+      ]
+    });
+  });
+
+  it('should prefer pnpm-workspace.yaml and match nested packages with **', async () => {
+    const workspace = await detector.detect(new MemoryFileSystem({
+      'package.json': JSON.stringify({ name: 'root', workspaces: ['ignored/*'] }),
+      'pnpm-workspace.yaml': "packages:\n  - 'apps/**'\n",
+      'apps/site/package.json': manifest('site', { test: 'vitest' }),
+      'apps/services/api/package.json': manifest('api'),
+      'apps/site/node_modules/dep/package.json': manifest('dep')
+    }));
+
+    expect(workspace?.manager).toBe('pnpm');
+    expect(workspace?.members.map(member => member.path)).toEqual(['apps/services/api', 'apps/site']);
+  });
+});
@@ -1,5 +1,9 @@
 import { describe, it, expect, beforeEach, vi } from 'vitest';
+import { promises as fs } from 'fs';
+import { join } from 'path';
+import { tmpdir } from 'os';
 import { NodeJSAnalyzer } from '../../../src/detection/analyzers/nodejs';
+import { FileSystemScanner } from '../../../src/detection/utils/file-scanner';
 import { ProjectInfo } from '../../../src/detection/interfaces/language-analyzer';
 
 describe('NodeJSAnalyzer', () => {
@@ -508,7 +512,7 @@ describe('NodeJSAnalyzer', () => {
   });
 
   describe('package manager detection', () => {
-    it('should prioritize yarn when yarn.lock is present', async () => {
+    it('should default to npm when several lockfiles are inconclusive', async () => {
       const projectInfo: ProjectInfo = {
         name: 'test-project',
         languages: ['JavaScript'],
@@ -527,7 +531,104 @@ describe('NodeJSAnalyzer', () => {
 
       const result = await analyzer.analyze(projectInfo, '/test/path');
 
+      expect(result.buildTools[0].name).toBe('npm');
+      expect(result.buildTools[0].configFile).toBe('package-lock.json');
+      expect(result.metadata.warnings.some(w => w.includes('Multiple lockfiles'))).toBe(true);
+    });
+
+    it('should prefer the packageManager field when several lockfiles exist', async () => {
+      const projectInfo: ProjectInfo = {
+        name: 'test-project',
+        languages: ['JavaScript'],
+        dependencies: [],
+        buildCommands: [],
+        testCommands: [],
+        installationSteps: [],
+        usageExamples: [],
+        configFiles: ['package.json'],
+        rawContent: ''
+      };
+
+      mockFileScanner.fileExists.mockResolvedValue(true);
+      mockFileScanner.readConfigFile.mockResolvedValue({ packageManager: 'yarn@1.22.19+sha512.abc' });
+      mockFileScanner.findConfigFiles.mockResolvedValue(['yarn.lock', 'package-lock.json']);
+
+      const result = await analyzer.analyze(projectInfo, '/test/path');
+
       expect(result.buildTools[0].name).toBe('yarn');
+      expect(result.buildTools[0].configFile).toBe('yarn.lock');
+      expect(result.buildTools[0].version).toBe('1.22.19');
+    });
+
+    it('should detect bun from bun.lockb', async () => {
+      const projectInfo: ProjectInfo = {
+        name: 'test-project',
+        languages: ['TypeScript'],
+        dependencies: [],
+        buildCommands: [],
+        testCommands: [],
+        installationSteps: [],
+        usageExamples: [],
+        configFiles: ['package.json'],
+        rawContent: ''
+      };
+
+      mockFileScanner.fileExists.mockResolvedValue(true);
+      mockFileScanner.readConfigFile.mockResolvedValue({});
+      mockFileScanner.findConfigFiles.mockResolvedValue(['bun.lockb']);
+
+      const result = await analyzer.analyze(projectInfo, '/test/path');
+
+      expect(result.buildTools[0].name).toBe('bun');
+      expect(result.buildTools[0].configFile).toBe('bun.lockb');
+      expect(result.buildTools[0].commands.find(c => c.name === 'build')?.command).toBe('bun run build');
+    });
+
+    it('should use the packageManager field when no lockfile is committed', async () => {
+      const projectInfo: ProjectInfo = {
+        name: 'test-project',
+        languages: ['JavaScript'],
+        dependencies: [],
+        buildCommands: ['npm run build'],
+        testCommands: [],
+        installationSteps: [],
+        usageExamples: [],
+        configFiles: ['package.json'],
+        rawContent: ''
+      };
+
+      mockFileScanner.fileExists.mockResolvedValue(true);
+      mockFileScanner.readConfigFile.mockResolvedValue({ packageManager: 'pnpm@9.1.0' });
+      mockFileScanner.findConfigFiles.mockResolvedValue([]);
+
+      const result = await analyzer.analyze(projectInfo, '/test/path');
+
+      expect(result.buildTools[0].name).toBe('pnpm');
+      expect(result.buildTools[0].configFile).toBe('pnpm-lock.yaml');
+      expect(result.buildTools[0].version).toBe('9.1.0');
+    });
+
+    it('should expose the engines.node constraint', async () => {
+      const projectInfo: ProjectInfo = {
+        name: 'test-project',
+        languages: ['JavaScript'],
+        dependencies: [],
+        buildCommands: [],
+        testCommands: [],
+        installationSteps: [],
+        usageExamples: [],
+        configFiles: ['package.json'],
+        rawContent: ''
+      };
+
+      mockFileScanner.fileExists.mockResolvedValue(true);
+      mockFileScanner.readConfigFile.mockResolvedValue({ engines: { node: '>=18' } });
+      mockFileScanner.findConfigFiles.mockResolvedValue([]);
+
+      const result = await analyzer.analyze(projectInfo, '/test/path');
+
+      expect(result.versionConstraint?.source).toBe('package.json');
+      expect(result.versionConstraint?.versions).toEqual(['18', '20', '22', '24']);
     });
 
     it('should detect pnpm when pnpm-lock.yaml is present', async () => {
@@ -607,4 +708,35 @@ describe('NodeJSAnalyzer', () => {
       expect(result.confidence).toBeLessThan(0.7); // Adjusted for build tool confidence
     });
   });
-});
\ No newline at end of file
+
+  describe('byte order marks', () => {
+    it('should parse a package.json saved with a byte order mark', async () => {
+      const projectPath = await fs.mkdtemp(join(tmpdir(), 'nodejs-analyzer-bom-'));
+      try {
+        await fs.writeFile(join(projectPath, 'package.json'), '\uFEFF' + JSON.stringify({
+          dependencies: { react: '^18.0.0', 'react-dom': '^18.0.0' },
+          scripts: { start: 'react-scripts start', build: 'react-scripts build' }
+        }));
+        const projectInfo: ProjectInfo = {
+          name: 'bom-app',
+          languages: ['JavaScript'],
+          dependencies: [],
+          buildCommands: [],
+          testCommands: [],
+          installationSteps: [],
+          usageExamples: [],
+          configFiles: ['package.json'],
+          rawContent: ''
+        };
+
+        const result = await new NodeJSAnalyzer(new FileSystemScanner()).analyze(projectInfo, projectPath);
+
+        expect(result.metadata.warnings.some(warning => warning.includes('Failed to parse package.json'))).toBe(false);
+        expect(result.metadata.filesAnalyzed).toContain('package.json');
+        expect(result.frameworks.some(framework => framework.name === 'React')).toBe(true);
+      } finally {
+        await fs.rm(projectPath, { recursive: true, force: true });
+      }
+    });
+  });
+});
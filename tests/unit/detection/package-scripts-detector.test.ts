@@ -0,0 +1,23 @@
+/**
+ * Tests for PackageScriptsDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { PackageScriptsDetector } from '../../../src/detection/package-scripts-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('PackageScriptsDetector', () => {
+  const detector = new PackageScriptsDetector();
+
+  it('should find nothing without a package.json', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'go.mod': 'module example.com/app\n' }))).toBeUndefined();
+  });
+
+  it('should read the scripts of package.json', async () => {
+    const packageScripts = await detector.detect(new MemoryFileSystem({
+      'package.json': JSON.stringify({ name: 'app', scripts: { 'test:ci': 'jest --ci', build: 'tsc', broken: 42 } })
+    }));
+
+    expect(packageScripts).toEqual({ scripts: { 'test:ci': 'jest --ci', build: 'tsc' } });
+  });
+});
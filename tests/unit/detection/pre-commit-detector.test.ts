@@ -0,0 +1,44 @@
+/**
+ * Tests for PreCommitDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { PreCommitDetector } from '../../../src/detection/pre-commit-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('PreCommitDetector', () => {
+  const detector = new PreCommitDetector();
+
+  it('should find nothing without a pre-commit config', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'setup.cfg': '[flake8]\n' }))).toBeUndefined();
+  });
+
+  it('should read the hook ids of every repo', async () => {
+    const preCommit = await detector.detect(new MemoryFileSystem({
+      '.pre-commit-config.yaml': [
+        'repos:',
+        '  - repo: https://github.com/pre-commit/pre-commit-hooks',
+        '    rev: v4.6.0',
+        '    hooks:',
+        '      - id: trailing-whitespace',
+        '      - id: end-of-file-fixer',
+        '  - repo: https://github.com/astral-sh/ruff-pre-commit',
+        '    rev: v0.4.4',
+        '    hooks:',
+        '      - id: ruff',
+        '        args: [--fix]',
+        '      - id: ruff-format'
+      ].join('\n')
+    }));
+
+    expect(preCommit).toEqual({
+      file: '.pre-commit-config.yaml',
+      hooks: ['trailing-whitespace', 'end-of-file-fixer', 'ruff', 'ruff-format']
+    });
+  });
+
+  it('should report a config that is not valid YAML', async () => {
+    await expect(detector.detect(new MemoryFileSystem({ '.pre-commit-config.yml': 'repos: [\n' })))
+      .rejects.toThrow('Failed to parse .pre-commit-config.yml');
+  });
+});
@@ -0,0 +1,198 @@
+/**
+ * Tests for the project file systems detectors read through
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import {
+  DirectoryFileSystem,
+  MemoryFileSystem,
+  IgnoredFileSystem,
+  NormalizedFileSystem,
+  ProjectFileSystem,
+  RetryingFileSystem,
+  SubdirectoryFileSystem
+} from '../../../src/detection/utils/project-fs';
+import { IgnoreMatcher } from '../../../src/detection/utils/ignore-patterns';
+import { DockerDetector } from '../../../src/detection/docker-detector';
+import { LanguageDetector } from '../../../src/detection/language-detector';
+import { runDetectors } from '../../../src/detection/detector-registry';
+import { DetectionResult } from '../../../src/detection/interfaces/detection-result';
+import '../../../src/detection/node-workspace-detector';
+import '../../../src/detection/package-scripts-detector';
+import '../../../src/detection/test-runner-detector';
+import '../../../src/detection/docker-detector';
+
+describe('project file systems', () => {
+  let tempDir: string;
+
+  const files: Record<string, string> = {
+    'go.mod': 'module example.com/app\n',
+    'main.go': 'package main\n',
+    'cmd/worker/main.go': 'package main\n',
+    'deploy/Dockerfile': 'FROM golang:1.22 AS build\nFROM gcr.io/distroless/base\n'
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'project-fs-test-'));
+    for (const [file, content] of Object.entries(files)) {
+      fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+      fs.writeFileSync(path.join(tempDir, file), content);
+    }
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should list, read and check paths in memory like on disk', async () => {
+    for (const tree of [new DirectoryFileSystem(tempDir), new MemoryFileSystem(files)] as ProjectFileSystem[]) {
+      const listing = (await tree.readdir('.')).map(entry => `${entry.name}${entry.isDirectory() ? '/' : ''}`).sort();
+
+      expect(listing).toEqual(['cmd/', 'deploy/', 'go.mod', 'main.go']);
+      expect(await tree.readFile('cmd/worker/main.go')).toBe('package main\n');
+      expect(await tree.exists('cmd/worker')).toBe(true);
+      expect(await tree.exists('deploy/.dockerignore')).toBe(false);
+      await expect(tree.readFile('missing.txt')).rejects.toThrow();
+      await expect(tree.readdir('missing')).rejects.toThrow();
+    }
+  });
+
+  it('should let detectors run against an in-memory tree', async () => {
+    const memory = new MemoryFileSystem(files);
+
+    expect(await new DockerDetector().detect(memory)).toEqual(await new DockerDetector().detect(tempDir));
+    expect((await new LanguageDetector().detect(memory)).map(language => language.name)).toEqual(['Go']);
+  });
+
+  it('should normalize separators, the case of well-known files and byte order marks', async () => {
+    const tree = new NormalizedFileSystem(new MemoryFileSystem({
+      'Package.json': '\uFEFF{ "name": "app" }',
+      'docs/ReadMe.md': '# App\n',
+      'src/Main.ts': 'export {};\n'
+    }));
+
+    expect(JSON.parse(await tree.readFile('package.json'))).toEqual({ name: 'app' });
+    expect(await tree.readFile('docs\\README.md')).toBe('# App\n');
+    expect((await tree.readdir('docs\\')).map(entry => entry.name)).toEqual(['ReadMe.md']);
+    expect(await tree.exists('src/main.ts')).toBe(false);
+  });
+
+  it('should detect the same project whatever the case of its manifests and separators of its globs', async () => {
+    const emptyResult = (): DetectionResult => ({
+      frameworks: [],
+      buildTools: [],
+      containers: [],
+      confidence: {} as DetectionResult['confidence'],
+      alternatives: [],
+      warnings: [],
+      detectedAt: new Date(0),
+      executionTime: 0
+    });
+    const project = (names: Record<string, string>) => new MemoryFileSystem({
+      [names.manifest!]: '{ "name": "shop", "private": true, "scripts": { "build": "tsc -b", "test": "vitest run" } }',
+      [names.workspace!]: `packages:\n  - '${names.glob}'\n`,
+      [names.tsconfig!]: '{}',
+      [names.readme!]: '# Shop\n',
+      'packages/web/package.json': '{ "name": "@shop/web" }',
+      'packages/web/src/index.ts': 'export {};\n',
+      'packages/api/package.json': '{ "name": "@shop/api" }',
+      'packages/api/src/index.ts': 'export {};\n'
+    });
+
+    const expected = emptyResult();
+    await runDetectors(expected, project({
+      manifest: 'package.json', workspace: 'pnpm-workspace.yaml', tsconfig: 'tsconfig.json', readme: 'README.md', glob: 'packages/*'
+    }));
+    const varied = emptyResult();
+    await runDetectors(varied, project({
+      manifest: 'Package.JSON', workspace: 'PNPM-Workspace.yaml', tsconfig: 'TSConfig.json', readme: 'readme.md', glob: 'packages\\*'
+    }));
+
+    expect(expected.nodeWorkspace?.members.map(member => member.name)).toEqual(['@shop/api', '@shop/web']);
+    expect(varied).toEqual(expected);
+  });
+
+  it('should view a subdirectory as a tree of its own', async () => {
+    const subtree = new SubdirectoryFileSystem(new MemoryFileSystem(files), 'cmd');
+
+    expect((await subtree.readdir('.')).map(entry => entry.name)).toEqual(['worker']);
+    expect(await subtree.readFile('worker/main.go')).toBe('package main\n');
+    expect(await subtree.exists('go.mod')).toBe(false);
+  });
+
+  it('should hide what ignore patterns leave out without hiding the module at the root', async () => {
+    const tree = new IgnoredFileSystem(new MemoryFileSystem({
+      ...files,
+      'vendor/github.com/acme/lib/go.mod': 'module github.com/acme/lib\n',
+      'vendor/github.com/acme/lib/lib.go': 'package lib\n',
+      'pkg/vendor': 'not a directory\n'
+    }), new IgnoreMatcher(['vendor/']));
+
+    expect((await tree.readdir('.')).map(entry => entry.name)).toEqual(['cmd', 'deploy', 'go.mod', 'main.go', 'pkg']);
+    expect(await tree.exists('vendor')).toBe(false);
+    expect(await tree.exists('pkg/vendor')).toBe(true);
+    await expect(tree.readFile('vendor/github.com/acme/lib/go.mod')).rejects.toThrow();
+    await expect(tree.readdir('vendor/github.com')).rejects.toThrow();
+    expect((await new LanguageDetector().detect(tree)).map(language => language.name)).toEqual(['Go']);
+    expect(await new SubdirectoryFileSystem(tree, 'cmd').readFile('worker/main.go')).toBe('package main\n');
+  });
+
+  describe('retrying reads', () => {
+    const retry = { maxAttempts: 3, sleep: async () => {} };
+
+    /** Tree whose reads of a path fail with an error code as many times as given before succeeding */
+    function flaky(failures: Record<string, [string, number]>): ProjectFileSystem & { reads: string[] } {
+      const tree = new MemoryFileSystem(files);
+      const reads: string[] = [];
+      const fail = (path: string) => {
+        reads.push(path);
+        const failure = failures[path];
+        if (failure && failure[1]-- > 0) {
+          throw Object.assign(new Error(`${failure[0]}: failed to read '${path}'`), { code: failure[0] });
+        }
+      };
+      return {
+        root: tree.root,
+        reads,
+        readFile: async path => { fail(path); return tree.readFile(path); },
+        readdir: async path => { fail(path); return tree.readdir(path); },
+        exists: path => tree.exists(path)
+      };
+    }
+
+    it('should retry reads that fail transiently', async () => {
+      const tree = flaky({ 'go.mod': ['EMFILE', 2], cmd: ['EAGAIN', 1] });
+      const retrying = new RetryingFileSystem(tree, retry);
+
+      expect(await retrying.readFile('go.mod')).toBe(files['go.mod']);
+      expect((await retrying.readdir('cmd')).map(entry => entry.name)).toEqual(['worker']);
+      expect(tree.reads).toEqual(['go.mod', 'go.mod', 'go.mod', 'cmd', 'cmd']);
+      expect(retrying.warnings).toEqual([]);
+    });
+
+    it('should give up after the attempts and warn once, treating the file as missing', async () => {
+      const tree = flaky({ 'go.mod': ['EIO', Infinity] });
+      const retrying = new RetryingFileSystem(tree, retry);
+
+      await expect(retrying.readFile('go.mod')).rejects.toMatchObject({ code: 'EIO' });
+      await expect(retrying.readFile('go.mod')).rejects.toMatchObject({ code: 'EIO' });
+      expect(tree.reads.filter(read => read === 'go.mod')).toHaveLength(6);
+      expect(retrying.warnings).toMatchObject([{ code: 'FILE_READ_FAILED', path: 'go.mod' }]);
+      await expect(new LanguageDetector().detect(retrying)).resolves.toBeDefined();
+    });
+
+    it('should not retry or warn about files that do not exist', async () => {
+      const tree = flaky({});
+      const retrying = new RetryingFileSystem(tree, retry);
+
+      await expect(retrying.readFile('package.json')).rejects.toThrow('ENOENT');
+      expect(tree.reads).toEqual(['package.json']);
+      expect(retrying.warnings).toEqual([]);
+    });
+  });
+});
@@ -0,0 +1,47 @@
+/**
+ * Tests for ProtobufDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ProtobufDetector } from '../../../src/detection/protobuf-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('ProtobufDetector', () => {
+  const detector = new ProtobufDetector();
+
+  it('should find nothing without .proto files', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'README.md': '# service\n',
+      'buf.yaml': 'version: v2\n',
+      'node_modules/google-protobuf/google/protobuf/any.proto': 'syntax = "proto3";\n'
+    }))).toBeUndefined();
+  });
+
+  it('should find the .proto files and run buf from the root without configuration', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'api/v1/users.proto': 'syntax = "proto3";\n',
+      'api/v1/orders.proto': 'syntax = "proto3";\n',
+      'vendor/github.com/gogo/protobuf/gogo.proto': 'syntax = "proto2";\n'
+    }))).toEqual({
+      protoFiles: ['api/v1/orders.proto', 'api/v1/users.proto'],
+      bufDirectory: '.'
+    });
+  });
+
+  it('should run buf where the configuration closest to the root is, preferring a workspace', async () => {
+    const protobuf = await detector.detect(new MemoryFileSystem({
+      'proto/buf.work.yaml': 'version: v1\ndirectories:\n  - acme\n',
+      'proto/buf.yaml': 'version: v1\n',
+      'proto/acme/buf.yaml': 'version: v1\n',
+      'proto/acme/pets/v1/pets.proto': 'syntax = "proto3";\n',
+      'buf.gen.yaml': 'version: v1\nplugins:\n  - plugin: buf.build/protocolbuffers/go\n    out: gen/go\n'
+    }));
+
+    expect(protobuf).toEqual({
+      protoFiles: ['proto/acme/pets/v1/pets.proto'],
+      bufDirectory: 'proto',
+      bufConfig: 'proto/buf.work.yaml',
+      bufGenConfig: 'buf.gen.yaml'
+    });
+  });
+});
@@ -0,0 +1,67 @@
+/**
+ * Tests for PythonLayoutDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { PythonLayoutDetector } from '../../../src/detection/python-layout-detector';
+
+describe('PythonLayoutDetector', () => {
+  let tempDir: string;
+  let detector: PythonLayoutDetector;
+
+  const writeFile = (file: string, content: string = '') => {
+    fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, file), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'python-layout-detector-test-'));
+    detector = new PythonLayoutDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should ignore projects without a Python manifest', async () => {
+    writeFile('src/app/__init__.py');
+
+    expect(await detector.detect(tempDir)).toBeUndefined();
+  });
+
+  it('should detect a src layout, including namespace packages', async () => {
+    writeFile('pyproject.toml', '[project]\nname = "acme"\n');
+    writeFile('src/acme/__init__.py');
+    writeFile('src/plugins/loader.py');
+    writeFile('tests/test_acme.py');
+
+    expect(await detector.detect(tempDir)).toEqual({
+      layout: 'src',
+      packages: ['acme', 'plugins'],
+      namespacePackages: ['plugins'],
+      installable: true,
+      requirementsFile: false
+    });
+  });
+
+  it('should detect a flat layout and skip test and tooling directories', async () => {
+    writeFile('requirements.txt', 'requests\n');
+    writeFile('app/__init__.py');
+    writeFile('tests/__init__.py');
+    writeFile('scripts/release.py');
+    writeFile('docs/conf.py');
+
+    expect(await detector.detect(tempDir)).toEqual({
+      layout: 'flat',
+      packages: ['app'],
+      namespacePackages: [],
+      installable: false,
+      requirementsFile: true
+    });
+  });
+});
@@ -0,0 +1,63 @@
+/**
+ * Tests for RubyDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { RubyDetector } from '../../../src/detection/ruby-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+const RAILS_GEMFILE = [
+  "source 'https://rubygems.org'",
+  '',
+  "ruby '3.2.2'",
+  '',
+  "gem 'rails', '~> 7.1'",
+  "gem 'pg', '~> 1.5'",
+  "# gem 'sqlite3'",
+  '',
+  'group :development, :test do',
+  "  gem 'rspec-rails'",
+  "  gem 'rubocop-rails', require: false",
+  'end'
+].join('\n');
+
+describe('RubyDetector', () => {
+  const detector = new RubyDetector();
+
+  it('should find nothing without a Gemfile, .ruby-version or gemspec', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'Rakefile': 'task default: :test\n' }))).toBeUndefined();
+  });
+
+  it('should read the ruby directive and the rspec, rubocop and database gems of a Rails app', async () => {
+    const rubyProject = await detector.detect(new MemoryFileSystem({ 'Gemfile': RAILS_GEMFILE, 'Rakefile': '' }));
+
+    expect(rubyProject).toEqual({
+      rubyVersion: '3.2.2',
+      versionSource: 'Gemfile',
+      rspec: true,
+      rake: true,
+      rubocop: true,
+      rails: true,
+      database: 'postgres'
+    });
+  });
+
+  it('should prefer .ruby-version and read the dependencies of a gemspec', async () => {
+    const rubyProject = await detector.detect(new MemoryFileSystem({
+      'Gemfile': "source 'https://rubygems.org'\ngemspec\n",
+      '.ruby-version': 'ruby-3.3.0\n',
+      'tiny.gemspec': "Gem::Specification.new do |spec|\n  spec.add_development_dependency 'minitest', '~> 5.0'\nend\n",
+      'Rakefile': "require 'rake/testtask'\n"
+    }));
+
+    expect(rubyProject).toEqual({
+      rubyVersion: '3.3.0',
+      versionSource: '.ruby-version',
+      gemspec: 'tiny.gemspec',
+      rspec: false,
+      rake: true,
+      rubocop: false,
+      rails: false
+    });
+  });
+});
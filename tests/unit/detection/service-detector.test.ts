@@ -0,0 +1,60 @@
+/**
+ * Tests for ServiceDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ServiceDetector } from '../../../src/detection/service-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('ServiceDetector', () => {
+  const detector = new ServiceDetector();
+
+  it('should find no services without a compose file or README requirements', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'README.md': '# App\n\nUses Redis for caching.\n' }))).toEqual([]);
+  });
+
+  it('should read service images, ports and environment from docker-compose.yml', async () => {
+    const services = await detector.detect(new MemoryFileSystem({
+      'docker-compose.yml': [
+        'services:',
+        '  app:',
+        '    build: .',
+        '  db:',
+        '    image: docker.io/library/postgres:15-alpine',
+        '    ports: ["127.0.0.1:5433:5432/tcp"]',
+        '    environment:',
+        '      POSTGRES_USER: app',
+        '      POSTGRES_PASSWORD: ${DB_PASSWORD:-secret}',
+        '      POSTGRES_DB: ${DB_NAME}',
+        '  cache:',
+        '    image: redis:${REDIS_VERSION}',
+        '    environment: [REDIS_ARGS=--save ""]',
+        '  mail:',
+        '    image: mailhog/mailhog'
+      ].join('\n')
+    }));
+
+    expect(services).toEqual([
+      {
+        name: 'postgres',
+        image: 'docker.io/library/postgres:15-alpine',
+        ports: [5432],
+        env: { POSTGRES_USER: 'app', POSTGRES_PASSWORD: 'secret' },
+        source: 'docker-compose.yml'
+      },
+      { name: 'redis', image: 'redis', ports: [], env: { REDIS_ARGS: '--save ""' }, source: 'docker-compose.yml' }
+    ]);
+  });
+
+  it('should add the README requirements the compose file does not run', async () => {
+    const services = await detector.detect(new MemoryFileSystem({
+      'compose.yaml': 'services:\n  db:\n    image: mysql:8.4\n',
+      'README.md': '# App\n\n## Requirements\n\n- MySQL 8\n- RabbitMQ 3.13\n- Docker\n'
+    }));
+
+    expect(services.map(service => [service.name, service.image || service.version, service.source])).toEqual([
+      ['mysql', 'mysql:8.4', 'compose.yaml'],
+      ['rabbitmq', '3.13', 'README.md']
+    ]);
+  });
+});
@@ -0,0 +1,47 @@
+/**
+ * Tests for ShellDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { ShellDetector } from '../../../src/detection/shell-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('ShellDetector', () => {
+  const detector = new ShellDetector();
+
+  it('should find nothing without scripts or when another language has a manifest', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'README.md': '# dotfiles\n' }))).toBeUndefined();
+    expect(await detector.detect(new MemoryFileSystem({
+      'go.mod': 'module example.com/tool\n',
+      'main.go': 'package main\n',
+      'scripts/release.sh': '#!/bin/sh\n'
+    }))).toBeUndefined();
+  });
+
+  it('should find scripts by extension and by shebang and the directories of the bats tests', async () => {
+    const shellProject = await detector.detect(new MemoryFileSystem({
+      'install.sh': 'echo install\n',
+      'bin/deploy': '#!/usr/bin/env bash\nset -euo pipefail\n',
+      'bin/prompt': '#!/bin/zsh\n',
+      'bin/notes': 'plain text\n',
+      'lib/common.bash': '',
+      'test/deploy.bats': '@test "deploys" { true; }\n',
+      'node_modules/tool/run.sh': ''
+    }));
+
+    expect(shellProject).toEqual({
+      scripts: ['bin/deploy', 'install.sh', 'lib/common.bash'],
+      batsTests: ['test'],
+      shfmt: false
+    });
+  });
+
+  it('should tell shfmt formats the scripts from its .editorconfig properties', async () => {
+    const shellProject = await detector.detect(new MemoryFileSystem({
+      '.editorconfig': 'root = true\n\n[*.sh]\nindent_style = space\nswitch_case_indent = true\n',
+      'run.sh': '#!/bin/sh\n'
+    }));
+
+    expect(shellProject?.shfmt).toBe(true);
+  });
+});
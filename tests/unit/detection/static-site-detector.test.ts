@@ -0,0 +1,62 @@
+/**
+ * Tests for StaticSiteDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { StaticSiteDetector } from '../../../src/detection/static-site-detector';
+
+describe('StaticSiteDetector', () => {
+  let tempDir: string;
+  let detector: StaticSiteDetector;
+
+  const writeFile = (file: string, content: string) => {
+    fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, file), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'static-site-detector-test-'));
+    detector = new StaticSiteDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should detect generators from their config files with default output directories', async () => {
+    writeFile('mkdocs.yml', 'site_name: Docs\n');
+    expect(await detector.detect(tempDir)).toEqual({ generator: 'mkdocs', configFile: 'mkdocs.yml', outputDir: 'site' });
+
+    writeFile('docs/.vitepress/config.ts', 'export default {}\n');
+    expect(await detector.detect(tempDir)).toEqual({
+      generator: 'vitepress',
+      configFile: 'docs/.vitepress/config.ts',
+      outputDir: 'docs/.vitepress/dist'
+    });
+
+    writeFile('_config.yml', 'title: Blog\n');
+    expect(await detector.detect(tempDir)).toMatchObject({ generator: 'jekyll', outputDir: '_site' });
+
+    writeFile('hugo.toml', 'baseURL = "https://example.org/"\n');
+    expect(await detector.detect(tempDir)).toMatchObject({ generator: 'hugo', outputDir: 'public' });
+  });
+
+  it('should read output directories moved in the config file', async () => {
+    writeFile('hugo.toml', 'baseURL = "https://example.org/"\npublishDir = "dist/"\n');
+
+    expect(await detector.detect(tempDir)).toMatchObject({ generator: 'hugo', outputDir: 'dist' });
+  });
+
+  it('should only treat Next.js apps with a static export as sites', async () => {
+    writeFile('next.config.js', 'module.exports = { reactStrictMode: true };\n');
+    expect(await detector.detect(tempDir)).toBeUndefined();
+
+    writeFile('next.config.js', "module.exports = { output: 'export' };\n");
+    expect(await detector.detect(tempDir)).toEqual({ generator: 'nextjs', configFile: 'next.config.js', outputDir: 'out' });
+  });
+});
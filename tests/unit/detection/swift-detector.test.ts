@@ -0,0 +1,52 @@
+/**
+ * Tests for SwiftDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { SwiftDetector } from '../../../src/detection/swift-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+const LIBRARY_MANIFEST = [
+  '// swift-tools-version:5.9',
+  'import PackageDescription',
+  '',
+  'let package = Package(',
+  '    name: "Parsing",',
+  '    products: [',
+  '        .library(name: "Parsing", targets: ["Parsing"]),',
+  '    ],',
+  '    dependencies: [',
+  '        .package(url: "https://github.com/apple/swift-argument-parser", from: "1.3.0"), // .executable(name: "ignored")',
+  '    ],',
+  '    targets: [',
+  '        .target(name: "Parsing"),',
+  '        .executableTarget(name: "parse", dependencies: ["Parsing"]),',
+  '        .testTarget(name: "ParsingTests", dependencies: ["Parsing"]),',
+  '    ]',
+  ')'
+].join('\n');
+
+describe('SwiftDetector', () => {
+  const detector = new SwiftDetector();
+
+  it('should find nothing without a Package.swift', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'main.swift': 'print("hello")\n' }))).toBeUndefined();
+  });
+
+  it('should read the tools version and the executable, library and test targets', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'Package.swift': LIBRARY_MANIFEST }))).toEqual({
+      toolsVersion: '5.9',
+      executables: ['parse'],
+      libraries: ['Parsing'],
+      testTargets: ['ParsingTests']
+    });
+  });
+
+  it('should find no test targets in a package of executables alone', async () => {
+    const swiftPackage = await detector.detect(new MemoryFileSystem({
+      'Package.swift': '// swift-tools-version: 6.0\nimport PackageDescription\n\nlet package = Package(\n    name: "tool",\n    targets: [.executableTarget(name: "tool")]\n)\n'
+    }));
+
+    expect(swiftPackage).toEqual({ toolsVersion: '6.0', executables: ['tool'], libraries: [], testTargets: [] });
+  });
+});
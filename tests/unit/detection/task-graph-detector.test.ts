@@ -0,0 +1,46 @@
+/**
+ * Tests for TaskGraphDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { TaskGraphDetector } from '../../../src/detection/task-graph-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('TaskGraphDetector', () => {
+  const detector = new TaskGraphDetector();
+
+  it('should find nothing without nx.json or turbo.json', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'package.json': '{"workspaces": ["packages/*"]}' }))).toBeUndefined();
+  });
+
+  it('should read the nx target defaults, leaving out executors and dev servers', async () => {
+    const taskGraph = await detector.detect(new MemoryFileSystem({
+      'nx.json': JSON.stringify({
+        targetDefaults: { build: { dependsOn: ['^build'] }, test: {}, 'e2e-ci': {}, serve: {}, '@nx/vite:test': { cache: true } }
+      })
+    }));
+
+    expect(taskGraph).toEqual({ tool: 'nx', configFile: 'nx.json', tasks: ['build', 'test', 'e2e-ci'] });
+    expect((await detector.detect(new MemoryFileSystem({ 'nx.json': '{}' })))?.tasks).toEqual(['build', 'test', 'lint']);
+  });
+
+  it('should read the turbo tasks from a commented turbo.json, skipping persistent and package tasks', async () => {
+    const taskGraph = await detector.detect(new MemoryFileSystem({
+      'turbo.json': [
+        '{',
+        '  "$schema": "https://turbo.build/schema.json",',
+        '  // Build before testing',
+        '  "tasks": {',
+        '    "build": { "outputs": ["dist/**"] },',
+        '    "web#build": { "outputs": [".next/**"] },',
+        '    "check-types": {}, /* tsc --noEmit */',
+        '    "test": { "dependsOn": ["build"] },',
+        '    "storybook": { "persistent": true, "cache": false }',
+        '  }',
+        '}'
+      ].join('\n')
+    }));
+
+    expect(taskGraph).toEqual({ tool: 'turbo', configFile: 'turbo.json', tasks: ['build', 'check-types', 'test'] });
+  });
+});
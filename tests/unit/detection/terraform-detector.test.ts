@@ -0,0 +1,32 @@
+/**
+ * Tests for TerraformDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { TerraformDetector } from '../../../src/detection/terraform-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('TerraformDetector', () => {
+  const detector = new TerraformDetector();
+
+  it('should find nothing without .tf files', async () => {
+    expect(await detector.detect(new MemoryFileSystem({ 'main.go': 'package main\n', 'terraform.md': '# Notes' }))).toBeUndefined();
+  });
+
+  it('should find root modules and read required_version', async () => {
+    const terraform = await detector.detect(new MemoryFileSystem({
+      'infra/prod/main.tf': 'module "network" {\n  source = "../modules/network"\n}\n',
+      'infra/prod/versions.tf': [
+        'terraform {',
+        '  # required_version = "0.12"',
+        '  required_version = "~> 1.6"',
+        '}'
+      ].join('\n'),
+      'infra/staging/main.tf': 'resource "null_resource" "noop" {}\n',
+      'infra/modules/network/main.tf': 'variable "cidr" {}\n',
+      'infra/prod/.terraform/modules/network/main.tf': 'variable "cidr" {}\n'
+    }));
+
+    expect(terraform).toEqual({ directories: ['infra/prod', 'infra/staging'], requiredVersion: '~> 1.6' });
+  });
+});
@@ -0,0 +1,97 @@
+/**
+ * Tests for TestRunnerDetector
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { TestRunnerDetector } from '../../../src/detection/test-runner-detector';
+
+describe('TestRunnerDetector', () => {
+  let tempDir: string;
+  let detector: TestRunnerDetector;
+
+  const writeFile = (relativePath: string, content: string): void => {
+    const fullPath = path.join(tempDir, relativePath);
+    fs.mkdirSync(path.dirname(fullPath), { recursive: true });
+    fs.writeFileSync(fullPath, content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'test-runner-detector-test-'));
+    detector = new TestRunnerDetector();
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should suppress pytest when tox runs it', async () => {
+    writeFile('tox.ini', '[tox]\nenvlist = py311\n\n[testenv]\ndeps = pytest\ncommands = pytest\n');
+    writeFile('pytest.ini', '[pytest]\ntestpaths = tests\n');
+
+    const { runners, warnings } = await detector.detect(tempDir);
+
+    expect(runners.map(runner => [runner.name, runner.suppressedBy])).toEqual([
+      ['tox', undefined],
+      ['pytest', 'tox']
+    ]);
+    expect(runners[0]!.setup).toEqual(['pip install tox']);
+    expect(warnings).toHaveLength(1);
+    expect(warnings[0]).toMatchObject({ type: 'conflict', affected: ['pytest', 'tox'] });
+  });
+
+  it('should not treat the [pytest] section of tox.ini as tox running pytest', async () => {
+    writeFile('tox.ini', '[tox]\nenvlist = lint\n\n[testenv:lint]\ncommands = flake8\n\n[pytest]\naddopts = -q\n');
+
+    const { runners, warnings } = await detector.detect(tempDir);
+
+    expect(runners.map(runner => runner.name)).toEqual(['tox', 'pytest']);
+    expect(runners[1]!.suppressedBy).toBeUndefined();
+    expect(runners[1]!.setup).toEqual(['pip install pytest']);
+    expect(warnings).toEqual([]);
+  });
+
+  it('should detect every configured Node.js runner', async () => {
+    writeFile('package.json', JSON.stringify({ devDependencies: { jest: '^29.0.0' } }));
+    writeFile('vitest.config.ts', 'export default {};\n');
+
+    const { runners } = await detector.detect(tempDir);
+
+    expect(runners.map(runner => [runner.name, runner.command, runner.source])).toEqual([
+      ['jest', 'npx jest', 'package.json'],
+      ['vitest', 'npx vitest run', 'vitest.config.ts']
+    ]);
+  });
+
+  it('should prefer nextest over cargo test', async () => {
+    writeFile('Cargo.toml', '[package]\nname = "demo"\n');
+    writeFile('.config/nextest.toml', '[profile.default]\n');
+
+    const { runners } = await detector.detect(tempDir);
+
+    expect(runners.map(runner => [runner.name, runner.suppressedBy])).toEqual([
+      ['nextest', undefined],
+      ['cargo test', 'nextest']
+    ]);
+  });
+
+  it('should prefer rspec over the Rakefile of a Ruby project', async () => {
+    writeFile('Gemfile', "source 'https://rubygems.org'\ngem 'rake'\n");
+    writeFile('Rakefile', 'task default: :test\n');
+
+    expect((await detector.detect(tempDir)).runners.map(runner => runner.command)).toEqual(['bundle exec rake']);
+
+    writeFile('.rspec', '--require spec_helper\n');
+    expect((await detector.detect(tempDir)).runners.map(runner => runner.command)).toEqual(['bundle exec rspec']);
+  });
+
+  it('should report nothing for a project without test configuration', async () => {
+    writeFile('README.md', '# demo\n');
+
+    expect(await detector.detect(tempDir)).toEqual({ runners: [], warnings: [] });
+  });
+});
@@ -0,0 +1,83 @@
+/**
+ * Tests for manifest version constraint parsing
+ */
+
+import { describe, it, expect } from 'vitest';
+import { parseVersionConstraint, satisfiesVersionLine } from '../../../src/detection/utils/version-constraint';
+
+describe('parseVersionConstraint', () => {
+  describe('engines.node', () => {
+    it('should treat a full version as an exact pin', () => {
+      const constraint = parseVersionConstraint('node', '20.11.1', 'package.json');
+
+      expect(constraint.exact).toBe('20.11.1');
+      expect(constraint.versions).toEqual(['20.11.1']);
+      expect(constraint.error).toBeUndefined();
+    });
+
+    it('should resolve open ranges to every supported major', () => {
+      expect(parseVersionConstraint('node', '>=18', 'package.json').versions).toEqual(['18', '20', '22', '24']);
+    });
+
+    it('should bound caret, tilde and x-ranges to their major', () => {
+      expect(parseVersionConstraint('node', '^18.17.0', 'package.json').versions).toEqual(['18']);
+      expect(parseVersionConstraint('node', '20.x', 'package.json').versions).toEqual(['20']);
+      expect(parseVersionConstraint('node', '~20.1', 'package.json').versions).toEqual(['20.1']);
+    });
+
+    it('should union || alternatives and honour hyphen ranges', () => {
+      expect(parseVersionConstraint('node', '^18 || ^22', 'package.json').versions).toEqual(['18', '22']);
+      expect(parseVersionConstraint('node', '16 - 20', 'package.json').versions).toEqual(['16', '18', '20']);
+    });
+  });
+
+  describe('requires-python', () => {
+    it('should apply every comma-separated specifier', () => {
+      expect(parseVersionConstraint('python', '>=3.9,<3.12', 'pyproject.toml').versions)
+        .toEqual(['3.9', '3.10', '3.11']);
+    });
+
+    it('should support compatible release and exclusions', () => {
+      expect(parseVersionConstraint('python', '~=3.10', 'pyproject.toml').versions)
+        .toEqual(['3.10', '3.11', '3.12', '3.13']);
+      expect(parseVersionConstraint('python', '>=3.10, !=3.11.*', 'pyproject.toml').versions)
+        .toEqual(['3.10', '3.12', '3.13']);
+    });
+  });
+
+  describe('go directive', () => {
+    it('should track the declared line rather than pinning the patch', () => {
+      const constraint = parseVersionConstraint('go', '1.21.0', 'go.mod');
+
+      expect(constraint.exact).toBeUndefined();
+      expect(constraint.versions).toEqual(['1.21']);
+    });
+
+    it('should reject a directive without a minor version', () => {
+      expect(parseVersionConstraint('go', '1', 'go.mod').error).toContain('expected a major.minor version');
+    });
+  });
+
+  describe('invalid constraints', () => {
+    it('should report unparseable input instead of throwing', () => {
+      const constraint = parseVersionConstraint('node', 'banana', 'package.json');
+
+      expect(constraint.versions).toEqual([]);
+      expect(constraint.error).toBe("Invalid node version constraint 'banana' in package.json: unrecognised version 'banana'");
+    });
+
+    it('should report ranges no supported release satisfies', () => {
+      const constraint = parseVersionConstraint('node', '>=30', 'package.json');
+
+      expect(constraint.error).toBe("No supported node release satisfies '>=30' in package.json");
+    });
+  });
+});
+
+describe('satisfiesVersionLine', () => {
+  it('should never match an invalid constraint', () => {
+    const constraint = parseVersionConstraint('python', 'latest', 'pyproject.toml');
+
+    expect(satisfiesVersionLine(constraint, [3, 12])).toBe(false);
+  });
+});
@@ -0,0 +1,34 @@
+/**
+ * Tests for WorkingDirectoryDetector
+ */
+
+import { describe, it, expect } from 'vitest';
+import { WorkingDirectoryDetector } from '../../../src/detection/working-directory-detector';
+import { MemoryFileSystem } from '../../../src/detection/utils/project-fs';
+
+describe('WorkingDirectoryDetector', () => {
+  const detector = new WorkingDirectoryDetector();
+
+  it('should find the one subdirectory holding the manifests', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'README.md': '# App\n',
+      'docs/index.md': '# Docs\n',
+      'app/package.json': '{"name": "app"}',
+      'app/src/index.js': 'console.log(1);\n'
+    }))).toBe('app');
+  });
+
+  it('should leave projects with manifests at the root alone', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'go.mod': 'module example.com/app\n',
+      'web/package.json': '{"name": "web"}'
+    }))).toBeUndefined();
+  });
+
+  it('should not pick between several subdirectories', async () => {
+    expect(await detector.detect(new MemoryFileSystem({
+      'api/go.mod': 'module example.com/api\n',
+      'web/package.json': '{"name": "web"}'
+    }))).toBeUndefined();
+  });
+});
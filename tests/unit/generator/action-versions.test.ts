@@ -0,0 +1,147 @@
+/**
+ * Unit tests for the action version registry
+ */
+
+import { describe, it, expect } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import { ACTION_VERSIONS, DEPRECATED_ACTIONS, applyActionVersions, findPinnableActions, resolveActionVersion } from '../../../src/generator/utils/action-versions';
+import { YAMLGeneratorImpl } from '../../../src/generator/yaml-generator';
+import { DetectionResult } from '../../../src/generator/interfaces';
+
+const CHECKOUT_SHA = 'b4ffde65f46336ab88eb53be808477a3936bae11';
+
+const workflow = [
+  'jobs:',
+  '  test:',
+  '    steps:',
+  '      - uses: actions/checkout@v4',
+  "      - uses: 'actions/setup-python@v4' # keep in sync with .python-version",
+  '      - uses: dtolnay/rust-toolchain@stable',
+  '      - uses: github/codeql-action/init@v3',
+  '      - uses: acme/deploy@v1',
+  ''
+].join('\n');
+
+/**
+ * Action paths referenced by the generator's sources
+ */
+function findGeneratedActions(directory: string): string[] {
+  const actions = new Set<string>();
+  for (const entry of fs.readdirSync(directory, { withFileTypes: true })) {
+    const entryPath = path.join(directory, entry.name);
+    if (entry.isDirectory()) {
+      findGeneratedActions(entryPath).forEach(action => actions.add(action));
+    } else if (entry.name.endsWith('.ts')) {
+      for (const match of fs.readFileSync(entryPath, 'utf8').matchAll(/uses: *['"`]?([\w.-]+\/[\w./-]+)@/g)) {
+        actions.add(match[1]!);
+      }
+    }
+  }
+  return [...actions];
+}
+
+describe('ACTION_VERSIONS', () => {
+  it('should have a ref for every action the generator uses', () => {
+    const unknown = findGeneratedActions(path.join(__dirname, '../../../src/generator'))
+      .filter(action => !resolveActionVersion(action));
+
+    expect(unknown).toEqual([]);
+  });
+
+  it('should not use deprecated actions in generated workflows', () => {
+    const deprecated = [
+      ...findGeneratedActions(path.join(__dirname, '../../../src/generator')),
+      ...findGeneratedActions(path.join(__dirname, '../../../src/detection/templates'))
+    ].filter(action => DEPRECATED_ACTIONS[action.split('/').slice(0, 2).join('/')]);
+
+    expect(deprecated).toEqual([]);
+    expect(Object.keys(DEPRECATED_ACTIONS).filter(repository => ACTION_VERSIONS[repository])).toEqual([]);
+  });
+});
+
+describe('applyActionVersions', () => {
+  it('should move actions to the registry refs and leave unknown actions and comments as they are', () => {
+    const result = applyActionVersions(workflow);
+
+    expect(ACTION_VERSIONS['actions/setup-python']).toBe('v5');
+    expect(result.content).toBe(workflow.replace("'actions/setup-python@v4'", "'actions/setup-python@v5'"));
+    expect(result.warnings).toEqual([]);
+  });
+
+  it('should use the refs and mirrors overrides name, by action path or repository', () => {
+    const { content } = applyActionVersions(workflow, {
+      overrides: {
+        'actions/checkout': 'v4.2.2',
+        'github/codeql-action': 'mirror/codeql-action@v3',
+        'acme/deploy': 'acme/deploy@v2'
+      }
+    });
+
+    expect(content).toContain('- uses: actions/checkout@v4.2.2\n');
+    expect(content).toContain('- uses: mirror/codeql-action/init@v3\n');
+    expect(content).toContain('- uses: acme/deploy@v2\n');
+  });
+
+  it('should pin release tags of known actions, but not mirrors, branches or actions it does not know', () => {
+    const overrides = { 'actions/setup-python': 'mirror/setup-python@v5' };
+    const shas = { 'actions/checkout@v4': CHECKOUT_SHA };
+
+    expect(findPinnableActions(workflow, overrides)).toEqual(['actions/checkout@v4', 'github/codeql-action/init@v3']);
+
+    const result = applyActionVersions(workflow, { overrides, pin: true, shas });
+    expect(result.content).toContain(`- uses: actions/checkout@${CHECKOUT_SHA} # v4\n`);
+    expect(result.content).toContain("- uses: 'mirror/setup-python@v5' # keep in sync with .python-version\n");
+    expect(result.content).toContain('- uses: dtolnay/rust-toolchain@stable\n');
+    expect(result.content).toContain('- uses: acme/deploy@v1\n');
+    expect(result.warnings).toEqual(['github/codeql-action/init@v3 is not pinned - no commit SHA is known for it']);
+
+    // Pinned lines are left alone, so pinning twice changes nothing
+    expect(applyActionVersions(result.content, { overrides, pin: true, shas }).content).toBe(result.content);
+  });
+
+  it('should report deprecated actions with their replacement and leave their ref', () => {
+    const deprecated = [
+      '    steps:',
+      '      - uses: actions-rs/toolchain@v1',
+      `      - uses: SonarSource/sonarcloud-github-action@${CHECKOUT_SHA} # master`,
+      '      - uses: actions-rs/toolchain@v1'
+    ].join('\n');
+    const result = applyActionVersions(deprecated, { pin: true });
+
+    expect(result.content).toBe(deprecated);
+    expect(result.warnings).toEqual([
+      'actions-rs/toolchain@v1 is deprecated (archived) - use dtolnay/rust-toolchain instead',
+      `SonarSource/sonarcloud-github-action@${CHECKOUT_SHA} is deprecated (superseded) - use SonarSource/sonarqube-scan-action instead`
+    ]);
+  });
+});
+
+describe('YAMLGeneratorImpl with pinActions', () => {
+  it('should pin the actions of generated workflows to the SHAs resolveActionSha finds', async () => {
+    const detectionResult: DetectionResult = {
+      frameworks: [],
+      languages: [{ name: 'JavaScript', version: '20', confidence: 0.95, primary: true }],
+      buildTools: [],
+      packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+      testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'test-project' }
+    };
+    const resolved: string[] = [];
+
+    const workflow = await new YAMLGeneratorImpl().generateWorkflow(detectionResult, {
+      workflowType: 'ci',
+      pinActions: true,
+      resolveActionSha: (repository, ref) => {
+        resolved.push(`${repository}@${ref}`);
+        return repository === 'actions/checkout' ? CHECKOUT_SHA : undefined;
+      }
+    });
+
+    expect(workflow.content).toContain(`uses: actions/checkout@${CHECKOUT_SHA} # v4`);
+    expect(workflow.content).not.toContain('actions/checkout@v4');
+    expect(resolved).toContain('actions/setup-node@v4');
+    expect(workflow.metadata.warnings).toContain('actions/setup-node@v4 is not pinned - no commit SHA is known for it');
+  });
+});
@@ -0,0 +1,280 @@
+/**
+ * Unit tests for Azure Pipelines Renderer
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import * as yaml from 'js-yaml';
+import { AzurePipelinesRenderer } from '../../../src/generator/renderers/azure-pipelines-renderer';
+import { FormattingOptions } from '../../../src/generator/renderers/renderer-types';
+import { CIWorkflowGenerator } from '../../../src/generator/workflow-specialization';
+import { WorkflowTemplate, WorkflowType } from '../../../src/generator/types';
+import { DetectionResult, GenerationOptions, Provider } from '../../../src/generator/interfaces';
+
+describe('AzurePipelinesRenderer', () => {
+  let renderer: AzurePipelinesRenderer;
+  let formattingOptions: FormattingOptions;
+  let nodeDetection: DetectionResult;
+  let sampleWorkflow: WorkflowTemplate;
+
+  beforeEach(() => {
+    formattingOptions = {
+      yamlConfig: {
+        indent: 2,
+        lineWidth: 120,
+        noRefs: true,
+        noCompatMode: true,
+        condenseFlow: false,
+        quotingType: 'auto',
+        forceQuotes: false,
+        sortKeys: false
+      },
+      commentConfig: {
+        enabled: false,
+        includeGenerationInfo: false,
+        includeStepDescriptions: false,
+        includeOptimizationNotes: false,
+        customComments: {}
+      },
+      preserveComments: false,
+      addBlankLines: false
+    };
+
+    renderer = new AzurePipelinesRenderer(formattingOptions);
+
+    nodeDetection = {
+      frameworks: [],
+      languages: [{ name: 'JavaScript', version: '20', confidence: 0.95, primary: true }],
+      buildTools: [],
+      packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+      testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'test-project' }
+    };
+
+    sampleWorkflow = {
+      name: 'CI Pipeline',
+      type: 'ci' as WorkflowType,
+      triggers: {
+        push: { branches: ['main'] },
+        pullRequest: { branches: ['main'] }
+      },
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Setup Node.js', uses: 'actions/setup-node@v4', with: { 'node-version': '20' } },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Build', run: 'npm run build' },
+            {
+              name: 'Upload build artifacts',
+              uses: 'actions/upload-artifact@v4',
+              with: { name: 'dist', path: 'dist/' }
+            }
+          ]
+        },
+        {
+          name: 'unit-tests',
+          runsOn: 'macos-latest',
+          needs: ['build'],
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Run tests', run: 'npm test' }
+          ]
+        }
+      ]
+    };
+  });
+
+  const render = (workflow: WorkflowTemplate, detection: DetectionResult): any =>
+    yaml.load(renderer.renderWorkflow(workflow, detection).yaml);
+
+  it('should group jobs into stages in pipeline order', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+
+    expect(pipeline.stages.map((stage: any) => stage.stage)).toEqual(['build', 'test']);
+    const testJob = pipeline.stages[1].jobs[0];
+    expect(testJob.job).toBe('unit_tests');
+    expect(testJob.displayName).toBe('unit-tests');
+    expect(testJob).not.toHaveProperty('dependsOn');
+  });
+
+  it('should choose the vmImage from the runner OS', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+
+    expect(pipeline.stages[0].jobs[0].pool).toEqual({ vmImage: 'ubuntu-latest' });
+    expect(pipeline.stages[1].jobs[0].pool).toEqual({ vmImage: 'macOS-latest' });
+  });
+
+  it('should translate setup actions to tool installer tasks and cache on the lockfile', () => {
+    const steps = render(sampleWorkflow, nodeDetection).stages[0].jobs[0].steps;
+
+    expect(steps[0]).toEqual({ checkout: 'self' });
+    expect(steps[1]).toEqual({
+      task: 'Cache@2',
+      displayName: 'Cache dependencies',
+      inputs: {
+        key: 'npm | "$(Agent.OS)" | package-lock.json',
+        restoreKeys: 'npm | "$(Agent.OS)"',
+        path: '$(Pipeline.Workspace)/.npm'
+      }
+    });
+    expect(steps[2]).toEqual({ task: 'UseNode@1', displayName: 'Setup Node.js', inputs: { version: '20' } });
+    expect(steps[5]).toEqual({
+      task: 'PublishPipelineArtifact@1',
+      displayName: 'Upload build artifacts',
+      inputs: { targetPath: 'dist/', artifact: 'dist' }
+    });
+    expect(render(sampleWorkflow, nodeDetection).variables).toEqual({ npm_config_cache: '$(Pipeline.Workspace)/.npm' });
+  });
+
+  it('should keep run commands in the same order as the GitHub steps', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+    const githubRuns = sampleWorkflow.jobs[0]!.steps.filter(step => step.run).map(step => step.run);
+    const scripts = pipeline.stages[0].jobs[0].steps.filter((step: any) => step.script).map((step: any) => step.script);
+
+    expect(scripts).toEqual(githubRuns);
+  });
+
+  it('should map push and pull request triggers', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+    expect(pipeline.trigger).toEqual({ branches: { include: ['main'] } });
+    expect(pipeline.pr).toEqual({ branches: { include: ['main'] } });
+
+    const pushOnly = render({ ...sampleWorkflow, triggers: { push: { branches: ['main'], tags: ['v*'] } } }, nodeDetection);
+    expect(pushOnly.trigger).toEqual({ branches: { include: ['main'] }, tags: { include: ['v*'] } });
+    expect(pushOnly.pr).toBe('none');
+  });
+
+  it('should batch pushes and auto-cancel pull request builds when superseded runs are cancelled', () => {
+    const cancelling = render({ ...sampleWorkflow, concurrency: { group: 'ci', cancelInProgress: true } }, nodeDetection);
+    expect(cancelling.trigger).toEqual({ branches: { include: ['main'] }, batch: true });
+    expect(cancelling.pr).toEqual({ branches: { include: ['main'] }, autoCancel: true });
+
+    const completing = render({ ...sampleWorkflow, concurrency: { group: 'ci', cancelInProgress: false } }, nodeDetection);
+    expect(completing.trigger).toEqual({ branches: { include: ['main'] } });
+    expect(completing.pr).toEqual({ branches: { include: ['main'] }, autoCancel: false });
+  });
+
+  it('should expand matrix strategies into named entries with matching conditions', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'test',
+          runsOn: 'ubuntu-latest',
+          strategy: { matrix: { 'node-version': ['18', '20'] } },
+          steps: [
+            {
+              name: 'Setup Node.js',
+              uses: 'actions/setup-node@v4',
+              with: { 'node-version': '${{ matrix.node-version }}' }
+            },
+            { name: 'Run tests', run: 'npm test' },
+            { name: 'Report', run: 'npm run report', if: "matrix.node-version == '20'" }
+          ]
+        }
+      ]
+    };
+
+    const job = render(workflow, nodeDetection).stages[0].jobs[0];
+
+    expect(job.strategy.matrix).toEqual({
+      node_version_18: { node_version: '18' },
+      node_version_20: { node_version: '20' }
+    });
+    expect(job.steps[0].inputs).toEqual({ version: '$(node_version)' });
+    expect(job.steps[2].condition).toBe("and(succeeded(), eq(variables['node_version'], '20'))");
+  });
+
+  it('should warn about actions it cannot translate', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Custom action', uses: 'some-org/some-action@v1' },
+            { name: 'Build', run: 'npm run build' }
+          ]
+        }
+      ]
+    };
+
+    const result = renderer.renderWorkflow(workflow, nodeDetection);
+
+    expect(result.warnings.some(warning => warning.includes('some-org/some-action@v1'))).toBe(true);
+  });
+
+  describe('CIWorkflowGenerator integration', () => {
+    const options: GenerationOptions = {
+      workflowType: 'ci',
+      optimizationLevel: 'standard',
+      includeComments: true,
+      securityLevel: 'standard',
+      provider: Provider.AzurePipelines
+    };
+
+    const goDetection: DetectionResult = {
+      frameworks: [],
+      languages: [{ name: 'Go', version: '1.22', confidence: 0.95, primary: true }],
+      buildTools: [{ name: 'go', configFile: 'go.mod', confidence: 0.95 }],
+      packageManagers: [],
+      testingFrameworks: [{ name: 'go test', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'go-project' },
+      buildConstraints: {
+        platforms: [
+          { goos: 'darwin', goarch: 'arm64' },
+          { goos: 'linux', goarch: 'amd64' }
+        ],
+        tags: []
+      }
+    };
+
+    const findJob = (pipeline: any, name: string): any =>
+      pipeline.stages.flatMap((stage: any) => stage.jobs).find((job: any) => job.job === name);
+
+    it('should write azure-pipelines.yml when the azure provider is selected', async () => {
+      const generator = new CIWorkflowGenerator();
+      const result = await generator.generateCIWorkflow(nodeDetection, options);
+
+      expect(result.filename).toBe('azure-pipelines.yml');
+      expect(yaml.load(result.content)).toHaveProperty('stages');
+    });
+
+    it('should keep run order identical to the GitHub Actions output', async () => {
+      const generator = new CIWorkflowGenerator();
+      const azure = yaml.load((await generator.generateCIWorkflow(nodeDetection, options)).content) as any;
+      const github = yaml.load(
+        (await generator.generateCIWorkflow(nodeDetection, { ...options, provider: Provider.GitHubActions })).content
+      ) as any;
+
+      const githubRuns = github.jobs.build.steps
+        .filter((step: any) => step.run)
+        .map((step: any) => step.run);
+      const azureRuns = findJob(azure, 'build').steps
+        .filter((step: any) => step.script)
+        .map((step: any) => step.script);
+
+      expect(azureRuns).toEqual(githubRuns);
+    });
+
+    it('should install Go with GoTool and skip tests for cross-compiled targets', async () => {
+      const generator = new CIWorkflowGenerator();
+      const pipeline = yaml.load((await generator.generateCIWorkflow(goDetection, options)).content) as any;
+      const tests = findJob(pipeline, 'unit_tests');
+      const entries = Object.values(tests.strategy.matrix) as any[];
+
+      expect(tests.pool).toEqual({ vmImage: '$(runner)' });
+      expect(tests.steps).toContainEqual(expect.objectContaining({ task: 'GoTool@0', inputs: { version: '$(go_version)' } }));
+      expect(entries).toContainEqual(expect.objectContaining({ go_version: '1.22.0', goos: 'darwin', runner: 'macOS-latest', cross_compile: 'true' }));
+      expect(entries).toContainEqual(expect.objectContaining({ go_version: '1.22.0', goos: 'linux', runner: 'ubuntu-latest', cross_compile: 'false' }));
+      expect(tests.steps.find((step: any) => step.script?.startsWith('go test')).condition)
+        .toBe("and(succeeded(), ne(variables['cross_compile'], 'true'))");
+    });
+  });
+});
@@ -0,0 +1,259 @@
+/**
+ * Unit tests for Bitbucket Pipelines Renderer
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import * as yaml from 'js-yaml';
+import { BitbucketPipelinesRenderer } from '../../../src/generator/renderers/bitbucket-pipelines-renderer';
+import { FormattingOptions } from '../../../src/generator/renderers/renderer-types';
+import { CIWorkflowGenerator } from '../../../src/generator/workflow-specialization';
+import { WorkflowTemplate, WorkflowType } from '../../../src/generator/types';
+import { DetectionResult, GenerationOptions, Provider } from '../../../src/generator/interfaces';
+
+describe('BitbucketPipelinesRenderer', () => {
+  let renderer: BitbucketPipelinesRenderer;
+  let formattingOptions: FormattingOptions;
+  let nodeDetection: DetectionResult;
+  let sampleWorkflow: WorkflowTemplate;
+
+  beforeEach(() => {
+    formattingOptions = {
+      yamlConfig: {
+        indent: 2,
+        lineWidth: 120,
+        noRefs: true,
+        noCompatMode: true,
+        condenseFlow: false,
+        quotingType: 'auto',
+        forceQuotes: false,
+        sortKeys: false
+      },
+      commentConfig: {
+        enabled: false,
+        includeGenerationInfo: false,
+        includeStepDescriptions: false,
+        includeOptimizationNotes: false,
+        customComments: {}
+      },
+      preserveComments: false,
+      addBlankLines: false
+    };
+
+    renderer = new BitbucketPipelinesRenderer(formattingOptions);
+
+    nodeDetection = {
+      frameworks: [],
+      languages: [{ name: 'JavaScript', version: '20', confidence: 0.95, primary: true }],
+      buildTools: [],
+      packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+      testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'test-project' }
+    };
+
+    sampleWorkflow = {
+      name: 'CI Pipeline',
+      type: 'ci' as WorkflowType,
+      triggers: {
+        push: { branches: ['main'] },
+        pullRequest: { branches: ['main'] }
+      },
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Setup Node.js', uses: 'actions/setup-node@v4', with: { 'node-version': '20' } },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Build', run: 'npm run build' },
+            {
+              name: 'Upload build artifacts',
+              uses: 'actions/upload-artifact@v4',
+              with: { name: 'dist', path: 'dist/' }
+            }
+          ]
+        },
+        {
+          name: 'test',
+          runsOn: 'ubuntu-latest',
+          needs: ['build'],
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Run tests', run: 'npm test' }
+          ]
+        }
+      ]
+    };
+  });
+
+  const render = (workflow: WorkflowTemplate, detection: DetectionResult): any =>
+    yaml.load(renderer.renderWorkflow(workflow, detection).yaml);
+
+  it('should run branch and pull request pipelines with the steps in needs order', () => {
+    const config = render(sampleWorkflow, nodeDetection);
+
+    expect(config.image).toBe('node:20');
+    expect(Object.keys(config.pipelines)).toEqual(['branches', 'pull-requests']);
+    expect(config.pipelines.branches.main.map((entry: any) => entry.step.name)).toEqual(['build', 'test']);
+    expect(config.pipelines['pull-requests']['**']).toEqual(config.pipelines.branches.main);
+  });
+
+  it('should use the default pipeline when every pushed branch builds', () => {
+    const config = render({ ...sampleWorkflow, triggers: { push: {} } }, nodeDetection);
+
+    expect(Object.keys(config.pipelines)).toEqual(['default']);
+  });
+
+  it('should translate run steps to scripts with the built-in node cache and artifacts', () => {
+    const config = render(sampleWorkflow, nodeDetection);
+    const build = config.pipelines.branches.main[0].step;
+
+    expect(build).toEqual({
+      name: 'build',
+      caches: ['node'],
+      script: ['npm ci', 'npm run build'],
+      artifacts: ['dist/**']
+    });
+    expect(config).not.toHaveProperty('definitions');
+  });
+
+  it('should define custom caches keyed on the lockfile when Bitbucket has no built-in one', () => {
+    const pnpmDetection: DetectionResult = {
+      ...nodeDetection,
+      packageManagers: [{ name: 'pnpm', lockFile: 'pnpm-lock.yaml', confidence: 0.9 }]
+    };
+    const config = render(sampleWorkflow, pnpmDetection);
+
+    expect(config.definitions.caches).toEqual({
+      'pnpm-store': { key: { files: ['pnpm-lock.yaml'] }, path: '~/.local/share/pnpm/store' }
+    });
+    expect(config.pipelines.branches.main[0].step.caches).toEqual(['pnpm-store']);
+  });
+
+  it('should expand matrices into a parallel group with a step per combination', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'test',
+          runsOn: 'ubuntu-latest',
+          strategy: { matrix: { 'node-version': ['18', '20'] } },
+          env: { NODE_OPTIONS: '--max-old-space-size=4096' },
+          steps: [
+            {
+              name: 'Setup Node.js',
+              uses: 'actions/setup-node@v4',
+              with: { 'node-version': '${{ matrix.node-version }}' }
+            },
+            { name: 'Legacy tests', run: 'npm run test:legacy', if: "matrix.node-version == '18'" },
+            { name: 'Run tests', run: 'npm test -- --node ${{ matrix.node-version }}' }
+          ]
+        }
+      ]
+    };
+
+    const [group] = render(workflow, nodeDetection).pipelines.branches.main;
+
+    expect(group.parallel.map((entry: any) => entry.step)).toEqual([
+      {
+        name: 'test (18)',
+        image: 'node:18',
+        caches: ['node'],
+        script: ['export NODE_OPTIONS="--max-old-space-size=4096"', 'npm run test:legacy', 'npm test -- --node 18']
+      },
+      {
+        name: 'test (20)',
+        caches: ['node'],
+        script: ['export NODE_OPTIONS="--max-old-space-size=4096"', 'npm test -- --node 20']
+      }
+    ]);
+  });
+
+  it('should define services once and run always() steps in after-script', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'integration-tests',
+          runsOn: 'ubuntu-latest',
+          services: { postgres: { image: 'postgres:16', env: { POSTGRES_PASSWORD: 'postgres' }, ports: ['5432:5432'] } },
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4', with: { 'fetch-depth': 0 } },
+            { name: 'Run integration tests', run: 'npm run test:integration', workingDirectory: 'api' },
+            { name: 'Print logs', run: 'cat logs/*.log', if: '${{ always() }}' }
+          ]
+        }
+      ]
+    };
+
+    const config = render(workflow, nodeDetection);
+
+    expect(config.definitions.services).toEqual({ postgres: { image: 'postgres:16', variables: { POSTGRES_PASSWORD: 'postgres' } } });
+    expect(config.pipelines.branches.main[0].step).toEqual({
+      name: 'integration-tests',
+      clone: { depth: 'full' },
+      services: ['postgres'],
+      script: ['cd "$BITBUCKET_CLONE_DIR/api"', 'npm run test:integration'],
+      'after-script': ['cat logs/*.log']
+    });
+  });
+
+  it('should warn about actions it cannot translate', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Custom action', uses: 'some-org/some-action@v1' },
+            { name: 'Build', run: 'npm run build' }
+          ]
+        }
+      ]
+    };
+
+    const result = renderer.renderWorkflow(workflow, nodeDetection);
+
+    expect(result.warnings.some(warning => warning.includes('some-org/some-action@v1'))).toBe(true);
+  });
+
+  describe('CIWorkflowGenerator integration', () => {
+    const options: GenerationOptions = {
+      workflowType: 'ci',
+      optimizationLevel: 'standard',
+      includeComments: true,
+      securityLevel: 'standard',
+      provider: Provider.BitbucketPipelines
+    };
+
+    it('should write bitbucket-pipelines.yml when the bitbucket provider is selected', async () => {
+      const generator = new CIWorkflowGenerator();
+      const result = await generator.generateCIWorkflow(nodeDetection, options);
+
+      expect(result.filename).toBe('bitbucket-pipelines.yml');
+      expect(yaml.load(result.content)).toHaveProperty('pipelines');
+      // Branch and pull request pipelines share the steps through a YAML alias
+      expect(result.content).toContain("'**': *steps");
+    });
+
+    it('should keep run order identical to the GitHub Actions output', async () => {
+      const generator = new CIWorkflowGenerator();
+      const bitbucket = yaml.load((await generator.generateCIWorkflow(nodeDetection, options)).content) as any;
+      const github = yaml.load(
+        (await generator.generateCIWorkflow(nodeDetection, { ...options, provider: Provider.GitHubActions })).content
+      ) as any;
+
+      const githubRuns = github.jobs.build.steps
+        .filter((step: any) => step.run)
+        .map((step: any) => step.run);
+      const [build] = Object.values<any>(bitbucket.pipelines.branches)[0]
+        .flatMap((entry: any) => entry.parallel ? entry.parallel.map((parallel: any) => parallel.step) : [entry.step])
+        .filter((step: any) => step.name.startsWith('build'));
+
+      expect(build.script).toEqual(githubRuns);
+    });
+  });
+});
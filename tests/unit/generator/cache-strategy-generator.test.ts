@@ -492,4 +492,54 @@ describe('CacheStrategyGenerator', () => {
       expect(kotlinConfig.strategy.paths).toEqual(scalaConfig.strategy.paths);
     });
   });
+  describe('Dependency Cache Resolution', () => {
+    it('should key Go modules on go.sum', () => {
+      const cache = generator.resolveDependencyCache('go', undefined, ['go.sum']);
+
+      expect(cache?.strategy.paths).toEqual(['~/go/pkg/mod']);
+      expect(cache?.strategy.key).toBe("go-${{ runner.os }}-${{ hashFiles('**/go.sum') }}");
+      expect(cache?.strategy.restoreKeys).toEqual(['go-${{ runner.os }}-']);
+      expect(cache?.warning).toBeUndefined();
+    });
+
+    it('should key npm on package-lock.json', () => {
+      const cache = generator.resolveDependencyCache('javascript', 'npm');
+
+      expect(cache?.strategy.paths).toEqual(['~/.npm']);
+      expect(cache?.strategy.key).toContain("hashFiles('**/package-lock.json')");
+    });
+
+    it('should key pip on requirements files and poetry on poetry.lock', () => {
+      expect(generator.resolveDependencyCache('python', 'pip')?.strategy).toMatchObject({
+        paths: ['~/.cache/pip'],
+        key: "pip-${{ runner.os }}-${{ hashFiles('**/requirements*.txt') }}"
+      });
+      expect(generator.resolveDependencyCache('python', 'poetry')?.strategy.key).toContain("hashFiles('**/poetry.lock')");
+    });
+
+    it('should cache the Cargo home and target directory keyed on Cargo.lock', () => {
+      const cache = generator.resolveDependencyCache('rust');
+
+      expect(cache?.strategy.paths).toEqual(['~/.cargo', 'target']);
+      expect(cache?.strategy.key).toContain("hashFiles('**/Cargo.lock')");
+    });
+
+    it('should fall back to a date-based key when the lockfile is not committed', () => {
+      const cache = generator.resolveDependencyCache('rust', undefined, []);
+      const steps = generator.createDependencyCacheSteps(cache!);
+
+      expect(cache?.strategy.key).toBe('cargo-${{ runner.os }}-${{ steps.cache-date.outputs.date }}');
+      expect(cache?.warning).toContain('No Cargo.lock is committed');
+      expect(steps.map(step => step.id)).toEqual(['cache-date', undefined]);
+      expect(steps[1]?.with?.path).toBe('~/.cargo\ntarget');
+    });
+
+    it('should not require a lockfile for Maven builds', () => {
+      expect(generator.resolveDependencyCache('java', 'maven', [])?.warning).toBeUndefined();
+    });
+
+    it('should return undefined for languages without a dependency cache', () => {
+      expect(generator.resolveDependencyCache('cobol')).toBeUndefined();
+    });
+  });
 });
\ No newline at end of file
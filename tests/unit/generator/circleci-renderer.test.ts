@@ -0,0 +1,258 @@
+/**
+ * Unit tests for CircleCI Renderer
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import * as yaml from 'js-yaml';
+import { CircleCIRenderer } from '../../../src/generator/renderers/circleci-renderer';
+import { FormattingOptions } from '../../../src/generator/renderers/renderer-types';
+import { CIWorkflowGenerator } from '../../../src/generator/workflow-specialization';
+import { WorkflowTemplate, WorkflowType } from '../../../src/generator/types';
+import { DetectionResult, GenerationOptions, Provider } from '../../../src/generator/interfaces';
+
+describe('CircleCIRenderer', () => {
+  let renderer: CircleCIRenderer;
+  let formattingOptions: FormattingOptions;
+  let nodeDetection: DetectionResult;
+  let sampleWorkflow: WorkflowTemplate;
+
+  beforeEach(() => {
+    formattingOptions = {
+      yamlConfig: {
+        indent: 2,
+        lineWidth: 120,
+        noRefs: true,
+        noCompatMode: true,
+        condenseFlow: false,
+        quotingType: 'auto',
+        forceQuotes: false,
+        sortKeys: false
+      },
+      commentConfig: {
+        enabled: false,
+        includeGenerationInfo: false,
+        includeStepDescriptions: false,
+        includeOptimizationNotes: false,
+        customComments: {}
+      },
+      preserveComments: false,
+      addBlankLines: false
+    };
+
+    renderer = new CircleCIRenderer(formattingOptions);
+
+    nodeDetection = {
+      frameworks: [],
+      languages: [{ name: 'JavaScript', version: '20', confidence: 0.95, primary: true }],
+      buildTools: [],
+      packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+      testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'test-project' }
+    };
+
+    sampleWorkflow = {
+      name: 'CI Pipeline',
+      type: 'ci' as WorkflowType,
+      triggers: {
+        push: { branches: ['main'] },
+        pullRequest: { branches: ['main'] }
+      },
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Setup Node.js', uses: 'actions/setup-node@v4', with: { 'node-version': '20' } },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Build', run: 'npm run build' },
+            {
+              name: 'Upload build artifacts',
+              uses: 'actions/upload-artifact@v4',
+              with: { name: 'dist', path: 'dist/' }
+            }
+          ]
+        },
+        {
+          name: 'test',
+          runsOn: 'ubuntu-latest',
+          needs: ['build'],
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Run tests', run: 'npm test' }
+          ]
+        }
+      ]
+    };
+  });
+
+  const render = (workflow: WorkflowTemplate, detection: DetectionResult, useOrbs = false): any =>
+    yaml.load(renderer.renderWorkflow(workflow, detection, useOrbs).yaml);
+
+  it('should emit jobs and a workflow that preserves job dependencies', () => {
+    const config = render(sampleWorkflow, nodeDetection);
+
+    expect(config.version).toBe(2.1);
+    expect(Object.keys(config.jobs)).toEqual(['build', 'test']);
+    expect(config.workflows.ci.jobs).toEqual(['build', { test: { requires: ['build'] } }]);
+  });
+
+  it('should choose the docker executor from the detected language version', () => {
+    const config = render(sampleWorkflow, nodeDetection);
+
+    expect(config.jobs.build.docker).toEqual([{ image: 'node:20' }]);
+  });
+
+  it('should restore and save the cache around dependency installation', () => {
+    const config = render(sampleWorkflow, nodeDetection);
+    const steps = config.jobs.build.steps;
+
+    expect(steps[0]).toBe('checkout');
+    expect(steps[1].restore_cache.keys).toEqual(['v1-npm-{{ checksum "package-lock.json" }}', 'v1-npm-']);
+    expect(steps[2].run.command).toBe('npm ci');
+    expect(steps[3].save_cache).toEqual({ key: 'v1-npm-{{ checksum "package-lock.json" }}', paths: ['~/.npm'] });
+  });
+
+  it('should keep run commands in the same order as the GitHub steps', () => {
+    const config = render(sampleWorkflow, nodeDetection);
+    const githubRuns = sampleWorkflow.jobs[0]!.steps.filter(step => step.run).map(step => step.run);
+    const runs = config.jobs.build.steps.filter((step: any) => step.run).map((step: any) => step.run.command);
+
+    expect(runs).toEqual(githubRuns);
+    expect(config.jobs.build.steps).toContainEqual({ store_artifacts: { path: 'dist/', destination: 'dist/dist' } });
+  });
+
+  it('should filter branches only when pull requests are not built', () => {
+    const pushOnly: WorkflowTemplate = { ...sampleWorkflow, triggers: { push: { branches: ['main', 'release/*'] } } };
+    const config = render(pushOnly, nodeDetection);
+
+    expect(config.workflows.ci.jobs[0].build.filters).toEqual({ branches: { only: ['main', '/^release\\/.*$/'] } });
+
+    const result = renderer.renderWorkflow(sampleWorkflow, nodeDetection);
+    expect((yaml.load(result.yaml) as any).workflows.ci.jobs[0]).toBe('build');
+    expect(result.warnings.some(warning => warning.includes('Only build pull requests'))).toBe(true);
+  });
+
+  it('should convert matrix strategies to job parameters', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'test',
+          runsOn: 'ubuntu-latest',
+          strategy: { matrix: { 'node-version': ['18', '20'] } },
+          steps: [
+            {
+              name: 'Setup Node.js',
+              uses: 'actions/setup-node@v4',
+              with: { 'node-version': '${{ matrix.node-version }}' }
+            },
+            { name: 'Run tests', run: 'npm test' }
+          ]
+        }
+      ]
+    };
+
+    const config = render(workflow, nodeDetection);
+
+    expect(config.jobs.test.parameters).toEqual({ node_version: { type: 'string' } });
+    expect(config.jobs.test.docker[0].image).toBe('node:<< parameters.node_version >>');
+    expect(config.workflows.ci.jobs[0].test.matrix).toEqual({ parameters: { node_version: ['18', '20'] } });
+  });
+
+  it('should only reference orbs when enabled', () => {
+    expect(render(sampleWorkflow, nodeDetection)).not.toHaveProperty('orbs');
+
+    const config = render(sampleWorkflow, nodeDetection, true);
+    expect(config.orbs).toEqual({ node: 'circleci/node@5' });
+    expect(config.jobs.build.steps[1]).toEqual({ 'node/install-packages': { 'pkg-manager': 'npm' } });
+    expect(config.jobs.build.steps.some((step: any) => step.restore_cache)).toBe(false);
+  });
+
+  it('should warn about actions it cannot translate', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Custom action', uses: 'some-org/some-action@v1' },
+            { name: 'Build', run: 'npm run build' }
+          ]
+        }
+      ]
+    };
+
+    const result = renderer.renderWorkflow(workflow, nodeDetection);
+
+    expect(result.warnings.some(warning => warning.includes('some-org/some-action@v1'))).toBe(true);
+  });
+
+  describe('CIWorkflowGenerator integration', () => {
+    const options: GenerationOptions = {
+      workflowType: 'ci',
+      optimizationLevel: 'standard',
+      includeComments: true,
+      securityLevel: 'standard',
+      provider: Provider.CircleCI
+    };
+
+    const goDetection: DetectionResult = {
+      frameworks: [],
+      languages: [{ name: 'Go', version: '1.22', confidence: 0.95, primary: true }],
+      buildTools: [{ name: 'go', configFile: 'go.mod', confidence: 0.95 }],
+      packageManagers: [],
+      testingFrameworks: [{ name: 'go test', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'go-project' },
+      buildConstraints: {
+        platforms: [
+          { goos: 'darwin', goarch: 'arm64' },
+          { goos: 'linux', goarch: 'amd64' }
+        ],
+        tags: []
+      }
+    };
+
+    it('should write config.yml when the circleci provider is selected', async () => {
+      const generator = new CIWorkflowGenerator();
+      const result = await generator.generateCIWorkflow(nodeDetection, options);
+
+      expect(result.filename).toBe('config.yml');
+      expect(yaml.load(result.content)).toHaveProperty('workflows');
+    });
+
+    it('should keep run order identical to the GitHub Actions output', async () => {
+      const generator = new CIWorkflowGenerator();
+      const circleci = yaml.load((await generator.generateCIWorkflow(nodeDetection, options)).content) as any;
+      const github = yaml.load(
+        (await generator.generateCIWorkflow(nodeDetection, { ...options, provider: Provider.GitHubActions })).content
+      ) as any;
+
+      const githubRuns = github.jobs.build.steps
+        .filter((step: any) => step.run)
+        .map((step: any) => step.run);
+      const circleciRuns = circleci.jobs.build.steps
+        .filter((step: any) => step.run)
+        .map((step: any) => step.run.command);
+
+      expect(circleciRuns).toEqual(githubRuns);
+    });
+
+    it('should list GOOS/GOARCH targets as explicit parameter sets', async () => {
+      const generator = new CIWorkflowGenerator();
+      const result = await generator.generateCIWorkflow(goDetection, options);
+      const config = yaml.load(result.content) as any;
+      const builds = config.workflows.ci.jobs
+        .filter((invocation: any) => typeof invocation === 'object' && invocation.build)
+        .map((invocation: any) => invocation.build);
+
+      expect(builds).toContainEqual(expect.objectContaining({ goos: 'linux', goarch: 'amd64', cross_compile: 'false' }));
+      expect(builds).toContainEqual(expect.objectContaining({ goos: 'darwin', goarch: 'arm64', cross_compile: 'true' }));
+      expect(new Set(builds.map((build: any) => build.name)).size).toBe(builds.length);
+    });
+  });
+});
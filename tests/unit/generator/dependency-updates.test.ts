@@ -0,0 +1,65 @@
+/**
+ * Unit tests for the Dependabot and Renovate configs written next to the workflows
+ */
+
+import { describe, it, expect } from 'vitest';
+import * as yaml from 'js-yaml';
+import { collectUpdateTargets, renderDependabotConfig, renderRenovateConfig } from '../../../src/generator/utils/dependency-updates';
+import { DetectionResult } from '../../../src/generator/interfaces';
+
+const detection = (overrides: Partial<DetectionResult>): DetectionResult => ({
+  frameworks: [],
+  languages: [],
+  buildTools: [],
+  packageManagers: [],
+  testingFrameworks: [],
+  deploymentTargets: [],
+  projectMetadata: { name: 'demo' },
+  ...overrides
+});
+
+describe('dependency update configs', () => {
+  const targets = collectUpdateTargets([
+    {
+      path: '.',
+      detectionResult: detection({
+        languages: [
+          { name: 'TypeScript', confidence: 0.9, primary: true },
+          { name: 'Go', confidence: 0.8, primary: false, directory: 'cli' }
+        ],
+        dockerImages: [{ dockerfile: 'deploy/api/Dockerfile', context: '.', hasDockerignore: false }]
+      })
+    },
+    {
+      path: 'services/worker',
+      detectionResult: detection({ languages: [{ name: 'Python', confidence: 0.9, primary: true }] })
+    }
+  ]);
+
+  it('should cover each detected ecosystem in its directory, and GitHub Actions', () => {
+    expect(targets).toEqual([
+      { ecosystem: 'docker', directory: '/deploy/api' },
+      { ecosystem: 'github-actions', directory: '/' },
+      { ecosystem: 'gomod', directory: '/cli' },
+      { ecosystem: 'npm', directory: '/' },
+      { ecosystem: 'pip', directory: '/services/worker' }
+    ]);
+
+    const dependabot = yaml.load(renderDependabotConfig(targets)) as any;
+    expect(dependabot.version).toBe(2);
+    expect(dependabot.updates[4]).toEqual({ 'package-ecosystem': 'pip', directory: '/services/worker', schedule: { interval: 'weekly' } });
+  });
+
+  it('should give Renovate a weekly package rule per ecosystem', () => {
+    const renovate = JSON.parse(renderRenovateConfig(targets));
+
+    expect(renovate.enabledManagers).toContain('github-actions');
+    expect(renovate.enabledManagers).toContain('pip_requirements');
+    expect(renovate.packageRules[0]).toEqual({
+      matchManagers: ['dockerfile'],
+      matchFileNames: ['deploy/api/**'],
+      schedule: ['before 6am on monday']
+    });
+    expect(renovate.packageRules[1]).toEqual({ matchManagers: ['github-actions'], schedule: ['before 6am on monday'] });
+  });
+});
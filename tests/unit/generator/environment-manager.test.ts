@@ -220,7 +220,7 @@ describe('EnvironmentManager', () => {
       const result = environmentManager.generateEnvironmentSteps(mockEnvironments, mockDetectionResult);
       
       expect(result.oidcSteps).toHaveLength(1);
-      expect(result.oidcSteps[0].uses).toBe('azure/login@v1');
+      expect(result.oidcSteps[0].uses).toBe('azure/login@v2');
       expect(result.oidcSteps[0].with?.['subscription-id']).toBe(oidcConfig.subscriptionId);
     });
 
@@ -0,0 +1,65 @@
+/**
+ * Tests for finding the CI configuration a repository already has
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { detectExistingCI, resolveExistingProvider } from '../../../src/generator/utils/existing-ci';
+import { Provider } from '../../../src/generator/interfaces';
+
+describe('detectExistingCI', () => {
+  let tempDir: string;
+
+  const write = (file: string, content = '') => {
+    fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, file), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'existing-ci-test-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should find nothing in a repository without CI configuration', async () => {
+    write('README.md', '# App\n');
+    write('.github/CODEOWNERS', '* @team\n');
+
+    expect(await detectExistingCI(tempDir)).toEqual([]);
+  });
+
+  it('should report every configured provider', async () => {
+    write('.github/workflows/ci.yml', 'on: push\n');
+    write('.gitlab-ci.yml', 'test:\n  script: make test\n');
+
+    expect(await detectExistingCI(tempDir)).toEqual([Provider.GitHubActions, Provider.GitLab]);
+  });
+
+  it('should find CircleCI, Azure Pipelines, Bitbucket Pipelines and Jenkins configs', async () => {
+    write('.circleci/config.yml', 'version: 2.1\n');
+    write('azure-pipelines.yml', 'trigger:\n  - main\n');
+    write('bitbucket-pipelines.yml', 'pipelines:\n  default: []\n');
+    write('Jenkinsfile', 'pipeline {\n  agent any\n}\n');
+
+    expect(await detectExistingCI(tempDir)).toEqual([Provider.CircleCI, Provider.AzurePipelines, Provider.BitbucketPipelines, Provider.Jenkins]);
+  });
+});
+
+describe('resolveExistingProvider', () => {
+  it('should target the only configured provider', () => {
+    expect(resolveExistingProvider([Provider.GitLab])).toBe(Provider.GitLab);
+  });
+
+  it('should require --provider when no provider is configured', () => {
+    expect(() => resolveExistingProvider([])).toThrow('Found no CI configuration - pass --provider to choose which provider to generate for');
+  });
+
+  it('should require --provider when several providers are configured', () => {
+    expect(() => resolveExistingProvider([Provider.GitHubActions, Provider.GitLab]))
+      .toThrow('Found CI configuration for GitHub Actions and GitLab CI - pass --provider to choose which one to generate for');
+  });
+});
@@ -0,0 +1,185 @@
+/**
+ * Unit tests for in-memory workflow generation
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { YAMLGeneratorImpl, getWorkflowOutputPath } from '../../../src/generator/yaml-generator';
+import { DetectionResult, Provider } from '../../../src/generator/interfaces';
+
+describe('YAMLGeneratorImpl.generateToMap', () => {
+  let generator: YAMLGeneratorImpl;
+  let detectionResult: DetectionResult;
+  let tempDir: string;
+
+  beforeEach(() => {
+    generator = new YAMLGeneratorImpl();
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'generate-to-map-test-'));
+
+    detectionResult = {
+      frameworks: [],
+      languages: [{ name: 'JavaScript', version: '20', confidence: 0.95, primary: true }],
+      buildTools: [],
+      packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+      testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'test-project' }
+    };
+  });
+
+  afterEach(() => {
+    if (fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  it('should key GitHub workflows under .github/workflows', async () => {
+    const files = await generator.generateToMap(detectionResult, ['ci']);
+
+    expect(Object.keys(files)).toEqual(['.github/workflows/ci.yml']);
+    expect(files['.github/workflows/ci.yml']).toContain('jobs:');
+    expect(fs.readdirSync(tempDir)).toEqual([]);
+  });
+
+  it('should key the GitLab pipeline at the repository root', async () => {
+    const files = await generator.generateToMap(detectionResult, ['ci'], {
+      workflowType: 'ci',
+      optimizationLevel: 'standard',
+      includeComments: false,
+      securityLevel: 'basic',
+      provider: Provider.GitLab
+    });
+
+    expect(Object.keys(files)).toEqual(['.gitlab-ci.yml']);
+  });
+
+  it('should add nightly.yml for a schedule and reject an invalid one before generating', async () => {
+    const options = { workflowType: 'ci' as const, optimizationLevel: 'standard' as const, includeComments: false, securityLevel: 'basic' as const };
+
+    const files = await generator.generateToMap(detectionResult, ['ci'], { ...options, schedule: '0 6 * * *' });
+    expect(Object.keys(files)).toEqual(['.github/workflows/ci.yml', '.github/workflows/nightly.yml']);
+
+    await expect(generator.generateToMap(detectionResult, ['ci'], { ...options, schedule: '0 6 * *' })).rejects.toThrow("Invalid schedule '0 6 * *'");
+  });
+
+  it('should write exactly the files returned by generateToMap', async () => {
+    const files = await generator.generateToMap(detectionResult, ['ci']);
+    const written = await generator.generate(detectionResult, ['ci'], tempDir);
+
+    expect(written).toEqual(Object.keys(files).map(key => path.join(tempDir, key)));
+    for (const [key, content] of Object.entries(files)) {
+      const withoutTimestamp = (yaml: string) => yaml.replace(/^# Generated at: .*$/m, '');
+      expect(withoutTimestamp(fs.readFileSync(path.join(tempDir, key), 'utf8'))).toBe(withoutTimestamp(content));
+    }
+  });
+
+  it('should check committed workflows against the generated ones', async () => {
+    const missing = await generator.check(detectionResult, ['ci'], tempDir);
+    expect(missing.upToDate).toBe(false);
+    expect(missing.diff).toContain('+++ b/.github/workflows/ci.yml');
+
+    await generator.generate(detectionResult, ['ci'], tempDir);
+    expect(await generator.check(detectionResult, ['ci'], tempDir)).toEqual({ upToDate: true, diff: '' });
+
+    const ciPath = path.join(tempDir, '.github', 'workflows', 'ci.yml');
+    fs.writeFileSync(ciPath, fs.readFileSync(ciPath, 'utf8').replace(/jobs:\n/, 'jobs:\n  stale:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo stale\n'));
+    const stale = await generator.check(detectionResult, ['ci'], tempDir);
+    expect(stale.upToDate).toBe(false);
+    expect(stale.diff).toContain('--- a/.github/workflows/ci.yml');
+    expect(stale.diff).toContain('-      - run: echo stale');
+  });
+
+  it('should refuse to write or check generated files that fail validation unless forced', async () => {
+    vi.spyOn(generator, 'generateToMap').mockResolvedValue({ '.github/workflows/ci.yml': 'on: push\n' });
+
+    await expect(generator.generate(detectionResult, ['ci'], tempDir))
+      .rejects.toThrow("Generated workflow files failed validation and were not written (set force to write them anyway):\n  .github/workflows/ci.yml: Workflow must define at least one job under \"jobs\"");
+    await expect(generator.check(detectionResult, ['ci'], tempDir)).rejects.toThrow('Generated workflow files failed validation');
+    expect(fs.readdirSync(tempDir)).toEqual([]);
+
+    const options = { workflowType: 'ci' as const, optimizationLevel: 'standard' as const, includeComments: false, securityLevel: 'basic' as const, force: true };
+    expect(await generator.generate(detectionResult, ['ci'], tempDir, options)).toEqual([path.join(tempDir, '.github', 'workflows', 'ci.yml')]);
+    expect((await generator.check(detectionResult, ['ci'], tempDir, options)).upToDate).toBe(true);
+  });
+
+  it('should validate the generated files before the postProcess hook runs', async () => {
+    vi.spyOn(generator, 'generateToMap').mockResolvedValue({ '.github/workflows/ci.yml': 'on: push\njobs:\n  test:\n    steps:\n      - run: npm test\n' });
+    const postProcess = vi.fn((files: Record<string, string>) => files);
+    const options = { workflowType: 'ci' as const, optimizationLevel: 'standard' as const, includeComments: false, securityLevel: 'basic' as const };
+
+    await expect(generator.generate(detectionResult, ['ci'], tempDir, options))
+      .rejects.toThrow('.github/workflows/ci.yml: ');
+    await expect(generator.generate(detectionResult, ['ci'], tempDir, { ...options, postProcess }))
+      .rejects.toThrow('Generated workflow files failed validation and were not written');
+    expect(postProcess).not.toHaveBeenCalled();
+    expect(fs.readdirSync(tempDir)).toEqual([]);
+  });
+
+  it('should find a Jenkinsfile just generated up to date', async () => {
+    const options = {
+      workflowType: 'ci' as const,
+      optimizationLevel: 'standard' as const,
+      includeComments: true,
+      securityLevel: 'basic' as const,
+      provider: Provider.Jenkins
+    };
+
+    const [jenkinsfile] = await generator.generate(detectionResult, ['ci'], tempDir, options);
+    expect(jenkinsfile).toBe(path.join(tempDir, 'Jenkinsfile'));
+    fs.writeFileSync(jenkinsfile!, fs.readFileSync(jenkinsfile!, 'utf8').replace(/(\/\/ Generated at: ).*/, '$12000-01-01T00:00:00.000Z'));
+    expect(fs.readFileSync(jenkinsfile!, 'utf8')).toContain('// Generated at: 2000-01-01T00:00:00.000Z');
+
+    expect(await generator.check(detectionResult, ['ci'], tempDir, options)).toEqual({ upToDate: true, diff: '' });
+  });
+
+  it('should write and check the files as postProcess returns them, validated again', async () => {
+    const options = {
+      workflowType: 'ci' as const,
+      optimizationLevel: 'standard' as const,
+      includeComments: false,
+      securityLevel: 'basic' as const,
+      postProcess: (files: Record<string, string>) => Object.fromEntries(Object.entries(files)
+        .map(([key, content]) => [key.replace('ci.yml', 'build.yml'), `# Maintained by the platform team\n${content}`]))
+    };
+
+    const written = await generator.generate(detectionResult, ['ci'], tempDir, options);
+    expect(written).toEqual([path.join(tempDir, '.github', 'workflows', 'build.yml')]);
+    expect(fs.readFileSync(written[0]!, 'utf8')).toMatch(/^# Maintained by the platform team\n/);
+    expect((await generator.check(detectionResult, ['ci'], tempDir, options)).upToDate).toBe(true);
+
+    await expect(generator.generate(detectionResult, ['ci'], tempDir, { ...options, postProcess: () => ({ '.github/workflows/ci.yml': 'on: push\n' }) }))
+      .rejects.toThrow('Post-processed workflow files failed validation and were not written');
+    await expect(generator.generate(detectionResult, ['ci'], tempDir, { ...options, postProcess: async () => { throw new Error('no header template'); } }))
+      .rejects.toThrow('Failed to post-process workflow files: no header template');
+    expect(fs.existsSync(path.join(tempDir, '.github', 'workflows', 'ci.yml'))).toBe(false);
+  });
+
+  it('should leave out the ci workflow when README badges show existing CI and skipping is requested', async () => {
+    const withBadge: DetectionResult = {
+      ...detectionResult,
+      ciBadges: [{ provider: 'travis', imageUrl: 'https://travis-ci.com/acme/app.svg?branch=main' }]
+    };
+
+    const skipped = await generator.generateToMap(withBadge, ['ci', 'cd'], {
+      workflowType: 'ci',
+      optimizationLevel: 'standard',
+      includeComments: false,
+      securityLevel: 'basic',
+      existingCI: 'skip'
+    });
+    const generated = await generator.generateToMap(withBadge, ['ci']);
+
+    expect(Object.keys(skipped)).toEqual(['.github/workflows/cd.yml']);
+    expect(Object.keys(generated)).toEqual(['.github/workflows/ci.yml']);
+  });
+
+  it('should resolve output paths per provider', () => {
+    expect(getWorkflowOutputPath('ci.yml')).toBe('.github/workflows/ci.yml');
+    expect(getWorkflowOutputPath('ci.yml', Provider.GitHubActions)).toBe('.github/workflows/ci.yml');
+    expect(getWorkflowOutputPath('.gitlab-ci.yml', Provider.GitLab)).toBe('.gitlab-ci.yml');
+    expect(getWorkflowOutputPath('config.yml', Provider.CircleCI)).toBe('.circleci/config.yml');
+    expect(getWorkflowOutputPath('azure-pipelines.yml', Provider.AzurePipelines)).toBe('azure-pipelines.yml');
+  });
+});
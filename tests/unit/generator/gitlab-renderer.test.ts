@@ -0,0 +1,267 @@
+/**
+ * Unit tests for GitLab CI Renderer
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import * as yaml from 'js-yaml';
+import { GitLabCIRenderer } from '../../../src/generator/renderers/gitlab-renderer';
+import { FormattingOptions } from '../../../src/generator/renderers/renderer-types';
+import { CIWorkflowGenerator } from '../../../src/generator/workflow-specialization';
+import { WorkflowTemplate, WorkflowType } from '../../../src/generator/types';
+import { DetectionResult, GenerationOptions, Provider } from '../../../src/generator/interfaces';
+
+describe('GitLabCIRenderer', () => {
+  let renderer: GitLabCIRenderer;
+  let formattingOptions: FormattingOptions;
+  let nodeDetection: DetectionResult;
+  let sampleWorkflow: WorkflowTemplate;
+
+  beforeEach(() => {
+    formattingOptions = {
+      yamlConfig: {
+        indent: 2,
+        lineWidth: 120,
+        noRefs: true,
+        noCompatMode: true,
+        condenseFlow: false,
+        quotingType: 'auto',
+        forceQuotes: false,
+        sortKeys: false
+      },
+      commentConfig: {
+        enabled: false,
+        includeGenerationInfo: false,
+        includeStepDescriptions: false,
+        includeOptimizationNotes: false,
+        customComments: {}
+      },
+      preserveComments: false,
+      addBlankLines: false
+    };
+
+    renderer = new GitLabCIRenderer(formattingOptions);
+
+    nodeDetection = {
+      frameworks: [],
+      languages: [{ name: 'JavaScript', version: '20', confidence: 0.95, primary: true }],
+      buildTools: [],
+      packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+      testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'test-project' }
+    };
+
+    sampleWorkflow = {
+      name: 'CI Pipeline',
+      type: 'ci' as WorkflowType,
+      triggers: {
+        push: { branches: ['main'] },
+        pullRequest: { branches: ['main'] }
+      },
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Setup Node.js', uses: 'actions/setup-node@v4', with: { 'node-version': '20' } },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Build', run: 'npm run build' },
+            {
+              name: 'Upload build artifacts',
+              uses: 'actions/upload-artifact@v4',
+              with: { name: 'dist', path: 'dist/' }
+            }
+          ]
+        },
+        {
+          name: 'test',
+          runsOn: 'ubuntu-latest',
+          needs: ['build'],
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Run tests', run: 'npm test' }
+          ]
+        }
+      ]
+    };
+  });
+
+  const render = (workflow: WorkflowTemplate, detection: DetectionResult): any =>
+    yaml.load(renderer.renderWorkflow(workflow, detection).yaml);
+
+  it('should map jobs onto ordered stages', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+
+    expect(pipeline.stages).toEqual(['build', 'test']);
+    expect(pipeline.build.stage).toBe('build');
+    expect(pipeline.test.stage).toBe('test');
+    expect(pipeline.test.needs).toEqual(['build']);
+  });
+
+  it('should derive the image from the detected language version', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+
+    expect(pipeline.default.image).toBe('node:20');
+  });
+
+  it('should key the cache on the lockfile', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+
+    expect(pipeline.default.cache.key.files).toEqual(['package-lock.json']);
+    expect(pipeline.default.cache.paths).toEqual(['.npm/']);
+    expect(pipeline.variables.npm_config_cache).toBe('$CI_PROJECT_DIR/.npm');
+  });
+
+  it('should keep script lines in the same order as the GitHub steps', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+    const githubRuns = sampleWorkflow.jobs[0]!.steps.filter(step => step.run).map(step => step.run);
+
+    expect(pipeline.build.script).toEqual(githubRuns);
+    expect(pipeline.build.artifacts.paths).toEqual(['dist/']);
+  });
+
+  it('should translate triggers into workflow rules', () => {
+    const pipeline = render(sampleWorkflow, nodeDetection);
+
+    expect(pipeline.workflow.rules).toContainEqual({ if: '$CI_PIPELINE_SOURCE == "merge_request_event"' });
+    expect(pipeline.workflow.rules).toContainEqual({ if: '$CI_COMMIT_BRANCH =~ /^(main)$/' });
+  });
+
+  it('should make jobs interruptible when superseded runs are cancelled, except deployments', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      concurrency: { group: '${{ github.workflow }}-${{ github.ref }}', cancelInProgress: true },
+      jobs: [
+        ...sampleWorkflow.jobs,
+        { name: 'deploy', runsOn: 'ubuntu-latest', environment: 'production', steps: [{ name: 'Deploy', run: './deploy.sh' }] }
+      ]
+    };
+    const pipeline = render(workflow, nodeDetection);
+
+    expect(pipeline.build.interruptible).toBe(true);
+    expect(pipeline.test.interruptible).toBe(true);
+    expect(pipeline.deploy).not.toHaveProperty('interruptible');
+    expect(render(sampleWorkflow, nodeDetection).build).not.toHaveProperty('interruptible');
+  });
+
+  it('should convert matrix strategies to parallel:matrix', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'test',
+          runsOn: 'ubuntu-latest',
+          strategy: { matrix: { 'node-version': ['18', '20'] } },
+          steps: [
+            {
+              name: 'Setup Node.js',
+              uses: 'actions/setup-node@v4',
+              with: { 'node-version': '${{ matrix.node-version }}' }
+            },
+            { name: 'Run tests', run: 'npm test' }
+          ]
+        }
+      ]
+    };
+
+    const pipeline = render(workflow, nodeDetection);
+
+    expect(pipeline.test.parallel.matrix).toEqual([{ NODE_VERSION: ['18', '20'] }]);
+    expect(pipeline.test.image).toBe('node:$NODE_VERSION');
+  });
+
+  it('should warn about actions it cannot translate', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Custom action', uses: 'some-org/some-action@v1' },
+            { name: 'Build', run: 'npm run build' }
+          ]
+        }
+      ]
+    };
+
+    const result = renderer.renderWorkflow(workflow, nodeDetection);
+
+    expect(result.warnings.some(warning => warning.includes('some-org/some-action@v1'))).toBe(true);
+  });
+
+  describe('CIWorkflowGenerator integration', () => {
+    const options: GenerationOptions = {
+      workflowType: 'ci',
+      optimizationLevel: 'standard',
+      includeComments: true,
+      securityLevel: 'standard',
+      provider: Provider.GitLab
+    };
+
+    const goDetection: DetectionResult = {
+      frameworks: [],
+      languages: [{ name: 'Go', version: '1.22', confidence: 0.95, primary: true }],
+      buildTools: [{ name: 'go', configFile: 'go.mod', confidence: 0.95 }],
+      packageManagers: [],
+      testingFrameworks: [{ name: 'go test', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'go-project' },
+      buildConstraints: {
+        platforms: [
+          { goos: 'darwin', goarch: 'arm64' },
+          { goos: 'linux', goarch: 'amd64' }
+        ],
+        tags: []
+      }
+    };
+
+    it('should write .gitlab-ci.yml when the gitlab provider is selected', async () => {
+      const generator = new CIWorkflowGenerator();
+      const result = await generator.generateCIWorkflow(nodeDetection, options);
+
+      expect(result.filename).toBe('.gitlab-ci.yml');
+      expect(yaml.load(result.content)).toHaveProperty('stages');
+    });
+
+    it('should keep run order identical to the GitHub Actions output', async () => {
+      const generator = new CIWorkflowGenerator();
+      const gitlab = yaml.load((await generator.generateCIWorkflow(nodeDetection, options)).content) as any;
+      const github = yaml.load(
+        (await generator.generateCIWorkflow(nodeDetection, { ...options, provider: Provider.GitHubActions })).content
+      ) as any;
+
+      const githubRuns = github.jobs.build.steps
+        .filter((step: any) => step.run)
+        .map((step: any) => step.run);
+
+      expect(gitlab.build.script).toEqual(githubRuns);
+    });
+
+    it('should expand the GOOS/GOARCH matrix into parallel:matrix entries', async () => {
+      const generator = new CIWorkflowGenerator();
+      const result = await generator.generateCIWorkflow(goDetection, options);
+      const pipeline = yaml.load(result.content) as any;
+
+      expect(pipeline.default.cache.key.files).toEqual(['go.sum']);
+      expect(pipeline.build.parallel.matrix).toContainEqual(
+        expect.objectContaining({ GOOS: 'linux', GOARCH: 'amd64', CROSS_COMPILE: 'false' })
+      );
+      expect(pipeline.build.parallel.matrix).toContainEqual(
+        expect.objectContaining({ GOOS: 'darwin', GOARCH: 'arm64', CROSS_COMPILE: 'true' })
+      );
+    });
+
+    it('should run README services as GitLab services', async () => {
+      const generator = new CIWorkflowGenerator();
+      const result = await generator.generateCIWorkflow({ ...nodeDetection, services: [{ name: 'postgres' }] }, options);
+      const pipeline = yaml.load(result.content) as any;
+
+      expect(pipeline['unit-tests'].services).toEqual([
+        { name: 'postgres:16', alias: 'postgres', variables: { POSTGRES_PASSWORD: 'postgres' } }
+      ]);
+    });
+  });
+});
@@ -0,0 +1,348 @@
+/**
+ * Unit tests for Jenkins Renderer
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import { JenkinsRenderer } from '../../../src/generator/renderers/jenkins-renderer';
+import { FormattingOptions } from '../../../src/generator/renderers/renderer-types';
+import { CIWorkflowGenerator } from '../../../src/generator/workflow-specialization';
+import { validateWorkflowStructure } from '../../../src/generator/validators/structure-validator';
+import { WorkflowTemplate, WorkflowType } from '../../../src/generator/types';
+import { DetectionResult, GenerationOptions, Provider } from '../../../src/generator/interfaces';
+
+describe('JenkinsRenderer', () => {
+  let renderer: JenkinsRenderer;
+  let formattingOptions: FormattingOptions;
+  let nodeDetection: DetectionResult;
+  let sampleWorkflow: WorkflowTemplate;
+
+  beforeEach(() => {
+    formattingOptions = {
+      yamlConfig: {
+        indent: 2,
+        lineWidth: 120,
+        noRefs: true,
+        noCompatMode: true,
+        condenseFlow: false,
+        quotingType: 'auto',
+        forceQuotes: false,
+        sortKeys: false
+      },
+      commentConfig: {
+        enabled: false,
+        includeGenerationInfo: false,
+        includeStepDescriptions: false,
+        includeOptimizationNotes: false,
+        customComments: {}
+      },
+      preserveComments: false,
+      addBlankLines: false
+    };
+
+    renderer = new JenkinsRenderer(formattingOptions);
+
+    nodeDetection = {
+      frameworks: [],
+      languages: [{ name: 'JavaScript', version: '20', confidence: 0.95, primary: true }],
+      buildTools: [],
+      packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+      testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+      deploymentTargets: [],
+      projectMetadata: { name: 'test-project' }
+    };
+
+    sampleWorkflow = {
+      name: 'CI Pipeline',
+      type: 'ci' as WorkflowType,
+      triggers: {
+        push: { branches: ['main'] },
+        pullRequest: { branches: ['main'] }
+      },
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Setup Node.js', uses: 'actions/setup-node@v4', with: { 'node-version': '20' } },
+            { name: 'Install dependencies', run: 'npm ci' },
+            { name: 'Build', run: 'npm run build' },
+            {
+              name: 'Upload build artifacts',
+              uses: 'actions/upload-artifact@v4',
+              with: { name: 'dist', path: 'dist/' }
+            }
+          ]
+        },
+        {
+          name: 'test',
+          runsOn: 'ubuntu-latest',
+          needs: ['build'],
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Download build artifacts', uses: 'actions/download-artifact@v4', with: { name: 'dist' } },
+            { name: 'Run tests', run: 'npm test' }
+          ]
+        }
+      ]
+    };
+  });
+
+  const render = (workflow: WorkflowTemplate, detection: DetectionResult): string =>
+    renderer.renderWorkflow(workflow, detection).yaml;
+
+  it('should run a stage per job in needs order on a docker agent with the language image', () => {
+    const jenkinsfile = render(sampleWorkflow, nodeDetection);
+
+    expect(jenkinsfile).toBe([
+      'pipeline {',
+      '  agent {',
+      '    docker {',
+      "      image 'node:20'",
+      '    }',
+      '  }',
+      '  stages {',
+      "    stage('build') {",
+      '      environment {',
+      '        npm_config_cache = "${env.WORKSPACE}/.cache/npm"',
+      '      }',
+      '      steps {',
+      "        sh label: 'Install dependencies', script: 'npm ci'",
+      "        sh label: 'Build', script: 'npm run build'",
+      "        stash name: 'dist', includes: 'dist/**'",
+      '      }',
+      '    }',
+      "    stage('test') {",
+      '      steps {',
+      "        unstash 'dist'",
+      "        sh label: 'Run tests', script: 'npm test'",
+      '      }',
+      '    }',
+      '  }',
+      '}',
+      ''
+    ].join('\n'));
+    expect(validateWorkflowStructure(jenkinsfile, Provider.Jenkins)).toEqual([]);
+  });
+
+  it('should run jobs of the same depth in parallel, each on an agent of its own', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        { name: 'lint', runsOn: 'ubuntu-latest', steps: [{ name: 'Lint', run: 'npm run lint' }] },
+        { name: 'test', runsOn: 'ubuntu-latest', steps: [{ name: 'Test', run: 'npm test' }] }
+      ]
+    };
+
+    const jenkinsfile = render(workflow, nodeDetection);
+
+    expect(jenkinsfile).toContain([
+      "    stage('lint + test') {",
+      '      parallel {',
+      "        stage('lint') {",
+      '          agent {',
+      '            docker {',
+      "              image 'node:20'",
+      '            }',
+      '          }'
+    ].join('\n'));
+    expect(renderer.renderWorkflow(workflow, nodeDetection).metadata.optimizationsApplied).toEqual(['parallel-execution']);
+  });
+
+  it('should map matrices onto a matrix directive with an axis per key', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'test',
+          runsOn: '${{ matrix.runner }}',
+          strategy: {
+            matrix: { 'node-version': ['18', '20'], runner: ['ubuntu-latest', 'windows-latest'] },
+            exclude: [{ 'node-version': '18', runner: 'ubuntu-latest' }],
+            failFast: false
+          },
+          steps: [
+            { name: 'Setup Node.js', uses: 'actions/setup-node@v4', with: { 'node-version': '${{ matrix.node-version }}' } },
+            { name: 'Legacy tests', run: 'npm run test:legacy', if: "matrix.node-version == '18'" },
+            { name: 'Run tests', run: 'npm test -- --node ${{ matrix.node-version }}' }
+          ]
+        }
+      ]
+    };
+
+    const result = renderer.renderWorkflow(workflow, nodeDetection);
+
+    expect(result.yaml).toContain([
+      '      matrix {',
+      '        agent {',
+      '          docker {',
+      '            image "node:${NODE_VERSION}"',
+      '          }',
+      '        }',
+      '        axes {',
+      '          axis {',
+      "            name 'NODE_VERSION'",
+      "            values '18', '20'",
+      '          }',
+      '        }',
+      '        excludes {',
+      '          exclude {',
+      '            axis {',
+      "              name 'NODE_VERSION'",
+      "              values '18'",
+      '            }',
+      '          }',
+      '        }'
+    ].join('\n'));
+    expect(result.yaml).toContain([
+      '              script {',
+      "                if (env.NODE_VERSION == '18') {",
+      "                  sh label: 'Legacy tests', script: 'npm run test:legacy'",
+      '                }',
+      '              }',
+      "              sh label: 'Run tests', script: 'npm test -- --node ${NODE_VERSION}'"
+    ].join('\n'));
+    expect(result.warnings).toContain("Job 'test' combinations on windows-latest were left out - the Jenkins docker agent runs Linux containers");
+    expect(validateWorkflowStructure(result.yaml, Provider.Jenkins)).toEqual([]);
+  });
+
+  it('should expand matrices with include entries into a parallel stage per combination', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          strategy: {
+            matrix: { compiler: ['gcc', 'clang'] },
+            include: [{ compiler: 'gcc', cc: 'gcc' }, { compiler: 'clang', cc: 'clang' }],
+            failFast: false
+          },
+          steps: [{ name: 'Configure', run: 'cmake -B build', env: { CC: '${{ matrix.cc }}' } }]
+        }
+      ]
+    };
+
+    const jenkinsfile = render(workflow, { ...nodeDetection, languages: [{ name: 'C/C++', confidence: 0.9, primary: true }] });
+
+    expect(jenkinsfile).toContain([
+      "    stage('build') {",
+      '      parallel {',
+      "        stage('build (gcc, gcc)') {",
+      '          agent {',
+      '            docker {',
+      "              image 'buildpack-deps:bookworm'",
+      '            }',
+      '          }',
+      '          steps {',
+      "            withEnv(['CC=gcc']) {",
+      "              sh label: 'Configure', script: 'cmake -B build'"
+    ].join('\n'));
+    expect(jenkinsfile).toContain("stage('build (clang, clang)')");
+  });
+
+  it('should bind secrets from credentials and map conditions, directories, retries and always() steps', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      triggers: { push: { branches: ['main'] }, schedule: [{ cron: '0 2 * * 1' }] },
+      jobs: [
+        {
+          name: 'integration-tests',
+          runsOn: 'ubuntu-latest',
+          if: "github.event_name == 'pull_request' || github.ref == 'refs/heads/main'",
+          timeout: 20,
+          env: { API_TOKEN: '${{ secrets.API_TOKEN }}' },
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4', with: { submodules: 'recursive' } },
+            { name: 'Run integration tests', run: 'npm run test:integration', workingDirectory: 'api', retries: 2 },
+            { name: 'Print logs', run: "cat 'logs/app.log'\ncat logs/*.log", if: '${{ always() }}' }
+          ]
+        }
+      ]
+    };
+
+    const result = renderer.renderWorkflow(workflow, nodeDetection);
+
+    expect(result.yaml).toContain([
+      '  triggers {',
+      "    cron('0 2 * * 1')",
+      '  }',
+      '  environment {',
+      "    API_TOKEN = credentials('API_TOKEN')",
+      '  }'
+    ].join('\n'));
+    expect(result.yaml).toContain([
+      "    stage('integration-tests') {",
+      '      when {',
+      '        beforeAgent true',
+      '        anyOf {',
+      '          changeRequest()',
+      "          branch 'main'",
+      '        }',
+      '      }',
+      '      options {',
+      "        timeout(time: 20, unit: 'MINUTES')",
+      '      }',
+      '      environment {',
+      '        API_TOKEN = "${env.API_TOKEN}"',
+      '      }',
+      '      steps {',
+      "        sh label: 'Checkout code', script: 'git submodule sync --recursive && git submodule update --init --recursive'",
+      "        dir('api') {",
+      '          retry(3) {',
+      "            sh label: 'Run integration tests', script: 'npm run test:integration'",
+      '          }',
+      '        }',
+      '      }',
+      '      post {',
+      '        always {',
+      "          sh label: 'Print logs', script: '''",
+      "cat \\'logs/app.log\\'",
+      'cat logs/*.log',
+      "'''",
+      '        }',
+      '      }',
+      '    }'
+    ].join('\n'));
+    expect(result.warnings).toContain('Add API_TOKEN as secret text credentials in Jenkins - the pipeline binds them by id');
+  });
+
+  it('should warn about actions it cannot translate', () => {
+    const workflow: WorkflowTemplate = {
+      ...sampleWorkflow,
+      jobs: [
+        {
+          name: 'build',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Custom action', uses: 'some-org/some-action@v1' },
+            { name: 'Build', run: 'npm run build' }
+          ]
+        }
+      ]
+    };
+
+    const result = renderer.renderWorkflow(workflow, nodeDetection);
+
+    expect(result.warnings.some(warning => warning.includes('some-org/some-action@v1'))).toBe(true);
+  });
+
+  describe('CIWorkflowGenerator integration', () => {
+    const options: GenerationOptions = {
+      workflowType: 'ci',
+      optimizationLevel: 'standard',
+      includeComments: true,
+      securityLevel: 'standard',
+      provider: Provider.Jenkins
+    };
+
+    it('should write a Jenkinsfile when the jenkins provider is selected', async () => {
+      const generator = new CIWorkflowGenerator();
+      const result = await generator.generateCIWorkflow(nodeDetection, options);
+
+      expect(result.filename).toBe('Jenkinsfile');
+      expect(result.content).toMatch(/^pipeline \{$/m);
+      expect(validateWorkflowStructure(result.content, Provider.Jenkins)).toEqual([]);
+    });
+  });
+});
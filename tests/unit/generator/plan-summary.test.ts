@@ -0,0 +1,74 @@
+/**
+ * Unit tests for the plan summary printed before workflows are written
+ */
+
+import { describe, it, expect } from 'vitest';
+import { planSummary } from '../../../src/generator/utils/plan-summary';
+import { DetectionResult, GenerationOptions, WorkflowOutput } from '../../../src/generator/interfaces';
+
+describe('planSummary', () => {
+  const detectionResult: DetectionResult = {
+    frameworks: [],
+    languages: [
+      { name: 'Shell', confidence: 0.5, primary: false },
+      { name: 'Go', version: '1.22', confidence: 0.9, primary: true }
+    ],
+    buildTools: [{ name: 'go', configFile: 'go.mod', confidence: 0.95 }],
+    packageManagers: [],
+    testingFrameworks: [{ name: 'go test', type: 'unit', confidence: 0.9 }],
+    deploymentTargets: [],
+    projectMetadata: { name: 'service' },
+    linters: [{ name: 'golangci-lint', language: 'Go', command: 'golangci-lint run', installed: false }]
+  };
+  const options: GenerationOptions = { workflowType: 'ci', optimizationLevel: 'standard', includeComments: true, securityLevel: 'standard' };
+  const workflow = (filename: string, content: string, warnings: string[] = []): WorkflowOutput => ({
+    filename,
+    content,
+    type: 'ci',
+    metadata: { generatedAt: new Date(0), generatorVersion: '1.0.0', detectionSummary: '', optimizations: [], warnings }
+  });
+
+  it('should tell what was detected and which files and jobs will be written', () => {
+    const summary = planSummary(detectionResult, [
+      workflow('ci.yml', 'name: CI\non: push\njobs:\n  build: {}\n  unit-tests: {}\n  lint: {}\n', ['No Dockerfile found - docker job skipped']),
+      workflow('release.yml', 'name: Release\njobs:\n  release: {}\n', ['No Dockerfile found - docker job skipped'])
+    ], { ...options, disabledJobs: ['docker'] }, { outputDirectory: '.github/workflows', skippedWorkflows: ['cd.yml'] });
+
+    expect(summary).toBe([
+      'Detected: Go 1.22 (confidence 0.9), Shell (confidence 0.5), lint via golangci-lint, test via go test.',
+      'Will generate .github/workflows/ci.yml with jobs: build, unit-tests, lint.',
+      'Will generate .github/workflows/release.yml with jobs: release.',
+      'Suppressed jobs: docker (disabledJobs).',
+      'Not generated: cd.yml.',
+      'Warnings:',
+      '  - No Dockerfile found - docker job skipped'
+    ].join('\n'));
+  });
+
+  it('should list the jobs of GitLab pipelines and prefer the test runners over the frameworks', () => {
+    const summary = planSummary({
+      ...detectionResult,
+      linters: [],
+      testRunners: [
+        { name: 'gotestsum', language: 'Go', command: 'gotestsum ./...' },
+        { name: 'go test', language: 'Go', command: 'go test ./...', suppressedBy: 'gotestsum' }
+      ]
+    }, [
+      workflow('.gitlab-ci.yml', 'stages: [build, test]\nvariables: { GOFLAGS: -mod=mod }\n.go-cache: {}\nbuild: { stage: build }\nunit-tests: { stage: test }\n')
+    ], options);
+
+    expect(summary).toBe([
+      'Detected: Go 1.22 (confidence 0.9), Shell (confidence 0.5), test via gotestsum.',
+      'Will generate .gitlab-ci.yml with jobs: build, unit-tests.'
+    ].join('\n'));
+  });
+
+  it('should name the API schemas found', () => {
+    const summary = planSummary({ ...detectionResult, apiSchemas: { openapi: ['api/openapi.yaml'], graphql: ['schema.graphql'] } }, [
+      workflow('ci.yml', 'jobs:\n  api-lint: {}\n')
+    ], { ...options, apiLint: true });
+
+    expect(summary.split('\n')[0]).toBe(
+      'Detected: Go 1.22 (confidence 0.9), Shell (confidence 0.5), lint via golangci-lint, test via go test, API schemas api/openapi.yaml and schema.graphql.');
+  });
+});
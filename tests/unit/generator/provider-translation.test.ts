@@ -0,0 +1,142 @@
+/**
+ * Unit tests for the translation shared by the renderers for other providers
+ */
+
+import { describe, it, expect } from 'vitest';
+import {
+  expandMatrix,
+  translateExpression,
+  translateSetupAction,
+  translateShellGuard,
+  resolveLanguageImage
+} from '../../../src/generator/renderers/provider-translation';
+import { GitLabCIRenderer } from '../../../src/generator/renderers/gitlab-renderer';
+import { CircleCIRenderer } from '../../../src/generator/renderers/circleci-renderer';
+import { AzurePipelinesRenderer } from '../../../src/generator/renderers/azure-pipelines-renderer';
+import { BitbucketPipelinesRenderer } from '../../../src/generator/renderers/bitbucket-pipelines-renderer';
+import { JenkinsRenderer } from '../../../src/generator/renderers/jenkins-renderer';
+import { FormattingOptions, Renderer } from '../../../src/generator/renderers/renderer-types';
+import { WorkflowTemplate, WorkflowType } from '../../../src/generator/types';
+import { DetectionResult } from '../../../src/generator/interfaces';
+
+describe('provider translation', () => {
+  const formattingOptions: FormattingOptions = {
+    yamlConfig: {
+      indent: 2,
+      lineWidth: 120,
+      noRefs: true,
+      noCompatMode: true,
+      condenseFlow: false,
+      quotingType: 'auto',
+      forceQuotes: false,
+      sortKeys: false
+    },
+    commentConfig: {
+      enabled: false,
+      includeGenerationInfo: false,
+      includeStepDescriptions: false,
+      includeOptimizationNotes: false,
+      customComments: {}
+    },
+    preserveComments: false,
+    addBlankLines: false
+  };
+
+  const goDetection: DetectionResult = {
+    frameworks: [],
+    languages: [{ name: 'Go', version: '1.22', confidence: 0.95, primary: true }],
+    buildTools: [],
+    packageManagers: [{ name: 'go', confidence: 0.9 }],
+    testingFrameworks: [],
+    deploymentTargets: [],
+    projectMetadata: { name: 'test-project' }
+  };
+
+  it('should expand a matrix into its combinations, less exclusions and with include entries merged', () => {
+    const combinations = expandMatrix({
+      matrix: { os: ['ubuntu-latest', 'macos-latest'], node: ['18', '20'] },
+      exclude: [{ os: 'macos-latest', node: '18' }],
+      include: [{ node: '20', experimental: true }, { os: 'windows-latest', node: '20' }]
+    });
+
+    expect(combinations).toEqual([
+      { os: 'ubuntu-latest', node: '18' },
+      { os: 'ubuntu-latest', node: '20', experimental: true },
+      { os: 'macos-latest', node: '20', experimental: true },
+      { os: 'windows-latest', node: '20' }
+    ]);
+  });
+
+  it('should translate expressions with the syntax of the provider', () => {
+    const translated = translateExpression(
+      "${{ matrix.node-version }} ${{ matrix.target || 'x64' }} ${{ secrets.NPM_TOKEN }} ${{ github.sha }} ${{ github.ref_name }} ${{ runner.os }}",
+      {
+        matrix: key => key === 'node-version' ? '$(node_version)' : undefined,
+        secret: name => `$(${name})`,
+        sha: '$(Build.SourceVersion)',
+        refName: '$(Build.SourceBranchName)',
+        os: '$(Agent.OS)'
+      }
+    );
+
+    expect(translated).toBe('$(node_version) x64 $(NPM_TOKEN) $(Build.SourceVersion) $(Build.SourceBranchName) $(Agent.OS)');
+  });
+
+  it('should translate matrix conditions to shell tests on the provider reference', () => {
+    const reference = (key: string) => key === 'experimental' ? '$EXPERIMENTAL' : undefined;
+
+    expect(translateShellGuard("${{ matrix.experimental == 'yes' }}", reference)).toBe('[ "$EXPERIMENTAL" = "yes" ]');
+    expect(translateShellGuard('!matrix.experimental', reference)).toBe('[ "$EXPERIMENTAL" != "true" ]');
+    expect(translateShellGuard("github.event_name == 'push'", reference)).toBeNull();
+  });
+
+  it('should install what setup actions provide, except the stable toolchain the images have', () => {
+    const translate = (value: string) => value;
+
+    expect(translateSetupAction({ name: 'Rust', uses: 'dtolnay/rust-toolchain@stable' }, translate)).toEqual([]);
+    expect(translateSetupAction({ name: 'Rust', uses: 'dtolnay/rust-toolchain@master', with: { toolchain: 'nightly' } }, translate))
+      .toEqual(['rustup toolchain install nightly && rustup default nightly']);
+    expect(translateSetupAction({ name: 'Checkout', uses: 'actions/checkout@v4' }, translate)).toBeUndefined();
+  });
+
+  it('should pick the image for the detected version, or the matrix version', () => {
+    expect(resolveLanguageImage(goDetection)).toBe('golang:1.22');
+    expect(resolveLanguageImage(goDetection, input => input === 'go-version' ? '$GO_VERSION' : undefined)).toBe('golang:$GO_VERSION');
+    expect(resolveLanguageImage({ ...goDetection, languages: [{ name: 'C/C++', confidence: 0.9, primary: true }] })).toBeUndefined();
+  });
+
+  describe('renderers', () => {
+    const renderers: Array<[Renderer, string]> = [
+      [new GitLabCIRenderer(formattingOptions), '.gitlab-ci.yml'],
+      [new CircleCIRenderer(formattingOptions), 'config.yml'],
+      [new AzurePipelinesRenderer(formattingOptions), 'azure-pipelines.yml'],
+      [new BitbucketPipelinesRenderer(formattingOptions), 'bitbucket-pipelines.yml'],
+      [new JenkinsRenderer(formattingOptions), 'Jenkinsfile']
+    ];
+
+    const lintWorkflow: WorkflowTemplate = {
+      name: 'CI Pipeline',
+      type: 'ci' as WorkflowType,
+      triggers: { push: { branches: ['main'] } },
+      jobs: [
+        {
+          name: 'lint',
+          runsOn: 'ubuntu-latest',
+          steps: [
+            { name: 'Checkout code', uses: 'actions/checkout@v4' },
+            { name: 'Run golangci-lint', uses: 'golangci/golangci-lint-action@v6', with: { args: '--exclude-dirs=gen' } }
+          ]
+        }
+      ]
+    };
+
+    for (const [renderer, path] of renderers) {
+      it(`should render ${path}, passing golangci-lint arguments on`, () => {
+        const rendered = renderer.render(lintWorkflow, goDetection);
+
+        expect(rendered.path).toBe(path);
+        expect(rendered.content).toContain('"$(go env GOPATH)/bin/golangci-lint" run --exclude-dirs=gen');
+      });
+    }
+  });
+});
@@ -0,0 +1,40 @@
+/**
+ * Unit tests for listing the secrets generated workflows reference
+ */
+
+import { describe, it, expect } from 'vitest';
+import { collectSecrets } from '../../../src/generator/utils/secrets-manifest';
+
+describe('collectSecrets', () => {
+  it('should deduplicate and sort the secrets referenced across workflows', () => {
+    const secrets = collectSecrets({
+      '.github/workflows/ci.yml': [
+        'env:',
+        '  NPM_TOKEN: ${{ secrets.NPM_TOKEN }}',
+        'steps:',
+        '  - uses: codecov/codecov-action@v5',
+        '    with:',
+        '      token: ${{ secrets.CODECOV_TOKEN }}'
+      ].join('\n'),
+      '.github/workflows/cd.yml': [
+        'env:',
+        "  AWS_ACCESS_KEY_ID: ${{ secrets['AWS_ACCESS_KEY_ID'] }}",
+        '  TOKEN: ${{ secrets.DEPLOY_TOKEN || secrets.NPM_TOKEN }}'
+      ].join('\n')
+    });
+
+    expect(secrets).toEqual(['AWS_ACCESS_KEY_ID', 'CODECOV_TOKEN', 'DEPLOY_TOKEN', 'NPM_TOKEN']);
+  });
+
+  it('should leave out GITHUB_TOKEN and references outside expressions', () => {
+    const secrets = collectSecrets({
+      'ci.yml': [
+        '# Set secrets.OLD_TOKEN in the repository settings',
+        'env:',
+        '  GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}'
+      ].join('\n')
+    });
+
+    expect(secrets).toEqual([]);
+  });
+});
@@ -155,7 +155,7 @@ describe('SecurityStepGenerator', () => {
       
       expect(standardSemgrep).toBeUndefined();
       expect(enterpriseSemgrep).toBeDefined();
-      expect(enterpriseSemgrep?.uses).toBe('returntocorp/semgrep-action@v1');
+      expect(enterpriseSemgrep?.run).toBe('pipx run semgrep ci');
     });
   });  describe(
 'generateDASTSteps', () => {
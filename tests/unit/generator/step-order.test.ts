@@ -0,0 +1,49 @@
+/**
+ * Unit tests for the category order of a job's steps
+ */
+
+import { describe, it, expect } from 'vitest';
+import { getStepCategory, orderSteps } from '../../../src/generator/utils/step-order';
+import { StepTemplate } from '../../../src/generator/types';
+
+describe('orderSteps', () => {
+  const checkout: StepTemplate = { name: 'Checkout code', uses: 'actions/checkout@v4' };
+  const setup: StepTemplate = { name: 'Setup Node.js', uses: 'actions/setup-node@v4' };
+  const cache: StepTemplate = { name: 'Cache dependencies', uses: 'actions/cache@v4' };
+  const install: StepTemplate = { name: 'Install dependencies', run: 'npm ci' };
+  const build: StepTemplate = { name: 'Build', run: 'npm run build' };
+  const test: StepTemplate = { name: 'Run unit tests', run: 'npm test' };
+  const upload: StepTemplate = { name: 'Upload coverage', uses: 'codecov/codecov-action@v5' };
+
+  it('should tell the category of each step', () => {
+    expect([checkout, setup, cache, install, build, test, upload].map(getStepCategory))
+      .toEqual(['checkout', 'setup', 'cache', 'install', 'build', 'test', 'upload']);
+    expect(getStepCategory({ name: 'Run ESLint', run: 'npx eslint .' })).toBe('lint');
+    expect(getStepCategory({ name: 'Install system packages', run: 'sudo apt-get install -y graphviz' })).toBe('setup');
+    expect(getStepCategory({ name: 'Setup browsers', run: 'npx playwright install --with-deps' })).toBeUndefined();
+    expect(getStepCategory({ name: 'Run migrations', run: 'mix ecto.migrate' })).toBeUndefined();
+  });
+
+  it('should put the steps in category order and keep the ones of a category as added', () => {
+    const lint: StepTemplate = { name: 'Run ESLint', run: 'npx eslint .' };
+    const typeCheck: StepTemplate = { name: 'Type check', run: 'npx tsc --noEmit' };
+
+    expect(orderSteps([checkout, install, cache, setup, typeCheck, test, lint, build, upload]))
+      .toEqual([checkout, setup, cache, install, build, typeCheck, lint, test, upload]);
+  });
+
+  it('should keep a step of no category after the step before it', () => {
+    const migrate: StepTemplate = { name: 'Run migrations', run: 'mix ecto.migrate' };
+
+    expect(orderSteps([checkout, install, migrate, setup, test])).toEqual([checkout, setup, install, migrate, test]);
+    expect(orderSteps([checkout, test, setup], step => step === setup)).toEqual([checkout, test, setup]);
+  });
+
+  it('should not move the other steps when one is added', () => {
+    const steps = [checkout, setup, install, build, test, upload];
+    const ordered = orderSteps([...steps.slice(0, 3), cache, ...steps.slice(3)]);
+
+    expect(ordered.filter(step => step !== cache)).toEqual(orderSteps(steps));
+    expect(ordered.indexOf(cache)).toBe(2);
+  });
+});
@@ -0,0 +1,184 @@
+/**
+ * Tests for validateWorkflowStructure
+ */
+
+import { describe, it, expect } from 'vitest';
+import { validateWorkflowStructure, validateCompositeActionStructure } from '../../../src/generator/validators/structure-validator';
+import { CIWorkflowGenerator } from '../../../src/generator/workflow-specialization';
+import { DetectionResult, GenerationOptions, Provider } from '../../../src/generator/interfaces';
+
+describe('validateWorkflowStructure', () => {
+  const detectionResult: DetectionResult = {
+    frameworks: [],
+    languages: [{ name: 'JavaScript', confidence: 0.95, primary: true }],
+    buildTools: [],
+    packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+    testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+    deploymentTargets: [],
+    projectMetadata: { name: 'test-project' }
+  };
+  const options: GenerationOptions = {
+    workflowType: 'ci',
+    optimizationLevel: 'standard',
+    includeComments: true,
+    securityLevel: 'standard'
+  };
+
+  it('should accept the CI configuration generated for every provider', async () => {
+    const generator = new CIWorkflowGenerator();
+
+    for (const provider of [Provider.GitHubActions, Provider.GitLab, Provider.CircleCI, Provider.AzurePipelines, Provider.BitbucketPipelines, Provider.Jenkins]) {
+      const result = await generator.generateCIWorkflow(detectionResult, { ...options, provider });
+      expect(validateWorkflowStructure(result.content, provider)).toEqual([]);
+    }
+  });
+
+  it('should report broken GitHub Actions jobs and steps', () => {
+    const content = [
+      'name: CI',
+      'jobs:',
+      '  "build app":',
+      '    runs-on: ""',
+      '    steps:',
+      '      - name: Both',
+      '        uses: actions/checkout@v4',
+      '        run: echo hi',
+      '  test:',
+      '    runs-on: ubuntu-latest',
+      '    needs: [compile]',
+      '    steps:',
+      "      - run: npm test -- --shard=${{ matrix.shard }}",
+      "      - run: echo ${{ matrix.node-version || '18' }}"
+    ].join('\n');
+
+    expect(validateWorkflowStructure(content, Provider.GitHubActions).map(error => error.message)).toEqual([
+      'Workflow must define "on" triggers',
+      'Job id "build app" must start with a letter or \'_\' and contain only letters, digits, \'-\' and \'_\'',
+      'Job "build app" must specify a non-empty "runs-on"',
+      'Step 1 of job "build app" must have exactly one of "uses" or "run"',
+      'Job "test" needs unknown job "compile"',
+      'Job "test" references matrix.shard, which its matrix does not define'
+    ]);
+  });
+
+  it('should accept reusable workflows and check their inputs and outputs', async () => {
+    const generator = new CIWorkflowGenerator();
+    const result = await generator.generateCIWorkflow(detectionResult, { ...options, reusable: true, coverage: 'codecov' });
+    expect(validateWorkflowStructure(result.content)).toEqual([]);
+
+    const content = [
+      'on:',
+      '  workflow_call:',
+      '    outputs:',
+      '      coverage:',
+      '        value: ${{ jobs.test.outputs.coverage }}',
+      'jobs:',
+      '  test:',
+      '    runs-on: ${{ inputs.runs-on }}',
+      '    steps:',
+      '      - run: npm test'
+    ].join('\n');
+    expect(validateWorkflowStructure(content).map(error => error.message)).toEqual([
+      'Job "test" references inputs.runs-on, which no workflow_call or workflow_dispatch input declares',
+      'Workflow output "coverage" must read an output its jobs declare, as ${{ jobs.<job>.outputs.<output> }}'
+    ]);
+  });
+
+  it('should check the structure of other providers', () => {
+    expect(validateWorkflowStructure('stages: [build]\nlint:\n  stage: test\n  script: []\n', Provider.GitLab).map(e => e.message)).toEqual([
+      'Job "lint" must have a non-empty "script"',
+      'Job "lint" uses stage "test", which "stages" does not list'
+    ]);
+
+    const circleci = [
+      'version: 2.1',
+      'jobs:',
+      '  test:',
+      '    docker: [{ image: node:20 }]',
+      '    steps: [checkout, { run: "npm test --node << parameters.node >>" }]',
+      'workflows:',
+      '  ci:',
+      '    jobs: [test, { deploy: { requires: [test] } }]'
+    ].join('\n');
+    expect(validateWorkflowStructure(circleci, Provider.CircleCI).map(e => e.message)).toEqual([
+      'Job "test" references parameters.node, which it does not declare',
+      'Workflow "ci" runs unknown job "deploy"'
+    ]);
+
+    expect(validateWorkflowStructure('jobs:\n  - job: unit-tests\n    steps:\n      - script: npm test\n        task: Npm@1\n', Provider.AzurePipelines).map(e => e.message)).toEqual([
+      'Job name "unit-tests" in the pipeline must start with a letter or \'_\' and contain only letters, digits and \'_\''
+    ]);
+
+    const bitbucket = [
+      'pipelines:',
+      '  branches:',
+      '    main:',
+      '      - step: { name: test, caches: [node, cargo], script: [npm test] }',
+      '      - parallel:',
+      '          - step: { name: lint, script: [] }',
+      '  nightly: []'
+    ].join('\n');
+    expect(validateWorkflowStructure(bitbucket, Provider.BitbucketPipelines).map(e => e.message)).toEqual([
+      'Unknown pipelines key "nightly"',
+      'Step "test" uses cache "cargo", which "definitions" does not define',
+      'Step 2 of pipeline branches "main" must have a non-empty "script"'
+    ]);
+
+    const jenkinsfile = [
+      'pipeline {',
+      '  agent any',
+      '  stages {',
+      "    stage('build') {",
+      '      steps {',
+      '      }',
+      '    }',
+      "    stage('build') {",
+      "      steps { sh 'make' }",
+      "      stages { stage('nested') { steps { sh 'make test' } } }",
+      '    }',
+      '  }',
+      '}'
+    ].join('\n');
+    expect(validateWorkflowStructure(jenkinsfile, Provider.Jenkins).map(e => e.message)).toEqual([
+      'Stage "build" has no steps',
+      'Stage name "build" is used more than once in the pipeline',
+      'Stage "build" must have exactly one of steps, stages, parallel or matrix'
+    ]);
+    expect(validateWorkflowStructure("pipeline {\n  stages {\n    stage('build') { steps { sh 'echo }' }\n  }\n}\n", Provider.Jenkins)[0])
+      .toMatchObject({ type: 'syntax', message: 'Block "pipeline" is not closed' });
+    expect(validateWorkflowStructure('- just a list', Provider.GitLab)[0]).toMatchObject({ type: 'syntax', severity: 'error' });
+  });
+});
+
+describe('validateCompositeActionStructure', () => {
+  it('should report what composite actions do not allow', () => {
+    const action = [
+      'name: CI',
+      'inputs:',
+      '  node-version: { default: "20" }',
+      'runs:',
+      '  using: composite',
+      '  steps:',
+      '    - uses: actions/setup-node@v4',
+      '      with: { node-version: "${{ inputs.node-version }}" }',
+      '    - name: Build',
+      '      run: npm run build',
+      '    - uses: acme/shared/.github/workflows/test.yml@v1',
+      '    - name: Publish',
+      '      run: npm publish',
+      '      shell: bash',
+      '      env: { NPM_TOKEN: "${{ secrets.NPM_TOKEN }}", DIR: "${{ inputs.directory }}" }'
+    ].join('\n');
+
+    expect(validateCompositeActionStructure(action).map(e => e.message)).toEqual([
+      'Step 2 ("Build") runs a command and must specify "shell"',
+      'Step 3 uses the reusable workflow acme/shared/.github/workflows/test.yml@v1, which only jobs can call',
+      'Step 4 ("Publish") references inputs.directory, which the action does not declare',
+      'Step 4 ("Publish") reads the secrets context, which composite actions cannot access - pass secrets as inputs'
+    ]);
+    expect(validateCompositeActionStructure('runs:\n  using: node20\n  main: index.js\n').map(e => e.message)).toEqual([
+      'Composite action must set runs.using to "composite"',
+      'Composite action must have at least one step under runs.steps'
+    ]);
+  });
+});
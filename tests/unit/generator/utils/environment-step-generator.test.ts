@@ -242,7 +242,7 @@ describe('EnvironmentStepGenerator', () => {
       const steps = stepGenerator.generateEnvironmentSetupSteps(mockEnvironments);
       
       const oidcStep = steps.find(step => 
-        step.uses === 'azure/login@v1'
+        step.uses === 'azure/login@v2'
       );
       
       expect(oidcStep).toBeDefined();
@@ -0,0 +1,67 @@
+/**
+ * Unit tests for comparing generated workflows with committed ones
+ */
+
+import { describe, it, expect } from 'vitest';
+import { diffWorkflowFile, workflowsEquivalent } from '../../../src/generator/utils/workflow-diff';
+
+const committed = [
+  '# Generated at: 2024-01-01T00:00:00.000Z',
+  'name: CI',
+  'on:',
+  '  push:',
+  '    branches: [main]',
+  'jobs:',
+  '  test:',
+  '    runs-on: ubuntu-latest',
+  '    steps:',
+  '      - uses: actions/checkout@v4',
+  '      - run: npm ci',
+  '      - run: npm test',
+  ''
+].join('\n');
+
+describe('workflowsEquivalent', () => {
+  it('should ignore line endings, trailing whitespace, the timestamp and key order', () => {
+    const regenerated = committed
+      .replace('2024-01-01T00:00:00.000Z', '2025-06-30T12:00:00.000Z')
+      .replace(/\n/g, '  \r\n');
+    const reordered = committed.replace('name: CI\non:\n  push:\n    branches: [main]\n', 'on:\n  push:\n    branches: [main]\nname: CI\n');
+
+    expect(workflowsEquivalent(committed, regenerated)).toBe(true);
+    expect(workflowsEquivalent(committed, reordered)).toBe(true);
+    expect(workflowsEquivalent(committed, committed.replace('npm test', 'npm run test'))).toBe(false);
+    expect(workflowsEquivalent(committed, committed.replace('npm ci\n      - run: npm test', 'npm test\n      - run: npm ci'))).toBe(false);
+  });
+
+  it('should ignore the timestamp of a Jenkinsfile header', () => {
+    const jenkinsfile = "// Generated at: 2024-01-01T00:00:00.000Z\npipeline {\n  agent any\n}\n";
+
+    expect(workflowsEquivalent(jenkinsfile, jenkinsfile.replace('2024-01-01T00:00:00.000Z', '2025-06-30T12:00:00.000Z'))).toBe(true);
+    expect(workflowsEquivalent(jenkinsfile, jenkinsfile.replace('agent any', 'agent none'))).toBe(false);
+  });
+});
+
+describe('diffWorkflowFile', () => {
+  it('should produce a unified diff pointing at the changed lines', () => {
+    const diff = diffWorkflowFile('.github/workflows/ci.yml', committed, committed.replace('npm test', 'npm run test'));
+
+    expect(diff).toBe([
+      '--- a/.github/workflows/ci.yml',
+      '+++ b/.github/workflows/ci.yml',
+      '@@ -9,4 +9,4 @@',
+      '     steps:',
+      '       - uses: actions/checkout@v4',
+      '       - run: npm ci',
+      '-      - run: npm test',
+      '+      - run: npm run test'
+    ].join('\n'));
+  });
+
+  it('should report a missing file as added and whitespace-only changes as no diff', () => {
+    const diff = diffWorkflowFile('.github/workflows/ci.yml', undefined, 'name: CI\n');
+
+    expect(diff).toBe('--- /dev/null\n+++ b/.github/workflows/ci.yml\n@@ -0,0 +1,1 @@\n+name: CI');
+    expect(diffWorkflowFile('.github/workflows/ci.yml', committed, committed.replace(/\n/g, ' \n'))).toBe('');
+  });
+});
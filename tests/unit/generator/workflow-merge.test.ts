@@ -0,0 +1,159 @@
+/**
+ * Unit tests for merging regenerated workflows into existing ones
+ */
+
+import { describe, it, expect } from 'vitest';
+import * as yaml from 'js-yaml';
+import {
+  appendManagedBlock,
+  mergeWorkflow,
+  readManagedBlock
+} from '../../../src/generator/utils/workflow-merge';
+import { YAMLGeneratorImpl } from '../../../src/generator/yaml-generator';
+import { DetectionResult, Provider } from '../../../src/generator/interfaces';
+
+const workflow = (jobs: Record<string, string[]>, extra: Record<string, any> = {}) => yaml.dump({
+  name: 'CI',
+  on: { push: { branches: ['main'] } },
+  ...extra,
+  jobs: Object.fromEntries(Object.entries(jobs).map(([id, steps]) => [
+    id,
+    { 'runs-on': 'ubuntu-latest', steps: steps.map(name => ({ name, run: `echo ${name}` })) }
+  ]))
+});
+
+const stepNames = (content: string, job: string): string[] =>
+  (yaml.load(content) as any).jobs[job].steps.map((step: any) => step.name);
+
+describe('mergeWorkflow', () => {
+  it('should record generated keys, jobs and steps in a managed block', () => {
+    const content = appendManagedBlock(workflow({ build: ['Checkout', 'Build'] }));
+
+    expect(readManagedBlock(content)).toEqual({ keys: ['name', 'on'], jobs: { build: ['Checkout', 'Build'] } });
+    expect(appendManagedBlock(content).match(/readme-to-cicd managed >>>/g)).toHaveLength(1);
+  });
+
+  it('should refresh managed steps and keep user steps in place', () => {
+    const existing = appendManagedBlock(workflow({ build: ['Checkout', 'Build'] }))
+      .replace('  - name: Build', '  - name: Notify\n        run: ./notify.sh\n      - name: Build');
+    const generated = yaml.dump({
+      name: 'CI',
+      on: { push: { branches: ['main'] } },
+      jobs: { build: { 'runs-on': 'ubuntu-latest', steps: [{ name: 'Checkout', uses: 'actions/checkout@v4' }, { name: 'Build', run: 'npm run build' }] } }
+    });
+
+    const merged = yaml.load(mergeWorkflow(existing, generated)) as any;
+
+    expect(merged.jobs.build.steps).toEqual([
+      { name: 'Checkout', uses: 'actions/checkout@v4' },
+      { name: 'Notify', run: './notify.sh' },
+      { name: 'Build', run: 'npm run build' }
+    ]);
+  });
+
+  it('should not re-add managed steps or jobs the user deleted', () => {
+    const original = appendManagedBlock(workflow({ build: ['Checkout', 'Lint', 'Build'], docs: ['Checkout'] }));
+    const edited = original
+      .replace(/ {6}- name: Lint\n {8}run: echo Lint\n/, '')
+      .replace(/ {2}docs:\n(?: {4}.*\n)+/, '');
+    expect(edited).not.toContain('run: echo Lint');
+
+    const merged = mergeWorkflow(edited, workflow({ build: ['Checkout', 'Lint', 'Build'], docs: ['Checkout'] }));
+
+    expect(stepNames(merged, 'build')).toEqual(['Checkout', 'Build']);
+    expect((yaml.load(merged) as any).jobs.docs).toBeUndefined();
+
+    // The deletions stay recorded, so the next merge keeps them deleted too
+    expect(readManagedBlock(merged)?.jobs).toEqual({ build: ['Checkout', 'Lint', 'Build'], docs: ['Checkout'] });
+    expect(stepNames(mergeWorkflow(merged, workflow({ build: ['Checkout', 'Lint', 'Build'] })), 'build'))
+      .toEqual(['Checkout', 'Build']);
+  });
+
+  it('should insert newly generated steps after the generated step preceding them', () => {
+    const existing = appendManagedBlock(workflow({ build: ['Checkout', 'Build'] }));
+
+    const merged = mergeWorkflow(existing, workflow({ build: ['Checkout', 'Cache dependencies', 'Build'] }));
+
+    expect(stepNames(merged, 'build')).toEqual(['Checkout', 'Cache dependencies', 'Build']);
+    expect(readManagedBlock(merged)?.jobs.build).toEqual(['Checkout', 'Cache dependencies', 'Build']);
+  });
+
+  it('should manage unnamed steps by what they run and their position', () => {
+    const job = (steps: any[]) => yaml.dump({ name: 'CI', on: 'push', jobs: { build: { 'runs-on': 'ubuntu-latest', steps } } });
+    const existing = appendManagedBlock(job([{ uses: 'actions/checkout@v3' }, { run: 'npm ci' }]))
+      .replace('  - run: npm ci', '  - name: Notify\n        run: ./notify.sh\n      - run: npm ci');
+
+    const merged = mergeWorkflow(existing, job([{ uses: 'actions/checkout@v4' }, { uses: 'actions/setup-node@v4' }, { run: 'npm ci' }, { run: 'npm ci' }]));
+
+    expect((yaml.load(merged) as any).jobs.build.steps).toEqual([
+      { uses: 'actions/checkout@v4' },
+      { uses: 'actions/setup-node@v4' },
+      { name: 'Notify', run: './notify.sh' },
+      { run: 'npm ci' },
+      { run: 'npm ci' }
+    ]);
+    expect(readManagedBlock(merged)?.jobs.build).toEqual(['uses: actions/checkout', 'uses: actions/setup-node', 'run: npm ci', 'run: npm ci #2']);
+  });
+
+  it('should preserve user jobs and top-level keys and drop jobs no longer generated', () => {
+    const existing = appendManagedBlock(workflow({ build: ['Build'], legacy: ['Old'] })).replace(
+      'jobs:\n',
+      'jobs:\n  deploy:\n    runs-on: ubuntu-latest\n    steps:\n      - run: ./deploy.sh\n'
+    ).replace('name: CI\n', 'name: CI\nenv:\n  FOO: bar\n');
+
+    const merged = yaml.load(mergeWorkflow(existing, workflow({ build: ['Build'] }))) as any;
+
+    expect(Object.keys(merged.jobs)).toEqual(['deploy', 'build']);
+    expect(merged.env).toEqual({ FOO: 'bar' });
+  });
+
+  it('should treat a workflow without a managed block as user-authored', () => {
+    const existing = workflow({ build: ['Custom build'] });
+
+    const merged = mergeWorkflow(existing, workflow({ build: ['Checkout'], test: ['Test'] }));
+
+    expect(stepNames(merged, 'build')).toEqual(['Custom build']);
+    expect(stepNames(merged, 'test')).toEqual(['Test']);
+    expect(Object.keys(readManagedBlock(merged)!.jobs)).toEqual(['test']);
+  });
+
+  it('should reject existing content that is not a workflow mapping', () => {
+    expect(() => mergeWorkflow('- not\n- a workflow\n', workflow({ build: ['Build'] })))
+      .toThrow('Failed to parse existing workflow: expected a mapping at the top level');
+  });
+});
+
+describe('YAMLGeneratorImpl.generateWithMerge', () => {
+  const detectionResult: DetectionResult = {
+    frameworks: [],
+    languages: [{ name: 'JavaScript', version: '20', confidence: 0.95, primary: true }],
+    buildTools: [],
+    packageManagers: [{ name: 'npm', lockFile: 'package-lock.json', confidence: 0.9 }],
+    testingFrameworks: [{ name: 'Jest', type: 'unit', confidence: 0.9 }],
+    deploymentTargets: [],
+    projectMetadata: { name: 'test-project' }
+  };
+
+  it('should keep user-added jobs when regenerating', async () => {
+    const generator = new YAMLGeneratorImpl();
+    const generated = await generator.generateWithMerge(detectionResult, '');
+    const withUserJob = generated.replace('jobs:\n', 'jobs:\n  custom:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo hi\n');
+
+    const merged = yaml.load(await generator.generateWithMerge(detectionResult, withUserJob)) as any;
+
+    expect(merged.jobs.custom.steps).toEqual([{ run: 'echo hi' }]);
+    expect(merged.jobs.build).toBeDefined();
+  });
+
+  it('should refuse to merge non-GitHub workflows', async () => {
+    const generator = new YAMLGeneratorImpl();
+
+    await expect(generator.generateWithMerge(detectionResult, '', {
+      workflowType: 'ci',
+      optimizationLevel: 'standard',
+      includeComments: false,
+      securityLevel: 'basic',
+      provider: Provider.GitLab
+    })).rejects.toThrow('merging is only supported for GitHub Actions');
+  });
+});
@@ -0,0 +1,55 @@
+/**
+ * Unit tests for repairing hand-written workflows
+ */
+
+import { describe, it, expect } from 'vitest';
+import * as yaml from 'js-yaml';
+import { repairWorkflow, findUnpinnedActions } from '../../../src/generator/utils/workflow-repair';
+
+const CHECKOUT_SHA = 'b4ffde65f46336ab88eb53be808477a3936bae11';
+
+const workflow = [
+  '# Hand-written CI',
+  'name: CI',
+  'on: [push, pull_request]',
+  'jobs:',
+  '    test:',
+  '        runs-on: ubuntu-latest',
+  '        steps:',
+  '            - uses: actions/checkout@v4',
+  "            - uses: 'actions/setup-node@v4' # keep in sync with .nvmrc",
+  '            - run: npm test',
+  ''
+].join('\n');
+
+describe('repairWorkflow', () => {
+  it('should pin known actions, add permissions and concurrency, and keep the rest of the file', () => {
+    expect(findUnpinnedActions(workflow)).toEqual(['actions/checkout@v4', 'actions/setup-node@v4']);
+
+    const result = repairWorkflow(workflow, { actionShas: { 'actions/checkout@v4': CHECKOUT_SHA } });
+
+    expect(result.fixes.map(fix => fix.id)).toEqual(['pin-actions', 'permissions', 'concurrency']);
+    expect(result.warnings).toEqual(['actions/setup-node@v4 is not pinned - no commit SHA is known for it']);
+    expect(result.content).toContain(`            - uses: actions/checkout@${CHECKOUT_SHA} # v4\n`);
+    expect(result.content).toContain("            - uses: 'actions/setup-node@v4' # keep in sync with .nvmrc\n");
+    expect(result.content.startsWith('# Hand-written CI\n')).toBe(true);
+
+    const repaired = yaml.load(result.content) as any;
+    expect(repaired.permissions).toEqual({ contents: 'read' });
+    expect(repaired.concurrency.group).toBe('${{ github.workflow }}-${{ github.ref }}');
+    expect(repaired.jobs.test.steps).toHaveLength(3);
+
+    const again = repairWorkflow(result.content, { actionShas: { 'actions/checkout@v4': CHECKOUT_SHA } });
+    expect(again.content).toBe(result.content);
+    expect(again.fixes).toEqual([]);
+  });
+
+  it('should skip fixes it is told to and leave permissions alone when a step writes', () => {
+    const release = workflow.replace('            - run: npm test', '            - uses: softprops/action-gh-release@v2');
+    const result = repairWorkflow(release, { skip: ['pin-actions', 'concurrency'] });
+
+    expect(result.content).toBe(release);
+    expect(result.fixes).toEqual([]);
+    expect(result.warnings).toEqual(['permissions: not added - softprops/action-gh-release may need write access']);
+  });
+});
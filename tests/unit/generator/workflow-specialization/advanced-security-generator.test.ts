@@ -136,7 +136,7 @@ describe('AdvancedSecurityGenerator', () => {
       const result = await generator.generateSASTWorkflow(mockDetectionResult, mockOptions);
 
       expect(result.content).toContain('SonarCloud Scan');
-      expect(result.content).toContain('SonarSource/sonarcloud-github-action@master');
+      expect(result.content).toContain('SonarSource/sonarqube-scan-action@v5');
       expect(result.content).toContain('SONAR_TOKEN');
     });
 
@@ -144,7 +144,7 @@ describe('AdvancedSecurityGenerator', () => {
       const result = await generator.generateSASTWorkflow(mockDetectionResult, mockOptions);
 
       expect(result.content).toContain('Run Semgrep');
-      expect(result.content).toContain('returntocorp/semgrep-action@v1');
+      expect(result.content).toContain('pipx run semgrep ci --sarif --output=semgrep.sarif');
       expect(result.content).toContain('Run Snyk Code');
       expect(result.content).toContain('snyk/actions/node@master');
     });
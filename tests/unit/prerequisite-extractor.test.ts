@@ -0,0 +1,75 @@
+/**
+ * Tests for PrerequisiteExtractor
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import { PrerequisiteExtractor } from '../../src/parser/utils/prerequisite-extractor';
+
+describe('PrerequisiteExtractor', () => {
+  let extractor: PrerequisiteExtractor;
+
+  beforeEach(() => {
+    extractor = new PrerequisiteExtractor();
+  });
+
+  it('should classify bullet items of a prerequisites section', () => {
+    const prerequisites = extractor.extract([
+      '# App',
+      '',
+      '## Prerequisites',
+      '',
+      '- Python 3.10+',
+      '- requires `libpq-dev` and build-essential',
+      '* PostgreSQL 14 and Redis',
+      '1. Needs [Docker](https://docker.com)',
+      '',
+      '### System packages',
+      '',
+      '```bash',
+      'sudo apt-get install -y libxml2-dev graphviz',
+      '```',
+      '',
+      '## Usage',
+      '',
+      '- Run with `pip install app` on Node 20'
+    ].join('\n'));
+
+    expect(prerequisites).toEqual([
+      { name: 'python', kind: 'runtime', version: '3.10', line: 5 },
+      { name: 'libpq-dev', kind: 'system-package', line: 6 },
+      { name: 'build-essential', kind: 'system-package', line: 6 },
+      { name: 'postgres', kind: 'service', version: '14', line: 7 },
+      { name: 'redis', kind: 'service', line: 7 },
+      { name: 'docker', kind: 'service', line: 8 },
+      { name: 'libxml2-dev', kind: 'system-package', line: 13 },
+      { name: 'graphviz', kind: 'system-package', line: 13 }
+    ]);
+  });
+
+  it('should read setext headings and stop at the next heading of the same level', () => {
+    const prerequisites = extractor.extract([
+      'Project',
+      '=======',
+      '',
+      'Requirements',
+      '------------',
+      '',
+      '- Go 1.22',
+      '- MySQL',
+      '',
+      'Contributing',
+      '------------',
+      '',
+      '- `libssl-dev`'
+    ].join('\n'));
+
+    expect(prerequisites).toEqual([
+      { name: 'go', kind: 'runtime', version: '1.22', line: 7 },
+      { name: 'mysql', kind: 'service', line: 8 }
+    ]);
+  });
+
+  it('should ignore READMEs without a prerequisites section', () => {
+    expect(extractor.extract('# App\n\n- Works with Redis\n- `libpq-dev`\n')).toEqual([]);
+  });
+});
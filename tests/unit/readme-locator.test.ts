@@ -0,0 +1,118 @@
+/**
+ * Tests for README discovery and non-Markdown README formats
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest';
+import * as fs from 'fs';
+import * as path from 'path';
+import * as os from 'os';
+import { findReadme } from '../../src/parser/utils/readme-locator';
+import { getReadmeFormat, toMarkdown } from '../../src/parser/utils/markup-parser';
+
+describe('findReadme', () => {
+  let tempDir: string;
+
+  const write = (file: string, content = '# Project\n') => {
+    fs.mkdirSync(path.dirname(path.join(tempDir, file)), { recursive: true });
+    fs.writeFileSync(path.join(tempDir, file), content);
+  };
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), 'readme-locator-test-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(tempDir, { recursive: true, force: true });
+  });
+
+  it('should prefer the root Markdown README over other formats and directories', async () => {
+    write('docs/README.md');
+    write('README.rst', 'Project\n=======\n');
+    expect(await findReadme(tempDir)).toEqual({ path: path.join(tempDir, 'README.rst'), format: 'restructuredtext' });
+
+    write('readme.md');
+    expect(await findReadme(tempDir)).toEqual({ path: path.join(tempDir, 'readme.md'), format: 'markdown' });
+  });
+
+  it('should fall back to the docs directory and report when there is no README', async () => {
+    expect(await findReadme(tempDir)).toBeUndefined();
+
+    write('docs/README.adoc', '= Project\n');
+    expect(await findReadme(tempDir)).toEqual({ path: path.join(tempDir, 'docs', 'README.adoc'), format: 'asciidoc' });
+  });
+});
+
+describe('markup parsers', () => {
+  it('should pick the format from the file extension', () => {
+    expect(getReadmeFormat('README.md')).toBe('markdown');
+    expect(getReadmeFormat('docs/README.RST')).toBe('restructuredtext');
+    expect(getReadmeFormat('README.asciidoc')).toBe('asciidoc');
+    expect(getReadmeFormat('README')).toBe('text');
+  });
+
+  it('should convert reStructuredText headings and code blocks, keeping line numbers', () => {
+    const rst = [
+      '=======',
+      'Project',
+      '=======',
+      '',
+      'Install',
+      '-------',
+      '',
+      '.. code-block:: bash',
+      '',
+      '   pip install -e .',
+      '   pytest',
+      '',
+      'Run ``make docs`` or::',
+      '',
+      '    tox -e docs',
+      ''
+    ].join('\n');
+
+    expect(toMarkdown(rst, 'restructuredtext')).toBe([
+      '',
+      '# Project',
+      '',
+      '',
+      '## Install',
+      '',
+      '',
+      '```bash',
+      '',
+      'pip install -e .',
+      'pytest',
+      '```',
+      'Run `make docs` or:',
+      '```',
+      'tox -e docs',
+      '```'
+    ].join('\n'));
+  });
+
+  it('should convert AsciiDoc titles and listing blocks', () => {
+    const adoc = [
+      '= Project',
+      '// build instructions',
+      '== Build',
+      '',
+      '[source,shell]',
+      '----',
+      'go build ./...',
+      'go test ./...',
+      '----'
+    ].join('\n');
+
+    expect(toMarkdown(adoc, 'asciidoc')).toBe([
+      '# Project',
+      '',
+      '## Build',
+      '',
+      '',
+      '```shell',
+      'go build ./...',
+      'go test ./...',
+      '```'
+    ].join('\n'));
+  });
+});
@@ -0,0 +1,66 @@
+/**
+ * Tests for SecretExtractor
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import { SecretExtractor } from '../../src/parser/utils/secret-extractor';
+
+describe('SecretExtractor', () => {
+  let extractor: SecretExtractor;
+
+  beforeEach(() => {
+    extractor = new SecretExtractor();
+  });
+
+  it('should extract variables from export commands, env blocks and prose', () => {
+    const secrets = extractor.extract([
+      '# App',
+      'Set `DATABASE_URL` and `API_KEY` before starting the server.',
+      '',
+      '```bash',
+      'export STRIPE_SECRET_KEY=sk_test_123 SENTRY_DSN=https://example',
+      'export API_KEY=dev',
+      '```',
+      '',
+      '```yaml',
+      'services:',
+      '  app:',
+      '    env:',
+      '      REDIS_URL: redis://localhost',
+      '      - JWT_SECRET=changeme',
+      '    ports:',
+      '      - "3000:3000"',
+      '```'
+    ].join('\n'));
+
+    expect(secrets).toEqual([
+      { name: 'DATABASE_URL', context: 'set', line: 2 },
+      { name: 'API_KEY', context: 'set', line: 2 },
+      { name: 'STRIPE_SECRET_KEY', context: 'export', line: 5 },
+      { name: 'SENTRY_DSN', context: 'export', line: 5 },
+      { name: 'REDIS_URL', context: 'env', line: 13 },
+      { name: 'JWT_SECRET', context: 'env', line: 14 }
+    ]);
+  });
+
+  it('should ignore constants, verbs and variables that are not secrets', () => {
+    const secrets = extractor.extract([
+      'Set GET and POST handlers as described in README.',
+      'set -euo pipefail',
+      'export NODE_ENV=production',
+      'export DEBUG=1',
+      'Set up the project first.',
+      'env:',
+      '  GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}'
+    ].join('\n'));
+
+    expect(secrets).toEqual([]);
+  });
+
+  it('should accept single-word names only when they look like credentials', () => {
+    expect(extractor.isSecretName('TOKEN')).toBe(true);
+    expect(extractor.isSecretName('APIKEY')).toBe(true);
+    expect(extractor.isSecretName('PORT')).toBe(false);
+    expect(extractor.isSecretName('AWS_REGION')).toBe(true);
+  });
+});
@@ -0,0 +1,118 @@
+/**
+ * Tests for ShellCommandClassifier
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import { ShellCommandClassifier } from '../../src/parser/utils/shell-command-classifier';
+import { MarkdownAST } from '../../src/parser/types';
+
+describe('ShellCommandClassifier', () => {
+  let classifier: ShellCommandClassifier;
+
+  beforeEach(() => {
+    classifier = new ShellCommandClassifier();
+  });
+
+  it('should classify commands into buckets', () => {
+    const commands = classifier.classifyBlock([
+      'npm install',
+      'go build ./...',
+      'pytest -q',
+      'npm run lint',
+      'npm start'
+    ].join('\n'), 'bash');
+
+    expect(commands.map(c => [c.command, c.bucket])).toEqual([
+      ['npm install', 'install'],
+      ['go build ./...', 'build'],
+      ['pytest -q', 'test'],
+      ['npm run lint', 'lint'],
+      ['npm start', 'run']
+    ]);
+    expect(commands.every(c => c.language === 'bash')).toBe(true);
+  });
+
+  it('should prefer the longest matching keyword', () => {
+    expect(classifier.classifyCommand('make')).toBe('build');
+    expect(classifier.classifyCommand('make test')).toBe('test');
+    expect(classifier.classifyCommand('python -m pip install -r requirements.txt')).toBe('install');
+    expect(classifier.classifyCommand('python app.py')).toBe('run');
+  });
+
+  it('should strip $ and > prompts before classifying', () => {
+    const commands = classifier.classifyBlock('$ cargo test\n> cargo clippy', 'sh');
+
+    expect(commands).toEqual([
+      { raw: '$ cargo test', command: 'cargo test', bucket: 'test', language: 'sh' },
+      { raw: '> cargo clippy', command: 'cargo clippy', bucket: 'lint', language: 'sh' }
+    ]);
+  });
+
+  it('should skip program output in prompted sessions', () => {
+    const commands = classifier.classifyBlock('$ go test ./...\nok  example.com/pkg  0.01s\nnode v18', 'console');
+
+    expect(commands.map(c => c.command)).toEqual(['go test ./...']);
+  });
+
+  it('should join backslash continuations', () => {
+    const raw = 'docker build \\\n  -t app:latest \\\n  .';
+    const commands = classifier.classifyBlock(raw, 'bash');
+
+    expect(commands).toHaveLength(1);
+    expect(commands[0]!.command).toBe('docker build -t app:latest .');
+    expect(commands[0]!.raw).toBe(raw);
+    expect(commands[0]!.bucket).toBe('build');
+  });
+
+  it('should classify each command in a chained line', () => {
+    const commands = classifier.classifyBlock('cd app && npm ci && npm test', 'bash');
+
+    expect(commands.map(c => c.bucket)).toEqual(['install', 'test']);
+  });
+
+  it('should not split inside quotes, subshells or compound commands', () => {
+    const commands = classifier.classifyBlock([
+      'for f in *; do go test $f; done',
+      'echo "built; npm test" || npm run lint',
+      'if [ -f go.mod ]; then go build ./...; fi && cargo test',
+      '(cd web && npm ci); make test'
+    ].join('\n'), 'bash');
+
+    expect(commands.map(c => [c.command, c.bucket])).toEqual([
+      ['npm run lint', 'lint'],
+      ['cargo test', 'test'],
+      ['make test', 'test']
+    ]);
+  });
+
+  it('should ignore comments and unknown commands', () => {
+    const commands = classifier.classifyBlock('# set up\necho hello\ngit clone repo', 'bash');
+
+    expect(commands).toEqual([]);
+  });
+
+  it('should accept a custom keyword map', () => {
+    const custom = new ShellCommandClassifier({ lint: ['just lint'] });
+
+    expect(custom.classifyCommand('just lint')).toBe('lint');
+    expect(custom.classifyCommand('eslint .')).toBeNull();
+    expect(custom.classifyCommand('npm test')).toBe('test');
+  });
+
+  it('should only read shell fences from the AST', () => {
+    const ast = [
+      { type: 'code', lang: 'bash', text: 'npm ci', raw: '' },
+      { type: 'code', lang: 'javascript', text: 'node index.js', raw: '' },
+      {
+        type: 'list',
+        raw: '',
+        items: [{ type: 'list_item', raw: '', tokens: [{ type: 'code', lang: 'sh', text: 'go test ./...', raw: '' }] }]
+      }
+    ] as unknown as MarkdownAST;
+
+    expect(classifier.classify(ast).map(c => [c.command, c.language])).toEqual([
+      ['npm ci', 'bash'],
+      ['go test ./...', 'sh']
+    ]);
+  });
+});
@@ -0,0 +1,47 @@
+/**
+ * Tests for TestedVersionExtractor
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest';
+import { TestedVersionExtractor } from '../../src/parser/utils/tested-version-extractor';
+
+describe('TestedVersionExtractor', () => {
+  let extractor: TestedVersionExtractor;
+
+  beforeEach(() => {
+    extractor = new TestedVersionExtractor();
+  });
+
+  it('should extract enumerations and ranges next to a tested or supported runtime', () => {
+    const tested = extractor.extract([
+      '# App',
+      '',
+      'Tested on Go 1.22, 1.20 and 1.21.',
+      'Supports **Python 3.9–3.12** on Linux and macOS.',
+      'The CLI is compatible with Node.js 18/20/22.',
+      'Also supports Python 3.7 and 3.8.'
+    ].join('\n'));
+
+    expect(tested).toEqual([
+      { runtime: 'go', versions: ['1.20', '1.21', '1.22'], line: 3 },
+      { runtime: 'python', versions: ['3.9', '3.10', '3.11', '3.12'], line: 4 },
+      { runtime: 'node', versions: ['18', '20', '22'], line: 5 }
+    ]);
+  });
+
+  it('should ignore minimums, single versions, unrelated numbers and code blocks', () => {
+    const tested = extractor.extract([
+      'Requires Python 3.10 or later.',
+      'Supports Python 3.9, 3.10 and newer.',
+      'Tested on Node 20.',
+      'Go 1.21 and 1.22 builds are published weekly.',
+      'Supports 3.9 and 3.10 of the API.',
+      '',
+      '```bash',
+      '# tested with go 1.21 and 1.22',
+      '```'
+    ].join('\n'));
+
+    expect(tested).toEqual([]);
+  });
+});